@@ -10,8 +10,3 @@ db.createUser({
         },
     ],
 });
-
-accounts = db.createCollection('accounts');
-db.accounts.createIndex( { "cpf": 1 }, { unique: true } )
-
-db.createCollection('transfers');
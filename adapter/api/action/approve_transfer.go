@@ -0,0 +1,77 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+// ApproveTransferAction answers with the output of the use case, of /v1 or of /v2
+type ApproveTransferAction[O any] struct {
+	uc  usecase.ApproveTransferUseCaseOf[O]
+	log logger.Logger
+}
+
+func NewApproveTransferAction[O any](uc usecase.ApproveTransferUseCaseOf[O], log logger.Logger) ApproveTransferAction[O] {
+	return ApproveTransferAction[O]{
+		uc:  uc,
+		log: log,
+	}
+}
+
+func (a ApproveTransferAction[O]) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "approve_transfer"
+
+	var transferID = r.URL.Query().Get("transfer_id")
+	if !domain.IsValidUUID(transferID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), domain.TransferID(transferID))
+	if err != nil {
+		handleTransferDecisionErr(w, a.log, err, logKey, "error when approving transfer")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success approving transfer")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
+
+func handleTransferDecisionErr(w http.ResponseWriter, log logger.Logger, err error, logKey, logMsg string) {
+	var status int
+	switch err {
+	case domain.ErrTransferNotFound:
+		status = http.StatusNotFound
+	case domain.ErrTransferNotPending, domain.ErrTransferApprovalExpired:
+		status = http.StatusConflict
+	case domain.ErrApproverIsRequester, domain.ErrForbidden:
+		status = http.StatusForbidden
+	case domain.ErrInsufficientBalance, domain.ErrAccountOriginNotFound, domain.ErrAccountDestinationNotFound,
+		domain.ErrMoneyOverflow:
+		status = http.StatusUnprocessableEntity
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	logging.NewError(
+		log,
+		err,
+		logKey,
+		status,
+	).Log(logMsg)
+
+	response.NewError(err, status).Send(w)
+}
@@ -0,0 +1,171 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type mockApproveTransfer struct {
+	result usecase.CreateTransferOutput
+	err    error
+}
+
+func (m mockApproveTransfer) Execute(_ context.Context, _ domain.TransferID) (usecase.CreateTransferOutput, error) {
+	return m.result, m.err
+}
+
+func TestApproveTransferAction_Execute(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		transferID string
+	}
+
+	tests := []struct {
+		name               string
+		args               args
+		ucMock             usecase.ApproveTransferUseCase
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name: "ApproveTransferAction success",
+			args: args{
+				transferID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+			},
+			ucMock: mockApproveTransfer{
+				result: usecase.CreateTransferOutput{
+					ID:                   "3c096a40-ccba-4b58-93ed-57379ab04680",
+					AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04681",
+					AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682",
+					Amount:               20000,
+					Status:               "completed",
+					CreatedAt:            "2020-01-01T00:00:00Z",
+				},
+			},
+			expectedBody:       `{"id":"3c096a40-ccba-4b58-93ed-57379ab04680","account_origin_id":"3c096a40-ccba-4b58-93ed-57379ab04681","account_destination_id":"3c096a40-ccba-4b58-93ed-57379ab04682","amount":20000,"status":"completed","created_at":"2020-01-01T00:00:00Z"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "ApproveTransferAction error not found",
+			args: args{
+				transferID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+			},
+			ucMock: mockApproveTransfer{
+				err: domain.ErrTransferNotFound,
+			},
+			expectedBody:       `{"errors":["transfer not found"]}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "ApproveTransferAction error not pending",
+			args: args{
+				transferID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+			},
+			ucMock: mockApproveTransfer{
+				err: domain.ErrTransferNotPending,
+			},
+			expectedBody:       `{"errors":["transfer is not awaiting a decision"]}`,
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "ApproveTransferAction error expired",
+			args: args{
+				transferID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+			},
+			ucMock: mockApproveTransfer{
+				err: domain.ErrTransferApprovalExpired,
+			},
+			expectedBody:       `{"errors":["transfer approval expired"]}`,
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "ApproveTransferAction error approver is requester",
+			args: args{
+				transferID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+			},
+			ucMock: mockApproveTransfer{
+				err: domain.ErrApproverIsRequester,
+			},
+			expectedBody:       `{"errors":["transfer must be decided by a different user than its requester"]}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "ApproveTransferAction error insufficient balance",
+			args: args{
+				transferID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+			},
+			ucMock: mockApproveTransfer{
+				err: domain.ErrInsufficientBalance,
+			},
+			expectedBody:       `{"errors":["origin account does not have sufficient balance"]}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "ApproveTransferAction generic error",
+			args: args{
+				transferID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+			},
+			ucMock: mockApproveTransfer{
+				err: errors.New("error"),
+			},
+			expectedBody:       `{"errors":["error"]}`,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "ApproveTransferAction error parameter invalid",
+			args: args{
+				transferID: "error",
+			},
+			ucMock:             mockApproveTransfer{},
+			expectedBody:       `{"errors":["parameter invalid"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri := fmt.Sprintf("/v1/transfers/%s/approve", tt.args.transferID)
+			req, _ := http.NewRequest(http.MethodPost, uri, nil)
+
+			q := req.URL.Query()
+			q.Add("transfer_id", tt.args.transferID)
+			req.URL.RawQuery = q.Encode()
+
+			var (
+				w      = httptest.NewRecorder()
+				action = NewApproveTransferAction(tt.ucMock, log.LoggerMock{})
+			)
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
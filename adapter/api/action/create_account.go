@@ -2,6 +2,7 @@ package action
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"strings"
 
@@ -9,28 +10,50 @@ import (
 	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
 	"github.com/gsabadini/go-clean-architecture/adapter/logger"
 	"github.com/gsabadini/go-clean-architecture/adapter/validator"
+	"github.com/gsabadini/go-clean-architecture/domain"
 	"github.com/gsabadini/go-clean-architecture/usecase"
 )
 
-type CreateAccountAction struct {
-	uc        usecase.CreateAccountUseCase
+// CreateAccountAction reads the input and answers with the output of the use case, of /v1 or of /v2
+type CreateAccountAction[O any] struct {
+	uc        usecase.CreateAccountUseCaseOf[O]
 	log       logger.Logger
 	validator validator.Validator
+	decode    func(io.Reader) (usecase.CreateAccountInput, error)
 }
 
-func NewCreateAccountAction(uc usecase.CreateAccountUseCase, log logger.Logger, v validator.Validator) CreateAccountAction {
-	return CreateAccountAction{
+func NewCreateAccountAction(
+	uc usecase.CreateAccountUseCase,
+	log logger.Logger,
+	v validator.Validator,
+) CreateAccountAction[usecase.CreateAccountOutput] {
+	return CreateAccountAction[usecase.CreateAccountOutput]{
 		uc:        uc,
 		log:       log,
 		validator: v,
+		decode:    decodeCreateAccountInput,
 	}
 }
 
-func (a CreateAccountAction) Execute(w http.ResponseWriter, r *http.Request) {
+// NewCreateAccountActionV2 reads the balance as a decimal string with its currency
+func NewCreateAccountActionV2(
+	uc usecase.CreateAccountUseCaseV2,
+	log logger.Logger,
+	v validator.Validator,
+) CreateAccountAction[usecase.AccountOutputV2] {
+	return CreateAccountAction[usecase.AccountOutputV2]{
+		uc:        uc,
+		log:       log,
+		validator: v,
+		decode:    decodeCreateAccountInputV2,
+	}
+}
+
+func (a CreateAccountAction[O]) Execute(w http.ResponseWriter, r *http.Request) {
 	const logKey = "create_account"
 
-	var input usecase.CreateAccountInput
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+	input, err := a.decode(r.Body)
+	if err != nil {
 		logging.NewError(
 			a.log,
 			err,
@@ -59,22 +82,41 @@ func (a CreateAccountAction) Execute(w http.ResponseWriter, r *http.Request) {
 
 	output, err := a.uc.Execute(r.Context(), input)
 	if err != nil {
-		logging.NewError(
-			a.log,
-			err,
-			logKey,
-			http.StatusInternalServerError,
-		).Log("error when creating a new account")
-
-		response.NewError(err, http.StatusInternalServerError).Send(w)
-		return
+		switch err {
+		case domain.ErrForbidden:
+			logging.NewError(
+				a.log,
+				err,
+				logKey,
+				http.StatusForbidden,
+			).Log("error when creating a new account")
+
+			response.NewError(err, http.StatusForbidden).Send(w)
+			return
+		default:
+			logging.NewError(
+				a.log,
+				err,
+				logKey,
+				http.StatusInternalServerError,
+			).Log("error when creating a new account")
+
+			response.NewError(err, http.StatusInternalServerError).Send(w)
+			return
+		}
 	}
 	logging.NewInfo(a.log, logKey, http.StatusCreated).Log("success creating account")
 
 	response.NewSuccess(output, http.StatusCreated).Send(w)
 }
 
-func (a CreateAccountAction) validateInput(input usecase.CreateAccountInput) []string {
+func decodeCreateAccountInput(body io.Reader) (usecase.CreateAccountInput, error) {
+	var input usecase.CreateAccountInput
+	err := json.NewDecoder(body).Decode(&input)
+	return input, err
+}
+
+func (a CreateAccountAction[O]) validateInput(input usecase.CreateAccountInput) []string {
 	var msgs []string
 
 	err := a.validator.Validate(input)
@@ -87,6 +129,6 @@ func (a CreateAccountAction) validateInput(input usecase.CreateAccountInput) []s
 	return msgs
 }
 
-func (a CreateAccountAction) cleanCPF(cpf string) string {
+func (a CreateAccountAction[O]) cleanCPF(cpf string) string {
 	return strings.Replace(strings.Replace(cpf, ".", "", -1), "-", "", -1)
 }
@@ -0,0 +1,65 @@
+package action
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/adapter/validator"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type CreateAPIKeyAction struct {
+	uc        usecase.CreateAPIKeyUseCase
+	log       logger.Logger
+	validator validator.Validator
+}
+
+func NewCreateAPIKeyAction(uc usecase.CreateAPIKeyUseCase, log logger.Logger, v validator.Validator) CreateAPIKeyAction {
+	return CreateAPIKeyAction{
+		uc:        uc,
+		log:       log,
+		validator: v,
+	}
+}
+
+func (a CreateAPIKeyAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "create_api_key"
+
+	var input usecase.CreateAPIKeyInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("error when decoding json")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := a.validator.Validate(input); err != nil {
+		logging.NewError(
+			a.log,
+			response.ErrInvalidInput,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid input")
+
+		response.NewErrorMessage(a.validator.Messages(), http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), input)
+	if err != nil {
+		handleAPIKeyErr(w, a.log, err, logKey, "error when creating a new api key")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusCreated).Log("success creating api key")
+
+	response.NewSuccess(output, http.StatusCreated).Send(w)
+}
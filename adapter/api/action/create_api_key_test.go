@@ -0,0 +1,134 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/validation"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type mockCreateAPIKey struct {
+	result usecase.CreateAPIKeyOutput
+	err    error
+}
+
+func (m mockCreateAPIKey) Execute(_ context.Context, _ usecase.CreateAPIKeyInput) (usecase.CreateAPIKeyOutput, error) {
+	return m.result, m.err
+}
+
+func TestCreateAPIKeyAction_Execute(t *testing.T) {
+	t.Parallel()
+
+	validator, _ := validation.NewValidatorFactory(validation.InstanceGoPlayground)
+
+	type args struct {
+		rawPayload []byte
+	}
+
+	tests := []struct {
+		name               string
+		args               args
+		ucMock             usecase.CreateAPIKeyUseCase
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name: "CreateAPIKeyAction success",
+			args: args{
+				rawPayload: []byte(`{"name": "erp", "scopes": ["accounts:read"]}`),
+			},
+			ucMock: mockCreateAPIKey{
+				result: usecase.CreateAPIKeyOutput{
+					ID:        "3c096a40-ccba-4b58-93ed-57379ab04680",
+					Name:      "erp",
+					Key:       "abcd1234.secret",
+					Prefix:    "abcd1234",
+					Scopes:    []string{"accounts:read"},
+					CreatedAt: "0001-01-01T00:00:00Z",
+				},
+				err: nil,
+			},
+			expectedBody:       `{"id":"3c096a40-ccba-4b58-93ed-57379ab04680","name":"erp","key":"abcd1234.secret","prefix":"abcd1234","scopes":["accounts:read"],"created_at":"0001-01-01T00:00:00Z"}`,
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "CreateAPIKeyAction generic error",
+			args: args{
+				rawPayload: []byte(`{"name": "erp", "scopes": ["accounts:read"]}`),
+			},
+			ucMock: mockCreateAPIKey{
+				result: usecase.CreateAPIKeyOutput{},
+				err:    errors.New("error"),
+			},
+			expectedBody:       `{"errors":["error"]}`,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "CreateAPIKeyAction error unknown scope",
+			args: args{
+				rawPayload: []byte(`{"name": "erp", "scopes": ["accounts:delete"]}`),
+			},
+			ucMock: mockCreateAPIKey{
+				result: usecase.CreateAPIKeyOutput{},
+				err:    nil,
+			},
+			expectedBody:       `{"errors":["Scopes[0] must be one of [accounts:read accounts:write transfers:read transfers:write api_keys:admin webhooks:admin audit:read]"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "CreateAPIKeyAction error without scopes",
+			args: args{
+				rawPayload: []byte(`{"name": "erp"}`),
+			},
+			ucMock: mockCreateAPIKey{
+				result: usecase.CreateAPIKeyOutput{},
+				err:    nil,
+			},
+			expectedBody:       `{"errors":["Scopes is a required field"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(
+				http.MethodPost,
+				"/admin/api-keys",
+				bytes.NewReader(tt.args.rawPayload),
+			)
+
+			var (
+				w      = httptest.NewRecorder()
+				action = NewCreateAPIKeyAction(tt.ucMock, log.LoggerMock{}, validator)
+			)
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
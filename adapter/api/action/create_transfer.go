@@ -3,6 +3,7 @@ package action
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 
 	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
@@ -13,27 +14,54 @@ import (
 	"github.com/gsabadini/go-clean-architecture/usecase"
 )
 
-type CreateTransferAction struct {
+// CreateTransferAction reads the input and answers with the output of the use case, of /v1 or of /v2
+type CreateTransferAction[O any] struct {
 	log       logger.Logger
-	uc        usecase.CreateTransferUseCase
+	uc        usecase.CreateTransferUseCaseOf[O]
 	validator validator.Validator
+	decode    func(io.Reader) (usecase.CreateTransferInput, error)
+	// status is the status of the transfer presented by the output
+	status func(O) string
 
 	logKey, logMsg string
 }
 
-func NewCreateTransferAction(uc usecase.CreateTransferUseCase, log logger.Logger, v validator.Validator) CreateTransferAction {
-	return CreateTransferAction{
+func NewCreateTransferAction(
+	uc usecase.CreateTransferUseCase,
+	log logger.Logger,
+	v validator.Validator,
+) CreateTransferAction[usecase.CreateTransferOutput] {
+	return CreateTransferAction[usecase.CreateTransferOutput]{
 		uc:        uc,
 		log:       log,
 		validator: v,
+		decode:    decodeCreateTransferInput,
+		status:    func(o usecase.CreateTransferOutput) string { return o.Status },
 		logKey:    "create_transfer",
 		logMsg:    "creating a new transfer",
 	}
 }
 
-func (t CreateTransferAction) Execute(w http.ResponseWriter, r *http.Request) {
-	var input usecase.CreateTransferInput
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+// NewCreateTransferActionV2 reads the amount as a decimal string with its currency
+func NewCreateTransferActionV2(
+	uc usecase.CreateTransferUseCaseV2,
+	log logger.Logger,
+	v validator.Validator,
+) CreateTransferAction[usecase.TransferOutputV2] {
+	return CreateTransferAction[usecase.TransferOutputV2]{
+		uc:        uc,
+		log:       log,
+		validator: v,
+		decode:    decodeCreateTransferInputV2,
+		status:    func(o usecase.TransferOutputV2) string { return o.Status },
+		logKey:    "create_transfer",
+		logMsg:    "creating a new transfer",
+	}
+}
+
+func (t CreateTransferAction[O]) Execute(w http.ResponseWriter, r *http.Request) {
+	input, err := t.decode(r.Body)
+	if err != nil {
 		logging.NewError(
 			t.log,
 			err,
@@ -64,12 +92,25 @@ func (t CreateTransferAction) Execute(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	logging.NewInfo(t.log, t.logKey, http.StatusCreated).Log(t.logMsg)
+	// Held and pending approval transfers were accepted but wait for a manual decision before moving the money
+	var status = http.StatusCreated
+	if transferStatus := t.status(output); transferStatus == domain.TransferStatusHeld.String() ||
+		transferStatus == domain.TransferStatusPendingApproval.String() {
+		status = http.StatusAccepted
+	}
+
+	logging.NewInfo(t.log, t.logKey, status).Log(t.logMsg)
+
+	response.NewSuccess(output, status).Send(w)
+}
 
-	response.NewSuccess(output, http.StatusCreated).Send(w)
+func decodeCreateTransferInput(body io.Reader) (usecase.CreateTransferInput, error) {
+	var input usecase.CreateTransferInput
+	err := json.NewDecoder(body).Decode(&input)
+	return input, err
 }
 
-func (t CreateTransferAction) handleErr(w http.ResponseWriter, err error) {
+func (t CreateTransferAction[O]) handleErr(w http.ResponseWriter, err error) {
 	switch err {
 	case domain.ErrInsufficientBalance:
 		logging.NewError(
@@ -91,7 +132,7 @@ func (t CreateTransferAction) handleErr(w http.ResponseWriter, err error) {
 
 		response.NewError(err, http.StatusUnprocessableEntity).Send(w)
 		return
-	case domain.ErrAccountDestinationNotFound:
+	case domain.ErrAccountDestinationNotFound, domain.ErrTransferDenied, domain.ErrNonPositiveAmount, domain.ErrMoneyOverflow:
 		logging.NewError(
 			t.log,
 			err,
@@ -101,6 +142,16 @@ func (t CreateTransferAction) handleErr(w http.ResponseWriter, err error) {
 
 		response.NewError(err, http.StatusUnprocessableEntity).Send(w)
 		return
+	case domain.ErrForbidden:
+		logging.NewError(
+			t.log,
+			err,
+			t.logKey,
+			http.StatusForbidden,
+		).Log(t.logMsg)
+
+		response.NewError(err, http.StatusForbidden).Send(w)
+		return
 	default:
 		logging.NewError(
 			t.log,
@@ -114,7 +165,7 @@ func (t CreateTransferAction) handleErr(w http.ResponseWriter, err error) {
 	}
 }
 
-func (t CreateTransferAction) validateInput(input usecase.CreateTransferInput) []string {
+func (t CreateTransferAction[O]) validateInput(input usecase.CreateTransferInput) []string {
 	var (
 		msgs              []string
 		errAccountsEquals = errors.New("account origin equals destination account")
@@ -56,13 +56,53 @@ func TestCreateTransferAction_Execute(t *testing.T) {
 					AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
 					AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04681",
 					Amount:               10,
+					Status:               "completed",
 					CreatedAt:            time.Time{}.String(),
 				},
 				err: nil,
 			},
-			expectedBody:       `{"id":"3c096a40-ccba-4b58-93ed-57379ab04679","account_origin_id":"3c096a40-ccba-4b58-93ed-57379ab04680","account_destination_id":"3c096a40-ccba-4b58-93ed-57379ab04681","amount":10,"created_at":"0001-01-01 00:00:00 +0000 UTC"}`,
+			expectedBody:       `{"id":"3c096a40-ccba-4b58-93ed-57379ab04679","account_origin_id":"3c096a40-ccba-4b58-93ed-57379ab04680","account_destination_id":"3c096a40-ccba-4b58-93ed-57379ab04681","amount":10,"status":"completed","created_at":"0001-01-01 00:00:00 +0000 UTC"}`,
 			expectedStatusCode: http.StatusCreated,
 		},
+		{
+			name: "CreateTransferAction held for review",
+			args: args{
+				rawPayload: []byte(`{
+					"account_destination_id": "3c096a40-ccba-4b58-93ed-57379ab04680",
+					"account_origin_id": "3c096a40-ccba-4b58-93ed-57379ab04681",
+					"amount": 10
+				}`),
+			},
+			ucMock: mockCreateTransfer{
+				result: usecase.CreateTransferOutput{
+					ID:                   "3c096a40-ccba-4b58-93ed-57379ab04679",
+					AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
+					AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04681",
+					Amount:               10,
+					Status:               "held",
+					CreatedAt:            time.Time{}.String(),
+				},
+				err: nil,
+			},
+			expectedBody:       `{"id":"3c096a40-ccba-4b58-93ed-57379ab04679","account_origin_id":"3c096a40-ccba-4b58-93ed-57379ab04680","account_destination_id":"3c096a40-ccba-4b58-93ed-57379ab04681","amount":10,"status":"held","created_at":"0001-01-01 00:00:00 +0000 UTC"}`,
+			expectedStatusCode: http.StatusAccepted,
+		},
+		{
+			name: "CreateTransferAction error denied by risk rules",
+			args: args{
+				rawPayload: []byte(`{
+					"account_destination_id": "3c096a40-ccba-4b58-93ed-57379ab04680",
+					"account_origin_id": "3c096a40-ccba-4b58-93ed-57379ab04681",
+					"amount": 10
+				}`),
+			},
+			ucMock: mockCreateTransfer{
+				result: usecase.CreateTransferOutput{},
+				err:    domain.ErrTransferDenied,
+			},
+			expectedBody:       `{"errors":["transfer denied by risk rules"]}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
 		{
 			name: "CreateTransferAction generic error",
 			args: args{
@@ -135,6 +175,24 @@ func TestCreateTransferAction_Execute(t *testing.T) {
 			expectedBody:       `{"errors":["account destination not found"]}`,
 			expectedStatusCode: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "CreateTransferAction error forbidden account origin",
+			args: args{
+				rawPayload: []byte(
+					`{
+						"account_destination_id": "3c096a40-ccba-4b58-93ed-57379ab04680",
+						"account_origin_id": "3c096a40-ccba-4b58-93ed-57379ab04681",
+						"amount": 10
+					}`,
+				),
+			},
+			ucMock: mockCreateTransfer{
+				result: usecase.CreateTransferOutput{},
+				err:    domain.ErrForbidden,
+			},
+			expectedBody:       `{"errors":["caller is not allowed to access this resource"]}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
 		{
 			name: "CreateTransferAction error account origin equals account destination",
 			args: args{
@@ -0,0 +1,151 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/presenter"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/validation"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type mockCreateTransferV2 struct {
+	presenter usecase.CreateTransferPresenterV2
+	amount    *int64
+}
+
+func (m mockCreateTransferV2) Execute(_ context.Context, input usecase.CreateTransferInput) (usecase.TransferOutputV2, error) {
+	*m.amount = input.Amount
+
+	return m.presenter.Output(domain.NewTransfer(
+		"3c096a40-ccba-4b58-93ed-57379ab04679",
+		domain.AccountID(input.AccountOriginID),
+		domain.AccountID(input.AccountDestinationID),
+		domain.Money(input.Amount),
+		domain.TransferStatusCompleted,
+		time.Time{},
+	)), nil
+}
+
+func TestCreateTransferActionV2_Execute(t *testing.T) {
+	t.Parallel()
+
+	validator, _ := validation.NewValidatorFactory(validation.InstanceGoPlayground)
+
+	tests := []struct {
+		name               string
+		rawPayload         []byte
+		expectedAmount     int64
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name: "CreateTransferActionV2 success",
+			rawPayload: []byte(`{
+				"account_destination_id": "3c096a40-ccba-4b58-93ed-57379ab04680",
+				"account_origin_id": "3c096a40-ccba-4b58-93ed-57379ab04681",
+				"amount": "29.99",
+				"currency": "BRL"
+			}`),
+			expectedAmount:     2999,
+			expectedBody:       `{"id":"3c096a40-ccba-4b58-93ed-57379ab04679","account_origin_id":"3c096a40-ccba-4b58-93ed-57379ab04681","account_destination_id":"3c096a40-ccba-4b58-93ed-57379ab04680","amount":"29.99","currency":"BRL","status":"completed","created_at":"0001-01-01T00:00:00Z"}`,
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "CreateTransferActionV2 error too many decimals",
+			rawPayload: []byte(`{
+				"account_destination_id": "3c096a40-ccba-4b58-93ed-57379ab04680",
+				"account_origin_id": "3c096a40-ccba-4b58-93ed-57379ab04681",
+				"amount": "29.999",
+				"currency": "BRL"
+			}`),
+			expectedBody:       `{"errors":["money amount has more than 2 decimal places"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "CreateTransferActionV2 error overflow",
+			rawPayload: []byte(`{
+				"account_destination_id": "3c096a40-ccba-4b58-93ed-57379ab04680",
+				"account_origin_id": "3c096a40-ccba-4b58-93ed-57379ab04681",
+				"amount": "92233720368547758.08",
+				"currency": "BRL"
+			}`),
+			expectedBody:       `{"errors":["money amount is too large"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "CreateTransferActionV2 error unsupported currency",
+			rawPayload: []byte(`{
+				"account_destination_id": "3c096a40-ccba-4b58-93ed-57379ab04680",
+				"account_origin_id": "3c096a40-ccba-4b58-93ed-57379ab04681",
+				"amount": "29.99",
+				"currency": "USD"
+			}`),
+			expectedBody:       `{"errors":["unsupported currency"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "CreateTransferActionV2 error float amount",
+			rawPayload: []byte(`{
+				"account_destination_id": "3c096a40-ccba-4b58-93ed-57379ab04680",
+				"account_origin_id": "3c096a40-ccba-4b58-93ed-57379ab04681",
+				"amount": 29.99,
+				"currency": "BRL"
+			}`),
+			expectedBody:       `{"errors":["json: cannot unmarshal number into Go struct field .amount of type string"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(
+				http.MethodPost,
+				"/v2/transfers",
+				bytes.NewReader(tt.rawPayload),
+			)
+
+			var (
+				amount int64
+				w      = httptest.NewRecorder()
+				action = NewCreateTransferActionV2(
+					mockCreateTransferV2{presenter: presenter.NewCreateTransferPresenterV2(), amount: &amount},
+					log.LoggerMock{},
+					validator,
+				)
+			)
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			if amount != tt.expectedAmount {
+				t.Errorf("[TestCase '%s'] Amount: '%v' | Expected: '%v'", tt.name, amount, tt.expectedAmount)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
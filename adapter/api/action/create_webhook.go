@@ -0,0 +1,92 @@
+package action
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/adapter/validator"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type CreateWebhookAction struct {
+	uc        usecase.CreateWebhookUseCase
+	log       logger.Logger
+	validator validator.Validator
+}
+
+func NewCreateWebhookAction(uc usecase.CreateWebhookUseCase, log logger.Logger, v validator.Validator) CreateWebhookAction {
+	return CreateWebhookAction{
+		uc:        uc,
+		log:       log,
+		validator: v,
+	}
+}
+
+func (a CreateWebhookAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "create_webhook"
+
+	var input usecase.CreateWebhookInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("error when decoding json")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := a.validator.Validate(input); err != nil {
+		logging.NewError(
+			a.log,
+			response.ErrInvalidInput,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid input")
+
+		response.NewErrorMessage(a.validator.Messages(), http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), input)
+	if err != nil {
+		handleWebhookErr(w, a.log, err, logKey, "error when creating a new webhook")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusCreated).Log("success creating webhook")
+
+	response.NewSuccess(output, http.StatusCreated).Send(w)
+}
+
+func handleWebhookErr(w http.ResponseWriter, log logger.Logger, err error, logKey, logMsg string) {
+	var status int
+	switch err {
+	case domain.ErrWebhookNotFound, domain.ErrWebhookDeliveryNotFound:
+		status = http.StatusNotFound
+	case domain.ErrWebhookDisabled,
+		domain.ErrWebhookDeliveryPending,
+		domain.ErrWebhookURLNotAllowed,
+		domain.ErrAccountNotFound:
+		status = http.StatusUnprocessableEntity
+	case domain.ErrForbidden:
+		status = http.StatusForbidden
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	logging.NewError(
+		log,
+		err,
+		logKey,
+		status,
+	).Log(logMsg)
+
+	response.NewError(err, status).Send(w)
+}
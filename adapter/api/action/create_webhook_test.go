@@ -0,0 +1,164 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/validation"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type mockCreateWebhook struct {
+	result usecase.CreateWebhookOutput
+	err    error
+}
+
+func (m mockCreateWebhook) Execute(_ context.Context, _ usecase.CreateWebhookInput) (usecase.CreateWebhookOutput, error) {
+	return m.result, m.err
+}
+
+func TestCreateWebhookAction_Execute(t *testing.T) {
+	t.Parallel()
+
+	validator, _ := validation.NewValidatorFactory(validation.InstanceGoPlayground)
+
+	type args struct {
+		rawPayload []byte
+	}
+
+	tests := []struct {
+		name               string
+		args               args
+		ucMock             usecase.CreateWebhookUseCase
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name: "CreateWebhookAction success",
+			args: args{
+				rawPayload: []byte(`{"url": "https://partner.example.com/hooks", "events": ["transfer.completed"], "account_ids": ["3c096a40-ccba-4b58-93ed-57379ab04681"]}`),
+			},
+			ucMock: mockCreateWebhook{
+				result: usecase.CreateWebhookOutput{
+					ID:         "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+					URL:        "https://partner.example.com/hooks",
+					Events:     []string{"transfer.completed"},
+					AccountIDs: []string{"3c096a40-ccba-4b58-93ed-57379ab04681"},
+					Secret:     "secret",
+					CreatedAt:  "0001-01-01T00:00:00Z",
+				},
+			},
+			expectedBody:       `{"id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","url":"https://partner.example.com/hooks","events":["transfer.completed"],"account_ids":["3c096a40-ccba-4b58-93ed-57379ab04681"],"secret":"secret","created_at":"0001-01-01T00:00:00Z"}`,
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "CreateWebhookAction error forbidden",
+			args: args{
+				rawPayload: []byte(`{"url": "https://partner.example.com/hooks", "events": ["transfer.completed"], "account_ids": ["3c096a40-ccba-4b58-93ed-57379ab04681"]}`),
+			},
+			ucMock: mockCreateWebhook{
+				err: domain.ErrForbidden,
+			},
+			expectedBody:       `{"errors":["caller is not allowed to access this resource"]}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "CreateWebhookAction error account not found",
+			args: args{
+				rawPayload: []byte(`{"url": "https://partner.example.com/hooks", "events": ["transfer.completed"], "account_ids": ["3c096a40-ccba-4b58-93ed-57379ab04681"]}`),
+			},
+			ucMock: mockCreateWebhook{
+				err: domain.ErrAccountNotFound,
+			},
+			expectedBody:       `{"errors":["account not found"]}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "CreateWebhookAction error without accounts",
+			args: args{
+				rawPayload: []byte(`{"url": "https://partner.example.com/hooks", "events": ["transfer.completed"]}`),
+			},
+			ucMock:             mockCreateWebhook{},
+			expectedBody:       `{"errors":["AccountIDs is a required field"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "CreateWebhookAction error invalid event",
+			args: args{
+				rawPayload: []byte(`{"url": "https://partner.example.com/hooks", "events": ["transfer.created"], "account_ids": ["3c096a40-ccba-4b58-93ed-57379ab04681"]}`),
+			},
+			ucMock:             mockCreateWebhook{},
+			expectedBody:       `{"errors":["Events[0] must be one of [account.created transfer.completed transfer.failed]"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "CreateWebhookAction error invalid url",
+			args: args{
+				rawPayload: []byte(`{"url": "partner", "events": ["transfer.completed"], "account_ids": ["3c096a40-ccba-4b58-93ed-57379ab04681"]}`),
+			},
+			ucMock:             mockCreateWebhook{},
+			expectedBody:       `{"errors":["URL must be a valid URL"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "CreateWebhookAction error short secret",
+			args: args{
+				rawPayload: []byte(`{"url": "https://partner.example.com/hooks", "events": ["transfer.completed"], "account_ids": ["3c096a40-ccba-4b58-93ed-57379ab04681"], "secret": "short"}`),
+			},
+			ucMock:             mockCreateWebhook{},
+			expectedBody:       `{"errors":["Secret must be at least 16 characters in length"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "CreateWebhookAction error invalid JSON",
+			args: args{
+				rawPayload: []byte(`{"url": }`),
+			},
+			ucMock:             mockCreateWebhook{},
+			expectedBody:       `{"errors":["invalid character '}' looking for beginning of value"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(
+				http.MethodPost,
+				"/webhooks",
+				bytes.NewReader(tt.args.rawPayload),
+			)
+
+			var (
+				w      = httptest.NewRecorder()
+				action = NewCreateWebhookAction(tt.ucMock, log.LoggerMock{}, validator)
+			)
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
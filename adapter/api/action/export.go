@@ -0,0 +1,94 @@
+package action
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+var (
+	errExportFormatNotAcceptable = errors.New("format must be one of json, csv, ofx or camt053")
+
+	errExportAccountRequired = errors.New("the format needs the transfers of a single account, narrowed with account_id")
+)
+
+// ExportFormat is a file transfers can be exported as, chosen by its name in the format query parameter or by one of
+// its media types in the Accept header. The first media type is the Content-Type of the answer
+type ExportFormat struct {
+	Name       string
+	MediaTypes []string
+	Extension  string
+	// Account tells whether the file describes the transfers of a single account
+	Account     bool
+	NewExporter func(io.Writer) usecase.TransferExporter
+}
+
+// negotiateExport picks the format asked for, preferring the format parameter over the Accept header. Without either,
+// or when no media type accepted is known, it is the first format. Only an unknown format parameter is refused
+func negotiateExport(formats []ExportFormat, r *http.Request) (ExportFormat, error) {
+	if name := r.URL.Query().Get("format"); name != "" {
+		for _, format := range formats {
+			if format.Name == name {
+				return format, nil
+			}
+		}
+
+		return ExportFormat{}, errExportFormatNotAcceptable
+	}
+
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+
+		for _, format := range formats {
+			for _, t := range format.MediaTypes {
+				if t == mediaType {
+					return format, nil
+				}
+			}
+		}
+	}
+
+	return formats[0], nil
+}
+
+// WantsExport tells whether the request asks for a format other than the first, the JSON answered by the listings
+func WantsExport(formats []ExportFormat, r *http.Request) bool {
+	format, err := negotiateExport(formats, r)
+	return err != nil || format.Name != formats[0].Name
+}
+
+// exportWriter sends the status and headers of the file on the first write, so an error found before the exporter
+// writes anything can still be answered as JSON
+type exportWriter struct {
+	w        http.ResponseWriter
+	format   ExportFormat
+	filename string
+	started  bool
+}
+
+func newExportWriter(w http.ResponseWriter, format ExportFormat, filename string) *exportWriter {
+	// Large files take longer than the write timeout of the server. The export is bounded by the timeout of its use
+	// case instead. Writers unable to change it keep the one of the server
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	return &exportWriter{w: w, format: format, filename: filename}
+}
+
+func (e *exportWriter) Write(b []byte) (int, error) {
+	if !e.started {
+		e.started = true
+		e.w.Header().Set("Content-Type", e.format.MediaTypes[0])
+		e.w.Header().Set("Content-Disposition", `attachment; filename="`+e.filename+"."+e.format.Extension+`"`)
+		e.w.WriteHeader(http.StatusOK)
+	}
+
+	return e.w.Write(b)
+}
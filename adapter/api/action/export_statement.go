@@ -0,0 +1,94 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type ExportStatementAction struct {
+	uc      usecase.ExportStatementUseCase
+	formats []ExportFormat
+	log     logger.Logger
+}
+
+func NewExportStatementAction(
+	uc usecase.ExportStatementUseCase,
+	formats []ExportFormat,
+	log logger.Logger,
+) ExportStatementAction {
+	return ExportStatementAction{
+		uc:      uc,
+		formats: formats,
+		log:     log,
+	}
+}
+
+func (a ExportStatementAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "export_statement"
+
+	format, err := negotiateExport(a.formats, r)
+	if err != nil {
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusNotAcceptable,
+		).Log("invalid format")
+
+		response.NewError(err, http.StatusNotAcceptable).Send(w)
+		return
+	}
+
+	var (
+		query     = r.URL.Query()
+		accountID = query.Get("account_id")
+	)
+
+	if !domain.IsValidUUID(accountID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	from, to, err := parseDailyBalancePeriod(query.Get("from"), query.Get("to"))
+	if err != nil {
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	var out = newExportWriter(
+		w,
+		format,
+		"statement-"+accountID+"-"+from.Format("20060102")+"-"+to.Format("20060102"),
+	)
+
+	err = a.uc.Execute(
+		r.Context(),
+		usecase.ExportStatementInput{AccountID: domain.AccountID(accountID), From: from, To: to},
+		format.NewExporter(out),
+	)
+	if err != nil {
+		handleExportErr(w, out, err, a.log, logKey, "error when exporting the statement")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success when exporting statement")
+}
@@ -0,0 +1,163 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/presenter"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+var exportFormatsMock = []ExportFormat{
+	{Name: "json", MediaTypes: []string{"application/json"}, Extension: "json", NewExporter: presenter.NewJSONExporter},
+	{Name: "csv", MediaTypes: []string{"text/csv"}, Extension: "csv", NewExporter: presenter.NewCSVExporter},
+	{Name: "ofx", MediaTypes: []string{"application/x-ofx"}, Extension: "ofx", Account: true, NewExporter: presenter.NewOFXExporter},
+}
+
+type mockExportStatement struct {
+	err error
+}
+
+func (m mockExportStatement) Execute(_ context.Context, input usecase.ExportStatementInput, exporter usecase.TransferExporter) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	if err := exporter.Begin(usecase.ExportHeader{AccountID: input.AccountID}); err != nil {
+		return err
+	}
+
+	return exporter.End()
+}
+
+func TestExportStatementAction_Execute(t *testing.T) {
+	t.Parallel()
+
+	const accountID = "3c096a40-ccba-4b58-93ed-57379ab04680"
+
+	tests := []struct {
+		name                string
+		rawQuery            string
+		accept              string
+		ucMock              usecase.ExportStatementUseCase
+		expectedContentType string
+		expectedBody        string
+		expectedStatusCode  int
+	}{
+		{
+			name:                "ExportStatementAction success csv by format",
+			rawQuery:            "account_id=" + accountID + "&from=2021-01-01&to=2021-01-02&format=csv",
+			ucMock:              mockExportStatement{},
+			expectedContentType: "text/csv",
+			expectedBody:        "id,created_at,account_origin_id,account_destination_id,direction,amount,status,failure_code",
+			expectedStatusCode:  http.StatusOK,
+		},
+		{
+			name:                "ExportStatementAction success ofx by Accept",
+			rawQuery:            "account_id=" + accountID + "&from=2021-01-01&to=2021-01-02",
+			accept:              "application/x-ofx",
+			ucMock:              mockExportStatement{},
+			expectedContentType: "application/x-ofx",
+			expectedBody:        "<ACCTID>" + accountID + "</ACCTID>",
+			expectedStatusCode:  http.StatusOK,
+		},
+		{
+			name:                "ExportStatementAction success json by default",
+			rawQuery:            "account_id=" + accountID,
+			accept:              "*/*",
+			ucMock:              mockExportStatement{},
+			expectedContentType: "application/json",
+			expectedBody:        `"transfers":[]}`,
+			expectedStatusCode:  http.StatusOK,
+		},
+		{
+			name:                "ExportStatementAction error unknown format",
+			rawQuery:            "account_id=" + accountID + "&format=pdf",
+			ucMock:              mockExportStatement{},
+			expectedContentType: "application/json",
+			expectedBody:        `{"errors":["format must be one of json, csv, ofx or camt053"]}`,
+			expectedStatusCode:  http.StatusNotAcceptable,
+		},
+		{
+			name:                "ExportStatementAction error invalid account id",
+			rawQuery:            "account_id=error",
+			ucMock:              mockExportStatement{},
+			expectedContentType: "application/json",
+			expectedBody:        `{"errors":["parameter invalid"]}`,
+			expectedStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "ExportStatementAction error from after to",
+			rawQuery:            "account_id=" + accountID + "&from=2021-01-02&to=2021-01-01",
+			ucMock:              mockExportStatement{},
+			expectedContentType: "application/json",
+			expectedBody:        `{"errors":["from and to must be dates as 2006-01-02, from not after to, covering at most 366 days"]}`,
+			expectedStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "ExportStatementAction error forbidden",
+			rawQuery:            "account_id=" + accountID + "&format=csv",
+			ucMock:              mockExportStatement{err: domain.ErrForbidden},
+			expectedContentType: "application/json",
+			expectedBody:        `{"errors":["caller is not allowed to access this resource"]}`,
+			expectedStatusCode:  http.StatusForbidden,
+		},
+		{
+			name:                "ExportStatementAction generic error",
+			rawQuery:            "account_id=" + accountID + "&format=csv",
+			ucMock:              mockExportStatement{err: errors.New("error")},
+			expectedContentType: "application/json",
+			expectedBody:        `{"errors":["error"]}`,
+			expectedStatusCode:  http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/accounts/statement?"+tt.rawQuery, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			var (
+				w      = httptest.NewRecorder()
+				action = NewExportStatementAction(tt.ucMock, exportFormatsMock, log.LoggerMock{})
+			)
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			if contentType := w.Header().Get("Content-Type"); contentType != tt.expectedContentType {
+				t.Errorf(
+					"[TestCase '%s'] Content-Type: '%v' | Expected: '%v'",
+					tt.name,
+					contentType,
+					tt.expectedContentType,
+				)
+			}
+
+			if !strings.Contains(w.Body.String(), tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					w.Body.String(),
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
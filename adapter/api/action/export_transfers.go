@@ -0,0 +1,130 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type ExportTransfersAction struct {
+	uc      usecase.ExportTransfersUseCase
+	formats []ExportFormat
+	log     logger.Logger
+}
+
+func NewExportTransfersAction(
+	uc usecase.ExportTransfersUseCase,
+	formats []ExportFormat,
+	log logger.Logger,
+) ExportTransfersAction {
+	return ExportTransfersAction{
+		uc:      uc,
+		formats: formats,
+		log:     log,
+	}
+}
+
+func (a ExportTransfersAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "export_transfers"
+
+	format, err := negotiateExport(a.formats, r)
+	if err != nil {
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusNotAcceptable,
+		).Log("invalid format")
+
+		response.NewError(err, http.StatusNotAcceptable).Send(w)
+		return
+	}
+
+	var (
+		query     = r.URL.Query()
+		accountID = query.Get("account_id")
+		status    = query.Get("status")
+	)
+
+	if accountID != "" && !domain.IsValidUUID(accountID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	if status != "" {
+		if _, err := domain.ParseTransferStatus(status); err != nil {
+			logging.NewError(
+				a.log,
+				err,
+				logKey,
+				http.StatusBadRequest,
+			).Log("invalid parameter")
+
+			response.NewError(err, http.StatusBadRequest).Send(w)
+			return
+		}
+	}
+
+	if format.Account && accountID == "" {
+		var err = errExportAccountRequired
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	var out = newExportWriter(w, format, "transfers")
+
+	err = a.uc.Execute(
+		r.Context(),
+		usecase.ExportTransfersInput{AccountID: domain.AccountID(accountID), Status: status},
+		format.NewExporter(out),
+	)
+	if err != nil {
+		handleExportErr(w, out, err, a.log, logKey, "error when exporting the transfer list")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success when exporting transfer list")
+}
+
+// handleExportErr answers the error as JSON when the file was not started yet. Otherwise the file is cut short,
+// which the client sees as an incomplete body
+func handleExportErr(w http.ResponseWriter, out *exportWriter, err error, log logger.Logger, logKey, msg string) {
+	var status = http.StatusInternalServerError
+	switch err {
+	case domain.ErrForbidden:
+		status = http.StatusForbidden
+	case domain.ErrAccountNotFound:
+		status = http.StatusNotFound
+	case domain.ErrOpeningBalanceUnknown:
+		status = http.StatusUnprocessableEntity
+	}
+
+	logging.NewError(
+		log,
+		err,
+		logKey,
+		status,
+	).Log(msg)
+
+	if !out.started {
+		response.NewError(err, status).Send(w)
+	}
+}
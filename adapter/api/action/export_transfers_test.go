@@ -0,0 +1,148 @@
+package action
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type mockExportTransfers struct {
+	err error
+}
+
+func (m mockExportTransfers) Execute(_ context.Context, input usecase.ExportTransfersInput, exporter usecase.TransferExporter) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	if err := exporter.Begin(usecase.ExportHeader{AccountID: input.AccountID}); err != nil {
+		return err
+	}
+
+	return exporter.End()
+}
+
+func TestExportTransfersAction_Execute(t *testing.T) {
+	t.Parallel()
+
+	const accountID = "3c096a40-ccba-4b58-93ed-57379ab04680"
+
+	tests := []struct {
+		name               string
+		rawQuery           string
+		ucMock             usecase.ExportTransfersUseCase
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name:               "ExportTransfersAction success csv",
+			rawQuery:           "format=csv&status=completed",
+			ucMock:             mockExportTransfers{},
+			expectedBody:       "id,created_at,account_origin_id,account_destination_id,direction,amount,status,failure_code",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "ExportTransfersAction success ofx of an account",
+			rawQuery:           "format=ofx&account_id=" + accountID,
+			ucMock:             mockExportTransfers{},
+			expectedBody:       "<ACCTID>" + accountID + "</ACCTID>",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "ExportTransfersAction error ofx without an account",
+			rawQuery:           "format=ofx",
+			ucMock:             mockExportTransfers{},
+			expectedBody:       `{"errors":["the format needs the transfers of a single account, narrowed with account_id"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "ExportTransfersAction error invalid status",
+			rawQuery:           "format=csv&status=unknown",
+			ucMock:             mockExportTransfers{},
+			expectedBody:       `{"errors":["invalid transfer status"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "ExportTransfersAction error invalid account id",
+			rawQuery:           "format=csv&account_id=error",
+			ucMock:             mockExportTransfers{},
+			expectedBody:       `{"errors":["parameter invalid"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "ExportTransfersAction error account not found",
+			rawQuery:           "format=csv&account_id=" + accountID,
+			ucMock:             mockExportTransfers{err: domain.ErrAccountNotFound},
+			expectedBody:       `{"errors":["account not found"]}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/transfers?"+tt.rawQuery, nil)
+
+			var (
+				w      = httptest.NewRecorder()
+				action = NewExportTransfersAction(tt.ucMock, exportFormatsMock, log.LoggerMock{})
+			)
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			if !strings.Contains(w.Body.String(), tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					w.Body.String(),
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
+
+func TestWantsExport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		rawQuery string
+		accept   string
+		expected bool
+	}{
+		{name: "WantsExport json by default", expected: false},
+		{name: "WantsExport json by Accept", accept: "application/json", expected: false},
+		{name: "WantsExport unknown Accept", accept: "text/html, */*;q=0.8", expected: false},
+		{name: "WantsExport csv by Accept", accept: "text/csv;charset=utf-8", expected: true},
+		{name: "WantsExport csv by format", rawQuery: "format=csv", accept: "application/json", expected: true},
+		{name: "WantsExport unknown format", rawQuery: "format=pdf", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/transfers?"+tt.rawQuery, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			if got := WantsExport(exportFormatsMock, req); got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
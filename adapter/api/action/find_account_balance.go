@@ -10,19 +10,23 @@ import (
 	"github.com/gsabadini/go-clean-architecture/usecase"
 )
 
-type FindAccountBalanceAction struct {
-	uc  usecase.FindAccountBalanceUseCase
+// FindAccountBalanceAction answers with the output of the use case, of /v1 or of /v2
+type FindAccountBalanceAction[O any] struct {
+	uc  usecase.FindAccountBalanceUseCaseOf[O]
 	log logger.Logger
 }
 
-func NewFindAccountBalanceAction(uc usecase.FindAccountBalanceUseCase, log logger.Logger) FindAccountBalanceAction {
-	return FindAccountBalanceAction{
+func NewFindAccountBalanceAction[O any](
+	uc usecase.FindAccountBalanceUseCaseOf[O],
+	log logger.Logger,
+) FindAccountBalanceAction[O] {
+	return FindAccountBalanceAction[O]{
 		uc:  uc,
 		log: log,
 	}
 }
 
-func (a FindAccountBalanceAction) Execute(w http.ResponseWriter, r *http.Request) {
+func (a FindAccountBalanceAction[O]) Execute(w http.ResponseWriter, r *http.Request) {
 	const logKey = "find_balance_account"
 
 	var accountID = r.URL.Query().Get("account_id")
@@ -52,6 +56,16 @@ func (a FindAccountBalanceAction) Execute(w http.ResponseWriter, r *http.Request
 
 			response.NewError(err, http.StatusBadRequest).Send(w)
 			return
+		case domain.ErrForbidden:
+			logging.NewError(
+				a.log,
+				err,
+				logKey,
+				http.StatusForbidden,
+			).Log("error fetching account balance")
+
+			response.NewError(err, http.StatusForbidden).Send(w)
+			return
 		default:
 			logging.NewError(
 				a.log,
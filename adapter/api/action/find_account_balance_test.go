@@ -63,6 +63,18 @@ func TestFindAccountBalanceAction_Execute(t *testing.T) {
 			expectedBody:       `{"errors":["error"]}`,
 			expectedStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "FindAccountBalanceAction error forbidden",
+			args: args{
+				accountID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+			},
+			ucMock: mockFindBalanceAccount{
+				result: usecase.FindAccountBalanceOutput{},
+				err:    domain.ErrForbidden,
+			},
+			expectedBody:       `{"errors":["caller is not allowed to access this resource"]}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
 		{
 			name: "FindAccountBalanceAction error parameter invalid",
 			args: args{
@@ -6,36 +6,64 @@ import (
 	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
 	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
 	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
 	"github.com/gsabadini/go-clean-architecture/usecase"
 )
 
-type FindAllAccountAction struct {
-	uc  usecase.FindAllAccountUseCase
+// FindAllAccountAction answers with the page of the outputs of the use case, of /v1 or of /v2
+type FindAllAccountAction[O any] struct {
+	uc  usecase.FindAllAccountUseCaseOf[O]
 	log logger.Logger
 }
 
-func NewFindAllAccountAction(uc usecase.FindAllAccountUseCase, log logger.Logger) FindAllAccountAction {
-	return FindAllAccountAction{
+func NewFindAllAccountAction[O any](uc usecase.FindAllAccountUseCaseOf[O], log logger.Logger) FindAllAccountAction[O] {
+	return FindAllAccountAction[O]{
 		uc:  uc,
 		log: log,
 	}
 }
 
-func (a FindAllAccountAction) Execute(w http.ResponseWriter, r *http.Request) {
+func (a FindAllAccountAction[O]) Execute(w http.ResponseWriter, r *http.Request) {
 	const logKey = "find_all_account"
 
-	output, err := a.uc.Execute(r.Context())
+	page, err := parsePageRequest(r.URL.Query(), domain.Sort{})
 	if err != nil {
 		logging.NewError(
 			a.log,
 			err,
 			logKey,
-			http.StatusInternalServerError,
-		).Log("error when returning account list")
+			http.StatusBadRequest,
+		).Log("invalid parameter")
 
-		response.NewError(err, http.StatusInternalServerError).Send(w)
+		response.NewError(err, http.StatusBadRequest).Send(w)
 		return
 	}
+
+	output, err := a.uc.Execute(r.Context(), page)
+	if err != nil {
+		switch err {
+		case domain.ErrForbidden:
+			logging.NewError(
+				a.log,
+				err,
+				logKey,
+				http.StatusForbidden,
+			).Log("error when returning account list")
+
+			response.NewError(err, http.StatusForbidden).Send(w)
+			return
+		default:
+			logging.NewError(
+				a.log,
+				err,
+				logKey,
+				http.StatusInternalServerError,
+			).Log("error when returning account list")
+
+			response.NewError(err, http.StatusInternalServerError).Send(w)
+			return
+		}
+	}
 	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success when returning account list")
 
 	response.NewSuccess(output, http.StatusOK).Send(w)
@@ -9,17 +9,19 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gsabadini/go-clean-architecture/domain"
 	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
 	"github.com/gsabadini/go-clean-architecture/usecase"
 )
 
 type mockFindAllAccount struct {
-	result []usecase.FindAllAccountOutput
-	err    error
+	result     []usecase.FindAllAccountOutput
+	nextCursor string
+	err        error
 }
 
-func (m mockFindAllAccount) Execute(_ context.Context) ([]usecase.FindAllAccountOutput, error) {
-	return m.result, m.err
+func (m mockFindAllAccount) Execute(_ context.Context, _ domain.PageRequest) (usecase.FindAllAccountPageOutput, error) {
+	return usecase.FindAllAccountPageOutput{Accounts: m.result, NextCursor: m.nextCursor}, m.err
 }
 
 func TestFindAllAccountAction_Execute(t *testing.T) {
@@ -27,6 +29,7 @@ func TestFindAllAccountAction_Execute(t *testing.T) {
 
 	tests := []struct {
 		name               string
+		rawQuery           string
 		ucMock             usecase.FindAllAccountUseCase
 		expectedBody       string
 		expectedStatusCode int
@@ -45,7 +48,7 @@ func TestFindAllAccountAction_Execute(t *testing.T) {
 				},
 				err: nil,
 			},
-			expectedBody:       `[{"id":"3c096a40-ccba-4b58-93ed-57379ab04680","name":"Test","cpf":"07094564964","balance":10,"created_at":"0001-01-01 00:00:00 +0000 UTC"}]`,
+			expectedBody:       `{"data":[{"id":"3c096a40-ccba-4b58-93ed-57379ab04680","name":"Test","cpf":"07094564964","balance":10,"created_at":"0001-01-01 00:00:00 +0000 UTC"}]}`,
 			expectedStatusCode: http.StatusOK,
 		},
 		{
@@ -54,9 +57,48 @@ func TestFindAllAccountAction_Execute(t *testing.T) {
 				result: []usecase.FindAllAccountOutput{},
 				err:    nil,
 			},
-			expectedBody:       `[]`,
+			expectedBody:       `{"data":[]}`,
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:     "FindAllAccountAction success with the cursor of the next page",
+			rawQuery: "limit=1",
+			ucMock: mockFindAllAccount{
+				result: []usecase.FindAllAccountOutput{
+					{
+						ID:        "3c096a40-ccba-4b58-93ed-57379ab04680",
+						Name:      "Test",
+						CPF:       "07094564964",
+						Balance:   10,
+						CreatedAt: time.Time{}.String(),
+					},
+				},
+				nextCursor: "MjAyMC0wMS0wMVQwMDowMDowMFp8M2MwOTZhNDAtY2NiYS00YjU4LTkzZWQtNTczNzlhYjA0Njgw",
+			},
+			expectedBody:       `{"data":[{"id":"3c096a40-ccba-4b58-93ed-57379ab04680","name":"Test","cpf":"07094564964","balance":10,"created_at":"0001-01-01 00:00:00 +0000 UTC"}],"next_cursor":"MjAyMC0wMS0wMVQwMDowMDowMFp8M2MwOTZhNDAtY2NiYS00YjU4LTkzZWQtNTczNzlhYjA0Njgw"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "FindAllAccountAction error limit above the maximum page size",
+			rawQuery:           "limit=101",
+			ucMock:             mockFindAllAccount{},
+			expectedBody:       `{"errors":["page size must be between 1 and 100"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindAllAccountAction error invalid limit",
+			rawQuery:           "limit=ten",
+			ucMock:             mockFindAllAccount{},
+			expectedBody:       `{"errors":["page size must be between 1 and 100"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindAllAccountAction error invalid cursor",
+			rawQuery:           "cursor=invalid",
+			ucMock:             mockFindAllAccount{},
+			expectedBody:       `{"errors":["invalid cursor"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
 		{
 			name: "FindAllAccountAction generic error",
 			ucMock: mockFindAllAccount{
@@ -70,6 +112,7 @@ func TestFindAllAccountAction_Execute(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			req, _ := http.NewRequest(http.MethodGet, "/accounts", nil)
+			req.URL.RawQuery = tt.rawQuery
 
 			var (
 				w      = httptest.NewRecorder()
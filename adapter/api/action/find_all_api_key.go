@@ -0,0 +1,35 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type FindAllAPIKeyAction struct {
+	uc  usecase.FindAllAPIKeyUseCase
+	log logger.Logger
+}
+
+func NewFindAllAPIKeyAction(uc usecase.FindAllAPIKeyUseCase, log logger.Logger) FindAllAPIKeyAction {
+	return FindAllAPIKeyAction{
+		uc:  uc,
+		log: log,
+	}
+}
+
+func (a FindAllAPIKeyAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "find_all_api_key"
+
+	output, err := a.uc.Execute(r.Context())
+	if err != nil {
+		handleAPIKeyErr(w, a.log, err, logKey, "error when returning api key list")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success when returning api key list")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
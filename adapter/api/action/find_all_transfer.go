@@ -1,42 +1,187 @@
 package action
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
 
 	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
 	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
 	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/adapter/validator"
+	"github.com/gsabadini/go-clean-architecture/domain"
 	"github.com/gsabadini/go-clean-architecture/usecase"
 )
 
-type FindAllTransferAction struct {
-	uc  usecase.FindAllTransferUseCase
-	log logger.Logger
+var errAccountFilters = errors.New("AccountID cannot be combined with AccountOriginID or AccountDestinationID")
+
+// FindAllTransferAction answers with the page of the outputs of the use case, of /v1 or of /v2
+type FindAllTransferAction[O any] struct {
+	uc        usecase.FindAllTransferUseCaseOf[O]
+	log       logger.Logger
+	validator validator.Validator
 }
 
-func NewFindAllTransferAction(uc usecase.FindAllTransferUseCase, log logger.Logger) FindAllTransferAction {
-	return FindAllTransferAction{
-		uc:  uc,
-		log: log,
+func NewFindAllTransferAction[O any](
+	uc usecase.FindAllTransferUseCaseOf[O],
+	log logger.Logger,
+	v validator.Validator,
+) FindAllTransferAction[O] {
+	return FindAllTransferAction[O]{
+		uc:        uc,
+		log:       log,
+		validator: v,
 	}
 }
 
-func (t FindAllTransferAction) Execute(w http.ResponseWriter, r *http.Request) {
+func (t FindAllTransferAction[O]) Execute(w http.ResponseWriter, r *http.Request) {
 	const logKey = "find_all_transfer"
 
-	output, err := t.uc.Execute(r.Context())
+	var query = r.URL.Query()
+
+	var status = query.Get("status")
+	if status != "" {
+		if _, err := domain.ParseTransferStatus(status); err != nil {
+			logging.NewError(
+				t.log,
+				err,
+				logKey,
+				http.StatusBadRequest,
+			).Log("invalid parameter")
+
+			response.NewError(err, http.StatusBadRequest).Send(w)
+			return
+		}
+	}
+
+	sort, err := domain.ParseSort(query.Get("sort"))
+	if err != nil {
+		logging.NewError(
+			t.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	page, err := parsePageRequest(query, sort)
 	if err != nil {
 		logging.NewError(
 			t.log,
 			err,
 			logKey,
-			http.StatusInternalServerError,
-		).Log("error when returning the transfer list")
+			http.StatusBadRequest,
+		).Log("invalid parameter")
 
-		response.NewError(err, http.StatusInternalServerError).Send(w)
+		response.NewError(err, http.StatusBadRequest).Send(w)
 		return
 	}
+
+	var input, msgs = t.parseInput(query)
+	if msgs = append(msgs, t.validateInput(input)...); len(msgs) > 0 {
+		logging.NewError(
+			t.log,
+			response.ErrInvalidInput,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewErrorMessage(msgs, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	input.Status = status
+	input.Page = page
+
+	output, err := t.uc.Execute(r.Context(), input)
+	if err != nil {
+		switch err {
+		case domain.ErrForbidden:
+			logging.NewError(
+				t.log,
+				err,
+				logKey,
+				http.StatusForbidden,
+			).Log("error when returning the transfer list")
+
+			response.NewError(err, http.StatusForbidden).Send(w)
+			return
+		default:
+			logging.NewError(
+				t.log,
+				err,
+				logKey,
+				http.StatusInternalServerError,
+			).Log("error when returning the transfer list")
+
+			response.NewError(err, http.StatusInternalServerError).Send(w)
+			return
+		}
+	}
 	logging.NewInfo(t.log, logKey, http.StatusOK).Log("success when returning transfer list")
 
 	response.NewSuccess(output, http.StatusOK).Send(w)
 }
+
+// parseInput reads the filters of the listing, the times in RFC 3339 and the amounts as decimal strings such as
+// "1234.56". The parameters that cannot be read are told by their messages
+func (t FindAllTransferAction[O]) parseInput(query url.Values) (usecase.FindAllTransferInput, []string) {
+	var (
+		input = usecase.FindAllTransferInput{
+			AccountID:            query.Get("account_id"),
+			AccountOriginID:      query.Get("account_origin_id"),
+			AccountDestinationID: query.Get("account_destination_id"),
+		}
+		msgs []string
+		err  error
+	)
+
+	if input.From, err = parseOptionalTime(query.Get("from")); err != nil {
+		msgs = append(msgs, "From must be an RFC 3339 time")
+	}
+
+	if input.To, err = parseOptionalTime(query.Get("to")); err != nil {
+		msgs = append(msgs, "To must be an RFC 3339 time")
+	}
+
+	if input.MinAmount, err = parseOptionalAmount(query.Get("min_amount")); err != nil {
+		msgs = append(msgs, "MinAmount "+err.Error())
+	}
+
+	if input.MaxAmount, err = parseOptionalAmount(query.Get("max_amount")); err != nil {
+		msgs = append(msgs, "MaxAmount "+err.Error())
+	}
+
+	return input, msgs
+}
+
+func (t FindAllTransferAction[O]) validateInput(input usecase.FindAllTransferInput) []string {
+	var msgs []string
+
+	if input.AccountID != "" && (input.AccountOriginID != "" || input.AccountDestinationID != "") {
+		msgs = append(msgs, errAccountFilters.Error())
+	}
+
+	if err := t.validator.Validate(input); err != nil {
+		msgs = append(msgs, t.validator.Messages()...)
+	}
+
+	return msgs
+}
+
+// parseOptionalAmount parses a decimal amount into cents, answering zero for an empty one
+func parseOptionalAmount(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	amount, err := domain.ParseMoney(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount.Int64(), nil
+}
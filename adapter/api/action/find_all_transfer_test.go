@@ -10,23 +10,31 @@ import (
 	"time"
 
 	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/validation"
 	"github.com/gsabadini/go-clean-architecture/usecase"
 )
 
 type mockFindAllTransfer struct {
-	result []usecase.FindAllTransferOutput
-	err    error
+	result     []usecase.FindAllTransferOutput
+	nextCursor string
+	err        error
 }
 
-func (m mockFindAllTransfer) Execute(_ context.Context) ([]usecase.FindAllTransferOutput, error) {
-	return m.result, m.err
+func (m mockFindAllTransfer) Execute(
+	_ context.Context,
+	_ usecase.FindAllTransferInput,
+) (usecase.FindAllTransferPageOutput, error) {
+	return usecase.FindAllTransferPageOutput{Transfers: m.result, NextCursor: m.nextCursor}, m.err
 }
 
 func TestTransfer_Index(t *testing.T) {
 	t.Parallel()
 
+	validator, _ := validation.NewValidatorFactory(validation.InstanceGoPlayground)
+
 	tests := []struct {
 		name               string
+		rawQuery           string
 		ucMock             usecase.FindAllTransferUseCase
 		expectedBody       string
 		expectedStatusCode int
@@ -40,23 +48,152 @@ func TestTransfer_Index(t *testing.T) {
 						AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
 						AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04681",
 						Amount:               10,
+						Status:               "completed",
 						CreatedAt:            time.Time{}.String(),
 					},
 				},
 				err: nil,
 			},
-			expectedBody:       `[{"id":"3c096a40-ccba-4b58-93ed-57379ab04679","account_origin_id":"3c096a40-ccba-4b58-93ed-57379ab04680","account_destination_id":"3c096a40-ccba-4b58-93ed-57379ab04681","amount":10,"created_at":"0001-01-01 00:00:00 +0000 UTC"}]`,
+			expectedBody:       `{"data":[{"id":"3c096a40-ccba-4b58-93ed-57379ab04679","account_origin_id":"3c096a40-ccba-4b58-93ed-57379ab04680","account_destination_id":"3c096a40-ccba-4b58-93ed-57379ab04681","amount":10,"status":"completed","created_at":"0001-01-01 00:00:00 +0000 UTC"}]}`,
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:     "FindAllTransferAction success filtered by status",
+			rawQuery: "status=failed",
+			ucMock: mockFindAllTransfer{
+				result: []usecase.FindAllTransferOutput{
+					{
+						ID:                   "3c096a40-ccba-4b58-93ed-57379ab04679",
+						AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
+						AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04681",
+						Amount:               10,
+						Status:               "failed",
+						FailureCode:          "insufficient_balance",
+						CreatedAt:            time.Time{}.String(),
+					},
+				},
+			},
+			expectedBody:       `{"data":[{"id":"3c096a40-ccba-4b58-93ed-57379ab04679","account_origin_id":"3c096a40-ccba-4b58-93ed-57379ab04680","account_destination_id":"3c096a40-ccba-4b58-93ed-57379ab04681","amount":10,"status":"failed","failure_code":"insufficient_balance","created_at":"0001-01-01 00:00:00 +0000 UTC"}]}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "FindAllTransferAction error invalid status",
+			rawQuery:           "status=lost",
+			ucMock:             mockFindAllTransfer{},
+			expectedBody:       `{"errors":["invalid transfer status"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
 		{
 			name: "FindAllTransferAction success empty",
 			ucMock: mockFindAllTransfer{
 				result: []usecase.FindAllTransferOutput{},
 				err:    nil,
 			},
-			expectedBody:       `[]`,
+			expectedBody:       `{"data":[]}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:     "FindAllTransferAction success with the cursor of the next page",
+			rawQuery: "limit=1&cursor=Y3JlYXRlZF9hdHwyMDIwLTAxLTAxVDAwOjAwOjAwWnwzYzA5NmE0MC1jY2JhLTRiNTgtOTNlZC01NzM3OWFiMDQ2Nzg",
+			ucMock: mockFindAllTransfer{
+				result:     []usecase.FindAllTransferOutput{},
+				nextCursor: "Y3JlYXRlZF9hdHwyMDIwLTAxLTAxVDAwOjAwOjAwWnwzYzA5NmE0MC1jY2JhLTRiNTgtOTNlZC01NzM3OWFiMDQ2Nzk",
+			},
+			expectedBody:       `{"data":[],"next_cursor":"Y3JlYXRlZF9hdHwyMDIwLTAxLTAxVDAwOjAwOjAwWnwzYzA5NmE0MC1jY2JhLTRiNTgtOTNlZC01NzM3OWFiMDQ2Nzk"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "FindAllTransferAction error invalid limit",
+			rawQuery:           "limit=-1",
+			ucMock:             mockFindAllTransfer{},
+			expectedBody:       `{"errors":["page size must be between 1 and 100"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindAllTransferAction error invalid cursor",
+			rawQuery:           "cursor=bm9wZQ",
+			ucMock:             mockFindAllTransfer{},
+			expectedBody:       `{"errors":["invalid cursor"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "FindAllTransferAction success filtered and sorted by amount",
+			rawQuery: "account_origin_id=3c096a40-ccba-4b58-93ed-57379ab04680&account_destination_id=3c096a40-ccba-4b58-93ed-57379ab04681" +
+				"&from=2020-01-01T00:00:00Z&to=2020-02-01T00:00:00Z&min_amount=10.50&max_amount=100&sort=-amount",
+			ucMock:             mockFindAllTransfer{result: []usecase.FindAllTransferOutput{}},
+			expectedBody:       `{"data":[]}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "FindAllTransferAction success with a cursor sorted by amount",
+			rawQuery:           "sort=-amount&cursor=LWFtb3VudHwxMDAwfDNjMDk2YTQwLWNjYmEtNGI1OC05M2VkLTU3Mzc5YWIwNDY3OA",
+			ucMock:             mockFindAllTransfer{result: []usecase.FindAllTransferOutput{}},
+			expectedBody:       `{"data":[]}`,
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:               "FindAllTransferAction error invalid sort",
+			rawQuery:           "sort=name",
+			ucMock:             mockFindAllTransfer{},
+			expectedBody:       `{"errors":["invalid sort, use created_at, -created_at, amount or -amount"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindAllTransferAction error cursor of another sort",
+			rawQuery:           "sort=amount&cursor=Y3JlYXRlZF9hdHwyMDIwLTAxLTAxVDAwOjAwOjAwWnwzYzA5NmE0MC1jY2JhLTRiNTgtOTNlZC01NzM3OWFiMDQ2Nzg",
+			ucMock:             mockFindAllTransfer{},
+			expectedBody:       `{"errors":["invalid cursor"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindAllTransferAction error account combined with origin",
+			rawQuery:           "account_id=3c096a40-ccba-4b58-93ed-57379ab04680&account_origin_id=3c096a40-ccba-4b58-93ed-57379ab04681",
+			ucMock:             mockFindAllTransfer{},
+			expectedBody:       `{"errors":["AccountID cannot be combined with AccountOriginID or AccountDestinationID"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindAllTransferAction error invalid account",
+			rawQuery:           "account_id=1",
+			ucMock:             mockFindAllTransfer{},
+			expectedBody:       `{"errors":["AccountID must be a valid version 4 UUID"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindAllTransferAction error origin equals destination",
+			rawQuery:           "account_origin_id=3c096a40-ccba-4b58-93ed-57379ab04680&account_destination_id=3c096a40-ccba-4b58-93ed-57379ab04680",
+			ucMock:             mockFindAllTransfer{},
+			expectedBody:       `{"errors":["AccountDestinationID cannot be equal to AccountOriginID"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindAllTransferAction error period ending before it starts",
+			rawQuery:           "from=2020-02-01T00:00:00Z&to=2020-01-01T00:00:00Z",
+			ucMock:             mockFindAllTransfer{},
+			expectedBody:       `{"errors":["To must be greater than From"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindAllTransferAction error maximum amount below the minimum",
+			rawQuery:           "min_amount=100&max_amount=10.50",
+			ucMock:             mockFindAllTransfer{},
+			expectedBody:       `{"errors":["MaxAmount must be greater than or equal to MinAmount"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindAllTransferAction error negative amount",
+			rawQuery:           "min_amount=-1",
+			ucMock:             mockFindAllTransfer{},
+			expectedBody:       `{"errors":["MinAmount must be 0 or greater"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindAllTransferAction error unreadable time and amount",
+			rawQuery:           "from=yesterday&max_amount=ten",
+			ucMock:             mockFindAllTransfer{},
+			expectedBody:       `{"errors":["From must be an RFC 3339 time","MaxAmount invalid money amount"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
 		{
 			name: "FindAllTransferAction generic error",
 			ucMock: mockFindAllTransfer{
@@ -70,10 +207,11 @@ func TestTransfer_Index(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			req, _ := http.NewRequest(http.MethodGet, "/transfers", nil)
+			req.URL.RawQuery = tt.rawQuery
 
 			var (
 				w      = httptest.NewRecorder()
-				action = NewFindAllTransferAction(tt.ucMock, log.LoggerMock{})
+				action = NewFindAllTransferAction(tt.ucMock, log.LoggerMock{}, validator)
 			)
 
 			action.Execute(w, req)
@@ -0,0 +1,35 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type FindAllWebhookAction struct {
+	uc  usecase.FindAllWebhookUseCase
+	log logger.Logger
+}
+
+func NewFindAllWebhookAction(uc usecase.FindAllWebhookUseCase, log logger.Logger) FindAllWebhookAction {
+	return FindAllWebhookAction{
+		uc:  uc,
+		log: log,
+	}
+}
+
+func (a FindAllWebhookAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "find_all_webhook"
+
+	output, err := a.uc.Execute(r.Context())
+	if err != nil {
+		handleWebhookErr(w, a.log, err, logKey, "error when returning webhook list")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success when returning webhook list")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
@@ -0,0 +1,91 @@
+package action
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+var errInvalidAuditPeriod = errors.New("from and to must be RFC 3339 times, from not after to")
+
+type FindAuditAction struct {
+	uc  usecase.FindAuditUseCase
+	log logger.Logger
+}
+
+func NewFindAuditAction(uc usecase.FindAuditUseCase, log logger.Logger) FindAuditAction {
+	return FindAuditAction{
+		uc:  uc,
+		log: log,
+	}
+}
+
+func (a FindAuditAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "find_audit"
+
+	var query = r.URL.Query()
+
+	from, errFrom := parseOptionalTime(query.Get("from"))
+	to, errTo := parseOptionalTime(query.Get("to"))
+	if errFrom != nil || errTo != nil || (!from.IsZero() && !to.IsZero() && from.After(to)) {
+		var err = errInvalidAuditPeriod
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), usecase.FindAuditInput{
+		Actor:  query.Get("actor"),
+		Target: query.Get("target"),
+		From:   from,
+		To:     to,
+	})
+	if err != nil {
+		switch err {
+		case domain.ErrForbidden:
+			logging.NewError(
+				a.log,
+				err,
+				logKey,
+				http.StatusForbidden,
+			).Log("error when returning the audit trail")
+
+			response.NewError(err, http.StatusForbidden).Send(w)
+			return
+		default:
+			logging.NewError(
+				a.log,
+				err,
+				logKey,
+				http.StatusInternalServerError,
+			).Log("error when returning the audit trail")
+
+			response.NewError(err, http.StatusInternalServerError).Send(w)
+			return
+		}
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success when returning the audit trail")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
+
+// parseOptionalTime parses an RFC 3339 time, answering the zero time for an empty one
+func parseOptionalTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, s)
+}
@@ -0,0 +1,120 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type mockFindAudit struct {
+	result []usecase.FindAuditOutput
+	err    error
+}
+
+func (m mockFindAudit) Execute(_ context.Context, _ usecase.FindAuditInput) ([]usecase.FindAuditOutput, error) {
+	return m.result, m.err
+}
+
+func TestFindAuditAction_Execute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		rawQuery           string
+		ucMock             usecase.FindAuditUseCase
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name:     "FindAuditAction success",
+			rawQuery: "actor=02815517078&from=2021-01-01T00:00:00Z&to=2021-01-02T00:00:00Z",
+			ucMock: mockFindAudit{
+				result: []usecase.FindAuditOutput{
+					{
+						ID:         "5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+						Actor:      "02815517078",
+						Role:       "customer",
+						Action:     "create_account",
+						Targets:    []string{"3c096a40-ccba-4b58-93ed-57379ab04680"},
+						InputHash:  "abc",
+						Payload:    []byte(`{"cpf":"***.155.170-**"}`),
+						Outcome:    "succeeded",
+						RequestID:  "req-1",
+						OccurredAt: "2021-01-01T00:00:00Z",
+					},
+				},
+			},
+			expectedBody:       `[{"id":"5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10","actor":"02815517078","role":"customer","action":"create_account","targets":["3c096a40-ccba-4b58-93ed-57379ab04680"],"input_hash":"abc","payload":{"cpf":"***.155.170-**"},"outcome":"succeeded","request_id":"req-1","occurred_at":"2021-01-01T00:00:00Z"}]`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "FindAuditAction error invalid time",
+			rawQuery:           "from=yesterday",
+			ucMock:             mockFindAudit{},
+			expectedBody:       `{"errors":["from and to must be RFC 3339 times, from not after to"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindAuditAction error from after to",
+			rawQuery:           "from=2021-01-02T00:00:00Z&to=2021-01-01T00:00:00Z",
+			ucMock:             mockFindAudit{},
+			expectedBody:       `{"errors":["from and to must be RFC 3339 times, from not after to"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "FindAuditAction error forbidden",
+			ucMock: mockFindAudit{
+				err: domain.ErrForbidden,
+			},
+			expectedBody:       `{"errors":["caller is not allowed to access this resource"]}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "FindAuditAction generic error",
+			ucMock: mockFindAudit{
+				err: errors.New("error"),
+			},
+			expectedBody:       `{"errors":["error"]}`,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/audit?"+tt.rawQuery, nil)
+
+			var (
+				w      = httptest.NewRecorder()
+				action = NewFindAuditAction(tt.ucMock, log.LoggerMock{})
+			)
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
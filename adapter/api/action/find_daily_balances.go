@@ -0,0 +1,132 @@
+package action
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+const (
+	// dailyBalanceDays is the period answered when from is not given
+	dailyBalanceDays = 30
+	// maxDailyBalanceDays bounds the period of a request
+	maxDailyBalanceDays = 366
+)
+
+var errInvalidDailyBalancePeriod = errors.New("from and to must be dates as 2006-01-02, from not after to, covering at most 366 days")
+
+type FindDailyBalancesAction struct {
+	uc  usecase.FindDailyBalancesUseCase
+	log logger.Logger
+}
+
+func NewFindDailyBalancesAction(uc usecase.FindDailyBalancesUseCase, log logger.Logger) FindDailyBalancesAction {
+	return FindDailyBalancesAction{
+		uc:  uc,
+		log: log,
+	}
+}
+
+func (a FindDailyBalancesAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "find_daily_balances"
+
+	var (
+		query     = r.URL.Query()
+		accountID = query.Get("account_id")
+	)
+
+	if !domain.IsValidUUID(accountID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	from, to, err := parseDailyBalancePeriod(query.Get("from"), query.Get("to"))
+	if err != nil {
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), usecase.FindDailyBalancesInput{
+		AccountID: domain.AccountID(accountID),
+		From:      from,
+		To:        to,
+	})
+	if err != nil {
+		switch err {
+		case domain.ErrForbidden:
+			logging.NewError(
+				a.log,
+				err,
+				logKey,
+				http.StatusForbidden,
+			).Log("error when returning daily balances")
+
+			response.NewError(err, http.StatusForbidden).Send(w)
+			return
+		default:
+			logging.NewError(
+				a.log,
+				err,
+				logKey,
+				http.StatusInternalServerError,
+			).Log("error when returning daily balances")
+
+			response.NewError(err, http.StatusInternalServerError).Send(w)
+			return
+		}
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success when returning daily balances")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
+
+// parseDailyBalancePeriod parses both days, to defaulting to yesterday, the last day closed, and from to the 30 days
+// up to to
+func parseDailyBalancePeriod(fromParam, toParam string) (time.Time, time.Time, error) {
+	var to = domain.StartOfDay(time.Now()).AddDate(0, 0, -1)
+	if toParam != "" {
+		t, err := time.Parse("2006-01-02", toParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, errInvalidDailyBalancePeriod
+		}
+
+		to = t
+	}
+
+	var from = to.AddDate(0, 0, 1-dailyBalanceDays)
+	if fromParam != "" {
+		f, err := time.Parse("2006-01-02", fromParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, errInvalidDailyBalancePeriod
+		}
+
+		from = f
+	}
+
+	if from.After(to) || to.Sub(from) >= maxDailyBalanceDays*24*time.Hour {
+		return time.Time{}, time.Time{}, errInvalidDailyBalancePeriod
+	}
+
+	return from, to, nil
+}
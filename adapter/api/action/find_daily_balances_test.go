@@ -0,0 +1,135 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type mockFindDailyBalances struct {
+	result []usecase.DailyBalanceOutput
+	err    error
+}
+
+func (m mockFindDailyBalances) Execute(_ context.Context, _ usecase.FindDailyBalancesInput) ([]usecase.DailyBalanceOutput, error) {
+	return m.result, m.err
+}
+
+func TestFindDailyBalancesAction_Execute(t *testing.T) {
+	t.Parallel()
+
+	const accountID = "3c096a40-ccba-4b58-93ed-57379ab04680"
+
+	tests := []struct {
+		name               string
+		rawQuery           string
+		ucMock             usecase.FindDailyBalancesUseCase
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name:     "FindDailyBalancesAction success",
+			rawQuery: "account_id=" + accountID + "&from=2021-01-01&to=2021-01-02",
+			ucMock: mockFindDailyBalances{
+				result: []usecase.DailyBalanceOutput{
+					{Date: "2021-01-01", ClosingBalance: 10, Credits: 0, Debits: 0, TransactionCount: 0},
+					{Date: "2021-01-02", ClosingBalance: 13, Credits: 5, Debits: 2, TransactionCount: 3, Interest: 0.01},
+				},
+			},
+			expectedBody:       `[{"date":"2021-01-01","closing_balance":10,"credits":0,"debits":0,"transaction_count":0,"interest":0},{"date":"2021-01-02","closing_balance":13,"credits":5,"debits":2,"transaction_count":3,"interest":0.01}]`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "FindDailyBalancesAction success default period",
+			rawQuery:           "account_id=" + accountID,
+			ucMock:             mockFindDailyBalances{result: []usecase.DailyBalanceOutput{}},
+			expectedBody:       `[]`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "FindDailyBalancesAction error invalid account id",
+			rawQuery:           "account_id=error",
+			ucMock:             mockFindDailyBalances{},
+			expectedBody:       `{"errors":["parameter invalid"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindDailyBalancesAction error invalid date",
+			rawQuery:           "account_id=" + accountID + "&from=2021-01-01T00:00:00Z",
+			ucMock:             mockFindDailyBalances{},
+			expectedBody:       `{"errors":["from and to must be dates as 2006-01-02, from not after to, covering at most 366 days"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindDailyBalancesAction error from after to",
+			rawQuery:           "account_id=" + accountID + "&from=2021-01-02&to=2021-01-01",
+			ucMock:             mockFindDailyBalances{},
+			expectedBody:       `{"errors":["from and to must be dates as 2006-01-02, from not after to, covering at most 366 days"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindDailyBalancesAction error period too long",
+			rawQuery:           "account_id=" + accountID + "&from=2020-01-01&to=2021-01-01",
+			ucMock:             mockFindDailyBalances{},
+			expectedBody:       `{"errors":["from and to must be dates as 2006-01-02, from not after to, covering at most 366 days"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "FindDailyBalancesAction error forbidden",
+			rawQuery: "account_id=" + accountID,
+			ucMock: mockFindDailyBalances{
+				err: domain.ErrForbidden,
+			},
+			expectedBody:       `{"errors":["caller is not allowed to access this resource"]}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:     "FindDailyBalancesAction generic error",
+			rawQuery: "account_id=" + accountID,
+			ucMock: mockFindDailyBalances{
+				err: errors.New("error"),
+			},
+			expectedBody:       `{"errors":["error"]}`,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/accounts/balances/daily?"+tt.rawQuery, nil)
+
+			var (
+				w      = httptest.NewRecorder()
+				action = NewFindDailyBalancesAction(tt.ucMock, log.LoggerMock{})
+			)
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
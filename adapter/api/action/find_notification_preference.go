@@ -0,0 +1,76 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type FindNotificationPreferenceAction struct {
+	uc  usecase.FindNotificationPreferenceUseCase
+	log logger.Logger
+}
+
+func NewFindNotificationPreferenceAction(
+	uc usecase.FindNotificationPreferenceUseCase,
+	log logger.Logger,
+) FindNotificationPreferenceAction {
+	return FindNotificationPreferenceAction{
+		uc:  uc,
+		log: log,
+	}
+}
+
+func (a FindNotificationPreferenceAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "find_notification_preference"
+
+	var accountID = r.URL.Query().Get("account_id")
+	if !domain.IsValidUUID(accountID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), domain.AccountID(accountID))
+	if err != nil {
+		handleNotificationPreferenceErr(w, a.log, err, logKey, "error when returning notification preference")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success when returning notification preference")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
+
+func handleNotificationPreferenceErr(w http.ResponseWriter, log logger.Logger, err error, logKey, logMsg string) {
+	var status int
+	switch err {
+	case domain.ErrAccountNotFound, domain.ErrNotificationPreferenceNotFound:
+		status = http.StatusNotFound
+	case domain.ErrInvalidMoney, domain.ErrMoneyTooManyDecimals, domain.ErrMoneyOverflow, domain.ErrNegativeThreshold:
+		status = http.StatusBadRequest
+	case domain.ErrForbidden:
+		status = http.StatusForbidden
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	logging.NewError(
+		log,
+		err,
+		logKey,
+		status,
+	).Log(logMsg)
+
+	response.NewError(err, status).Send(w)
+}
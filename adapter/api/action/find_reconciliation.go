@@ -0,0 +1,50 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type FindReconciliationAction struct {
+	uc  usecase.FindReconciliationUseCase
+	log logger.Logger
+}
+
+func NewFindReconciliationAction(uc usecase.FindReconciliationUseCase, log logger.Logger) FindReconciliationAction {
+	return FindReconciliationAction{
+		uc:  uc,
+		log: log,
+	}
+}
+
+func (a FindReconciliationAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "find_reconciliation"
+
+	var runID = r.URL.Query().Get("run_id")
+	if !domain.IsValidUUID(runID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), domain.ReconciliationRunID(runID))
+	if err != nil {
+		handleReconciliationErr(w, a.log, err, logKey, "error when returning reconciliation")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success when returning reconciliation")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
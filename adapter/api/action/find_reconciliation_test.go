@@ -0,0 +1,130 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type mockFindReconciliation struct {
+	result usecase.ReconciliationOutput
+	err    error
+}
+
+func (m mockFindReconciliation) Execute(_ context.Context, _ domain.ReconciliationRunID) (usecase.ReconciliationOutput, error) {
+	return m.result, m.err
+}
+
+func TestFindReconciliationAction_Execute(t *testing.T) {
+	t.Parallel()
+
+	const runID = "5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10"
+
+	tests := []struct {
+		name               string
+		runID              string
+		ucMock             usecase.FindReconciliationUseCase
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name:  "FindReconciliationAction success",
+			runID: runID,
+			ucMock: mockFindReconciliation{
+				result: usecase.ReconciliationOutput{
+					ID:              runID,
+					Status:          "completed",
+					Trigger:         "manual",
+					AccountsChecked: 2,
+					Mismatches: []usecase.ReconciliationMismatchOutput{
+						{
+							AccountID:       "3c096a40-ccba-4b58-93ed-57379ab04680",
+							StoredBalance:   9,
+							ExpectedBalance: 10,
+							Difference:      -1,
+						},
+					},
+					Unverifiable: []string{"0db298eb-c8e7-4829-84b7-c1036b4f0791"},
+					RequestedAt:  "2021-01-01T00:00:00Z",
+					StartedAt:    "2021-01-01T00:00:01Z",
+					FinishedAt:   "2021-01-01T00:00:02Z",
+				},
+			},
+			expectedBody:       `{"id":"5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10","status":"completed","trigger":"manual","accounts_checked":2,"mismatches":[{"account_id":"3c096a40-ccba-4b58-93ed-57379ab04680","stored_balance":9,"expected_balance":10,"difference":-1}],"unverifiable":["0db298eb-c8e7-4829-84b7-c1036b4f0791"],"requested_at":"2021-01-01T00:00:00Z","started_at":"2021-01-01T00:00:01Z","finished_at":"2021-01-01T00:00:02Z"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "FindReconciliationAction error invalid run id",
+			runID:              "error",
+			ucMock:             mockFindReconciliation{},
+			expectedBody:       `{"errors":["parameter invalid"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "FindReconciliationAction error not found",
+			runID: runID,
+			ucMock: mockFindReconciliation{
+				err: domain.ErrReconciliationNotFound,
+			},
+			expectedBody:       `{"errors":["reconciliation run not found"]}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:  "FindReconciliationAction error forbidden",
+			runID: runID,
+			ucMock: mockFindReconciliation{
+				err: domain.ErrForbidden,
+			},
+			expectedBody:       `{"errors":["caller is not allowed to access this resource"]}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:  "FindReconciliationAction generic error",
+			runID: runID,
+			ucMock: mockFindReconciliation{
+				err: errors.New("error"),
+			},
+			expectedBody:       `{"errors":["error"]}`,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/admin/reconciliation?run_id="+tt.runID, nil)
+
+			var (
+				w      = httptest.NewRecorder()
+				action = NewFindReconciliationAction(tt.ucMock, log.LoggerMock{})
+			)
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type FindTransferApprovalsAction struct {
+	uc  usecase.FindTransferApprovalsUseCase
+	log logger.Logger
+}
+
+func NewFindTransferApprovalsAction(uc usecase.FindTransferApprovalsUseCase, log logger.Logger) FindTransferApprovalsAction {
+	return FindTransferApprovalsAction{
+		uc:  uc,
+		log: log,
+	}
+}
+
+func (a FindTransferApprovalsAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "find_transfer_approvals"
+
+	var transferID = r.URL.Query().Get("transfer_id")
+	if !domain.IsValidUUID(transferID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), domain.TransferID(transferID))
+	if err != nil {
+		handleTransferDecisionErr(w, a.log, err, logKey, "error when finding transfer approvals")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success when returning transfer approvals")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
@@ -0,0 +1,99 @@
+package action
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+// TransferImportReport writes the lines of the job that did not create their transfer
+type TransferImportReport func(io.Writer, usecase.TransferImportOutput) error
+
+type FindTransferImportAction struct {
+	uc     usecase.FindTransferImportUseCase
+	report TransferImportReport
+	log    logger.Logger
+}
+
+func NewFindTransferImportAction(uc usecase.FindTransferImportUseCase, log logger.Logger) FindTransferImportAction {
+	return FindTransferImportAction{
+		uc:  uc,
+		log: log,
+	}
+}
+
+// NewFindTransferImportErrorsAction answers the error report of the job as a csv file instead of the job
+func NewFindTransferImportErrorsAction(
+	uc usecase.FindTransferImportUseCase,
+	report TransferImportReport,
+	log logger.Logger,
+) FindTransferImportAction {
+	var a = NewFindTransferImportAction(uc, log)
+	a.report = report
+	return a
+}
+
+func (a FindTransferImportAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "find_transfer_import"
+
+	var importID = r.URL.Query().Get("import_id")
+	if !domain.IsValidUUID(importID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), domain.TransferImportID(importID))
+	if err != nil {
+		var status int
+		switch err {
+		case domain.ErrTransferImportNotFound:
+			status = http.StatusNotFound
+		case domain.ErrForbidden:
+			status = http.StatusForbidden
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			status,
+		).Log("error when returning transfer import")
+
+		response.NewError(err, status).Send(w)
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success when returning transfer import")
+
+	if a.report == nil {
+		response.NewSuccess(output, http.StatusOK).Send(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="transfer-import-`+output.ID+`-errors.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	if err := a.report(w, output); err != nil {
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusOK,
+		).Log("error when writing the transfer import report")
+	}
+}
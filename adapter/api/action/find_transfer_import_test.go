@@ -0,0 +1,136 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type mockFindTransferImport struct {
+	result usecase.TransferImportOutput
+	err    error
+}
+
+func (m mockFindTransferImport) Execute(_ context.Context, _ domain.TransferImportID) (usecase.TransferImportOutput, error) {
+	return m.result, m.err
+}
+
+func TestFindTransferImportAction_Execute(t *testing.T) {
+	t.Parallel()
+
+	const importID = "0b4c4b5e-8a0f-4f0c-9f4d-7c2c5f1d9e11"
+
+	var (
+		output = usecase.TransferImportOutput{
+			ID:        importID,
+			Format:    "csv",
+			Status:    "completed",
+			Lines:     1,
+			Failed:    1,
+			Results:   []usecase.TransferImportLineOutput{{Line: 2, Status: "failed", Errors: []string{"error"}}},
+			CreatedAt: "2021-01-01T00:00:00Z",
+		}
+		report = func(w io.Writer, output usecase.TransferImportOutput) error {
+			_, err := io.WriteString(w, "line,status\n2,"+output.Results[0].Status)
+			return err
+		}
+	)
+
+	tests := []struct {
+		name               string
+		importID           string
+		report             TransferImportReport
+		ucMock             usecase.FindTransferImportUseCase
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name:               "FindTransferImportAction success",
+			importID:           importID,
+			ucMock:             mockFindTransferImport{result: output},
+			expectedBody:       `{"id":"0b4c4b5e-8a0f-4f0c-9f4d-7c2c5f1d9e11","format":"csv","status":"completed","lines":1,"pending":0,"created":0,"failed":1,"invalid":0,"results":[{"line":2,"account_origin_id":"","account_destination_id":"","amount":0,"status":"failed","errors":["error"]}],"created_at":"2021-01-01T00:00:00Z"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "FindTransferImportAction success error report",
+			importID:           importID,
+			report:             report,
+			ucMock:             mockFindTransferImport{result: output},
+			expectedBody:       "line,status\n2,failed",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "FindTransferImportAction error invalid import id",
+			importID:           "error",
+			ucMock:             mockFindTransferImport{},
+			expectedBody:       `{"errors":["parameter invalid"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "FindTransferImportAction error not found",
+			importID:           importID,
+			report:             report,
+			ucMock:             mockFindTransferImport{err: domain.ErrTransferImportNotFound},
+			expectedBody:       `{"errors":["transfer import not found"]}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "FindTransferImportAction error forbidden",
+			importID:           importID,
+			ucMock:             mockFindTransferImport{err: domain.ErrForbidden},
+			expectedBody:       `{"errors":["caller is not allowed to access this resource"]}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "FindTransferImportAction generic error",
+			importID:           importID,
+			ucMock:             mockFindTransferImport{err: errors.New("error")},
+			expectedBody:       `{"errors":["error"]}`,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/v1/transfers/imports?import_id="+tt.importID, nil)
+
+			var (
+				w      = httptest.NewRecorder()
+				action = NewFindTransferImportAction(tt.ucMock, log.LoggerMock{})
+			)
+
+			if tt.report != nil {
+				action = NewFindTransferImportErrorsAction(tt.ucMock, tt.report, log.LoggerMock{})
+			}
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type FindWebhookDeliveriesAction struct {
+	uc  usecase.FindWebhookDeliveriesUseCase
+	log logger.Logger
+}
+
+func NewFindWebhookDeliveriesAction(uc usecase.FindWebhookDeliveriesUseCase, log logger.Logger) FindWebhookDeliveriesAction {
+	return FindWebhookDeliveriesAction{
+		uc:  uc,
+		log: log,
+	}
+}
+
+func (a FindWebhookDeliveriesAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "find_webhook_deliveries"
+
+	var webhookID = r.URL.Query().Get("webhook_id")
+	if !domain.IsValidUUID(webhookID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), domain.WebhookID(webhookID))
+	if err != nil {
+		handleWebhookErr(w, a.log, err, logKey, "error when returning webhook deliveries")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success when returning webhook deliveries")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
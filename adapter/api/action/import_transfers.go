@@ -0,0 +1,127 @@
+package action
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/adapter/transferfile"
+	"github.com/gsabadini/go-clean-architecture/adapter/validator"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+// maxTransferImportSize bounds the file uploaded, which is read whole to be hashed
+const maxTransferImportSize = 10 << 20
+
+var errTransferImportTooLarge = errors.New("the file is larger than 10MB")
+
+type ImportTransfersAction struct {
+	uc        usecase.ImportTransfersUseCase
+	log       logger.Logger
+	validator validator.Validator
+}
+
+func NewImportTransfersAction(uc usecase.ImportTransfersUseCase, log logger.Logger, v validator.Validator) ImportTransfersAction {
+	return ImportTransfersAction{
+		uc:        uc,
+		log:       log,
+		validator: v,
+	}
+}
+
+// Execute reads the file sent as the body of the request, csv or pain001 as given by the format parameter or else by
+// its Content-Type. The lines are validated here and the transfers created later by the import job, so the answer is
+// the job with the result of each line read
+func (a ImportTransfersAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "import_transfers"
+
+	var (
+		query  = r.URL.Query()
+		format = transferImportFormat(query.Get("format"), r.Header.Get("Content-Type"))
+	)
+
+	content, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxTransferImportSize))
+	if err != nil {
+		var status = http.StatusBadRequest
+		if errors.As(err, new(*http.MaxBytesError)) {
+			err, status = errTransferImportTooLarge, http.StatusRequestEntityTooLarge
+		}
+
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			status,
+		).Log("error when reading the file")
+
+		response.NewError(err, status).Send(w)
+		return
+	}
+	defer r.Body.Close()
+
+	lines, err := transferfile.Read(format, content, a.validator)
+	if err != nil {
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid file")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), usecase.ImportTransfersInput{
+		FileName: query.Get("file_name"),
+		Format:   format.String(),
+		Content:  content,
+		Lines:    lines,
+	})
+	if err != nil {
+		var status int
+		switch err {
+		case domain.ErrDuplicateTransferImport:
+			status = http.StatusConflict
+		case domain.ErrForbidden:
+			status = http.StatusForbidden
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			status,
+		).Log("error when importing transfers")
+
+		response.NewError(err, status).Send(w)
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusAccepted).Log("success importing transfers")
+
+	response.NewSuccess(output, http.StatusAccepted).Send(w)
+}
+
+// transferImportFormat is the format asked for, or the one of the media type of the body
+func transferImportFormat(param, contentType string) domain.TransferImportFormat {
+	if param != "" {
+		return domain.TransferImportFormat(param)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return domain.TransferImportCSV
+	case "application/xml", "text/xml":
+		return domain.TransferImportPain001
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,145 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/validation"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+// mockImportTransfers answers a pending job with the lines it was given
+type mockImportTransfers struct {
+	err error
+}
+
+func (m mockImportTransfers) Execute(_ context.Context, input usecase.ImportTransfersInput) (usecase.TransferImportOutput, error) {
+	var output = usecase.TransferImportOutput{
+		ID:        "0b4c4b5e-8a0f-4f0c-9f4d-7c2c5f1d9e11",
+		FileName:  input.FileName,
+		Format:    input.Format,
+		Status:    "pending",
+		Lines:     len(input.Lines),
+		Results:   []usecase.TransferImportLineOutput{},
+		CreatedAt: "2021-01-01T00:00:00Z",
+	}
+
+	for _, line := range input.Lines {
+		if len(line.Errors) > 0 {
+			output.Invalid++
+		} else {
+			output.Pending++
+		}
+	}
+
+	return output, m.err
+}
+
+func TestImportTransfersAction_Execute(t *testing.T) {
+	t.Parallel()
+
+	validator, _ := validation.NewValidatorFactory(validation.InstanceGoPlayground)
+
+	const file = "account_origin_id,account_destination_id,amount\n" +
+		"3c096a40-ccba-4b58-93ed-57379ab04680,a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c,10.50\n" +
+		"3c096a40-ccba-4b58-93ed-57379ab04680,a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c,-1\n"
+
+	tests := []struct {
+		name               string
+		query              string
+		contentType        string
+		body               string
+		ucMock             usecase.ImportTransfersUseCase
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name:               "ImportTransfersAction success",
+			query:              "?file_name=payroll.csv",
+			contentType:        "text/csv; charset=utf-8",
+			body:               file,
+			ucMock:             mockImportTransfers{},
+			expectedBody:       `{"id":"0b4c4b5e-8a0f-4f0c-9f4d-7c2c5f1d9e11","file_name":"payroll.csv","format":"csv","status":"pending","lines":2,"pending":1,"created":0,"failed":0,"invalid":1,"results":[],"created_at":"2021-01-01T00:00:00Z"}`,
+			expectedStatusCode: http.StatusAccepted,
+		},
+		{
+			name:               "ImportTransfersAction error unknown format",
+			contentType:        "application/json",
+			body:               file,
+			ucMock:             mockImportTransfers{},
+			expectedBody:       `{"errors":["format must be csv or pain001"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "ImportTransfersAction error invalid file",
+			query:              "?format=pain001",
+			body:               file,
+			ucMock:             mockImportTransfers{},
+			expectedBody:       `{"errors":["the file is not a pain.001 customer credit transfer initiation"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "ImportTransfersAction error file already imported",
+			query:              "?format=csv",
+			body:               file,
+			ucMock:             mockImportTransfers{err: domain.ErrDuplicateTransferImport},
+			expectedBody:       `{"errors":["file already imported"]}`,
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:               "ImportTransfersAction error forbidden",
+			query:              "?format=csv",
+			body:               file,
+			ucMock:             mockImportTransfers{err: domain.ErrForbidden},
+			expectedBody:       `{"errors":["caller is not allowed to access this resource"]}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "ImportTransfersAction generic error",
+			query:              "?format=csv",
+			body:               file,
+			ucMock:             mockImportTransfers{err: errors.New("error")},
+			expectedBody:       `{"errors":["error"]}`,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "/v1/transfers/imports"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+
+			var (
+				w      = httptest.NewRecorder()
+				action = NewImportTransfersAction(tt.ucMock, log.LoggerMock{}, validator)
+			)
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
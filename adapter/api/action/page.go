@@ -0,0 +1,22 @@
+package action
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// parsePageRequest reads the limit and cursor query parameters of a listing answered a page at a time, in the order of
+// the sort
+func parsePageRequest(query url.Values, sort domain.Sort) (domain.PageRequest, error) {
+	var limit int
+	if s := query.Get("limit"); s != "" {
+		var err error
+		if limit, err = strconv.Atoi(s); err != nil || limit == 0 {
+			return domain.PageRequest{}, domain.ErrInvalidPageSize
+		}
+	}
+
+	return domain.NewPageRequest(limit, sort, query.Get("cursor"))
+}
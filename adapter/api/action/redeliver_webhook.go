@@ -0,0 +1,54 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type RedeliverWebhookAction struct {
+	uc  usecase.RedeliverWebhookUseCase
+	log logger.Logger
+}
+
+func NewRedeliverWebhookAction(uc usecase.RedeliverWebhookUseCase, log logger.Logger) RedeliverWebhookAction {
+	return RedeliverWebhookAction{
+		uc:  uc,
+		log: log,
+	}
+}
+
+func (a RedeliverWebhookAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "redeliver_webhook"
+
+	var (
+		webhookID  = r.URL.Query().Get("webhook_id")
+		deliveryID = r.URL.Query().Get("delivery_id")
+	)
+
+	if !domain.IsValidUUID(webhookID) || !domain.IsValidUUID(deliveryID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), domain.WebhookID(webhookID), domain.WebhookDeliveryID(deliveryID))
+	if err != nil {
+		handleWebhookErr(w, a.log, err, logKey, "error when redelivering webhook")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusAccepted).Log("success scheduling webhook redelivery")
+
+	response.NewSuccess(output, http.StatusAccepted).Send(w)
+}
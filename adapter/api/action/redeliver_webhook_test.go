@@ -0,0 +1,154 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type mockRedeliverWebhook struct {
+	result usecase.WebhookDeliveryOutput
+	err    error
+}
+
+func (m mockRedeliverWebhook) Execute(
+	_ context.Context,
+	_ domain.WebhookID,
+	_ domain.WebhookDeliveryID,
+) (usecase.WebhookDeliveryOutput, error) {
+	return m.result, m.err
+}
+
+func TestRedeliverWebhookAction_Execute(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		webhookID  string
+		deliveryID string
+	}
+
+	var validArgs = args{
+		webhookID:  "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+		deliveryID: "5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+	}
+
+	tests := []struct {
+		name               string
+		args               args
+		ucMock             usecase.RedeliverWebhookUseCase
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name: "RedeliverWebhookAction success",
+			args: validArgs,
+			ucMock: mockRedeliverWebhook{
+				result: usecase.WebhookDeliveryOutput{
+					ID:            "5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+					WebhookID:     "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+					EventID:       "a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10",
+					EventName:     "transfer.completed",
+					Status:        "pending",
+					LastError:     "webhook answered with status 500",
+					NextAttemptAt: "2021-01-02T00:00:00Z",
+					CreatedAt:     "2021-01-01T00:00:00Z",
+					Payload:       []byte(`{"id":"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10"}`),
+				},
+			},
+			expectedBody:       `{"id":"5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10","webhook_id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","event_id":"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10","event_name":"transfer.completed","status":"pending","attempts":0,"last_error":"webhook answered with status 500","next_attempt_at":"2021-01-02T00:00:00Z","created_at":"2021-01-01T00:00:00Z","payload":{"id":"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10"}}`,
+			expectedStatusCode: http.StatusAccepted,
+		},
+		{
+			name: "RedeliverWebhookAction error delivery pending",
+			args: validArgs,
+			ucMock: mockRedeliverWebhook{
+				err: domain.ErrWebhookDeliveryPending,
+			},
+			expectedBody:       `{"errors":["webhook delivery is still pending"]}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "RedeliverWebhookAction error webhook disabled",
+			args: validArgs,
+			ucMock: mockRedeliverWebhook{
+				err: domain.ErrWebhookDisabled,
+			},
+			expectedBody:       `{"errors":["webhook is disabled"]}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "RedeliverWebhookAction error not found",
+			args: validArgs,
+			ucMock: mockRedeliverWebhook{
+				err: domain.ErrWebhookDeliveryNotFound,
+			},
+			expectedBody:       `{"errors":["webhook delivery not found"]}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "RedeliverWebhookAction generic error",
+			args: validArgs,
+			ucMock: mockRedeliverWebhook{
+				err: errors.New("error"),
+			},
+			expectedBody:       `{"errors":["error"]}`,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "RedeliverWebhookAction error parameter invalid",
+			args: args{
+				webhookID:  "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+				deliveryID: "error",
+			},
+			ucMock:             mockRedeliverWebhook{},
+			expectedBody:       `{"errors":["parameter invalid"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri := fmt.Sprintf("/webhooks/%s/deliveries/%s/redeliver", tt.args.webhookID, tt.args.deliveryID)
+			req, _ := http.NewRequest(http.MethodPost, uri, nil)
+
+			q := req.URL.Query()
+			q.Add("webhook_id", tt.args.webhookID)
+			q.Add("delivery_id", tt.args.deliveryID)
+			req.URL.RawQuery = q.Encode()
+
+			var (
+				w      = httptest.NewRecorder()
+				action = NewRedeliverWebhookAction(tt.ucMock, log.LoggerMock{})
+			)
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
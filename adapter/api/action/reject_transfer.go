@@ -0,0 +1,87 @@
+package action
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/adapter/validator"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+// RejectTransferAction answers with the output of the use case, of /v1 or of /v2
+type RejectTransferAction[O any] struct {
+	uc        usecase.RejectTransferUseCaseOf[O]
+	log       logger.Logger
+	validator validator.Validator
+}
+
+func NewRejectTransferAction[O any](
+	uc usecase.RejectTransferUseCaseOf[O],
+	log logger.Logger,
+	v validator.Validator,
+) RejectTransferAction[O] {
+	return RejectTransferAction[O]{
+		uc:        uc,
+		log:       log,
+		validator: v,
+	}
+}
+
+func (a RejectTransferAction[O]) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "reject_transfer"
+
+	var transferID = r.URL.Query().Get("transfer_id")
+	if !domain.IsValidUUID(transferID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	// The reason is optional, so an empty body is a rejection without one
+	var input usecase.RejectTransferInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && err != io.EOF {
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("error when decoding json")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := a.validator.Validate(input); err != nil {
+		logging.NewError(
+			a.log,
+			response.ErrInvalidInput,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid input")
+
+		response.NewErrorMessage(a.validator.Messages(), http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), domain.TransferID(transferID), input)
+	if err != nil {
+		handleTransferDecisionErr(w, a.log, err, logKey, "error when rejecting transfer")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success rejecting transfer")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
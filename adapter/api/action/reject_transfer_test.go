@@ -0,0 +1,143 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/validation"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type mockRejectTransfer struct {
+	result usecase.CreateTransferOutput
+	err    error
+}
+
+func (m mockRejectTransfer) Execute(_ context.Context, _ domain.TransferID, _ usecase.RejectTransferInput) (usecase.CreateTransferOutput, error) {
+	return m.result, m.err
+}
+
+func TestRejectTransferAction_Execute(t *testing.T) {
+	t.Parallel()
+
+	validator, _ := validation.NewValidatorFactory(validation.InstanceGoPlayground)
+
+	type args struct {
+		transferID string
+		body       []byte
+	}
+
+	tests := []struct {
+		name               string
+		args               args
+		ucMock             usecase.RejectTransferUseCase
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name: "RejectTransferAction success",
+			args: args{
+				transferID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+				body:       []byte(`{"reason": "suspected fraud"}`),
+			},
+			ucMock: mockRejectTransfer{
+				result: usecase.CreateTransferOutput{
+					ID:                   "3c096a40-ccba-4b58-93ed-57379ab04680",
+					AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04681",
+					AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682",
+					Amount:               20000,
+					Status:               "rejected",
+					CreatedAt:            "2020-01-01T00:00:00Z",
+				},
+			},
+			expectedBody:       `{"id":"3c096a40-ccba-4b58-93ed-57379ab04680","account_origin_id":"3c096a40-ccba-4b58-93ed-57379ab04681","account_destination_id":"3c096a40-ccba-4b58-93ed-57379ab04682","amount":20000,"status":"rejected","created_at":"2020-01-01T00:00:00Z"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "RejectTransferAction success without reason",
+			args: args{
+				transferID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+			},
+			ucMock: mockRejectTransfer{
+				result: usecase.CreateTransferOutput{Status: "rejected"},
+			},
+			expectedBody:       `{"id":"","account_origin_id":"","account_destination_id":"","amount":0,"status":"rejected","created_at":""}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "RejectTransferAction error approver is requester",
+			args: args{
+				transferID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+				body:       []byte(`{"reason": "suspected fraud"}`),
+			},
+			ucMock: mockRejectTransfer{
+				err: domain.ErrApproverIsRequester,
+			},
+			expectedBody:       `{"errors":["transfer must be decided by a different user than its requester"]}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "RejectTransferAction error invalid JSON",
+			args: args{
+				transferID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+				body:       []byte(`{"reason": }`),
+			},
+			ucMock:             mockRejectTransfer{},
+			expectedBody:       `{"errors":["invalid character '}' looking for beginning of value"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "RejectTransferAction error parameter invalid",
+			args: args{
+				transferID: "error",
+			},
+			ucMock:             mockRejectTransfer{},
+			expectedBody:       `{"errors":["parameter invalid"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri := fmt.Sprintf("/v1/transfers/%s/reject", tt.args.transferID)
+			req, _ := http.NewRequest(http.MethodPost, uri, bytes.NewReader(tt.args.body))
+
+			q := req.URL.Query()
+			q.Add("transfer_id", tt.args.transferID)
+			req.URL.RawQuery = q.Encode()
+
+			var (
+				w      = httptest.NewRecorder()
+				action = NewRejectTransferAction(tt.ucMock, log.LoggerMock{}, validator)
+			)
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
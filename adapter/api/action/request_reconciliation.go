@@ -0,0 +1,57 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type RequestReconciliationAction struct {
+	uc  usecase.RequestReconciliationUseCase
+	log logger.Logger
+}
+
+func NewRequestReconciliationAction(uc usecase.RequestReconciliationUseCase, log logger.Logger) RequestReconciliationAction {
+	return RequestReconciliationAction{
+		uc:  uc,
+		log: log,
+	}
+}
+
+func (a RequestReconciliationAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "request_reconciliation"
+
+	output, err := a.uc.Execute(r.Context())
+	if err != nil {
+		handleReconciliationErr(w, a.log, err, logKey, "error when requesting reconciliation")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusAccepted).Log("success requesting reconciliation")
+
+	response.NewSuccess(output, http.StatusAccepted).Send(w)
+}
+
+func handleReconciliationErr(w http.ResponseWriter, log logger.Logger, err error, logKey, logMsg string) {
+	var status int
+	switch err {
+	case domain.ErrReconciliationNotFound:
+		status = http.StatusNotFound
+	case domain.ErrForbidden:
+		status = http.StatusForbidden
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	logging.NewError(
+		log,
+		err,
+		logKey,
+		status,
+	).Log(logMsg)
+
+	response.NewError(err, status).Send(w)
+}
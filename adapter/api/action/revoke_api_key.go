@@ -0,0 +1,73 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type RevokeAPIKeyAction struct {
+	uc  usecase.RevokeAPIKeyUseCase
+	log logger.Logger
+}
+
+func NewRevokeAPIKeyAction(uc usecase.RevokeAPIKeyUseCase, log logger.Logger) RevokeAPIKeyAction {
+	return RevokeAPIKeyAction{
+		uc:  uc,
+		log: log,
+	}
+}
+
+func (a RevokeAPIKeyAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "revoke_api_key"
+
+	var apiKeyID = r.URL.Query().Get("api_key_id")
+	if !domain.IsValidUUID(apiKeyID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), domain.APIKeyID(apiKeyID))
+	if err != nil {
+		handleAPIKeyErr(w, a.log, err, logKey, "error when revoking api key")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success revoking api key")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
+
+func handleAPIKeyErr(w http.ResponseWriter, log logger.Logger, err error, logKey, logMsg string) {
+	var status int
+	switch err {
+	case domain.ErrAPIKeyNotFound:
+		status = http.StatusNotFound
+	case domain.ErrAPIKeyInactive:
+		status = http.StatusUnprocessableEntity
+	case domain.ErrForbidden, domain.ErrScopeNotHeld:
+		status = http.StatusForbidden
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	logging.NewError(
+		log,
+		err,
+		logKey,
+		status,
+	).Log(logMsg)
+
+	response.NewError(err, status).Send(w)
+}
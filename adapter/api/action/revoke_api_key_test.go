@@ -0,0 +1,138 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type mockRevokeAPIKey struct {
+	result usecase.FindAllAPIKeyOutput
+	err    error
+}
+
+func (m mockRevokeAPIKey) Execute(_ context.Context, _ domain.APIKeyID) (usecase.FindAllAPIKeyOutput, error) {
+	return m.result, m.err
+}
+
+func TestRevokeAPIKeyAction_Execute(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		apiKeyID string
+	}
+
+	tests := []struct {
+		name               string
+		args               args
+		ucMock             usecase.RevokeAPIKeyUseCase
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name: "RevokeAPIKeyAction success",
+			args: args{
+				apiKeyID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+			},
+			ucMock: mockRevokeAPIKey{
+				result: usecase.FindAllAPIKeyOutput{
+					ID:        "3c096a40-ccba-4b58-93ed-57379ab04680",
+					Name:      "erp",
+					Prefix:    "abcd1234",
+					Scopes:    []string{"accounts:read"},
+					RevokedAt: "2021-01-01T00:00:00Z",
+					CreatedAt: "2020-01-01T00:00:00Z",
+				},
+			},
+			expectedBody:       `{"id":"3c096a40-ccba-4b58-93ed-57379ab04680","name":"erp","prefix":"abcd1234","scopes":["accounts:read"],"revoked_at":"2021-01-01T00:00:00Z","created_at":"2020-01-01T00:00:00Z"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "RevokeAPIKeyAction error not found",
+			args: args{
+				apiKeyID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+			},
+			ucMock: mockRevokeAPIKey{
+				err: domain.ErrAPIKeyNotFound,
+			},
+			expectedBody:       `{"errors":["api key not found"]}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "RevokeAPIKeyAction error forbidden",
+			args: args{
+				apiKeyID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+			},
+			ucMock: mockRevokeAPIKey{
+				err: domain.ErrForbidden,
+			},
+			expectedBody:       `{"errors":["caller is not allowed to access this resource"]}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "RevokeAPIKeyAction generic error",
+			args: args{
+				apiKeyID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+			},
+			ucMock: mockRevokeAPIKey{
+				err: errors.New("error"),
+			},
+			expectedBody:       `{"errors":["error"]}`,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "RevokeAPIKeyAction error parameter invalid",
+			args: args{
+				apiKeyID: "error",
+			},
+			ucMock:             mockRevokeAPIKey{},
+			expectedBody:       `{"errors":["parameter invalid"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri := fmt.Sprintf("/admin/api-keys/%s/revoke", tt.args.apiKeyID)
+			req, _ := http.NewRequest(http.MethodPost, uri, nil)
+
+			q := req.URL.Query()
+			q.Add("api_key_id", tt.args.apiKeyID)
+			req.URL.RawQuery = q.Encode()
+
+			var (
+				w      = httptest.NewRecorder()
+				action = NewRevokeAPIKeyAction(tt.ucMock, log.LoggerMock{})
+			)
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type RotateAPIKeyAction struct {
+	uc  usecase.RotateAPIKeyUseCase
+	log logger.Logger
+}
+
+func NewRotateAPIKeyAction(uc usecase.RotateAPIKeyUseCase, log logger.Logger) RotateAPIKeyAction {
+	return RotateAPIKeyAction{
+		uc:  uc,
+		log: log,
+	}
+}
+
+func (a RotateAPIKeyAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "rotate_api_key"
+
+	var apiKeyID = r.URL.Query().Get("api_key_id")
+	if !domain.IsValidUUID(apiKeyID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	output, err := a.uc.Execute(r.Context(), domain.APIKeyID(apiKeyID))
+	if err != nil {
+		handleAPIKeyErr(w, a.log, err, logKey, "error when rotating api key")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusCreated).Log("success rotating api key")
+
+	response.NewSuccess(output, http.StatusCreated).Send(w)
+}
@@ -0,0 +1,86 @@
+package action
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/adapter/validator"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type UpdateNotificationPreferenceAction struct {
+	uc        usecase.UpdateNotificationPreferenceUseCase
+	log       logger.Logger
+	validator validator.Validator
+}
+
+func NewUpdateNotificationPreferenceAction(
+	uc usecase.UpdateNotificationPreferenceUseCase,
+	log logger.Logger,
+	v validator.Validator,
+) UpdateNotificationPreferenceAction {
+	return UpdateNotificationPreferenceAction{
+		uc:        uc,
+		log:       log,
+		validator: v,
+	}
+}
+
+func (a UpdateNotificationPreferenceAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "update_notification_preference"
+
+	var accountID = r.URL.Query().Get("account_id")
+	if !domain.IsValidUUID(accountID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	var input usecase.UpdateNotificationPreferenceInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("error when decoding json")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := a.validator.Validate(input); err != nil {
+		logging.NewError(
+			a.log,
+			response.ErrInvalidInput,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid input")
+
+		response.NewErrorMessage(a.validator.Messages(), http.StatusBadRequest).Send(w)
+		return
+	}
+
+	input.AccountID = accountID
+
+	output, err := a.uc.Execute(r.Context(), input)
+	if err != nil {
+		handleNotificationPreferenceErr(w, a.log, err, logKey, "error when updating notification preference")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success updating notification preference")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
@@ -0,0 +1,188 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/validation"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type mockUpdateNotificationPreference struct {
+	result usecase.NotificationPreferenceOutput
+	err    error
+}
+
+func (m mockUpdateNotificationPreference) Execute(
+	_ context.Context,
+	_ usecase.UpdateNotificationPreferenceInput,
+) (usecase.NotificationPreferenceOutput, error) {
+	return m.result, m.err
+}
+
+func TestUpdateNotificationPreferenceAction_Execute(t *testing.T) {
+	t.Parallel()
+
+	validator, _ := validation.NewValidatorFactory(validation.InstanceGoPlayground)
+
+	type args struct {
+		accountID  string
+		rawPayload []byte
+	}
+
+	const accountID = "3c096a40-ccba-4b58-93ed-57379ab04680"
+
+	tests := []struct {
+		name               string
+		args               args
+		ucMock             usecase.UpdateNotificationPreferenceUseCase
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name: "UpdateNotificationPreferenceAction success",
+			args: args{
+				accountID:  accountID,
+				rawPayload: []byte(`{"email": "test@example.com", "transfer_received": true, "low_balance_threshold": "100.00"}`),
+			},
+			ucMock: mockUpdateNotificationPreference{
+				result: usecase.NotificationPreferenceOutput{
+					AccountID:           accountID,
+					Email:               "test@example.com",
+					Locale:              "pt-BR",
+					TransferReceived:    true,
+					LowBalanceThreshold: "100.00",
+					UpdatedAt:           "2021-01-01T00:00:00Z",
+				},
+			},
+			expectedBody:       `{"account_id":"3c096a40-ccba-4b58-93ed-57379ab04680","email":"test@example.com","locale":"pt-BR","transfer_received":true,"low_balance_threshold":"100.00","updated_at":"2021-01-01T00:00:00Z"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "UpdateNotificationPreferenceAction error invalid email",
+			args: args{
+				accountID:  accountID,
+				rawPayload: []byte(`{"email": "test"}`),
+			},
+			ucMock:             mockUpdateNotificationPreference{},
+			expectedBody:       `{"errors":["Email must be a valid email address"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "UpdateNotificationPreferenceAction error invalid locale",
+			args: args{
+				accountID:  accountID,
+				rawPayload: []byte(`{"email": "test@example.com", "locale": "fr-FR"}`),
+			},
+			ucMock:             mockUpdateNotificationPreference{},
+			expectedBody:       `{"errors":["Locale must be one of [pt-BR en-US]"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "UpdateNotificationPreferenceAction error invalid threshold",
+			args: args{
+				accountID:  accountID,
+				rawPayload: []byte(`{"email": "test@example.com", "low_balance_threshold": "ten"}`),
+			},
+			ucMock: mockUpdateNotificationPreference{
+				err: domain.ErrInvalidMoney,
+			},
+			expectedBody:       `{"errors":["invalid money amount"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "UpdateNotificationPreferenceAction error forbidden",
+			args: args{
+				accountID:  accountID,
+				rawPayload: []byte(`{"email": "test@example.com"}`),
+			},
+			ucMock: mockUpdateNotificationPreference{
+				err: domain.ErrForbidden,
+			},
+			expectedBody:       `{"errors":["caller is not allowed to access this resource"]}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "UpdateNotificationPreferenceAction error account not found",
+			args: args{
+				accountID:  accountID,
+				rawPayload: []byte(`{"email": "test@example.com"}`),
+			},
+			ucMock: mockUpdateNotificationPreference{
+				err: domain.ErrAccountNotFound,
+			},
+			expectedBody:       `{"errors":["account not found"]}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "UpdateNotificationPreferenceAction generic error",
+			args: args{
+				accountID:  accountID,
+				rawPayload: []byte(`{"email": "test@example.com"}`),
+			},
+			ucMock: mockUpdateNotificationPreference{
+				err: errors.New("error"),
+			},
+			expectedBody:       `{"errors":["error"]}`,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "UpdateNotificationPreferenceAction error parameter invalid",
+			args: args{
+				accountID:  "error",
+				rawPayload: []byte(`{"email": "test@example.com"}`),
+			},
+			ucMock:             mockUpdateNotificationPreference{},
+			expectedBody:       `{"errors":["parameter invalid"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(
+				http.MethodPut,
+				fmt.Sprintf("/accounts/%s/notification-preferences", tt.args.accountID),
+				bytes.NewReader(tt.args.rawPayload),
+			)
+
+			q := req.URL.Query()
+			q.Add("account_id", tt.args.accountID)
+			req.URL.RawQuery = q.Encode()
+
+			var (
+				w      = httptest.NewRecorder()
+				action = NewUpdateNotificationPreferenceAction(tt.ucMock, log.LoggerMock{}, validator)
+			)
+
+			action.Execute(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package action
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/adapter/validator"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type UpdateWebhookAction struct {
+	uc        usecase.UpdateWebhookUseCase
+	log       logger.Logger
+	validator validator.Validator
+}
+
+func NewUpdateWebhookAction(uc usecase.UpdateWebhookUseCase, log logger.Logger, v validator.Validator) UpdateWebhookAction {
+	return UpdateWebhookAction{
+		uc:        uc,
+		log:       log,
+		validator: v,
+	}
+}
+
+func (a UpdateWebhookAction) Execute(w http.ResponseWriter, r *http.Request) {
+	const logKey = "update_webhook"
+
+	var webhookID = r.URL.Query().Get("webhook_id")
+	if !domain.IsValidUUID(webhookID) {
+		var err = response.ErrParameterInvalid
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid parameter")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+
+	var input usecase.UpdateWebhookInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusBadRequest,
+		).Log("error when decoding json")
+
+		response.NewError(err, http.StatusBadRequest).Send(w)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := a.validator.Validate(input); err != nil {
+		logging.NewError(
+			a.log,
+			response.ErrInvalidInput,
+			logKey,
+			http.StatusBadRequest,
+		).Log("invalid input")
+
+		response.NewErrorMessage(a.validator.Messages(), http.StatusBadRequest).Send(w)
+		return
+	}
+
+	input.ID = webhookID
+
+	output, err := a.uc.Execute(r.Context(), input)
+	if err != nil {
+		handleWebhookErr(w, a.log, err, logKey, "error when updating webhook")
+		return
+	}
+	logging.NewInfo(a.log, logKey, http.StatusOK).Log("success updating webhook")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
@@ -0,0 +1,77 @@
+package action
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+// moneyInputV2 is how /v2 payloads carry money: an exact decimal string and its currency
+type moneyInputV2 struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func (m moneyInputV2) cents() (int64, error) {
+	if _, err := domain.ParseCurrency(m.Currency); err != nil {
+		return 0, err
+	}
+
+	amount, err := domain.ParseMoney(m.Amount)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount.Int64(), nil
+}
+
+// decodeCreateTransferInputV2 reads a /v2 transfer, whose amount is a decimal string such as "1234.56"
+func decodeCreateTransferInputV2(body io.Reader) (usecase.CreateTransferInput, error) {
+	var input struct {
+		AccountOriginID      string `json:"account_origin_id"`
+		AccountDestinationID string `json:"account_destination_id"`
+		moneyInputV2
+	}
+
+	if err := json.NewDecoder(body).Decode(&input); err != nil {
+		return usecase.CreateTransferInput{}, err
+	}
+
+	amount, err := input.cents()
+	if err != nil {
+		return usecase.CreateTransferInput{}, err
+	}
+
+	return usecase.CreateTransferInput{
+		AccountOriginID:      input.AccountOriginID,
+		AccountDestinationID: input.AccountDestinationID,
+		Amount:               amount,
+	}, nil
+}
+
+// decodeCreateAccountInputV2 reads a /v2 account, whose initial balance is a decimal string such as "1234.56"
+func decodeCreateAccountInputV2(body io.Reader) (usecase.CreateAccountInput, error) {
+	var input struct {
+		Name     string `json:"name"`
+		CPF      string `json:"cpf"`
+		Balance  string `json:"balance"`
+		Currency string `json:"currency"`
+	}
+
+	if err := json.NewDecoder(body).Decode(&input); err != nil {
+		return usecase.CreateAccountInput{}, err
+	}
+
+	balance, err := moneyInputV2{Amount: input.Balance, Currency: input.Currency}.cents()
+	if err != nil {
+		return usecase.CreateAccountInput{}, err
+	}
+
+	return usecase.CreateAccountInput{
+		Name:    input.Name,
+		CPF:     input.CPF,
+		Balance: balance,
+	}, nil
+}
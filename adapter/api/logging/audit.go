@@ -0,0 +1,35 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type auditAlerter struct {
+	log logger.Logger
+}
+
+// NewAuditAlerter logs the audit records that could not be stored as errors, with their masked payload, so they can be
+// alerted on and appended again
+func NewAuditAlerter(log logger.Logger) domain.AuditAlerter {
+	return auditAlerter{log: log}
+}
+
+func (a auditAlerter) Alert(_ context.Context, record domain.AuditRecord, err error) {
+	a.log.WithFields(logger.Fields{
+		"key":         "audit",
+		"error":       err.Error(),
+		"audit_id":    record.ID(),
+		"actor":       record.Actor(),
+		"role":        record.Role().String(),
+		"action":      record.Action(),
+		"targets":     record.Targets(),
+		"input_hash":  record.InputHash(),
+		"payload":     record.Payload(),
+		"outcome":     record.Outcome().String(),
+		"request_id":  record.RequestID(),
+		"occurred_at": record.OccurredAt(),
+	}).Errorf("error appending audit record")
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/auth"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+const (
+	schemeBearer = "Bearer"
+	schemeAPIKey = "ApiKey"
+)
+
+type Authentication struct {
+	log      logger.Logger
+	verifier auth.TokenVerifier
+	apiKeys  usecase.AuthenticateAPIKeyUseCase
+}
+
+func NewAuthentication(
+	log logger.Logger,
+	verifier auth.TokenVerifier,
+	apiKeys usecase.AuthenticateAPIKeyUseCase,
+) Authentication {
+	return Authentication{
+		log:      log,
+		verifier: verifier,
+		apiKeys:  apiKeys,
+	}
+}
+
+func (a Authentication) Execute(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	const logKey = "authentication_middleware"
+
+	var (
+		principal domain.Principal
+		err       error
+	)
+
+	scheme, credentials := authorizationHeader(r)
+	switch {
+	case strings.EqualFold(scheme, schemeBearer):
+		principal, err = a.verifier.Verify(credentials)
+	case strings.EqualFold(scheme, schemeAPIKey):
+		principal, err = a.apiKeys.Execute(r.Context(), credentials)
+	default:
+		err = auth.ErrInvalidToken
+	}
+
+	if err != nil {
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusUnauthorized,
+		).Log("error authenticating request")
+
+		w.Header().Set("WWW-Authenticate", schemeBearer)
+		response.NewError(auth.ErrInvalidToken, http.StatusUnauthorized).Send(w)
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(domain.ContextWithPrincipal(r.Context(), principal)))
+}
+
+func authorizationHeader(r *http.Request) (string, string) {
+	var parts = strings.SplitN(strings.TrimSpace(r.Header.Get("Authorization")), " ", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+
+	return parts[0], strings.TrimSpace(parts[1])
+}
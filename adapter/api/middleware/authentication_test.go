@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/auth"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+)
+
+type stubTokenVerifier struct {
+	token     string
+	principal domain.Principal
+}
+
+func (s stubTokenVerifier) Verify(token string) (domain.Principal, error) {
+	if token != s.token {
+		return domain.Principal{}, auth.ErrInvalidToken
+	}
+
+	return s.principal, nil
+}
+
+type stubAuthenticateAPIKey struct {
+	key       string
+	principal domain.Principal
+}
+
+func (s stubAuthenticateAPIKey) Execute(_ context.Context, key string) (domain.Principal, error) {
+	if key != s.key {
+		return domain.Principal{}, errors.New("api key not found")
+	}
+
+	return s.principal, nil
+}
+
+func TestAuthentication_Execute(t *testing.T) {
+	t.Parallel()
+
+	var (
+		customer = domain.NewPrincipal("07094564964", domain.RoleCustomer, domain.ScopeAccountsRead)
+		client   = domain.NewClientPrincipal("client", domain.ScopeAccountsRead)
+		authn    = NewAuthentication(
+			log.LoggerMock{},
+			stubTokenVerifier{token: "valid", principal: customer},
+			stubAuthenticateAPIKey{key: "key", principal: client},
+		)
+	)
+
+	tests := []struct {
+		name               string
+		header             string
+		expectedStatusCode int
+		expectedSubject    string
+	}{
+		{
+			name:               "Valid bearer token",
+			header:             "Bearer valid",
+			expectedStatusCode: http.StatusOK,
+			expectedSubject:    customer.Subject(),
+		},
+		{
+			name:               "Scheme is case insensitive",
+			header:             "bearer valid",
+			expectedStatusCode: http.StatusOK,
+			expectedSubject:    customer.Subject(),
+		},
+		{
+			name:               "Valid api key",
+			header:             "ApiKey key",
+			expectedStatusCode: http.StatusOK,
+			expectedSubject:    client.Subject(),
+		},
+		{
+			name:               "Missing header",
+			header:             "",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Header without credentials",
+			header:             "Bearer",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Unknown scheme",
+			header:             "Basic dXNlcjpwYXNz",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Invalid bearer token",
+			header:             "Bearer invalid",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Unknown api key",
+			header:             "ApiKey unknown",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequest(http.MethodGet, "/v1/accounts", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			var (
+				rr      = httptest.NewRecorder()
+				subject string
+			)
+
+			authn.Execute(rr, req, func(w http.ResponseWriter, r *http.Request) {
+				principal, _ := domain.PrincipalFromContext(r.Context())
+				subject = principal.Subject()
+				w.WriteHeader(http.StatusOK)
+			})
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					rr.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			if subject != tt.expectedSubject {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					subject,
+					tt.expectedSubject,
+				)
+			}
+
+			if rr.Code == http.StatusUnauthorized && rr.Header().Get("WWW-Authenticate") != schemeBearer {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					rr.Header().Get("WWW-Authenticate"),
+					schemeBearer,
+				)
+			}
+		})
+	}
+}
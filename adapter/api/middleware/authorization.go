@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/response"
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+// Authorization rejects principals whose role or scopes are not allowed to run the operation behind the route
+type Authorization struct {
+	log logger.Logger
+	op  usecase.Operation
+}
+
+func NewAuthorization(log logger.Logger, op usecase.Operation) Authorization {
+	return Authorization{
+		log: log,
+		op:  op,
+	}
+}
+
+func (a Authorization) Execute(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	const logKey = "authorization_middleware"
+
+	principal, _, err := usecase.Authorize(r.Context(), a.op)
+	if err != nil {
+		logging.NewError(
+			a.log,
+			err,
+			logKey,
+			http.StatusForbidden,
+		).Log(fmt.Sprintf("role '%s' denied on operation '%s'", principal.Role(), a.op))
+
+		response.NewError(err, http.StatusForbidden).Send(w)
+		return
+	}
+
+	next.ServeHTTP(w, r)
+}
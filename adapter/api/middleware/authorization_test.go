@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+func TestAuthorization_Execute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		op                 usecase.Operation
+		principal          *domain.Principal
+		expectedStatusCode int
+		expectedNext       bool
+	}{
+		{
+			name:               "Role and scope allowed",
+			op:                 usecase.OpFindAudit,
+			principal:          principalOf(domain.NewPrincipal("admin", domain.RoleAdmin, domain.ScopeAuditRead)),
+			expectedStatusCode: http.StatusOK,
+			expectedNext:       true,
+		},
+		{
+			name:               "Role denied",
+			op:                 usecase.OpFindAudit,
+			principal:          principalOf(domain.NewPrincipal("07094564964", domain.RoleCustomer, domain.ScopeAuditRead)),
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Scope not held",
+			op:                 usecase.OpFindAudit,
+			principal:          principalOf(domain.NewPrincipal("admin", domain.RoleAdmin, domain.ScopeAccountsRead)),
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Operation without policy",
+			op:                 usecase.Operation("unknown"),
+			principal:          principalOf(domain.NewPrincipal("admin", domain.RoleAdmin, domain.ScopeAuditRead)),
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Request without principal",
+			op:                 usecase.OpFindAudit,
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequest(http.MethodGet, "/v1/audit", nil)
+			if tt.principal != nil {
+				req = req.WithContext(domain.ContextWithPrincipal(req.Context(), *tt.principal))
+			}
+
+			var (
+				rr     = httptest.NewRecorder()
+				called bool
+			)
+
+			NewAuthorization(log.LoggerMock{}, tt.op).Execute(rr, req, func(w http.ResponseWriter, _ *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			})
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					rr.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			if called != tt.expectedNext {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					called,
+					tt.expectedNext,
+				)
+			}
+		})
+	}
+}
+
+func principalOf(p domain.Principal) *domain.Principal {
+	return &p
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
 	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
 
 	"github.com/pkg/errors"
 	"github.com/urfave/negroni"
@@ -45,6 +46,7 @@ func (l Logger) Execute(w http.ResponseWriter, r *http.Request, next http.Handle
 
 	l.log.WithFields(logger.Fields{
 		"key":         requestKey,
+		"request_id":  domain.RequestIDFromContext(r.Context()),
 		"payload":     body,
 		"url":         r.URL.Path,
 		"http_method": r.Method,
@@ -56,6 +58,7 @@ func (l Logger) Execute(w http.ResponseWriter, r *http.Request, next http.Handle
 	res := w.(negroni.ResponseWriter)
 	l.log.WithFields(logger.Fields{
 		"key":           responseKey,
+		"request_id":    domain.RequestIDFromContext(r.Context()),
 		"url":           r.URL.Path,
 		"http_method":   r.Method,
 		"http_status":   res.Status(),
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+const (
+	headerRequestID = "X-Request-Id"
+
+	maxRequestIDLength = 128
+)
+
+// RequestID carries the ID of the request to the use cases and echoes it in the response. The ID sent by the caller
+// is kept when it is printable and short enough, otherwise a new one is generated
+type RequestID struct{}
+
+func NewRequestID() RequestID {
+	return RequestID{}
+}
+
+func (rid RequestID) Execute(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	var ID = r.Header.Get(headerRequestID)
+	if !validRequestID(ID) {
+		ID = domain.NewUUID()
+	}
+
+	w.Header().Set(headerRequestID, ID)
+	next.ServeHTTP(w, r.WithContext(domain.ContextWithRequestID(r.Context(), ID)))
+}
+
+func validRequestID(ID string) bool {
+	if ID == "" || len(ID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range ID {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid or expired token")
+)
+
+type TokenVerifier interface {
+	Verify(string) (domain.Principal, error)
+}
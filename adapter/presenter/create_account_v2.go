@@ -0,0 +1,30 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type createAccountPresenterV2 struct{}
+
+// NewCreateAccountPresenterV2 presents the account with its balance as a decimal string
+func NewCreateAccountPresenterV2() usecase.CreateAccountPresenterV2 {
+	return createAccountPresenterV2{}
+}
+
+func (c createAccountPresenterV2) Output(account domain.Account) usecase.AccountOutputV2 {
+	return accountOutputV2(account)
+}
+
+func accountOutputV2(account domain.Account) usecase.AccountOutputV2 {
+	return usecase.AccountOutputV2{
+		ID:        account.ID().String(),
+		Name:      account.Name(),
+		CPF:       account.CPF(),
+		Balance:   account.Balance().String(),
+		Currency:  domain.CurrencyBRL.String(),
+		CreatedAt: account.CreatedAt().Format(time.RFC3339),
+	}
+}
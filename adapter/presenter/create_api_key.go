@@ -0,0 +1,43 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type createAPIKeyPresenter struct{}
+
+func NewCreateAPIKeyPresenter() usecase.CreateAPIKeyPresenter {
+	return createAPIKeyPresenter{}
+}
+
+func (c createAPIKeyPresenter) Output(apiKey domain.APIKey, key string) usecase.CreateAPIKeyOutput {
+	return usecase.CreateAPIKeyOutput{
+		ID:        apiKey.ID().String(),
+		Name:      apiKey.Name(),
+		Key:       key,
+		Prefix:    apiKey.Prefix(),
+		Scopes:    scopesOutput(apiKey.Scopes()),
+		ExpiresAt: optionalTime(apiKey.ExpiresAt()),
+		CreatedAt: apiKey.CreatedAt().Format(time.RFC3339),
+	}
+}
+
+func scopesOutput(scopes []domain.Scope) []string {
+	var o = make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		o = append(o, s.String())
+	}
+
+	return o
+}
+
+func optionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
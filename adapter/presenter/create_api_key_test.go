@@ -0,0 +1,58 @@
+package presenter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+func Test_createAPIKeyPresenter_Output(t *testing.T) {
+	type args struct {
+		apiKey domain.APIKey
+		key    string
+	}
+	tests := []struct {
+		name string
+		args args
+		want usecase.CreateAPIKeyOutput
+	}{
+		{
+			name: "Create api key output",
+			args: args{
+				apiKey: domain.NewAPIKey(
+					"3c096a40-ccba-4b58-93ed-57379ab04680",
+					"client",
+					"erp",
+					"abcd1234",
+					"hash",
+					[]domain.Scope{domain.ScopeAccountsRead, domain.ScopeTransfersWrite},
+					time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+					time.Time{},
+					time.Time{},
+					time.Time{},
+				),
+				key: "abcd1234.secret",
+			},
+			want: usecase.CreateAPIKeyOutput{
+				ID:        "3c096a40-ccba-4b58-93ed-57379ab04680",
+				Name:      "erp",
+				Key:       "abcd1234.secret",
+				Prefix:    "abcd1234",
+				Scopes:    []string{"accounts:read", "transfers:write"},
+				ExpiresAt: "2030-01-01T00:00:00Z",
+				CreatedAt: "0001-01-01T00:00:00Z",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pre := NewCreateAPIKeyPresenter()
+			if got := pre.Output(tt.args.apiKey, tt.args.key); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ func (c createTransferPresenter) Output(transfer domain.Transfer) usecase.Create
 		AccountOriginID:      transfer.AccountOriginID().String(),
 		AccountDestinationID: transfer.AccountDestinationID().String(),
 		Amount:               transfer.Amount().Float64(),
+		Status:               transfer.Status().String(),
 		CreatedAt:            transfer.CreatedAt().Format(time.RFC3339),
 	}
 }
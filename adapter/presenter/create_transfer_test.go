@@ -26,6 +26,7 @@ func Test_createTransferPresenter_Output(t *testing.T) {
 					"3c096a40-ccba-4b58-93ed-57379ab04681",
 					"3c096a40-ccba-4b58-93ed-57379ab04682",
 					1000,
+					domain.TransferStatusCompleted,
 					time.Time{},
 				),
 			},
@@ -34,6 +35,7 @@ func Test_createTransferPresenter_Output(t *testing.T) {
 				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04681",
 				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682",
 				Amount:               10,
+				Status:               "completed",
 				CreatedAt:            "0001-01-01T00:00:00Z",
 			},
 		},
@@ -0,0 +1,33 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type createTransferPresenterV2 struct{}
+
+// NewCreateTransferPresenterV2 presents the transfer with its amount as a decimal string. It also serves the approval
+// and the rejection, which answer with the transfer too
+func NewCreateTransferPresenterV2() usecase.CreateTransferPresenterV2 {
+	return createTransferPresenterV2{}
+}
+
+func (c createTransferPresenterV2) Output(transfer domain.Transfer) usecase.TransferOutputV2 {
+	return transferOutputV2(transfer)
+}
+
+func transferOutputV2(transfer domain.Transfer) usecase.TransferOutputV2 {
+	return usecase.TransferOutputV2{
+		ID:                   transfer.ID().String(),
+		AccountOriginID:      transfer.AccountOriginID().String(),
+		AccountDestinationID: transfer.AccountDestinationID().String(),
+		Amount:               transfer.Amount().String(),
+		Currency:             domain.CurrencyBRL.String(),
+		Status:               transfer.Status().String(),
+		FailureCode:          transfer.FailureCode().String(),
+		CreatedAt:            transfer.CreatedAt().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,52 @@
+package presenter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+func Test_createTransferPresenterV2_Output(t *testing.T) {
+	type args struct {
+		transfer domain.Transfer
+	}
+	tests := []struct {
+		name string
+		args args
+		want usecase.TransferOutputV2
+	}{
+		{
+			name: "Create transfer output v2",
+			args: args{
+				transfer: domain.NewTransfer(
+					"3c096a40-ccba-4b58-93ed-57379ab04680",
+					"3c096a40-ccba-4b58-93ed-57379ab04681",
+					"3c096a40-ccba-4b58-93ed-57379ab04682",
+					1001,
+					domain.TransferStatusCompleted,
+					time.Time{},
+				),
+			},
+			want: usecase.TransferOutputV2{
+				ID:                   "3c096a40-ccba-4b58-93ed-57379ab04680",
+				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04681",
+				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682",
+				Amount:               "10.01",
+				Currency:             "BRL",
+				Status:               "completed",
+				CreatedAt:            "0001-01-01T00:00:00Z",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pre := NewCreateTransferPresenterV2()
+			if got := pre.Output(tt.args.transfer); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type createWebhookPresenter struct{}
+
+func NewCreateWebhookPresenter() usecase.CreateWebhookPresenter {
+	return createWebhookPresenter{}
+}
+
+func (c createWebhookPresenter) Output(webhook domain.Webhook) usecase.CreateWebhookOutput {
+	return usecase.CreateWebhookOutput{
+		ID:         webhook.ID().String(),
+		URL:        webhook.URL(),
+		Events:     eventsOutput(webhook.Events()),
+		AccountIDs: accountIDsOutput(webhook.AccountIDs()),
+		Secret:     webhook.Secret(),
+		CreatedAt:  webhook.CreatedAt().Format(time.RFC3339),
+	}
+}
+
+func accountIDsOutput(IDs []domain.AccountID) []string {
+	var o = make([]string, 0, len(IDs))
+	for _, ID := range IDs {
+		o = append(o, ID.String())
+	}
+
+	return o
+}
+
+func eventsOutput(events []domain.EventName) []string {
+	var o = make([]string, 0, len(events))
+	for _, e := range events {
+		o = append(o, e.String())
+	}
+
+	return o
+}
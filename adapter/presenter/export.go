@@ -0,0 +1,37 @@
+package presenter
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// ErrExportAccountRequired is returned by the exporters of statement files when the transfers are not narrowed to an
+// account
+var ErrExportAccountRequired = errors.New("the export format needs the transfers of a single account")
+
+const (
+	directionCredit = "credit"
+	directionDebit  = "debit"
+)
+
+// direction tells whether the transfer moved money into or out of the account. Transfers between other accounts, or
+// listed without an account, have none
+func direction(accountID domain.AccountID, transfer domain.Transfer) string {
+	switch {
+	case accountID == "":
+		return ""
+	case transfer.AccountDestinationID() == accountID:
+		return directionCredit
+	case transfer.AccountOriginID() == accountID:
+		return directionDebit
+	default:
+		return ""
+	}
+}
+
+// lastDay is the day of the last instant before the end of the period
+func lastDay(to time.Time) time.Time {
+	return to.Add(-time.Nanosecond)
+}
@@ -0,0 +1,125 @@
+package presenter
+
+import (
+	"io"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+const camt053Header = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+`
+
+const (
+	camtCredit = "CRDT"
+	camtDebit  = "DBIT"
+)
+
+type camt053Exporter struct {
+	x      *xmlWriter
+	header usecase.ExportHeader
+}
+
+// NewCamt053Exporter writes an ISO 20022 camt.053 bank to customer statement. Completed transfers are booked
+// entries, the ones still to be decided are pending and the others are informative
+func NewCamt053Exporter(w io.Writer) usecase.TransferExporter {
+	return &camt053Exporter{x: newXMLWriter(w)}
+}
+
+func (c *camt053Exporter) Begin(header usecase.ExportHeader) error {
+	if header.AccountID == "" {
+		return ErrExportAccountRequired
+	}
+
+	c.header = header
+
+	var (
+		ID         = escape(header.AccountID.String() + "-" + header.GeneratedAt.UTC().Format("20060102150405"))
+		generated  = camtTime(header.GeneratedAt)
+		closingDay = lastDay(header.To)
+	)
+
+	c.x.printf(camt053Header)
+	c.x.printf("<BkToCstmrStmt>\n")
+	c.x.printf("<GrpHdr><MsgId>%s</MsgId><CreDtTm>%s</CreDtTm></GrpHdr>\n", ID, generated)
+	c.x.printf("<Stmt><Id>%s</Id><CreDtTm>%s</CreDtTm>", ID, generated)
+	c.x.printf("<FrToDt><FrDtTm>%s</FrDtTm><ToDtTm>%s</ToDtTm></FrToDt>\n", camtTime(header.From), camtTime(header.To))
+	c.x.printf(
+		"<Acct><Id><Othr><Id>%s</Id></Othr></Id><Ccy>%s</Ccy></Acct>\n",
+		escape(header.AccountID.String()),
+		domain.CurrencyBRL,
+	)
+
+	if header.Statement {
+		c.balance("OPBD", header.OpeningBalance, header.From)
+	}
+	c.balance("CLBD", header.ClosingBalance, closingDay)
+
+	return c.x.err
+}
+
+func (c *camt053Exporter) Write(transfer domain.Transfer) error {
+	var indicator = camtCredit
+	if direction(c.header.AccountID, transfer) == directionDebit {
+		indicator = camtDebit
+	}
+
+	c.x.printf("<Ntry><NtryRef>%s</NtryRef>", escape(transfer.ID().String()))
+	c.x.printf("<Amt Ccy=\"%s\">%s</Amt><CdtDbtInd>%s</CdtDbtInd>", domain.CurrencyBRL, transfer.Amount().String(), indicator)
+	c.x.printf("<Sts>%s</Sts>", camtStatus(transfer.Status()))
+	c.x.printf("<BookgDt><DtTm>%s</DtTm></BookgDt>", camtTime(transfer.CreatedAt()))
+	c.x.printf("<AcctSvcrRef>%s</AcctSvcrRef>", escape(transfer.ID().String()))
+	c.x.printf("<BkTxCd><Prtry><Cd>TRANSFER</Cd></Prtry></BkTxCd>")
+	c.x.printf("<NtryDtls><TxDtls><Refs><EndToEndId>%s</EndToEndId></Refs>", escape(transfer.ID().String()))
+	c.x.printf(
+		"<RltdPties><DbtrAcct><Id><Othr><Id>%s</Id></Othr></Id></DbtrAcct><CdtrAcct><Id><Othr><Id>%s</Id></Othr></Id></CdtrAcct></RltdPties>",
+		escape(transfer.AccountOriginID().String()),
+		escape(transfer.AccountDestinationID().String()),
+	)
+	c.x.printf("</TxDtls></NtryDtls></Ntry>\n")
+
+	return c.x.err
+}
+
+func (c *camt053Exporter) End() error {
+	c.x.printf("</Stmt>\n")
+	c.x.printf("</BkToCstmrStmt>\n")
+	c.x.printf("</Document>\n")
+
+	return c.x.flush()
+}
+
+// balance writes the amount unsigned, with the side it stands on
+func (c *camt053Exporter) balance(code string, amount domain.Money, day time.Time) {
+	var indicator = camtCredit
+	if amount < 0 {
+		indicator = camtDebit
+		amount = -amount
+	}
+
+	c.x.printf(
+		"<Bal><Tp><CdOrPrtry><Cd>%s</Cd></CdOrPrtry></Tp><Amt Ccy=\"%s\">%s</Amt><CdtDbtInd>%s</CdtDbtInd><Dt><Dt>%s</Dt></Dt></Bal>\n",
+		code,
+		domain.CurrencyBRL,
+		amount.String(),
+		indicator,
+		day.UTC().Format("2006-01-02"),
+	)
+}
+
+func camtStatus(status domain.TransferStatus) string {
+	switch status {
+	case domain.TransferStatusCompleted:
+		return "BOOK"
+	case domain.TransferStatusPending, domain.TransferStatusHeld, domain.TransferStatusPendingApproval:
+		return "PDNG"
+	default:
+		return "INFO"
+	}
+}
+
+func camtTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
@@ -0,0 +1,54 @@
+package presenter
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+var csvColumns = []string{
+	"id",
+	"created_at",
+	"account_origin_id",
+	"account_destination_id",
+	"direction",
+	"amount",
+	"status",
+	"failure_code",
+}
+
+type csvExporter struct {
+	w         *csv.Writer
+	accountID domain.AccountID
+}
+
+// NewCSVExporter writes one row per transfer. The rows are buffered in small chunks only, never the whole file
+func NewCSVExporter(w io.Writer) usecase.TransferExporter {
+	return &csvExporter{w: csv.NewWriter(w)}
+}
+
+func (c *csvExporter) Begin(header usecase.ExportHeader) error {
+	c.accountID = header.AccountID
+	return c.w.Write(csvColumns)
+}
+
+func (c *csvExporter) Write(transfer domain.Transfer) error {
+	return c.w.Write([]string{
+		transfer.ID().String(),
+		transfer.CreatedAt().UTC().Format(time.RFC3339),
+		transfer.AccountOriginID().String(),
+		transfer.AccountDestinationID().String(),
+		direction(c.accountID, transfer),
+		transfer.Amount().String(),
+		transfer.Status().String(),
+		transfer.FailureCode().String(),
+	})
+}
+
+func (c *csvExporter) End() error {
+	c.w.Flush()
+	return c.w.Error()
+}
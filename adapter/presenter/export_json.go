@@ -0,0 +1,103 @@
+package presenter
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type (
+	jsonExporter struct {
+		w     *bufio.Writer
+		first bool
+		err   error
+	}
+
+	exportHeaderJSON struct {
+		AccountID      string   `json:"account_id,omitempty"`
+		From           string   `json:"from,omitempty"`
+		To             string   `json:"to,omitempty"`
+		OpeningBalance *float64 `json:"opening_balance,omitempty"`
+		ClosingBalance *float64 `json:"closing_balance,omitempty"`
+		GeneratedAt    string   `json:"generated_at"`
+	}
+)
+
+// NewJSONExporter writes the header fields and then the transfers, as in the listing, one at a time
+func NewJSONExporter(w io.Writer) usecase.TransferExporter {
+	return &jsonExporter{w: bufio.NewWriter(w), first: true}
+}
+
+func (j *jsonExporter) Begin(header usecase.ExportHeader) error {
+	var h = exportHeaderJSON{
+		AccountID:   header.AccountID.String(),
+		GeneratedAt: header.GeneratedAt.UTC().Format(time.RFC3339),
+	}
+
+	if header.AccountID != "" {
+		var closing = header.ClosingBalance.Float64()
+		h.ClosingBalance = &closing
+	}
+
+	if header.Statement {
+		var opening = header.OpeningBalance.Float64()
+		h.OpeningBalance = &opening
+		h.From = header.From.UTC().Format("2006-01-02")
+		h.To = lastDay(header.To).UTC().Format("2006-01-02")
+	}
+
+	b, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+
+	// The object is left open for the transfers
+	j.write(b[:len(b)-1])
+	j.write([]byte(`,"transfers":[`))
+
+	return j.err
+}
+
+func (j *jsonExporter) Write(transfer domain.Transfer) error {
+	b, err := json.Marshal(usecase.FindAllTransferOutput{
+		ID:                   transfer.ID().String(),
+		AccountOriginID:      transfer.AccountOriginID().String(),
+		AccountDestinationID: transfer.AccountDestinationID().String(),
+		Amount:               transfer.Amount().Float64(),
+		Status:               transfer.Status().String(),
+		FailureCode:          transfer.FailureCode().String(),
+		CreatedAt:            transfer.CreatedAt().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	if !j.first {
+		j.write([]byte(","))
+	}
+	j.first = false
+	j.write(b)
+
+	return j.err
+}
+
+func (j *jsonExporter) End() error {
+	j.write([]byte("]}\n"))
+	if j.err != nil {
+		return j.err
+	}
+
+	return j.w.Flush()
+}
+
+func (j *jsonExporter) write(b []byte) {
+	if j.err != nil {
+		return
+	}
+
+	_, j.err = j.w.Write(b)
+}
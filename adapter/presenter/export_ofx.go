@@ -0,0 +1,93 @@
+package presenter
+
+import (
+	"io"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+`
+
+type ofxExporter struct {
+	x      *xmlWriter
+	header usecase.ExportHeader
+}
+
+// NewOFXExporter writes an OFX 2.2 bank statement. Only completed transfers are posted transactions, the others are
+// left out
+func NewOFXExporter(w io.Writer) usecase.TransferExporter {
+	return &ofxExporter{x: newXMLWriter(w)}
+}
+
+func (o *ofxExporter) Begin(header usecase.ExportHeader) error {
+	if header.AccountID == "" {
+		return ErrExportAccountRequired
+	}
+
+	o.header = header
+
+	o.x.printf(ofxHeader)
+	o.x.printf("<OFX>\n")
+	o.x.printf("<SIGNONMSGSRSV1><SONRS>")
+	o.x.printf("<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>")
+	o.x.printf("<DTSERVER>%s</DTSERVER><LANGUAGE>POR</LANGUAGE>", ofxTime(header.GeneratedAt))
+	o.x.printf("</SONRS></SIGNONMSGSRSV1>\n")
+	o.x.printf("<BANKMSGSRSV1><STMTTRNRS><TRNUID>0</TRNUID>")
+	o.x.printf("<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n")
+	o.x.printf("<STMTRS><CURDEF>%s</CURDEF>", domain.CurrencyBRL)
+	o.x.printf("<BANKACCTFROM><ACCTID>%s</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>\n", escape(header.AccountID.String()))
+	o.x.printf("<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>\n", ofxTime(header.From), ofxTime(header.To))
+
+	return o.x.err
+}
+
+func (o *ofxExporter) Write(transfer domain.Transfer) error {
+	if transfer.Status() != domain.TransferStatusCompleted {
+		return nil
+	}
+
+	var (
+		trnType = "CREDIT"
+		amount  = transfer.Amount().String()
+		memo    = "Transfer from " + transfer.AccountOriginID().String()
+	)
+
+	if direction(o.header.AccountID, transfer) == directionDebit {
+		trnType = "DEBIT"
+		amount = "-" + amount
+		memo = "Transfer to " + transfer.AccountDestinationID().String()
+	}
+
+	o.x.printf(
+		"<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%s</FITID><MEMO>%s</MEMO></STMTTRN>\n",
+		trnType,
+		ofxTime(transfer.CreatedAt()),
+		amount,
+		escape(transfer.ID().String()),
+		escape(memo),
+	)
+
+	return o.x.err
+}
+
+func (o *ofxExporter) End() error {
+	o.x.printf("</BANKTRANLIST>\n")
+	o.x.printf(
+		"<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>\n",
+		o.header.ClosingBalance.String(),
+		ofxTime(o.header.To),
+	)
+	o.x.printf("</STMTRS></STMTTRNRS></BANKMSGSRSV1>\n")
+	o.x.printf("</OFX>\n")
+
+	return o.x.flush()
+}
+
+// ofxTime formats the instant in UTC, as OFX dates carry their offset
+func ofxTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.000") + "[0:GMT]"
+}
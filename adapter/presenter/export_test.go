@@ -0,0 +1,188 @@
+package presenter
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+const exportAccountID = domain.AccountID("3c096a40-ccba-4b58-93ed-57379ab04680")
+
+var (
+	exportHeader = usecase.ExportHeader{
+		AccountID:      exportAccountID,
+		From:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+		Statement:      true,
+		OpeningBalance: 1000,
+		ClosingBalance: 1200,
+		GeneratedAt:    time.Date(2021, 1, 4, 8, 0, 0, 0, time.UTC),
+	}
+
+	exportTransfers = []domain.Transfer{
+		domain.NewTransfer(
+			"7bd5d7e2-5a5b-4ef0-8e8a-3f3a3b0a9d8b",
+			"b5a2e2d2-4b1f-4e4b-9d4b-9a7b6c2d1e0f",
+			exportAccountID,
+			350,
+			domain.TransferStatusCompleted,
+			time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+		),
+		domain.NewTransfer(
+			"9a1c6b5e-2f4d-4c3b-8a7e-6d5c4b3a2f1e",
+			exportAccountID,
+			"b5a2e2d2-4b1f-4e4b-9d4b-9a7b6c2d1e0f",
+			150,
+			domain.TransferStatusPending,
+			time.Date(2021, 1, 2, 9, 30, 0, 0, time.UTC),
+		),
+	}
+)
+
+func export(t *testing.T, newExporter func(io.Writer) usecase.TransferExporter, header usecase.ExportHeader) string {
+	t.Helper()
+
+	var (
+		buf      bytes.Buffer
+		exporter = newExporter(&buf)
+	)
+
+	if err := exporter.Begin(header); err != nil {
+		t.Fatalf("Begin: '%v'", err)
+	}
+
+	for _, transfer := range exportTransfers {
+		if err := exporter.Write(transfer); err != nil {
+			t.Fatalf("Write: '%v'", err)
+		}
+	}
+
+	if err := exporter.End(); err != nil {
+		t.Fatalf("End: '%v'", err)
+	}
+
+	return buf.String()
+}
+
+func Test_csvExporter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header usecase.ExportHeader
+		want   string
+	}{
+		{
+			name:   "CSV export of an account",
+			header: exportHeader,
+			want: "id,created_at,account_origin_id,account_destination_id,direction,amount,status,failure_code\n" +
+				"7bd5d7e2-5a5b-4ef0-8e8a-3f3a3b0a9d8b,2021-01-01T12:00:00Z,b5a2e2d2-4b1f-4e4b-9d4b-9a7b6c2d1e0f,3c096a40-ccba-4b58-93ed-57379ab04680,credit,3.50,completed,\n" +
+				"9a1c6b5e-2f4d-4c3b-8a7e-6d5c4b3a2f1e,2021-01-02T09:30:00Z,3c096a40-ccba-4b58-93ed-57379ab04680,b5a2e2d2-4b1f-4e4b-9d4b-9a7b6c2d1e0f,debit,1.50,pending,\n",
+		},
+		{
+			name:   "CSV export without an account",
+			header: usecase.ExportHeader{},
+			want: "id,created_at,account_origin_id,account_destination_id,direction,amount,status,failure_code\n" +
+				"7bd5d7e2-5a5b-4ef0-8e8a-3f3a3b0a9d8b,2021-01-01T12:00:00Z,b5a2e2d2-4b1f-4e4b-9d4b-9a7b6c2d1e0f,3c096a40-ccba-4b58-93ed-57379ab04680,,3.50,completed,\n" +
+				"9a1c6b5e-2f4d-4c3b-8a7e-6d5c4b3a2f1e,2021-01-02T09:30:00Z,3c096a40-ccba-4b58-93ed-57379ab04680,b5a2e2d2-4b1f-4e4b-9d4b-9a7b6c2d1e0f,,1.50,pending,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := export(t, NewCSVExporter, tt.header); got != tt.want {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ofxExporter(t *testing.T) {
+	var got = export(t, NewOFXExporter, exportHeader)
+
+	for _, want := range []string{
+		"<ACCTID>3c096a40-ccba-4b58-93ed-57379ab04680</ACCTID>",
+		"<DTSTART>20210101000000.000[0:GMT]</DTSTART><DTEND>20210103000000.000[0:GMT]</DTEND>",
+		"<TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20210101120000.000[0:GMT]</DTPOSTED><TRNAMT>3.50</TRNAMT>",
+		"<LEDGERBAL><BALAMT>12.00</BALAMT>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("[TestCase 'OFX export'] Got: '%+v' | Want: '%+v'", got, want)
+		}
+	}
+
+	// Only completed transfers are posted
+	if strings.Contains(got, "9a1c6b5e-2f4d-4c3b-8a7e-6d5c4b3a2f1e") {
+		t.Errorf("[TestCase 'OFX export'] Got: '%+v' | Want: pending transfer left out", got)
+	}
+
+	if err := NewOFXExporter(io.Discard).Begin(usecase.ExportHeader{}); err != ErrExportAccountRequired {
+		t.Errorf("[TestCase 'OFX export without an account'] Got: '%v' | Want: '%v'", err, ErrExportAccountRequired)
+	}
+}
+
+func Test_camt053Exporter(t *testing.T) {
+	var got = export(t, NewCamt053Exporter, exportHeader)
+
+	var document struct {
+		Stmt struct {
+			Bal []struct {
+				Cd        string `xml:"Tp>CdOrPrtry>Cd"`
+				Amt       string `xml:"Amt"`
+				CdtDbtInd string `xml:"CdtDbtInd"`
+				Dt        string `xml:"Dt>Dt"`
+			} `xml:"Bal"`
+			Ntry []struct {
+				Amt       string `xml:"Amt"`
+				CdtDbtInd string `xml:"CdtDbtInd"`
+				Sts       string `xml:"Sts"`
+			} `xml:"Ntry"`
+		} `xml:"BkToCstmrStmt>Stmt"`
+	}
+
+	if err := xml.Unmarshal([]byte(got), &document); err != nil {
+		t.Fatalf("[TestCase 'camt.053 export'] Got: '%v' | Want: well formed XML", err)
+	}
+
+	var (
+		balances = document.Stmt.Bal
+		entries  = document.Stmt.Ntry
+	)
+
+	if len(balances) != 2 ||
+		balances[0].Cd != "OPBD" || balances[0].Amt != "10.00" || balances[0].Dt != "2021-01-01" ||
+		balances[1].Cd != "CLBD" || balances[1].Amt != "12.00" || balances[1].Dt != "2021-01-02" {
+		t.Errorf("[TestCase 'camt.053 export balances'] Got: '%+v'", balances)
+	}
+
+	if len(entries) != 2 ||
+		entries[0].CdtDbtInd != "CRDT" || entries[0].Sts != "BOOK" || entries[0].Amt != "3.50" ||
+		entries[1].CdtDbtInd != "DBIT" || entries[1].Sts != "PDNG" || entries[1].Amt != "1.50" {
+		t.Errorf("[TestCase 'camt.053 export entries'] Got: '%+v'", entries)
+	}
+}
+
+func Test_jsonExporter(t *testing.T) {
+	var got struct {
+		AccountID      string                          `json:"account_id"`
+		From           string                          `json:"from"`
+		To             string                          `json:"to"`
+		OpeningBalance float64                         `json:"opening_balance"`
+		ClosingBalance float64                         `json:"closing_balance"`
+		Transfers      []usecase.FindAllTransferOutput `json:"transfers"`
+	}
+
+	if err := json.Unmarshal([]byte(export(t, NewJSONExporter, exportHeader)), &got); err != nil {
+		t.Fatalf("[TestCase 'JSON export'] Got: '%v' | Want: valid JSON", err)
+	}
+
+	if got.AccountID != exportAccountID.String() || got.From != "2021-01-01" || got.To != "2021-01-02" ||
+		got.OpeningBalance != 10 || got.ClosingBalance != 12 || len(got.Transfers) != 2 {
+		t.Errorf("[TestCase 'JSON export'] Got: '%+v'", got)
+	}
+}
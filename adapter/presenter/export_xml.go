@@ -0,0 +1,42 @@
+package presenter
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// xmlWriter buffers the markup written by the exporters of XML files, keeping the first error
+type xmlWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func newXMLWriter(w io.Writer) *xmlWriter {
+	return &xmlWriter{w: bufio.NewWriter(w)}
+}
+
+func (x *xmlWriter) printf(format string, a ...interface{}) {
+	if x.err != nil {
+		return
+	}
+
+	_, x.err = fmt.Fprintf(x.w, format, a...)
+}
+
+func (x *xmlWriter) flush() error {
+	if x.err != nil {
+		return x.err
+	}
+
+	return x.w.Flush()
+}
+
+// escape makes a value safe to be written as element text or attribute
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
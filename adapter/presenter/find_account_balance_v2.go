@@ -0,0 +1,20 @@
+package presenter
+
+import (
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type findAccountBalancePresenterV2 struct{}
+
+// NewFindAccountBalancePresenterV2 presents the balance as a decimal string
+func NewFindAccountBalancePresenterV2() usecase.FindAccountBalancePresenterV2 {
+	return findAccountBalancePresenterV2{}
+}
+
+func (f findAccountBalancePresenterV2) Output(balance domain.Money) usecase.FindAccountBalanceOutputV2 {
+	return usecase.FindAccountBalanceOutputV2{
+		Balance:  balance.String(),
+		Currency: domain.CurrencyBRL.String(),
+	}
+}
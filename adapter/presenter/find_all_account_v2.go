@@ -0,0 +1,21 @@
+package presenter
+
+import (
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type findAllAccountPresenterV2 struct{}
+
+func NewFindAllAccountPresenterV2() usecase.FindAllAccountPresenterV2 {
+	return findAllAccountPresenterV2{}
+}
+
+func (f findAllAccountPresenterV2) Output(accounts []domain.Account) []usecase.AccountOutputV2 {
+	var o = make([]usecase.AccountOutputV2, 0)
+	for _, account := range accounts {
+		o = append(o, accountOutputV2(account))
+	}
+
+	return o
+}
@@ -0,0 +1,37 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type findAllAPIKeyPresenter struct{}
+
+func NewFindAllAPIKeyPresenter() usecase.FindAllAPIKeyPresenter {
+	return findAllAPIKeyPresenter{}
+}
+
+func (f findAllAPIKeyPresenter) Output(apiKeys []domain.APIKey) []usecase.FindAllAPIKeyOutput {
+	var o = make([]usecase.FindAllAPIKeyOutput, 0)
+
+	for _, apiKey := range apiKeys {
+		o = append(o, apiKeyOutput(apiKey))
+	}
+
+	return o
+}
+
+func apiKeyOutput(apiKey domain.APIKey) usecase.FindAllAPIKeyOutput {
+	return usecase.FindAllAPIKeyOutput{
+		ID:         apiKey.ID().String(),
+		Name:       apiKey.Name(),
+		Prefix:     apiKey.Prefix(),
+		Scopes:     scopesOutput(apiKey.Scopes()),
+		ExpiresAt:  optionalTime(apiKey.ExpiresAt()),
+		LastUsedAt: optionalTime(apiKey.LastUsedAt()),
+		RevokedAt:  optionalTime(apiKey.RevokedAt()),
+		CreatedAt:  apiKey.CreatedAt().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,67 @@
+package presenter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+func Test_findAllAPIKeyPresenter_Output(t *testing.T) {
+	type args struct {
+		apiKeys []domain.APIKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want []usecase.FindAllAPIKeyOutput
+	}{
+		{
+			name: "Find all api key output",
+			args: args{
+				apiKeys: []domain.APIKey{
+					domain.NewAPIKey(
+						"3c096a40-ccba-4b58-93ed-57379ab04680",
+						"client",
+						"erp",
+						"abcd1234",
+						"hash",
+						[]domain.Scope{domain.ScopeAccountsRead},
+						time.Time{},
+						time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+						time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+						time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					),
+				},
+			},
+			want: []usecase.FindAllAPIKeyOutput{
+				{
+					ID:         "3c096a40-ccba-4b58-93ed-57379ab04680",
+					Name:       "erp",
+					Prefix:     "abcd1234",
+					Scopes:     []string{"accounts:read"},
+					LastUsedAt: "2021-01-02T00:00:00Z",
+					RevokedAt:  "2021-01-03T00:00:00Z",
+					CreatedAt:  "2021-01-01T00:00:00Z",
+				},
+			},
+		},
+		{
+			name: "Find all api key empty output",
+			args: args{
+				apiKeys: []domain.APIKey{},
+			},
+			want: []usecase.FindAllAPIKeyOutput{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pre := NewFindAllAPIKeyPresenter()
+			if got := pre.Output(tt.args.apiKeys); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -22,6 +22,8 @@ func (a findAllTransferPresenter) Output(transfers []domain.Transfer) []usecase.
 			AccountOriginID:      transfer.AccountOriginID().String(),
 			AccountDestinationID: transfer.AccountDestinationID().String(),
 			Amount:               transfer.Amount().Float64(),
+			Status:               transfer.Status().String(),
+			FailureCode:          transfer.FailureCode().String(),
 			CreatedAt:            transfer.CreatedAt().Format(time.RFC3339),
 		})
 	}
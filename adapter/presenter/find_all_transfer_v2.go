@@ -0,0 +1,21 @@
+package presenter
+
+import (
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type findAllTransferPresenterV2 struct{}
+
+func NewFindAllTransferPresenterV2() usecase.FindAllTransferPresenterV2 {
+	return findAllTransferPresenterV2{}
+}
+
+func (f findAllTransferPresenterV2) Output(transfers []domain.Transfer) []usecase.TransferOutputV2 {
+	var o = make([]usecase.TransferOutputV2, 0)
+	for _, transfer := range transfers {
+		o = append(o, transferOutputV2(transfer))
+	}
+
+	return o
+}
@@ -0,0 +1,37 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type findAllWebhookPresenter struct{}
+
+func NewFindAllWebhookPresenter() usecase.FindAllWebhookPresenter {
+	return findAllWebhookPresenter{}
+}
+
+func (f findAllWebhookPresenter) Output(webhooks []domain.Webhook) []usecase.WebhookOutput {
+	var o = make([]usecase.WebhookOutput, 0)
+
+	for _, webhook := range webhooks {
+		o = append(o, webhookOutput(webhook))
+	}
+
+	return o
+}
+
+func webhookOutput(webhook domain.Webhook) usecase.WebhookOutput {
+	return usecase.WebhookOutput{
+		ID:         webhook.ID().String(),
+		URL:        webhook.URL(),
+		Events:     eventsOutput(webhook.Events()),
+		AccountIDs: accountIDsOutput(webhook.AccountIDs()),
+		Enabled:    webhook.Enabled(),
+		Failures:   webhook.Failures(),
+		DisabledAt: optionalTime(webhook.DisabledAt()),
+		CreatedAt:  webhook.CreatedAt().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,51 @@
+package presenter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type findAuditPresenter struct{}
+
+func NewFindAuditPresenter() usecase.FindAuditPresenter {
+	return findAuditPresenter{}
+}
+
+func (f findAuditPresenter) Output(records []domain.AuditRecord) []usecase.FindAuditOutput {
+	var o = make([]usecase.FindAuditOutput, 0)
+
+	for _, record := range records {
+		var (
+			targets = record.Targets()
+			payload = json.RawMessage(record.Payload())
+		)
+
+		if targets == nil {
+			targets = []string{}
+		}
+
+		// payloads are JSON unless recorded from an input that was not
+		if !json.Valid(payload) {
+			payload, _ = json.Marshal(record.Payload())
+		}
+
+		o = append(o, usecase.FindAuditOutput{
+			ID:         record.ID(),
+			Actor:      record.Actor(),
+			Role:       record.Role().String(),
+			Action:     record.Action(),
+			Targets:    targets,
+			InputHash:  record.InputHash(),
+			Payload:    payload,
+			Outcome:    record.Outcome().String(),
+			Error:      record.ErrorMessage(),
+			RequestID:  record.RequestID(),
+			OccurredAt: record.OccurredAt().Format(time.RFC3339),
+		})
+	}
+
+	return o
+}
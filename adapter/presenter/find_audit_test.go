@@ -0,0 +1,75 @@
+package presenter
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+func Test_findAuditPresenter_Output(t *testing.T) {
+	var occurredAt = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		records []domain.AuditRecord
+	}
+	tests := []struct {
+		name string
+		args args
+		want []usecase.FindAuditOutput
+	}{
+		{
+			name: "Find audit output",
+			args: args{
+				records: []domain.AuditRecord{
+					domain.NewAuditRecord(
+						"5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+						"02815517078",
+						domain.RoleCustomer,
+						"create_transfer",
+						nil,
+						"abc",
+						`{"amount":100}`,
+						domain.AuditFailed,
+						"account not found",
+						"",
+						occurredAt,
+					),
+				},
+			},
+			want: []usecase.FindAuditOutput{
+				{
+					ID:         "5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+					Actor:      "02815517078",
+					Role:       "customer",
+					Action:     "create_transfer",
+					Targets:    []string{},
+					InputHash:  "abc",
+					Payload:    json.RawMessage(`{"amount":100}`),
+					Outcome:    "failed",
+					Error:      "account not found",
+					OccurredAt: "2021-01-01T00:00:00Z",
+				},
+			},
+		},
+		{
+			name: "Find audit output empty",
+			args: args{
+				records: []domain.AuditRecord{},
+			},
+			want: []usecase.FindAuditOutput{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pre := NewFindAuditPresenter()
+			if got := pre.Output(tt.args.records); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package presenter
+
+import (
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type findDailyBalancesPresenter struct{}
+
+func NewFindDailyBalancesPresenter() usecase.FindDailyBalancesPresenter {
+	return findDailyBalancesPresenter{}
+}
+
+func (f findDailyBalancesPresenter) Output(balances []domain.DailyBalance) []usecase.DailyBalanceOutput {
+	var o = make([]usecase.DailyBalanceOutput, 0)
+
+	for _, balance := range balances {
+		o = append(o, usecase.DailyBalanceOutput{
+			Date:             balance.Day().Format("2006-01-02"),
+			ClosingBalance:   balance.ClosingBalance().Float64(),
+			Credits:          balance.Credits().Float64(),
+			Debits:           balance.Debits().Float64(),
+			TransactionCount: balance.TransactionCount(),
+			Interest:         balance.Interest().Float64(),
+		})
+	}
+
+	return o
+}
@@ -0,0 +1,62 @@
+package presenter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+func Test_findDailyBalancesPresenter_Output(t *testing.T) {
+	type args struct {
+		balances []domain.DailyBalance
+	}
+	tests := []struct {
+		name string
+		args args
+		want []usecase.DailyBalanceOutput
+	}{
+		{
+			name: "Find daily balances output",
+			args: args{
+				balances: []domain.DailyBalance{
+					domain.NewDailyBalance(
+						"3c096a40-ccba-4b58-93ed-57379ab04680",
+						time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+						1350,
+						500,
+						150,
+						3,
+					),
+				},
+			},
+			want: []usecase.DailyBalanceOutput{
+				{
+					Date:             "2021-01-02",
+					ClosingBalance:   13.5,
+					Credits:          5,
+					Debits:           1.5,
+					TransactionCount: 3,
+				},
+			},
+		},
+		{
+			name: "Find daily balances output empty",
+			args: args{
+				balances: []domain.DailyBalance{},
+			},
+			want: []usecase.DailyBalanceOutput{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pre := NewFindDailyBalancesPresenter()
+			if got := pre.Output(tt.args.balances); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
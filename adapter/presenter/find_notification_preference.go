@@ -0,0 +1,31 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type findNotificationPreferencePresenter struct{}
+
+func NewFindNotificationPreferencePresenter() usecase.FindNotificationPreferencePresenter {
+	return findNotificationPreferencePresenter{}
+}
+
+func (f findNotificationPreferencePresenter) Output(
+	preference domain.NotificationPreference,
+) usecase.NotificationPreferenceOutput {
+	return notificationPreferenceOutput(preference)
+}
+
+func notificationPreferenceOutput(preference domain.NotificationPreference) usecase.NotificationPreferenceOutput {
+	return usecase.NotificationPreferenceOutput{
+		AccountID:           preference.AccountID().String(),
+		Email:               preference.Email(),
+		Locale:              preference.Locale(),
+		TransferReceived:    preference.TransferReceived(),
+		LowBalanceThreshold: preference.LowBalanceThreshold().String(),
+		UpdatedAt:           preference.UpdatedAt().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,32 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type findTransferApprovalsPresenter struct{}
+
+func NewFindTransferApprovalsPresenter() usecase.FindTransferApprovalsPresenter {
+	return findTransferApprovalsPresenter{}
+}
+
+func (f findTransferApprovalsPresenter) Output(approvals []domain.TransferApproval) []usecase.FindTransferApprovalsOutput {
+	var o = make([]usecase.FindTransferApprovalsOutput, 0)
+
+	for _, approval := range approvals {
+		o = append(o, usecase.FindTransferApprovalsOutput{
+			ID:         approval.ID().String(),
+			TransferID: approval.TransferID().String(),
+			Action:     approval.Action().String(),
+			Actor:      approval.Actor(),
+			Role:       approval.Role().String(),
+			Reason:     approval.Reason(),
+			CreatedAt:  approval.CreatedAt().Format(time.RFC3339),
+		})
+	}
+
+	return o
+}
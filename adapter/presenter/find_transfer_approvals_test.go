@@ -0,0 +1,81 @@
+package presenter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+func Test_findTransferApprovalsPresenter_Output(t *testing.T) {
+	type args struct {
+		approvals []domain.TransferApproval
+	}
+	tests := []struct {
+		name string
+		args args
+		want []usecase.FindTransferApprovalsOutput
+	}{
+		{
+			name: "Find transfer approvals output",
+			args: args{
+				approvals: []domain.TransferApproval{
+					domain.NewTransferApproval(
+						"3c096a40-ccba-4b58-93ed-57379ab04690",
+						"3c096a40-ccba-4b58-93ed-57379ab04680",
+						domain.ApprovalRequested,
+						"08098565895",
+						domain.RoleCustomer,
+						"",
+						time.Time{},
+					),
+					domain.NewTransferApproval(
+						"3c096a40-ccba-4b58-93ed-57379ab04691",
+						"3c096a40-ccba-4b58-93ed-57379ab04680",
+						domain.ApprovalRejected,
+						"02815517078",
+						domain.RoleAdmin,
+						"suspected fraud",
+						time.Time{},
+					),
+				},
+			},
+			want: []usecase.FindTransferApprovalsOutput{
+				{
+					ID:         "3c096a40-ccba-4b58-93ed-57379ab04690",
+					TransferID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+					Action:     "requested",
+					Actor:      "08098565895",
+					Role:       "customer",
+					CreatedAt:  "0001-01-01T00:00:00Z",
+				},
+				{
+					ID:         "3c096a40-ccba-4b58-93ed-57379ab04691",
+					TransferID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+					Action:     "rejected",
+					Actor:      "02815517078",
+					Role:       "admin",
+					Reason:     "suspected fraud",
+					CreatedAt:  "0001-01-01T00:00:00Z",
+				},
+			},
+		},
+		{
+			name: "Find transfer approvals empty output",
+			args: args{
+				approvals: []domain.TransferApproval{},
+			},
+			want: []usecase.FindTransferApprovalsOutput{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pre := NewFindTransferApprovalsPresenter()
+			if got := pre.Output(tt.args.approvals); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type findWebhookDeliveriesPresenter struct{}
+
+func NewFindWebhookDeliveriesPresenter() usecase.FindWebhookDeliveriesPresenter {
+	return findWebhookDeliveriesPresenter{}
+}
+
+func (f findWebhookDeliveriesPresenter) Output(deliveries []domain.WebhookDelivery) []usecase.WebhookDeliveryOutput {
+	var o = make([]usecase.WebhookDeliveryOutput, 0)
+
+	for _, delivery := range deliveries {
+		o = append(o, webhookDeliveryOutput(delivery))
+	}
+
+	return o
+}
+
+func webhookDeliveryOutput(delivery domain.WebhookDelivery) usecase.WebhookDeliveryOutput {
+	var nextAttemptAt string
+	if delivery.Status() == domain.WebhookDeliveryPending {
+		nextAttemptAt = optionalTime(delivery.NextAttemptAt())
+	}
+
+	return usecase.WebhookDeliveryOutput{
+		ID:             delivery.ID().String(),
+		WebhookID:      delivery.WebhookID().String(),
+		EventID:        delivery.EventID(),
+		EventName:      delivery.EventName().String(),
+		Status:         delivery.Status().String(),
+		Attempts:       delivery.Attempts(),
+		ResponseStatus: delivery.ResponseStatus(),
+		LastError:      delivery.LastError(),
+		NextAttemptAt:  nextAttemptAt,
+		DeliveredAt:    optionalTime(delivery.DeliveredAt()),
+		CreatedAt:      delivery.CreatedAt().Format(time.RFC3339),
+		Payload:        delivery.Payload(),
+	}
+}
@@ -0,0 +1,93 @@
+package presenter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+func Test_findWebhookDeliveriesPresenter_Output(t *testing.T) {
+	var (
+		createdAt = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		payload   = []byte(`{"id":"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10"}`)
+		pending   = domain.NewWebhookDelivery(
+			"5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+			"0db298eb-c8e7-4829-84b7-c1036b4f0791",
+			"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10",
+			domain.EventTransferCompleted,
+			payload,
+			domain.WebhookDeliveryPending,
+			0,
+			createdAt,
+			0,
+			"",
+			time.Time{},
+			createdAt,
+		)
+	)
+
+	type args struct {
+		deliveries []domain.WebhookDelivery
+	}
+	tests := []struct {
+		name string
+		args args
+		want []usecase.WebhookDeliveryOutput
+	}{
+		{
+			name: "Find webhook deliveries output",
+			args: args{
+				deliveries: []domain.WebhookDelivery{
+					pending.MarkFailed(503, errors.New("webhook answered with status 503"), createdAt.Add(time.Minute), false),
+					pending.MarkSucceeded(204, createdAt.Add(time.Second)),
+				},
+			},
+			want: []usecase.WebhookDeliveryOutput{
+				{
+					ID:             "5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+					WebhookID:      "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+					EventID:        "a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10",
+					EventName:      "transfer.completed",
+					Status:         "pending",
+					Attempts:       1,
+					ResponseStatus: 503,
+					LastError:      "webhook answered with status 503",
+					NextAttemptAt:  "2021-01-01T00:01:00Z",
+					CreatedAt:      "2021-01-01T00:00:00Z",
+					Payload:        payload,
+				},
+				{
+					ID:             "5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+					WebhookID:      "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+					EventID:        "a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10",
+					EventName:      "transfer.completed",
+					Status:         "succeeded",
+					Attempts:       1,
+					ResponseStatus: 204,
+					DeliveredAt:    "2021-01-01T00:00:01Z",
+					CreatedAt:      "2021-01-01T00:00:00Z",
+					Payload:        payload,
+				},
+			},
+		},
+		{
+			name: "Find webhook deliveries empty output",
+			args: args{
+				deliveries: []domain.WebhookDelivery{},
+			},
+			want: []usecase.WebhookDeliveryOutput{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pre := NewFindWebhookDeliveriesPresenter()
+			if got := pre.Output(tt.args.deliveries); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package presenter
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+// notificationTemplates holds a file per locale and kind, as templates/notification/<locale>/<kind>.tmpl, each
+// defining the "subject" and "body" templates
+//
+//go:embed templates/notification
+var notificationTemplates embed.FS
+
+var errNotificationTemplateNotFound = errors.New("notification template not found")
+
+// notificationLocale is how the messages of a locale write amounts and dates
+type notificationLocale struct {
+	thousands  string
+	decimal    string
+	symbolGap  string
+	dateLayout string
+}
+
+var notificationLocales = map[string]notificationLocale{
+	"pt-BR": {thousands: ".", decimal: ",", symbolGap: " ", dateLayout: "02/01/2006 15:04 MST"},
+	"en-US": {thousands: ",", decimal: ".", symbolGap: "", dateLayout: "Jan 2, 2006 3:04 PM MST"},
+}
+
+var currencySymbols = map[string]string{
+	domain.CurrencyBRL.String(): "R$",
+}
+
+type notificationPresenter struct {
+	templates map[string]*template.Template
+}
+
+// NewNotificationPresenter parses the embedded templates, which are part of the build, so a broken one panics
+func NewNotificationPresenter() usecase.NotificationPresenter {
+	files, err := fs.Glob(notificationTemplates, "templates/notification/*/*.tmpl")
+	if err != nil {
+		panic(err)
+	}
+
+	var templates = make(map[string]*template.Template, len(files))
+	for _, file := range files {
+		var (
+			locale = path.Base(path.Dir(file))
+			kind   = strings.TrimSuffix(path.Base(file), ".tmpl")
+		)
+
+		templates[locale+"/"+kind] = template.Must(
+			template.New(path.Base(file)).Funcs(notificationFuncs(locale)).ParseFS(notificationTemplates, file),
+		)
+	}
+
+	return notificationPresenter{templates: templates}
+}
+
+// Output renders the message of the kind in the locale, or in domain.DefaultLocale when the locale has no template
+// for it
+func (n notificationPresenter) Output(
+	kind domain.NotificationKind,
+	locale string,
+	data usecase.NotificationData,
+) (usecase.NotificationOutput, error) {
+	tmpl, ok := n.templates[locale+"/"+kind.String()]
+	if !ok {
+		tmpl, ok = n.templates[domain.DefaultLocale+"/"+kind.String()]
+	}
+
+	if !ok {
+		return usecase.NotificationOutput{}, errNotificationTemplateNotFound
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return usecase.NotificationOutput{}, err
+	}
+
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return usecase.NotificationOutput{}, err
+	}
+
+	return usecase.NotificationOutput{
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    body.String(),
+	}, nil
+}
+
+func notificationFuncs(locale string) template.FuncMap {
+	l, ok := notificationLocales[locale]
+	if !ok {
+		l = notificationLocales[domain.DefaultLocale]
+	}
+
+	return template.FuncMap{
+		"money": func(amount, currency string) string {
+			return formatMoney(amount, currency, l)
+		},
+		"date": func(t time.Time) string {
+			return t.UTC().Format(l.dateLayout)
+		},
+	}
+}
+
+// formatMoney writes an exact decimal amount, such as "-1234.56", with the separators of the locale and the symbol
+// of the currency, such as "-R$ 1.234,56"
+func formatMoney(amount, currency string, l notificationLocale) string {
+	var sign string
+	if strings.HasPrefix(amount, "-") {
+		sign, amount = "-", amount[1:]
+	}
+
+	var units, cents = amount, ""
+	if i := strings.IndexByte(amount, '.'); i >= 0 {
+		units, cents = amount[:i], amount[i+1:]
+	}
+
+	var grouped []string
+	for len(units) > 3 {
+		grouped = append([]string{units[len(units)-3:]}, grouped...)
+		units = units[:len(units)-3]
+	}
+	grouped = append([]string{units}, grouped...)
+
+	symbol, ok := currencySymbols[currency]
+	if !ok {
+		symbol = currency
+	}
+
+	var s = sign + symbol + l.symbolGap + strings.Join(grouped, l.thousands)
+	if cents != "" {
+		s += l.decimal + cents
+	}
+
+	return s
+}
@@ -0,0 +1,85 @@
+package presenter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+func Test_notificationPresenter_Output(t *testing.T) {
+	var data = usecase.NotificationData{
+		Name:       "Test",
+		TransferID: "3c096a40-ccba-4b58-93ed-57379ab04682",
+		Amount:     "1234.56",
+		Balance:    "99.90",
+		Threshold:  "100.00",
+		Currency:   "BRL",
+		OccurredAt: time.Date(2021, 1, 2, 15, 4, 0, 0, time.UTC),
+	}
+
+	type args struct {
+		kind   domain.NotificationKind
+		locale string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    usecase.NotificationOutput
+		wantErr bool
+	}{
+		{
+			name: "Transfer received in pt-BR",
+			args: args{kind: domain.NotificationTransferReceived, locale: "pt-BR"},
+			want: usecase.NotificationOutput{
+				Subject: "Você recebeu R$ 1.234,56",
+				Body: "Olá, Test.\n\n" +
+					"Você recebeu uma transferência de R$ 1.234,56 em 02/01/2021 15:04 UTC.\n" +
+					"Seu saldo agora é R$ 99,90.\n\n" +
+					"Transferência: 3c096a40-ccba-4b58-93ed-57379ab04682\n",
+			},
+		},
+		{
+			name: "Low balance in en-US",
+			args: args{kind: domain.NotificationLowBalance, locale: "en-US"},
+			want: usecase.NotificationOutput{
+				Subject: "Your balance is below R$100.00",
+				Body: "Hi Test,\n\n" +
+					"After the transfer of R$1,234.56 on Jan 2, 2021 3:04 PM UTC, your balance is R$99.90,\n" +
+					"below the R$100.00 threshold you set.\n\n" +
+					"Transfer: 3c096a40-ccba-4b58-93ed-57379ab04682\n",
+			},
+		},
+		{
+			name: "Unknown locale falls back to pt-BR",
+			args: args{kind: domain.NotificationLowBalance, locale: "fr-FR"},
+			want: usecase.NotificationOutput{
+				Subject: "Seu saldo está abaixo de R$ 100,00",
+				Body: "Olá, Test.\n\n" +
+					"Após a transferência de R$ 1.234,56 em 02/01/2021 15:04 UTC, seu saldo é R$ 99,90,\n" +
+					"abaixo do limite de R$ 100,00 que você definiu.\n\n" +
+					"Transferência: 3c096a40-ccba-4b58-93ed-57379ab04682\n",
+			},
+		},
+		{
+			name:    "Unknown kind",
+			args:    args{kind: "unknown", locale: "pt-BR"},
+			want:    usecase.NotificationOutput{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewNotificationPresenter().Output(tt.args.kind, tt.args.locale, data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
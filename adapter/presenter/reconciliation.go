@@ -0,0 +1,47 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type reconciliationPresenter struct{}
+
+func NewReconciliationPresenter() usecase.ReconciliationPresenter {
+	return reconciliationPresenter{}
+}
+
+func (r reconciliationPresenter) Output(run domain.ReconciliationRun) usecase.ReconciliationOutput {
+	var mismatches = make([]usecase.ReconciliationMismatchOutput, 0, len(run.Mismatches()))
+	for _, m := range run.Mismatches() {
+		// Both balances fit in an int64, only their difference may not, which is left at zero
+		difference, _ := m.Difference()
+
+		mismatches = append(mismatches, usecase.ReconciliationMismatchOutput{
+			AccountID:       m.AccountID().String(),
+			StoredBalance:   m.Stored().Float64(),
+			ExpectedBalance: m.Expected().Float64(),
+			Difference:      difference.Float64(),
+		})
+	}
+
+	var unverifiable = make([]string, 0, len(run.Unverifiable()))
+	for _, ID := range run.Unverifiable() {
+		unverifiable = append(unverifiable, ID.String())
+	}
+
+	return usecase.ReconciliationOutput{
+		ID:              run.ID().String(),
+		Status:          run.Status().String(),
+		Trigger:         run.Trigger().String(),
+		AccountsChecked: run.AccountsChecked(),
+		Mismatches:      mismatches,
+		Unverifiable:    unverifiable,
+		Error:           run.LastError(),
+		RequestedAt:     run.RequestedAt().Format(time.RFC3339),
+		StartedAt:       optionalTime(run.StartedAt()),
+		FinishedAt:      optionalTime(run.FinishedAt()),
+	}
+}
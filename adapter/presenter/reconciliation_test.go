@@ -0,0 +1,88 @@
+package presenter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+func Test_reconciliationPresenter_Output(t *testing.T) {
+	var requestedAt = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		run domain.ReconciliationRun
+	}
+	tests := []struct {
+		name string
+		args args
+		want usecase.ReconciliationOutput
+	}{
+		{
+			name: "Reconciliation output completed",
+			args: args{
+				run: domain.NewReconciliationRun(
+					"5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+					domain.ReconciliationCompleted,
+					domain.ReconciliationScheduled,
+					2,
+					[]domain.ReconciliationMismatch{
+						domain.NewReconciliationMismatch("3c096a40-ccba-4b58-93ed-57379ab04680", 1250, 1000),
+					},
+					[]domain.AccountID{"0db298eb-c8e7-4829-84b7-c1036b4f0791"},
+					"",
+					requestedAt,
+					requestedAt.Add(time.Second),
+					requestedAt.Add(2*time.Second),
+				),
+			},
+			want: usecase.ReconciliationOutput{
+				ID:              "5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+				Status:          "completed",
+				Trigger:         "scheduled",
+				AccountsChecked: 2,
+				Mismatches: []usecase.ReconciliationMismatchOutput{
+					{
+						AccountID:       "3c096a40-ccba-4b58-93ed-57379ab04680",
+						StoredBalance:   12.5,
+						ExpectedBalance: 10,
+						Difference:      2.5,
+					},
+				},
+				Unverifiable: []string{"0db298eb-c8e7-4829-84b7-c1036b4f0791"},
+				RequestedAt:  "2021-01-01T00:00:00Z",
+				StartedAt:    "2021-01-01T00:00:01Z",
+				FinishedAt:   "2021-01-01T00:00:02Z",
+			},
+		},
+		{
+			name: "Reconciliation output pending",
+			args: args{
+				run: domain.NewPendingReconciliationRun(
+					"5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+					domain.ReconciliationManual,
+					requestedAt,
+				),
+			},
+			want: usecase.ReconciliationOutput{
+				ID:           "5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+				Status:       "pending",
+				Trigger:      "manual",
+				Mismatches:   []usecase.ReconciliationMismatchOutput{},
+				Unverifiable: []string{},
+				RequestedAt:  "2021-01-01T00:00:00Z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pre := NewReconciliationPresenter()
+			if got := pre.Output(tt.args.run); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
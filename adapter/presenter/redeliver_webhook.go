@@ -0,0 +1,16 @@
+package presenter
+
+import (
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type redeliverWebhookPresenter struct{}
+
+func NewRedeliverWebhookPresenter() usecase.RedeliverWebhookPresenter {
+	return redeliverWebhookPresenter{}
+}
+
+func (r redeliverWebhookPresenter) Output(delivery domain.WebhookDelivery) usecase.WebhookDeliveryOutput {
+	return webhookDeliveryOutput(delivery)
+}
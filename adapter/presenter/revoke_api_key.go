@@ -0,0 +1,16 @@
+package presenter
+
+import (
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type revokeAPIKeyPresenter struct{}
+
+func NewRevokeAPIKeyPresenter() usecase.RevokeAPIKeyPresenter {
+	return revokeAPIKeyPresenter{}
+}
+
+func (r revokeAPIKeyPresenter) Output(apiKey domain.APIKey) usecase.FindAllAPIKeyOutput {
+	return apiKeyOutput(apiKey)
+}
@@ -0,0 +1,81 @@
+package presenter
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type transferImportPresenter struct{}
+
+func NewTransferImportPresenter() usecase.TransferImportPresenter {
+	return transferImportPresenter{}
+}
+
+func (t transferImportPresenter) Output(job domain.TransferImport) usecase.TransferImportOutput {
+	var results = make([]usecase.TransferImportLineOutput, 0, len(job.Lines()))
+	for _, line := range job.Lines() {
+		results = append(results, usecase.TransferImportLineOutput{
+			Line:                 line.Number(),
+			AccountOriginID:      line.AccountOriginID().String(),
+			AccountDestinationID: line.AccountDestinationID().String(),
+			Amount:               line.Amount().Float64(),
+			Status:               line.Status().String(),
+			TransferID:           line.TransferID().String(),
+			TransferStatus:       line.TransferStatus().String(),
+			Errors:               line.Errors(),
+		})
+	}
+
+	return usecase.TransferImportOutput{
+		ID:         job.ID().String(),
+		FileName:   job.FileName(),
+		Format:     job.Format().String(),
+		Status:     job.Status().String(),
+		Lines:      len(job.Lines()),
+		Pending:    job.Count(domain.TransferImportLinePending) + job.Count(domain.TransferImportLineRunning),
+		Created:    job.Count(domain.TransferImportLineCreated),
+		Failed:     job.Count(domain.TransferImportLineFailed),
+		Invalid:    job.Count(domain.TransferImportLineInvalid),
+		Results:    results,
+		CreatedAt:  job.CreatedAt().Format(time.RFC3339),
+		StartedAt:  optionalTime(job.StartedAt()),
+		FinishedAt: optionalTime(job.FinishedAt()),
+	}
+}
+
+// WriteTransferImportErrorReport writes the lines that were invalid or failed as CSV, with their problems joined in
+// the last column, so the file can be fixed and uploaded again
+func WriteTransferImportErrorReport(w io.Writer, output usecase.TransferImportOutput) error {
+	var c = csv.NewWriter(w)
+
+	if err := c.Write([]string{"line", "account_origin_id", "account_destination_id", "amount", "status", "errors"}); err != nil {
+		return err
+	}
+
+	for _, result := range output.Results {
+		var status = domain.TransferImportLineStatus(result.Status)
+		if status != domain.TransferImportLineInvalid && status != domain.TransferImportLineFailed {
+			continue
+		}
+
+		if err := c.Write([]string{
+			strconv.Itoa(result.Line),
+			result.AccountOriginID,
+			result.AccountDestinationID,
+			strconv.FormatFloat(result.Amount, 'f', 2, 64),
+			result.Status,
+			strings.Join(result.Errors, "; "),
+		}); err != nil {
+			return err
+		}
+	}
+
+	c.Flush()
+	return c.Error()
+}
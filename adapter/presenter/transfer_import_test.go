@@ -0,0 +1,119 @@
+package presenter
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+func Test_transferImportPresenter_Output(t *testing.T) {
+	var createdAt = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		job domain.TransferImport
+	}
+	tests := []struct {
+		name string
+		args args
+		want usecase.TransferImportOutput
+	}{
+		{
+			name: "Transfer import output running",
+			args: args{
+				job: domain.NewTransferImport(
+					"0b4c4b5e-8a0f-4f0c-9f4d-7c2c5f1d9e11",
+					"payroll.csv",
+					domain.TransferImportCSV,
+					"",
+					domain.TransferImportRunning,
+					domain.NewPrincipal("02815517078", domain.RoleCustomer),
+					[]domain.TransferImportLine{
+						domain.NewTransferImportLine(
+							2,
+							"3c096a40-ccba-4b58-93ed-57379ab04680",
+							"a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c",
+							1250,
+							domain.TransferImportLineCreated,
+							nil,
+							"5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+							domain.TransferStatusCompleted,
+						),
+						domain.NewTransferImportLine(3, "", "", 0, domain.TransferImportLineInvalid, []string{"invalid amount"}, "", ""),
+						domain.NewTransferImportLine(4, "3c096a40-ccba-4b58-93ed-57379ab04680", "a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c", 100, domain.TransferImportLineRunning, nil, "", ""),
+					},
+					"",
+					createdAt,
+					createdAt.Add(time.Second),
+					time.Time{},
+				),
+			},
+			want: usecase.TransferImportOutput{
+				ID:       "0b4c4b5e-8a0f-4f0c-9f4d-7c2c5f1d9e11",
+				FileName: "payroll.csv",
+				Format:   "csv",
+				Status:   "running",
+				Lines:    3,
+				Pending:  1,
+				Created:  1,
+				Invalid:  1,
+				Results: []usecase.TransferImportLineOutput{
+					{
+						Line:                 2,
+						AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
+						AccountDestinationID: "a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c",
+						Amount:               12.5,
+						Status:               "created",
+						TransferID:           "5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+						TransferStatus:       "completed",
+					},
+					{
+						Line:   3,
+						Status: "invalid",
+						Errors: []string{"invalid amount"},
+					},
+					{
+						Line:                 4,
+						AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
+						AccountDestinationID: "a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c",
+						Amount:               1,
+						Status:               "running",
+					},
+				},
+				CreatedAt: "2021-01-01T00:00:00Z",
+				StartedAt: "2021-01-01T00:00:01Z",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pre := NewTransferImportPresenter()
+			if got := pre.Output(tt.args.job); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteTransferImportErrorReport(t *testing.T) {
+	var (
+		output = usecase.TransferImportOutput{
+			Results: []usecase.TransferImportLineOutput{
+				{Line: 2, AccountOriginID: "a", AccountDestinationID: "b", Amount: 12.5, Status: "created"},
+				{Line: 3, Status: "invalid", Errors: []string{"invalid amount", "AccountOriginID is a required field"}},
+				{Line: 4, AccountOriginID: "a", AccountDestinationID: "b", Amount: 1, Status: "failed", Errors: []string{"origin account does not have sufficient balance"}},
+			},
+		}
+		want = "line,account_origin_id,account_destination_id,amount,status,errors\n" +
+			"3,,,0.00,invalid,invalid amount; AccountOriginID is a required field\n" +
+			"4,a,b,1.00,failed,origin account does not have sufficient balance\n"
+		got bytes.Buffer
+	)
+
+	if err := WriteTransferImportErrorReport(&got, output); err != nil || got.String() != want {
+		t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", "Error report of the lines not created", got.String(), want)
+	}
+}
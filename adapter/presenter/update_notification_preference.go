@@ -0,0 +1,18 @@
+package presenter
+
+import (
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type updateNotificationPreferencePresenter struct{}
+
+func NewUpdateNotificationPreferencePresenter() usecase.UpdateNotificationPreferencePresenter {
+	return updateNotificationPreferencePresenter{}
+}
+
+func (u updateNotificationPreferencePresenter) Output(
+	preference domain.NotificationPreference,
+) usecase.NotificationPreferenceOutput {
+	return notificationPreferenceOutput(preference)
+}
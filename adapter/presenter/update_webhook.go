@@ -0,0 +1,16 @@
+package presenter
+
+import (
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+type updateWebhookPresenter struct{}
+
+func NewUpdateWebhookPresenter() usecase.UpdateWebhookPresenter {
+	return updateWebhookPresenter{}
+}
+
+func (u updateWebhookPresenter) Output(webhook domain.Webhook) usecase.WebhookOutput {
+	return webhookOutput(webhook)
+}
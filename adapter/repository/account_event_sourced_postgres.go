@@ -0,0 +1,341 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+// errEventSourcedDialect is returned by the appends on an engine without ON CONFLICT ... RETURNING, which they need to
+// tell an entry whose version was taken
+var errEventSourcedDialect = errors.New("event-sourced accounts are not supported by the dialect")
+
+// querier is what reads need from a database handle or a transaction
+type querier interface {
+	QueryContext(context.Context, string, ...interface{}) (Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) Row
+}
+
+// AccountEventSourcedSQL keeps each account as its stream of changes in account_events, rebuilt by replay from the
+// latest snapshot. The list, CPF and balance reads go to the accounts read model, which the projection updates in the
+// transaction of each append
+type AccountEventSourcedSQL struct {
+	AccountSQL
+
+	db            SQL
+	projection    AccountProjectionSQL
+	snapshotEvery int64
+}
+
+// NewAccountEventSourcedSQL snapshots a stream every snapshotEvery entries
+func NewAccountEventSourcedSQL(db SQL, snapshotEvery int64) AccountEventSourcedSQL {
+	return AccountEventSourcedSQL{
+		AccountSQL:    NewAccountSQL(db),
+		db:            db,
+		projection:    NewAccountProjectionSQL(),
+		snapshotEvery: snapshotEvery,
+	}
+}
+
+func (a AccountEventSourcedSQL) Create(ctx context.Context, account domain.Account) (domain.Account, error) {
+	err := a.withTx(ctx, func(tx Tx) error {
+		if err := a.append(ctx, tx, domain.NewAccountOpenedEvent(account)); err != nil {
+			return err
+		}
+
+		return a.projection.Project(ctx, tx, account)
+	})
+	if err != nil {
+		return domain.Account{}, errors.Wrap(err, "error creating account")
+	}
+
+	return account, nil
+}
+
+// UpdateBalance appends the move taking the account to the balance. The stream of an account created before the
+// event-sourced mode starts with its current state
+func (a AccountEventSourcedSQL) UpdateBalance(ctx context.Context, ID domain.AccountID, balance domain.Money) error {
+	return a.withTx(ctx, func(tx Tx) error {
+		account, version, err := a.load(ctx, tx, ID)
+		if err != nil {
+			return err
+		}
+
+		var events []domain.AccountEvent
+		if version == 0 {
+			events = append(events, domain.NewAccountOpenedEvent(account))
+			version = 1
+		}
+
+		event, changed, err := domain.NewAccountBalanceEvent(account, version, balance, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if changed {
+			events = append(events, event)
+			if account, version, err = domain.ReplayAccount(account, version, []domain.AccountEvent{event}); err != nil {
+				return err
+			}
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		if err = a.append(ctx, tx, events...); err != nil {
+			return errors.Wrap(err, "error updating account balance")
+		}
+
+		if err = a.projection.Project(ctx, tx, account); err != nil {
+			return errors.Wrap(err, "error updating account balance")
+		}
+
+		if version/a.snapshotEvery > (version-int64(len(events)))/a.snapshotEvery {
+			return a.snapshot(ctx, tx, account, version)
+		}
+
+		return nil
+	})
+}
+
+// FindByID replays the stream of the account. Within a transaction it locks the account until the commit, as the
+// state-based repository does, so the balance read is the one updated
+func (a AccountEventSourcedSQL) FindByID(ctx context.Context, ID domain.AccountID) (domain.Account, error) {
+	var q querier = a.db
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		q = tx
+	}
+
+	account, _, err := a.load(ctx, q, ID)
+	return account, err
+}
+
+// load returns the account at the end of its stream and the version of the last entry, zero for an account that has
+// no stream yet, which is returned as the read model holds it. Read by a transaction, the account is locked until it
+// ends; read by the database, it is not, as the lock would be released as soon as taken
+func (a AccountEventSourcedSQL) load(ctx context.Context, q querier, ID domain.AccountID) (domain.Account, int64, error) {
+	var query = "SELECT id, name, cpf, balance, created_at FROM accounts WHERE id = $1"
+	if _, ok := q.(Tx); ok {
+		query += a.db.Dialect().ForNoKeyUpdate()
+	}
+
+	current, err := scanAccount(q.QueryRowContext(ctx, query, ID))
+	switch {
+	case err == sql.ErrNoRows:
+		return domain.Account{}, 0, domain.ErrAccountNotFound
+	case err != nil:
+		return domain.Account{}, 0, errors.Wrap(err, "error find account by id")
+	}
+
+	account, version, err := a.findSnapshot(ctx, q, ID)
+	if err != nil {
+		return domain.Account{}, 0, err
+	}
+
+	events, err := a.findEvents(ctx, q, ID, version)
+	if err != nil {
+		return domain.Account{}, 0, err
+	}
+
+	if version == 0 && len(events) == 0 {
+		return current, 0, nil
+	}
+
+	account, version, err = domain.ReplayAccount(account, version, events)
+	if err != nil {
+		return domain.Account{}, 0, errors.Wrap(err, "error replaying account")
+	}
+
+	return account, version, nil
+}
+
+func (a AccountEventSourcedSQL) findSnapshot(
+	ctx context.Context,
+	q querier,
+	ID domain.AccountID,
+) (domain.Account, int64, error) {
+	var (
+		query = `
+			SELECT version, name, cpf, balance, created_at
+			FROM account_snapshots
+			WHERE account_id = $1
+		`
+		version   int64
+		name      string
+		CPF       string
+		balance   int64
+		createdAt time.Time
+	)
+
+	err := q.QueryRowContext(ctx, query, ID).Scan(&version, &name, &CPF, &balance, &createdAt)
+	switch {
+	case err == sql.ErrNoRows:
+		return domain.Account{}, 0, nil
+	case err != nil:
+		return domain.Account{}, 0, errors.Wrap(err, "error fetching account snapshot")
+	}
+
+	return domain.NewAccount(ID, name, CPF, domain.Money(balance), createdAt), version, nil
+}
+
+func (a AccountEventSourcedSQL) findEvents(
+	ctx context.Context,
+	q querier,
+	ID domain.AccountID,
+	after int64,
+) ([]domain.AccountEvent, error) {
+	var query = `
+		SELECT version, type, name, cpf, amount, occurred_at
+		FROM account_events
+		WHERE account_id = $1 AND version > $2
+		ORDER BY version
+	`
+
+	rows, err := q.QueryContext(ctx, query, ID, after)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing account events")
+	}
+	defer rows.Close()
+
+	var events = make([]domain.AccountEvent, 0)
+	for rows.Next() {
+		var (
+			version    int64
+			kind       string
+			name       string
+			CPF        string
+			amount     int64
+			occurredAt time.Time
+		)
+
+		if err = rows.Scan(&version, &kind, &name, &CPF, &amount, &occurredAt); err != nil {
+			return nil, errors.Wrap(err, "error listing account events")
+		}
+
+		events = append(events, domain.NewAccountEvent(
+			ID,
+			version,
+			domain.AccountEventType(kind),
+			name,
+			CPF,
+			domain.Money(amount),
+			occurredAt,
+		))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// append adds the entries to their stream. An entry whose version is taken means another writer appended first
+func (a AccountEventSourcedSQL) append(ctx context.Context, tx Tx, events ...domain.AccountEvent) error {
+	switch d := a.db.Dialect().(type) {
+	case PostgresDialect, SQLiteDialect:
+	default:
+		return errors.Wrapf(errEventSourcedDialect, "%T", d)
+	}
+
+	var query = `
+		INSERT INTO
+			account_events (account_id, version, type, name, cpf, amount, occurred_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (account_id, version) DO NOTHING
+		RETURNING version
+	`
+
+	for _, e := range events {
+		var version int64
+		err := tx.QueryRowContext(
+			ctx,
+			query,
+			e.AccountID(),
+			e.Version(),
+			e.Kind(),
+			e.Name(),
+			e.CPF(),
+			e.Amount(),
+			e.OccurredAt(),
+		).Scan(&version)
+
+		switch {
+		case err == sql.ErrNoRows:
+			return domain.ErrAccountVersionConflict
+		case err != nil:
+			return errors.Wrap(err, "error appending account event")
+		}
+	}
+
+	return nil
+}
+
+func (a AccountEventSourcedSQL) snapshot(ctx context.Context, tx Tx, account domain.Account, version int64) error {
+	var query = `
+		INSERT INTO
+			account_snapshots (account_id, version, name, cpf, balance, created_at, taken_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (account_id) DO UPDATE SET
+			version = EXCLUDED.version,
+			balance = EXCLUDED.balance,
+			taken_at = EXCLUDED.taken_at
+	`
+
+	if err := tx.ExecuteContext(
+		ctx,
+		query,
+		account.ID(),
+		version,
+		account.Name(),
+		account.CPF(),
+		account.Balance(),
+		account.CreatedAt(),
+		time.Now(),
+	); err != nil {
+		return errors.Wrap(err, "error saving account snapshot")
+	}
+
+	return nil
+}
+
+// withTx runs fn in the transaction carried by ctx, or in one of its own
+func (a AccountEventSourcedSQL) withTx(ctx context.Context, fn func(Tx) error) error {
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		return fn(tx)
+	}
+
+	tx, err := a.db.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func scanAccount(row Row) (domain.Account, error) {
+	var (
+		ID        string
+		name      string
+		CPF       string
+		balance   int64
+		createdAt time.Time
+	)
+
+	if err := row.Scan(&ID, &name, &CPF, &balance, &createdAt); err != nil {
+		return domain.Account{}, err
+	}
+
+	return domain.NewAccount(domain.AccountID(ID), name, CPF, domain.Money(balance), createdAt), nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+// recordingSQL answers every read with no rows and records the queries run on it and on its transactions
+type recordingSQL struct {
+	dialect Dialect
+	queries *[]string
+}
+
+func (r recordingSQL) ExecuteContext(_ context.Context, query string, _ ...interface{}) error {
+	*r.queries = append(*r.queries, query)
+	return nil
+}
+
+func (r recordingSQL) ExecuteAffectedContext(_ context.Context, query string, _ ...interface{}) (int64, error) {
+	*r.queries = append(*r.queries, query)
+	return 0, nil
+}
+
+func (r recordingSQL) QueryContext(_ context.Context, query string, _ ...interface{}) (Rows, error) {
+	*r.queries = append(*r.queries, query)
+	return nil, sql.ErrNoRows
+}
+
+func (r recordingSQL) QueryRowContext(_ context.Context, query string, _ ...interface{}) Row {
+	*r.queries = append(*r.queries, query)
+	return recordingRow{}
+}
+
+func (r recordingSQL) BeginTx(_ context.Context) (Tx, error) {
+	return recordingTx{r}, nil
+}
+
+func (r recordingSQL) Dialect() Dialect {
+	return r.dialect
+}
+
+type recordingTx struct {
+	recordingSQL
+}
+
+func (recordingTx) Commit() error {
+	return nil
+}
+
+func (recordingTx) Rollback() error {
+	return nil
+}
+
+type recordingRow struct{}
+
+func (recordingRow) Scan(_ ...interface{}) error {
+	return sql.ErrNoRows
+}
+
+func TestAccountEventSourcedSQL_FindByID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		inTx     bool
+		expected bool
+	}{
+		{name: "Outside of a transaction", inTx: false, expected: false},
+		{name: "Inside a transaction", inTx: true, expected: true},
+	}
+
+	for _, tt := range tests {
+		var (
+			queries []string
+			db      = recordingSQL{dialect: PostgresDialect{}, queries: &queries}
+			ctx     = context.Background()
+		)
+
+		if tt.inTx {
+			ctx = context.WithValue(ctx, "TransactionContextKey", recordingTx{db})
+		}
+
+		if _, err := NewAccountEventSourcedSQL(db, 100).FindByID(ctx, "a"); err != domain.ErrAccountNotFound {
+			t.Fatalf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, domain.ErrAccountNotFound)
+		}
+
+		if result := strings.HasSuffix(queries[0], "FOR NO KEY UPDATE"); result != tt.expected {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, queries[0], tt.expected)
+		}
+	}
+}
+
+func TestAccountEventSourcedSQL_Create(t *testing.T) {
+	t.Parallel()
+
+	var (
+		queries []string
+		db      = recordingSQL{dialect: MySQLDialect{}, queries: &queries}
+		account = domain.NewAccount("a", "Test", "02815517078", 100, time.Now())
+	)
+
+	_, err := NewAccountEventSourcedSQL(db, 100).Create(context.Background(), account)
+	if errors.Cause(err) != errEventSourcedDialect {
+		t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Rejects the dialect", err, errEventSourcedDialect)
+	}
+
+	if len(queries) != 0 {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Runs no query", queries, nil)
+	}
+}
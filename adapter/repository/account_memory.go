@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type AccountMemory struct {
+	memory *Memory
+}
+
+func NewAccountMemory(memory *Memory) AccountMemory {
+	return AccountMemory{
+		memory: memory,
+	}
+}
+
+func (a AccountMemory) Create(ctx context.Context, account domain.Account) (domain.Account, error) {
+	err := a.memory.WithTransaction(ctx, func(ctx context.Context) error {
+		tx, _ := memoryTxFrom(ctx)
+
+		if err := a.memory.lock(ctx, tx, "accounts:"+account.ID().String()); err != nil {
+			return err
+		}
+
+		// The ledger reconciles the account from the balance it was opened with, as the opening_balance column
+		if err := a.memory.save(ctx, "opening_balances", account.ID().String(), account.Balance()); err != nil {
+			return err
+		}
+
+		a.memory.mu.RLock()
+		defer a.memory.mu.RUnlock()
+
+		if a.memory.hasCPF(account.CPF()) {
+			return errMemoryDuplicateCPF
+		}
+
+		for _, created := range tx.accounts {
+			if created.CPF() == account.CPF() && created.ID() != account.ID() {
+				return errMemoryDuplicateCPF
+			}
+		}
+
+		tx.accounts[account.ID()] = account
+		return nil
+	})
+	if err != nil {
+		return domain.Account{}, errors.Wrap(err, "error creating account")
+	}
+
+	return account, nil
+}
+
+func (a AccountMemory) UpdateBalance(ctx context.Context, ID domain.AccountID, balance domain.Money) error {
+	err := a.memory.WithTransaction(ctx, func(ctx context.Context) error {
+		tx, _ := memoryTxFrom(ctx)
+
+		if err := a.memory.lock(ctx, tx, "accounts:"+ID.String()); err != nil {
+			return err
+		}
+
+		a.memory.mu.RLock()
+		account, ok := a.memory.account(tx, ID)
+		a.memory.mu.RUnlock()
+
+		if !ok {
+			return domain.ErrAccountNotFound
+		}
+
+		tx.accounts[ID] = domain.NewAccount(account.ID(), account.Name(), account.CPF(), balance, account.CreatedAt())
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "error updating account balance")
+	}
+
+	return nil
+}
+
+func (a AccountMemory) FindAll(ctx context.Context, page domain.PageRequest) ([]domain.Account, error) {
+	var tx, _ = memoryTxFrom(ctx)
+
+	a.memory.mu.RLock()
+	defer a.memory.mu.RUnlock()
+
+	var accounts = make([]domain.Account, 0)
+	for _, account := range a.memory.allAccounts(tx) {
+		if len(accounts) == page.Limit {
+			break
+		}
+
+		if page.After.Follows(domain.NewCursor(account.CreatedAt(), account.ID().String())) {
+			accounts = append(accounts, account)
+		}
+	}
+
+	return accounts, nil
+}
+
+// FindByID locks the account when called inside a transaction, so concurrent changes of its balance are serialized
+func (a AccountMemory) FindByID(ctx context.Context, ID domain.AccountID) (domain.Account, error) {
+	var tx, ok = memoryTxFrom(ctx)
+	if ok {
+		if err := a.memory.lock(ctx, tx, "accounts:"+ID.String()); err != nil {
+			return domain.Account{}, errors.Wrap(err, "error find account by id")
+		}
+	}
+
+	a.memory.mu.RLock()
+	defer a.memory.mu.RUnlock()
+
+	account, found := a.memory.account(tx, ID)
+	if !found {
+		return domain.Account{}, domain.ErrAccountNotFound
+	}
+
+	return account, nil
+}
+
+func (a AccountMemory) FindByCPF(ctx context.Context, CPF string) (domain.Account, error) {
+	var tx, _ = memoryTxFrom(ctx)
+
+	a.memory.mu.RLock()
+	defer a.memory.mu.RUnlock()
+
+	for _, account := range a.memory.allAccounts(tx) {
+		if account.CPF() == CPF {
+			return account, nil
+		}
+	}
+
+	return domain.Account{}, domain.ErrAccountNotFound
+}
+
+func (a AccountMemory) FindBalance(ctx context.Context, ID domain.AccountID) (domain.Account, error) {
+	var tx, _ = memoryTxFrom(ctx)
+
+	a.memory.mu.RLock()
+	defer a.memory.mu.RUnlock()
+
+	account, ok := a.memory.account(tx, ID)
+	if !ok {
+		return domain.Account{}, domain.ErrAccountNotFound
+	}
+
+	return domain.NewAccountBalance(account.Balance()), nil
+}
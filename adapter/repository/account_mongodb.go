@@ -11,11 +11,12 @@ import (
 )
 
 type accountBSON struct {
-	ID        string    `bson:"id"`
-	Name      string    `bson:"name"`
-	CPF       string    `bson:"cpf"`
-	Balance   int64     `bson:"balance"`
-	CreatedAt time.Time `bson:"created_at"`
+	ID             string    `bson:"id"`
+	Name           string    `bson:"name"`
+	CPF            string    `bson:"cpf"`
+	Balance        int64     `bson:"balance"`
+	OpeningBalance *int64    `bson:"opening_balance"`
+	CreatedAt      time.Time `bson:"created_at"`
 }
 
 type AccountNoSQL struct {
@@ -31,12 +32,16 @@ func NewAccountNoSQL(db NoSQL) AccountNoSQL {
 }
 
 func (a AccountNoSQL) Create(ctx context.Context, account domain.Account) (domain.Account, error) {
+	// The opening balance is null on the accounts stored before it was recorded
+	var opening = account.Balance().Int64()
+
 	var accountBSON = accountBSON{
-		ID:        account.ID().String(),
-		Name:      account.Name(),
-		CPF:       account.CPF(),
-		Balance:   account.Balance().Int64(),
-		CreatedAt: account.CreatedAt(),
+		ID:             account.ID().String(),
+		Name:           account.Name(),
+		CPF:            account.CPF(),
+		Balance:        account.Balance().Int64(),
+		OpeningBalance: &opening,
+		CreatedAt:      account.CreatedAt(),
 	}
 
 	if err := a.db.Store(ctx, a.collectionName, accountBSON); err != nil {
@@ -64,10 +69,17 @@ func (a AccountNoSQL) UpdateBalance(ctx context.Context, ID domain.AccountID, ba
 	return nil
 }
 
-func (a AccountNoSQL) FindAll(ctx context.Context) ([]domain.Account, error) {
-	var accountsBSON = make([]accountBSON, 0)
+func (a AccountNoSQL) FindAll(ctx context.Context, page domain.PageRequest) ([]domain.Account, error) {
+	var (
+		accountsBSON = make([]accountBSON, 0)
+		query        = bson.M{}
+	)
 
-	if err := a.db.FindAll(ctx, a.collectionName, bson.M{}, &accountsBSON); err != nil {
+	if !page.After.IsZero() {
+		query = keysetQuery(page.After)
+	}
+
+	if err := a.db.FindPage(ctx, a.collectionName, query, keysetSort(domain.Sort{}), int64(page.Limit), &accountsBSON); err != nil {
 		switch err {
 		case mongo.ErrNilDocument:
 			return []domain.Account{}, errors.Wrap(domain.ErrAccountNotFound, "error listing accounts")
@@ -117,6 +129,30 @@ func (a AccountNoSQL) FindByID(ctx context.Context, ID domain.AccountID) (domain
 	), nil
 }
 
+func (a AccountNoSQL) FindByCPF(ctx context.Context, CPF string) (domain.Account, error) {
+	var (
+		accountBSON = &accountBSON{}
+		query       = bson.M{"cpf": CPF}
+	)
+
+	if err := a.db.FindOne(ctx, a.collectionName, query, nil, accountBSON); err != nil {
+		switch err {
+		case mongo.ErrNoDocuments:
+			return domain.Account{}, domain.ErrAccountNotFound
+		default:
+			return domain.Account{}, errors.Wrap(err, "error fetching account by cpf")
+		}
+	}
+
+	return domain.NewAccount(
+		domain.AccountID(accountBSON.ID),
+		accountBSON.Name,
+		accountBSON.CPF,
+		domain.Money(accountBSON.Balance),
+		accountBSON.CreatedAt,
+	), nil
+}
+
 func (a AccountNoSQL) FindBalance(ctx context.Context, ID domain.AccountID) (domain.Account, error) {
 	var (
 		accountBSON = &accountBSON{}
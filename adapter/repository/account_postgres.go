@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/gsabadini/go-clean-architecture/domain"
@@ -22,18 +23,24 @@ func NewAccountSQL(db SQL) AccountSQL {
 func (a AccountSQL) Create(ctx context.Context, account domain.Account) (domain.Account, error) {
 	var query = `
 		INSERT INTO 
-			accounts (id, name, cpf, balance, created_at)
+			accounts (id, name, cpf, balance, opening_balance, created_at)
 		VALUES 
-			($1, $2, $3, $4, $5)
+			($1, $2, $3, $4, $5, $6)
 	`
 
-	if err := a.db.ExecuteContext(
+	var exec = a.db.ExecuteContext
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		exec = tx.ExecuteContext
+	}
+
+	if err := exec(
 		ctx,
 		query,
 		account.ID(),
 		account.Name(),
 		account.CPF(),
 		account.Balance(),
+		account.Balance(),
 		account.CreatedAt(),
 	); err != nil {
 		return domain.Account{}, errors.Wrap(err, "error creating account")
@@ -43,28 +50,37 @@ func (a AccountSQL) Create(ctx context.Context, account domain.Account) (domain.
 }
 
 func (a AccountSQL) UpdateBalance(ctx context.Context, ID domain.AccountID, balance domain.Money) error {
-	tx, ok := ctx.Value("TransactionContextKey").(Tx)
-	if !ok {
-		var err error
-		tx, err = a.db.BeginTx(ctx)
-		if err != nil {
-			return errors.Wrap(err, "error updating account balance")
-		}
-	}
-
 	query := "UPDATE accounts SET balance = $1 WHERE id = $2"
 
-	if err := tx.ExecuteContext(ctx, query, balance, ID); err != nil {
+	var exec = a.db.ExecuteContext
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		exec = tx.ExecuteContext
+	}
+
+	if err := exec(ctx, query, balance, ID); err != nil {
 		return errors.Wrap(err, "error updating account balance")
 	}
 
 	return nil
 }
 
-func (a AccountSQL) FindAll(ctx context.Context) ([]domain.Account, error) {
-	var query = "SELECT * FROM accounts"
+// FindAll seeks the page from its cursor, along the order of created_at and id, instead of skipping the rows before it
+func (a AccountSQL) FindAll(ctx context.Context, page domain.PageRequest) ([]domain.Account, error) {
+	var (
+		query = "SELECT id, name, cpf, balance, created_at FROM accounts"
+		args  []interface{}
+	)
+
+	if !page.After.IsZero() {
+		var condition string
+		condition, args = keysetCondition(page.After, args)
+		query += " WHERE " + condition
+	}
 
-	rows, err := a.db.QueryContext(ctx, query)
+	args = append(args, page.Limit)
+	query += fmt.Sprintf(" ORDER BY created_at, id LIMIT $%d", len(args))
+
+	rows, err := a.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return []domain.Account{}, errors.Wrap(err, "error listing accounts")
 	}
@@ -100,18 +116,20 @@ func (a AccountSQL) FindAll(ctx context.Context) ([]domain.Account, error) {
 	return accounts, nil
 }
 
+// FindByID locks the account when called inside a transaction, so concurrent changes to its balance are serialized
 func (a AccountSQL) FindByID(ctx context.Context, ID domain.AccountID) (domain.Account, error) {
-	tx, ok := ctx.Value("TransactionContextKey").(Tx)
-	if !ok {
-		var err error
-		tx, err = a.db.BeginTx(ctx)
-		if err != nil {
-			return domain.Account{}, errors.Wrap(err, "error find account by id")
-		}
+	var (
+		query = "SELECT id, name, cpf, balance, created_at FROM accounts WHERE id = $1 LIMIT 1"
+		row   Row
+	)
+
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		row = tx.QueryRowContext(ctx, query+a.db.Dialect().ForNoKeyUpdate(), ID)
+	} else {
+		row = a.db.QueryRowContext(ctx, query, ID)
 	}
 
 	var (
-		query     = "SELECT * FROM accounts WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE"
 		id        string
 		name      string
 		CPF       string
@@ -119,7 +137,7 @@ func (a AccountSQL) FindByID(ctx context.Context, ID domain.AccountID) (domain.A
 		createdAt time.Time
 	)
 
-	err := tx.QueryRowContext(ctx, query, ID).Scan(&id, &name, &CPF, &balance, &createdAt)
+	err := row.Scan(&id, &name, &CPF, &balance, &createdAt)
 	switch {
 	case err == sql.ErrNoRows:
 		return domain.Account{}, domain.ErrAccountNotFound
@@ -134,6 +152,33 @@ func (a AccountSQL) FindByID(ctx context.Context, ID domain.AccountID) (domain.A
 	}
 }
 
+func (a AccountSQL) FindByCPF(ctx context.Context, CPF string) (domain.Account, error) {
+	var (
+		query     = "SELECT id, name, cpf, balance, created_at FROM accounts WHERE cpf = $1 LIMIT 1"
+		id        string
+		name      string
+		cpf       string
+		balance   int64
+		createdAt time.Time
+	)
+
+	err := a.db.QueryRowContext(ctx, query, CPF).Scan(&id, &name, &cpf, &balance, &createdAt)
+	switch {
+	case err == sql.ErrNoRows:
+		return domain.Account{}, domain.ErrAccountNotFound
+	case err != nil:
+		return domain.Account{}, errors.Wrap(err, "error fetching account by cpf")
+	default:
+		return domain.NewAccount(
+			domain.AccountID(id),
+			name,
+			cpf,
+			domain.Money(balance),
+			createdAt,
+		), nil
+	}
+}
+
 func (a AccountSQL) FindBalance(ctx context.Context, ID domain.AccountID) (domain.Account, error) {
 	var (
 		query   = "SELECT balance FROM accounts WHERE id = $1"
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+// AccountProjectionSQL keeps the accounts table, the read model of the event-sourced accounts, at the state of
+// their streams
+type AccountProjectionSQL struct{}
+
+func NewAccountProjectionSQL() AccountProjectionSQL {
+	return AccountProjectionSQL{}
+}
+
+// Project writes the state of the account replayed up to its latest entry. It runs in the transaction appending the
+// entries, so the read model never lags behind a committed stream
+func (a AccountProjectionSQL) Project(ctx context.Context, tx Tx, account domain.Account) error {
+	var query = `
+		INSERT INTO
+			accounts (id, name, cpf, balance, opening_balance, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET
+			balance = EXCLUDED.balance
+	`
+
+	if err := tx.ExecuteContext(
+		ctx,
+		query,
+		account.ID(),
+		account.Name(),
+		account.CPF(),
+		account.Balance(),
+		account.Balance(),
+		account.CreatedAt(),
+	); err != nil {
+		return errors.Wrap(err, "error projecting account")
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type APIKeyMemory struct {
+	memory *Memory
+}
+
+func NewAPIKeyMemory(memory *Memory) APIKeyMemory {
+	return APIKeyMemory{
+		memory: memory,
+	}
+}
+
+func (a APIKeyMemory) Create(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error) {
+	if err := a.memory.insert(ctx, "api_keys", apiKey.ID().String(), apiKey); err != nil {
+		return domain.APIKey{}, errors.Wrap(err, "error creating api key")
+	}
+
+	return apiKey, nil
+}
+
+// Update writes the expiration and the revocation of the key, as the SQL one
+func (a APIKeyMemory) Update(ctx context.Context, apiKey domain.APIKey) error {
+	err := a.memory.change(ctx, "api_keys", apiKey.ID().String(), func(value interface{}) interface{} {
+		var stored = value.(domain.APIKey)
+		return domain.NewAPIKey(
+			stored.ID(),
+			stored.ClientID(),
+			stored.Name(),
+			stored.Prefix(),
+			stored.Hash(),
+			stored.Scopes(),
+			apiKey.ExpiresAt(),
+			stored.LastUsedAt(),
+			apiKey.RevokedAt(),
+			stored.CreatedAt(),
+		)
+	})
+	if err != nil {
+		return errors.Wrap(err, "error updating api key")
+	}
+
+	return nil
+}
+
+func (a APIKeyMemory) UpdateLastUsed(ctx context.Context, ID domain.APIKeyID, lastUsedAt time.Time) error {
+	err := a.memory.change(ctx, "api_keys", ID.String(), func(value interface{}) interface{} {
+		var stored = value.(domain.APIKey)
+		return domain.NewAPIKey(
+			stored.ID(),
+			stored.ClientID(),
+			stored.Name(),
+			stored.Prefix(),
+			stored.Hash(),
+			stored.Scopes(),
+			stored.ExpiresAt(),
+			lastUsedAt,
+			stored.RevokedAt(),
+			stored.CreatedAt(),
+		)
+	})
+	if err != nil {
+		return errors.Wrap(err, "error updating api key last use")
+	}
+
+	return nil
+}
+
+func (a APIKeyMemory) FindAll(ctx context.Context) ([]domain.APIKey, error) {
+	var apiKeys = make([]domain.APIKey, 0)
+	for _, value := range a.memory.list(ctx, "api_keys") {
+		apiKeys = append(apiKeys, value.(domain.APIKey))
+	}
+
+	return apiKeys, nil
+}
+
+func (a APIKeyMemory) FindByID(ctx context.Context, ID domain.APIKeyID) (domain.APIKey, error) {
+	value, ok := a.memory.find(ctx, "api_keys", ID.String())
+	if !ok {
+		return domain.APIKey{}, domain.ErrAPIKeyNotFound
+	}
+
+	return value.(domain.APIKey), nil
+}
+
+func (a APIKeyMemory) FindByHash(ctx context.Context, hash string) (domain.APIKey, error) {
+	apiKeys, _ := a.FindAll(ctx)
+	for _, apiKey := range apiKeys {
+		if apiKey.Hash() == hash {
+			return apiKey, nil
+		}
+	}
+
+	return domain.APIKey{}, domain.ErrAPIKeyNotFound
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type apiKeyBSON struct {
+	ID         string     `bson:"id"`
+	ClientID   string     `bson:"client_id"`
+	Name       string     `bson:"name"`
+	Prefix     string     `bson:"prefix"`
+	Hash       string     `bson:"hash"`
+	Scopes     []string   `bson:"scopes"`
+	ExpiresAt  *time.Time `bson:"expires_at"`
+	LastUsedAt *time.Time `bson:"last_used_at"`
+	RevokedAt  *time.Time `bson:"revoked_at"`
+	CreatedAt  time.Time  `bson:"created_at"`
+}
+
+type APIKeyNoSQL struct {
+	collectionName string
+	db             NoSQL
+}
+
+func NewAPIKeyNoSQL(db NoSQL) APIKeyNoSQL {
+	return APIKeyNoSQL{
+		db:             db,
+		collectionName: "api_keys",
+	}
+}
+
+func (a APIKeyNoSQL) Create(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error) {
+	var scopes = make([]string, 0, len(apiKey.Scopes()))
+	for _, s := range apiKey.Scopes() {
+		scopes = append(scopes, s.String())
+	}
+
+	var apiKeyBSON = apiKeyBSON{
+		ID:         apiKey.ID().String(),
+		ClientID:   apiKey.ClientID(),
+		Name:       apiKey.Name(),
+		Prefix:     apiKey.Prefix(),
+		Hash:       apiKey.Hash(),
+		Scopes:     scopes,
+		ExpiresAt:  optionalTimeBSON(apiKey.ExpiresAt()),
+		LastUsedAt: optionalTimeBSON(apiKey.LastUsedAt()),
+		RevokedAt:  optionalTimeBSON(apiKey.RevokedAt()),
+		CreatedAt:  apiKey.CreatedAt(),
+	}
+
+	if err := a.db.Store(ctx, a.collectionName, apiKeyBSON); err != nil {
+		return domain.APIKey{}, errors.Wrap(err, "error creating api key")
+	}
+
+	return apiKey, nil
+}
+
+func (a APIKeyNoSQL) Update(ctx context.Context, apiKey domain.APIKey) error {
+	var (
+		query  = bson.M{"id": apiKey.ID()}
+		update = bson.M{"$set": bson.M{
+			"expires_at": optionalTimeBSON(apiKey.ExpiresAt()),
+			"revoked_at": optionalTimeBSON(apiKey.RevokedAt()),
+		}}
+	)
+
+	if err := a.db.Update(ctx, a.collectionName, query, update); err != nil {
+		return errors.Wrap(err, "error updating api key")
+	}
+
+	return nil
+}
+
+func (a APIKeyNoSQL) UpdateLastUsed(ctx context.Context, ID domain.APIKeyID, lastUsedAt time.Time) error {
+	var (
+		query  = bson.M{"id": ID}
+		update = bson.M{"$set": bson.M{"last_used_at": lastUsedAt}}
+	)
+
+	if err := a.db.Update(ctx, a.collectionName, query, update); err != nil {
+		return errors.Wrap(err, "error updating api key last use")
+	}
+
+	return nil
+}
+
+func (a APIKeyNoSQL) FindAll(ctx context.Context) ([]domain.APIKey, error) {
+	var apiKeysBSON = make([]apiKeyBSON, 0)
+
+	if err := a.db.FindAll(ctx, a.collectionName, bson.M{}, &apiKeysBSON); err != nil {
+		return []domain.APIKey{}, errors.Wrap(err, "error listing api keys")
+	}
+
+	var apiKeys = make([]domain.APIKey, 0)
+	for _, apiKeyBSON := range apiKeysBSON {
+		apiKeys = append(apiKeys, apiKeyBSON.toDomain())
+	}
+
+	return apiKeys, nil
+}
+
+func (a APIKeyNoSQL) FindByID(ctx context.Context, ID domain.APIKeyID) (domain.APIKey, error) {
+	return a.findOne(ctx, bson.M{"id": ID})
+}
+
+func (a APIKeyNoSQL) FindByHash(ctx context.Context, hash string) (domain.APIKey, error) {
+	return a.findOne(ctx, bson.M{"hash": hash})
+}
+
+func (a APIKeyNoSQL) findOne(ctx context.Context, query bson.M) (domain.APIKey, error) {
+	var apiKeyBSON = &apiKeyBSON{}
+
+	if err := a.db.FindOne(ctx, a.collectionName, query, nil, apiKeyBSON); err != nil {
+		switch err {
+		case mongo.ErrNoDocuments:
+			return domain.APIKey{}, domain.ErrAPIKeyNotFound
+		default:
+			return domain.APIKey{}, errors.Wrap(err, "error fetching api key")
+		}
+	}
+
+	return apiKeyBSON.toDomain(), nil
+}
+
+func (a apiKeyBSON) toDomain() domain.APIKey {
+	var scopes = make([]domain.Scope, 0, len(a.Scopes))
+	for _, s := range a.Scopes {
+		scopes = append(scopes, domain.Scope(s))
+	}
+
+	return domain.NewAPIKey(
+		domain.APIKeyID(a.ID),
+		a.ClientID,
+		a.Name,
+		a.Prefix,
+		a.Hash,
+		scopes,
+		timeFromBSON(a.ExpiresAt),
+		timeFromBSON(a.LastUsedAt),
+		timeFromBSON(a.RevokedAt),
+		a.CreatedAt,
+	)
+}
+
+// optionalTimeBSON stores the zero time as null
+func optionalTimeBSON(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func timeFromBSON(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type APIKeySQL struct {
+	db SQL
+}
+
+func NewAPIKeySQL(db SQL) APIKeySQL {
+	return APIKeySQL{
+		db: db,
+	}
+}
+
+func (a APIKeySQL) Create(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error) {
+	var query = `
+		INSERT INTO
+			api_keys (id, client_id, name, prefix, hash, scopes, expires_at, last_used_at, revoked_at, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	if err := a.db.ExecuteContext(
+		ctx,
+		query,
+		apiKey.ID(),
+		apiKey.ClientID(),
+		apiKey.Name(),
+		apiKey.Prefix(),
+		apiKey.Hash(),
+		domain.JoinScopes(apiKey.Scopes()),
+		nullTime(apiKey.ExpiresAt()),
+		nullTime(apiKey.LastUsedAt()),
+		nullTime(apiKey.RevokedAt()),
+		apiKey.CreatedAt(),
+	); err != nil {
+		return domain.APIKey{}, errors.Wrap(err, "error creating api key")
+	}
+
+	return apiKey, nil
+}
+
+func (a APIKeySQL) Update(ctx context.Context, apiKey domain.APIKey) error {
+	var query = "UPDATE api_keys SET expires_at = $1, revoked_at = $2 WHERE id = $3"
+
+	if err := a.db.ExecuteContext(
+		ctx,
+		query,
+		nullTime(apiKey.ExpiresAt()),
+		nullTime(apiKey.RevokedAt()),
+		apiKey.ID(),
+	); err != nil {
+		return errors.Wrap(err, "error updating api key")
+	}
+
+	return nil
+}
+
+func (a APIKeySQL) UpdateLastUsed(ctx context.Context, ID domain.APIKeyID, lastUsedAt time.Time) error {
+	var query = "UPDATE api_keys SET last_used_at = $1 WHERE id = $2"
+
+	if err := a.db.ExecuteContext(ctx, query, lastUsedAt, ID); err != nil {
+		return errors.Wrap(err, "error updating api key last use")
+	}
+
+	return nil
+}
+
+func (a APIKeySQL) FindAll(ctx context.Context) ([]domain.APIKey, error) {
+	var query = `
+		SELECT id, client_id, name, prefix, hash, scopes, expires_at, last_used_at, revoked_at, created_at
+		FROM api_keys
+		ORDER BY created_at
+	`
+
+	rows, err := a.db.QueryContext(ctx, query)
+	if err != nil {
+		return []domain.APIKey{}, errors.Wrap(err, "error listing api keys")
+	}
+	defer rows.Close()
+
+	var apiKeys = make([]domain.APIKey, 0)
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return []domain.APIKey{}, errors.Wrap(err, "error listing api keys")
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	if err = rows.Err(); err != nil {
+		return []domain.APIKey{}, err
+	}
+
+	return apiKeys, nil
+}
+
+func (a APIKeySQL) FindByID(ctx context.Context, ID domain.APIKeyID) (domain.APIKey, error) {
+	var query = `
+		SELECT id, client_id, name, prefix, hash, scopes, expires_at, last_used_at, revoked_at, created_at
+		FROM api_keys
+		WHERE id = $1
+	`
+
+	return a.findOne(ctx, query, ID)
+}
+
+func (a APIKeySQL) FindByHash(ctx context.Context, hash string) (domain.APIKey, error) {
+	var query = `
+		SELECT id, client_id, name, prefix, hash, scopes, expires_at, last_used_at, revoked_at, created_at
+		FROM api_keys
+		WHERE hash = $1
+	`
+
+	return a.findOne(ctx, query, hash)
+}
+
+func (a APIKeySQL) findOne(ctx context.Context, query string, arg interface{}) (domain.APIKey, error) {
+	apiKey, err := scanAPIKey(a.db.QueryRowContext(ctx, query, arg))
+	switch {
+	case err == sql.ErrNoRows:
+		return domain.APIKey{}, domain.ErrAPIKeyNotFound
+	case err != nil:
+		return domain.APIKey{}, errors.Wrap(err, "error fetching api key")
+	default:
+		return apiKey, nil
+	}
+}
+
+func scanAPIKey(row Row) (domain.APIKey, error) {
+	var (
+		ID         string
+		clientID   string
+		name       string
+		prefix     string
+		hash       string
+		scopes     string
+		expiresAt  sql.NullTime
+		lastUsedAt sql.NullTime
+		revokedAt  sql.NullTime
+		createdAt  time.Time
+	)
+
+	if err := row.Scan(
+		&ID,
+		&clientID,
+		&name,
+		&prefix,
+		&hash,
+		&scopes,
+		&expiresAt,
+		&lastUsedAt,
+		&revokedAt,
+		&createdAt,
+	); err != nil {
+		return domain.APIKey{}, err
+	}
+
+	return domain.NewAPIKey(
+		domain.APIKeyID(ID),
+		clientID,
+		name,
+		prefix,
+		hash,
+		domain.ParseScopes(scopes),
+		expiresAt.Time,
+		lastUsedAt.Time,
+		revokedAt.Time,
+		createdAt,
+	), nil
+}
+
+// nullTime stores the zero time as NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
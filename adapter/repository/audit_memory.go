@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type AuditMemory struct {
+	memory *Memory
+}
+
+func NewAuditMemory(memory *Memory) AuditMemory {
+	return AuditMemory{
+		memory: memory,
+	}
+}
+
+func (a AuditMemory) Append(ctx context.Context, record domain.AuditRecord) error {
+	if err := a.memory.insert(ctx, "audit_records", record.ID(), record); err != nil {
+		return errors.Wrap(err, "error appending audit record")
+	}
+
+	return nil
+}
+
+func (a AuditMemory) Find(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditRecord, error) {
+	var records = make([]domain.AuditRecord, 0)
+	for _, value := range a.memory.list(ctx, "audit_records") {
+		var record = value.(domain.AuditRecord)
+
+		switch {
+		case filter.Actor != "" && record.Actor() != filter.Actor:
+		case filter.Target != "" && !hasAuditTarget(record, filter.Target):
+		case !filter.From.IsZero() && record.OccurredAt().Before(filter.From):
+		case !filter.To.IsZero() && record.OccurredAt().After(filter.To):
+		default:
+			records = append(records, record)
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].OccurredAt().Equal(records[j].OccurredAt()) {
+			return records[i].ID() < records[j].ID()
+		}
+
+		return records[i].OccurredAt().After(records[j].OccurredAt())
+	})
+
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[:filter.Limit]
+	}
+
+	return records, nil
+}
+
+func hasAuditTarget(record domain.AuditRecord, target string) bool {
+	for _, t := range record.Targets() {
+		if t == target {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type auditRecordBSON struct {
+	ID         string    `bson:"id"`
+	Actor      string    `bson:"actor"`
+	Role       string    `bson:"role"`
+	Action     string    `bson:"action"`
+	Targets    []string  `bson:"targets"`
+	InputHash  string    `bson:"input_hash"`
+	Payload    string    `bson:"payload"`
+	Outcome    string    `bson:"outcome"`
+	Error      string    `bson:"error"`
+	RequestID  string    `bson:"request_id"`
+	OccurredAt time.Time `bson:"occurred_at"`
+}
+
+// AuditNoSQL only ever stores into audit_records. MongoDB has no append-only collections, so keeping others from
+// changing it is up to the grants of the database users
+type AuditNoSQL struct {
+	collectionName string
+	db             NoSQL
+}
+
+func NewAuditNoSQL(db NoSQL) AuditNoSQL {
+	return AuditNoSQL{
+		db:             db,
+		collectionName: "audit_records",
+	}
+}
+
+func (a AuditNoSQL) Append(ctx context.Context, record domain.AuditRecord) error {
+	var recordBSON = auditRecordBSON{
+		ID:         record.ID(),
+		Actor:      record.Actor(),
+		Role:       record.Role().String(),
+		Action:     record.Action(),
+		Targets:    record.Targets(),
+		InputHash:  record.InputHash(),
+		Payload:    record.Payload(),
+		Outcome:    record.Outcome().String(),
+		Error:      record.ErrorMessage(),
+		RequestID:  record.RequestID(),
+		OccurredAt: record.OccurredAt(),
+	}
+
+	if err := a.db.Store(ctx, a.collectionName, recordBSON); err != nil {
+		return errors.Wrap(err, "error appending audit record")
+	}
+
+	return nil
+}
+
+func (a AuditNoSQL) Find(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditRecord, error) {
+	var (
+		query       = bson.M{}
+		occurredAt  = bson.M{}
+		recordsBSON = make([]auditRecordBSON, 0)
+	)
+
+	if filter.Actor != "" {
+		query["actor"] = filter.Actor
+	}
+
+	if filter.Target != "" {
+		query["targets"] = filter.Target
+	}
+
+	if !filter.From.IsZero() {
+		occurredAt["$gte"] = filter.From
+	}
+
+	if !filter.To.IsZero() {
+		occurredAt["$lte"] = filter.To
+	}
+
+	if len(occurredAt) > 0 {
+		query["occurred_at"] = occurredAt
+	}
+
+	if err := a.db.FindAll(ctx, a.collectionName, query, &recordsBSON); err != nil {
+		return []domain.AuditRecord{}, errors.Wrap(err, "error listing audit records")
+	}
+
+	sort.SliceStable(recordsBSON, func(i, j int) bool {
+		return recordsBSON[i].OccurredAt.After(recordsBSON[j].OccurredAt)
+	})
+
+	if filter.Limit > 0 && len(recordsBSON) > filter.Limit {
+		recordsBSON = recordsBSON[:filter.Limit]
+	}
+
+	var records = make([]domain.AuditRecord, 0, len(recordsBSON))
+	for _, r := range recordsBSON {
+		records = append(records, domain.NewAuditRecord(
+			r.ID,
+			r.Actor,
+			domain.Role(r.Role),
+			r.Action,
+			r.Targets,
+			r.InputHash,
+			r.Payload,
+			domain.AuditOutcome(r.Outcome),
+			r.Error,
+			r.RequestID,
+			r.OccurredAt,
+		))
+	}
+
+	return records, nil
+}
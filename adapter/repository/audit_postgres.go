@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+// AuditSQL appends to audit_records, which a trigger keeps from being changed. The targets of a record are also
+// written to audit_record_targets, the index of the queries by target
+type AuditSQL struct {
+	db SQL
+}
+
+func NewAuditSQL(db SQL) AuditSQL {
+	return AuditSQL{
+		db: db,
+	}
+}
+
+func (a AuditSQL) Append(ctx context.Context, record domain.AuditRecord) error {
+	tx, err := a.db.BeginTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error appending audit record")
+	}
+
+	if err = a.append(ctx, tx, record); err != nil {
+		_ = tx.Rollback()
+		return errors.Wrap(err, "error appending audit record")
+	}
+
+	return tx.Commit()
+}
+
+func (a AuditSQL) append(ctx context.Context, tx Tx, record domain.AuditRecord) error {
+	var query = `
+		INSERT INTO
+			audit_records (id, actor, role, action, targets, input_hash, payload, outcome, error, request_id, occurred_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	if err := tx.ExecuteContext(
+		ctx,
+		query,
+		record.ID(),
+		record.Actor(),
+		record.Role(),
+		record.Action(),
+		strings.Join(record.Targets(), " "),
+		record.InputHash(),
+		record.Payload(),
+		record.Outcome(),
+		record.ErrorMessage(),
+		record.RequestID(),
+		record.OccurredAt(),
+	); err != nil {
+		return err
+	}
+
+	for _, target := range record.Targets() {
+		if err := tx.ExecuteContext(
+			ctx,
+			"INSERT INTO audit_record_targets (record_id, target) VALUES ($1, $2)"+a.db.Dialect().OnConflictDoNothing("target", "record_id"),
+			record.ID(),
+			target,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a AuditSQL) Find(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditRecord, error) {
+	var (
+		query = `
+			SELECT id, actor, role, action, targets, input_hash, payload, outcome, error, request_id, occurred_at
+			FROM audit_records
+		`
+		conditions []string
+		args       []interface{}
+	)
+
+	if filter.Actor != "" {
+		args = append(args, filter.Actor)
+		conditions = append(conditions, fmt.Sprintf("actor = $%d", len(args)))
+	}
+
+	if filter.Target != "" {
+		args = append(args, filter.Target)
+		conditions = append(conditions, fmt.Sprintf(
+			"id IN (SELECT record_id FROM audit_record_targets WHERE target = $%d)",
+			len(args),
+		))
+	}
+
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		conditions = append(conditions, fmt.Sprintf("occurred_at >= $%d", len(args)))
+	}
+
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		conditions = append(conditions, fmt.Sprintf("occurred_at <= $%d", len(args)))
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY occurred_at DESC, id"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := a.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return []domain.AuditRecord{}, errors.Wrap(err, "error listing audit records")
+	}
+	defer rows.Close()
+
+	var records = make([]domain.AuditRecord, 0)
+	for rows.Next() {
+		var (
+			ID         string
+			actor      string
+			role       string
+			action     string
+			targets    string
+			inputHash  string
+			payload    string
+			outcome    string
+			errMessage string
+			requestID  string
+			occurredAt time.Time
+		)
+
+		if err = rows.Scan(
+			&ID,
+			&actor,
+			&role,
+			&action,
+			&targets,
+			&inputHash,
+			&payload,
+			&outcome,
+			&errMessage,
+			&requestID,
+			&occurredAt,
+		); err != nil {
+			return []domain.AuditRecord{}, errors.Wrap(err, "error listing audit records")
+		}
+
+		records = append(records, domain.NewAuditRecord(
+			ID,
+			actor,
+			domain.Role(role),
+			action,
+			strings.Fields(targets),
+			inputHash,
+			payload,
+			domain.AuditOutcome(outcome),
+			errMessage,
+			requestID,
+			occurredAt,
+		))
+	}
+
+	if err = rows.Err(); err != nil {
+		return []domain.AuditRecord{}, err
+	}
+
+	return records, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type DailyBalanceMemory struct {
+	memory *Memory
+}
+
+func NewDailyBalanceMemory(memory *Memory) DailyBalanceMemory {
+	return DailyBalanceMemory{
+		memory: memory,
+	}
+}
+
+// Save writes the snapshots in one transaction, so an account never has some of the days of a pass closed
+func (d DailyBalanceMemory) Save(ctx context.Context, balances ...domain.DailyBalance) error {
+	err := d.memory.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, balance := range balances {
+			var key = balance.AccountID().String() + ":" + balance.Day().Format("2006-01-02")
+			if err := d.memory.save(ctx, "account_daily_balances", key, balance); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "error saving daily balances")
+	}
+
+	return nil
+}
+
+func (d DailyBalanceMemory) FindByAccountID(
+	ctx context.Context,
+	ID domain.AccountID,
+	from time.Time,
+	to time.Time,
+) ([]domain.DailyBalance, error) {
+	var balances = make([]domain.DailyBalance, 0)
+	for _, balance := range d.findAll(ctx, ID) {
+		if !balance.Day().Before(from) && !balance.Day().After(to) {
+			balances = append(balances, balance)
+		}
+	}
+
+	return balances, nil
+}
+
+func (d DailyBalanceMemory) FindLatest(ctx context.Context, ID domain.AccountID) (domain.DailyBalance, error) {
+	var balances = d.findAll(ctx, ID)
+	if len(balances) == 0 {
+		return domain.DailyBalance{}, domain.ErrDailyBalanceNotFound
+	}
+
+	return balances[len(balances)-1], nil
+}
+
+// findAll returns the snapshots of the account, oldest first
+func (d DailyBalanceMemory) findAll(ctx context.Context, ID domain.AccountID) []domain.DailyBalance {
+	var balances = make([]domain.DailyBalance, 0)
+	for _, value := range d.memory.list(ctx, "account_daily_balances") {
+		if balance := value.(domain.DailyBalance); balance.AccountID() == ID {
+			balances = append(balances, balance)
+		}
+	}
+
+	sort.SliceStable(balances, func(i, j int) bool {
+		return balances[i].Day().Before(balances[j].Day())
+	})
+
+	return balances
+}
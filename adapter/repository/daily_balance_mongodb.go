@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type dailyBalanceBSON struct {
+	AccountID        string    `bson:"account_id"`
+	Day              time.Time `bson:"day"`
+	ClosingBalance   int64     `bson:"closing_balance"`
+	Credits          int64     `bson:"credits"`
+	Debits           int64     `bson:"debits"`
+	TransactionCount int       `bson:"transaction_count"`
+}
+
+func (d dailyBalanceBSON) toDomain() domain.DailyBalance {
+	return domain.NewDailyBalance(
+		domain.AccountID(d.AccountID),
+		d.Day,
+		domain.Money(d.ClosingBalance),
+		domain.Money(d.Credits),
+		domain.Money(d.Debits),
+		d.TransactionCount,
+	)
+}
+
+type DailyBalanceNoSQL struct {
+	collectionName string
+	db             NoSQL
+}
+
+func NewDailyBalanceNoSQL(db NoSQL) DailyBalanceNoSQL {
+	return DailyBalanceNoSQL{
+		db:             db,
+		collectionName: "account_daily_balances",
+	}
+}
+
+// Save writes the snapshots. The unique index on account_id and day rejects a day already closed, which is updated
+// instead
+func (d DailyBalanceNoSQL) Save(ctx context.Context, balances ...domain.DailyBalance) error {
+	for _, balance := range balances {
+		var balanceBSON = dailyBalanceBSON{
+			AccountID:        balance.AccountID().String(),
+			Day:              balance.Day(),
+			ClosingBalance:   balance.ClosingBalance().Int64(),
+			Credits:          balance.Credits().Int64(),
+			Debits:           balance.Debits().Int64(),
+			TransactionCount: balance.TransactionCount(),
+		}
+
+		err := d.db.Store(ctx, d.collectionName, balanceBSON)
+		if err != nil && mongo.IsDuplicateKeyError(err) {
+			err = d.db.Update(
+				ctx,
+				d.collectionName,
+				bson.M{"account_id": balanceBSON.AccountID, "day": balanceBSON.Day},
+				bson.M{"$set": balanceBSON},
+			)
+		}
+
+		if err != nil {
+			return errors.Wrap(err, "error saving daily balances")
+		}
+	}
+
+	return nil
+}
+
+func (d DailyBalanceNoSQL) FindByAccountID(
+	ctx context.Context,
+	ID domain.AccountID,
+	from time.Time,
+	to time.Time,
+) ([]domain.DailyBalance, error) {
+	var (
+		balancesBSON = make([]dailyBalanceBSON, 0)
+		query        = bson.M{
+			"account_id": ID,
+			"day":        bson.M{"$gte": from, "$lte": to},
+		}
+	)
+
+	if err := d.db.FindAll(ctx, d.collectionName, query, &balancesBSON); err != nil {
+		return []domain.DailyBalance{}, errors.Wrap(err, "error listing daily balances")
+	}
+
+	sort.SliceStable(balancesBSON, func(i, j int) bool {
+		return balancesBSON[i].Day.Before(balancesBSON[j].Day)
+	})
+
+	var balances = make([]domain.DailyBalance, 0, len(balancesBSON))
+	for _, balanceBSON := range balancesBSON {
+		balances = append(balances, balanceBSON.toDomain())
+	}
+
+	return balances, nil
+}
+
+func (d DailyBalanceNoSQL) FindLatest(ctx context.Context, ID domain.AccountID) (domain.DailyBalance, error) {
+	var balancesBSON = make([]dailyBalanceBSON, 0)
+
+	if err := d.db.FindAll(ctx, d.collectionName, bson.M{"account_id": ID}, &balancesBSON); err != nil {
+		return domain.DailyBalance{}, errors.Wrap(err, "error fetching daily balance")
+	}
+
+	if len(balancesBSON) == 0 {
+		return domain.DailyBalance{}, domain.ErrDailyBalanceNotFound
+	}
+
+	var latest = balancesBSON[0]
+	for _, balanceBSON := range balancesBSON[1:] {
+		if balanceBSON.Day.After(latest.Day) {
+			latest = balanceBSON
+		}
+	}
+
+	return latest.toDomain(), nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type DailyBalanceSQL struct {
+	db SQL
+}
+
+func NewDailyBalanceSQL(db SQL) DailyBalanceSQL {
+	return DailyBalanceSQL{
+		db: db,
+	}
+}
+
+// Save writes the snapshots in one transaction, so an account never has some of the days of a pass closed
+func (d DailyBalanceSQL) Save(ctx context.Context, balances ...domain.DailyBalance) error {
+	tx, err := d.db.BeginTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error saving daily balances")
+	}
+
+	var query = `
+		INSERT INTO
+			account_daily_balances (account_id, day, closing_balance, credits, debits, transaction_count)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+	` + d.db.Dialect().OnConflictUpdate(
+		[]string{"account_id", "day"},
+		"closing_balance",
+		"credits",
+		"debits",
+		"transaction_count",
+	)
+
+	for _, balance := range balances {
+		if err = tx.ExecuteContext(
+			ctx,
+			query,
+			balance.AccountID(),
+			balance.Day(),
+			balance.ClosingBalance(),
+			balance.Credits(),
+			balance.Debits(),
+			balance.TransactionCount(),
+		); err != nil {
+			_ = tx.Rollback()
+			return errors.Wrap(err, "error saving daily balances")
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (d DailyBalanceSQL) FindByAccountID(
+	ctx context.Context,
+	ID domain.AccountID,
+	from time.Time,
+	to time.Time,
+) ([]domain.DailyBalance, error) {
+	var query = `
+		SELECT account_id, day, closing_balance, credits, debits, transaction_count
+		FROM account_daily_balances
+		WHERE account_id = $1 AND day >= $2 AND day <= $3
+		ORDER BY day
+	`
+
+	rows, err := d.db.QueryContext(ctx, query, ID, from, to)
+	if err != nil {
+		return []domain.DailyBalance{}, errors.Wrap(err, "error listing daily balances")
+	}
+	defer rows.Close()
+
+	var balances = make([]domain.DailyBalance, 0)
+	for rows.Next() {
+		balance, err := scanDailyBalance(rows)
+		if err != nil {
+			return []domain.DailyBalance{}, errors.Wrap(err, "error listing daily balances")
+		}
+
+		balances = append(balances, balance)
+	}
+
+	if err = rows.Err(); err != nil {
+		return []domain.DailyBalance{}, err
+	}
+
+	return balances, nil
+}
+
+func (d DailyBalanceSQL) FindLatest(ctx context.Context, ID domain.AccountID) (domain.DailyBalance, error) {
+	var query = `
+		SELECT account_id, day, closing_balance, credits, debits, transaction_count
+		FROM account_daily_balances
+		WHERE account_id = $1
+		ORDER BY day DESC
+		LIMIT 1
+	`
+
+	balance, err := scanDailyBalance(d.db.QueryRowContext(ctx, query, ID))
+	switch {
+	case err == sql.ErrNoRows:
+		return domain.DailyBalance{}, domain.ErrDailyBalanceNotFound
+	case err != nil:
+		return domain.DailyBalance{}, errors.Wrap(err, "error fetching daily balance")
+	default:
+		return balance, nil
+	}
+}
+
+func scanDailyBalance(row Row) (domain.DailyBalance, error) {
+	var (
+		accountID        string
+		day              time.Time
+		closingBalance   int64
+		credits          int64
+		debits           int64
+		transactionCount int
+	)
+
+	if err := row.Scan(&accountID, &day, &closingBalance, &credits, &debits, &transactionCount); err != nil {
+		return domain.DailyBalance{}, err
+	}
+
+	return domain.NewDailyBalance(
+		domain.AccountID(accountID),
+		day,
+		domain.Money(closingBalance),
+		domain.Money(credits),
+		domain.Money(debits),
+		transactionCount,
+	), nil
+}
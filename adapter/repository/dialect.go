@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialect writes the parts of a query that differ between the SQL engines. Queries are written as for Postgres, with
+// $1 placeholders, and the handler of each engine rebinds them with its dialect before running them
+type Dialect interface {
+	// Rebind rewrites the placeholders of the query and the arguments bound to them for the engine
+	Rebind(query string, args []interface{}) (string, []interface{})
+	// ForUpdate locks the rows read inside a transaction until it ends
+	ForUpdate() string
+	// ForNoKeyUpdate locks the rows read inside a transaction until it ends, for changes leaving their keys alone
+	ForNoKeyUpdate() string
+	// ForUpdateSkipLocked locks the rows read inside a transaction until it ends, leaving out the rows another
+	// transaction holds, so workers running side by side claim different rows
+	ForUpdateSkipLocked() string
+	// Date truncates the timestamp of the column to its day
+	Date(column string) string
+	// Integer casts the expression, such as a sum, to a 64-bit integer
+	Integer(expr string) string
+	// OnConflictDoNothing ends an insert skipping the row when its key is taken
+	OnConflictDoNothing(key ...string) string
+	// OnConflictUpdate ends an insert overwriting the columns of the row whose key is taken with the values inserted
+	OnConflictUpdate(key []string, columns ...string) string
+}
+
+// PostgresDialect is the dialect the queries are written in
+type PostgresDialect struct{}
+
+func (PostgresDialect) Rebind(query string, args []interface{}) (string, []interface{}) {
+	return query, args
+}
+
+func (PostgresDialect) ForUpdate() string {
+	return " FOR UPDATE"
+}
+
+func (PostgresDialect) ForNoKeyUpdate() string {
+	return " FOR NO KEY UPDATE"
+}
+
+func (PostgresDialect) ForUpdateSkipLocked() string {
+	return " FOR UPDATE SKIP LOCKED"
+}
+
+func (PostgresDialect) Date(column string) string {
+	return "CAST(" + column + " AS DATE)"
+}
+
+func (PostgresDialect) Integer(expr string) string {
+	return "CAST(" + expr + " AS BIGINT)"
+}
+
+func (PostgresDialect) OnConflictDoNothing(key ...string) string {
+	return onConflictDoNothing(key)
+}
+
+func (PostgresDialect) OnConflictUpdate(key []string, columns ...string) string {
+	return onConflictUpdate(key, columns)
+}
+
+// SQLiteDialect binds arguments with ? placeholders. SQLite has no row locks: its transactions take the write lock of
+// the database as they begin, which serializes them as the locks would. Timestamps are stored as text, so they are
+// bound in UTC, where comparing them as text compares them in time
+type SQLiteDialect struct{}
+
+func (SQLiteDialect) Rebind(query string, args []interface{}) (string, []interface{}) {
+	query, args = rebindQuestion(query, args)
+
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case time.Time:
+			args[i] = v.UTC()
+		case sql.NullTime:
+			args[i] = sql.NullTime{Time: v.Time.UTC(), Valid: v.Valid}
+		}
+	}
+
+	return query, args
+}
+
+func (SQLiteDialect) ForUpdate() string {
+	return ""
+}
+
+func (SQLiteDialect) ForNoKeyUpdate() string {
+	return ""
+}
+
+func (SQLiteDialect) ForUpdateSkipLocked() string {
+	return ""
+}
+
+func (SQLiteDialect) Date(column string) string {
+	return "DATE(" + column + ")"
+}
+
+func (SQLiteDialect) Integer(expr string) string {
+	return "CAST(" + expr + " AS BIGINT)"
+}
+
+func (SQLiteDialect) OnConflictDoNothing(key ...string) string {
+	return onConflictDoNothing(key)
+}
+
+func (SQLiteDialect) OnConflictUpdate(key []string, columns ...string) string {
+	return onConflictUpdate(key, columns)
+}
+
+// MySQLDialect binds arguments with ? placeholders. MySQL has no lock for changes leaving the keys alone, so the rows
+// are locked FOR UPDATE, and an insert whose key is taken is turned into an update of the row
+type MySQLDialect struct{}
+
+func (MySQLDialect) Rebind(query string, args []interface{}) (string, []interface{}) {
+	return rebindQuestion(query, args)
+}
+
+func (MySQLDialect) ForUpdate() string {
+	return " FOR UPDATE"
+}
+
+func (MySQLDialect) ForNoKeyUpdate() string {
+	return " FOR UPDATE"
+}
+
+func (MySQLDialect) ForUpdateSkipLocked() string {
+	return " FOR UPDATE SKIP LOCKED"
+}
+
+func (MySQLDialect) Date(column string) string {
+	return "DATE(" + column + ")"
+}
+
+func (MySQLDialect) Integer(expr string) string {
+	return "CAST(" + expr + " AS SIGNED)"
+}
+
+// OnConflictDoNothing sets the first column of the key to itself, which leaves the row as it is
+func (MySQLDialect) OnConflictDoNothing(key ...string) string {
+	return " ON DUPLICATE KEY UPDATE " + key[0] + " = " + key[0]
+}
+
+func (MySQLDialect) OnConflictUpdate(_ []string, columns ...string) string {
+	var set = make([]string, 0, len(columns))
+	for _, column := range columns {
+		set = append(set, column+" = VALUES("+column+")")
+	}
+
+	return " ON DUPLICATE KEY UPDATE " + strings.Join(set, ", ")
+}
+
+func onConflictDoNothing(key []string) string {
+	return " ON CONFLICT (" + strings.Join(key, ", ") + ") DO NOTHING"
+}
+
+func onConflictUpdate(key []string, columns []string) string {
+	var set = make([]string, 0, len(columns))
+	for _, column := range columns {
+		set = append(set, column+" = EXCLUDED."+column)
+	}
+
+	return " ON CONFLICT (" + strings.Join(key, ", ") + ") DO UPDATE SET " + strings.Join(set, ", ")
+}
+
+// rebindQuestion replaces the $n placeholders with ?, which are bound by position, repeating the arguments used more
+// than once. Placeholders inside quoted literals are left alone
+func rebindQuestion(query string, args []interface{}) (string, []interface{}) {
+	var (
+		b     strings.Builder
+		bound = make([]interface{}, 0, len(args))
+		quote byte
+	)
+
+	b.Grow(len(query))
+
+	for i := 0; i < len(query); i++ {
+		var c = query[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '$':
+			var j = i + 1
+			for j < len(query) && query[j] >= '0' && query[j] <= '9' {
+				j++
+			}
+
+			if n, err := strconv.Atoi(query[i+1 : j]); err == nil && n >= 1 && n <= len(args) {
+				b.WriteByte('?')
+				bound = append(bound, args[n-1])
+				i = j - 1
+				continue
+			}
+		}
+
+		b.WriteByte(c)
+	}
+
+	return b.String(), bound
+}
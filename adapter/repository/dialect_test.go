@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSQLiteDialect_Rebind(t *testing.T) {
+	t.Parallel()
+
+	var createdAt = time.Date(2020, 5, 10, 9, 30, 0, 0, time.FixedZone("BRT", -3*60*60))
+
+	tests := []struct {
+		name          string
+		query         string
+		args          []interface{}
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		{
+			name:          "Replaces the placeholders in order",
+			query:         "SELECT * FROM accounts WHERE id = $1 AND cpf = $2",
+			args:          []interface{}{"a", "b"},
+			expectedQuery: "SELECT * FROM accounts WHERE id = ? AND cpf = ?",
+			expectedArgs:  []interface{}{"a", "b"},
+		},
+		{
+			name:          "Repeats the arguments of placeholders used more than once",
+			query:         "SELECT * FROM transfers WHERE (account_origin_id = $1 OR account_destination_id = $1) AND status = $2",
+			args:          []interface{}{"a", "completed"},
+			expectedQuery: "SELECT * FROM transfers WHERE (account_origin_id = ? OR account_destination_id = ?) AND status = ?",
+			expectedArgs:  []interface{}{"a", "a", "completed"},
+		},
+		{
+			name:          "Binds the placeholders out of order",
+			query:         "UPDATE accounts SET balance = $2 WHERE id = $1",
+			args:          []interface{}{"a", 100},
+			expectedQuery: "UPDATE accounts SET balance = ? WHERE id = ?",
+			expectedArgs:  []interface{}{100, "a"},
+		},
+		{
+			name:          "Leaves the placeholders inside literals alone",
+			query:         "SELECT '$1', name FROM accounts WHERE id = $1",
+			args:          []interface{}{"a"},
+			expectedQuery: "SELECT '$1', name FROM accounts WHERE id = ?",
+			expectedArgs:  []interface{}{"a"},
+		},
+		{
+			name:          "Binds the timestamps in UTC",
+			query:         "SELECT * FROM transfers WHERE created_at >= $1",
+			args:          []interface{}{createdAt},
+			expectedQuery: "SELECT * FROM transfers WHERE created_at >= ?",
+			expectedArgs:  []interface{}{createdAt.UTC()},
+		},
+	}
+
+	for _, tt := range tests {
+		query, args := SQLiteDialect{}.Rebind(tt.query, tt.args)
+
+		if query != tt.expectedQuery {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, query, tt.expectedQuery)
+		}
+
+		if !reflect.DeepEqual(args, tt.expectedArgs) {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, args, tt.expectedArgs)
+		}
+	}
+}
+
+func TestDialect_OnConflict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		dialect         Dialect
+		expectedNothing string
+		expectedUpdate  string
+	}{
+		{
+			name:            "Postgres",
+			dialect:         PostgresDialect{},
+			expectedNothing: " ON CONFLICT (webhook_id, event_id) DO NOTHING",
+			expectedUpdate:  " ON CONFLICT (webhook_id, event_id) DO UPDATE SET status = EXCLUDED.status, attempts = EXCLUDED.attempts",
+		},
+		{
+			name:            "SQLite",
+			dialect:         SQLiteDialect{},
+			expectedNothing: " ON CONFLICT (webhook_id, event_id) DO NOTHING",
+			expectedUpdate:  " ON CONFLICT (webhook_id, event_id) DO UPDATE SET status = EXCLUDED.status, attempts = EXCLUDED.attempts",
+		},
+		{
+			name:            "MySQL",
+			dialect:         MySQLDialect{},
+			expectedNothing: " ON DUPLICATE KEY UPDATE webhook_id = webhook_id",
+			expectedUpdate:  " ON DUPLICATE KEY UPDATE status = VALUES(status), attempts = VALUES(attempts)",
+		},
+	}
+
+	for _, tt := range tests {
+		var key = []string{"webhook_id", "event_id"}
+
+		if result := tt.dialect.OnConflictDoNothing(key...); result != tt.expectedNothing {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expectedNothing)
+		}
+
+		if result := tt.dialect.OnConflictUpdate(key, "status", "attempts"); result != tt.expectedUpdate {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expectedUpdate)
+		}
+	}
+}
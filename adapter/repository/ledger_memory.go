@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+// LedgerMemory reads the accounts and the transfers of the in-memory repositories under one lock, so both sides agree
+// on what happened as the single statement of the SQL one does
+type LedgerMemory struct {
+	memory *Memory
+}
+
+func NewLedgerMemory(memory *Memory) LedgerMemory {
+	return LedgerMemory{
+		memory: memory,
+	}
+}
+
+func (l LedgerMemory) FindAll(ctx context.Context) ([]domain.AccountLedger, error) {
+	var tx, _ = memoryTxFrom(ctx)
+
+	l.memory.mu.RLock()
+	defer l.memory.mu.RUnlock()
+
+	var (
+		transfers = l.memory.allTransfers(tx)
+		ledgers   = make([]domain.AccountLedger, 0)
+	)
+
+	for _, account := range l.memory.allAccounts(tx) {
+		ledger, err := l.ledger(tx, account, transfers)
+		if err != nil {
+			return []domain.AccountLedger{}, errors.Wrap(err, "error listing ledgers")
+		}
+
+		ledgers = append(ledgers, ledger)
+	}
+
+	return ledgers, nil
+}
+
+func (l LedgerMemory) FindByAccountID(ctx context.Context, ID domain.AccountID) (domain.AccountLedger, error) {
+	var tx, _ = memoryTxFrom(ctx)
+
+	l.memory.mu.RLock()
+	defer l.memory.mu.RUnlock()
+
+	account, ok := l.memory.account(tx, ID)
+	if !ok {
+		return domain.AccountLedger{}, domain.ErrAccountNotFound
+	}
+
+	ledger, err := l.ledger(tx, account, l.memory.allTransfers(tx))
+	if err != nil {
+		return domain.AccountLedger{}, errors.Wrap(err, "error fetching ledger")
+	}
+
+	return ledger, nil
+}
+
+// FindActivity sums the completed transfers of the account by the day they were made on
+func (l LedgerMemory) FindActivity(
+	ctx context.Context,
+	ID domain.AccountID,
+	from time.Time,
+	to time.Time,
+) ([]domain.DailyActivity, error) {
+	var tx, _ = memoryTxFrom(ctx)
+
+	l.memory.mu.RLock()
+	var transfers = l.memory.allTransfers(tx)
+	l.memory.mu.RUnlock()
+
+	type totals struct {
+		credits domain.Money
+		debits  domain.Money
+		count   int
+	}
+
+	var (
+		days  = make([]time.Time, 0)
+		byDay = make(map[time.Time]*totals)
+		until = to.AddDate(0, 0, 1)
+	)
+
+	for _, transfer := range transfers {
+		switch {
+		case transfer.Status() != domain.TransferStatusCompleted:
+			continue
+		case transfer.AccountOriginID() != ID && transfer.AccountDestinationID() != ID:
+			continue
+		case transfer.CreatedAt().Before(from) || !transfer.CreatedAt().Before(until):
+			continue
+		}
+
+		var day = domain.StartOfDay(transfer.CreatedAt())
+
+		t, ok := byDay[day]
+		if !ok {
+			t = &totals{}
+			byDay[day] = t
+			days = append(days, day)
+		}
+
+		var err error
+		if transfer.AccountDestinationID() == ID {
+			t.credits, err = t.credits.Add(transfer.Amount())
+		} else {
+			t.debits, err = t.debits.Add(transfer.Amount())
+		}
+
+		if err != nil {
+			return []domain.DailyActivity{}, errors.Wrap(err, "error listing daily activity")
+		}
+
+		t.count++
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Before(days[j])
+	})
+
+	var activity = make([]domain.DailyActivity, 0, len(days))
+	for _, day := range days {
+		activity = append(activity, domain.NewDailyActivity(day, byDay[day].credits, byDay[day].debits, byDay[day].count))
+	}
+
+	return activity, nil
+}
+
+// ledger sums the completed transfers the account received and sent. The caller holds the read lock
+func (l LedgerMemory) ledger(
+	tx *memoryTx,
+	account domain.Account,
+	transfers []domain.Transfer,
+) (domain.AccountLedger, error) {
+	var (
+		credits domain.Money
+		debits  domain.Money
+		err     error
+	)
+
+	for _, transfer := range transfers {
+		if transfer.Status() != domain.TransferStatusCompleted {
+			continue
+		}
+
+		if transfer.AccountDestinationID() == account.ID() {
+			if credits, err = credits.Add(transfer.Amount()); err != nil {
+				return domain.AccountLedger{}, err
+			}
+		}
+
+		if transfer.AccountOriginID() == account.ID() {
+			if debits, err = debits.Add(transfer.Amount()); err != nil {
+				return domain.AccountLedger{}, err
+			}
+		}
+	}
+
+	record, ok := l.memory.record(tx, "opening_balances", account.ID().String())
+	if !ok {
+		return domain.NewAccountLedgerWithoutOpening(account.ID(), account.CreatedAt(), account.Balance(), credits, debits), nil
+	}
+
+	return domain.NewAccountLedger(
+		account.ID(),
+		account.CreatedAt(),
+		record.value.(domain.Money),
+		account.Balance(),
+		credits,
+		debits,
+	), nil
+}
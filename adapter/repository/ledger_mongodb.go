@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// LedgerNoSQL reads the accounts and then their completed transfers. The reads do not share a snapshot, so a transfer
+// completing in between shows up as a mismatch, which the reconciliation reads again before reporting
+type LedgerNoSQL struct {
+	accountCollectionName  string
+	transferCollectionName string
+	db                     NoSQL
+}
+
+func NewLedgerNoSQL(db NoSQL) LedgerNoSQL {
+	return LedgerNoSQL{
+		db:                     db,
+		accountCollectionName:  "accounts",
+		transferCollectionName: "transfers",
+	}
+}
+
+func (l LedgerNoSQL) FindAll(ctx context.Context) ([]domain.AccountLedger, error) {
+	var accountsBSON = make([]accountBSON, 0)
+
+	if err := l.db.FindAll(ctx, l.accountCollectionName, bson.M{}, &accountsBSON); err != nil {
+		return []domain.AccountLedger{}, errors.Wrap(err, "error listing ledgers")
+	}
+
+	var transfersBSON = make([]transferBSON, 0)
+
+	if err := l.db.FindAll(
+		ctx,
+		l.transferCollectionName,
+		bson.M{"status": domain.TransferStatusCompleted},
+		&transfersBSON,
+	); err != nil {
+		return []domain.AccountLedger{}, errors.Wrap(err, "error listing ledgers")
+	}
+
+	var ledgers = make([]domain.AccountLedger, 0, len(accountsBSON))
+	for _, account := range accountsBSON {
+		ledger, err := ledgerFromBSON(account, transfersBSON)
+		if err != nil {
+			return []domain.AccountLedger{}, errors.Wrap(err, "error listing ledgers")
+		}
+
+		ledgers = append(ledgers, ledger)
+	}
+
+	return ledgers, nil
+}
+
+func (l LedgerNoSQL) FindByAccountID(ctx context.Context, ID domain.AccountID) (domain.AccountLedger, error) {
+	var account = &accountBSON{}
+
+	if err := l.db.FindOne(ctx, l.accountCollectionName, bson.M{"id": ID}, nil, account); err != nil {
+		switch err {
+		case mongo.ErrNoDocuments:
+			return domain.AccountLedger{}, domain.ErrAccountNotFound
+		default:
+			return domain.AccountLedger{}, errors.Wrap(err, "error fetching ledger")
+		}
+	}
+
+	var (
+		transfersBSON = make([]transferBSON, 0)
+		query         = bson.M{
+			"status": domain.TransferStatusCompleted,
+			"$or": bson.A{
+				bson.M{"account_origin_id": ID},
+				bson.M{"account_destination_id": ID},
+			},
+		}
+	)
+
+	if err := l.db.FindAll(ctx, l.transferCollectionName, query, &transfersBSON); err != nil {
+		return domain.AccountLedger{}, errors.Wrap(err, "error fetching ledger")
+	}
+
+	ledger, err := ledgerFromBSON(*account, transfersBSON)
+	if err != nil {
+		return domain.AccountLedger{}, errors.Wrap(err, "error fetching ledger")
+	}
+
+	return ledger, nil
+}
+
+// FindActivity sums the completed transfers of the account by the day they were made on
+func (l LedgerNoSQL) FindActivity(
+	ctx context.Context,
+	ID domain.AccountID,
+	from time.Time,
+	to time.Time,
+) ([]domain.DailyActivity, error) {
+	var (
+		transfersBSON = make([]transferBSON, 0)
+		query         = bson.M{
+			"status":     domain.TransferStatusCompleted,
+			"created_at": bson.M{"$gte": from, "$lt": to.AddDate(0, 0, 1)},
+			"$or": bson.A{
+				bson.M{"account_origin_id": ID},
+				bson.M{"account_destination_id": ID},
+			},
+		}
+	)
+
+	if err := l.db.FindAll(ctx, l.transferCollectionName, query, &transfersBSON); err != nil {
+		return []domain.DailyActivity{}, errors.Wrap(err, "error listing daily activity")
+	}
+
+	type totals struct {
+		credits domain.Money
+		debits  domain.Money
+		count   int
+	}
+
+	var (
+		days  = make([]time.Time, 0)
+		byDay = make(map[time.Time]*totals)
+	)
+
+	for _, transfer := range transfersBSON {
+		var day = domain.StartOfDay(transfer.CreatedAt)
+
+		t, ok := byDay[day]
+		if !ok {
+			t = &totals{}
+			byDay[day] = t
+			days = append(days, day)
+		}
+
+		var err error
+		if transfer.AccountDestinationID == ID.String() {
+			t.credits, err = t.credits.Add(domain.Money(transfer.Amount))
+		} else {
+			t.debits, err = t.debits.Add(domain.Money(transfer.Amount))
+		}
+
+		if err != nil {
+			return []domain.DailyActivity{}, errors.Wrap(err, "error listing daily activity")
+		}
+
+		t.count++
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Before(days[j])
+	})
+
+	var activity = make([]domain.DailyActivity, 0, len(days))
+	for _, day := range days {
+		activity = append(activity, domain.NewDailyActivity(day, byDay[day].credits, byDay[day].debits, byDay[day].count))
+	}
+
+	return activity, nil
+}
+
+// ledgerFromBSON sums the transfers the account received and sent
+func ledgerFromBSON(account accountBSON, transfers []transferBSON) (domain.AccountLedger, error) {
+	var (
+		credits domain.Money
+		debits  domain.Money
+		err     error
+	)
+
+	for _, transfer := range transfers {
+		switch account.ID {
+		case transfer.AccountDestinationID:
+			credits, err = credits.Add(domain.Money(transfer.Amount))
+		case transfer.AccountOriginID:
+			debits, err = debits.Add(domain.Money(transfer.Amount))
+		}
+
+		if err != nil {
+			return domain.AccountLedger{}, err
+		}
+	}
+
+	// Accounts stored before the opening balance have none
+	if account.OpeningBalance == nil {
+		return domain.NewAccountLedgerWithoutOpening(
+			domain.AccountID(account.ID),
+			account.CreatedAt,
+			domain.Money(account.Balance),
+			credits,
+			debits,
+		), nil
+	}
+
+	return domain.NewAccountLedger(
+		domain.AccountID(account.ID),
+		account.CreatedAt,
+		domain.Money(*account.OpeningBalance),
+		domain.Money(account.Balance),
+		credits,
+		debits,
+	), nil
+}
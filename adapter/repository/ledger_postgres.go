@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+// ledgerQuery reads every account next to the sum of the completed transfers it received and sent. Reversed transfers
+// gave the money back, so they are left out. A single statement reads a consistent snapshot, and transfers complete in
+// the transaction updating the balances, so both sides always agree on what happened
+func ledgerQuery(d Dialect) string {
+	return `
+		SELECT
+			a.id,
+			a.created_at,
+			a.opening_balance,
+			a.balance,
+			` + d.Integer("COALESCE((SELECT SUM(t.amount) FROM transfers t WHERE t.account_destination_id = a.id AND t.status = $1), 0)") + `,
+			` + d.Integer("COALESCE((SELECT SUM(t.amount) FROM transfers t WHERE t.account_origin_id = a.id AND t.status = $1), 0)") + `
+		FROM accounts a
+	`
+}
+
+type LedgerSQL struct {
+	db SQL
+}
+
+func NewLedgerSQL(db SQL) LedgerSQL {
+	return LedgerSQL{
+		db: db,
+	}
+}
+
+func (l LedgerSQL) FindAll(ctx context.Context) ([]domain.AccountLedger, error) {
+	rows, err := l.db.QueryContext(ctx, ledgerQuery(l.db.Dialect())+" ORDER BY a.created_at", domain.TransferStatusCompleted)
+	if err != nil {
+		return []domain.AccountLedger{}, errors.Wrap(err, "error listing ledgers")
+	}
+	defer rows.Close()
+
+	var ledgers = make([]domain.AccountLedger, 0)
+	for rows.Next() {
+		ledger, err := scanLedger(rows)
+		if err != nil {
+			return []domain.AccountLedger{}, errors.Wrap(err, "error listing ledgers")
+		}
+
+		ledgers = append(ledgers, ledger)
+	}
+
+	if err = rows.Err(); err != nil {
+		return []domain.AccountLedger{}, err
+	}
+
+	return ledgers, nil
+}
+
+func (l LedgerSQL) FindByAccountID(ctx context.Context, ID domain.AccountID) (domain.AccountLedger, error) {
+	ledger, err := scanLedger(l.db.QueryRowContext(
+		ctx,
+		ledgerQuery(l.db.Dialect())+" WHERE a.id = $2",
+		domain.TransferStatusCompleted,
+		ID,
+	))
+	switch {
+	case err == sql.ErrNoRows:
+		return domain.AccountLedger{}, domain.ErrAccountNotFound
+	case err != nil:
+		return domain.AccountLedger{}, errors.Wrap(err, "error fetching ledger")
+	default:
+		return ledger, nil
+	}
+}
+
+// FindActivity sums the completed transfers of the account by the day they were made on
+func (l LedgerSQL) FindActivity(
+	ctx context.Context,
+	ID domain.AccountID,
+	from time.Time,
+	to time.Time,
+) ([]domain.DailyActivity, error) {
+	var query = `
+		SELECT
+			` + l.db.Dialect().Date("created_at") + `,
+			` + l.db.Dialect().Integer("SUM(CASE WHEN account_destination_id = $1 THEN amount ELSE 0 END)") + `,
+			` + l.db.Dialect().Integer("SUM(CASE WHEN account_origin_id = $1 THEN amount ELSE 0 END)") + `,
+			COUNT(*)
+		FROM transfers
+		WHERE status = $2
+			AND (account_origin_id = $1 OR account_destination_id = $1)
+			AND created_at >= $3 AND created_at < $4
+		GROUP BY 1
+		ORDER BY 1
+	`
+
+	rows, err := l.db.QueryContext(ctx, query, ID, domain.TransferStatusCompleted, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return []domain.DailyActivity{}, errors.Wrap(err, "error listing daily activity")
+	}
+	defer rows.Close()
+
+	var activity = make([]domain.DailyActivity, 0)
+	for rows.Next() {
+		var (
+			day              sqlDay
+			credits          int64
+			debits           int64
+			transactionCount int
+		)
+
+		if err = rows.Scan(&day, &credits, &debits, &transactionCount); err != nil {
+			return []domain.DailyActivity{}, errors.Wrap(err, "error listing daily activity")
+		}
+
+		activity = append(activity, domain.NewDailyActivity(
+			day.Time,
+			domain.Money(credits),
+			domain.Money(debits),
+			transactionCount,
+		))
+	}
+
+	if err = rows.Err(); err != nil {
+		return []domain.DailyActivity{}, err
+	}
+
+	return activity, nil
+}
+
+func scanLedger(row Row) (domain.AccountLedger, error) {
+	var (
+		ID             string
+		createdAt      time.Time
+		openingBalance sql.NullInt64
+		balance        int64
+		credits        int64
+		debits         int64
+	)
+
+	if err := row.Scan(&ID, &createdAt, &openingBalance, &balance, &credits, &debits); err != nil {
+		return domain.AccountLedger{}, err
+	}
+
+	// A NULL opening balance is unknown
+	if !openingBalance.Valid {
+		return domain.NewAccountLedgerWithoutOpening(
+			domain.AccountID(ID),
+			createdAt,
+			domain.Money(balance),
+			domain.Money(credits),
+			domain.Money(debits),
+		), nil
+	}
+
+	return domain.NewAccountLedger(
+		domain.AccountID(ID),
+		createdAt,
+		domain.Money(openingBalance.Int64),
+		domain.Money(balance),
+		domain.Money(credits),
+		domain.Money(debits),
+	), nil
+}
+
+// sqlDay scans a day, answered as a date by Postgres and as text by the engines without a date type
+type sqlDay struct {
+	time.Time
+}
+
+func (d *sqlDay) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		d.Time = v
+	case string:
+		return d.parse(v)
+	case []byte:
+		return d.parse(string(v))
+	default:
+		return errors.Errorf("cannot scan %T as a day", src)
+	}
+
+	return nil
+}
+
+func (d *sqlDay) parse(s string) error {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return errors.Wrap(err, "cannot scan "+s+" as a day")
+	}
+
+	d.Time = t
+	return nil
+}
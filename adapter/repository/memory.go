@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+// memoryTransactionContextKey holds the transaction of the in-memory repositories, apart from the SQL one
+const memoryTransactionContextKey = "MemoryTransactionContextKey"
+
+var (
+	errMemoryDuplicateCPF = errors.New("duplicate key value violates unique constraint on cpf")
+	errMemoryDuplicateKey = errors.New("duplicate key value violates unique constraint")
+)
+
+// Memory holds the accounts, the transfers and the rows of the other stores of the in-memory repositories, for
+// development and tests. It is safe for concurrent use. Writes inside a transaction are kept apart and only seen by it
+// until it commits, and the accounts and transfers it reads by ID are locked until it ends, as rows locked FOR UPDATE.
+// A transaction waiting for a lock gives up when its context is done
+type Memory struct {
+	mu        sync.RWMutex
+	accounts  map[domain.AccountID]domain.Account
+	transfers map[domain.TransferID]domain.Transfer
+	records   map[string]map[string]memoryRecord
+	sequence  int64
+
+	locksMu sync.Mutex
+	locks   map[string]chan struct{}
+}
+
+// memoryTx holds the writes of a transaction and the locks it took
+type memoryTx struct {
+	accounts  map[domain.AccountID]domain.Account
+	transfers map[domain.TransferID]domain.Transfer
+	records   map[string]map[string]memoryRecord
+	held      map[string]bool
+}
+
+// memoryRecord is a row of a table of the other stores, numbered in the order it was first written
+type memoryRecord struct {
+	sequence int64
+	value    interface{}
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		accounts:  make(map[domain.AccountID]domain.Account),
+		transfers: make(map[domain.TransferID]domain.Transfer),
+		records:   make(map[string]map[string]memoryRecord),
+		locks:     make(map[string]chan struct{}),
+	}
+}
+
+// WithTransaction runs the function in a transaction, committed when it returns nil and discarded otherwise. A
+// transaction already in the context is joined
+func (m *Memory) WithTransaction(ctx context.Context, fn func(ctxTx context.Context) error) error {
+	if _, ok := memoryTxFrom(ctx); ok {
+		return fn(ctx)
+	}
+
+	var tx = &memoryTx{
+		accounts:  make(map[domain.AccountID]domain.Account),
+		transfers: make(map[domain.TransferID]domain.Transfer),
+		records:   make(map[string]map[string]memoryRecord),
+		held:      make(map[string]bool),
+	}
+	defer m.release(tx)
+
+	if err := fn(context.WithValue(ctx, memoryTransactionContextKey, tx)); err != nil {
+		return err
+	}
+
+	return m.commit(tx)
+}
+
+func memoryTxFrom(ctx context.Context) (*memoryTx, bool) {
+	tx, ok := ctx.Value(memoryTransactionContextKey).(*memoryTx)
+	return tx, ok
+}
+
+// lock waits for the lock of the key, unless the transaction already holds it
+func (m *Memory) lock(ctx context.Context, tx *memoryTx, key string) error {
+	if tx.held[key] {
+		return nil
+	}
+
+	m.locksMu.Lock()
+	l, ok := m.locks[key]
+	if !ok {
+		l = make(chan struct{}, 1)
+		m.locks[key] = l
+	}
+	m.locksMu.Unlock()
+
+	select {
+	case l <- struct{}{}:
+		tx.held[key] = true
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "error waiting for lock on "+key)
+	}
+}
+
+func (m *Memory) release(tx *memoryTx) {
+	m.locksMu.Lock()
+	defer m.locksMu.Unlock()
+
+	for key := range tx.held {
+		<-m.locks[key]
+	}
+}
+
+// commit applies the writes of the transaction, checking again the uniqueness of the CPF of the accounts created
+func (m *Memory) commit(tx *memoryTx) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for ID, account := range tx.accounts {
+		if _, ok := m.accounts[ID]; !ok && m.hasCPF(account.CPF()) {
+			return errMemoryDuplicateCPF
+		}
+	}
+
+	for ID, account := range tx.accounts {
+		m.accounts[ID] = account
+	}
+
+	for ID, transfer := range tx.transfers {
+		m.transfers[ID] = transfer
+	}
+
+	for table, records := range tx.records {
+		if m.records[table] == nil {
+			m.records[table] = make(map[string]memoryRecord)
+		}
+
+		for key, record := range records {
+			m.records[table][key] = record
+		}
+	}
+
+	return nil
+}
+
+// account reads the account as seen by the transaction, if any. The caller holds the read lock
+func (m *Memory) account(tx *memoryTx, ID domain.AccountID) (domain.Account, bool) {
+	if tx != nil {
+		if account, ok := tx.accounts[ID]; ok {
+			return account, true
+		}
+	}
+
+	account, ok := m.accounts[ID]
+	return account, ok
+}
+
+// transfer reads the transfer as seen by the transaction, if any. The caller holds the read lock
+func (m *Memory) transfer(tx *memoryTx, ID domain.TransferID) (domain.Transfer, bool) {
+	if tx != nil {
+		if transfer, ok := tx.transfers[ID]; ok {
+			return transfer, true
+		}
+	}
+
+	transfer, ok := m.transfers[ID]
+	return transfer, ok
+}
+
+// hasCPF tells whether a committed account has the CPF. The caller holds the read lock
+func (m *Memory) hasCPF(CPF string) bool {
+	for _, account := range m.accounts {
+		if account.CPF() == CPF {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allAccounts lists the accounts as seen by the transaction, oldest first. The caller holds the read lock
+func (m *Memory) allAccounts(tx *memoryTx) []domain.Account {
+	var accounts = make([]domain.Account, 0, len(m.accounts))
+	for ID := range m.accounts {
+		account, _ := m.account(tx, ID)
+		accounts = append(accounts, account)
+	}
+
+	if tx != nil {
+		for ID, account := range tx.accounts {
+			if _, ok := m.accounts[ID]; !ok {
+				accounts = append(accounts, account)
+			}
+		}
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		if accounts[i].CreatedAt().Equal(accounts[j].CreatedAt()) {
+			return accounts[i].ID() < accounts[j].ID()
+		}
+
+		return accounts[i].CreatedAt().Before(accounts[j].CreatedAt())
+	})
+
+	return accounts
+}
+
+// allTransfers lists the transfers as seen by the transaction, oldest first. The caller holds the read lock
+func (m *Memory) allTransfers(tx *memoryTx) []domain.Transfer {
+	var transfers = make([]domain.Transfer, 0, len(m.transfers))
+	for ID := range m.transfers {
+		transfer, _ := m.transfer(tx, ID)
+		transfers = append(transfers, transfer)
+	}
+
+	if tx != nil {
+		for ID, transfer := range tx.transfers {
+			if _, ok := m.transfers[ID]; !ok {
+				transfers = append(transfers, transfer)
+			}
+		}
+	}
+
+	sort.Slice(transfers, func(i, j int) bool {
+		if transfers[i].CreatedAt().Equal(transfers[j].CreatedAt()) {
+			return transfers[i].ID() < transfers[j].ID()
+		}
+
+		return transfers[i].CreatedAt().Before(transfers[j].CreatedAt())
+	})
+
+	return transfers
+}
+
+// save writes the row of the table in the transaction of the context, or in one of its own, keeping the place of the
+// row when it already exists
+func (m *Memory) save(ctx context.Context, table, key string, value interface{}) error {
+	return m.write(ctx, table, key, func(interface{}, bool) (interface{}, bool) {
+		return value, true
+	})
+}
+
+// insert writes the row of the table as save does, failing when a row has the key
+func (m *Memory) insert(ctx context.Context, table, key string, value interface{}) error {
+	var duplicate bool
+
+	err := m.write(ctx, table, key, func(_ interface{}, found bool) (interface{}, bool) {
+		duplicate = found
+		return value, !found
+	})
+	if err == nil && duplicate {
+		return errMemoryDuplicateKey
+	}
+
+	return err
+}
+
+// change rewrites the row of the table found by the key, leaving the table alone when there is none
+func (m *Memory) change(ctx context.Context, table, key string, fn func(value interface{}) interface{}) error {
+	return m.write(ctx, table, key, func(value interface{}, ok bool) (interface{}, bool) {
+		if !ok {
+			return nil, false
+		}
+
+		return fn(value), true
+	})
+}
+
+// write locks the row of the table until the transaction ends, so writes of the same row are serialized, then writes
+// what fn makes of it, unless fn tells to leave it
+func (m *Memory) write(
+	ctx context.Context,
+	table, key string,
+	fn func(value interface{}, ok bool) (interface{}, bool),
+) error {
+	return m.WithTransaction(ctx, func(ctx context.Context) error {
+		tx, _ := memoryTxFrom(ctx)
+
+		if err := m.lock(ctx, tx, table+":"+key); err != nil {
+			return err
+		}
+
+		m.mu.RLock()
+		record, found := m.record(tx, table, key)
+		m.mu.RUnlock()
+
+		value, ok := fn(record.value, found)
+		if !ok {
+			return nil
+		}
+
+		if !found {
+			record.sequence = atomic.AddInt64(&m.sequence, 1)
+		}
+		record.value = value
+
+		if tx.records[table] == nil {
+			tx.records[table] = make(map[string]memoryRecord)
+		}
+		tx.records[table][key] = record
+
+		return nil
+	})
+}
+
+// find reads the row of the table as seen by the transaction of the context, if any
+func (m *Memory) find(ctx context.Context, table, key string) (interface{}, bool) {
+	var tx, _ = memoryTxFrom(ctx)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	record, ok := m.record(tx, table, key)
+	return record.value, ok
+}
+
+// list reads the rows of the table as seen by the transaction of the context, if any, in the order they were first
+// written
+func (m *Memory) list(ctx context.Context, table string) []interface{} {
+	var tx, _ = memoryTxFrom(ctx)
+
+	m.mu.RLock()
+	var records = make([]memoryRecord, 0, len(m.records[table]))
+	for key := range m.records[table] {
+		record, _ := m.record(tx, table, key)
+		records = append(records, record)
+	}
+
+	if tx != nil {
+		for key, record := range tx.records[table] {
+			if _, ok := m.records[table][key]; !ok {
+				records = append(records, record)
+			}
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].sequence < records[j].sequence
+	})
+
+	var values = make([]interface{}, 0, len(records))
+	for _, record := range records {
+		values = append(values, record.value)
+	}
+
+	return values
+}
+
+// record reads the row of the table as seen by the transaction, if any. The caller holds the read lock
+func (m *Memory) record(tx *memoryTx, table, key string) (memoryRecord, bool) {
+	if tx != nil {
+		if record, ok := tx.records[table][key]; ok {
+			return record, true
+		}
+	}
+
+	record, ok := m.records[table][key]
+	return record, ok
+}
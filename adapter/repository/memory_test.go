@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+func TestMemory_WithTransaction(t *testing.T) {
+	t.Parallel()
+
+	const accountID = domain.AccountID("3c096a40-ccba-4b58-93ed-57379ab04680")
+
+	tests := []struct {
+		name            string
+		fn              func(ctx context.Context, accounts AccountMemory, transfers TransferMemory) error
+		expectedError   error
+		expectedBalance domain.Money
+		expectedCount   int
+	}{
+		{
+			name: "Commits the writes of the transaction",
+			fn: func(ctx context.Context, accounts AccountMemory, transfers TransferMemory) error {
+				if _, err := transfers.Create(ctx, newMemoryTransfer(accountID)); err != nil {
+					return err
+				}
+
+				return accounts.UpdateBalance(ctx, accountID, 500)
+			},
+			expectedBalance: 500,
+			expectedCount:   1,
+		},
+		{
+			name: "Rolls back the writes of a failed transaction",
+			fn: func(ctx context.Context, accounts AccountMemory, transfers TransferMemory) error {
+				if _, err := transfers.Create(ctx, newMemoryTransfer(accountID)); err != nil {
+					return err
+				}
+
+				if err := accounts.UpdateBalance(ctx, accountID, 500); err != nil {
+					return err
+				}
+
+				return domain.ErrInsufficientBalance
+			},
+			expectedError:   domain.ErrInsufficientBalance,
+			expectedBalance: 1000,
+			expectedCount:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				memory    = NewMemory()
+				accounts  = NewAccountMemory(memory)
+				transfers = NewTransferMemory(memory)
+				ctx       = context.Background()
+			)
+
+			if _, err := accounts.Create(ctx, domain.NewAccount(accountID, "Test", "02815517078", 1000, time.Now())); err != nil {
+				t.Fatalf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, nil)
+			}
+
+			err := transfers.WithTransaction(ctx, func(ctxTx context.Context) error {
+				return tt.fn(ctxTx, accounts, transfers)
+			})
+			if err != tt.expectedError {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+			}
+
+			account, _ := accounts.FindBalance(ctx, accountID)
+			if account.Balance() != tt.expectedBalance {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, account.Balance(), tt.expectedBalance)
+			}
+
+			all, _ := transfers.FindAll(ctx, domain.TransferFilter{AccountID: accountID}, domain.PageRequest{Limit: domain.MaxPageSize})
+			if len(all) != tt.expectedCount {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, len(all), tt.expectedCount)
+			}
+		})
+	}
+}
+
+// TestMemory_Stores writes to the other stores next to a transfer: they commit and roll back with it
+func TestMemory_Stores(t *testing.T) {
+	t.Parallel()
+
+	const accountID = domain.AccountID("3c096a40-ccba-4b58-93ed-57379ab04680")
+
+	tests := []struct {
+		name          string
+		err           error
+		expectedCount int
+	}{
+		{name: "Commits the writes of the other stores", expectedCount: 1},
+		{name: "Rolls back the writes of the other stores", err: domain.ErrInsufficientBalance, expectedCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				memory    = NewMemory()
+				transfers = NewTransferMemory(memory)
+				approvals = NewTransferApprovalMemory(memory)
+				outbox    = NewOutboxMemory(memory)
+				transfer  = newMemoryTransfer(accountID)
+				ctx       = context.Background()
+			)
+
+			_ = transfers.WithTransaction(ctx, func(ctxTx context.Context) error {
+				if _, err := transfers.Create(ctxTx, transfer); err != nil {
+					return err
+				}
+
+				if _, err := approvals.Create(ctxTx, domain.NewTransferApproval(
+					domain.TransferApprovalID(domain.NewUUID()),
+					transfer.ID(),
+					domain.ApprovalRequested,
+					"user",
+					domain.RoleCustomer,
+					"",
+					time.Now(),
+				)); err != nil {
+					return err
+				}
+
+				if err := outbox.Create(ctxTx, domain.NewEvent(
+					domain.NewUUID(),
+					domain.EventTransferCompleted,
+					1,
+					transfer.ID().String(),
+					[]byte("{}"),
+					time.Now(),
+				)); err != nil {
+					return err
+				}
+
+				return tt.err
+			})
+
+			all, _ := approvals.FindAllByTransfer(ctx, transfer.ID())
+			if len(all) != tt.expectedCount {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, len(all), tt.expectedCount)
+			}
+
+			counts, _ := outbox.CountByStatus(ctx)
+			if counts[domain.OutboxPending] != tt.expectedCount {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, counts[domain.OutboxPending], tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestLedgerMemory_FindByAccountID(t *testing.T) {
+	t.Parallel()
+
+	const accountID = domain.AccountID("3c096a40-ccba-4b58-93ed-57379ab04680")
+
+	var (
+		memory    = NewMemory()
+		accounts  = NewAccountMemory(memory)
+		transfers = NewTransferMemory(memory)
+		ctx       = context.Background()
+	)
+
+	if _, err := accounts.Create(ctx, domain.NewAccount(accountID, "Test", "02815517078", 1000, time.Now())); err != nil {
+		t.Fatalf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Create account", err, nil)
+	}
+
+	_ = transfers.WithTransaction(ctx, func(ctxTx context.Context) error {
+		if _, err := transfers.Create(ctxTx, newMemoryTransfer(accountID)); err != nil {
+			return err
+		}
+
+		return accounts.UpdateBalance(ctxTx, accountID, 500)
+	})
+
+	ledger, err := NewLedgerMemory(memory).FindByAccountID(ctx, accountID)
+	if err != nil {
+		t.Fatalf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Find ledger", err, nil)
+	}
+
+	if _, mismatch, _ := ledger.Mismatch(); mismatch {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Reconciles from the opening balance", mismatch, false)
+	}
+
+	if opening, known := ledger.OpeningBalance(); !known || opening != 1000 {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Keeps the opening balance", opening, 1000)
+	}
+}
+
+// TestMemory_Locking withdraws from the same account concurrently: the account read inside a transaction stays locked
+// until it ends, so no withdrawal is lost
+func TestMemory_Locking(t *testing.T) {
+	t.Parallel()
+
+	const (
+		accountID   = domain.AccountID("3c096a40-ccba-4b58-93ed-57379ab04680")
+		withdrawals = 50
+	)
+
+	var (
+		memory   = NewMemory()
+		accounts = NewAccountMemory(memory)
+		ctx      = context.Background()
+		wg       sync.WaitGroup
+	)
+
+	if _, err := accounts.Create(ctx, domain.NewAccount(accountID, "Test", "02815517078", withdrawals, time.Now())); err != nil {
+		t.Fatalf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Create account", err, nil)
+	}
+
+	for i := 0; i < withdrawals; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := memory.WithTransaction(ctx, func(ctxTx context.Context) error {
+				account, err := accounts.FindByID(ctxTx, accountID)
+				if err != nil {
+					return err
+				}
+
+				if err = account.Withdraw(1); err != nil {
+					return err
+				}
+
+				return accounts.UpdateBalance(ctxTx, accountID, account.Balance())
+			})
+			if err != nil {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Concurrent withdrawal", err, nil)
+			}
+		}()
+	}
+	wg.Wait()
+
+	account, _ := accounts.FindBalance(ctx, accountID)
+	if account.Balance() != 0 {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Every withdrawal counted", account.Balance(), 0)
+	}
+}
+
+func TestMemory_LockTimeout(t *testing.T) {
+	t.Parallel()
+
+	const accountID = domain.AccountID("3c096a40-ccba-4b58-93ed-57379ab04680")
+
+	var (
+		memory   = NewMemory()
+		accounts = NewAccountMemory(memory)
+		locked   = make(chan struct{})
+		done     = make(chan struct{})
+	)
+
+	_, _ = accounts.Create(context.Background(), domain.NewAccount(accountID, "Test", "02815517078", 10, time.Now()))
+
+	go func() {
+		_ = memory.WithTransaction(context.Background(), func(ctxTx context.Context) error {
+			_, err := accounts.FindByID(ctxTx, accountID)
+			close(locked)
+			<-done
+			return err
+		})
+	}()
+	<-locked
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := memory.WithTransaction(ctx, func(ctxTx context.Context) error {
+		_, err := accounts.FindByID(ctxTx, accountID)
+		return err
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Gives up waiting for the lock", err, context.DeadlineExceeded)
+	}
+}
+
+func newMemoryTransfer(accountID domain.AccountID) domain.Transfer {
+	return domain.NewTransfer(
+		domain.TransferID(domain.NewUUID()),
+		accountID,
+		"a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c",
+		500,
+		domain.TransferStatusCompleted,
+		time.Now(),
+	)
+}
@@ -5,8 +5,17 @@ import "context"
 type NoSQL interface {
 	Store(context.Context, string, interface{}) error
 	Update(context.Context, string, interface{}, interface{}) error
+	// UpdateMatched updates the first document matching the query, as Update, telling whether one matched
+	UpdateMatched(context.Context, string, interface{}, interface{}) (bool, error)
 	FindAll(context.Context, string, interface{}, interface{}) error
+	// FindPage decodes into the result up to the limit of the documents matching the query, in the order of the sort
+	FindPage(context.Context, string, interface{}, interface{}, int64, interface{}) error
 	FindOne(context.Context, string, interface{}, interface{}, interface{}) error
+	// Stream decodes the documents matching the query one at a time, in the order of the sort, handing the decoder of
+	// each to the function
+	Stream(context.Context, string, interface{}, interface{}, func(func(interface{}) error) error) error
+	// Aggregate decodes into the result the documents out of the pipeline
+	Aggregate(context.Context, string, interface{}, interface{}) error
 	StartSession() (Session, error)
 }
 
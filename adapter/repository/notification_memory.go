@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type NotificationMemory struct {
+	memory *Memory
+}
+
+func NewNotificationMemory(memory *Memory) NotificationMemory {
+	return NotificationMemory{
+		memory: memory,
+	}
+}
+
+func (n NotificationMemory) Create(ctx context.Context, notification domain.Notification) error {
+	if err := n.memory.insert(ctx, "notifications", notification.Key(), notification); err != nil {
+		return errors.Wrap(err, "error creating notification")
+	}
+
+	return nil
+}
+
+// Update writes the status of the notification and when it was sent, as the SQL one
+func (n NotificationMemory) Update(ctx context.Context, notification domain.Notification) error {
+	err := n.memory.change(ctx, "notifications", notification.Key(), func(value interface{}) interface{} {
+		var stored = value.(domain.Notification)
+		return domain.NewNotification(
+			stored.Key(),
+			stored.AccountID(),
+			stored.EventID(),
+			stored.Kind(),
+			stored.Recipient(),
+			stored.Subject(),
+			stored.Body(),
+			notification.Status(),
+			stored.CreatedAt(),
+			notification.SentAt(),
+		)
+	})
+	if err != nil {
+		return errors.Wrap(err, "error updating notification")
+	}
+
+	return nil
+}
+
+func (n NotificationMemory) FindByKey(ctx context.Context, key string) (domain.Notification, error) {
+	value, ok := n.memory.find(ctx, "notifications", key)
+	if !ok {
+		return domain.Notification{}, domain.ErrNotificationNotFound
+	}
+
+	return value.(domain.Notification), nil
+}
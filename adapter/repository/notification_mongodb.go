@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type notificationBSON struct {
+	Key       string     `bson:"key"`
+	AccountID string     `bson:"account_id"`
+	EventID   string     `bson:"event_id"`
+	Kind      string     `bson:"kind"`
+	Recipient string     `bson:"recipient"`
+	Subject   string     `bson:"subject"`
+	Body      string     `bson:"body"`
+	Status    string     `bson:"status"`
+	CreatedAt time.Time  `bson:"created_at"`
+	SentAt    *time.Time `bson:"sent_at"`
+}
+
+type NotificationNoSQL struct {
+	collectionName string
+	db             NoSQL
+}
+
+func NewNotificationNoSQL(db NoSQL) NotificationNoSQL {
+	return NotificationNoSQL{
+		db:             db,
+		collectionName: "notifications",
+	}
+}
+
+func (n NotificationNoSQL) Create(ctx context.Context, notification domain.Notification) error {
+	var notificationBSON = notificationBSON{
+		Key:       notification.Key(),
+		AccountID: notification.AccountID().String(),
+		EventID:   notification.EventID(),
+		Kind:      notification.Kind().String(),
+		Recipient: notification.Recipient(),
+		Subject:   notification.Subject(),
+		Body:      notification.Body(),
+		Status:    notification.Status().String(),
+		CreatedAt: notification.CreatedAt(),
+		SentAt:    optionalTimeBSON(notification.SentAt()),
+	}
+
+	if err := n.db.Store(ctx, n.collectionName, notificationBSON); err != nil {
+		return errors.Wrap(err, "error creating notification")
+	}
+
+	return nil
+}
+
+func (n NotificationNoSQL) Update(ctx context.Context, notification domain.Notification) error {
+	var (
+		query  = bson.M{"key": notification.Key()}
+		update = bson.M{"$set": bson.M{
+			"status":  notification.Status().String(),
+			"sent_at": optionalTimeBSON(notification.SentAt()),
+		}}
+	)
+
+	if err := n.db.Update(ctx, n.collectionName, query, update); err != nil {
+		return errors.Wrap(err, "error updating notification")
+	}
+
+	return nil
+}
+
+func (n NotificationNoSQL) FindByKey(ctx context.Context, key string) (domain.Notification, error) {
+	var notificationBSON = &notificationBSON{}
+
+	if err := n.db.FindOne(ctx, n.collectionName, bson.M{"key": key}, nil, notificationBSON); err != nil {
+		switch err {
+		case mongo.ErrNoDocuments:
+			return domain.Notification{}, domain.ErrNotificationNotFound
+		default:
+			return domain.Notification{}, errors.Wrap(err, "error fetching notification")
+		}
+	}
+
+	return domain.NewNotification(
+		notificationBSON.Key,
+		domain.AccountID(notificationBSON.AccountID),
+		notificationBSON.EventID,
+		domain.NotificationKind(notificationBSON.Kind),
+		notificationBSON.Recipient,
+		notificationBSON.Subject,
+		notificationBSON.Body,
+		domain.NotificationStatus(notificationBSON.Status),
+		notificationBSON.CreatedAt,
+		timeFromBSON(notificationBSON.SentAt),
+	), nil
+}
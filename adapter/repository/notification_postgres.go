@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+// NotificationSQL quotes the key column, a reserved word in MySQL
+type NotificationSQL struct {
+	db SQL
+}
+
+func NewNotificationSQL(db SQL) NotificationSQL {
+	return NotificationSQL{
+		db: db,
+	}
+}
+
+func (n NotificationSQL) Create(ctx context.Context, notification domain.Notification) error {
+	var query = `
+		INSERT INTO
+			notifications ("key", account_id, event_id, kind, recipient, subject, body, status, created_at, sent_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	if err := n.db.ExecuteContext(
+		ctx,
+		query,
+		notification.Key(),
+		notification.AccountID(),
+		notification.EventID(),
+		notification.Kind(),
+		notification.Recipient(),
+		notification.Subject(),
+		notification.Body(),
+		notification.Status(),
+		notification.CreatedAt(),
+		nullTime(notification.SentAt()),
+	); err != nil {
+		return errors.Wrap(err, "error creating notification")
+	}
+
+	return nil
+}
+
+func (n NotificationSQL) Update(ctx context.Context, notification domain.Notification) error {
+	var query = "UPDATE notifications SET status = $1, sent_at = $2 WHERE \"key\" = $3"
+
+	if err := n.db.ExecuteContext(
+		ctx,
+		query,
+		notification.Status(),
+		nullTime(notification.SentAt()),
+		notification.Key(),
+	); err != nil {
+		return errors.Wrap(err, "error updating notification")
+	}
+
+	return nil
+}
+
+func (n NotificationSQL) FindByKey(ctx context.Context, key string) (domain.Notification, error) {
+	var (
+		query = `
+			SELECT account_id, event_id, kind, recipient, subject, body, status, created_at, sent_at
+			FROM notifications
+			WHERE "key" = $1
+		`
+		accountID string
+		eventID   string
+		kind      string
+		recipient string
+		subject   string
+		body      string
+		status    string
+		createdAt time.Time
+		sentAt    sql.NullTime
+	)
+
+	err := n.db.QueryRowContext(ctx, query, key).Scan(
+		&accountID,
+		&eventID,
+		&kind,
+		&recipient,
+		&subject,
+		&body,
+		&status,
+		&createdAt,
+		&sentAt,
+	)
+	switch {
+	case err == sql.ErrNoRows:
+		return domain.Notification{}, domain.ErrNotificationNotFound
+	case err != nil:
+		return domain.Notification{}, errors.Wrap(err, "error fetching notification")
+	}
+
+	return domain.NewNotification(
+		key,
+		domain.AccountID(accountID),
+		eventID,
+		domain.NotificationKind(kind),
+		recipient,
+		subject,
+		body,
+		domain.NotificationStatus(status),
+		createdAt,
+		sentAt.Time,
+	), nil
+}
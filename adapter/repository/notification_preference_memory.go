@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type NotificationPreferenceMemory struct {
+	memory *Memory
+}
+
+func NewNotificationPreferenceMemory(memory *Memory) NotificationPreferenceMemory {
+	return NotificationPreferenceMemory{
+		memory: memory,
+	}
+}
+
+func (n NotificationPreferenceMemory) Save(ctx context.Context, preference domain.NotificationPreference) error {
+	if err := n.memory.save(ctx, "notification_preferences", preference.AccountID().String(), preference); err != nil {
+		return errors.Wrap(err, "error saving notification preference")
+	}
+
+	return nil
+}
+
+func (n NotificationPreferenceMemory) FindByAccountID(
+	ctx context.Context,
+	ID domain.AccountID,
+) (domain.NotificationPreference, error) {
+	value, ok := n.memory.find(ctx, "notification_preferences", ID.String())
+	if !ok {
+		return domain.NotificationPreference{}, domain.ErrNotificationPreferenceNotFound
+	}
+
+	return value.(domain.NotificationPreference), nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type notificationPreferenceBSON struct {
+	AccountID           string    `bson:"account_id"`
+	Email               string    `bson:"email"`
+	Locale              string    `bson:"locale"`
+	TransferReceived    bool      `bson:"transfer_received"`
+	LowBalanceThreshold int64     `bson:"low_balance_threshold"`
+	UpdatedAt           time.Time `bson:"updated_at"`
+}
+
+type NotificationPreferenceNoSQL struct {
+	collectionName string
+	db             NoSQL
+}
+
+func NewNotificationPreferenceNoSQL(db NoSQL) NotificationPreferenceNoSQL {
+	return NotificationPreferenceNoSQL{
+		db:             db,
+		collectionName: "notification_preferences",
+	}
+}
+
+// Save stores the preference of an account that has none and replaces the fields of an existing one
+func (n NotificationPreferenceNoSQL) Save(ctx context.Context, preference domain.NotificationPreference) error {
+	var preferenceBSON = notificationPreferenceBSON{
+		AccountID:           preference.AccountID().String(),
+		Email:               preference.Email(),
+		Locale:              preference.Locale(),
+		TransferReceived:    preference.TransferReceived(),
+		LowBalanceThreshold: preference.LowBalanceThreshold().Int64(),
+		UpdatedAt:           preference.UpdatedAt(),
+	}
+
+	_, err := n.FindByAccountID(ctx, preference.AccountID())
+	switch err {
+	case domain.ErrNotificationPreferenceNotFound:
+		if err := n.db.Store(ctx, n.collectionName, preferenceBSON); err != nil {
+			return errors.Wrap(err, "error saving notification preference")
+		}
+	case nil:
+		var (
+			query  = bson.M{"account_id": preference.AccountID()}
+			update = bson.M{"$set": preferenceBSON}
+		)
+
+		if err := n.db.Update(ctx, n.collectionName, query, update); err != nil {
+			return errors.Wrap(err, "error saving notification preference")
+		}
+	default:
+		return err
+	}
+
+	return nil
+}
+
+func (n NotificationPreferenceNoSQL) FindByAccountID(
+	ctx context.Context,
+	ID domain.AccountID,
+) (domain.NotificationPreference, error) {
+	var preferenceBSON = &notificationPreferenceBSON{}
+
+	if err := n.db.FindOne(ctx, n.collectionName, bson.M{"account_id": ID}, nil, preferenceBSON); err != nil {
+		switch err {
+		case mongo.ErrNoDocuments:
+			return domain.NotificationPreference{}, domain.ErrNotificationPreferenceNotFound
+		default:
+			return domain.NotificationPreference{}, errors.Wrap(err, "error fetching notification preference")
+		}
+	}
+
+	return domain.NewNotificationPreference(
+		domain.AccountID(preferenceBSON.AccountID),
+		preferenceBSON.Email,
+		preferenceBSON.Locale,
+		preferenceBSON.TransferReceived,
+		domain.Money(preferenceBSON.LowBalanceThreshold),
+		preferenceBSON.UpdatedAt,
+	), nil
+}
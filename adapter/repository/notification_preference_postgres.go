@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type NotificationPreferenceSQL struct {
+	db SQL
+}
+
+func NewNotificationPreferenceSQL(db SQL) NotificationPreferenceSQL {
+	return NotificationPreferenceSQL{
+		db: db,
+	}
+}
+
+func (n NotificationPreferenceSQL) Save(ctx context.Context, preference domain.NotificationPreference) error {
+	var query = `
+		INSERT INTO
+			notification_preferences (account_id, email, locale, transfer_received, low_balance_threshold, updated_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+	` + n.db.Dialect().OnConflictUpdate(
+		[]string{"account_id"},
+		"email",
+		"locale",
+		"transfer_received",
+		"low_balance_threshold",
+		"updated_at",
+	)
+
+	if err := n.db.ExecuteContext(
+		ctx,
+		query,
+		preference.AccountID(),
+		preference.Email(),
+		preference.Locale(),
+		preference.TransferReceived(),
+		preference.LowBalanceThreshold(),
+		preference.UpdatedAt(),
+	); err != nil {
+		return errors.Wrap(err, "error saving notification preference")
+	}
+
+	return nil
+}
+
+func (n NotificationPreferenceSQL) FindByAccountID(
+	ctx context.Context,
+	ID domain.AccountID,
+) (domain.NotificationPreference, error) {
+	var (
+		query = `
+			SELECT email, locale, transfer_received, low_balance_threshold, updated_at
+			FROM notification_preferences
+			WHERE account_id = $1
+		`
+		email            string
+		locale           string
+		transferReceived bool
+		threshold        int64
+		updatedAt        time.Time
+	)
+
+	err := n.db.QueryRowContext(ctx, query, ID).Scan(&email, &locale, &transferReceived, &threshold, &updatedAt)
+	switch {
+	case err == sql.ErrNoRows:
+		return domain.NotificationPreference{}, domain.ErrNotificationPreferenceNotFound
+	case err != nil:
+		return domain.NotificationPreference{}, errors.Wrap(err, "error fetching notification preference")
+	}
+
+	return domain.NewNotificationPreference(
+		ID,
+		email,
+		locale,
+		transferReceived,
+		domain.Money(threshold),
+		updatedAt,
+	), nil
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type OutboxMemory struct {
+	memory *Memory
+}
+
+func NewOutboxMemory(memory *Memory) OutboxMemory {
+	return OutboxMemory{
+		memory: memory,
+	}
+}
+
+// Create writes the events as pending messages, in the transaction of the context when there is one, so they are
+// only relayed once the change they describe commits
+func (o OutboxMemory) Create(ctx context.Context, events ...domain.Event) error {
+	err := o.memory.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, event := range events {
+			var message = domain.NewOutboxMessage(
+				event,
+				atomic.AddInt64(&o.memory.sequence, 1),
+				domain.OutboxPending,
+				0,
+				event.OccurredAt(),
+				"",
+				time.Time{},
+			)
+
+			if err := o.memory.insert(ctx, "outbox", event.ID(), message); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "error creating outbox message")
+	}
+
+	return nil
+}
+
+// ClaimDue leases the due messages heading their aggregate, as the SQL one. Each message is locked and read again
+// before it is leased, as another relay may have taken it meanwhile
+func (o OutboxMemory) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	until time.Time,
+	limit int,
+) ([]domain.OutboxMessage, error) {
+	var messages = make([]domain.OutboxMessage, 0)
+
+	err := o.memory.WithTransaction(ctx, func(ctx context.Context) error {
+		var heads = make(map[string]bool)
+
+		for _, message := range o.findAll(ctx) {
+			if len(messages) == limit {
+				break
+			}
+
+			var aggregateID = message.Event().AggregateID()
+			if message.Status() != domain.OutboxPending || heads[aggregateID] {
+				continue
+			}
+			heads[aggregateID] = true
+
+			if !message.Due(now) {
+				continue
+			}
+
+			err := o.memory.change(ctx, "outbox", message.Event().ID(), func(value interface{}) interface{} {
+				var stored = value.(domain.OutboxMessage)
+				if !stored.Due(now) {
+					return stored
+				}
+
+				messages = append(messages, stored)
+				return domain.NewOutboxMessage(
+					stored.Event(),
+					stored.Sequence(),
+					stored.Status(),
+					stored.Attempts(),
+					until,
+					stored.LastError(),
+					stored.SentAt(),
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return []domain.OutboxMessage{}, errors.Wrap(err, "error claiming outbox messages")
+	}
+
+	return messages, nil
+}
+
+// Update writes the delivery state of the message, as the SQL one
+func (o OutboxMemory) Update(ctx context.Context, message domain.OutboxMessage) error {
+	err := o.memory.change(ctx, "outbox", message.Event().ID(), func(value interface{}) interface{} {
+		var stored = value.(domain.OutboxMessage)
+		return domain.NewOutboxMessage(
+			stored.Event(),
+			stored.Sequence(),
+			message.Status(),
+			message.Attempts(),
+			message.NextAttemptAt(),
+			message.LastError(),
+			message.SentAt(),
+		)
+	})
+	if err != nil {
+		return errors.Wrap(err, "error updating outbox message")
+	}
+
+	return nil
+}
+
+func (o OutboxMemory) CountByStatus(ctx context.Context) (map[domain.OutboxStatus]int, error) {
+	var counts = make(map[domain.OutboxStatus]int)
+	for _, message := range o.findAll(ctx) {
+		counts[message.Status()]++
+	}
+
+	return counts, nil
+}
+
+func (o OutboxMemory) WithTransaction(ctx context.Context, fn func(ctxTx context.Context) error) error {
+	return o.memory.WithTransaction(ctx, fn)
+}
+
+// findAll returns the messages in the order of their sequence
+func (o OutboxMemory) findAll(ctx context.Context) []domain.OutboxMessage {
+	var messages = make([]domain.OutboxMessage, 0)
+	for _, value := range o.memory.list(ctx, "outbox") {
+		messages = append(messages, value.(domain.OutboxMessage))
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Sequence() < messages[j].Sequence()
+	})
+
+	return messages
+}
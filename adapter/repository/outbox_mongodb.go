@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type outboxBSON struct {
+	ID            string     `bson:"id"`
+	Sequence      int64      `bson:"sequence"`
+	Name          string     `bson:"name"`
+	Version       int        `bson:"version"`
+	AggregateID   string     `bson:"aggregate_id"`
+	Data          string     `bson:"data"`
+	OccurredAt    time.Time  `bson:"occurred_at"`
+	Status        string     `bson:"status"`
+	Attempts      int        `bson:"attempts"`
+	NextAttemptAt time.Time  `bson:"next_attempt_at"`
+	LastError     string     `bson:"last_error"`
+	SentAt        *time.Time `bson:"sent_at"`
+}
+
+type OutboxNoSQL struct {
+	collectionName string
+	db             NoSQL
+}
+
+func NewOutboxNoSQL(db NoSQL) OutboxNoSQL {
+	return OutboxNoSQL{
+		db:             db,
+		collectionName: "outbox",
+	}
+}
+
+// Create writes the events as pending messages. Mongo has no sequence, so the messages are ordered by the time they
+// were written, the events of a call keeping their order
+func (o OutboxNoSQL) Create(ctx context.Context, events ...domain.Event) error {
+	var sequence = time.Now().UnixNano()
+
+	for i, event := range events {
+		var messageBSON = outboxBSON{
+			ID:            event.ID(),
+			Sequence:      sequence + int64(i),
+			Name:          event.Name().String(),
+			Version:       event.Version(),
+			AggregateID:   event.AggregateID(),
+			Data:          string(event.Data()),
+			OccurredAt:    event.OccurredAt(),
+			Status:        domain.OutboxPending.String(),
+			NextAttemptAt: event.OccurredAt(),
+		}
+
+		if err := o.db.Store(ctx, o.collectionName, messageBSON); err != nil {
+			return errors.Wrap(err, "error creating outbox message")
+		}
+	}
+
+	return nil
+}
+
+// ClaimDue leases the due messages heading their aggregate, as the SQL one. Each lease is taken by an update matching
+// only a message still due, so two relays never take the same one
+func (o OutboxNoSQL) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	until time.Time,
+	limit int,
+) ([]domain.OutboxMessage, error) {
+	var messagesBSON = make([]outboxBSON, 0)
+
+	if err := o.db.FindAll(ctx, o.collectionName, bson.M{"status": domain.OutboxPending}, &messagesBSON); err != nil {
+		return []domain.OutboxMessage{}, errors.Wrap(err, "error claiming outbox messages")
+	}
+
+	sort.SliceStable(messagesBSON, func(i, j int) bool {
+		return messagesBSON[i].Sequence < messagesBSON[j].Sequence
+	})
+
+	var (
+		messages = make([]domain.OutboxMessage, 0)
+		heads    = make(map[string]bool)
+	)
+
+	for _, m := range messagesBSON {
+		if len(messages) == limit {
+			break
+		}
+
+		if heads[m.AggregateID] {
+			continue
+		}
+		heads[m.AggregateID] = true
+
+		if m.NextAttemptAt.After(now) {
+			continue
+		}
+
+		var (
+			query = bson.M{
+				"id":              m.ID,
+				"status":          domain.OutboxPending,
+				"next_attempt_at": bson.M{"$lte": now},
+			}
+			update = bson.M{"$set": bson.M{"next_attempt_at": until}}
+		)
+
+		claimed, err := o.db.UpdateMatched(ctx, o.collectionName, query, update)
+		if err != nil {
+			return []domain.OutboxMessage{}, errors.Wrap(err, "error claiming outbox messages")
+		}
+
+		if !claimed {
+			continue
+		}
+
+		messages = append(messages, domain.NewOutboxMessage(
+			domain.NewEvent(m.ID, domain.EventName(m.Name), m.Version, m.AggregateID, []byte(m.Data), m.OccurredAt),
+			m.Sequence,
+			domain.OutboxStatus(m.Status),
+			m.Attempts,
+			m.NextAttemptAt,
+			m.LastError,
+			timeFromBSON(m.SentAt),
+		))
+	}
+
+	return messages, nil
+}
+
+func (o OutboxNoSQL) Update(ctx context.Context, message domain.OutboxMessage) error {
+	var (
+		query  = bson.M{"id": message.Event().ID()}
+		update = bson.M{"$set": bson.M{
+			"status":          message.Status().String(),
+			"attempts":        message.Attempts(),
+			"next_attempt_at": message.NextAttemptAt(),
+			"last_error":      message.LastError(),
+			"sent_at":         optionalTimeBSON(message.SentAt()),
+		}}
+	)
+
+	if err := o.db.Update(ctx, o.collectionName, query, update); err != nil {
+		return errors.Wrap(err, "error updating outbox message")
+	}
+
+	return nil
+}
+
+func (o OutboxNoSQL) CountByStatus(ctx context.Context) (map[domain.OutboxStatus]int, error) {
+	var counts = make(map[domain.OutboxStatus]int)
+
+	for _, status := range []domain.OutboxStatus{domain.OutboxPending, domain.OutboxSent, domain.OutboxDead} {
+		var messagesBSON = make([]struct {
+			ID string `bson:"id"`
+		}, 0)
+
+		if err := o.db.FindAll(ctx, o.collectionName, bson.M{"status": status}, &messagesBSON); err != nil {
+			return nil, errors.Wrap(err, "error counting outbox messages")
+		}
+
+		counts[status] = len(messagesBSON)
+	}
+
+	return counts, nil
+}
+
+func (o OutboxNoSQL) WithTransaction(ctx context.Context, fn func(ctxTx context.Context) error) error {
+	session, err := o.db.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	return session.WithTransaction(ctx, fn)
+}
@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type OutboxSQL struct {
+	db SQL
+}
+
+func NewOutboxSQL(db SQL) OutboxSQL {
+	return OutboxSQL{
+		db: db,
+	}
+}
+
+// Create writes the events as pending messages. The sequence, which orders the messages, is assigned by the database
+func (o OutboxSQL) Create(ctx context.Context, events ...domain.Event) error {
+	var query = `
+		INSERT INTO
+			outbox (id, name, version, aggregate_id, data, occurred_at, status, attempts, next_attempt_at, last_error)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, 0, $6, '')
+	`
+
+	var exec = o.db.ExecuteContext
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		exec = tx.ExecuteContext
+	}
+
+	for _, event := range events {
+		if err := exec(
+			ctx,
+			query,
+			event.ID(),
+			event.Name(),
+			event.Version(),
+			event.AggregateID(),
+			string(event.Data()),
+			event.OccurredAt(),
+			domain.OutboxPending,
+		); err != nil {
+			return errors.Wrap(err, "error creating outbox message")
+		}
+	}
+
+	return nil
+}
+
+// ClaimDue leases the due messages heading their aggregate by moving their next attempt to the end of the lease, so
+// no other relay takes them meanwhile. The messages are locked while they are leased, skipping the ones another relay
+// is leasing at the same time
+func (o OutboxSQL) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	until time.Time,
+	limit int,
+) ([]domain.OutboxMessage, error) {
+	var query = `
+		SELECT o.sequence, o.id, o.name, o.version, o.aggregate_id, o.data, o.occurred_at, o.status, o.attempts,
+			o.next_attempt_at, o.last_error, o.sent_at
+		FROM outbox o
+		WHERE o.status = $1
+			AND o.next_attempt_at <= $2
+			AND NOT EXISTS (
+				SELECT 1 FROM outbox p
+				WHERE p.aggregate_id = o.aggregate_id AND p.status = $1 AND p.sequence < o.sequence
+			)
+		ORDER BY o.sequence
+		LIMIT $3
+	` + o.db.Dialect().ForUpdateSkipLocked()
+
+	var messages = make([]domain.OutboxMessage, 0)
+
+	err := o.WithTransaction(ctx, func(ctxTx context.Context) error {
+		var tx = ctxTx.Value("TransactionContextKey").(Tx)
+
+		rows, err := tx.QueryContext(ctxTx, query, domain.OutboxPending, now, limit)
+		if err != nil {
+			return err
+		}
+
+		messages, err = scanOutboxMessages(rows)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			if err = tx.ExecuteContext(
+				ctxTx,
+				`UPDATE outbox SET next_attempt_at = $1 WHERE id = $2`,
+				until,
+				message.Event().ID(),
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return []domain.OutboxMessage{}, errors.Wrap(err, "error claiming outbox messages")
+	}
+
+	return messages, nil
+}
+
+// scanOutboxMessages reads the messages of the rows, closing them
+func scanOutboxMessages(rows Rows) ([]domain.OutboxMessage, error) {
+	defer rows.Close()
+
+	var messages = make([]domain.OutboxMessage, 0)
+	for rows.Next() {
+		var (
+			sequence      int64
+			ID            string
+			name          string
+			version       int
+			aggregateID   string
+			data          string
+			occurredAt    time.Time
+			status        string
+			attempts      int
+			nextAttemptAt time.Time
+			lastError     string
+			sentAt        sql.NullTime
+		)
+
+		if err := rows.Scan(
+			&sequence,
+			&ID,
+			&name,
+			&version,
+			&aggregateID,
+			&data,
+			&occurredAt,
+			&status,
+			&attempts,
+			&nextAttemptAt,
+			&lastError,
+			&sentAt,
+		); err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, domain.NewOutboxMessage(
+			domain.NewEvent(ID, domain.EventName(name), version, aggregateID, []byte(data), occurredAt),
+			sequence,
+			domain.OutboxStatus(status),
+			attempts,
+			nextAttemptAt,
+			lastError,
+			sentAt.Time,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (o OutboxSQL) Update(ctx context.Context, message domain.OutboxMessage) error {
+	var query = `
+		UPDATE outbox
+		SET status = $1, attempts = $2, next_attempt_at = $3, last_error = $4, sent_at = $5
+		WHERE id = $6
+	`
+
+	var exec = o.db.ExecuteContext
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		exec = tx.ExecuteContext
+	}
+
+	if err := exec(
+		ctx,
+		query,
+		message.Status(),
+		message.Attempts(),
+		message.NextAttemptAt(),
+		message.LastError(),
+		nullTime(message.SentAt()),
+		message.Event().ID(),
+	); err != nil {
+		return errors.Wrap(err, "error updating outbox message")
+	}
+
+	return nil
+}
+
+func (o OutboxSQL) CountByStatus(ctx context.Context) (map[domain.OutboxStatus]int, error) {
+	var query = `SELECT status, COUNT(*) FROM outbox GROUP BY status`
+
+	rows, err := o.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "error counting outbox messages")
+	}
+	defer rows.Close()
+
+	var counts = make(map[domain.OutboxStatus]int)
+	for rows.Next() {
+		var (
+			status string
+			count  int
+		)
+
+		if err = rows.Scan(&status, &count); err != nil {
+			return nil, errors.Wrap(err, "error counting outbox messages")
+		}
+
+		counts[domain.OutboxStatus(status)] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error counting outbox messages")
+	}
+
+	return counts, nil
+}
+
+func (o OutboxSQL) WithTransaction(ctx context.Context, fn func(ctxTx context.Context) error) error {
+	tx, err := o.db.BeginTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error begin tx")
+	}
+
+	ctxTx := context.WithValue(ctx, "TransactionContextKey", tx)
+	err = fn(ctxTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Wrap(err, "rollback error")
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// sortColumn is the column, or the field, compared by the sort
+func sortColumn(sort domain.Sort) string {
+	switch sort.By() {
+	case domain.SortByAmount:
+		return "amount"
+	default:
+		return "created_at"
+	}
+}
+
+// keysetValue is the value of the item at the cursor in the column of its sort
+func keysetValue(after domain.Cursor) interface{} {
+	switch after.Sort.By() {
+	case domain.SortByAmount:
+		return after.Amount
+	default:
+		return after.CreatedAt
+	}
+}
+
+// keysetCondition builds the condition of the rows after the cursor, in the order of its sort and then of id. Its
+// placeholders are numbered after the args it is appended to
+func keysetCondition(after domain.Cursor, args []interface{}) (string, []interface{}) {
+	var operator = ">"
+	if after.Sort.Descending {
+		operator = "<"
+	}
+
+	args = append(args, keysetValue(after), after.ID)
+
+	return fmt.Sprintf(
+		"(%[1]s %[2]s $%[3]d OR (%[1]s = $%[3]d AND id %[2]s $%[4]d))",
+		sortColumn(after.Sort),
+		operator,
+		len(args)-1,
+		len(args),
+	), args
+}
+
+// keysetOrder is the ORDER BY clause of the sort, the ID breaking its ties
+func keysetOrder(sort domain.Sort) string {
+	var direction string
+	if sort.Descending {
+		direction = " DESC"
+	}
+
+	return fmt.Sprintf(" ORDER BY %s%s, id%s", sortColumn(sort), direction, direction)
+}
+
+// keysetQuery is the keysetCondition of a document query
+func keysetQuery(after domain.Cursor) bson.M {
+	var (
+		field    = sortColumn(after.Sort)
+		value    = keysetValue(after)
+		operator = "$gt"
+	)
+
+	if after.Sort.Descending {
+		operator = "$lt"
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{operator: value}},
+		bson.M{field: value, "id": bson.M{operator: after.ID}},
+	}}
+}
+
+// keysetSort is the keysetOrder of a document query
+func keysetSort(sort domain.Sort) bson.D {
+	var direction = 1
+	if sort.Descending {
+		direction = -1
+	}
+
+	return bson.D{{Key: sortColumn(sort), Value: direction}, {Key: "id", Value: direction}}
+}
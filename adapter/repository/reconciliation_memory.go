@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type ReconciliationMemory struct {
+	memory *Memory
+}
+
+func NewReconciliationMemory(memory *Memory) ReconciliationMemory {
+	return ReconciliationMemory{
+		memory: memory,
+	}
+}
+
+func (r ReconciliationMemory) Create(ctx context.Context, run domain.ReconciliationRun) error {
+	if err := r.memory.insert(ctx, "reconciliation_runs", run.ID().String(), run); err != nil {
+		return errors.Wrap(err, "error creating reconciliation run")
+	}
+
+	return nil
+}
+
+// Update writes the state of the run and the accounts it reports together, so a finished run is never read without them
+func (r ReconciliationMemory) Update(ctx context.Context, run domain.ReconciliationRun) error {
+	err := r.memory.change(ctx, "reconciliation_runs", run.ID().String(), func(value interface{}) interface{} {
+		var stored = value.(domain.ReconciliationRun)
+		return domain.NewReconciliationRun(
+			stored.ID(),
+			run.Status(),
+			stored.Trigger(),
+			run.AccountsChecked(),
+			run.Mismatches(),
+			run.Unverifiable(),
+			run.LastError(),
+			stored.RequestedAt(),
+			run.StartedAt(),
+			run.FinishedAt(),
+		)
+	})
+	if err != nil {
+		return errors.Wrap(err, "error updating reconciliation run")
+	}
+
+	return nil
+}
+
+func (r ReconciliationMemory) FindByID(ctx context.Context, ID domain.ReconciliationRunID) (domain.ReconciliationRun, error) {
+	value, ok := r.memory.find(ctx, "reconciliation_runs", ID.String())
+	if !ok {
+		return domain.ReconciliationRun{}, domain.ErrReconciliationNotFound
+	}
+
+	return value.(domain.ReconciliationRun), nil
+}
+
+func (r ReconciliationMemory) FindPending(ctx context.Context) ([]domain.ReconciliationRun, error) {
+	var runs = make([]domain.ReconciliationRun, 0)
+	for _, run := range r.findAll(ctx) {
+		if run.Status() == domain.ReconciliationPending {
+			runs = append(runs, run)
+		}
+	}
+
+	return runs, nil
+}
+
+func (r ReconciliationMemory) FindLatest(ctx context.Context) (domain.ReconciliationRun, error) {
+	var runs = r.findAll(ctx)
+	if len(runs) == 0 {
+		return domain.ReconciliationRun{}, domain.ErrReconciliationNotFound
+	}
+
+	return runs[len(runs)-1], nil
+}
+
+// findAll returns the runs in the order they were requested
+func (r ReconciliationMemory) findAll(ctx context.Context) []domain.ReconciliationRun {
+	var runs = make([]domain.ReconciliationRun, 0)
+	for _, value := range r.memory.list(ctx, "reconciliation_runs") {
+		runs = append(runs, value.(domain.ReconciliationRun))
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].RequestedAt().Before(runs[j].RequestedAt())
+	})
+
+	return runs
+}
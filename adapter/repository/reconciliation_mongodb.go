@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type reconciliationRunBSON struct {
+	ID              string                       `bson:"id"`
+	Status          string                       `bson:"status"`
+	Trigger         string                       `bson:"trigger"`
+	AccountsChecked int                          `bson:"accounts_checked"`
+	Mismatches      []reconciliationMismatchBSON `bson:"mismatches"`
+	Unverifiable    []string                     `bson:"unverifiable"`
+	LastError       string                       `bson:"last_error"`
+	RequestedAt     time.Time                    `bson:"requested_at"`
+	StartedAt       *time.Time                   `bson:"started_at"`
+	FinishedAt      *time.Time                   `bson:"finished_at"`
+}
+
+type reconciliationMismatchBSON struct {
+	AccountID       string `bson:"account_id"`
+	StoredBalance   int64  `bson:"stored_balance"`
+	ExpectedBalance int64  `bson:"expected_balance"`
+}
+
+func (r reconciliationRunBSON) toDomain() domain.ReconciliationRun {
+	var mismatches = make([]domain.ReconciliationMismatch, 0, len(r.Mismatches))
+	for _, m := range r.Mismatches {
+		mismatches = append(mismatches, domain.NewReconciliationMismatch(
+			domain.AccountID(m.AccountID),
+			domain.Money(m.StoredBalance),
+			domain.Money(m.ExpectedBalance),
+		))
+	}
+
+	var unverifiable = make([]domain.AccountID, 0, len(r.Unverifiable))
+	for _, ID := range r.Unverifiable {
+		unverifiable = append(unverifiable, domain.AccountID(ID))
+	}
+
+	return domain.NewReconciliationRun(
+		domain.ReconciliationRunID(r.ID),
+		domain.ReconciliationStatus(r.Status),
+		domain.ReconciliationTrigger(r.Trigger),
+		r.AccountsChecked,
+		mismatches,
+		unverifiable,
+		r.LastError,
+		r.RequestedAt,
+		timeFromBSON(r.StartedAt),
+		timeFromBSON(r.FinishedAt),
+	)
+}
+
+func reconciliationMismatchesBSON(run domain.ReconciliationRun) []reconciliationMismatchBSON {
+	var mismatches = make([]reconciliationMismatchBSON, 0, len(run.Mismatches()))
+	for _, m := range run.Mismatches() {
+		mismatches = append(mismatches, reconciliationMismatchBSON{
+			AccountID:       m.AccountID().String(),
+			StoredBalance:   m.Stored().Int64(),
+			ExpectedBalance: m.Expected().Int64(),
+		})
+	}
+
+	return mismatches
+}
+
+func reconciliationUnverifiableBSON(run domain.ReconciliationRun) []string {
+	var unverifiable = make([]string, 0, len(run.Unverifiable()))
+	for _, ID := range run.Unverifiable() {
+		unverifiable = append(unverifiable, ID.String())
+	}
+
+	return unverifiable
+}
+
+// ReconciliationNoSQL keeps a run and the accounts it reports in a single document, so they are written together
+type ReconciliationNoSQL struct {
+	collectionName string
+	db             NoSQL
+}
+
+func NewReconciliationNoSQL(db NoSQL) ReconciliationNoSQL {
+	return ReconciliationNoSQL{
+		db:             db,
+		collectionName: "reconciliation_runs",
+	}
+}
+
+func (r ReconciliationNoSQL) Create(ctx context.Context, run domain.ReconciliationRun) error {
+	var runBSON = reconciliationRunBSON{
+		ID:              run.ID().String(),
+		Status:          run.Status().String(),
+		Trigger:         run.Trigger().String(),
+		AccountsChecked: run.AccountsChecked(),
+		Mismatches:      reconciliationMismatchesBSON(run),
+		Unverifiable:    reconciliationUnverifiableBSON(run),
+		LastError:       run.LastError(),
+		RequestedAt:     run.RequestedAt(),
+		StartedAt:       optionalTimeBSON(run.StartedAt()),
+		FinishedAt:      optionalTimeBSON(run.FinishedAt()),
+	}
+
+	if err := r.db.Store(ctx, r.collectionName, runBSON); err != nil {
+		return errors.Wrap(err, "error creating reconciliation run")
+	}
+
+	return nil
+}
+
+func (r ReconciliationNoSQL) Update(ctx context.Context, run domain.ReconciliationRun) error {
+	var (
+		query  = bson.M{"id": run.ID()}
+		update = bson.M{"$set": bson.M{
+			"status":           run.Status().String(),
+			"accounts_checked": run.AccountsChecked(),
+			"mismatches":       reconciliationMismatchesBSON(run),
+			"unverifiable":     reconciliationUnverifiableBSON(run),
+			"last_error":       run.LastError(),
+			"started_at":       optionalTimeBSON(run.StartedAt()),
+			"finished_at":      optionalTimeBSON(run.FinishedAt()),
+		}}
+	)
+
+	if err := r.db.Update(ctx, r.collectionName, query, update); err != nil {
+		return errors.Wrap(err, "error updating reconciliation run")
+	}
+
+	return nil
+}
+
+func (r ReconciliationNoSQL) FindByID(ctx context.Context, ID domain.ReconciliationRunID) (domain.ReconciliationRun, error) {
+	var runBSON = &reconciliationRunBSON{}
+
+	if err := r.db.FindOne(ctx, r.collectionName, bson.M{"id": ID}, nil, runBSON); err != nil {
+		switch err {
+		case mongo.ErrNoDocuments:
+			return domain.ReconciliationRun{}, domain.ErrReconciliationNotFound
+		default:
+			return domain.ReconciliationRun{}, errors.Wrap(err, "error fetching reconciliation run")
+		}
+	}
+
+	return runBSON.toDomain(), nil
+}
+
+func (r ReconciliationNoSQL) FindPending(ctx context.Context) ([]domain.ReconciliationRun, error) {
+	var runsBSON = make([]reconciliationRunBSON, 0)
+
+	if err := r.db.FindAll(ctx, r.collectionName, bson.M{"status": domain.ReconciliationPending}, &runsBSON); err != nil {
+		return []domain.ReconciliationRun{}, errors.Wrap(err, "error listing reconciliation runs")
+	}
+
+	sort.SliceStable(runsBSON, func(i, j int) bool {
+		return runsBSON[i].RequestedAt.Before(runsBSON[j].RequestedAt)
+	})
+
+	var runs = make([]domain.ReconciliationRun, 0, len(runsBSON))
+	for _, runBSON := range runsBSON {
+		runs = append(runs, runBSON.toDomain())
+	}
+
+	return runs, nil
+}
+
+func (r ReconciliationNoSQL) FindLatest(ctx context.Context) (domain.ReconciliationRun, error) {
+	var runsBSON = make([]reconciliationRunBSON, 0)
+
+	if err := r.db.FindAll(ctx, r.collectionName, bson.M{}, &runsBSON); err != nil {
+		return domain.ReconciliationRun{}, errors.Wrap(err, "error fetching reconciliation run")
+	}
+
+	if len(runsBSON) == 0 {
+		return domain.ReconciliationRun{}, domain.ErrReconciliationNotFound
+	}
+
+	var latest = runsBSON[0]
+	for _, runBSON := range runsBSON[1:] {
+		if runBSON.RequestedAt.After(latest.RequestedAt) {
+			latest = runBSON
+		}
+	}
+
+	return latest.toDomain(), nil
+}
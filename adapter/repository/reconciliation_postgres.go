@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+const reconciliationRunColumns = "id, status, triggered_by, accounts_checked, last_error, requested_at, started_at, finished_at"
+
+type ReconciliationSQL struct {
+	db SQL
+}
+
+func NewReconciliationSQL(db SQL) ReconciliationSQL {
+	return ReconciliationSQL{
+		db: db,
+	}
+}
+
+func (r ReconciliationSQL) Create(ctx context.Context, run domain.ReconciliationRun) error {
+	var query = `
+		INSERT INTO
+			reconciliation_runs (` + reconciliationRunColumns + `)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	if err := r.db.ExecuteContext(
+		ctx,
+		query,
+		run.ID(),
+		run.Status(),
+		run.Trigger(),
+		run.AccountsChecked(),
+		run.LastError(),
+		run.RequestedAt(),
+		nullTime(run.StartedAt()),
+		nullTime(run.FinishedAt()),
+	); err != nil {
+		return errors.Wrap(err, "error creating reconciliation run")
+	}
+
+	return nil
+}
+
+// Update writes the state of the run and the accounts it reports in one transaction, so a finished run is never read
+// without them
+func (r ReconciliationSQL) Update(ctx context.Context, run domain.ReconciliationRun) error {
+	tx, err := r.db.BeginTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error updating reconciliation run")
+	}
+
+	if err = r.update(ctx, tx, run); err != nil {
+		_ = tx.Rollback()
+		return errors.Wrap(err, "error updating reconciliation run")
+	}
+
+	return tx.Commit()
+}
+
+func (r ReconciliationSQL) update(ctx context.Context, tx Tx, run domain.ReconciliationRun) error {
+	var query = `
+		UPDATE reconciliation_runs
+		SET status = $1, accounts_checked = $2, last_error = $3, started_at = $4, finished_at = $5
+		WHERE id = $6
+	`
+
+	if err := tx.ExecuteContext(
+		ctx,
+		query,
+		run.Status(),
+		run.AccountsChecked(),
+		run.LastError(),
+		nullTime(run.StartedAt()),
+		nullTime(run.FinishedAt()),
+		run.ID(),
+	); err != nil {
+		return err
+	}
+
+	if err := tx.ExecuteContext(ctx, "DELETE FROM reconciliation_mismatches WHERE run_id = $1", run.ID()); err != nil {
+		return err
+	}
+
+	for _, mismatch := range run.Mismatches() {
+		if err := tx.ExecuteContext(
+			ctx,
+			`INSERT INTO reconciliation_mismatches (run_id, account_id, stored_balance, expected_balance)
+			VALUES ($1, $2, $3, $4)`,
+			run.ID(),
+			mismatch.AccountID(),
+			mismatch.Stored(),
+			mismatch.Expected(),
+		); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.ExecuteContext(ctx, "DELETE FROM reconciliation_unverifiable WHERE run_id = $1", run.ID()); err != nil {
+		return err
+	}
+
+	for _, accountID := range run.Unverifiable() {
+		if err := tx.ExecuteContext(
+			ctx,
+			"INSERT INTO reconciliation_unverifiable (run_id, account_id) VALUES ($1, $2)",
+			run.ID(),
+			accountID,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r ReconciliationSQL) FindByID(ctx context.Context, ID domain.ReconciliationRunID) (domain.ReconciliationRun, error) {
+	var query = "SELECT " + reconciliationRunColumns + " FROM reconciliation_runs WHERE id = $1"
+
+	return r.findOne(ctx, query, ID)
+}
+
+func (r ReconciliationSQL) FindPending(ctx context.Context) ([]domain.ReconciliationRun, error) {
+	var query = "SELECT " + reconciliationRunColumns + " FROM reconciliation_runs WHERE status = $1 ORDER BY requested_at"
+
+	rows, err := r.db.QueryContext(ctx, query, domain.ReconciliationPending)
+	if err != nil {
+		return []domain.ReconciliationRun{}, errors.Wrap(err, "error listing reconciliation runs")
+	}
+	defer rows.Close()
+
+	var runs = make([]domain.ReconciliationRun, 0)
+	for rows.Next() {
+		run, err := scanReconciliationRun(rows)
+		if err != nil {
+			return []domain.ReconciliationRun{}, errors.Wrap(err, "error listing reconciliation runs")
+		}
+
+		runs = append(runs, run)
+	}
+
+	if err = rows.Err(); err != nil {
+		return []domain.ReconciliationRun{}, err
+	}
+
+	return runs, nil
+}
+
+func (r ReconciliationSQL) FindLatest(ctx context.Context) (domain.ReconciliationRun, error) {
+	var query = "SELECT " + reconciliationRunColumns + " FROM reconciliation_runs ORDER BY requested_at DESC LIMIT 1"
+
+	return r.findOne(ctx, query)
+}
+
+func (r ReconciliationSQL) findOne(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) (domain.ReconciliationRun, error) {
+	run, err := scanReconciliationRun(r.db.QueryRowContext(ctx, query, args...))
+	switch {
+	case err == sql.ErrNoRows:
+		return domain.ReconciliationRun{}, domain.ErrReconciliationNotFound
+	case err != nil:
+		return domain.ReconciliationRun{}, errors.Wrap(err, "error fetching reconciliation run")
+	}
+
+	mismatches, err := r.findMismatches(ctx, run.ID())
+	if err != nil {
+		return domain.ReconciliationRun{}, errors.Wrap(err, "error fetching reconciliation run")
+	}
+
+	unverifiable, err := r.findUnverifiable(ctx, run.ID())
+	if err != nil {
+		return domain.ReconciliationRun{}, errors.Wrap(err, "error fetching reconciliation run")
+	}
+
+	return domain.NewReconciliationRun(
+		run.ID(),
+		run.Status(),
+		run.Trigger(),
+		run.AccountsChecked(),
+		mismatches,
+		unverifiable,
+		run.LastError(),
+		run.RequestedAt(),
+		run.StartedAt(),
+		run.FinishedAt(),
+	), nil
+}
+
+func (r ReconciliationSQL) findMismatches(
+	ctx context.Context,
+	ID domain.ReconciliationRunID,
+) ([]domain.ReconciliationMismatch, error) {
+	var query = `
+		SELECT account_id, stored_balance, expected_balance
+		FROM reconciliation_mismatches
+		WHERE run_id = $1
+		ORDER BY account_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mismatches = make([]domain.ReconciliationMismatch, 0)
+	for rows.Next() {
+		var (
+			accountID string
+			stored    int64
+			expected  int64
+		)
+
+		if err = rows.Scan(&accountID, &stored, &expected); err != nil {
+			return nil, err
+		}
+
+		mismatches = append(mismatches, domain.NewReconciliationMismatch(
+			domain.AccountID(accountID),
+			domain.Money(stored),
+			domain.Money(expected),
+		))
+	}
+
+	return mismatches, rows.Err()
+}
+
+func (r ReconciliationSQL) findUnverifiable(ctx context.Context, ID domain.ReconciliationRunID) ([]domain.AccountID, error) {
+	var query = "SELECT account_id FROM reconciliation_unverifiable WHERE run_id = $1 ORDER BY account_id"
+
+	rows, err := r.db.QueryContext(ctx, query, ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var unverifiable = make([]domain.AccountID, 0)
+	for rows.Next() {
+		var accountID string
+		if err = rows.Scan(&accountID); err != nil {
+			return nil, err
+		}
+
+		unverifiable = append(unverifiable, domain.AccountID(accountID))
+	}
+
+	return unverifiable, rows.Err()
+}
+
+// scanReconciliationRun reads a run without the accounts it reports, which only finished runs have
+func scanReconciliationRun(row Row) (domain.ReconciliationRun, error) {
+	var (
+		ID              string
+		status          string
+		trigger         string
+		accountsChecked int
+		lastError       string
+		requestedAt     time.Time
+		startedAt       sql.NullTime
+		finishedAt      sql.NullTime
+	)
+
+	if err := row.Scan(
+		&ID,
+		&status,
+		&trigger,
+		&accountsChecked,
+		&lastError,
+		&requestedAt,
+		&startedAt,
+		&finishedAt,
+	); err != nil {
+		return domain.ReconciliationRun{}, err
+	}
+
+	return domain.NewReconciliationRun(
+		domain.ReconciliationRunID(ID),
+		domain.ReconciliationStatus(status),
+		domain.ReconciliationTrigger(trigger),
+		accountsChecked,
+		nil,
+		nil,
+		lastError,
+		requestedAt,
+		startedAt.Time,
+		finishedAt.Time,
+	), nil
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type RiskEvaluationMemory struct {
+	memory *Memory
+}
+
+func NewRiskEvaluationMemory(memory *Memory) RiskEvaluationMemory {
+	return RiskEvaluationMemory{
+		memory: memory,
+	}
+}
+
+// Create writes the evaluation in the transaction of the context when there is one, next to the transfer it decided
+func (r RiskEvaluationMemory) Create(ctx context.Context, evaluation domain.RiskEvaluation) (domain.RiskEvaluation, error) {
+	if err := r.memory.insert(ctx, "risk_evaluations", evaluation.ID().String(), evaluation); err != nil {
+		return domain.RiskEvaluation{}, errors.Wrap(err, "error creating risk evaluation")
+	}
+
+	return evaluation, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type riskEvaluationBSON struct {
+	ID                   string           `bson:"id"`
+	TransferID           string           `bson:"transfer_id"`
+	AccountOriginID      string           `bson:"account_origin_id"`
+	AccountDestinationID string           `bson:"account_destination_id"`
+	Amount               int64            `bson:"amount"`
+	Decision             string           `bson:"decision"`
+	Results              []ruleResultBSON `bson:"results"`
+	CreatedAt            time.Time        `bson:"created_at"`
+}
+
+type ruleResultBSON struct {
+	Rule     string `bson:"rule"`
+	Decision string `bson:"decision"`
+	Reason   string `bson:"reason,omitempty"`
+}
+
+type RiskEvaluationNoSQL struct {
+	collectionName string
+	db             NoSQL
+}
+
+func NewRiskEvaluationNoSQL(db NoSQL) RiskEvaluationNoSQL {
+	return RiskEvaluationNoSQL{
+		db:             db,
+		collectionName: "risk_evaluations",
+	}
+}
+
+func (r RiskEvaluationNoSQL) Create(ctx context.Context, evaluation domain.RiskEvaluation) (domain.RiskEvaluation, error) {
+	var results = make([]ruleResultBSON, 0, len(evaluation.Results()))
+	for _, result := range evaluation.Results() {
+		results = append(results, ruleResultBSON{
+			Rule:     result.Rule(),
+			Decision: result.Decision().String(),
+			Reason:   result.Reason(),
+		})
+	}
+
+	var evaluationBSON = riskEvaluationBSON{
+		ID:                   evaluation.ID().String(),
+		TransferID:           evaluation.TransferID().String(),
+		AccountOriginID:      evaluation.AccountOriginID().String(),
+		AccountDestinationID: evaluation.AccountDestinationID().String(),
+		Amount:               evaluation.Amount().Int64(),
+		Decision:             evaluation.Decision().String(),
+		Results:              results,
+		CreatedAt:            evaluation.CreatedAt(),
+	}
+
+	if err := r.db.Store(ctx, r.collectionName, evaluationBSON); err != nil {
+		return domain.RiskEvaluation{}, errors.Wrap(err, "error creating risk evaluation")
+	}
+
+	return evaluation, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type ruleResultJSON struct {
+	Rule     string `json:"rule"`
+	Decision string `json:"decision"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+type RiskEvaluationSQL struct {
+	db SQL
+}
+
+func NewRiskEvaluationSQL(db SQL) RiskEvaluationSQL {
+	return RiskEvaluationSQL{
+		db: db,
+	}
+}
+
+func (r RiskEvaluationSQL) Create(ctx context.Context, evaluation domain.RiskEvaluation) (domain.RiskEvaluation, error) {
+	var results = make([]ruleResultJSON, 0, len(evaluation.Results()))
+	for _, result := range evaluation.Results() {
+		results = append(results, ruleResultJSON{
+			Rule:     result.Rule(),
+			Decision: result.Decision().String(),
+			Reason:   result.Reason(),
+		})
+	}
+
+	raw, err := json.Marshal(results)
+	if err != nil {
+		return domain.RiskEvaluation{}, errors.Wrap(err, "error creating risk evaluation")
+	}
+
+	var query = `
+		INSERT INTO
+			risk_evaluations (id, transfer_id, account_origin_id, account_destination_id, amount, decision, results, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	var exec = r.db.ExecuteContext
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		exec = tx.ExecuteContext
+	}
+
+	if err := exec(
+		ctx,
+		query,
+		evaluation.ID(),
+		evaluation.TransferID(),
+		evaluation.AccountOriginID(),
+		evaluation.AccountDestinationID(),
+		evaluation.Amount(),
+		evaluation.Decision(),
+		string(raw),
+		evaluation.CreatedAt(),
+	); err != nil {
+		return domain.RiskEvaluation{}, errors.Wrap(err, "error creating risk evaluation")
+	}
+
+	return evaluation, nil
+}
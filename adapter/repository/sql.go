@@ -4,9 +4,13 @@ import "context"
 
 type SQL interface {
 	ExecuteContext(context.Context, string, ...interface{}) error
+	// ExecuteAffectedContext runs the statement as ExecuteContext, answering how many rows it changed
+	ExecuteAffectedContext(context.Context, string, ...interface{}) (int64, error)
 	QueryContext(context.Context, string, ...interface{}) (Rows, error)
 	QueryRowContext(context.Context, string, ...interface{}) Row
 	BeginTx(ctx context.Context) (Tx, error)
+	// Dialect is the flavor of SQL of the engine, for the parts of the queries it changes
+	Dialect() Dialect
 }
 
 type Rows interface {
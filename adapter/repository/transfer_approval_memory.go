@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type TransferApprovalMemory struct {
+	memory *Memory
+}
+
+func NewTransferApprovalMemory(memory *Memory) TransferApprovalMemory {
+	return TransferApprovalMemory{
+		memory: memory,
+	}
+}
+
+// Create writes the approval in the transaction of the context when there is one, next to the transfer it decided
+func (t TransferApprovalMemory) Create(ctx context.Context, approval domain.TransferApproval) (domain.TransferApproval, error) {
+	if err := t.memory.insert(ctx, "transfer_approvals", approval.ID().String(), approval); err != nil {
+		return domain.TransferApproval{}, errors.Wrap(err, "error creating transfer approval")
+	}
+
+	return approval, nil
+}
+
+func (t TransferApprovalMemory) FindAllByTransfer(ctx context.Context, ID domain.TransferID) ([]domain.TransferApproval, error) {
+	var approvals = make([]domain.TransferApproval, 0)
+	for _, value := range t.memory.list(ctx, "transfer_approvals") {
+		if approval := value.(domain.TransferApproval); approval.TransferID() == ID {
+			approvals = append(approvals, approval)
+		}
+	}
+
+	sort.SliceStable(approvals, func(i, j int) bool {
+		return approvals[i].CreatedAt().Before(approvals[j].CreatedAt())
+	})
+
+	return approvals, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type transferApprovalBSON struct {
+	ID         string    `bson:"id"`
+	TransferID string    `bson:"transfer_id"`
+	Action     string    `bson:"action"`
+	Actor      string    `bson:"actor"`
+	Role       string    `bson:"role"`
+	Reason     string    `bson:"reason"`
+	CreatedAt  time.Time `bson:"created_at"`
+}
+
+type TransferApprovalNoSQL struct {
+	collectionName string
+	db             NoSQL
+}
+
+func NewTransferApprovalNoSQL(db NoSQL) TransferApprovalNoSQL {
+	return TransferApprovalNoSQL{
+		db:             db,
+		collectionName: "transfer_approvals",
+	}
+}
+
+func (t TransferApprovalNoSQL) Create(ctx context.Context, approval domain.TransferApproval) (domain.TransferApproval, error) {
+	var approvalBSON = transferApprovalBSON{
+		ID:         approval.ID().String(),
+		TransferID: approval.TransferID().String(),
+		Action:     approval.Action().String(),
+		Actor:      approval.Actor(),
+		Role:       approval.Role().String(),
+		Reason:     approval.Reason(),
+		CreatedAt:  approval.CreatedAt(),
+	}
+
+	if err := t.db.Store(ctx, t.collectionName, approvalBSON); err != nil {
+		return domain.TransferApproval{}, errors.Wrap(err, "error creating transfer approval")
+	}
+
+	return approval, nil
+}
+
+func (t TransferApprovalNoSQL) FindAllByTransfer(ctx context.Context, ID domain.TransferID) ([]domain.TransferApproval, error) {
+	var approvalsBSON = make([]transferApprovalBSON, 0)
+
+	if err := t.db.FindAll(ctx, t.collectionName, bson.M{"transfer_id": ID}, &approvalsBSON); err != nil {
+		return []domain.TransferApproval{}, errors.Wrap(err, "error listing transfer approvals")
+	}
+
+	var approvals = make([]domain.TransferApproval, 0, len(approvalsBSON))
+	for _, approvalBSON := range approvalsBSON {
+		approvals = append(approvals, domain.NewTransferApproval(
+			domain.TransferApprovalID(approvalBSON.ID),
+			domain.TransferID(approvalBSON.TransferID),
+			domain.ApprovalAction(approvalBSON.Action),
+			approvalBSON.Actor,
+			domain.Role(approvalBSON.Role),
+			approvalBSON.Reason,
+			approvalBSON.CreatedAt,
+		))
+	}
+
+	sort.SliceStable(approvals, func(i, j int) bool {
+		return approvals[i].CreatedAt().Before(approvals[j].CreatedAt())
+	})
+
+	return approvals, nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type TransferApprovalSQL struct {
+	db SQL
+}
+
+func NewTransferApprovalSQL(db SQL) TransferApprovalSQL {
+	return TransferApprovalSQL{
+		db: db,
+	}
+}
+
+func (t TransferApprovalSQL) Create(ctx context.Context, approval domain.TransferApproval) (domain.TransferApproval, error) {
+	var query = `
+		INSERT INTO
+			transfer_approvals (id, transfer_id, action, actor, role, reason, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	var exec = t.db.ExecuteContext
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		exec = tx.ExecuteContext
+	}
+
+	if err := exec(
+		ctx,
+		query,
+		approval.ID(),
+		approval.TransferID(),
+		approval.Action(),
+		approval.Actor(),
+		approval.Role(),
+		approval.Reason(),
+		approval.CreatedAt(),
+	); err != nil {
+		return domain.TransferApproval{}, errors.Wrap(err, "error creating transfer approval")
+	}
+
+	return approval, nil
+}
+
+func (t TransferApprovalSQL) FindAllByTransfer(ctx context.Context, ID domain.TransferID) ([]domain.TransferApproval, error) {
+	var query = `
+		SELECT id, transfer_id, action, actor, role, reason, created_at
+		FROM transfer_approvals
+		WHERE transfer_id = $1
+		ORDER BY created_at
+	`
+
+	var (
+		rows Rows
+		err  error
+	)
+
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		rows, err = tx.QueryContext(ctx, query, ID)
+	} else {
+		rows, err = t.db.QueryContext(ctx, query, ID)
+	}
+	if err != nil {
+		return []domain.TransferApproval{}, errors.Wrap(err, "error listing transfer approvals")
+	}
+	defer rows.Close()
+
+	var approvals = make([]domain.TransferApproval, 0)
+	for rows.Next() {
+		var (
+			id         string
+			transferID string
+			action     string
+			actor      string
+			role       string
+			reason     string
+			createdAt  time.Time
+		)
+
+		if err = rows.Scan(&id, &transferID, &action, &actor, &role, &reason, &createdAt); err != nil {
+			return []domain.TransferApproval{}, errors.Wrap(err, "error listing transfer approvals")
+		}
+
+		approvals = append(approvals, domain.NewTransferApproval(
+			domain.TransferApprovalID(id),
+			domain.TransferID(transferID),
+			domain.ApprovalAction(action),
+			actor,
+			domain.Role(role),
+			reason,
+			createdAt,
+		))
+	}
+
+	if err = rows.Err(); err != nil {
+		return []domain.TransferApproval{}, err
+	}
+
+	return approvals, nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type TransferImportMemory struct {
+	memory *Memory
+}
+
+func NewTransferImportMemory(memory *Memory) TransferImportMemory {
+	return TransferImportMemory{
+		memory: memory,
+	}
+}
+
+// Create stores the job and its lines together. The content hash is unique to the requester, kept as a key of its own,
+// so of two files of theirs with the same content only the first is stored
+func (t TransferImportMemory) Create(ctx context.Context, job domain.TransferImport) error {
+	err := t.memory.WithTransaction(ctx, func(ctx context.Context) error {
+		var key = job.RequestedBy().Subject() + ":" + job.ContentHash()
+		if err := t.memory.insert(ctx, "transfer_import_hashes", key, job.ID()); err != nil {
+			if err == errMemoryDuplicateKey {
+				return domain.ErrDuplicateTransferImport
+			}
+
+			return err
+		}
+
+		var lines = append([]domain.TransferImportLine{}, job.Lines()...)
+		sort.SliceStable(lines, func(i, j int) bool {
+			return lines[i].Number() < lines[j].Number()
+		})
+
+		return t.memory.insert(ctx, "transfer_imports", job.ID().String(), domain.NewTransferImport(
+			job.ID(),
+			job.FileName(),
+			job.Format(),
+			job.ContentHash(),
+			job.Status(),
+			job.RequestedBy(),
+			lines,
+			job.LastError(),
+			job.CreatedAt(),
+			job.StartedAt(),
+			job.FinishedAt(),
+		))
+	})
+	switch {
+	case err == domain.ErrDuplicateTransferImport:
+		return err
+	case err != nil:
+		return errors.Wrap(err, "error creating transfer import")
+	default:
+		return nil
+	}
+}
+
+// Update writes the status of the job and its last error, not its lines
+func (t TransferImportMemory) Update(ctx context.Context, job domain.TransferImport) error {
+	err := t.memory.change(ctx, "transfer_imports", job.ID().String(), func(value interface{}) interface{} {
+		var stored = value.(domain.TransferImport)
+		return domain.NewTransferImport(
+			stored.ID(),
+			stored.FileName(),
+			stored.Format(),
+			stored.ContentHash(),
+			job.Status(),
+			stored.RequestedBy(),
+			stored.Lines(),
+			job.LastError(),
+			stored.CreatedAt(),
+			job.StartedAt(),
+			job.FinishedAt(),
+		)
+	})
+	if err != nil {
+		return errors.Wrap(err, "error updating transfer import")
+	}
+
+	return nil
+}
+
+func (t TransferImportMemory) UpdateLine(ctx context.Context, ID domain.TransferImportID, line domain.TransferImportLine) error {
+	err := t.memory.change(ctx, "transfer_imports", ID.String(), func(value interface{}) interface{} {
+		return value.(domain.TransferImport).WithLine(line)
+	})
+	if err != nil {
+		return errors.Wrap(err, "error updating transfer import line")
+	}
+
+	return nil
+}
+
+// ClaimLine changes the line only where the stored one is still pending, the job being locked while it is read
+func (t TransferImportMemory) ClaimLine(
+	ctx context.Context,
+	ID domain.TransferImportID,
+	line domain.TransferImportLine,
+) (bool, error) {
+	var claimed bool
+
+	err := t.memory.write(ctx, "transfer_imports", ID.String(), func(value interface{}, ok bool) (interface{}, bool) {
+		if !ok {
+			return nil, false
+		}
+
+		var stored = value.(domain.TransferImport)
+		for _, l := range stored.Lines() {
+			if l.Number() == line.Number() && l.Status() == domain.TransferImportLinePending {
+				claimed = true
+				return stored.WithLine(line), true
+			}
+		}
+
+		return nil, false
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "error claiming transfer import line")
+	}
+
+	return claimed, nil
+}
+
+func (t TransferImportMemory) FindByID(ctx context.Context, ID domain.TransferImportID) (domain.TransferImport, error) {
+	value, ok := t.memory.find(ctx, "transfer_imports", ID.String())
+	if !ok {
+		return domain.TransferImport{}, domain.ErrTransferImportNotFound
+	}
+
+	return value.(domain.TransferImport), nil
+}
+
+// ClaimDue leases the jobs whose lease expired, as the SQL one. The lease of each job is locked and read again before
+// it is taken, as another import job may have taken it meanwhile
+func (t TransferImportMemory) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	until time.Time,
+	limit int,
+) ([]domain.TransferImport, error) {
+	var jobs = make([]domain.TransferImport, 0)
+
+	err := t.memory.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, job := range t.findAll(ctx) {
+			if len(jobs) == limit {
+				break
+			}
+
+			if job.Status() == domain.TransferImportCompleted {
+				continue
+			}
+
+			err := t.memory.write(ctx, "transfer_import_leases", job.ID().String(), func(value interface{}, ok bool) (interface{}, bool) {
+				if ok && value.(time.Time).After(now) {
+					return nil, false
+				}
+
+				stored, err := t.FindByID(ctx, job.ID())
+				if err != nil || stored.Status() == domain.TransferImportCompleted {
+					return nil, false
+				}
+
+				jobs = append(jobs, stored)
+				return until, true
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return []domain.TransferImport{}, errors.Wrap(err, "error claiming transfer imports")
+	}
+
+	return jobs, nil
+}
+
+// findAll returns the jobs, oldest first
+func (t TransferImportMemory) findAll(ctx context.Context) []domain.TransferImport {
+	var jobs = make([]domain.TransferImport, 0)
+	for _, value := range t.memory.list(ctx, "transfer_imports") {
+		jobs = append(jobs, value.(domain.TransferImport))
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt().Before(jobs[j].CreatedAt())
+	})
+
+	return jobs
+}
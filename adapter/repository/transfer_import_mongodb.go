@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type transferImportBSON struct {
+	ID              string     `bson:"id"`
+	FileName        string     `bson:"file_name"`
+	Format          string     `bson:"format"`
+	ContentHash     string     `bson:"content_hash"`
+	Status          string     `bson:"status"`
+	RequestedBy     string     `bson:"requested_by"`
+	RequestedRole   string     `bson:"requested_role"`
+	RequestedScopes []string   `bson:"requested_scopes"`
+	CreatedAt       time.Time  `bson:"created_at"`
+	StartedAt       *time.Time `bson:"started_at"`
+	FinishedAt      *time.Time `bson:"finished_at"`
+	LastError       string     `bson:"last_error"`
+	LeasedUntil     *time.Time `bson:"leased_until"`
+}
+
+type transferImportLineBSON struct {
+	ImportID             string   `bson:"import_id"`
+	Line                 int      `bson:"line"`
+	AccountOriginID      string   `bson:"account_origin_id"`
+	AccountDestinationID string   `bson:"account_destination_id"`
+	Amount               int64    `bson:"amount"`
+	Status               string   `bson:"status"`
+	Errors               []string `bson:"errors"`
+	TransferID           string   `bson:"transfer_id"`
+	TransferStatus       string   `bson:"transfer_status"`
+}
+
+func (t transferImportBSON) toDomain(lines []domain.TransferImportLine) domain.TransferImport {
+	var scopes = make([]domain.Scope, 0, len(t.RequestedScopes))
+	for _, s := range t.RequestedScopes {
+		scopes = append(scopes, domain.Scope(s))
+	}
+
+	return domain.NewTransferImport(
+		domain.TransferImportID(t.ID),
+		t.FileName,
+		domain.TransferImportFormat(t.Format),
+		t.ContentHash,
+		domain.TransferImportStatus(t.Status),
+		domain.NewPrincipal(t.RequestedBy, domain.Role(t.RequestedRole), scopes...),
+		lines,
+		t.LastError,
+		t.CreatedAt,
+		timeFromBSON(t.StartedAt),
+		timeFromBSON(t.FinishedAt),
+	)
+}
+
+func (t transferImportLineBSON) toDomain() domain.TransferImportLine {
+	return domain.NewTransferImportLine(
+		t.Line,
+		domain.AccountID(t.AccountOriginID),
+		domain.AccountID(t.AccountDestinationID),
+		domain.Money(t.Amount),
+		domain.TransferImportLineStatus(t.Status),
+		t.Errors,
+		domain.TransferID(t.TransferID),
+		domain.TransferStatus(t.TransferStatus),
+	)
+}
+
+func newTransferImportLineBSON(ID domain.TransferImportID, line domain.TransferImportLine) transferImportLineBSON {
+	return transferImportLineBSON{
+		ImportID:             ID.String(),
+		Line:                 line.Number(),
+		AccountOriginID:      line.AccountOriginID().String(),
+		AccountDestinationID: line.AccountDestinationID().String(),
+		Amount:               line.Amount().Int64(),
+		Status:               line.Status().String(),
+		Errors:               line.Errors(),
+		TransferID:           line.TransferID().String(),
+		TransferStatus:       line.TransferStatus().String(),
+	}
+}
+
+// TransferImportNoSQL keeps the lines apart from their job, so large files stay below the size of a document
+type TransferImportNoSQL struct {
+	collectionName     string
+	lineCollectionName string
+	db                 NoSQL
+}
+
+func NewTransferImportNoSQL(db NoSQL) TransferImportNoSQL {
+	return TransferImportNoSQL{
+		db:                 db,
+		collectionName:     "transfer_imports",
+		lineCollectionName: "transfer_import_lines",
+	}
+}
+
+// Create stores the job and its lines in one transaction. The requester and the content hash have a unique index
+func (t TransferImportNoSQL) Create(ctx context.Context, job domain.TransferImport) error {
+	session, err := t.db.StartSession()
+	if err != nil {
+		return errors.Wrap(err, "error creating transfer import")
+	}
+	defer session.EndSession(ctx)
+
+	var scopes = make([]string, 0, len(job.RequestedBy().Scopes()))
+	for _, s := range job.RequestedBy().Scopes() {
+		scopes = append(scopes, s.String())
+	}
+
+	err = session.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := t.db.Store(ctx, t.collectionName, transferImportBSON{
+			ID:              job.ID().String(),
+			FileName:        job.FileName(),
+			Format:          job.Format().String(),
+			ContentHash:     job.ContentHash(),
+			Status:          job.Status().String(),
+			RequestedBy:     job.RequestedBy().Subject(),
+			RequestedRole:   job.RequestedBy().Role().String(),
+			RequestedScopes: scopes,
+			CreatedAt:       job.CreatedAt(),
+			StartedAt:       optionalTimeBSON(job.StartedAt()),
+			FinishedAt:      optionalTimeBSON(job.FinishedAt()),
+			LastError:       job.LastError(),
+		}); err != nil {
+			return err
+		}
+
+		for _, line := range job.Lines() {
+			if err := t.db.Store(ctx, t.lineCollectionName, newTransferImportLineBSON(job.ID(), line)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	switch {
+	case mongo.IsDuplicateKeyError(err):
+		return domain.ErrDuplicateTransferImport
+	case err != nil:
+		return errors.Wrap(err, "error creating transfer import")
+	}
+
+	return nil
+}
+
+func (t TransferImportNoSQL) Update(ctx context.Context, job domain.TransferImport) error {
+	var (
+		query  = bson.M{"id": job.ID()}
+		update = bson.M{"$set": bson.M{
+			"status":      job.Status().String(),
+			"started_at":  optionalTimeBSON(job.StartedAt()),
+			"finished_at": optionalTimeBSON(job.FinishedAt()),
+			"last_error":  job.LastError(),
+		}}
+	)
+
+	if err := t.db.Update(ctx, t.collectionName, query, update); err != nil {
+		return errors.Wrap(err, "error updating transfer import")
+	}
+
+	return nil
+}
+
+func (t TransferImportNoSQL) UpdateLine(ctx context.Context, ID domain.TransferImportID, line domain.TransferImportLine) error {
+	var (
+		query  = bson.M{"import_id": ID, "line": line.Number()}
+		update = bson.M{"$set": bson.M{
+			"status":          line.Status().String(),
+			"errors":          line.Errors(),
+			"transfer_id":     line.TransferID().String(),
+			"transfer_status": line.TransferStatus().String(),
+		}}
+	)
+
+	if err := t.db.Update(ctx, t.lineCollectionName, query, update); err != nil {
+		return errors.Wrap(err, "error updating transfer import line")
+	}
+
+	return nil
+}
+
+// ClaimLine updates the line only where it is still pending, so of two runs claiming it only one matches
+func (t TransferImportNoSQL) ClaimLine(
+	ctx context.Context,
+	ID domain.TransferImportID,
+	line domain.TransferImportLine,
+) (bool, error) {
+	var (
+		query  = bson.M{"import_id": ID, "line": line.Number(), "status": domain.TransferImportLinePending}
+		update = bson.M{"$set": bson.M{
+			"status":          line.Status().String(),
+			"errors":          line.Errors(),
+			"transfer_id":     line.TransferID().String(),
+			"transfer_status": line.TransferStatus().String(),
+		}}
+	)
+
+	claimed, err := t.db.UpdateMatched(ctx, t.lineCollectionName, query, update)
+	if err != nil {
+		return false, errors.Wrap(err, "error claiming transfer import line")
+	}
+
+	return claimed, nil
+}
+
+func (t TransferImportNoSQL) FindByID(ctx context.Context, ID domain.TransferImportID) (domain.TransferImport, error) {
+	return t.findOne(ctx, bson.M{"id": ID})
+}
+
+// ClaimDue leases the jobs whose lease expired, as the SQL one. Each lease is taken by an update matching only a job
+// whose lease still expired, so two import jobs never take the same one
+func (t TransferImportNoSQL) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	until time.Time,
+	limit int,
+) ([]domain.TransferImport, error) {
+	var (
+		jobsBSON = make([]transferImportBSON, 0)
+		due      = bson.M{
+			"status": bson.M{"$ne": domain.TransferImportCompleted},
+			"$or":    bson.A{bson.M{"leased_until": nil}, bson.M{"leased_until": bson.M{"$lte": now}}},
+		}
+	)
+
+	if err := t.db.FindAll(ctx, t.collectionName, due, &jobsBSON); err != nil {
+		return []domain.TransferImport{}, errors.Wrap(err, "error claiming transfer imports")
+	}
+
+	sort.SliceStable(jobsBSON, func(i, j int) bool {
+		return jobsBSON[i].CreatedAt.Before(jobsBSON[j].CreatedAt)
+	})
+
+	var jobs = make([]domain.TransferImport, 0)
+	for _, jobBSON := range jobsBSON {
+		if len(jobs) == limit {
+			break
+		}
+
+		var query = bson.M{"id": jobBSON.ID}
+		for key, value := range due {
+			query[key] = value
+		}
+
+		claimed, err := t.db.UpdateMatched(ctx, t.collectionName, query, bson.M{"$set": bson.M{"leased_until": until}})
+		if err != nil {
+			return []domain.TransferImport{}, errors.Wrap(err, "error claiming transfer imports")
+		}
+
+		if !claimed {
+			continue
+		}
+
+		lines, err := t.findLines(ctx, domain.TransferImportID(jobBSON.ID))
+		if err != nil {
+			return []domain.TransferImport{}, errors.Wrap(err, "error claiming transfer imports")
+		}
+
+		jobs = append(jobs, jobBSON.toDomain(lines))
+	}
+
+	return jobs, nil
+}
+
+func (t TransferImportNoSQL) findOne(ctx context.Context, query bson.M) (domain.TransferImport, error) {
+	var jobBSON = &transferImportBSON{}
+
+	if err := t.db.FindOne(ctx, t.collectionName, query, nil, jobBSON); err != nil {
+		switch err {
+		case mongo.ErrNoDocuments:
+			return domain.TransferImport{}, domain.ErrTransferImportNotFound
+		default:
+			return domain.TransferImport{}, errors.Wrap(err, "error fetching transfer import")
+		}
+	}
+
+	lines, err := t.findLines(ctx, domain.TransferImportID(jobBSON.ID))
+	if err != nil {
+		return domain.TransferImport{}, errors.Wrap(err, "error fetching transfer import")
+	}
+
+	return jobBSON.toDomain(lines), nil
+}
+
+// findLines reads the lines of the job, in the order of the file
+func (t TransferImportNoSQL) findLines(ctx context.Context, ID domain.TransferImportID) ([]domain.TransferImportLine, error) {
+	var lines = make([]domain.TransferImportLine, 0)
+
+	err := t.db.Stream(ctx, t.lineCollectionName, bson.M{"import_id": ID}, bson.D{{Key: "line", Value: 1}}, func(decode func(interface{}) error) error {
+		var lineBSON transferImportLineBSON
+		if err := decode(&lineBSON); err != nil {
+			return err
+		}
+
+		lines = append(lines, lineBSON.toDomain())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,428 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+const transferImportColumns = "id, file_name, format, content_hash, status, requested_by, requested_role, requested_scopes, created_at, started_at, finished_at, last_error"
+
+// transferImportErrorsSeparator joins the problems of a line in a single column
+const transferImportErrorsSeparator = "\n"
+
+type TransferImportSQL struct {
+	db SQL
+}
+
+func NewTransferImportSQL(db SQL) TransferImportSQL {
+	return TransferImportSQL{
+		db: db,
+	}
+}
+
+// Create stores the job and its lines in one transaction. The content hash is unique to the requester, a second file
+// of theirs with the same content inserts nothing
+func (t TransferImportSQL) Create(ctx context.Context, job domain.TransferImport) error {
+	tx, err := t.db.BeginTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error creating transfer import")
+	}
+
+	if err = t.create(ctx, tx, job); err != nil {
+		_ = tx.Rollback()
+		if err == domain.ErrDuplicateTransferImport {
+			return err
+		}
+
+		return errors.Wrap(err, "error creating transfer import")
+	}
+
+	return tx.Commit()
+}
+
+func (t TransferImportSQL) create(ctx context.Context, tx Tx, job domain.TransferImport) error {
+	var query = `
+		INSERT INTO
+			transfer_imports (` + transferImportColumns + `)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	` + t.db.Dialect().OnConflictDoNothing("requested_by", "content_hash")
+
+	if err := tx.ExecuteContext(
+		ctx,
+		query,
+		job.ID(),
+		job.FileName(),
+		job.Format(),
+		job.ContentHash(),
+		job.Status(),
+		job.RequestedBy().Subject(),
+		job.RequestedBy().Role(),
+		domain.JoinScopes(job.RequestedBy().Scopes()),
+		job.CreatedAt(),
+		nullTime(job.StartedAt()),
+		nullTime(job.FinishedAt()),
+		job.LastError(),
+	); err != nil {
+		return err
+	}
+
+	// The insert is skipped when another job of the requester has the content, which is then the one found by its hash
+	var ID string
+	if err := tx.QueryRowContext(
+		ctx,
+		"SELECT id FROM transfer_imports WHERE requested_by = $1 AND content_hash = $2",
+		job.RequestedBy().Subject(),
+		job.ContentHash(),
+	).Scan(&ID); err != nil {
+		return err
+	}
+
+	if ID != string(job.ID()) {
+		return domain.ErrDuplicateTransferImport
+	}
+
+	for _, line := range job.Lines() {
+		if err := tx.ExecuteContext(
+			ctx,
+			`INSERT INTO transfer_import_lines
+				(import_id, line, account_origin_id, account_destination_id, amount, status, errors, transfer_id, transfer_status)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			job.ID(),
+			line.Number(),
+			line.AccountOriginID(),
+			line.AccountDestinationID(),
+			line.Amount(),
+			line.Status(),
+			strings.Join(line.Errors(), transferImportErrorsSeparator),
+			line.TransferID(),
+			line.TransferStatus(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t TransferImportSQL) Update(ctx context.Context, job domain.TransferImport) error {
+	var query = `
+		UPDATE transfer_imports
+		SET status = $1, started_at = $2, finished_at = $3, last_error = $4
+		WHERE id = $5
+	`
+
+	if err := t.db.ExecuteContext(
+		ctx,
+		query,
+		job.Status(),
+		nullTime(job.StartedAt()),
+		nullTime(job.FinishedAt()),
+		job.LastError(),
+		job.ID(),
+	); err != nil {
+		return errors.Wrap(err, "error updating transfer import")
+	}
+
+	return nil
+}
+
+func (t TransferImportSQL) UpdateLine(ctx context.Context, ID domain.TransferImportID, line domain.TransferImportLine) error {
+	var query = `
+		UPDATE transfer_import_lines
+		SET status = $1, errors = $2, transfer_id = $3, transfer_status = $4
+		WHERE import_id = $5 AND line = $6
+	`
+
+	if err := t.db.ExecuteContext(
+		ctx,
+		query,
+		line.Status(),
+		strings.Join(line.Errors(), transferImportErrorsSeparator),
+		line.TransferID(),
+		line.TransferStatus(),
+		ID,
+		line.Number(),
+	); err != nil {
+		return errors.Wrap(err, "error updating transfer import line")
+	}
+
+	return nil
+}
+
+// ClaimLine updates the line only where it is still pending, so of two runs claiming it only one changes the row
+func (t TransferImportSQL) ClaimLine(
+	ctx context.Context,
+	ID domain.TransferImportID,
+	line domain.TransferImportLine,
+) (bool, error) {
+	var query = `
+		UPDATE transfer_import_lines
+		SET status = $1, errors = $2, transfer_id = $3, transfer_status = $4
+		WHERE import_id = $5 AND line = $6 AND status = $7
+	`
+
+	affected, err := t.db.ExecuteAffectedContext(
+		ctx,
+		query,
+		line.Status(),
+		strings.Join(line.Errors(), transferImportErrorsSeparator),
+		line.TransferID(),
+		line.TransferStatus(),
+		ID,
+		line.Number(),
+		domain.TransferImportLinePending,
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "error claiming transfer import line")
+	}
+
+	return affected == 1, nil
+}
+
+func (t TransferImportSQL) FindByID(ctx context.Context, ID domain.TransferImportID) (domain.TransferImport, error) {
+	return t.findOne(ctx, "SELECT "+transferImportColumns+" FROM transfer_imports WHERE id = $1", ID)
+}
+
+// ClaimDue leases the jobs whose lease expired by moving it to the end of the new one, so no other import job takes
+// them meanwhile. The jobs are locked while they are leased, skipping the ones another import job is leasing at the
+// same time
+func (t TransferImportSQL) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	until time.Time,
+	limit int,
+) ([]domain.TransferImport, error) {
+	var query = `
+		SELECT ` + transferImportColumns + `
+		FROM transfer_imports
+		WHERE status <> $1 AND (leased_until IS NULL OR leased_until <= $2)
+		ORDER BY created_at
+		LIMIT $3
+	` + t.db.Dialect().ForUpdateSkipLocked()
+
+	var jobs = make([]domain.TransferImport, 0)
+
+	err := t.withTransaction(ctx, func(tx Tx) error {
+		rows, err := tx.QueryContext(ctx, query, domain.TransferImportCompleted, now, limit)
+		if err != nil {
+			return err
+		}
+
+		jobs, err = scanTransferImports(rows)
+		if err != nil {
+			return err
+		}
+
+		for _, job := range jobs {
+			if err = tx.ExecuteContext(
+				ctx,
+				`UPDATE transfer_imports SET leased_until = $1 WHERE id = $2`,
+				until,
+				job.ID(),
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return []domain.TransferImport{}, errors.Wrap(err, "error claiming transfer imports")
+	}
+
+	for i, job := range jobs {
+		if jobs[i], err = t.withLines(ctx, job); err != nil {
+			return []domain.TransferImport{}, errors.Wrap(err, "error claiming transfer imports")
+		}
+	}
+
+	return jobs, nil
+}
+
+func (t TransferImportSQL) withTransaction(ctx context.Context, fn func(Tx) error) error {
+	tx, err := t.db.BeginTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error begin tx")
+	}
+
+	if err = fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Wrap(err, "rollback error")
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (t TransferImportSQL) findOne(ctx context.Context, query string, args ...interface{}) (domain.TransferImport, error) {
+	job, err := scanTransferImport(t.db.QueryRowContext(ctx, query, args...))
+	switch {
+	case err == sql.ErrNoRows:
+		return domain.TransferImport{}, domain.ErrTransferImportNotFound
+	case err != nil:
+		return domain.TransferImport{}, errors.Wrap(err, "error fetching transfer import")
+	}
+
+	if job, err = t.withLines(ctx, job); err != nil {
+		return domain.TransferImport{}, errors.Wrap(err, "error fetching transfer import")
+	}
+
+	return job, nil
+}
+
+// withLines reads the lines of the job, in the order of the file
+func (t TransferImportSQL) withLines(ctx context.Context, job domain.TransferImport) (domain.TransferImport, error) {
+	var query = `
+		SELECT line, account_origin_id, account_destination_id, amount, status, errors, transfer_id, transfer_status
+		FROM transfer_import_lines
+		WHERE import_id = $1
+		ORDER BY line
+	`
+
+	rows, err := t.db.QueryContext(ctx, query, job.ID())
+	if err != nil {
+		return job, err
+	}
+	defer rows.Close()
+
+	var lines = make([]domain.TransferImportLine, 0)
+	for rows.Next() {
+		var (
+			number               int
+			accountOriginID      string
+			accountDestinationID string
+			amount               int64
+			status               string
+			errs                 string
+			transferID           string
+			transferStatus       string
+		)
+
+		if err = rows.Scan(
+			&number,
+			&accountOriginID,
+			&accountDestinationID,
+			&amount,
+			&status,
+			&errs,
+			&transferID,
+			&transferStatus,
+		); err != nil {
+			return job, err
+		}
+
+		lines = append(lines, domain.NewTransferImportLine(
+			number,
+			domain.AccountID(accountOriginID),
+			domain.AccountID(accountDestinationID),
+			domain.Money(amount),
+			domain.TransferImportLineStatus(status),
+			splitTransferImportErrors(errs),
+			domain.TransferID(transferID),
+			domain.TransferStatus(transferStatus),
+		))
+	}
+
+	if err = rows.Err(); err != nil {
+		return job, err
+	}
+
+	return domain.NewTransferImport(
+		job.ID(),
+		job.FileName(),
+		job.Format(),
+		job.ContentHash(),
+		job.Status(),
+		job.RequestedBy(),
+		lines,
+		job.LastError(),
+		job.CreatedAt(),
+		job.StartedAt(),
+		job.FinishedAt(),
+	), nil
+}
+
+// scanTransferImports reads the jobs of the rows without their lines, closing them
+func scanTransferImports(rows Rows) ([]domain.TransferImport, error) {
+	defer rows.Close()
+
+	var jobs = make([]domain.TransferImport, 0)
+	for rows.Next() {
+		job, err := scanTransferImport(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// scanTransferImport reads a job without its lines
+func scanTransferImport(row Row) (domain.TransferImport, error) {
+	var (
+		ID              string
+		fileName        string
+		format          string
+		contentHash     string
+		status          string
+		requestedBy     string
+		requestedRole   string
+		requestedScopes string
+		createdAt       time.Time
+		startedAt       sql.NullTime
+		finishedAt      sql.NullTime
+		lastError       string
+	)
+
+	if err := row.Scan(
+		&ID,
+		&fileName,
+		&format,
+		&contentHash,
+		&status,
+		&requestedBy,
+		&requestedRole,
+		&requestedScopes,
+		&createdAt,
+		&startedAt,
+		&finishedAt,
+		&lastError,
+	); err != nil {
+		return domain.TransferImport{}, err
+	}
+
+	return domain.NewTransferImport(
+		domain.TransferImportID(ID),
+		fileName,
+		domain.TransferImportFormat(format),
+		contentHash,
+		domain.TransferImportStatus(status),
+		domain.NewPrincipal(requestedBy, domain.Role(requestedRole), domain.ParseScopes(requestedScopes)...),
+		nil,
+		lastError,
+		createdAt,
+		startedAt.Time,
+		finishedAt.Time,
+	), nil
+}
+
+func splitTransferImportErrors(errs string) []string {
+	if errs == "" {
+		return nil
+	}
+
+	return strings.Split(errs, transferImportErrorsSeparator)
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type TransferMemory struct {
+	memory *Memory
+}
+
+func NewTransferMemory(memory *Memory) TransferMemory {
+	return TransferMemory{
+		memory: memory,
+	}
+}
+
+func (t TransferMemory) Create(ctx context.Context, transfer domain.Transfer) (domain.Transfer, error) {
+	err := t.memory.WithTransaction(ctx, func(ctx context.Context) error {
+		tx, _ := memoryTxFrom(ctx)
+
+		if err := t.memory.lock(ctx, tx, "transfers:"+transfer.ID().String()); err != nil {
+			return err
+		}
+
+		tx.transfers[transfer.ID()] = transfer
+		return nil
+	})
+	if err != nil {
+		return domain.Transfer{}, errors.Wrap(err, "error creating transfer")
+	}
+
+	return transfer, nil
+}
+
+// FindAll sorts the matching transfers in the order of the page, then keeps the ones after its cursor
+func (t TransferMemory) FindAll(
+	ctx context.Context,
+	filter domain.TransferFilter,
+	page domain.PageRequest,
+) ([]domain.Transfer, error) {
+	var matching = make([]domain.Transfer, 0)
+
+	err := t.Stream(ctx, filter, func(transfer domain.Transfer) error {
+		matching = append(matching, transfer)
+		return nil
+	})
+	if err != nil {
+		return []domain.Transfer{}, errors.Wrap(err, "error listing transfers")
+	}
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		return page.Sort.Less(domain.NewTransferCursor(page.Sort, matching[i]), domain.NewTransferCursor(page.Sort, matching[j]))
+	})
+
+	var transfers = make([]domain.Transfer, 0)
+	for _, transfer := range matching {
+		if len(transfers) == page.Limit {
+			break
+		}
+
+		if page.After.Follows(domain.NewTransferCursor(page.Sort, transfer)) {
+			transfers = append(transfers, transfer)
+		}
+	}
+
+	return transfers, nil
+}
+
+// Stream hands over a copy of the listing taken when called, so the function may use the repository
+func (t TransferMemory) Stream(ctx context.Context, filter domain.TransferFilter, fn func(domain.Transfer) error) error {
+	var tx, _ = memoryTxFrom(ctx)
+
+	t.memory.mu.RLock()
+	var transfers = t.memory.allTransfers(tx)
+	t.memory.mu.RUnlock()
+
+	for _, transfer := range transfers {
+		if !transferMatches(transfer, filter) {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := fn(transfer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t TransferMemory) Summarize(ctx context.Context, filter domain.TransferFilter) (domain.TransferSummary, error) {
+	var tx, _ = memoryTxFrom(ctx)
+
+	t.memory.mu.RLock()
+	defer t.memory.mu.RUnlock()
+
+	var summary domain.TransferSummary
+	for _, transfer := range t.memory.allTransfers(tx) {
+		if !transferMatches(transfer, filter) {
+			continue
+		}
+
+		total, err := summary.Total.Add(transfer.Amount())
+		if err != nil {
+			return domain.TransferSummary{}, errors.Wrap(err, "error summarizing transfers")
+		}
+
+		summary.Count++
+		summary.Total = total
+	}
+
+	return summary, nil
+}
+
+// transferMatches applies the conditions of the filter, as transferWhere does
+func transferMatches(transfer domain.Transfer, filter domain.TransferFilter) bool {
+	switch {
+	case filter.AccountID != "" &&
+		transfer.AccountOriginID() != filter.AccountID &&
+		transfer.AccountDestinationID() != filter.AccountID:
+		return false
+	case filter.AccountOriginID != "" && transfer.AccountOriginID() != filter.AccountOriginID:
+		return false
+	case filter.AccountDestinationID != "" && transfer.AccountDestinationID() != filter.AccountDestinationID:
+		return false
+	case filter.Status != "" && transfer.Status() != filter.Status:
+		return false
+	case !filter.From.IsZero() && transfer.CreatedAt().Before(filter.From):
+		return false
+	case !filter.To.IsZero() && !transfer.CreatedAt().Before(filter.To):
+		return false
+	case filter.MinAmount != 0 && transfer.Amount() < filter.MinAmount:
+		return false
+	case filter.MaxAmount != 0 && transfer.Amount() > filter.MaxAmount:
+		return false
+	default:
+		return true
+	}
+}
+
+// FindByID locks the transfer when called inside a transaction, so concurrent decisions on it are serialized
+func (t TransferMemory) FindByID(ctx context.Context, ID domain.TransferID) (domain.Transfer, error) {
+	var tx, ok = memoryTxFrom(ctx)
+	if ok {
+		if err := t.memory.lock(ctx, tx, "transfers:"+ID.String()); err != nil {
+			return domain.Transfer{}, errors.Wrap(err, "error fetching transfer")
+		}
+	}
+
+	t.memory.mu.RLock()
+	defer t.memory.mu.RUnlock()
+
+	transfer, found := t.memory.transfer(tx, ID)
+	if !found {
+		return domain.Transfer{}, domain.ErrTransferNotFound
+	}
+
+	return transfer, nil
+}
+
+func (t TransferMemory) UpdateStatus(ctx context.Context, ID domain.TransferID, status domain.TransferStatus) error {
+	err := t.memory.WithTransaction(ctx, func(ctx context.Context) error {
+		tx, _ := memoryTxFrom(ctx)
+
+		if err := t.memory.lock(ctx, tx, "transfers:"+ID.String()); err != nil {
+			return err
+		}
+
+		t.memory.mu.RLock()
+		transfer, ok := t.memory.transfer(tx, ID)
+		t.memory.mu.RUnlock()
+
+		// An update matching no transfer changes nothing, as in the SQL repository
+		if !ok {
+			return nil
+		}
+
+		tx.transfers[ID] = restoreTransfer(
+			transfer.ID().String(),
+			transfer.AccountOriginID().String(),
+			transfer.AccountDestinationID().String(),
+			transfer.Amount().Int64(),
+			status.String(),
+			transfer.FailureCode().String(),
+			transfer.CreatedAt(),
+		)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "error updating transfer status")
+	}
+
+	return nil
+}
+
+func (t TransferMemory) WithTransaction(ctx context.Context, fn func(ctxTx context.Context) error) error {
+	return t.memory.WithTransaction(ctx, fn)
+}
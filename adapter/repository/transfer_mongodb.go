@@ -8,6 +8,7 @@ import (
 
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type transferBSON struct {
@@ -15,9 +16,15 @@ type transferBSON struct {
 	AccountOriginID      string    `bson:"account_origin_id"`
 	AccountDestinationID string    `bson:"account_destination_id"`
 	Amount               int64     `bson:"amount"`
+	Status               string    `bson:"status"`
+	FailureCode          string    `bson:"failure_code"`
 	CreatedAt            time.Time `bson:"created_at"`
 }
 
+func (t transferBSON) toDomain() domain.Transfer {
+	return restoreTransfer(t.ID, t.AccountOriginID, t.AccountDestinationID, t.Amount, t.Status, t.FailureCode, t.CreatedAt)
+}
+
 type TransferNoSQL struct {
 	collectionName string
 	db             NoSQL
@@ -36,6 +43,8 @@ func (t TransferNoSQL) Create(ctx context.Context, transfer domain.Transfer) (do
 		AccountOriginID:      transfer.AccountOriginID().String(),
 		AccountDestinationID: transfer.AccountDestinationID().String(),
 		Amount:               transfer.Amount().Int64(),
+		Status:               transfer.Status().String(),
+		FailureCode:          transfer.FailureCode().String(),
 		CreatedAt:            transfer.CreatedAt(),
 	}
 
@@ -46,30 +55,164 @@ func (t TransferNoSQL) Create(ctx context.Context, transfer domain.Transfer) (do
 	return transfer, nil
 }
 
-func (t TransferNoSQL) FindAll(ctx context.Context) ([]domain.Transfer, error) {
+func (t TransferNoSQL) FindAll(
+	ctx context.Context,
+	filter domain.TransferFilter,
+	page domain.PageRequest,
+) ([]domain.Transfer, error) {
 	var transfersBSON = make([]transferBSON, 0)
 
-	if err := t.db.FindAll(ctx, t.collectionName, bson.M{}, &transfersBSON); err != nil {
+	if err := t.db.FindPage(
+		ctx,
+		t.collectionName,
+		transferQuery(filter, page.After),
+		keysetSort(page.Sort),
+		int64(page.Limit),
+		&transfersBSON,
+	); err != nil {
 		return []domain.Transfer{}, errors.Wrap(err, "error listing transfers")
 	}
 
-	var transfers = make([]domain.Transfer, 0)
-
+	var transfers = make([]domain.Transfer, 0, len(transfersBSON))
 	for _, transferBSON := range transfersBSON {
-		var transfer = domain.NewTransfer(
-			domain.TransferID(transferBSON.ID),
-			domain.AccountID(transferBSON.AccountOriginID),
-			domain.AccountID(transferBSON.AccountDestinationID),
-			domain.Money(transferBSON.Amount),
-			transferBSON.CreatedAt,
-		)
-
-		transfers = append(transfers, transfer)
+		transfers = append(transfers, transferBSON.toDomain())
 	}
 
 	return transfers, nil
 }
 
+// Stream decodes the documents as they are handed over, never holding the whole listing
+func (t TransferNoSQL) Stream(ctx context.Context, filter domain.TransferFilter, fn func(domain.Transfer) error) error {
+	if err := t.db.Stream(ctx, t.collectionName, transferQuery(filter, domain.Cursor{}), keysetSort(domain.Sort{}), func(decode func(interface{}) error) error {
+		var transferBSON transferBSON
+		if err := decode(&transferBSON); err != nil {
+			return errors.Wrap(err, "error streaming transfers")
+		}
+
+		return fn(transferBSON.toDomain())
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Summarize groups the documents in the database, decoding a single one whatever the number of transfers
+func (t TransferNoSQL) Summarize(ctx context.Context, filter domain.TransferFilter) (domain.TransferSummary, error) {
+	var (
+		pipeline = bson.A{
+			bson.M{"$match": transferQuery(filter, domain.Cursor{})},
+			bson.M{"$group": bson.M{"_id": nil, "count": bson.M{"$sum": 1}, "total": bson.M{"$sum": "$amount"}}},
+		}
+		result = make([]struct {
+			Count int64 `bson:"count"`
+			Total int64 `bson:"total"`
+		}, 0)
+	)
+
+	if err := t.db.Aggregate(ctx, t.collectionName, pipeline, &result); err != nil {
+		return domain.TransferSummary{}, errors.Wrap(err, "error summarizing transfers")
+	}
+
+	if len(result) == 0 {
+		return domain.TransferSummary{}, nil
+	}
+
+	return domain.TransferSummary{Count: result[0].Count, Total: domain.Money(result[0].Total)}, nil
+}
+
+// transferQuery builds the query of the filter, and of the documents after the cursor unless it is zero
+func transferQuery(filter domain.TransferFilter, after domain.Cursor) bson.M {
+	var query = bson.M{}
+
+	if filter.AccountID != "" {
+		query["$or"] = []bson.M{
+			{"account_origin_id": filter.AccountID},
+			{"account_destination_id": filter.AccountID},
+		}
+	}
+
+	if filter.AccountOriginID != "" {
+		query["account_origin_id"] = filter.AccountOriginID
+	}
+
+	if filter.AccountDestinationID != "" {
+		query["account_destination_id"] = filter.AccountDestinationID
+	}
+
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+
+	var createdAt = bson.M{}
+	if !filter.From.IsZero() {
+		createdAt["$gte"] = filter.From
+	}
+
+	if !filter.To.IsZero() {
+		createdAt["$lt"] = filter.To
+	}
+
+	if len(createdAt) > 0 {
+		query["created_at"] = createdAt
+	}
+
+	var amount = bson.M{}
+	if filter.MinAmount != 0 {
+		amount["$gte"] = filter.MinAmount
+	}
+
+	if filter.MaxAmount != 0 {
+		amount["$lte"] = filter.MaxAmount
+	}
+
+	if len(amount) > 0 {
+		query["amount"] = amount
+	}
+
+	if !after.IsZero() {
+		return bson.M{"$and": bson.A{query, keysetQuery(after)}}
+	}
+
+	return query
+}
+
+func (t TransferNoSQL) FindByID(ctx context.Context, ID domain.TransferID) (domain.Transfer, error) {
+	var (
+		transferBSON = &transferBSON{}
+		query        = bson.M{"id": ID}
+	)
+
+	if err := t.db.FindOne(ctx, t.collectionName, query, nil, transferBSON); err != nil {
+		switch err {
+		case mongo.ErrNoDocuments:
+			return domain.Transfer{}, domain.ErrTransferNotFound
+		default:
+			return domain.Transfer{}, errors.Wrap(err, "error fetching transfer")
+		}
+	}
+
+	return transferBSON.toDomain(), nil
+}
+
+func (t TransferNoSQL) UpdateStatus(ctx context.Context, ID domain.TransferID, status domain.TransferStatus) error {
+	var (
+		query  = bson.M{"id": ID}
+		update = bson.M{"$set": bson.M{"status": status}}
+	)
+
+	if err := t.db.Update(ctx, t.collectionName, query, update); err != nil {
+		switch err {
+		case mongo.ErrNilDocument:
+			return errors.Wrap(domain.ErrTransferNotFound, "error updating transfer status")
+		default:
+			return errors.Wrap(err, "error updating transfer status")
+		}
+	}
+
+	return nil
+}
+
 func (t TransferNoSQL) WithTransaction(ctx context.Context, fn func(ctxTx context.Context) error) error {
 	session, err := t.db.StartSession()
 	if err != nil {
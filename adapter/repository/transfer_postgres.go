@@ -2,12 +2,17 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gsabadini/go-clean-architecture/domain"
 	"github.com/pkg/errors"
 )
 
+const transferColumns = "id, account_origin_id, account_destination_id, amount, status, failure_code, created_at"
+
 type TransferSQL struct {
 	db SQL
 }
@@ -19,29 +24,27 @@ func NewTransferSQL(db SQL) TransferSQL {
 }
 
 func (t TransferSQL) Create(ctx context.Context, transfer domain.Transfer) (domain.Transfer, error) {
-	tx, ok := ctx.Value("TransactionContextKey").(Tx)
-	if !ok {
-		var err error
-		tx, err = t.db.BeginTx(ctx)
-		if err != nil {
-			return domain.Transfer{}, errors.Wrap(err, "error creating transfer")
-		}
-	}
-
 	var query = `
 		INSERT INTO 
-			transfers (id, account_origin_id, account_destination_id, amount, created_at)
+			transfers (id, account_origin_id, account_destination_id, amount, status, failure_code, created_at)
 		VALUES 
-			($1, $2, $3, $4, $5)
+			($1, $2, $3, $4, $5, $6, $7)
 	`
 
-	if err := tx.ExecuteContext(
+	var exec = t.db.ExecuteContext
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		exec = tx.ExecuteContext
+	}
+
+	if err := exec(
 		ctx,
 		query,
 		transfer.ID(),
 		transfer.AccountOriginID(),
 		transfer.AccountDestinationID(),
 		transfer.Amount(),
+		transfer.Status(),
+		transfer.FailureCode(),
 		transfer.CreatedAt(),
 	); err != nil {
 		return domain.Transfer{}, errors.Wrap(err, "error creating transfer")
@@ -50,43 +53,244 @@ func (t TransferSQL) Create(ctx context.Context, transfer domain.Transfer) (doma
 	return transfer, nil
 }
 
-func (t TransferSQL) FindAll(ctx context.Context) ([]domain.Transfer, error) {
-	var query = "SELECT * FROM transfers"
+// FindAll seeks the page from its cursor, along the order of its sort and id, instead of skipping the rows before it
+func (t TransferSQL) FindAll(
+	ctx context.Context,
+	filter domain.TransferFilter,
+	page domain.PageRequest,
+) ([]domain.Transfer, error) {
+	var where, args = transferWhere(filter, page.After)
 
-	rows, err := t.db.QueryContext(ctx, query)
+	args = append(args, page.Limit)
+	rows, err := t.db.QueryContext(
+		ctx,
+		"SELECT "+transferColumns+" FROM transfers"+where+keysetOrder(page.Sort)+fmt.Sprintf(" LIMIT $%d", len(args)),
+		args...,
+	)
 	if err != nil {
 		return []domain.Transfer{}, errors.Wrap(err, "error listing transfers")
 	}
 
-	var transfers = make([]domain.Transfer, 0)
+	return t.scanTransfers(rows)
+}
+
+// Stream reads the rows as they are handed over, never holding the whole listing
+func (t TransferSQL) Stream(ctx context.Context, filter domain.TransferFilter, fn func(domain.Transfer) error) error {
+	var where, args = transferWhere(filter, domain.Cursor{})
+
+	rows, err := t.db.QueryContext(ctx, "SELECT "+transferColumns+" FROM transfers"+where+" ORDER BY created_at, id", args...)
+	if err != nil {
+		return errors.Wrap(err, "error streaming transfers")
+	}
+	defer rows.Close()
+
 	for rows.Next() {
-		var (
-			ID                   string
-			accountOriginID      string
-			accountDestinationID string
-			amount               int64
-			createdAt            time.Time
-		)
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return errors.Wrap(err, "error streaming transfers")
+		}
+
+		if err = fn(transfer); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// Summarize aggregates the rows in the database, reading a single row whatever the number of transfers
+func (t TransferSQL) Summarize(ctx context.Context, filter domain.TransferFilter) (domain.TransferSummary, error) {
+	var (
+		where, args = transferWhere(filter, domain.Cursor{})
+		summary     domain.TransferSummary
+	)
+
+	if err := t.db.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*), COALESCE(SUM(amount), 0) FROM transfers"+where,
+		args...,
+	).Scan(&summary.Count, &summary.Total); err != nil {
+		return domain.TransferSummary{}, errors.Wrap(err, "error summarizing transfers")
+	}
+
+	return summary, nil
+}
+
+// transferWhere builds the conditions of the filter, and of the rows after the cursor unless it is zero
+func transferWhere(filter domain.TransferFilter, after domain.Cursor) (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if filter.AccountID != "" {
+		args = append(args, filter.AccountID)
+		conditions = append(conditions, fmt.Sprintf("(account_origin_id = $%d OR account_destination_id = $%d)", len(args), len(args)))
+	}
+
+	if filter.AccountOriginID != "" {
+		args = append(args, filter.AccountOriginID)
+		conditions = append(conditions, fmt.Sprintf("account_origin_id = $%d", len(args)))
+	}
 
-		if err = rows.Scan(&ID, &accountOriginID, &accountDestinationID, &amount, &createdAt); err != nil {
+	if filter.AccountDestinationID != "" {
+		args = append(args, filter.AccountDestinationID)
+		conditions = append(conditions, fmt.Sprintf("account_destination_id = $%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	if filter.MinAmount != 0 {
+		args = append(args, filter.MinAmount)
+		conditions = append(conditions, fmt.Sprintf("amount >= $%d", len(args)))
+	}
+
+	if filter.MaxAmount != 0 {
+		args = append(args, filter.MaxAmount)
+		conditions = append(conditions, fmt.Sprintf("amount <= $%d", len(args)))
+	}
+
+	if !after.IsZero() {
+		var condition string
+		condition, args = keysetCondition(after, args)
+		conditions = append(conditions, condition)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// FindByID locks the transfer when called inside a transaction, so concurrent decisions on it are serialized
+func (t TransferSQL) FindByID(ctx context.Context, ID domain.TransferID) (domain.Transfer, error) {
+	var (
+		query = "SELECT " + transferColumns + " FROM transfers WHERE id = $1 LIMIT 1"
+		row   Row
+	)
+
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		row = tx.QueryRowContext(ctx, query+t.db.Dialect().ForUpdate(), ID)
+	} else {
+		row = t.db.QueryRowContext(ctx, query, ID)
+	}
+
+	var (
+		id                   string
+		accountOriginID      string
+		accountDestinationID string
+		amount               int64
+		status               string
+		failureCode          string
+		createdAt            time.Time
+	)
+
+	err := row.Scan(&id, &accountOriginID, &accountDestinationID, &amount, &status, &failureCode, &createdAt)
+	switch {
+	case err == sql.ErrNoRows:
+		return domain.Transfer{}, domain.ErrTransferNotFound
+	case err != nil:
+		return domain.Transfer{}, errors.Wrap(err, "error fetching transfer")
+	default:
+		return restoreTransfer(id, accountOriginID, accountDestinationID, amount, status, failureCode, createdAt), nil
+	}
+}
+
+func (t TransferSQL) UpdateStatus(ctx context.Context, ID domain.TransferID, status domain.TransferStatus) error {
+	var (
+		query = "UPDATE transfers SET status = $1 WHERE id = $2"
+		exec  = t.db.ExecuteContext
+	)
+
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		exec = tx.ExecuteContext
+	}
+
+	if err := exec(ctx, query, status, ID); err != nil {
+		return errors.Wrap(err, "error updating transfer status")
+	}
+
+	return nil
+}
+
+func (t TransferSQL) scanTransfers(rows Rows) ([]domain.Transfer, error) {
+	defer rows.Close()
+
+	var transfers = make([]domain.Transfer, 0)
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
 			return []domain.Transfer{}, errors.Wrap(err, "error listing transfers")
 		}
 
-		transfers = append(transfers, domain.NewTransfer(
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []domain.Transfer{}, err
+	}
+
+	return transfers, nil
+}
+
+func scanTransfer(row Row) (domain.Transfer, error) {
+	var (
+		ID                   string
+		accountOriginID      string
+		accountDestinationID string
+		amount               int64
+		status               string
+		failureCode          string
+		createdAt            time.Time
+	)
+
+	if err := row.Scan(&ID, &accountOriginID, &accountDestinationID, &amount, &status, &failureCode, &createdAt); err != nil {
+		return domain.Transfer{}, err
+	}
+
+	return restoreTransfer(ID, accountOriginID, accountDestinationID, amount, status, failureCode, createdAt), nil
+}
+
+// restoreTransfer rebuilds a stored transfer, keeping the failure code of failed ones
+func restoreTransfer(
+	ID, accountOriginID, accountDestinationID string,
+	amount int64,
+	status, failureCode string,
+	createdAt time.Time,
+) domain.Transfer {
+	if domain.TransferStatus(status) == domain.TransferStatusFailed {
+		return domain.NewFailedTransfer(
 			domain.TransferID(ID),
 			domain.AccountID(accountOriginID),
 			domain.AccountID(accountDestinationID),
 			domain.Money(amount),
+			domain.TransferFailureCode(failureCode),
 			createdAt,
-		))
-	}
-	defer rows.Close()
-
-	if err = rows.Err(); err != nil {
-		return []domain.Transfer{}, err
+		)
 	}
 
-	return transfers, nil
+	return domain.NewTransfer(
+		domain.TransferID(ID),
+		domain.AccountID(accountOriginID),
+		domain.AccountID(accountDestinationID),
+		domain.Money(amount),
+		domain.TransferStatus(status),
+		createdAt,
+	)
 }
 
 func (t TransferSQL) WithTransaction(ctx context.Context, fn func(ctxTx context.Context) error) error {
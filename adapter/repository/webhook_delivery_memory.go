@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type WebhookDeliveryMemory struct {
+	memory *Memory
+}
+
+func NewWebhookDeliveryMemory(memory *Memory) WebhookDeliveryMemory {
+	return WebhookDeliveryMemory{
+		memory: memory,
+	}
+}
+
+// Create writes the deliveries, skipping the events a webhook already has a delivery of. Deliveries are kept by their
+// webhook and event, the key the SQL one has unique
+func (w WebhookDeliveryMemory) Create(ctx context.Context, deliveries ...domain.WebhookDelivery) error {
+	err := w.memory.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, delivery := range deliveries {
+			var err = w.memory.insert(ctx, "webhook_deliveries", webhookDeliveryKey(delivery), delivery)
+			if err != nil && err != errMemoryDuplicateKey {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "error creating webhook delivery")
+	}
+
+	return nil
+}
+
+// Update writes the delivery state of the delivery, as the SQL one
+func (w WebhookDeliveryMemory) Update(ctx context.Context, delivery domain.WebhookDelivery) error {
+	stored, err := w.FindByID(ctx, delivery.ID())
+	if err == domain.ErrWebhookDeliveryNotFound {
+		return nil
+	}
+
+	err = w.memory.change(ctx, "webhook_deliveries", webhookDeliveryKey(stored), func(value interface{}) interface{} {
+		var stored = value.(domain.WebhookDelivery)
+		return domain.NewWebhookDelivery(
+			stored.ID(),
+			stored.WebhookID(),
+			stored.EventID(),
+			stored.EventName(),
+			stored.Payload(),
+			delivery.Status(),
+			delivery.Attempts(),
+			delivery.NextAttemptAt(),
+			delivery.ResponseStatus(),
+			delivery.LastError(),
+			delivery.DeliveredAt(),
+			stored.CreatedAt(),
+		)
+	})
+	if err != nil {
+		return errors.Wrap(err, "error updating webhook delivery")
+	}
+
+	return nil
+}
+
+func (w WebhookDeliveryMemory) FindByID(ctx context.Context, ID domain.WebhookDeliveryID) (domain.WebhookDelivery, error) {
+	for _, delivery := range w.findAll(ctx) {
+		if delivery.ID() == ID {
+			return delivery, nil
+		}
+	}
+
+	return domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound
+}
+
+// FindByWebhook returns the deliveries of the webhook, the latest first
+func (w WebhookDeliveryMemory) FindByWebhook(ctx context.Context, ID domain.WebhookID) ([]domain.WebhookDelivery, error) {
+	var (
+		all        = w.findAll(ctx)
+		deliveries = make([]domain.WebhookDelivery, 0)
+	)
+
+	for i := len(all) - 1; i >= 0; i-- {
+		if all[i].WebhookID() == ID {
+			deliveries = append(deliveries, all[i])
+		}
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDue leases the due deliveries, as the SQL one. Each delivery is locked and read again before it is leased, as
+// another deliverer may have taken it meanwhile
+func (w WebhookDeliveryMemory) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	until time.Time,
+	limit int,
+) ([]domain.WebhookDelivery, error) {
+	var deliveries = make([]domain.WebhookDelivery, 0)
+
+	err := w.memory.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, delivery := range w.findAll(ctx) {
+			if len(deliveries) == limit {
+				break
+			}
+
+			if !delivery.Due(now) {
+				continue
+			}
+
+			var key = webhookDeliveryKey(delivery)
+
+			err := w.memory.change(ctx, "webhook_deliveries", key, func(value interface{}) interface{} {
+				var stored = value.(domain.WebhookDelivery)
+				if !stored.Due(now) {
+					return stored
+				}
+
+				deliveries = append(deliveries, stored)
+				return domain.NewWebhookDelivery(
+					stored.ID(),
+					stored.WebhookID(),
+					stored.EventID(),
+					stored.EventName(),
+					stored.Payload(),
+					stored.Status(),
+					stored.Attempts(),
+					until,
+					stored.ResponseStatus(),
+					stored.LastError(),
+					stored.DeliveredAt(),
+					stored.CreatedAt(),
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return []domain.WebhookDelivery{}, errors.Wrap(err, "error claiming webhook deliveries")
+	}
+
+	return deliveries, nil
+}
+
+// findAll returns the deliveries in the order they were created
+func (w WebhookDeliveryMemory) findAll(ctx context.Context) []domain.WebhookDelivery {
+	var deliveries = make([]domain.WebhookDelivery, 0)
+	for _, value := range w.memory.list(ctx, "webhook_deliveries") {
+		deliveries = append(deliveries, value.(domain.WebhookDelivery))
+	}
+
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt().Before(deliveries[j].CreatedAt())
+	})
+
+	return deliveries
+}
+
+func webhookDeliveryKey(delivery domain.WebhookDelivery) string {
+	return delivery.WebhookID().String() + ":" + delivery.EventID()
+}
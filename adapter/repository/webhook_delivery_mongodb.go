@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type webhookDeliveryBSON struct {
+	ID             string     `bson:"id"`
+	WebhookID      string     `bson:"webhook_id"`
+	EventID        string     `bson:"event_id"`
+	EventName      string     `bson:"event_name"`
+	Payload        string     `bson:"payload"`
+	Status         string     `bson:"status"`
+	Attempts       int        `bson:"attempts"`
+	NextAttemptAt  time.Time  `bson:"next_attempt_at"`
+	ResponseStatus int        `bson:"response_status"`
+	LastError      string     `bson:"last_error"`
+	DeliveredAt    *time.Time `bson:"delivered_at"`
+	CreatedAt      time.Time  `bson:"created_at"`
+}
+
+type WebhookDeliveryNoSQL struct {
+	collectionName string
+	db             NoSQL
+}
+
+func NewWebhookDeliveryNoSQL(db NoSQL) WebhookDeliveryNoSQL {
+	return WebhookDeliveryNoSQL{
+		db:             db,
+		collectionName: "webhook_deliveries",
+	}
+}
+
+// Create writes the deliveries. The unique index on webhook_id and event_id rejects the events a webhook already has
+// a delivery of, which are skipped
+func (w WebhookDeliveryNoSQL) Create(ctx context.Context, deliveries ...domain.WebhookDelivery) error {
+	for _, delivery := range deliveries {
+		var deliveryBSON = webhookDeliveryBSON{
+			ID:             delivery.ID().String(),
+			WebhookID:      delivery.WebhookID().String(),
+			EventID:        delivery.EventID(),
+			EventName:      delivery.EventName().String(),
+			Payload:        string(delivery.Payload()),
+			Status:         delivery.Status().String(),
+			Attempts:       delivery.Attempts(),
+			NextAttemptAt:  delivery.NextAttemptAt(),
+			ResponseStatus: delivery.ResponseStatus(),
+			LastError:      delivery.LastError(),
+			DeliveredAt:    optionalTimeBSON(delivery.DeliveredAt()),
+			CreatedAt:      delivery.CreatedAt(),
+		}
+
+		if err := w.db.Store(ctx, w.collectionName, deliveryBSON); err != nil && !mongo.IsDuplicateKeyError(err) {
+			return errors.Wrap(err, "error creating webhook delivery")
+		}
+	}
+
+	return nil
+}
+
+func (w WebhookDeliveryNoSQL) Update(ctx context.Context, delivery domain.WebhookDelivery) error {
+	var (
+		query  = bson.M{"id": delivery.ID()}
+		update = bson.M{"$set": bson.M{
+			"status":          delivery.Status().String(),
+			"attempts":        delivery.Attempts(),
+			"next_attempt_at": delivery.NextAttemptAt(),
+			"response_status": delivery.ResponseStatus(),
+			"last_error":      delivery.LastError(),
+			"delivered_at":    optionalTimeBSON(delivery.DeliveredAt()),
+		}}
+	)
+
+	if err := w.db.Update(ctx, w.collectionName, query, update); err != nil {
+		return errors.Wrap(err, "error updating webhook delivery")
+	}
+
+	return nil
+}
+
+func (w WebhookDeliveryNoSQL) FindByID(ctx context.Context, ID domain.WebhookDeliveryID) (domain.WebhookDelivery, error) {
+	var deliveryBSON = &webhookDeliveryBSON{}
+
+	if err := w.db.FindOne(ctx, w.collectionName, bson.M{"id": ID}, nil, deliveryBSON); err != nil {
+		switch err {
+		case mongo.ErrNoDocuments:
+			return domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound
+		default:
+			return domain.WebhookDelivery{}, errors.Wrap(err, "error fetching webhook delivery")
+		}
+	}
+
+	return deliveryBSON.toDomain(), nil
+}
+
+func (w WebhookDeliveryNoSQL) FindByWebhook(ctx context.Context, ID domain.WebhookID) ([]domain.WebhookDelivery, error) {
+	deliveriesBSON, err := w.findMany(ctx, bson.M{"webhook_id": ID})
+	if err != nil {
+		return []domain.WebhookDelivery{}, err
+	}
+
+	sort.SliceStable(deliveriesBSON, func(i, j int) bool {
+		return deliveriesBSON[i].CreatedAt.After(deliveriesBSON[j].CreatedAt)
+	})
+
+	return webhookDeliveriesToDomain(deliveriesBSON), nil
+}
+
+// ClaimDue leases the due deliveries, as the SQL one. Each lease is taken by an update matching only a delivery still
+// due, so two deliverers never take the same one
+func (w WebhookDeliveryNoSQL) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	until time.Time,
+	limit int,
+) ([]domain.WebhookDelivery, error) {
+	deliveriesBSON, err := w.findMany(ctx, bson.M{
+		"status":          domain.WebhookDeliveryPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	})
+	if err != nil {
+		return []domain.WebhookDelivery{}, err
+	}
+
+	sort.SliceStable(deliveriesBSON, func(i, j int) bool {
+		return deliveriesBSON[i].CreatedAt.Before(deliveriesBSON[j].CreatedAt)
+	})
+
+	var deliveries = make([]domain.WebhookDelivery, 0)
+	for _, deliveryBSON := range deliveriesBSON {
+		if len(deliveries) == limit {
+			break
+		}
+
+		var (
+			query = bson.M{
+				"id":              deliveryBSON.ID,
+				"status":          domain.WebhookDeliveryPending,
+				"next_attempt_at": bson.M{"$lte": now},
+			}
+			update = bson.M{"$set": bson.M{"next_attempt_at": until}}
+		)
+
+		claimed, err := w.db.UpdateMatched(ctx, w.collectionName, query, update)
+		if err != nil {
+			return []domain.WebhookDelivery{}, errors.Wrap(err, "error claiming webhook deliveries")
+		}
+
+		if claimed {
+			deliveries = append(deliveries, deliveryBSON.toDomain())
+		}
+	}
+
+	return deliveries, nil
+}
+
+func (w WebhookDeliveryNoSQL) findMany(ctx context.Context, query bson.M) ([]webhookDeliveryBSON, error) {
+	var deliveriesBSON = make([]webhookDeliveryBSON, 0)
+
+	if err := w.db.FindAll(ctx, w.collectionName, query, &deliveriesBSON); err != nil {
+		return nil, errors.Wrap(err, "error listing webhook deliveries")
+	}
+
+	return deliveriesBSON, nil
+}
+
+func webhookDeliveriesToDomain(deliveriesBSON []webhookDeliveryBSON) []domain.WebhookDelivery {
+	var deliveries = make([]domain.WebhookDelivery, 0, len(deliveriesBSON))
+	for _, deliveryBSON := range deliveriesBSON {
+		deliveries = append(deliveries, deliveryBSON.toDomain())
+	}
+
+	return deliveries
+}
+
+func (w webhookDeliveryBSON) toDomain() domain.WebhookDelivery {
+	return domain.NewWebhookDelivery(
+		domain.WebhookDeliveryID(w.ID),
+		domain.WebhookID(w.WebhookID),
+		w.EventID,
+		domain.EventName(w.EventName),
+		[]byte(w.Payload),
+		domain.WebhookDeliveryStatus(w.Status),
+		w.Attempts,
+		w.NextAttemptAt,
+		w.ResponseStatus,
+		w.LastError,
+		timeFromBSON(w.DeliveredAt),
+		w.CreatedAt,
+	)
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type WebhookDeliverySQL struct {
+	db SQL
+}
+
+func NewWebhookDeliverySQL(db SQL) WebhookDeliverySQL {
+	return WebhookDeliverySQL{
+		db: db,
+	}
+}
+
+// Create writes the deliveries, skipping the events a webhook already has a delivery of
+func (w WebhookDeliverySQL) Create(ctx context.Context, deliveries ...domain.WebhookDelivery) error {
+	var query = `
+		INSERT INTO
+			webhook_deliveries (id, webhook_id, event_id, event_name, payload, status, attempts, next_attempt_at,
+				response_status, last_error, delivered_at, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	` + w.db.Dialect().OnConflictDoNothing("webhook_id", "event_id")
+
+	for _, delivery := range deliveries {
+		if err := w.db.ExecuteContext(
+			ctx,
+			query,
+			delivery.ID(),
+			delivery.WebhookID(),
+			delivery.EventID(),
+			delivery.EventName(),
+			string(delivery.Payload()),
+			delivery.Status(),
+			delivery.Attempts(),
+			delivery.NextAttemptAt(),
+			delivery.ResponseStatus(),
+			delivery.LastError(),
+			nullTime(delivery.DeliveredAt()),
+			delivery.CreatedAt(),
+		); err != nil {
+			return errors.Wrap(err, "error creating webhook delivery")
+		}
+	}
+
+	return nil
+}
+
+func (w WebhookDeliverySQL) Update(ctx context.Context, delivery domain.WebhookDelivery) error {
+	var query = `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = $2, next_attempt_at = $3, response_status = $4, last_error = $5, delivered_at = $6
+		WHERE id = $7
+	`
+
+	if err := w.db.ExecuteContext(
+		ctx,
+		query,
+		delivery.Status(),
+		delivery.Attempts(),
+		delivery.NextAttemptAt(),
+		delivery.ResponseStatus(),
+		delivery.LastError(),
+		nullTime(delivery.DeliveredAt()),
+		delivery.ID(),
+	); err != nil {
+		return errors.Wrap(err, "error updating webhook delivery")
+	}
+
+	return nil
+}
+
+func (w WebhookDeliverySQL) FindByID(ctx context.Context, ID domain.WebhookDeliveryID) (domain.WebhookDelivery, error) {
+	var query = `
+		SELECT id, webhook_id, event_id, event_name, payload, status, attempts, next_attempt_at, response_status,
+			last_error, delivered_at, created_at
+		FROM webhook_deliveries
+		WHERE id = $1
+	`
+
+	delivery, err := scanWebhookDelivery(w.db.QueryRowContext(ctx, query, ID))
+	switch {
+	case err == sql.ErrNoRows:
+		return domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound
+	case err != nil:
+		return domain.WebhookDelivery{}, errors.Wrap(err, "error fetching webhook delivery")
+	default:
+		return delivery, nil
+	}
+}
+
+func (w WebhookDeliverySQL) FindByWebhook(ctx context.Context, ID domain.WebhookID) ([]domain.WebhookDelivery, error) {
+	var query = `
+		SELECT id, webhook_id, event_id, event_name, payload, status, attempts, next_attempt_at, response_status,
+			last_error, delivered_at, created_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC
+	`
+
+	return w.findMany(ctx, query, ID)
+}
+
+// ClaimDue leases the due deliveries by moving their next attempt to the end of the lease, so no other deliverer takes
+// them meanwhile. The deliveries are locked while they are leased, skipping the ones another deliverer is leasing at
+// the same time
+func (w WebhookDeliverySQL) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	until time.Time,
+	limit int,
+) ([]domain.WebhookDelivery, error) {
+	var query = `
+		SELECT id, webhook_id, event_id, event_name, payload, status, attempts, next_attempt_at, response_status,
+			last_error, delivered_at, created_at
+		FROM webhook_deliveries
+		WHERE status = $1 AND next_attempt_at <= $2
+		ORDER BY created_at
+		LIMIT $3
+	` + w.db.Dialect().ForUpdateSkipLocked()
+
+	var deliveries = make([]domain.WebhookDelivery, 0)
+
+	err := w.withTransaction(ctx, func(tx Tx) error {
+		rows, err := tx.QueryContext(ctx, query, domain.WebhookDeliveryPending, now, limit)
+		if err != nil {
+			return err
+		}
+
+		deliveries, err = scanWebhookDeliveries(rows)
+		if err != nil {
+			return err
+		}
+
+		for _, delivery := range deliveries {
+			if err = tx.ExecuteContext(
+				ctx,
+				`UPDATE webhook_deliveries SET next_attempt_at = $1 WHERE id = $2`,
+				until,
+				delivery.ID(),
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return []domain.WebhookDelivery{}, errors.Wrap(err, "error claiming webhook deliveries")
+	}
+
+	return deliveries, nil
+}
+
+func (w WebhookDeliverySQL) withTransaction(ctx context.Context, fn func(Tx) error) error {
+	tx, err := w.db.BeginTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error begin tx")
+	}
+
+	if err = fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Wrap(err, "rollback error")
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (w WebhookDeliverySQL) findMany(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) ([]domain.WebhookDelivery, error) {
+	rows, err := w.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return []domain.WebhookDelivery{}, errors.Wrap(err, "error listing webhook deliveries")
+	}
+
+	deliveries, err := scanWebhookDeliveries(rows)
+	if err != nil {
+		return []domain.WebhookDelivery{}, errors.Wrap(err, "error listing webhook deliveries")
+	}
+
+	return deliveries, nil
+}
+
+// scanWebhookDeliveries reads the deliveries of the rows, closing them
+func scanWebhookDeliveries(rows Rows) ([]domain.WebhookDelivery, error) {
+	defer rows.Close()
+
+	var deliveries = make([]domain.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func scanWebhookDelivery(row Row) (domain.WebhookDelivery, error) {
+	var (
+		ID             string
+		webhookID      string
+		eventID        string
+		eventName      string
+		payload        string
+		status         string
+		attempts       int
+		nextAttemptAt  time.Time
+		responseStatus int
+		lastError      string
+		deliveredAt    sql.NullTime
+		createdAt      time.Time
+	)
+
+	if err := row.Scan(
+		&ID,
+		&webhookID,
+		&eventID,
+		&eventName,
+		&payload,
+		&status,
+		&attempts,
+		&nextAttemptAt,
+		&responseStatus,
+		&lastError,
+		&deliveredAt,
+		&createdAt,
+	); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	return domain.NewWebhookDelivery(
+		domain.WebhookDeliveryID(ID),
+		domain.WebhookID(webhookID),
+		eventID,
+		domain.EventName(eventName),
+		[]byte(payload),
+		domain.WebhookDeliveryStatus(status),
+		attempts,
+		nextAttemptAt,
+		responseStatus,
+		lastError,
+		deliveredAt.Time,
+		createdAt,
+	), nil
+}
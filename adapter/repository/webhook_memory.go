@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type WebhookMemory struct {
+	memory *Memory
+}
+
+func NewWebhookMemory(memory *Memory) WebhookMemory {
+	return WebhookMemory{
+		memory: memory,
+	}
+}
+
+func (w WebhookMemory) Create(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	if err := w.memory.insert(ctx, "webhooks", webhook.ID().String(), webhook); err != nil {
+		return domain.Webhook{}, errors.Wrap(err, "error creating webhook")
+	}
+
+	return webhook, nil
+}
+
+// Update writes the failures of the webhook and when it was disabled, as the SQL one
+func (w WebhookMemory) Update(ctx context.Context, webhook domain.Webhook) error {
+	err := w.memory.change(ctx, "webhooks", webhook.ID().String(), func(value interface{}) interface{} {
+		var stored = value.(domain.Webhook)
+		return domain.NewWebhook(
+			stored.ID(),
+			stored.Owner(),
+			stored.URL(),
+			stored.Events(),
+			stored.AccountIDs(),
+			stored.Secret(),
+			webhook.Failures(),
+			webhook.DisabledAt(),
+			stored.CreatedAt(),
+		)
+	})
+	if err != nil {
+		return errors.Wrap(err, "error updating webhook")
+	}
+
+	return nil
+}
+
+func (w WebhookMemory) FindAll(ctx context.Context) ([]domain.Webhook, error) {
+	var webhooks = make([]domain.Webhook, 0)
+	for _, value := range w.memory.list(ctx, "webhooks") {
+		webhooks = append(webhooks, value.(domain.Webhook))
+	}
+
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt().Before(webhooks[j].CreatedAt())
+	})
+
+	return webhooks, nil
+}
+
+func (w WebhookMemory) FindByEvent(
+	ctx context.Context,
+	name domain.EventName,
+	accountIDs []domain.AccountID,
+) ([]domain.Webhook, error) {
+	all, _ := w.FindAll(ctx)
+
+	var webhooks = make([]domain.Webhook, 0)
+	for _, webhook := range all {
+		if webhook.Enabled() && webhook.Subscribes(name) && webhook.Watches(accountIDs) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return webhooks, nil
+}
+
+// FindByID locks the webhook when called inside a transaction, as the SQL one
+func (w WebhookMemory) FindByID(ctx context.Context, ID domain.WebhookID) (domain.Webhook, error) {
+	if tx, ok := memoryTxFrom(ctx); ok {
+		if err := w.memory.lock(ctx, tx, "webhooks:"+ID.String()); err != nil {
+			return domain.Webhook{}, errors.Wrap(err, "error fetching webhook")
+		}
+	}
+
+	value, ok := w.memory.find(ctx, "webhooks", ID.String())
+	if !ok {
+		return domain.Webhook{}, domain.ErrWebhookNotFound
+	}
+
+	return value.(domain.Webhook), nil
+}
+
+func (w WebhookMemory) WithTransaction(ctx context.Context, fn func(ctxTx context.Context) error) error {
+	return w.memory.WithTransaction(ctx, fn)
+}
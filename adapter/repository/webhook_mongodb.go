@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type webhookBSON struct {
+	ID         string     `bson:"id"`
+	Owner      string     `bson:"owner"`
+	URL        string     `bson:"url"`
+	Events     []string   `bson:"events"`
+	AccountIDs []string   `bson:"account_ids"`
+	Secret     string     `bson:"secret"`
+	Failures   int        `bson:"failures"`
+	DisabledAt *time.Time `bson:"disabled_at"`
+	CreatedAt  time.Time  `bson:"created_at"`
+}
+
+type WebhookNoSQL struct {
+	collectionName string
+	db             NoSQL
+}
+
+func NewWebhookNoSQL(db NoSQL) WebhookNoSQL {
+	return WebhookNoSQL{
+		db:             db,
+		collectionName: "webhooks",
+	}
+}
+
+func (w WebhookNoSQL) Create(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	var events = make([]string, 0, len(webhook.Events()))
+	for _, e := range webhook.Events() {
+		events = append(events, e.String())
+	}
+
+	var accountIDs = make([]string, 0, len(webhook.AccountIDs()))
+	for _, ID := range webhook.AccountIDs() {
+		accountIDs = append(accountIDs, ID.String())
+	}
+
+	var webhookBSON = webhookBSON{
+		ID:         webhook.ID().String(),
+		Owner:      webhook.Owner(),
+		URL:        webhook.URL(),
+		Events:     events,
+		AccountIDs: accountIDs,
+		Secret:     webhook.Secret(),
+		Failures:   webhook.Failures(),
+		DisabledAt: optionalTimeBSON(webhook.DisabledAt()),
+		CreatedAt:  webhook.CreatedAt(),
+	}
+
+	if err := w.db.Store(ctx, w.collectionName, webhookBSON); err != nil {
+		return domain.Webhook{}, errors.Wrap(err, "error creating webhook")
+	}
+
+	return webhook, nil
+}
+
+func (w WebhookNoSQL) Update(ctx context.Context, webhook domain.Webhook) error {
+	var (
+		query  = bson.M{"id": webhook.ID()}
+		update = bson.M{"$set": bson.M{
+			"failures":    webhook.Failures(),
+			"disabled_at": optionalTimeBSON(webhook.DisabledAt()),
+		}}
+	)
+
+	if err := w.db.Update(ctx, w.collectionName, query, update); err != nil {
+		return errors.Wrap(err, "error updating webhook")
+	}
+
+	return nil
+}
+
+func (w WebhookNoSQL) FindAll(ctx context.Context) ([]domain.Webhook, error) {
+	return w.findMany(ctx, bson.M{})
+}
+
+func (w WebhookNoSQL) FindByEvent(
+	ctx context.Context,
+	name domain.EventName,
+	accountIDs []domain.AccountID,
+) ([]domain.Webhook, error) {
+	var IDs = make(bson.A, 0, len(accountIDs))
+	for _, ID := range accountIDs {
+		IDs = append(IDs, ID.String())
+	}
+
+	return w.findMany(ctx, bson.M{"events": name, "account_ids": bson.M{"$in": IDs}, "disabled_at": nil})
+}
+
+func (w WebhookNoSQL) FindByID(ctx context.Context, ID domain.WebhookID) (domain.Webhook, error) {
+	var webhookBSON = &webhookBSON{}
+
+	if err := w.db.FindOne(ctx, w.collectionName, bson.M{"id": ID}, nil, webhookBSON); err != nil {
+		switch err {
+		case mongo.ErrNoDocuments:
+			return domain.Webhook{}, domain.ErrWebhookNotFound
+		default:
+			return domain.Webhook{}, errors.Wrap(err, "error fetching webhook")
+		}
+	}
+
+	return webhookBSON.toDomain(), nil
+}
+
+func (w WebhookNoSQL) findMany(ctx context.Context, query bson.M) ([]domain.Webhook, error) {
+	var webhooksBSON = make([]webhookBSON, 0)
+
+	if err := w.db.FindAll(ctx, w.collectionName, query, &webhooksBSON); err != nil {
+		return []domain.Webhook{}, errors.Wrap(err, "error listing webhooks")
+	}
+
+	sort.SliceStable(webhooksBSON, func(i, j int) bool {
+		return webhooksBSON[i].CreatedAt.Before(webhooksBSON[j].CreatedAt)
+	})
+
+	var webhooks = make([]domain.Webhook, 0, len(webhooksBSON))
+	for _, webhookBSON := range webhooksBSON {
+		webhooks = append(webhooks, webhookBSON.toDomain())
+	}
+
+	return webhooks, nil
+}
+
+func (w webhookBSON) toDomain() domain.Webhook {
+	var events = make([]domain.EventName, 0, len(w.Events))
+	for _, e := range w.Events {
+		events = append(events, domain.EventName(e))
+	}
+
+	var accountIDs = make([]domain.AccountID, 0, len(w.AccountIDs))
+	for _, ID := range w.AccountIDs {
+		accountIDs = append(accountIDs, domain.AccountID(ID))
+	}
+
+	return domain.NewWebhook(
+		domain.WebhookID(w.ID),
+		w.Owner,
+		w.URL,
+		events,
+		accountIDs,
+		w.Secret,
+		w.Failures,
+		timeFromBSON(w.DisabledAt),
+		w.CreatedAt,
+	)
+}
+
+func (w WebhookNoSQL) WithTransaction(ctx context.Context, fn func(ctxTx context.Context) error) error {
+	session, err := w.db.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	return session.WithTransaction(ctx, fn)
+}
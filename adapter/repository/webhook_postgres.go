@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/pkg/errors"
+)
+
+type WebhookSQL struct {
+	db SQL
+}
+
+func NewWebhookSQL(db SQL) WebhookSQL {
+	return WebhookSQL{
+		db: db,
+	}
+}
+
+func (w WebhookSQL) Create(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	var query = `
+		INSERT INTO
+			webhooks (id, owner, url, events, account_ids, secret, failures, disabled_at, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	if err := w.db.ExecuteContext(
+		ctx,
+		query,
+		webhook.ID(),
+		webhook.Owner(),
+		webhook.URL(),
+		joinEventNames(webhook.Events()),
+		joinAccountIDs(webhook.AccountIDs()),
+		webhook.Secret(),
+		webhook.Failures(),
+		nullTime(webhook.DisabledAt()),
+		webhook.CreatedAt(),
+	); err != nil {
+		return domain.Webhook{}, errors.Wrap(err, "error creating webhook")
+	}
+
+	return webhook, nil
+}
+
+func (w WebhookSQL) Update(ctx context.Context, webhook domain.Webhook) error {
+	var query = "UPDATE webhooks SET failures = $1, disabled_at = $2 WHERE id = $3"
+
+	var exec = w.db.ExecuteContext
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		exec = tx.ExecuteContext
+	}
+
+	if err := exec(
+		ctx,
+		query,
+		webhook.Failures(),
+		nullTime(webhook.DisabledAt()),
+		webhook.ID(),
+	); err != nil {
+		return errors.Wrap(err, "error updating webhook")
+	}
+
+	return nil
+}
+
+func (w WebhookSQL) FindAll(ctx context.Context) ([]domain.Webhook, error) {
+	var query = `
+		SELECT id, owner, url, events, account_ids, secret, failures, disabled_at, created_at
+		FROM webhooks
+		ORDER BY created_at
+	`
+
+	return w.findMany(ctx, query)
+}
+
+// FindByEvent filters the events and the accounts of the enabled webhooks once read, as they are stored as lists in
+// single columns that every engine splits its own way
+func (w WebhookSQL) FindByEvent(
+	ctx context.Context,
+	name domain.EventName,
+	accountIDs []domain.AccountID,
+) ([]domain.Webhook, error) {
+	var query = `
+		SELECT id, owner, url, events, account_ids, secret, failures, disabled_at, created_at
+		FROM webhooks
+		WHERE disabled_at IS NULL
+		ORDER BY created_at
+	`
+
+	enabled, err := w.findMany(ctx, query)
+	if err != nil {
+		return []domain.Webhook{}, err
+	}
+
+	var webhooks = make([]domain.Webhook, 0, len(enabled))
+	for _, webhook := range enabled {
+		if webhook.Subscribes(name) && webhook.Watches(accountIDs) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return webhooks, nil
+}
+
+// FindByID locks the webhook when called inside a transaction, so the changes of its failures and of its state are
+// serialized
+func (w WebhookSQL) FindByID(ctx context.Context, ID domain.WebhookID) (domain.Webhook, error) {
+	var (
+		query = `
+			SELECT id, owner, url, events, account_ids, secret, failures, disabled_at, created_at
+			FROM webhooks
+			WHERE id = $1
+		`
+		row Row
+	)
+
+	if tx, ok := ctx.Value("TransactionContextKey").(Tx); ok {
+		row = tx.QueryRowContext(ctx, query+w.db.Dialect().ForNoKeyUpdate(), ID)
+	} else {
+		row = w.db.QueryRowContext(ctx, query, ID)
+	}
+
+	webhook, err := scanWebhook(row)
+	switch {
+	case err == sql.ErrNoRows:
+		return domain.Webhook{}, domain.ErrWebhookNotFound
+	case err != nil:
+		return domain.Webhook{}, errors.Wrap(err, "error fetching webhook")
+	default:
+		return webhook, nil
+	}
+}
+
+func (w WebhookSQL) WithTransaction(ctx context.Context, fn func(ctxTx context.Context) error) error {
+	tx, err := w.db.BeginTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error begin tx")
+	}
+
+	ctxTx := context.WithValue(ctx, "TransactionContextKey", tx)
+	err = fn(ctxTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Wrap(err, "rollback error")
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (w WebhookSQL) findMany(ctx context.Context, query string, args ...interface{}) ([]domain.Webhook, error) {
+	rows, err := w.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return []domain.Webhook{}, errors.Wrap(err, "error listing webhooks")
+	}
+	defer rows.Close()
+
+	var webhooks = make([]domain.Webhook, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return []domain.Webhook{}, errors.Wrap(err, "error listing webhooks")
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return []domain.Webhook{}, err
+	}
+
+	return webhooks, nil
+}
+
+func scanWebhook(row Row) (domain.Webhook, error) {
+	var (
+		ID         string
+		owner      string
+		url        string
+		events     string
+		accountIDs string
+		secret     string
+		failures   int
+		disabledAt sql.NullTime
+		createdAt  time.Time
+	)
+
+	if err := row.Scan(
+		&ID,
+		&owner,
+		&url,
+		&events,
+		&accountIDs,
+		&secret,
+		&failures,
+		&disabledAt,
+		&createdAt,
+	); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	return domain.NewWebhook(
+		domain.WebhookID(ID),
+		owner,
+		url,
+		parseEventNames(events),
+		parseAccountIDs(accountIDs),
+		secret,
+		failures,
+		disabledAt.Time,
+		createdAt,
+	), nil
+}
+
+// joinEventNames stores event names space separated, as scopes are
+func joinEventNames(names []domain.EventName) string {
+	var s = make([]string, 0, len(names))
+	for _, n := range names {
+		s = append(s, n.String())
+	}
+
+	return strings.Join(s, " ")
+}
+
+func parseEventNames(raw string) []domain.EventName {
+	var names = make([]domain.EventName, 0)
+	for _, s := range strings.Fields(raw) {
+		names = append(names, domain.EventName(s))
+	}
+
+	return names
+}
+
+// joinAccountIDs stores the accounts of a webhook space separated, as its events
+func joinAccountIDs(IDs []domain.AccountID) string {
+	var s = make([]string, 0, len(IDs))
+	for _, ID := range IDs {
+		s = append(s, ID.String())
+	}
+
+	return strings.Join(s, " ")
+}
+
+func parseAccountIDs(raw string) []domain.AccountID {
+	var IDs = make([]domain.AccountID, 0)
+	for _, s := range strings.Fields(raw) {
+		IDs = append(IDs, domain.AccountID(s))
+	}
+
+	return IDs
+}
@@ -0,0 +1,213 @@
+package transferfile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/validator"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+// MaxLines bounds the transfers of a file
+const MaxLines = 10000
+
+var (
+	ErrUnknownFormat = errors.New("format must be csv or pain001")
+
+	ErrTooManyLines = errors.New("the file has more than 10000 transfers")
+
+	ErrInvalidCSVHeader = errors.New("the csv file must start with a header naming the columns account_origin_id, account_destination_id and amount")
+
+	ErrInvalidPain001 = errors.New("the file is not a pain.001 customer credit transfer initiation")
+
+	errAccountsEquals = errors.New("account origin equals destination account")
+)
+
+// Read parses the transfers of the file and validates each one with the rules of CreateTransferInput. A file that
+// can not be parsed fails as a whole, a transfer that is not valid only fails its line
+func Read(format domain.TransferImportFormat, content []byte, v validator.Validator) ([]usecase.ImportTransferLine, error) {
+	var (
+		lines []usecase.ImportTransferLine
+		err   error
+	)
+
+	switch format {
+	case domain.TransferImportCSV:
+		lines, err = readCSV(content)
+	case domain.TransferImportPain001:
+		lines, err = readPain001(content)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range lines {
+		lines[i].Errors = append(lines[i].Errors, validate(v, lines[i].Transfer)...)
+	}
+
+	return lines, nil
+}
+
+// readCSV reads a row per transfer, the amount being a decimal such as 1234.56. Columns are found by the header, in
+// any order, and a currency column, when present, must be BRL. Lines are numbered as lines of the file, the header
+// being the first
+func readCSV(content []byte) ([]usecase.ImportTransferLine, error) {
+	var r = csv.NewReader(bytes.NewReader(content))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, ErrInvalidCSVHeader
+	}
+
+	var columns = make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range []string{"account_origin_id", "account_destination_id", "amount"} {
+		if _, ok := columns[name]; !ok {
+			return nil, ErrInvalidCSVHeader
+		}
+	}
+
+	var lines = make([]usecase.ImportTransferLine, 0)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var (
+			parseErr *csv.ParseError
+			line     usecase.ImportTransferLine
+		)
+
+		if errors.As(err, &parseErr) {
+			line.Number = parseErr.StartLine
+			line.Errors = append(line.Errors, parseErr.Err.Error())
+			if lines = append(lines, line); len(lines) > MaxLines {
+				return nil, ErrTooManyLines
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line.Number, _ = r.FieldPos(0)
+
+		var field = func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+
+			return strings.TrimSpace(record[i])
+		}
+
+		line.Transfer.AccountOriginID = field("account_origin_id")
+		line.Transfer.AccountDestinationID = field("account_destination_id")
+		line.Transfer.Amount, line.Errors = readAmount(field("amount"), field("currency"))
+
+		if lines = append(lines, line); len(lines) > MaxLines {
+			return nil, ErrTooManyLines
+		}
+	}
+
+	return lines, nil
+}
+
+type (
+	pain001Document struct {
+		Payments []struct {
+			Debtor       pain001Account `xml:"DbtrAcct"`
+			Transactions []struct {
+				Amount struct {
+					Value    string `xml:",chardata"`
+					Currency string `xml:"Ccy,attr"`
+				} `xml:"Amt>InstdAmt"`
+				Creditor pain001Account `xml:"CdtrAcct"`
+			} `xml:"CdtTrfTxInf"`
+		} `xml:"CstmrCdtTrfInitn>PmtInf"`
+	}
+
+	// pain001Account is an account identified by its ID, IBANs are not held here
+	pain001Account struct {
+		ID string `xml:"Id>Othr>Id"`
+	}
+)
+
+// readPain001 reads a transfer per credit transfer transaction, from the debtor account of its payment. Lines are
+// numbered by transaction, across payments, starting at 1
+func readPain001(content []byte) ([]usecase.ImportTransferLine, error) {
+	var document pain001Document
+	if err := xml.Unmarshal(content, &document); err != nil || len(document.Payments) == 0 {
+		return nil, ErrInvalidPain001
+	}
+
+	var lines = make([]usecase.ImportTransferLine, 0)
+	for _, payment := range document.Payments {
+		for _, transaction := range payment.Transactions {
+			var line = usecase.ImportTransferLine{
+				Number: len(lines) + 1,
+				Transfer: usecase.CreateTransferInput{
+					AccountOriginID:      strings.TrimSpace(payment.Debtor.ID),
+					AccountDestinationID: strings.TrimSpace(transaction.Creditor.ID),
+				},
+			}
+
+			line.Transfer.Amount, line.Errors = readAmount(
+				strings.TrimSpace(transaction.Amount.Value),
+				transaction.Amount.Currency,
+			)
+
+			if lines = append(lines, line); len(lines) > MaxLines {
+				return nil, ErrTooManyLines
+			}
+		}
+	}
+
+	return lines, nil
+}
+
+// readAmount converts a decimal amount to cents. An empty currency is taken as BRL
+func readAmount(amount, currency string) (int64, []string) {
+	var errs []string
+
+	if currency != "" {
+		if _, err := domain.ParseCurrency(currency); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	money, err := domain.ParseMoney(amount)
+	if err != nil {
+		return 0, append(errs, err.Error())
+	}
+
+	return money.Int64(), errs
+}
+
+// validate applies the rules of a transfer created through the API
+func validate(v validator.Validator, input usecase.CreateTransferInput) []string {
+	var msgs []string
+
+	if input.AccountOriginID != "" && input.AccountOriginID == input.AccountDestinationID {
+		msgs = append(msgs, errAccountsEquals.Error())
+	}
+
+	if err := v.Validate(input); err != nil {
+		msgs = append(msgs, v.Messages()...)
+	}
+
+	return msgs
+}
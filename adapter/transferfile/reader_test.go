@@ -0,0 +1,114 @@
+package transferfile
+
+import (
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/validation"
+)
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	validator, _ := validation.NewValidatorFactory(validation.InstanceGoPlayground)
+
+	type line struct {
+		number  int
+		amount  int64
+		invalid bool
+	}
+
+	tests := []struct {
+		name          string
+		format        domain.TransferImportFormat
+		content       string
+		expected      []line
+		expectedError error
+	}{
+		{
+			name:   "CSV file with columns in any order",
+			format: domain.TransferImportCSV,
+			content: "amount,account_destination_id,account_origin_id\n" +
+				"12.34,a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c,3c096a40-ccba-4b58-93ed-57379ab04680\n" +
+				"\n" +
+				"5,a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c,3c096a40-ccba-4b58-93ed-57379ab04680\n",
+			expected: []line{{number: 2, amount: 1234}, {number: 4, amount: 500}},
+		},
+		{
+			name:   "CSV lines failing the rules of a transfer",
+			format: domain.TransferImportCSV,
+			content: "account_origin_id,account_destination_id,amount,currency\n" +
+				"3c096a40-ccba-4b58-93ed-57379ab04680,3c096a40-ccba-4b58-93ed-57379ab04680,1,BRL\n" +
+				"invalid,a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c,1,BRL\n" +
+				"3c096a40-ccba-4b58-93ed-57379ab04680,a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c,abc,BRL\n" +
+				"3c096a40-ccba-4b58-93ed-57379ab04680,a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c,1,USD\n",
+			expected: []line{
+				{number: 2, amount: 100, invalid: true},
+				{number: 3, amount: 100, invalid: true},
+				{number: 4, invalid: true},
+				{number: 5, amount: 100, invalid: true},
+			},
+		},
+		{
+			name:          "CSV file without header",
+			format:        domain.TransferImportCSV,
+			content:       "3c096a40-ccba-4b58-93ed-57379ab04680,a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c,1\n",
+			expectedError: ErrInvalidCSVHeader,
+		},
+		{
+			name:   "pain.001 file",
+			format: domain.TransferImportPain001,
+			content: `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03">
+  <CstmrCdtTrfInitn>
+    <PmtInf>
+      <DbtrAcct><Id><Othr><Id>3c096a40-ccba-4b58-93ed-57379ab04680</Id></Othr></Id></DbtrAcct>
+      <CdtTrfTxInf>
+        <Amt><InstdAmt Ccy="BRL">1500.00</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <Amt><InstdAmt Ccy="BRL">0</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>`,
+			expected: []line{{number: 1, amount: 150000}, {number: 2, invalid: true}},
+		},
+		{
+			name:          "XML file that is not a pain.001",
+			format:        domain.TransferImportPain001,
+			content:       `<Document><BkToCstmrStmt/></Document>`,
+			expectedError: ErrInvalidPain001,
+		},
+		{
+			name:          "Unknown format",
+			format:        "xlsx",
+			content:       "",
+			expectedError: ErrUnknownFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(tt.format, []byte(tt.content), validator)
+			if err != tt.expectedError {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if len(got) != len(tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%+v' | Expected: '%+v'", tt.name, got, tt.expected)
+				return
+			}
+
+			for i, l := range got {
+				var result = line{number: l.Number, amount: l.Transfer.Amount, invalid: len(l.Errors) > 0}
+				if result != tt.expected[i] {
+					t.Errorf("[TestCase '%s'] Result: '%+v' | Expected: '%+v'", tt.name, result, tt.expected[i])
+				}
+			}
+		})
+	}
+}
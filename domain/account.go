@@ -13,7 +13,11 @@ var (
 
 	ErrAccountDestinationNotFound = errors.New("account destination not found")
 
+	ErrFeeAccountNotFound = errors.New("fee account not found")
+
 	ErrInsufficientBalance = errors.New("origin account does not have sufficient balance")
+
+	ErrNonPositiveAmount = errors.New("amount must be greater than zero")
 )
 
 type AccountID string
@@ -26,8 +30,10 @@ type (
 	AccountRepository interface {
 		Create(context.Context, Account) (Account, error)
 		UpdateBalance(context.Context, AccountID, Money) error
-		FindAll(context.Context) ([]Account, error)
+		// FindAll returns the page of the accounts, oldest first
+		FindAll(context.Context, PageRequest) ([]Account, error)
 		FindByID(context.Context, AccountID) (Account, error)
+		FindByCPF(context.Context, string) (Account, error)
 		FindBalance(context.Context, AccountID) (Account, error)
 	}
 
@@ -50,11 +56,26 @@ func NewAccount(ID AccountID, name, CPF string, balance Money, createdAt time.Ti
 	}
 }
 
-func (a *Account) Deposit(amount Money) {
-	a.balance += amount
+func (a *Account) Deposit(amount Money) error {
+	if amount <= 0 {
+		return ErrNonPositiveAmount
+	}
+
+	balance, err := a.balance.Add(amount)
+	if err != nil {
+		return err
+	}
+
+	a.balance = balance
+
+	return nil
 }
 
 func (a *Account) Withdraw(amount Money) error {
+	if amount <= 0 {
+		return ErrNonPositiveAmount
+	}
+
 	if a.balance < amount {
 		return ErrInsufficientBalance
 	}
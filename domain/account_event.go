@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrAccountVersionConflict = errors.New("account was changed by another writer")
+
+	ErrAccountEventOutOfOrder = errors.New("account event is out of order")
+
+	ErrUnknownAccountEvent = errors.New("unknown account event")
+)
+
+// AccountEventType is a change recorded in the event stream of an account. Accounts have no status yet, so opening
+// and balance moves are the only changes
+type AccountEventType string
+
+const (
+	AccountOpened    AccountEventType = "opened"
+	AccountDeposited AccountEventType = "deposited"
+	AccountWithdrew  AccountEventType = "withdrew"
+)
+
+func (a AccountEventType) String() string {
+	return string(a)
+}
+
+// AccountEvent is an entry of the event stream of an account, the state of which is the replay of its entries. The
+// version is its position in the stream, starting at 1, and two writers appending the same one conflict
+type AccountEvent struct {
+	accountID  AccountID
+	version    int64
+	kind       AccountEventType
+	name       string
+	cpf        string
+	amount     Money
+	occurredAt time.Time
+}
+
+// NewAccountEvent restores an entry of the stream. Name and CPF are only set when opening, the amount is the opening
+// balance or the money moved
+func NewAccountEvent(
+	accountID AccountID,
+	version int64,
+	kind AccountEventType,
+	name string,
+	CPF string,
+	amount Money,
+	occurredAt time.Time,
+) AccountEvent {
+	return AccountEvent{
+		accountID:  accountID,
+		version:    version,
+		kind:       kind,
+		name:       name,
+		cpf:        CPF,
+		amount:     amount,
+		occurredAt: occurredAt,
+	}
+}
+
+// NewAccountOpenedEvent starts the stream of the account
+func NewAccountOpenedEvent(account Account) AccountEvent {
+	return NewAccountEvent(
+		account.ID(),
+		1,
+		AccountOpened,
+		account.Name(),
+		account.CPF(),
+		account.Balance(),
+		account.CreatedAt(),
+	)
+}
+
+// NewAccountBalanceEvent records the move taking the account to the balance, as the entry after version. It reports
+// false when the balance does not change
+func NewAccountBalanceEvent(account Account, version int64, balance Money, at time.Time) (AccountEvent, bool, error) {
+	if balance == account.Balance() {
+		return AccountEvent{}, false, nil
+	}
+
+	var kind = AccountDeposited
+	delta, err := balance.Sub(account.Balance())
+	if err != nil {
+		return AccountEvent{}, false, err
+	}
+
+	if delta < 0 {
+		kind = AccountWithdrew
+		if delta, err = account.Balance().Sub(balance); err != nil {
+			return AccountEvent{}, false, err
+		}
+	}
+
+	return NewAccountEvent(account.ID(), version+1, kind, "", "", delta, at), true, nil
+}
+
+// ReplayAccount applies the entries, in order, to the state at version, which is a zero Account at version 0
+func ReplayAccount(account Account, version int64, events []AccountEvent) (Account, int64, error) {
+	for _, e := range events {
+		if e.version != version+1 {
+			return Account{}, version, ErrAccountEventOutOfOrder
+		}
+
+		var err error
+		switch e.kind {
+		case AccountOpened:
+			account = NewAccount(e.accountID, e.name, e.cpf, e.amount, e.occurredAt)
+		case AccountDeposited:
+			account.balance, err = account.balance.Add(e.amount)
+		case AccountWithdrew:
+			account.balance, err = account.balance.Sub(e.amount)
+		default:
+			err = ErrUnknownAccountEvent
+		}
+
+		if err != nil {
+			return Account{}, version, err
+		}
+
+		version = e.version
+	}
+
+	return account, version, nil
+}
+
+func (a AccountEvent) AccountID() AccountID {
+	return a.accountID
+}
+
+func (a AccountEvent) Version() int64 {
+	return a.version
+}
+
+func (a AccountEvent) Kind() AccountEventType {
+	return a.kind
+}
+
+func (a AccountEvent) Name() string {
+	return a.name
+}
+
+func (a AccountEvent) CPF() string {
+	return a.cpf
+}
+
+// Amount is the opening balance of AccountOpened, and the money moved, always positive, of the other entries
+func (a AccountEvent) Amount() Money {
+	return a.amount
+}
+
+func (a AccountEvent) OccurredAt() time.Time {
+	return a.occurredAt
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReplayAccount(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now      = time.Now()
+		ID       = AccountID("3c096a40-ccba-4b58-93ed-57379ab04680")
+		opened   = NewAccountEvent(ID, 1, AccountOpened, "Test", "02815517078", 10000, now)
+		deposit  = NewAccountEvent(ID, 2, AccountDeposited, "", "", 2550, now)
+		withdraw = NewAccountEvent(ID, 3, AccountWithdrew, "", "", 12550, now)
+	)
+
+	tests := []struct {
+		name            string
+		account         Account
+		version         int64
+		events          []AccountEvent
+		expectedBalance Money
+		expectedVersion int64
+		expectedErr     error
+	}{
+		{
+			name:            "Replay the whole stream",
+			events:          []AccountEvent{opened, deposit, withdraw},
+			expectedBalance: 0,
+			expectedVersion: 3,
+		},
+		{
+			name:            "Replay from a snapshot",
+			account:         NewAccount(ID, "Test", "02815517078", 12550, now),
+			version:         2,
+			events:          []AccountEvent{withdraw},
+			expectedBalance: 0,
+			expectedVersion: 3,
+		},
+		{
+			name:            "Replay nothing past the snapshot",
+			account:         NewAccount(ID, "Test", "02815517078", 12550, now),
+			version:         2,
+			expectedBalance: 12550,
+			expectedVersion: 2,
+		},
+		{
+			name:        "Gap in the stream",
+			events:      []AccountEvent{opened, withdraw},
+			expectedErr: ErrAccountEventOutOfOrder,
+		},
+		{
+			name:        "Unknown entry",
+			events:      []AccountEvent{NewAccountEvent(ID, 1, "closed", "", "", 0, now)},
+			expectedErr: ErrUnknownAccountEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, version, err := ReplayAccount(tt.account, tt.version, tt.events)
+			if err != tt.expectedErr {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if account.Balance() != tt.expectedBalance || version != tt.expectedVersion {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' '%v' | Expected: '%v' '%v'",
+					tt.name,
+					account.Balance(),
+					version,
+					tt.expectedBalance,
+					tt.expectedVersion,
+				)
+			}
+
+			if account.ID() != ID || account.CPF() != "02815517078" {
+				t.Errorf("[TestCase '%s'] Account: '%v' '%v'", tt.name, account.ID(), account.CPF())
+			}
+		})
+	}
+}
+
+func TestNewAccountBalanceEvent(t *testing.T) {
+	t.Parallel()
+
+	var account = NewAccount("3c096a40-ccba-4b58-93ed-57379ab04680", "Test", "02815517078", 10000, time.Now())
+
+	tests := []struct {
+		name            string
+		balance         Money
+		expectedKind    AccountEventType
+		expectedAmount  Money
+		expectedChanged bool
+	}{
+		{
+			name:            "Higher balance is a deposit",
+			balance:         15000,
+			expectedKind:    AccountDeposited,
+			expectedAmount:  5000,
+			expectedChanged: true,
+		},
+		{
+			name:            "Lower balance is a withdrawal",
+			balance:         2500,
+			expectedKind:    AccountWithdrew,
+			expectedAmount:  7500,
+			expectedChanged: true,
+		},
+		{
+			name:    "Same balance is no change",
+			balance: 10000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, changed, err := NewAccountBalanceEvent(account, 4, tt.balance, time.Now())
+			if err != nil {
+				t.Fatalf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, nil)
+			}
+
+			if changed != tt.expectedChanged || event.Kind() != tt.expectedKind || event.Amount() != tt.expectedAmount {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' '%v' '%v' | Expected: '%v' '%v' '%v'",
+					tt.name,
+					changed,
+					event.Kind(),
+					event.Amount(),
+					tt.expectedChanged,
+					tt.expectedKind,
+					tt.expectedAmount,
+				)
+			}
+
+			if changed && event.Version() != 5 {
+				t.Errorf("[TestCase '%s'] Version: '%v' | Expected: '%v'", tt.name, event.Version(), 5)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"math"
 	"testing"
 )
 
@@ -12,10 +13,11 @@ func TestAccount_Deposit(t *testing.T) {
 	}
 
 	tests := []struct {
-		name     string
-		account  Account
-		args     args
-		expected Money
+		name        string
+		account     Account
+		args        args
+		expected    Money
+		expectedErr error
 	}{
 		{
 			name: "Successful depositing balance",
@@ -41,11 +43,45 @@ func TestAccount_Deposit(t *testing.T) {
 			account:  NewAccountBalance(98),
 			expected: 4596,
 		},
+		{
+			name: "error when depositing a zero amount",
+			args: args{
+				amount: 0,
+			},
+			account:     NewAccountBalance(98),
+			expected:    98,
+			expectedErr: ErrNonPositiveAmount,
+		},
+		{
+			name: "error when depositing a negative amount",
+			args: args{
+				amount: -10,
+			},
+			account:     NewAccountBalance(98),
+			expected:    98,
+			expectedErr: ErrNonPositiveAmount,
+		},
+		{
+			name: "error when depositing overflows the balance",
+			args: args{
+				amount: 1,
+			},
+			account:     NewAccountBalance(math.MaxInt64),
+			expected:    math.MaxInt64,
+			expectedErr: ErrMoneyOverflow,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.account.Deposit(tt.args.amount)
+			if err := tt.account.Deposit(tt.args.amount); err != tt.expectedErr {
+				t.Errorf("[TestCase '%s'] ResultError: '%v' | ExpectedError: '%v'",
+					tt.name,
+					err,
+					tt.expectedErr,
+				)
+				return
+			}
 
 			if tt.account.Balance() != tt.expected {
 				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'",
@@ -120,6 +156,14 @@ func TestAccount_Withdraw(t *testing.T) {
 			account:     NewAccountBalance(0),
 			expectedErr: ErrInsufficientBalance,
 		},
+		{
+			name: "error when withdrawing a negative amount",
+			args: args{
+				amount: -10,
+			},
+			account:     NewAccountBalance(10),
+			expectedErr: ErrNonPositiveAmount,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAPIKey_Active(t *testing.T) {
+	t.Parallel()
+
+	var now = time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		apiKey   APIKey
+		expected bool
+	}{
+		{
+			name:     "Active without expiration",
+			apiKey:   NewAPIKey("id", "client", "erp", "prefix", "hash", nil, time.Time{}, time.Time{}, time.Time{}, now),
+			expected: true,
+		},
+		{
+			name:     "Active before expiration",
+			apiKey:   NewAPIKey("id", "client", "erp", "prefix", "hash", nil, now.Add(time.Minute), time.Time{}, time.Time{}, now),
+			expected: true,
+		},
+		{
+			name:     "Inactive after expiration",
+			apiKey:   NewAPIKey("id", "client", "erp", "prefix", "hash", nil, now.Add(-time.Minute), time.Time{}, time.Time{}, now),
+			expected: false,
+		},
+		{
+			name:     "Inactive when revoked",
+			apiKey:   NewAPIKey("id", "client", "erp", "prefix", "hash", nil, time.Time{}, time.Time{}, now.Add(-time.Minute), now),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.apiKey.Active(now); got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAPIKey_ExpireBy(t *testing.T) {
+	t.Parallel()
+
+	var now = time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		deadline  time.Time
+		expected  time.Time
+	}{
+		{
+			name:      "Sets expiration on key that never expires",
+			expiresAt: time.Time{},
+			deadline:  now.Add(time.Hour),
+			expected:  now.Add(time.Hour),
+		},
+		{
+			name:      "Shortens a later expiration",
+			expiresAt: now.Add(48 * time.Hour),
+			deadline:  now.Add(time.Hour),
+			expected:  now.Add(time.Hour),
+		},
+		{
+			name:      "Never extends an earlier expiration",
+			expiresAt: now.Add(time.Minute),
+			deadline:  now.Add(time.Hour),
+			expected:  now.Add(time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var apiKey = NewAPIKey("id", "client", "erp", "prefix", "hash", nil, tt.expiresAt, time.Time{}, time.Time{}, now)
+
+			apiKey.ExpireBy(tt.deadline)
+
+			if !apiKey.ExpiresAt().Equal(tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, apiKey.ExpiresAt(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateAPIKey(t *testing.T) {
+	t.Parallel()
+
+	key, prefix, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("unexpected error: '%v'", err)
+	}
+
+	if len(prefix) != 8 || key[:9] != prefix+"." {
+		t.Errorf("Result: key '%v' does not start with prefix '%v'", key, prefix)
+	}
+
+	if HashAPIKey(key) == HashAPIKey(key+"x") {
+		t.Errorf("expected distinct hashes")
+	}
+}
@@ -0,0 +1,248 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// AuditOutcome is how a call to a mutating use case ended
+type AuditOutcome string
+
+const (
+	AuditSucceeded AuditOutcome = "succeeded"
+	// AuditDenied calls were refused to the principal
+	AuditDenied AuditOutcome = "denied"
+	AuditFailed AuditOutcome = "failed"
+)
+
+func (a AuditOutcome) String() string {
+	return string(a)
+}
+
+// NewAuditOutcome tells the outcome of a call from the error it returned
+func NewAuditOutcome(err error) AuditOutcome {
+	switch err {
+	case nil:
+		return AuditSucceeded
+	case ErrForbidden:
+		return AuditDenied
+	default:
+		return AuditFailed
+	}
+}
+
+type (
+	// AuditRepository is append-only: records are never changed nor removed
+	AuditRepository interface {
+		Append(context.Context, AuditRecord) error
+		// Find returns up to the filter limit of the records matching it, newest first
+		Find(context.Context, AuditFilter) ([]AuditRecord, error)
+	}
+
+	// AuditAlerter is told of the records that could not be appended, so the gap they leave in the trail is followed
+	// up instead of failing a call whose change may already be committed
+	AuditAlerter interface {
+		Alert(context.Context, AuditRecord, error)
+	}
+
+	// AuditFilter narrows a query of the audit trail. Zero fields match every record
+	AuditFilter struct {
+		Actor string
+		// Target matches records having it among their targets
+		Target string
+		From   time.Time
+		To     time.Time
+		Limit  int
+	}
+
+	// AuditRecord tells who called a mutating use case, on what, with which input and how it ended
+	AuditRecord struct {
+		id         string
+		actor      string
+		role       Role
+		action     string
+		targets    []string
+		inputHash  string
+		payload    string
+		outcome    AuditOutcome
+		errMessage string
+		requestID  string
+		occurredAt time.Time
+	}
+)
+
+// NewAuditRecord restores a record. The payload is the input of the call as JSON, with its CPFs masked
+func NewAuditRecord(
+	ID string,
+	actor string,
+	role Role,
+	action string,
+	targets []string,
+	inputHash string,
+	payload string,
+	outcome AuditOutcome,
+	errMessage string,
+	requestID string,
+	occurredAt time.Time,
+) AuditRecord {
+	return AuditRecord{
+		id:         ID,
+		actor:      actor,
+		role:       role,
+		action:     action,
+		targets:    targets,
+		inputHash:  inputHash,
+		payload:    payload,
+		outcome:    outcome,
+		errMessage: errMessage,
+		requestID:  requestID,
+		occurredAt: occurredAt,
+	}
+}
+
+func (a AuditRecord) ID() string {
+	return a.id
+}
+
+// Actor is the subject of the principal that made the call
+func (a AuditRecord) Actor() string {
+	return a.actor
+}
+
+func (a AuditRecord) Role() Role {
+	return a.role
+}
+
+// Action is the operation of the use case called
+func (a AuditRecord) Action() string {
+	return a.action
+}
+
+// Targets are the IDs of the resources the call was about, those it created included
+func (a AuditRecord) Targets() []string {
+	return a.targets
+}
+
+// InputHash is the hex SHA-256 of the input as JSON, before masking
+func (a AuditRecord) InputHash() string {
+	return a.inputHash
+}
+
+func (a AuditRecord) Payload() string {
+	return a.payload
+}
+
+func (a AuditRecord) Outcome() AuditOutcome {
+	return a.outcome
+}
+
+// ErrorMessage is the message of the error a failed call returned
+func (a AuditRecord) ErrorMessage() string {
+	return a.errMessage
+}
+
+func (a AuditRecord) RequestID() string {
+	return a.requestID
+}
+
+func (a AuditRecord) OccurredAt() time.Time {
+	return a.occurredAt
+}
+
+// HashAuditInput is the hex SHA-256 of the input
+func HashAuditInput(input []byte) string {
+	var sum = sha256.Sum256(input)
+	return hex.EncodeToString(sum[:])
+}
+
+const redacted = "[REDACTED]"
+
+var (
+	// cpfPattern finds CPFs, formatted or not, within any text
+	cpfPattern = regexp.MustCompile(`\b\d{3}\.?\d{3}\.?\d{3}-?\d{2}\b`)
+
+	// secretFields are never kept, not even masked
+	secretFields = map[string]bool{"secret": true, "password": true, "token": true}
+)
+
+// MaskCPF keeps the middle six digits of a CPF, the part receipts usually show
+func MaskCPF(CPF string) string {
+	var digits = strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, CPF)
+
+	if len(digits) != 11 {
+		return strings.Repeat("*", len(CPF))
+	}
+
+	return "***." + digits[3:6] + "." + digits[6:9] + "-**"
+}
+
+// MaskAuditPayload masks the CPFs in a JSON input, whether in a cpf field or within any other text, and redacts its
+// secrets. Input that is not JSON is masked as text
+func MaskAuditPayload(input []byte) string {
+	var (
+		value   interface{}
+		decoder = json.NewDecoder(bytes.NewReader(input))
+	)
+
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return cpfPattern.ReplaceAllStringFunc(string(input), MaskCPF)
+	}
+
+	masked, err := json.Marshal(maskAuditValue("", value))
+	if err != nil {
+		return redacted
+	}
+
+	return string(masked)
+}
+
+func maskAuditValue(field string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = maskAuditValue(strings.ToLower(k), e)
+		}
+		return v
+	case []interface{}:
+		for i, e := range v {
+			v[i] = maskAuditValue(field, e)
+		}
+		return v
+	case string:
+		switch {
+		case secretFields[field]:
+			return redacted
+		case field == "cpf":
+			return MaskCPF(v)
+		default:
+			return cpfPattern.ReplaceAllStringFunc(v, MaskCPF)
+		}
+	default:
+		return v
+	}
+}
+
+type requestIDContextKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the ID of the request being served
+func ContextWithRequestID(ctx context.Context, ID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, ID)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, empty when there is none
+func RequestIDFromContext(ctx context.Context) string {
+	ID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return ID
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMaskCPF(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cpf      string
+		expected string
+	}{
+		{
+			name:     "Digits only",
+			cpf:      "02815517078",
+			expected: "***.155.170-**",
+		},
+		{
+			name:     "Formatted",
+			cpf:      "028.155.170-78",
+			expected: "***.155.170-**",
+		},
+		{
+			name:     "Not a CPF is masked whole",
+			cpf:      "12345",
+			expected: "*****",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := MaskCPF(tt.cpf); got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMaskAuditPayload(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "CPF field",
+			input:    `{"balance":100,"cpf":"02815517078","name":"Test"}`,
+			expected: `{"balance":100,"cpf":"***.155.170-**","name":"Test"}`,
+		},
+		{
+			name:     "CPF within text",
+			input:    `{"reason":"holder 028.155.170-78 asked to cancel"}`,
+			expected: `{"reason":"holder ***.155.170-** asked to cancel"}`,
+		},
+		{
+			name:     "Secret redacted",
+			input:    `{"events":["transfer.completed"],"secret":"0123456789abcdef","url":"https://example.com"}`,
+			expected: `{"events":["transfer.completed"],"secret":"[REDACTED]","url":"https://example.com"}`,
+		},
+		{
+			name:     "IDs are kept",
+			input:    `"3c096a40-ccba-4b58-93ed-57379ab04680"`,
+			expected: `"3c096a40-ccba-4b58-93ed-57379ab04680"`,
+		},
+		{
+			name:     "Large numbers are kept as sent",
+			input:    `{"balance":9007199254740993}`,
+			expected: `{"balance":9007199254740993}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := MaskAuditPayload([]byte(tt.input)); got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewAuditOutcome(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected AuditOutcome
+	}{
+		{name: "Success", expected: AuditSucceeded},
+		{name: "Forbidden", err: ErrForbidden, expected: AuditDenied},
+		{name: "Other error", err: errors.New("error"), expected: AuditFailed},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := NewAuditOutcome(tt.err); got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "context"
+
+type readYourWritesContextKey struct{}
+
+// ContextWithReadYourWrites returns a copy of ctx whose reads must see the writes made before them, so they are served
+// by the primary database instead of a replica lagging behind it
+func ContextWithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesContextKey{}, true)
+}
+
+// ReadYourWritesFromContext reports whether the reads of ctx must see the writes made before them
+func ReadYourWritesFromContext(ctx context.Context) bool {
+	readYourWrites, _ := ctx.Value(readYourWritesContextKey{}).(bool)
+	return readYourWrites
+}
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrDailyBalanceNotFound = errors.New("daily balance not found")
+
+type (
+	// DailyBalanceRepository keeps one snapshot per account and day
+	DailyBalanceRepository interface {
+		// Save writes the snapshots, replacing the ones of the same account and day
+		Save(context.Context, ...DailyBalance) error
+		// FindByAccountID returns the snapshots of the account between both days, included, oldest first
+		FindByAccountID(context.Context, AccountID, time.Time, time.Time) ([]DailyBalance, error)
+		// FindLatest returns the snapshot of the last day closed for the account
+		FindLatest(context.Context, AccountID) (DailyBalance, error)
+	}
+
+	// DailyActivityRepository sums the completed transfers of an account by the day they were made on
+	DailyActivityRepository interface {
+		// FindActivity returns the days between both, included, that had transfers, oldest first
+		FindActivity(context.Context, AccountID, time.Time, time.Time) ([]DailyActivity, error)
+	}
+
+	// DailyActivity is the money an account received and sent on a day
+	DailyActivity struct {
+		day              time.Time
+		credits          Money
+		debits           Money
+		transactionCount int
+	}
+
+	// DailyBalance is the balance an account closed a day with, and the money that moved it on that day
+	DailyBalance struct {
+		accountID        AccountID
+		day              time.Time
+		closingBalance   Money
+		credits          Money
+		debits           Money
+		transactionCount int
+		interest         Money
+	}
+)
+
+// StartOfDay is the midnight, in UTC, of the day of t. Days are closed in UTC
+func StartOfDay(t time.Time) time.Time {
+	var y, m, d = t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func NewDailyActivity(day time.Time, credits, debits Money, transactionCount int) DailyActivity {
+	return DailyActivity{
+		day:              StartOfDay(day),
+		credits:          credits,
+		debits:           debits,
+		transactionCount: transactionCount,
+	}
+}
+
+func (d DailyActivity) Day() time.Time {
+	return d.day
+}
+
+func (d DailyActivity) Credits() Money {
+	return d.credits
+}
+
+func (d DailyActivity) Debits() Money {
+	return d.debits
+}
+
+func (d DailyActivity) TransactionCount() int {
+	return d.transactionCount
+}
+
+func NewDailyBalance(
+	accountID AccountID,
+	day time.Time,
+	closingBalance Money,
+	credits Money,
+	debits Money,
+	transactionCount int,
+) DailyBalance {
+	return DailyBalance{
+		accountID:        accountID,
+		day:              StartOfDay(day),
+		closingBalance:   closingBalance,
+		credits:          credits,
+		debits:           debits,
+		transactionCount: transactionCount,
+	}
+}
+
+// CloseDays takes the balance the account had before from and closes every day up to to, both included, days without
+// activity keeping the balance of the day before
+func CloseDays(
+	accountID AccountID,
+	from time.Time,
+	to time.Time,
+	balance Money,
+	activity []DailyActivity,
+) ([]DailyBalance, error) {
+	var byDay = make(map[time.Time]DailyActivity, len(activity))
+	for _, a := range activity {
+		byDay[a.Day()] = a
+	}
+
+	var days = make([]DailyBalance, 0)
+	for day := StartOfDay(from); !day.After(StartOfDay(to)); day = day.AddDate(0, 0, 1) {
+		var (
+			a   = byDay[day]
+			err error
+		)
+
+		if balance, err = balance.Add(a.Credits()); err != nil {
+			return nil, err
+		}
+
+		if balance, err = balance.Sub(a.Debits()); err != nil {
+			return nil, err
+		}
+
+		days = append(days, NewDailyBalance(accountID, day, balance, a.Credits(), a.Debits(), a.TransactionCount()))
+	}
+
+	return days, nil
+}
+
+func (d DailyBalance) AccountID() AccountID {
+	return d.accountID
+}
+
+func (d DailyBalance) Day() time.Time {
+	return d.day
+}
+
+func (d DailyBalance) ClosingBalance() Money {
+	return d.closingBalance
+}
+
+func (d DailyBalance) Credits() Money {
+	return d.credits
+}
+
+func (d DailyBalance) Debits() Money {
+	return d.debits
+}
+
+func (d DailyBalance) TransactionCount() int {
+	return d.transactionCount
+}
+
+// Interest is what the closing balance accrued over the day, once AccrueInterest worked it out
+func (d DailyBalance) Interest() Money {
+	return d.interest
+}
+
+// AccrueInterest returns the snapshot with the simple interest its closing balance earns over its day, at an annual
+// rate in basis points rounded by mode
+func (d DailyBalance) AccrueInterest(annualBasisPoints int64, mode RoundingMode) (DailyBalance, error) {
+	interest, err := d.closingBalance.Interest(annualBasisPoints, 1, mode)
+	if err != nil {
+		return DailyBalance{}, err
+	}
+
+	d.interest = interest
+	return d, nil
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStartOfDay(t *testing.T) {
+	t.Parallel()
+
+	var saoPaulo = time.FixedZone("BRT", -3*60*60)
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		expected time.Time
+	}{
+		{
+			name:     "Start of a UTC day",
+			t:        time.Date(2021, 1, 1, 15, 30, 0, 0, time.UTC),
+			expected: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Start of the UTC day of a local time",
+			t:        time.Date(2021, 1, 1, 22, 0, 0, 0, saoPaulo),
+			expected: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StartOfDay(tt.t); !got.Equal(tt.expected) || got.Location() != time.UTC {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCloseDays(t *testing.T) {
+	t.Parallel()
+
+	const accountID = AccountID("3c096a40-ccba-4b58-93ed-57379ab04680")
+
+	var (
+		day1 = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		day2 = day1.AddDate(0, 0, 1)
+		day3 = day1.AddDate(0, 0, 2)
+	)
+
+	tests := []struct {
+		name          string
+		from          time.Time
+		to            time.Time
+		balance       Money
+		activity      []DailyActivity
+		expected      []DailyBalance
+		expectedError error
+	}{
+		{
+			name:    "Days without activity keep the balance",
+			from:    day1,
+			to:      day3,
+			balance: 1000,
+			activity: []DailyActivity{
+				NewDailyActivity(day2.Add(5*time.Hour), 500, 200, 3),
+			},
+			expected: []DailyBalance{
+				NewDailyBalance(accountID, day1, 1000, 0, 0, 0),
+				NewDailyBalance(accountID, day2, 1300, 500, 200, 3),
+				NewDailyBalance(accountID, day3, 1300, 0, 0, 0),
+			},
+		},
+		{
+			name:     "No day when from is after to",
+			from:     day2,
+			to:       day1,
+			balance:  1000,
+			expected: []DailyBalance{},
+		},
+		{
+			name:    "Closing balance overflows",
+			from:    day1,
+			to:      day1,
+			balance: math.MaxInt64,
+			activity: []DailyActivity{
+				NewDailyActivity(day1, 1, 0, 1),
+			},
+			expectedError: ErrMoneyOverflow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CloseDays(accountID, tt.from, tt.to, tt.balance, tt.activity)
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if err == nil && !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%+v' | Expected: '%+v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
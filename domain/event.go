@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// EventName identifies what happened, and with the version, the shape of the event data
+type EventName string
+
+const (
+	EventAccountCreated    EventName = "account.created"
+	EventTransferCompleted EventName = "transfer.completed"
+	EventTransferFailed    EventName = "transfer.failed"
+)
+
+func (e EventName) String() string {
+	return string(e)
+}
+
+// EventSchemaVersion is the version of the event data raised by this build. Fields may be added within a version;
+// renaming or removing one needs a new version, so consumers can tell both shapes apart
+const EventSchemaVersion = 1
+
+type (
+	// EventPublisher delivers domain events once the change that raised them is committed. An error means some
+	// subscriber may not have received them, and they are published again
+	EventPublisher interface {
+		Publish(context.Context, ...Event) error
+	}
+
+	// Event is something that happened to an aggregate, with its data already encoded as JSON
+	Event struct {
+		id          string
+		name        EventName
+		version     int
+		aggregateID string
+		data        json.RawMessage
+		occurredAt  time.Time
+	}
+
+	// AccountCreatedData is the data of EventAccountCreated. The CPF is left out, consumers read it from the account
+	AccountCreatedData struct {
+		AccountID string    `json:"account_id"`
+		Name      string    `json:"name"`
+		Balance   string    `json:"balance"`
+		Currency  string    `json:"currency"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	// TransferData is the data of the transfer events. A completed transfer carries the balance the origin was left
+	// with, which events recorded before it was added lack
+	TransferData struct {
+		TransferID           string    `json:"transfer_id"`
+		AccountOriginID      string    `json:"account_origin_id"`
+		AccountDestinationID string    `json:"account_destination_id"`
+		Amount               string    `json:"amount"`
+		Currency             string    `json:"currency"`
+		Status               string    `json:"status"`
+		FailureCode          string    `json:"failure_code,omitempty"`
+		AccountOriginBalance string    `json:"account_origin_balance,omitempty"`
+		CreatedAt            time.Time `json:"created_at"`
+	}
+)
+
+// NewEvent restores an event, such as one read back from storage
+func NewEvent(
+	ID string,
+	name EventName,
+	version int,
+	aggregateID string,
+	data json.RawMessage,
+	occurredAt time.Time,
+) Event {
+	return Event{
+		id:          ID,
+		name:        name,
+		version:     version,
+		aggregateID: aggregateID,
+		data:        data,
+		occurredAt:  occurredAt,
+	}
+}
+
+// NewAccountCreatedEvent is raised when an account is opened
+func NewAccountCreatedEvent(account Account) Event {
+	return newEvent(EventAccountCreated, account.ID().String(), AccountCreatedData{
+		AccountID: account.ID().String(),
+		Name:      account.Name(),
+		Balance:   account.Balance().String(),
+		Currency:  CurrencyBRL.String(),
+		CreatedAt: account.CreatedAt(),
+	})
+}
+
+// NewTransferCompletedEvent is raised when the money of a transfer moved, leaving the origin with the balance given
+func NewTransferCompletedEvent(transfer Transfer, originBalance Money) Event {
+	var data = transferData(transfer)
+	data.AccountOriginBalance = originBalance.String()
+
+	return newEvent(EventTransferCompleted, transfer.ID().String(), data)
+}
+
+// NewTransferFailedEvent is raised when a transfer attempt is kept as failed
+func NewTransferFailedEvent(transfer Transfer) Event {
+	return newEvent(EventTransferFailed, transfer.ID().String(), transferData(transfer))
+}
+
+func newEvent(name EventName, aggregateID string, data interface{}) Event {
+	// The data types above only hold strings and times, which always encode
+	raw, _ := json.Marshal(data)
+
+	return NewEvent(NewUUID(), name, EventSchemaVersion, aggregateID, raw, time.Now())
+}
+
+func transferData(transfer Transfer) TransferData {
+	return TransferData{
+		TransferID:           transfer.ID().String(),
+		AccountOriginID:      transfer.AccountOriginID().String(),
+		AccountDestinationID: transfer.AccountDestinationID().String(),
+		Amount:               transfer.Amount().String(),
+		Currency:             CurrencyBRL.String(),
+		Status:               transfer.Status().String(),
+		FailureCode:          transfer.FailureCode().String(),
+		CreatedAt:            transfer.CreatedAt(),
+	}
+}
+
+// MarshalJSON encodes the event in the envelope handed to consumers
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		ID          string          `json:"id"`
+		Name        EventName       `json:"name"`
+		Version     int             `json:"version"`
+		AggregateID string          `json:"aggregate_id"`
+		OccurredAt  time.Time       `json:"occurred_at"`
+		Data        json.RawMessage `json:"data"`
+	}{
+		ID:          e.id,
+		Name:        e.name,
+		Version:     e.version,
+		AggregateID: e.aggregateID,
+		OccurredAt:  e.occurredAt,
+		Data:        e.data,
+	})
+}
+
+func (e Event) ID() string {
+	return e.id
+}
+
+func (e Event) Name() EventName {
+	return e.name
+}
+
+func (e Event) Version() int {
+	return e.version
+}
+
+func (e Event) AggregateID() string {
+	return e.aggregateID
+}
+
+func (e Event) Data() json.RawMessage {
+	return e.data
+}
+
+func (e Event) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// AccountIDs are the accounts the event happened to: the account opened, or the origin and destination of a transfer.
+// Events whose data cannot be read concern no account
+func (e Event) AccountIDs() []AccountID {
+	switch e.name {
+	case EventAccountCreated:
+		var data AccountCreatedData
+		if err := json.Unmarshal(e.data, &data); err != nil || data.AccountID == "" {
+			return []AccountID{}
+		}
+
+		return []AccountID{AccountID(data.AccountID)}
+	case EventTransferCompleted, EventTransferFailed:
+		var data TransferData
+		if err := json.Unmarshal(e.data, &data); err != nil {
+			return []AccountID{}
+		}
+
+		var IDs = make([]AccountID, 0, 2)
+		for _, ID := range []string{data.AccountOriginID, data.AccountDestinationID} {
+			if ID != "" {
+				IDs = append(IDs, AccountID(ID))
+			}
+		}
+
+		return IDs
+	default:
+		return []AccountID{}
+	}
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEvent_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var transfer, _ = NewTransfer(
+		"3c096a40-ccba-4b58-93ed-57379ab04680",
+		"3c096a40-ccba-4b58-93ed-57379ab04681",
+		"3c096a40-ccba-4b58-93ed-57379ab04682",
+		2999,
+		TransferStatusPending,
+		time.Time{},
+	).Fail(TransferFailureInsufficientBalance)
+
+	tests := []struct {
+		name         string
+		event        Event
+		expectedName EventName
+		expectedData string
+	}{
+		{
+			name:         "Account created",
+			event:        NewAccountCreatedEvent(NewAccount("3c096a40-ccba-4b58-93ed-57379ab04681", "Test", "02815517078", 10050, time.Time{})),
+			expectedName: EventAccountCreated,
+			expectedData: `{"account_id":"3c096a40-ccba-4b58-93ed-57379ab04681","name":"Test","balance":"100.50","currency":"BRL","created_at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:         "Transfer failed",
+			event:        NewTransferFailedEvent(transfer),
+			expectedName: EventTransferFailed,
+			expectedData: `{"transfer_id":"3c096a40-ccba-4b58-93ed-57379ab04680","account_origin_id":"3c096a40-ccba-4b58-93ed-57379ab04681","account_destination_id":"3c096a40-ccba-4b58-93ed-57379ab04682","amount":"29.99","currency":"BRL","status":"failed","failure_code":"insufficient_balance","created_at":"0001-01-01T00:00:00Z"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := json.Marshal(tt.event)
+			if err != nil {
+				t.Fatalf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, nil)
+			}
+
+			var envelope struct {
+				ID          string          `json:"id"`
+				Name        EventName       `json:"name"`
+				Version     int             `json:"version"`
+				AggregateID string          `json:"aggregate_id"`
+				Data        json.RawMessage `json:"data"`
+			}
+			if err = json.Unmarshal(raw, &envelope); err != nil {
+				t.Fatalf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, nil)
+			}
+
+			if envelope.ID == "" || envelope.Name != tt.expectedName || envelope.Version != EventSchemaVersion {
+				t.Errorf("[TestCase '%s'] Result: '%s' | Expected: '%v' version '%v'", tt.name, raw, tt.expectedName, EventSchemaVersion)
+			}
+
+			if envelope.AggregateID != tt.event.AggregateID() || string(envelope.Data) != tt.expectedData {
+				t.Errorf("[TestCase '%s'] Result: '%s' | Expected: '%s'", tt.name, envelope.Data, tt.expectedData)
+			}
+		})
+	}
+}
+
+func TestEvent_AccountIDs(t *testing.T) {
+	t.Parallel()
+
+	var transfer = NewTransfer(
+		"3c096a40-ccba-4b58-93ed-57379ab04680",
+		"3c096a40-ccba-4b58-93ed-57379ab04681",
+		"3c096a40-ccba-4b58-93ed-57379ab04682",
+		2999,
+		TransferStatusCompleted,
+		time.Time{},
+	)
+
+	tests := []struct {
+		name     string
+		event    Event
+		expected []AccountID
+	}{
+		{
+			name:     "Account created",
+			event:    NewAccountCreatedEvent(NewAccount("3c096a40-ccba-4b58-93ed-57379ab04681", "Test", "02815517078", 0, time.Time{})),
+			expected: []AccountID{"3c096a40-ccba-4b58-93ed-57379ab04681"},
+		},
+		{
+			name:     "Transfer completed",
+			event:    NewTransferCompletedEvent(transfer, 0),
+			expected: []AccountID{"3c096a40-ccba-4b58-93ed-57379ab04681", "3c096a40-ccba-4b58-93ed-57379ab04682"},
+		},
+		{
+			name:     "Unreadable data",
+			event:    NewEvent("1", EventTransferFailed, EventSchemaVersion, "1", json.RawMessage(`[]`), time.Time{}),
+			expected: []AccountID{},
+		},
+		{
+			name:     "Unknown event",
+			event:    NewEvent("1", "account.closed", EventSchemaVersion, "1", json.RawMessage(`{}`), time.Time{}),
+			expected: []AccountID{},
+		},
+	}
+
+	for _, tt := range tests {
+		if result := tt.event.AccountIDs(); !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+		}
+	}
+}
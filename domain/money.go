@@ -1,7 +1,277 @@
 package domain
 
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidMoney         = errors.New("invalid money amount")
+	ErrMoneyTooManyDecimals = errors.New("money amount has more than 2 decimal places")
+	ErrMoneyOverflow        = errors.New("money amount is too large")
+	ErrInvalidMoneyRatio    = errors.New("invalid money ratio")
+
+	ErrInvalidRoundingMode = errors.New("invalid rounding mode")
+
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+)
+
+// Currency is the ISO 4217 code of the money. Every account holds BRL
+type Currency string
+
+const CurrencyBRL Currency = "BRL"
+
+// ParseCurrency accepts the currencies the accounts can hold
+func ParseCurrency(s string) (Currency, error) {
+	switch c := Currency(strings.ToUpper(s)); c {
+	case CurrencyBRL:
+		return c, nil
+	default:
+		return "", ErrUnsupportedCurrency
+	}
+}
+
+func (c Currency) String() string {
+	return string(c)
+}
+
+// Money is an amount in cents
 type Money int64
 
+// moneyScale is how many cents make a unit of the currency
+const moneyScale = 100
+
+// ParseMoney converts a decimal string such as "1234.56" into cents, rejecting more than 2 decimal places and
+// amounts that do not fit in Money
+func ParseMoney(s string) (Money, error) {
+	var negative = strings.HasPrefix(s, "-")
+	if negative {
+		s = s[1:]
+	}
+
+	var units, cents = s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		units, cents = s[:i], s[i+1:]
+		if cents == "" {
+			return 0, ErrInvalidMoney
+		}
+	}
+
+	if units == "" || !isDigits(units) || !isDigits(cents) {
+		return 0, ErrInvalidMoney
+	}
+
+	if len(cents) > 2 {
+		return 0, ErrMoneyTooManyDecimals
+	}
+	cents += strings.Repeat("0", 2-len(cents))
+
+	u, err := strconv.ParseInt(units, 10, 64)
+	if err != nil {
+		return 0, ErrMoneyOverflow
+	}
+
+	c, _ := strconv.ParseInt(cents, 10, 64)
+	if u > (math.MaxInt64-c)/moneyScale {
+		return 0, ErrMoneyOverflow
+	}
+
+	var m = Money(u*moneyScale + c)
+	if negative {
+		m = -m
+	}
+
+	return m, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String formats the money as an exact decimal with 2 places, such as "1234.56"
+func (m Money) String() string {
+	var (
+		sign  string
+		cents = uint64(m)
+	)
+
+	if m < 0 {
+		sign = "-"
+		cents = uint64(-(m + 1)) + 1
+	}
+
+	var frac = strconv.FormatUint(cents%moneyScale, 10)
+	if len(frac) < 2 {
+		frac = "0" + frac
+	}
+
+	return sign + strconv.FormatUint(cents/moneyScale, 10) + "." + frac
+}
+
+// RoundingMode is how a calculation that falls between two cents picks one of them
+type RoundingMode string
+
+const (
+	// RoundHalfEven picks the nearest cent and, on a tie, the even one. It is the default since it does not drift
+	// over many operations
+	RoundHalfEven RoundingMode = "half_even"
+	// RoundHalfUp picks the nearest cent and, on a tie, the one away from zero
+	RoundHalfUp RoundingMode = "half_up"
+	// RoundDown drops the fraction of a cent
+	RoundDown RoundingMode = "down"
+)
+
+// ParseRoundingMode accepts the rounding modes by name, an empty name meaning RoundHalfEven
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	switch m := RoundingMode(strings.ToLower(s)); m {
+	case "":
+		return RoundHalfEven, nil
+	case RoundHalfEven, RoundHalfUp, RoundDown:
+		return m, nil
+	default:
+		return "", ErrInvalidRoundingMode
+	}
+}
+
+func (r RoundingMode) String() string {
+	return string(r)
+}
+
+// Add returns the sum, or ErrMoneyOverflow when it does not fit in Money
+func (m Money) Add(o Money) (Money, error) {
+	if (o > 0 && m > math.MaxInt64-o) || (o < 0 && m < math.MinInt64-o) {
+		return 0, ErrMoneyOverflow
+	}
+
+	return m + o, nil
+}
+
+// Sub returns the difference, or ErrMoneyOverflow when it does not fit in Money
+func (m Money) Sub(o Money) (Money, error) {
+	if (o < 0 && m > math.MaxInt64+o) || (o > 0 && m < math.MinInt64+o) {
+		return 0, ErrMoneyOverflow
+	}
+
+	return m - o, nil
+}
+
+// MulRatio returns m * num / den rounded to the cent by mode. The product is computed exactly, so only a result
+// that does not fit in Money overflows
+func (m Money) MulRatio(num, den int64, mode RoundingMode) (Money, error) {
+	if den == 0 {
+		return 0, ErrInvalidMoneyRatio
+	}
+
+	var n = new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(num))
+	return roundQuo(n, big.NewInt(den), mode)
+}
+
+// Fee returns the basis points (hundredths of a percent) of the money, rounded by mode
+func (m Money) Fee(basisPoints int64, mode RoundingMode) (Money, error) {
+	return m.MulRatio(basisPoints, 10000, mode)
+}
+
+// Interest returns the simple interest of the money over days at an annual rate in basis points, on a 365 day year
+func (m Money) Interest(annualBasisPoints int64, days int64, mode RoundingMode) (Money, error) {
+	var n = new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(annualBasisPoints))
+	n.Mul(n, big.NewInt(days))
+
+	return roundQuo(n, big.NewInt(10000*365), mode)
+}
+
+// Allocate splits the money in proportion to the ratios, each share rounded by mode. The cents left over or taken in
+// excess by rounding are given back one by one to the shares with a non-zero ratio, in order, so the shares always
+// add up to m
+func (m Money) Allocate(mode RoundingMode, ratios ...int64) ([]Money, error) {
+	var total int64
+	for _, r := range ratios {
+		if r < 0 || total > math.MaxInt64-r {
+			return nil, ErrInvalidMoneyRatio
+		}
+		total += r
+	}
+
+	if total == 0 {
+		return nil, ErrInvalidMoneyRatio
+	}
+
+	var (
+		shares = make([]Money, len(ratios))
+		rest   = m
+		err    error
+	)
+
+	for i, r := range ratios {
+		if shares[i], err = m.MulRatio(r, total, mode); err != nil {
+			return nil, err
+		}
+
+		if rest, err = rest.Sub(shares[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	var step Money = 1
+	if rest < 0 {
+		step = -1
+	}
+
+	for i := 0; rest != 0; i = (i + 1) % len(shares) {
+		if ratios[i] == 0 {
+			continue
+		}
+
+		shares[i] += step
+		rest -= step
+	}
+
+	return shares, nil
+}
+
+// roundQuo divides n by d and rounds the quotient to the cent by mode
+func roundQuo(n, d *big.Int, mode RoundingMode) (Money, error) {
+	if d.Sign() < 0 {
+		n, d = new(big.Int).Neg(n), new(big.Int).Neg(d)
+	}
+
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+
+	if r.Sign() != 0 {
+		// cmp tells whether the dropped fraction is below, at or above half a cent
+		var cmp = new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(d)
+
+		var away bool
+		switch mode {
+		case RoundDown:
+		case RoundHalfUp:
+			away = cmp >= 0
+		case RoundHalfEven:
+			away = cmp > 0 || (cmp == 0 && q.Bit(0) == 1)
+		default:
+			return 0, ErrInvalidRoundingMode
+		}
+
+		if away {
+			q.Add(q, big.NewInt(int64(r.Sign())))
+		}
+	}
+
+	if !q.IsInt64() {
+		return 0, ErrMoneyOverflow
+	}
+
+	return Money(q.Int64()), nil
+}
+
 func (m Money) Float64() float64 {
 	return float64(m) / 100
 }
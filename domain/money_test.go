@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    Money
+		expectedErr error
+	}{
+		{name: "Units and cents", input: "1234.56", expected: 123456},
+		{name: "Units only", input: "10", expected: 1000},
+		{name: "One decimal place", input: "0.5", expected: 50},
+		{name: "Negative amount", input: "-1.05", expected: -105},
+		{name: "Largest amount", input: "92233720368547758.07", expected: math.MaxInt64},
+		{name: "Too many decimal places", input: "1.001", expectedErr: ErrMoneyTooManyDecimals},
+		{name: "Overflow by cents", input: "92233720368547758.08", expectedErr: ErrMoneyOverflow},
+		{name: "Overflow by units", input: "99999999999999999999", expectedErr: ErrMoneyOverflow},
+		{name: "Empty amount", input: "", expectedErr: ErrInvalidMoney},
+		{name: "Missing units", input: ".50", expectedErr: ErrInvalidMoney},
+		{name: "Missing cents", input: "1.", expectedErr: ErrInvalidMoney},
+		{name: "Exponent notation", input: "1e3", expectedErr: ErrInvalidMoney},
+		{name: "Comma separator", input: "1,50", expectedErr: ErrInvalidMoney},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.input)
+			if err != tt.expectedErr {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedErr)
+				return
+			}
+
+			if got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		money    Money
+		expected string
+	}{
+		{name: "Units and cents", money: 123456, expected: "1234.56"},
+		{name: "Cents only", money: 5, expected: "0.05"},
+		{name: "Zero", money: 0, expected: "0.00"},
+		{name: "Negative", money: -105, expected: "-1.05"},
+		{name: "Largest", money: math.MaxInt64, expected: "92233720368547758.07"},
+		{name: "Smallest", money: math.MinInt64, expected: "-92233720368547758.08"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.money.String(); got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMoney_AddSub(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		op          func() (Money, error)
+		expected    Money
+		expectedErr error
+	}{
+		{name: "Add", op: func() (Money, error) { return Money(150).Add(50) }, expected: 200},
+		{name: "Add negative", op: func() (Money, error) { return Money(150).Add(-200) }, expected: -50},
+		{name: "Add overflow", op: func() (Money, error) { return Money(math.MaxInt64).Add(1) }, expectedErr: ErrMoneyOverflow},
+		{name: "Add underflow", op: func() (Money, error) { return Money(math.MinInt64).Add(-1) }, expectedErr: ErrMoneyOverflow},
+		{name: "Sub", op: func() (Money, error) { return Money(150).Sub(50) }, expected: 100},
+		{name: "Sub overflow", op: func() (Money, error) { return Money(math.MaxInt64).Sub(-1) }, expectedErr: ErrMoneyOverflow},
+		{name: "Sub underflow", op: func() (Money, error) { return Money(math.MinInt64).Sub(1) }, expectedErr: ErrMoneyOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op()
+			if err != tt.expectedErr {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedErr)
+				return
+			}
+
+			if got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMoney_MulRatio(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		money       Money
+		num, den    int64
+		mode        RoundingMode
+		expected    Money
+		expectedErr error
+	}{
+		{name: "Exact", money: 1000, num: 1, den: 4, mode: RoundHalfEven, expected: 250},
+		{name: "Half even tie to even", money: 25, num: 1, den: 10, mode: RoundHalfEven, expected: 2},
+		{name: "Half even tie to even above", money: 35, num: 1, den: 10, mode: RoundHalfEven, expected: 4},
+		{name: "Half up tie", money: 25, num: 1, den: 10, mode: RoundHalfUp, expected: 3},
+		{name: "Half up negative tie", money: -25, num: 1, den: 10, mode: RoundHalfUp, expected: -3},
+		{name: "Half even above half", money: 26, num: 1, den: 10, mode: RoundHalfEven, expected: 3},
+		{name: "Down", money: 29, num: 1, den: 10, mode: RoundDown, expected: 2},
+		{name: "Down negative", money: -29, num: 1, den: 10, mode: RoundDown, expected: -2},
+		{name: "Negative denominator", money: 25, num: 1, den: -10, mode: RoundHalfUp, expected: -3},
+		{name: "Intermediate product beyond int64", money: math.MaxInt64, num: 3, den: 4, mode: RoundDown, expected: 6917529027641081855},
+		{name: "Overflow", money: math.MaxInt64, num: 2, den: 1, mode: RoundDown, expectedErr: ErrMoneyOverflow},
+		{name: "Zero denominator", money: 100, num: 1, den: 0, mode: RoundDown, expectedErr: ErrInvalidMoneyRatio},
+		{name: "Unknown mode", money: 25, num: 1, den: 10, mode: "up", expectedErr: ErrInvalidRoundingMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.money.MulRatio(tt.num, tt.den, tt.mode)
+			if err != tt.expectedErr {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedErr)
+				return
+			}
+
+			if got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMoney_FeeInterest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		op       func() (Money, error)
+		expected Money
+	}{
+		{name: "Fee of 2.5%", op: func() (Money, error) { return Money(10000).Fee(250, RoundHalfEven) }, expected: 250},
+		{name: "Fee rounded half even", op: func() (Money, error) { return Money(50).Fee(100, RoundHalfEven) }, expected: 0},
+		{name: "Fee rounded half up", op: func() (Money, error) { return Money(50).Fee(100, RoundHalfUp) }, expected: 1},
+		{name: "Interest of a year", op: func() (Money, error) { return Money(100000).Interest(1000, 365, RoundHalfEven) }, expected: 10000},
+		{name: "Interest of a day rounded down", op: func() (Money, error) { return Money(100000).Interest(1000, 1, RoundDown) }, expected: 27},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op()
+			if err != nil {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, nil)
+				return
+			}
+
+			if got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		money       Money
+		mode        RoundingMode
+		ratios      []int64
+		expected    []Money
+		expectedErr error
+	}{
+		{name: "Even split", money: 100, mode: RoundHalfEven, ratios: []int64{1, 1}, expected: []Money{50, 50}},
+		{name: "Split in three rounded down", money: 100, mode: RoundDown, ratios: []int64{1, 1, 1}, expected: []Money{34, 33, 33}},
+		{name: "Split in three half up", money: 200, mode: RoundHalfUp, ratios: []int64{1, 1, 1}, expected: []Money{66, 67, 67}},
+		{name: "Weighted split", money: 1000, mode: RoundHalfEven, ratios: []int64{70, 20, 10}, expected: []Money{700, 200, 100}},
+		{name: "Zero ratio keeps nothing", money: 5, mode: RoundDown, ratios: []int64{0, 1, 1}, expected: []Money{0, 3, 2}},
+		{name: "Negative money", money: -100, mode: RoundDown, ratios: []int64{1, 1, 1}, expected: []Money{-34, -33, -33}},
+		{name: "Excess cents given back", money: 5, mode: RoundHalfUp, ratios: []int64{1, 1, 1, 1}, expected: []Money{2, 1, 1, 1}},
+		{name: "Largest money", money: math.MaxInt64, mode: RoundHalfEven, ratios: []int64{1, 1}, expected: []Money{math.MaxInt64 / 2, math.MaxInt64/2 + 1}},
+		{name: "No ratio", money: 100, mode: RoundDown, ratios: []int64{0}, expectedErr: ErrInvalidMoneyRatio},
+		{name: "Negative ratio", money: 100, mode: RoundDown, ratios: []int64{1, -1}, expectedErr: ErrInvalidMoneyRatio},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.money.Allocate(tt.mode, tt.ratios...)
+			if err != tt.expectedErr {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+
+			var sum Money
+			for _, share := range got {
+				sum += share
+			}
+
+			if got != nil && sum != tt.money {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, sum, tt.money)
+			}
+		})
+	}
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input       string
+		expected    RoundingMode
+		expectedErr error
+	}{
+		{input: "", expected: RoundHalfEven},
+		{input: "HALF_UP", expected: RoundHalfUp},
+		{input: "down", expected: RoundDown},
+		{input: "ceiling", expectedErr: ErrInvalidRoundingMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRoundingMode(tt.input)
+			if err != tt.expectedErr || got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' '%v' | Expected: '%v' '%v'", tt.input, got, err, tt.expected, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotificationPreferenceNotFound = errors.New("notification preference not found")
+
+	ErrNotificationNotFound = errors.New("notification not found")
+
+	ErrNegativeThreshold = errors.New("low balance threshold must not be negative")
+)
+
+// NotificationKind is what a customer is notified about, and with the locale, picks the template of the message
+type NotificationKind string
+
+const (
+	// NotificationTransferReceived tells the holder of the destination account that money arrived
+	NotificationTransferReceived NotificationKind = "transfer_received"
+	// NotificationLowBalance tells the holder of the origin account that a transfer took the balance below the
+	// threshold they set
+	NotificationLowBalance NotificationKind = "low_balance"
+)
+
+func (n NotificationKind) String() string {
+	return string(n)
+}
+
+// DefaultLocale is the locale of the messages of customers who did not choose one, and of the templates missing
+// in the chosen one
+const DefaultLocale = "pt-BR"
+
+// NotificationStatus is whether a notification was handed to the notifier
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "pending"
+	NotificationSent    NotificationStatus = "sent"
+)
+
+func (n NotificationStatus) String() string {
+	return string(n)
+}
+
+type (
+	NotificationPreferenceRepository interface {
+		// Save creates the preference of the account or replaces it
+		Save(context.Context, NotificationPreference) error
+		FindByAccountID(context.Context, AccountID) (NotificationPreference, error)
+	}
+
+	NotificationRepository interface {
+		Create(context.Context, Notification) error
+		Update(context.Context, Notification) error
+		FindByKey(context.Context, string) (Notification, error)
+	}
+
+	// Notifier hands a rendered notification to the customer, such as by email. An error means it may not have
+	// reached them and it is sent again
+	Notifier interface {
+		Notify(context.Context, Notification) error
+	}
+
+	// NotificationPreference is how and about what the holder of an account wants to be notified
+	NotificationPreference struct {
+		accountID           AccountID
+		email               string
+		locale              string
+		transferReceived    bool
+		lowBalanceThreshold Money
+		updatedAt           time.Time
+	}
+
+	// Notification is a message sent to a customer about an event. The key identifies it among retries of the event
+	Notification struct {
+		key       string
+		accountID AccountID
+		eventID   string
+		kind      NotificationKind
+		recipient string
+		subject   string
+		body      string
+		status    NotificationStatus
+		createdAt time.Time
+		sentAt    time.Time
+	}
+)
+
+func NewNotificationPreference(
+	accountID AccountID,
+	email string,
+	locale string,
+	transferReceived bool,
+	lowBalanceThreshold Money,
+	updatedAt time.Time,
+) NotificationPreference {
+	if locale == "" {
+		locale = DefaultLocale
+	}
+
+	return NotificationPreference{
+		accountID:           accountID,
+		email:               email,
+		locale:              locale,
+		transferReceived:    transferReceived,
+		lowBalanceThreshold: lowBalanceThreshold,
+		updatedAt:           updatedAt,
+	}
+}
+
+// LowBalanceCrossed reports whether the balance went from at or above the threshold to below it. A zero threshold
+// turns the notification off
+func (n NotificationPreference) LowBalanceCrossed(before, after Money) bool {
+	return n.lowBalanceThreshold > 0 && before >= n.lowBalanceThreshold && after < n.lowBalanceThreshold
+}
+
+func (n NotificationPreference) AccountID() AccountID {
+	return n.accountID
+}
+
+func (n NotificationPreference) Email() string {
+	return n.email
+}
+
+func (n NotificationPreference) Locale() string {
+	return n.locale
+}
+
+// TransferReceived reports whether the holder wants to know about the transfers arriving at the account
+func (n NotificationPreference) TransferReceived() bool {
+	return n.transferReceived
+}
+
+func (n NotificationPreference) LowBalanceThreshold() Money {
+	return n.lowBalanceThreshold
+}
+
+func (n NotificationPreference) UpdatedAt() time.Time {
+	return n.updatedAt
+}
+
+// NotificationKey identifies the notification of the kind sent to the account about the event
+func NotificationKey(eventID string, accountID AccountID, kind NotificationKind) string {
+	return eventID + ":" + accountID.String() + ":" + kind.String()
+}
+
+func NewNotification(
+	key string,
+	accountID AccountID,
+	eventID string,
+	kind NotificationKind,
+	recipient string,
+	subject string,
+	body string,
+	status NotificationStatus,
+	createdAt time.Time,
+	sentAt time.Time,
+) Notification {
+	return Notification{
+		key:       key,
+		accountID: accountID,
+		eventID:   eventID,
+		kind:      kind,
+		recipient: recipient,
+		subject:   subject,
+		body:      body,
+		status:    status,
+		createdAt: createdAt,
+		sentAt:    sentAt,
+	}
+}
+
+// MarkSent records that the notifier took the notification
+func (n Notification) MarkSent(at time.Time) Notification {
+	n.status = NotificationSent
+	n.sentAt = at
+	return n
+}
+
+func (n Notification) Key() string {
+	return n.key
+}
+
+func (n Notification) AccountID() AccountID {
+	return n.accountID
+}
+
+func (n Notification) EventID() string {
+	return n.eventID
+}
+
+func (n Notification) Kind() NotificationKind {
+	return n.kind
+}
+
+// Recipient is the email address the notification is sent to
+func (n Notification) Recipient() string {
+	return n.recipient
+}
+
+func (n Notification) Subject() string {
+	return n.subject
+}
+
+func (n Notification) Body() string {
+	return n.body
+}
+
+func (n Notification) Status() NotificationStatus {
+	return n.status
+}
+
+func (n Notification) CreatedAt() time.Time {
+	return n.createdAt
+}
+
+func (n Notification) SentAt() time.Time {
+	return n.sentAt
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotificationPreference_LowBalanceCrossed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		threshold Money
+		before    Money
+		after     Money
+		expected  bool
+	}{
+		{
+			name:      "Balance goes below the threshold",
+			threshold: 10000,
+			before:    15000,
+			after:     5000,
+			expected:  true,
+		},
+		{
+			name:      "Balance starts at the threshold",
+			threshold: 10000,
+			before:    10000,
+			after:     9999,
+			expected:  true,
+		},
+		{
+			name:      "Balance stays above the threshold",
+			threshold: 10000,
+			before:    25000,
+			after:     10000,
+		},
+		{
+			name:      "Balance was already below the threshold",
+			threshold: 10000,
+			before:    9000,
+			after:     5000,
+		},
+		{
+			name:      "Zero threshold is off",
+			threshold: 0,
+			before:    100,
+			after:     -100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var preference = NewNotificationPreference(
+				"3c096a40-ccba-4b58-93ed-57379ab04680",
+				"test@example.com",
+				"",
+				false,
+				tt.threshold,
+				time.Now(),
+			)
+
+			if got := preference.LowBalanceCrossed(tt.before, tt.after); got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+
+			if preference.Locale() != DefaultLocale {
+				t.Errorf("[TestCase '%s'] Locale: '%v' | Expected: '%v'", tt.name, preference.Locale(), DefaultLocale)
+			}
+		})
+	}
+}
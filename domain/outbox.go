@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// OutboxStatus is where an outbox message is in its delivery
+type OutboxStatus string
+
+const (
+	// OutboxPending messages wait to be published, now or after a failed attempt
+	OutboxPending OutboxStatus = "pending"
+	// OutboxSent messages were published at least once
+	OutboxSent OutboxStatus = "sent"
+	// OutboxDead messages failed every attempt and are left for an operator
+	OutboxDead OutboxStatus = "dead"
+)
+
+func (o OutboxStatus) String() string {
+	return string(o)
+}
+
+type (
+	// OutboxRepository keeps the events raised by a change in the same transaction as the change, until a relay
+	// publishes them
+	OutboxRepository interface {
+		Create(context.Context, ...Event) error
+		// ClaimDue returns up to limit pending messages due at now, in the order they were created, leased to the caller
+		// until the second time: they are not due again before it unless updated. A message is only returned once the
+		// earlier pending messages of its aggregate are gone, so relays running side by side keep the order of each
+		// aggregate, and never take the same message while its lease lasts
+		ClaimDue(ctx context.Context, now time.Time, until time.Time, limit int) ([]OutboxMessage, error)
+		Update(context.Context, OutboxMessage) error
+		CountByStatus(context.Context) (map[OutboxStatus]int, error)
+		WithTransaction(context.Context, func(context.Context) error) error
+	}
+
+	// OutboxMessage is an event waiting in the outbox, with the state of its delivery
+	OutboxMessage struct {
+		event         Event
+		sequence      int64
+		status        OutboxStatus
+		attempts      int
+		nextAttemptAt time.Time
+		lastError     string
+		sentAt        time.Time
+	}
+)
+
+func NewOutboxMessage(
+	event Event,
+	sequence int64,
+	status OutboxStatus,
+	attempts int,
+	nextAttemptAt time.Time,
+	lastError string,
+	sentAt time.Time,
+) OutboxMessage {
+	return OutboxMessage{
+		event:         event,
+		sequence:      sequence,
+		status:        status,
+		attempts:      attempts,
+		nextAttemptAt: nextAttemptAt,
+		lastError:     lastError,
+		sentAt:        sentAt,
+	}
+}
+
+// Due reports whether a pending message may be attempted at now
+func (o OutboxMessage) Due(now time.Time) bool {
+	return o.status == OutboxPending && !o.nextAttemptAt.After(now)
+}
+
+// MarkSent records the message as published
+func (o OutboxMessage) MarkSent(at time.Time) OutboxMessage {
+	o.status = OutboxSent
+	o.attempts++
+	o.lastError = ""
+	o.sentAt = at
+	return o
+}
+
+// MarkFailed records a failed attempt, either to be retried at nextAttemptAt or, when dead, never again
+func (o OutboxMessage) MarkFailed(err error, nextAttemptAt time.Time, dead bool) OutboxMessage {
+	o.attempts++
+	o.lastError = err.Error()
+	o.nextAttemptAt = nextAttemptAt
+
+	if dead {
+		o.status = OutboxDead
+	}
+
+	return o
+}
+
+func (o OutboxMessage) Event() Event {
+	return o.event
+}
+
+func (o OutboxMessage) Sequence() int64 {
+	return o.sequence
+}
+
+func (o OutboxMessage) Status() OutboxStatus {
+	return o.status
+}
+
+func (o OutboxMessage) Attempts() int {
+	return o.attempts
+}
+
+func (o OutboxMessage) NextAttemptAt() time.Time {
+	return o.nextAttemptAt
+}
+
+func (o OutboxMessage) LastError() string {
+	return o.lastError
+}
+
+func (o OutboxMessage) SentAt() time.Time {
+	return o.sentAt
+}
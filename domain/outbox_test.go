@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOutboxMessage_Delivery(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now     = time.Now()
+		event   = NewEvent("3c096a40-ccba-4b58-93ed-57379ab04680", EventTransferCompleted, EventSchemaVersion, "3c096a40-ccba-4b58-93ed-57379ab04681", []byte(`{}`), now)
+		pending = NewOutboxMessage(event, 1, OutboxPending, 0, now, "", time.Time{})
+	)
+
+	tests := []struct {
+		name             string
+		message          OutboxMessage
+		expectedStatus   OutboxStatus
+		expectedAttempts int
+		expectedDue      bool
+	}{
+		{
+			name:           "New message is due",
+			message:        pending,
+			expectedStatus: OutboxPending,
+			expectedDue:    true,
+		},
+		{
+			name:             "Sent message is not due",
+			message:          pending.MarkSent(now),
+			expectedStatus:   OutboxSent,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "Failed message waits for its next attempt",
+			message:          pending.MarkFailed(errors.New("error"), now.Add(time.Minute), false),
+			expectedStatus:   OutboxPending,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "Dead message is not due",
+			message:          pending.MarkFailed(errors.New("error"), now, true),
+			expectedStatus:   OutboxDead,
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.message.Status() != tt.expectedStatus || tt.message.Attempts() != tt.expectedAttempts {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' '%v' | Expected: '%v' '%v'",
+					tt.name,
+					tt.message.Status(),
+					tt.message.Attempts(),
+					tt.expectedStatus,
+					tt.expectedAttempts,
+				)
+			}
+
+			if got := tt.message.Due(now); got != tt.expectedDue {
+				t.Errorf("[TestCase '%s'] Due: '%v' | Expected: '%v'", tt.name, got, tt.expectedDue)
+			}
+		})
+	}
+}
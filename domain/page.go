@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPageSize is the size of a page asked without a limit
+	DefaultPageSize = 20
+
+	// MaxPageSize bounds the size of a page
+	MaxPageSize = 100
+)
+
+var (
+	ErrInvalidPageSize = errors.New("page size must be between 1 and 100")
+
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	ErrInvalidSort = errors.New("invalid sort, use created_at, -created_at, amount or -amount")
+)
+
+// SortKey is what a listing is ordered by, before the ID breaking its ties
+type SortKey string
+
+const (
+	SortByCreatedAt SortKey = "created_at"
+	SortByAmount    SortKey = "amount"
+)
+
+type (
+	// PageRequest asks for the items of a listing after the cursor, up to the limit, in the order of the sort. The
+	// ID breaks the ties of the sort, so a page is not shifted by the items created while paging
+	PageRequest struct {
+		Limit int
+		Sort  Sort
+		// After is the cursor of the last item of the previous page. The zero cursor starts from the first item
+		After Cursor
+	}
+
+	// Sort orders a listing. The zero sort is by creation time, oldest first
+	Sort struct {
+		Key        SortKey
+		Descending bool
+	}
+
+	// Cursor is the position of an item in a listing of the sort, kept by the values the sort compares
+	Cursor struct {
+		Sort      Sort
+		CreatedAt time.Time
+		Amount    Money
+		ID        string
+	}
+)
+
+// NewPageRequest validates the limit and the cursor of a page, as asked by a client. A zero limit asks for the
+// default size and an empty cursor for the first page. The cursor must come from a page of the same sort
+func NewPageRequest(limit int, sort Sort, cursor string) (PageRequest, error) {
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+
+	if limit < 0 || limit > MaxPageSize {
+		return PageRequest{}, ErrInvalidPageSize
+	}
+
+	after, err := ParseCursor(cursor)
+	if err != nil {
+		return PageRequest{}, err
+	}
+
+	if !after.IsZero() && after.Sort.String() != sort.String() {
+		return PageRequest{}, ErrInvalidCursor
+	}
+
+	return PageRequest{Limit: limit, Sort: sort, After: after}, nil
+}
+
+// ParseSort reads a sort as its key, preceded by a minus sign when descending. The empty string sorts by creation
+// time, oldest first
+func ParseSort(s string) (Sort, error) {
+	if s == "" {
+		return Sort{Key: SortByCreatedAt}, nil
+	}
+
+	var sort = Sort{Descending: strings.HasPrefix(s, "-")}
+
+	switch key := SortKey(strings.TrimPrefix(s, "-")); key {
+	case SortByCreatedAt, SortByAmount:
+		sort.Key = key
+		return sort, nil
+	default:
+		return Sort{}, ErrInvalidSort
+	}
+}
+
+// By is the key of the sort, the creation time when unset
+func (s Sort) By() SortKey {
+	if s.Key == "" {
+		return SortByCreatedAt
+	}
+
+	return s.Key
+}
+
+func (s Sort) String() string {
+	if s.Descending {
+		return "-" + string(s.By())
+	}
+
+	return string(s.By())
+}
+
+// Less reports whether the item at the first cursor comes before the other one, for the listings sorted in memory
+func (s Sort) Less(a, b Cursor) bool {
+	return s.compare(a, b) < 0
+}
+
+// compare orders the items at both cursors, telling whether the first comes before, with the same values or after
+func (s Sort) compare(a, b Cursor) int {
+	var c int
+	switch s.By() {
+	case SortByAmount:
+		c = compareInt64(a.Amount.Int64(), b.Amount.Int64())
+	default:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	}
+
+	if c == 0 {
+		c = strings.Compare(a.ID, b.ID)
+	}
+
+	if s.Descending {
+		return -c
+	}
+
+	return c
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// NewCursor creates the cursor of an item in a listing sorted by creation time, oldest first
+func NewCursor(createdAt time.Time, ID string) Cursor {
+	return Cursor{Sort: Sort{Key: SortByCreatedAt}, CreatedAt: createdAt.UTC(), ID: ID}
+}
+
+// ParseCursor reads a cursor written by String. The empty string is the zero cursor
+func ParseCursor(s string) (Cursor, error) {
+	if s == "" {
+		return Cursor{}, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var parts = strings.SplitN(string(decoded), "|", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	sort, err := ParseSort(parts[0])
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor = Cursor{Sort: sort, ID: parts[2]}
+	switch sort.By() {
+	case SortByAmount:
+		amount, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return Cursor{}, ErrInvalidCursor
+		}
+
+		cursor.Amount = Money(amount)
+	default:
+		createdAt, err := time.Parse(time.RFC3339Nano, parts[1])
+		if err != nil {
+			return Cursor{}, ErrInvalidCursor
+		}
+
+		cursor.CreatedAt = createdAt.UTC()
+	}
+
+	return cursor, nil
+}
+
+// IsZero reports whether the cursor points before the first item
+func (c Cursor) IsZero() bool {
+	return c.ID == ""
+}
+
+// String writes the cursor as an opaque token, empty for the zero cursor. It keeps the sort and the value of the
+// item compared by it
+func (c Cursor) String() string {
+	if c.IsZero() {
+		return ""
+	}
+
+	var value string
+	switch c.Sort.By() {
+	case SortByAmount:
+		value = strconv.FormatInt(c.Amount.Int64(), 10)
+	default:
+		value = c.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(c.Sort.String() + "|" + value + "|" + c.ID))
+}
+
+// Follows reports whether the item at the other cursor comes after this one, in the order of its sort
+func (c Cursor) Follows(item Cursor) bool {
+	return c.IsZero() || c.Sort.compare(c, item) < 0
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewPageRequest(t *testing.T) {
+	t.Parallel()
+
+	var (
+		byCreatedAt = Sort{Key: SortByCreatedAt}
+		byAmount    = Sort{Key: SortByAmount, Descending: true}
+		cursor      = NewCursor(time.Date(2021, 1, 1, 12, 30, 0, 123456000, time.UTC), "3c096a40-ccba-4b58-93ed-57379ab04680")
+		amount      = Cursor{Sort: byAmount, Amount: 1050, ID: "3c096a40-ccba-4b58-93ed-57379ab04680"}
+	)
+
+	tests := []struct {
+		name        string
+		limit       int
+		sort        Sort
+		cursor      string
+		expected    PageRequest
+		expectedErr error
+	}{
+		{name: "First page of the default size", expected: PageRequest{Limit: DefaultPageSize}},
+		{
+			name:     "Page after a cursor",
+			limit:    50,
+			sort:     byCreatedAt,
+			cursor:   cursor.String(),
+			expected: PageRequest{Limit: 50, Sort: byCreatedAt, After: cursor},
+		},
+		{
+			name:     "Page after a cursor of the zero sort",
+			cursor:   cursor.String(),
+			expected: PageRequest{Limit: DefaultPageSize, After: cursor},
+		},
+		{
+			name:     "Page after a cursor sorted by amount",
+			sort:     byAmount,
+			cursor:   amount.String(),
+			expected: PageRequest{Limit: DefaultPageSize, Sort: byAmount, After: amount},
+		},
+		{name: "Cursor of another sort", sort: byAmount, cursor: cursor.String(), expectedErr: ErrInvalidCursor},
+		{name: "Largest page", limit: MaxPageSize, expected: PageRequest{Limit: MaxPageSize}},
+		{name: "Page above the maximum size", limit: MaxPageSize + 1, expectedErr: ErrInvalidPageSize},
+		{name: "Negative limit", limit: -1, expectedErr: ErrInvalidPageSize},
+		{name: "Cursor not in base64", cursor: "not a cursor", expectedErr: ErrInvalidCursor},
+		{
+			name:        "Cursor without an ID",
+			cursor:      base64.RawURLEncoding.EncodeToString([]byte("created_at|2021-01-01T00:00:00Z|")),
+			expectedErr: ErrInvalidCursor,
+		},
+		{
+			name:        "Cursor with an invalid time",
+			cursor:      base64.RawURLEncoding.EncodeToString([]byte("created_at|yesterday|3c096a40-ccba-4b58-93ed-57379ab04680")),
+			expectedErr: ErrInvalidCursor,
+		},
+		{
+			name:        "Cursor with an invalid amount",
+			sort:        byAmount,
+			cursor:      base64.RawURLEncoding.EncodeToString([]byte("-amount|10.50|3c096a40-ccba-4b58-93ed-57379ab04680")),
+			expectedErr: ErrInvalidCursor,
+		},
+		{
+			name:        "Cursor with an invalid sort",
+			cursor:      base64.RawURLEncoding.EncodeToString([]byte("name|Gabriel|3c096a40-ccba-4b58-93ed-57379ab04680")),
+			expectedErr: ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPageRequest(tt.limit, tt.sort, tt.cursor)
+			if err != tt.expectedErr {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		sort        string
+		expected    Sort
+		expectedErr error
+	}{
+		{name: "Default sort", expected: Sort{Key: SortByCreatedAt}},
+		{name: "Oldest first", sort: "created_at", expected: Sort{Key: SortByCreatedAt}},
+		{name: "Newest first", sort: "-created_at", expected: Sort{Key: SortByCreatedAt, Descending: true}},
+		{name: "Smallest amount first", sort: "amount", expected: Sort{Key: SortByAmount}},
+		{name: "Largest amount first", sort: "-amount", expected: Sort{Key: SortByAmount, Descending: true}},
+		{name: "Unknown key", sort: "name", expectedErr: ErrInvalidSort},
+		{name: "Only the minus sign", sort: "-", expectedErr: ErrInvalidSort},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSort(tt.sort)
+			if err != tt.expectedErr {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedErr)
+				return
+			}
+
+			if got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCursor_Follows(t *testing.T) {
+	t.Parallel()
+
+	var (
+		createdAt = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		cursor    = NewCursor(createdAt, "3c096a40-ccba-4b58-93ed-57379ab04681")
+		largest   = Sort{Key: SortByAmount, Descending: true}
+		amount    = Cursor{Sort: largest, Amount: 1000, ID: "3c096a40-ccba-4b58-93ed-57379ab04681"}
+	)
+
+	tests := []struct {
+		name     string
+		cursor   Cursor
+		item     Cursor
+		expected bool
+	}{
+		{name: "Zero cursor", item: NewCursor(createdAt, "3c096a40-ccba-4b58-93ed-57379ab04680"), expected: true},
+		{name: "Created later", cursor: cursor, item: NewCursor(createdAt.Add(time.Microsecond), "0"), expected: true},
+		{name: "Created earlier", cursor: cursor, item: NewCursor(createdAt.Add(-time.Microsecond), "f"), expected: false},
+		{
+			name:     "Same time, greater ID",
+			cursor:   cursor,
+			item:     NewCursor(createdAt, "3c096a40-ccba-4b58-93ed-57379ab04682"),
+			expected: true,
+		},
+		{
+			name:     "Same time, same ID",
+			cursor:   cursor,
+			item:     NewCursor(createdAt, "3c096a40-ccba-4b58-93ed-57379ab04681"),
+			expected: false,
+		},
+		{
+			name:     "Same time, lower ID",
+			cursor:   cursor,
+			item:     NewCursor(createdAt, "3c096a40-ccba-4b58-93ed-57379ab04680"),
+			expected: false,
+		},
+		{name: "Smaller amount, largest first", cursor: amount, item: Cursor{Sort: largest, Amount: 999, ID: "f"}, expected: true},
+		{name: "Larger amount, largest first", cursor: amount, item: Cursor{Sort: largest, Amount: 1001, ID: "0"}, expected: false},
+		{
+			name:     "Same amount, lower ID, largest first",
+			cursor:   amount,
+			item:     Cursor{Sort: largest, Amount: 1000, ID: "3c096a40-ccba-4b58-93ed-57379ab04680"},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cursor.Follows(tt.item); got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrForbidden = errors.New("caller is not allowed to access this resource")
+
+	ErrScopeNotHeld = errors.New("caller cannot grant a scope it does not hold")
+)
+
+type principalContextKey struct{}
+
+// Principal is the authenticated caller of a use case. For customers the subject is the CPF of the
+// account holder, for machine clients it is the ID of the API key
+type Principal struct {
+	subject string
+	role    Role
+	scopes  []Scope
+}
+
+func NewPrincipal(subject string, role Role, scopes ...Scope) Principal {
+	return Principal{
+		subject: subject,
+		role:    role,
+		scopes:  scopes,
+	}
+}
+
+func NewClientPrincipal(subject string, scopes ...Scope) Principal {
+	return NewPrincipal(subject, RoleClient, scopes...)
+}
+
+func (p Principal) Subject() string {
+	return p.subject
+}
+
+func (p Principal) Role() Role {
+	return p.role
+}
+
+func (p Principal) Scopes() []Scope {
+	return p.scopes
+}
+
+// IsClient reports whether the principal is a machine client, which is bound by scopes instead of owning accounts
+func (p Principal) IsClient() bool {
+	return p.role == RoleClient
+}
+
+func (p Principal) HasScope(scope Scope) bool {
+	for _, s := range p.scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HoldsScopes reports whether the principal holds every one of the scopes
+func (p Principal) HoldsScopes(scopes []Scope) bool {
+	for _, scope := range scopes {
+		if !p.HasScope(scope) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p Principal) Owns(account Account) bool {
+	return !p.IsClient() && p.subject != "" && p.subject == account.CPF()
+}
+
+// ContextWithPrincipal returns a copy of ctx carrying the principal
+func ContextWithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, p)
+}
+
+// PrincipalFromContext returns the principal carried by ctx, if any
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalContextKey{}).(Principal)
+	return p, ok
+}
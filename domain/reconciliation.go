@@ -0,0 +1,313 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrReconciliationNotFound = errors.New("reconciliation run not found")
+
+	ErrInvalidReconciliationStatus = errors.New("invalid reconciliation status")
+
+	ErrOpeningBalanceUnknown = errors.New("opening balance of the account is unknown")
+)
+
+type ReconciliationRunID string
+
+func (r ReconciliationRunID) String() string {
+	return string(r)
+}
+
+// ReconciliationStatus is where a reconciliation run is
+type ReconciliationStatus string
+
+const (
+	// ReconciliationPending runs were requested and wait for the job
+	ReconciliationPending   ReconciliationStatus = "pending"
+	ReconciliationRunning   ReconciliationStatus = "running"
+	ReconciliationCompleted ReconciliationStatus = "completed"
+	// ReconciliationFailed runs could not read the balances, the reason is kept in their error
+	ReconciliationFailed ReconciliationStatus = "failed"
+)
+
+func (r ReconciliationStatus) String() string {
+	return string(r)
+}
+
+// ReconciliationTrigger tells who asked for a run
+type ReconciliationTrigger string
+
+const (
+	ReconciliationManual    ReconciliationTrigger = "manual"
+	ReconciliationScheduled ReconciliationTrigger = "scheduled"
+)
+
+func (r ReconciliationTrigger) String() string {
+	return string(r)
+}
+
+type (
+	ReconciliationRepository interface {
+		Create(context.Context, ReconciliationRun) error
+		Update(context.Context, ReconciliationRun) error
+		FindByID(context.Context, ReconciliationRunID) (ReconciliationRun, error)
+		// FindPending returns the runs waiting for the job, oldest first
+		FindPending(context.Context) ([]ReconciliationRun, error)
+		// FindLatest returns the run requested last
+		FindLatest(context.Context) (ReconciliationRun, error)
+	}
+
+	// LedgerRepository reads, for each account, its stored balance next to the money that moved it: the opening
+	// balance and the completed transfers. Both are read at the same moment
+	LedgerRepository interface {
+		FindAll(context.Context) ([]AccountLedger, error)
+		FindByAccountID(context.Context, AccountID) (AccountLedger, error)
+	}
+
+	// AccountLedger is the stored balance of an account and the money that moved it. The opening balance of the
+	// accounts opened before it was recorded is unknown, so their balance can not be verified
+	AccountLedger struct {
+		accountID      AccountID
+		openedAt       time.Time
+		openingBalance Money
+		openingKnown   bool
+		balance        Money
+		credits        Money
+		debits         Money
+	}
+
+	// ReconciliationMismatch is an account whose stored balance is not the one its transfers lead to
+	ReconciliationMismatch struct {
+		accountID AccountID
+		stored    Money
+		expected  Money
+	}
+
+	// ReconciliationRun compares the stored balance of every account with the one recomputed from its ledger. The
+	// accounts whose opening balance is unknown are reported as unverifiable instead
+	ReconciliationRun struct {
+		id              ReconciliationRunID
+		status          ReconciliationStatus
+		trigger         ReconciliationTrigger
+		accountsChecked int
+		mismatches      []ReconciliationMismatch
+		unverifiable    []AccountID
+		lastError       string
+		requestedAt     time.Time
+		startedAt       time.Time
+		finishedAt      time.Time
+	}
+)
+
+// NewAccountLedger sums the transfers received in credits and the transfers sent in debits
+func NewAccountLedger(
+	accountID AccountID,
+	openedAt time.Time,
+	openingBalance, balance, credits, debits Money,
+) AccountLedger {
+	return AccountLedger{
+		accountID:      accountID,
+		openedAt:       openedAt,
+		openingBalance: openingBalance,
+		openingKnown:   true,
+		balance:        balance,
+		credits:        credits,
+		debits:         debits,
+	}
+}
+
+// NewAccountLedgerWithoutOpening is the ledger of an account whose opening balance is unknown
+func NewAccountLedgerWithoutOpening(accountID AccountID, openedAt time.Time, balance, credits, debits Money) AccountLedger {
+	return AccountLedger{
+		accountID: accountID,
+		openedAt:  openedAt,
+		balance:   balance,
+		credits:   credits,
+		debits:    debits,
+	}
+}
+
+func (a AccountLedger) AccountID() AccountID {
+	return a.accountID
+}
+
+// OpenedAt is when the account was created
+func (a AccountLedger) OpenedAt() time.Time {
+	return a.openedAt
+}
+
+// OpeningBalance is the balance the account was opened with, reporting false when it is unknown
+func (a AccountLedger) OpeningBalance() (Money, bool) {
+	return a.openingBalance, a.openingKnown
+}
+
+// ExpectedBalance is the opening balance plus the credits minus the debits, which can not be worked out without the
+// opening balance
+func (a AccountLedger) ExpectedBalance() (Money, error) {
+	if !a.openingKnown {
+		return 0, ErrOpeningBalanceUnknown
+	}
+
+	expected, err := a.openingBalance.Add(a.credits)
+	if err != nil {
+		return 0, err
+	}
+
+	return expected.Sub(a.debits)
+}
+
+// Mismatch compares the stored balance with the expected one, reporting false when they agree
+func (a AccountLedger) Mismatch() (ReconciliationMismatch, bool, error) {
+	expected, err := a.ExpectedBalance()
+	if err != nil {
+		return ReconciliationMismatch{}, false, err
+	}
+
+	if expected == a.balance {
+		return ReconciliationMismatch{}, false, nil
+	}
+
+	return NewReconciliationMismatch(a.accountID, a.balance, expected), true, nil
+}
+
+func NewReconciliationMismatch(accountID AccountID, stored, expected Money) ReconciliationMismatch {
+	return ReconciliationMismatch{
+		accountID: accountID,
+		stored:    stored,
+		expected:  expected,
+	}
+}
+
+func (r ReconciliationMismatch) AccountID() AccountID {
+	return r.accountID
+}
+
+func (r ReconciliationMismatch) Stored() Money {
+	return r.stored
+}
+
+func (r ReconciliationMismatch) Expected() Money {
+	return r.expected
+}
+
+// Difference is the stored balance minus the expected one, positive when the account holds more than it should
+func (r ReconciliationMismatch) Difference() (Money, error) {
+	return r.stored.Sub(r.expected)
+}
+
+// NewReconciliationRun restores a run
+func NewReconciliationRun(
+	ID ReconciliationRunID,
+	status ReconciliationStatus,
+	trigger ReconciliationTrigger,
+	accountsChecked int,
+	mismatches []ReconciliationMismatch,
+	unverifiable []AccountID,
+	lastError string,
+	requestedAt time.Time,
+	startedAt time.Time,
+	finishedAt time.Time,
+) ReconciliationRun {
+	return ReconciliationRun{
+		id:              ID,
+		status:          status,
+		trigger:         trigger,
+		accountsChecked: accountsChecked,
+		mismatches:      mismatches,
+		unverifiable:    unverifiable,
+		lastError:       lastError,
+		requestedAt:     requestedAt,
+		startedAt:       startedAt,
+		finishedAt:      finishedAt,
+	}
+}
+
+// NewPendingReconciliationRun requests a run of the job
+func NewPendingReconciliationRun(ID ReconciliationRunID, trigger ReconciliationTrigger, at time.Time) ReconciliationRun {
+	return NewReconciliationRun(ID, ReconciliationPending, trigger, 0, nil, nil, "", at, time.Time{}, time.Time{})
+}
+
+// Start takes a pending run
+func (r ReconciliationRun) Start(at time.Time) (ReconciliationRun, error) {
+	if r.status != ReconciliationPending {
+		return r, ErrInvalidReconciliationStatus
+	}
+
+	r.status = ReconciliationRunning
+	r.startedAt = at
+	return r, nil
+}
+
+// Complete ends a running run with the accounts it checked, those that did not match and those it could not verify
+func (r ReconciliationRun) Complete(
+	accountsChecked int,
+	mismatches []ReconciliationMismatch,
+	unverifiable []AccountID,
+	at time.Time,
+) (ReconciliationRun, error) {
+	if r.status != ReconciliationRunning {
+		return r, ErrInvalidReconciliationStatus
+	}
+
+	r.status = ReconciliationCompleted
+	r.accountsChecked = accountsChecked
+	r.mismatches = mismatches
+	r.unverifiable = unverifiable
+	r.finishedAt = at
+	return r, nil
+}
+
+// Fail ends a running run that could not finish
+func (r ReconciliationRun) Fail(err error, at time.Time) (ReconciliationRun, error) {
+	if r.status != ReconciliationRunning {
+		return r, ErrInvalidReconciliationStatus
+	}
+
+	r.status = ReconciliationFailed
+	r.lastError = err.Error()
+	r.finishedAt = at
+	return r, nil
+}
+
+func (r ReconciliationRun) ID() ReconciliationRunID {
+	return r.id
+}
+
+func (r ReconciliationRun) Status() ReconciliationStatus {
+	return r.status
+}
+
+func (r ReconciliationRun) Trigger() ReconciliationTrigger {
+	return r.trigger
+}
+
+func (r ReconciliationRun) AccountsChecked() int {
+	return r.accountsChecked
+}
+
+func (r ReconciliationRun) Mismatches() []ReconciliationMismatch {
+	return r.mismatches
+}
+
+// Unverifiable are the accounts whose opening balance is unknown
+func (r ReconciliationRun) Unverifiable() []AccountID {
+	return r.unverifiable
+}
+
+func (r ReconciliationRun) LastError() string {
+	return r.lastError
+}
+
+func (r ReconciliationRun) RequestedAt() time.Time {
+	return r.requestedAt
+}
+
+func (r ReconciliationRun) StartedAt() time.Time {
+	return r.startedAt
+}
+
+func (r ReconciliationRun) FinishedAt() time.Time {
+	return r.finishedAt
+}
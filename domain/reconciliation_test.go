@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAccountLedger_Mismatch(t *testing.T) {
+	t.Parallel()
+
+	const accountID = AccountID("3c096a40-ccba-4b58-93ed-57379ab04680")
+
+	tests := []struct {
+		name             string
+		ledger           AccountLedger
+		expectedMismatch ReconciliationMismatch
+		expectedOk       bool
+		expectedError    error
+	}{
+		{
+			name:       "Balance matches its transfers",
+			ledger:     NewAccountLedger(accountID, time.Time{}, 1000, 1300, 500, 200),
+			expectedOk: false,
+		},
+		{
+			name:             "Balance holds more than its transfers",
+			ledger:           NewAccountLedger(accountID, time.Time{}, 1000, 1500, 500, 200),
+			expectedMismatch: NewReconciliationMismatch(accountID, 1500, 1300),
+			expectedOk:       true,
+		},
+		{
+			name:             "Balance holds less than its transfers",
+			ledger:           NewAccountLedger(accountID, time.Time{}, 0, 0, 0, 100),
+			expectedMismatch: NewReconciliationMismatch(accountID, 0, -100),
+			expectedOk:       true,
+		},
+		{
+			name:          "Opening balance unknown",
+			ledger:        NewAccountLedgerWithoutOpening(accountID, time.Time{}, 1300, 500, 200),
+			expectedError: ErrOpeningBalanceUnknown,
+		},
+		{
+			name:          "Credits overflow",
+			ledger:        NewAccountLedger(accountID, time.Time{}, math.MaxInt64, 0, 1, 0),
+			expectedError: ErrMoneyOverflow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := tt.ledger.Mismatch()
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if ok != tt.expectedOk || !reflect.DeepEqual(got, tt.expectedMismatch) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%+v' '%v' | Expected: '%+v' '%v'",
+					tt.name,
+					got,
+					ok,
+					tt.expectedMismatch,
+					tt.expectedOk,
+				)
+			}
+		})
+	}
+}
+
+func TestReconciliationRun_Transitions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now        = time.Now()
+		pending    = NewPendingReconciliationRun("5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10", ReconciliationManual, now)
+		running, _ = pending.Start(now)
+		mismatches = []ReconciliationMismatch{NewReconciliationMismatch("3c096a40-ccba-4b58-93ed-57379ab04680", 10, 0)}
+	)
+
+	tests := []struct {
+		name           string
+		transition     func() (ReconciliationRun, error)
+		expectedStatus ReconciliationStatus
+		expectedError  error
+	}{
+		{
+			name:           "Pending run starts",
+			transition:     func() (ReconciliationRun, error) { return pending.Start(now) },
+			expectedStatus: ReconciliationRunning,
+		},
+		{
+			name:           "Running run completes",
+			transition:     func() (ReconciliationRun, error) { return running.Complete(2, mismatches, nil, now) },
+			expectedStatus: ReconciliationCompleted,
+		},
+		{
+			name:           "Running run fails",
+			transition:     func() (ReconciliationRun, error) { return running.Fail(errors.New("error"), now) },
+			expectedStatus: ReconciliationFailed,
+		},
+		{
+			name:           "Running run does not start again",
+			transition:     func() (ReconciliationRun, error) { return running.Start(now) },
+			expectedStatus: ReconciliationRunning,
+			expectedError:  ErrInvalidReconciliationStatus,
+		},
+		{
+			name:           "Pending run does not complete",
+			transition:     func() (ReconciliationRun, error) { return pending.Complete(0, nil, nil, now) },
+			expectedStatus: ReconciliationPending,
+			expectedError:  ErrInvalidReconciliationStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.transition()
+			if err != tt.expectedError {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+			}
+
+			if got.Status() != tt.expectedStatus {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got.Status(), tt.expectedStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrTransferDenied = errors.New("transfer denied by risk rules")
+)
+
+// RiskDecision is the outcome of a risk rule, ordered by severity
+type RiskDecision string
+
+const (
+	RiskAllow  RiskDecision = "allow"
+	RiskReview RiskDecision = "review"
+	RiskDeny   RiskDecision = "deny"
+)
+
+func (d RiskDecision) severity() int {
+	switch d {
+	case RiskDeny:
+		return 2
+	case RiskReview:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (d RiskDecision) String() string {
+	return string(d)
+}
+
+type (
+	// RiskEngine evaluates a transfer against the fraud and velocity rules
+	RiskEngine interface {
+		Evaluate(context.Context, RiskSubject) ([]RuleResult, error)
+	}
+
+	// RiskSubject is what the rules know about a transfer when it is evaluated. The rules read the past transfers
+	// from the history, each bounded to the window or the aggregate it needs
+	RiskSubject struct {
+		Transfer    Transfer
+		Origin      Account
+		Destination Account
+		History     TransferHistory
+	}
+
+	// TransferHistory is the part of the TransferRepository read by the risk rules
+	TransferHistory interface {
+		Stream(context.Context, TransferFilter, func(Transfer) error) error
+		Summarize(context.Context, TransferFilter) (TransferSummary, error)
+	}
+
+	RiskEvaluationRepository interface {
+		Create(context.Context, RiskEvaluation) (RiskEvaluation, error)
+	}
+
+	RuleResult struct {
+		rule     string
+		decision RiskDecision
+		reason   string
+	}
+
+	RiskEvaluationID string
+
+	// RiskEvaluation records the rules evaluated for a transfer and the most severe of their decisions
+	RiskEvaluation struct {
+		id                   RiskEvaluationID
+		transferID           TransferID
+		accountOriginID      AccountID
+		accountDestinationID AccountID
+		amount               Money
+		decision             RiskDecision
+		results              []RuleResult
+		createdAt            time.Time
+	}
+)
+
+func NewRuleResult(rule string, decision RiskDecision, reason string) RuleResult {
+	return RuleResult{
+		rule:     rule,
+		decision: decision,
+		reason:   reason,
+	}
+}
+
+func (r RuleResult) Rule() string {
+	return r.rule
+}
+
+func (r RuleResult) Decision() RiskDecision {
+	return r.decision
+}
+
+func (r RuleResult) Reason() string {
+	return r.reason
+}
+
+func NewRiskEvaluation(
+	ID RiskEvaluationID,
+	transfer Transfer,
+	results []RuleResult,
+	createdAt time.Time,
+) RiskEvaluation {
+	var decision = RiskAllow
+	for _, r := range results {
+		if r.decision.severity() > decision.severity() {
+			decision = r.decision
+		}
+	}
+
+	return RiskEvaluation{
+		id:                   ID,
+		transferID:           transfer.ID(),
+		accountOriginID:      transfer.AccountOriginID(),
+		accountDestinationID: transfer.AccountDestinationID(),
+		amount:               transfer.Amount(),
+		decision:             decision,
+		results:              results,
+		createdAt:            createdAt,
+	}
+}
+
+func (r RiskEvaluationID) String() string {
+	return string(r)
+}
+
+func (r RiskEvaluation) ID() RiskEvaluationID {
+	return r.id
+}
+
+func (r RiskEvaluation) TransferID() TransferID {
+	return r.transferID
+}
+
+func (r RiskEvaluation) AccountOriginID() AccountID {
+	return r.accountOriginID
+}
+
+func (r RiskEvaluation) AccountDestinationID() AccountID {
+	return r.accountDestinationID
+}
+
+func (r RiskEvaluation) Amount() Money {
+	return r.amount
+}
+
+func (r RiskEvaluation) Decision() RiskDecision {
+	return r.decision
+}
+
+func (r RiskEvaluation) Results() []RuleResult {
+	return r.results
+}
+
+func (r RiskEvaluation) CreatedAt() time.Time {
+	return r.createdAt
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRiskEvaluation_Decision(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		results  []RuleResult
+		expected RiskDecision
+	}{
+		{
+			name:     "Allow without rules",
+			results:  nil,
+			expected: RiskAllow,
+		},
+		{
+			name: "Review when any rule asks for review",
+			results: []RuleResult{
+				NewRuleResult("velocity", RiskAllow, ""),
+				NewRuleResult("history", RiskReview, "amount above history"),
+			},
+			expected: RiskReview,
+		},
+		{
+			name: "Deny wins over review",
+			results: []RuleResult{
+				NewRuleResult("velocity", RiskDeny, "too many new destinations"),
+				NewRuleResult("history", RiskReview, "amount above history"),
+			},
+			expected: RiskDeny,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var evaluation = NewRiskEvaluation("id", Transfer{}, tt.results, time.Time{})
+
+			if evaluation.Decision() != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, evaluation.Decision(), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidRole = errors.New("invalid role")
+)
+
+// Role groups what a principal may do regardless of the scopes it was granted
+type Role string
+
+const (
+	// RoleCustomer is an account holder, restricted to its own account
+	RoleCustomer Role = "customer"
+	// RoleSupport reads any account but never changes it
+	RoleSupport Role = "support"
+	// RoleAdmin operates the bank: any account and the admin endpoints
+	RoleAdmin Role = "admin"
+	// RoleClient is a machine client authenticated by an API key, bound by its scopes and to the webhooks it created
+	RoleClient Role = "client"
+)
+
+// ParseRole converts a role claim, defaulting to customer when it is empty. Clients can not be claimed by tokens
+func ParseRole(s string) (Role, error) {
+	switch Role(s) {
+	case "":
+		return RoleCustomer, nil
+	case RoleCustomer, RoleSupport, RoleAdmin:
+		return Role(s), nil
+	default:
+		return "", ErrInvalidRole
+	}
+}
+
+// DefaultScopes are granted to users whose token does not list scopes
+func (r Role) DefaultScopes() []Scope {
+	switch r {
+	case RoleCustomer:
+		return CustomerScopes
+	case RoleSupport:
+		return []Scope{ScopeAccountsRead, ScopeTransfersRead, ScopeAuditRead}
+	case RoleAdmin:
+		return []Scope{
+			ScopeAccountsRead,
+			ScopeAccountsWrite,
+			ScopeTransfersRead,
+			ScopeTransfersWrite,
+			ScopeTransfersApprove,
+			ScopeAPIKeysAdmin,
+			ScopeWebhooksAdmin,
+			ScopeAuditRead,
+		}
+	default:
+		return nil
+	}
+}
+
+func (r Role) String() string {
+	return string(r)
+}
@@ -2,36 +2,204 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+var (
+	ErrTransferNotFound        = errors.New("transfer not found")
+	ErrTransferNotPending      = errors.New("transfer is not awaiting a decision")
+	ErrTransferApprovalExpired = errors.New("transfer approval expired")
+	ErrApproverIsRequester     = errors.New("transfer must be decided by a different user than its requester")
+
+	ErrInvalidTransferStatus     = errors.New("invalid transfer status")
+	ErrInvalidTransferTransition = errors.New("invalid transfer status transition")
+)
+
 type TransferID string
 
 func (t TransferID) String() string {
 	return string(t)
 }
 
+type TransferStatus string
+
+const (
+	// TransferStatusPending transfers are being processed and did not move the money yet
+	TransferStatusPending TransferStatus = "pending"
+	// TransferStatusCompleted transfers moved the money between the accounts
+	TransferStatusCompleted TransferStatus = "completed"
+	// TransferStatusFailed transfers could not be executed, the reason is kept in their failure code
+	TransferStatusFailed TransferStatus = "failed"
+	// TransferStatusReversed transfers were completed and had the money given back afterwards
+	TransferStatusReversed TransferStatus = "reversed"
+	// TransferStatusCancelled transfers were withdrawn before moving the money
+	TransferStatusCancelled TransferStatus = "cancelled"
+	// TransferStatusHeld transfers were flagged for review by the risk rules and wait for a manual decision
+	TransferStatusHeld TransferStatus = "held"
+	// TransferStatusPendingApproval transfers are over the approval threshold and wait for a second user
+	TransferStatusPendingApproval TransferStatus = "pending_approval"
+	// TransferStatusRejected transfers were turned down without moving the money
+	TransferStatusRejected TransferStatus = "rejected"
+	// TransferStatusExpired transfers were not decided before the approval timeout
+	TransferStatusExpired TransferStatus = "expired"
+)
+
+// transferTransitions lists the statuses each status may move to. Statuses without entries are final
+var transferTransitions = map[TransferStatus][]TransferStatus{
+	TransferStatusPending: {
+		TransferStatusCompleted,
+		TransferStatusFailed,
+		TransferStatusHeld,
+		TransferStatusPendingApproval,
+		TransferStatusCancelled,
+	},
+	TransferStatusHeld: {
+		TransferStatusCompleted,
+		TransferStatusFailed,
+		TransferStatusRejected,
+		TransferStatusExpired,
+		TransferStatusCancelled,
+	},
+	TransferStatusPendingApproval: {
+		TransferStatusCompleted,
+		TransferStatusFailed,
+		TransferStatusRejected,
+		TransferStatusExpired,
+		TransferStatusCancelled,
+	},
+	TransferStatusCompleted: {
+		TransferStatusReversed,
+	},
+}
+
+// ParseTransferStatus converts a status filter or stored status, rejecting unknown values
+func ParseTransferStatus(s string) (TransferStatus, error) {
+	switch status := TransferStatus(s); status {
+	case TransferStatusPending,
+		TransferStatusCompleted,
+		TransferStatusFailed,
+		TransferStatusReversed,
+		TransferStatusCancelled,
+		TransferStatusHeld,
+		TransferStatusPendingApproval,
+		TransferStatusRejected,
+		TransferStatusExpired:
+		return status, nil
+	default:
+		return "", ErrInvalidTransferStatus
+	}
+}
+
+func (t TransferStatus) String() string {
+	return string(t)
+}
+
+// CanTransitionTo reports whether a transfer in this status may move to next
+func (t TransferStatus) CanTransitionTo(next TransferStatus) bool {
+	for _, allowed := range transferTransitions[t] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TransferFailureCode tells why a failed transfer could not be executed
+type TransferFailureCode string
+
+const (
+	TransferFailureInsufficientBalance        TransferFailureCode = "insufficient_balance"
+	TransferFailureAccountOriginNotFound      TransferFailureCode = "account_origin_not_found"
+	TransferFailureAccountDestinationNotFound TransferFailureCode = "account_destination_not_found"
+	TransferFailureRiskDenied                 TransferFailureCode = "risk_denied"
+)
+
+func (t TransferFailureCode) String() string {
+	return string(t)
+}
+
+// TransferFailureCodeOf returns the failure code of the errors that end a transfer attempt as failed. Other errors,
+// such as infrastructure ones or a forbidden caller, leave no transfer behind
+func TransferFailureCodeOf(err error) (TransferFailureCode, bool) {
+	switch err {
+	case ErrInsufficientBalance:
+		return TransferFailureInsufficientBalance, true
+	case ErrAccountOriginNotFound:
+		return TransferFailureAccountOriginNotFound, true
+	case ErrAccountDestinationNotFound:
+		return TransferFailureAccountDestinationNotFound, true
+	case ErrTransferDenied:
+		return TransferFailureRiskDenied, true
+	default:
+		return "", false
+	}
+}
+
 type (
 	TransferRepository interface {
 		Create(context.Context, Transfer) (Transfer, error)
-		FindAll(context.Context) ([]Transfer, error)
+		// FindAll returns the page of the transfers matching the filter, in the order of its sort
+		FindAll(context.Context, TransferFilter, PageRequest) ([]Transfer, error)
+		// Stream hands the transfers matching the filter to the function one at a time, oldest first, stopping at the
+		// first error it returns
+		Stream(context.Context, TransferFilter, func(Transfer) error) error
+		// Summarize counts and sums the transfers matching the filter, without reading them
+		Summarize(context.Context, TransferFilter) (TransferSummary, error)
+		FindByID(context.Context, TransferID) (Transfer, error)
+		UpdateStatus(context.Context, TransferID, TransferStatus) error
 		WithTransaction(context.Context, func(context.Context) error) error
 	}
 
+	// TransferFilter narrows a transfer listing. Zero fields match every transfer
+	TransferFilter struct {
+		// AccountID matches transfers where the account is the origin or the destination
+		AccountID            AccountID
+		AccountOriginID      AccountID
+		AccountDestinationID AccountID
+		Status               TransferStatus
+		// From and To bound the creation time, From included and To excluded. Zero times leave the bound open
+		From time.Time
+		To   time.Time
+		// MinAmount and MaxAmount bound the amount, both included. Zero amounts leave the bound open
+		MinAmount Money
+		MaxAmount Money
+	}
+
+	// TransferSummary aggregates the transfers matching a filter
+	TransferSummary struct {
+		Count int64
+		Total Money
+	}
+
 	Transfer struct {
 		id                   TransferID
 		accountOriginID      AccountID
 		accountDestinationID AccountID
 		amount               Money
+		status               TransferStatus
+		failureCode          TransferFailureCode
 		createdAt            time.Time
 	}
 )
 
+// NewTransferCursor creates the cursor of the transfer in a listing of the sort
+func NewTransferCursor(sort Sort, transfer Transfer) Cursor {
+	return Cursor{
+		Sort:      sort,
+		CreatedAt: transfer.CreatedAt().UTC(),
+		Amount:    transfer.Amount(),
+		ID:        transfer.ID().String(),
+	}
+}
+
 func NewTransfer(
 	ID TransferID,
 	accountOriginID AccountID,
 	accountDestinationID AccountID,
 	amount Money,
+	status TransferStatus,
 	createdAt time.Time,
 ) Transfer {
 	return Transfer{
@@ -39,10 +207,25 @@ func NewTransfer(
 		accountOriginID:      accountOriginID,
 		accountDestinationID: accountDestinationID,
 		amount:               amount,
+		status:               status,
 		createdAt:            createdAt,
 	}
 }
 
+// NewFailedTransfer creates a transfer that could not be executed, keeping why
+func NewFailedTransfer(
+	ID TransferID,
+	accountOriginID AccountID,
+	accountDestinationID AccountID,
+	amount Money,
+	failureCode TransferFailureCode,
+	createdAt time.Time,
+) Transfer {
+	var t = NewTransfer(ID, accountOriginID, accountDestinationID, amount, TransferStatusFailed, createdAt)
+	t.failureCode = failureCode
+	return t
+}
+
 func (t Transfer) ID() TransferID {
 	return t.id
 }
@@ -59,6 +242,41 @@ func (t Transfer) Amount() Money {
 	return t.amount
 }
 
+func (t Transfer) Status() TransferStatus {
+	return t.status
+}
+
+// AwaitingDecision reports whether the transfer waits for a user to approve or reject it
+func (t Transfer) AwaitingDecision() bool {
+	return t.status == TransferStatusHeld || t.status == TransferStatusPendingApproval
+}
+
+// TransitionTo returns a copy of the transfer in the status, when its current status allows moving there
+func (t Transfer) TransitionTo(status TransferStatus) (Transfer, error) {
+	if status == TransferStatusFailed || !t.status.CanTransitionTo(status) {
+		return Transfer{}, ErrInvalidTransferTransition
+	}
+
+	t.status = status
+	return t, nil
+}
+
+// Fail returns a copy of the transfer failed with the code, when its current status allows it
+func (t Transfer) Fail(code TransferFailureCode) (Transfer, error) {
+	if !t.status.CanTransitionTo(TransferStatusFailed) {
+		return Transfer{}, ErrInvalidTransferTransition
+	}
+
+	t.status = TransferStatusFailed
+	t.failureCode = code
+	return t, nil
+}
+
+// FailureCode is empty unless the transfer failed
+func (t Transfer) FailureCode() TransferFailureCode {
+	return t.failureCode
+}
+
 func (t Transfer) CreatedAt() time.Time {
 	return t.createdAt
 }
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ApprovalAction is a step in the approval history of a transfer
+type ApprovalAction string
+
+const (
+	ApprovalRequested ApprovalAction = "requested"
+	ApprovalApproved  ApprovalAction = "approved"
+	ApprovalRejected  ApprovalAction = "rejected"
+	ApprovalExpired   ApprovalAction = "expired"
+)
+
+// ApprovalActorSystem is the actor of the steps taken without a user, such as expirations
+const ApprovalActorSystem = "system"
+
+func (a ApprovalAction) String() string {
+	return string(a)
+}
+
+type TransferApprovalID string
+
+func (t TransferApprovalID) String() string {
+	return string(t)
+}
+
+type (
+	TransferApprovalRepository interface {
+		Create(context.Context, TransferApproval) (TransferApproval, error)
+		FindAllByTransfer(context.Context, TransferID) ([]TransferApproval, error)
+	}
+
+	// TransferApproval records who took a step in the approval of a transfer, forming its history
+	TransferApproval struct {
+		id         TransferApprovalID
+		transferID TransferID
+		action     ApprovalAction
+		actor      string
+		role       Role
+		reason     string
+		createdAt  time.Time
+	}
+)
+
+func NewTransferApproval(
+	ID TransferApprovalID,
+	transferID TransferID,
+	action ApprovalAction,
+	actor string,
+	role Role,
+	reason string,
+	createdAt time.Time,
+) TransferApproval {
+	return TransferApproval{
+		id:         ID,
+		transferID: transferID,
+		action:     action,
+		actor:      actor,
+		role:       role,
+		reason:     reason,
+		createdAt:  createdAt,
+	}
+}
+
+func (t TransferApproval) ID() TransferApprovalID {
+	return t.id
+}
+
+func (t TransferApproval) TransferID() TransferID {
+	return t.transferID
+}
+
+func (t TransferApproval) Action() ApprovalAction {
+	return t.action
+}
+
+func (t TransferApproval) Actor() string {
+	return t.actor
+}
+
+func (t TransferApproval) Role() Role {
+	return t.role
+}
+
+func (t TransferApproval) Reason() string {
+	return t.reason
+}
+
+func (t TransferApproval) CreatedAt() time.Time {
+	return t.createdAt
+}
@@ -0,0 +1,380 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var (
+	ErrTransferImportNotFound = errors.New("transfer import not found")
+
+	ErrDuplicateTransferImport = errors.New("file already imported")
+
+	ErrInvalidTransferImportStatus = errors.New("invalid transfer import status")
+
+	// ErrTransferImportInterrupted fails the lines whose transfer was being created when the job stopped. The
+	// transfer may have been made, so they are never run again
+	ErrTransferImportInterrupted = errors.New("import interrupted while creating the transfer, check it before importing the line again")
+)
+
+type TransferImportID string
+
+func (t TransferImportID) String() string {
+	return string(t)
+}
+
+// TransferImportFormat is the kind of file the transfers were read from
+type TransferImportFormat string
+
+const (
+	TransferImportCSV     TransferImportFormat = "csv"
+	TransferImportPain001 TransferImportFormat = "pain001"
+)
+
+func (t TransferImportFormat) String() string {
+	return string(t)
+}
+
+// TransferImportStatus is where an import job is
+type TransferImportStatus string
+
+const (
+	// TransferImportPending jobs wait for the import job
+	TransferImportPending   TransferImportStatus = "pending"
+	TransferImportRunning   TransferImportStatus = "running"
+	TransferImportCompleted TransferImportStatus = "completed"
+)
+
+func (t TransferImportStatus) String() string {
+	return string(t)
+}
+
+// TransferImportLineStatus is the result of a line of the file
+type TransferImportLineStatus string
+
+const (
+	// TransferImportLineInvalid lines did not pass the validation and are never run
+	TransferImportLineInvalid TransferImportLineStatus = "invalid"
+	TransferImportLinePending TransferImportLineStatus = "pending"
+	// TransferImportLineRunning lines are creating their transfer
+	TransferImportLineRunning TransferImportLineStatus = "running"
+	TransferImportLineCreated TransferImportLineStatus = "created"
+	TransferImportLineFailed  TransferImportLineStatus = "failed"
+)
+
+func (t TransferImportLineStatus) String() string {
+	return string(t)
+}
+
+type (
+	TransferImportRepository interface {
+		// Create stores the job and its lines, failing with ErrDuplicateTransferImport when a job of the same requester
+		// has the same content hash
+		Create(context.Context, TransferImport) error
+		// Update writes the status of the job and its last error, not its lines nor its lease
+		Update(context.Context, TransferImport) error
+		UpdateLine(context.Context, TransferImportID, TransferImportLine) error
+		// ClaimLine writes the running line only when the stored one is still pending, telling whether it did: a line
+		// claimed by another run is left to it
+		ClaimLine(context.Context, TransferImportID, TransferImportLine) (bool, error)
+		FindByID(context.Context, TransferImportID) (TransferImport, error)
+		// ClaimDue returns up to limit jobs not completed whose lease expired at now, oldest first, leased to the caller
+		// until the second time, so import jobs running side by side never run the same job while its lease lasts
+		ClaimDue(ctx context.Context, now time.Time, until time.Time, limit int) ([]TransferImport, error)
+	}
+
+	// TransferImportLine is a transfer read from an imported file and what became of it
+	TransferImportLine struct {
+		number               int
+		accountOriginID      AccountID
+		accountDestinationID AccountID
+		amount               Money
+		status               TransferImportLineStatus
+		errors               []string
+		transferID           TransferID
+		transferStatus       TransferStatus
+	}
+
+	// TransferImport is a file of transfers created one line at a time by the import job, on behalf of the principal
+	// who uploaded it
+	TransferImport struct {
+		id          TransferImportID
+		fileName    string
+		format      TransferImportFormat
+		contentHash string
+		status      TransferImportStatus
+		requestedBy Principal
+		lines       []TransferImportLine
+		lastError   string
+		createdAt   time.Time
+		startedAt   time.Time
+		finishedAt  time.Time
+	}
+)
+
+// TransferImportContentHash identifies a file by its content, whatever its name
+func TransferImportContentHash(content []byte) string {
+	var sum = sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// NewTransferImportLine restores a line
+func NewTransferImportLine(
+	number int,
+	accountOriginID AccountID,
+	accountDestinationID AccountID,
+	amount Money,
+	status TransferImportLineStatus,
+	errs []string,
+	transferID TransferID,
+	transferStatus TransferStatus,
+) TransferImportLine {
+	return TransferImportLine{
+		number:               number,
+		accountOriginID:      accountOriginID,
+		accountDestinationID: accountDestinationID,
+		amount:               amount,
+		status:               status,
+		errors:               errs,
+		transferID:           transferID,
+		transferStatus:       transferStatus,
+	}
+}
+
+// NewReadTransferImportLine is a line as read from the file. Lines with errors are invalid, the others pending
+func NewReadTransferImportLine(
+	number int,
+	accountOriginID AccountID,
+	accountDestinationID AccountID,
+	amount Money,
+	errs []string,
+) TransferImportLine {
+	var status = TransferImportLinePending
+	if len(errs) > 0 {
+		status = TransferImportLineInvalid
+	}
+
+	return NewTransferImportLine(number, accountOriginID, accountDestinationID, amount, status, errs, "", "")
+}
+
+// Run marks a pending line as creating its transfer
+func (t TransferImportLine) Run() (TransferImportLine, error) {
+	if t.status != TransferImportLinePending {
+		return t, ErrInvalidTransferImportStatus
+	}
+
+	t.status = TransferImportLineRunning
+	return t, nil
+}
+
+// Succeed ends a running line with the transfer it created
+func (t TransferImportLine) Succeed(transferID TransferID, transferStatus TransferStatus) (TransferImportLine, error) {
+	if t.status != TransferImportLineRunning {
+		return t, ErrInvalidTransferImportStatus
+	}
+
+	t.status = TransferImportLineCreated
+	t.transferID = transferID
+	t.transferStatus = transferStatus
+	return t, nil
+}
+
+// Fail ends a running line whose transfer could not be created
+func (t TransferImportLine) Fail(err error) (TransferImportLine, error) {
+	if t.status != TransferImportLineRunning {
+		return t, ErrInvalidTransferImportStatus
+	}
+
+	t.status = TransferImportLineFailed
+	t.errors = append(t.errors, err.Error())
+	return t, nil
+}
+
+func (t TransferImportLine) Number() int {
+	return t.number
+}
+
+func (t TransferImportLine) AccountOriginID() AccountID {
+	return t.accountOriginID
+}
+
+func (t TransferImportLine) AccountDestinationID() AccountID {
+	return t.accountDestinationID
+}
+
+func (t TransferImportLine) Amount() Money {
+	return t.amount
+}
+
+func (t TransferImportLine) Status() TransferImportLineStatus {
+	return t.status
+}
+
+func (t TransferImportLine) Errors() []string {
+	return t.errors
+}
+
+func (t TransferImportLine) TransferID() TransferID {
+	return t.transferID
+}
+
+func (t TransferImportLine) TransferStatus() TransferStatus {
+	return t.transferStatus
+}
+
+// NewTransferImport restores a job
+func NewTransferImport(
+	ID TransferImportID,
+	fileName string,
+	format TransferImportFormat,
+	contentHash string,
+	status TransferImportStatus,
+	requestedBy Principal,
+	lines []TransferImportLine,
+	lastError string,
+	createdAt time.Time,
+	startedAt time.Time,
+	finishedAt time.Time,
+) TransferImport {
+	return TransferImport{
+		id:          ID,
+		fileName:    fileName,
+		format:      format,
+		contentHash: contentHash,
+		status:      status,
+		requestedBy: requestedBy,
+		lines:       lines,
+		lastError:   lastError,
+		createdAt:   createdAt,
+		startedAt:   startedAt,
+		finishedAt:  finishedAt,
+	}
+}
+
+// NewPendingTransferImport queues the lines read from a file for the import job
+func NewPendingTransferImport(
+	ID TransferImportID,
+	fileName string,
+	format TransferImportFormat,
+	contentHash string,
+	requestedBy Principal,
+	lines []TransferImportLine,
+	at time.Time,
+) TransferImport {
+	return NewTransferImport(
+		ID,
+		fileName,
+		format,
+		contentHash,
+		TransferImportPending,
+		requestedBy,
+		lines,
+		"",
+		at,
+		time.Time{},
+		time.Time{},
+	)
+}
+
+// Start takes a pending job
+func (t TransferImport) Start(at time.Time) (TransferImport, error) {
+	if t.status != TransferImportPending {
+		return t, ErrInvalidTransferImportStatus
+	}
+
+	t.status = TransferImportRunning
+	t.startedAt = at
+	return t, nil
+}
+
+// Complete ends a running job once every line has its result, forgetting the error of an earlier run
+func (t TransferImport) Complete(at time.Time) (TransferImport, error) {
+	if t.status != TransferImportRunning {
+		return t, ErrInvalidTransferImportStatus
+	}
+
+	t.status = TransferImportCompleted
+	t.lastError = ""
+	t.finishedAt = at
+	return t, nil
+}
+
+// Interrupt records the error that stopped the run of the job, which is run again once its lease expires
+func (t TransferImport) Interrupt(err error) TransferImport {
+	t.lastError = err.Error()
+	return t
+}
+
+// WithLine replaces the line of the same number
+func (t TransferImport) WithLine(line TransferImportLine) TransferImport {
+	var lines = make([]TransferImportLine, len(t.lines))
+	copy(lines, t.lines)
+
+	for i := range lines {
+		if lines[i].number == line.number {
+			lines[i] = line
+		}
+	}
+
+	t.lines = lines
+	return t
+}
+
+// Count is the number of lines with the status
+func (t TransferImport) Count(status TransferImportLineStatus) int {
+	var count int
+	for _, line := range t.lines {
+		if line.status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (t TransferImport) ID() TransferImportID {
+	return t.id
+}
+
+func (t TransferImport) FileName() string {
+	return t.fileName
+}
+
+func (t TransferImport) Format() TransferImportFormat {
+	return t.format
+}
+
+func (t TransferImport) ContentHash() string {
+	return t.contentHash
+}
+
+func (t TransferImport) Status() TransferImportStatus {
+	return t.status
+}
+
+func (t TransferImport) RequestedBy() Principal {
+	return t.requestedBy
+}
+
+func (t TransferImport) Lines() []TransferImportLine {
+	return t.lines
+}
+
+func (t TransferImport) LastError() string {
+	return t.lastError
+}
+
+func (t TransferImport) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t TransferImport) StartedAt() time.Time {
+	return t.startedAt
+}
+
+func (t TransferImport) FinishedAt() time.Time {
+	return t.finishedAt
+}
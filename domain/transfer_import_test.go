@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTransferImportLine_Transitions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pending    = NewReadTransferImportLine(2, "3c096a40-ccba-4b58-93ed-57379ab04680", "a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c", 100, nil)
+		invalid    = NewReadTransferImportLine(3, "", "", 0, []string{"amount is required"})
+		running, _ = pending.Run()
+	)
+
+	tests := []struct {
+		name           string
+		transition     func() (TransferImportLine, error)
+		expectedStatus TransferImportLineStatus
+		expectedError  error
+	}{
+		{
+			name:           "Pending line runs",
+			transition:     pending.Run,
+			expectedStatus: TransferImportLineRunning,
+		},
+		{
+			name: "Running line creates its transfer",
+			transition: func() (TransferImportLine, error) {
+				return running.Succeed("5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10", TransferStatusCompleted)
+			},
+			expectedStatus: TransferImportLineCreated,
+		},
+		{
+			name:           "Running line fails",
+			transition:     func() (TransferImportLine, error) { return running.Fail(ErrInsufficientBalance) },
+			expectedStatus: TransferImportLineFailed,
+		},
+		{
+			name:           "Invalid line does not run",
+			transition:     invalid.Run,
+			expectedStatus: TransferImportLineInvalid,
+			expectedError:  ErrInvalidTransferImportStatus,
+		},
+		{
+			name: "Pending line does not create a transfer",
+			transition: func() (TransferImportLine, error) {
+				return pending.Succeed("5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10", TransferStatusCompleted)
+			},
+			expectedStatus: TransferImportLinePending,
+			expectedError:  ErrInvalidTransferImportStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.transition()
+			if err != tt.expectedError {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+			}
+
+			if got.Status() != tt.expectedStatus {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got.Status(), tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestTransferImport_Transitions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now     = time.Now()
+		pending = NewPendingTransferImport(
+			"0b4c4b5e-8a0f-4f0c-9f4d-7c2c5f1d9e11",
+			"payroll.csv",
+			TransferImportCSV,
+			TransferImportContentHash([]byte("payroll")),
+			NewPrincipal("admin", RoleAdmin),
+			nil,
+			now,
+		)
+		running, _  = pending.Start(now)
+		interrupted = running.Interrupt(ErrTransferImportInterrupted)
+	)
+
+	tests := []struct {
+		name              string
+		transition        func() (TransferImport, error)
+		expectedStatus    TransferImportStatus
+		expectedLastError string
+		expectedError     error
+	}{
+		{
+			name:           "Pending job starts",
+			transition:     func() (TransferImport, error) { return pending.Start(now) },
+			expectedStatus: TransferImportRunning,
+		},
+		{
+			name:           "Running job completes",
+			transition:     func() (TransferImport, error) { return running.Complete(now) },
+			expectedStatus: TransferImportCompleted,
+		},
+		{
+			name:              "Interrupted job keeps its error while it runs",
+			transition:        func() (TransferImport, error) { return interrupted, nil },
+			expectedStatus:    TransferImportRunning,
+			expectedLastError: ErrTransferImportInterrupted.Error(),
+		},
+		{
+			name:           "Interrupted job forgets its error once completed",
+			transition:     func() (TransferImport, error) { return interrupted.Complete(now) },
+			expectedStatus: TransferImportCompleted,
+		},
+		{
+			name:           "Running job does not start again",
+			transition:     func() (TransferImport, error) { return running.Start(now) },
+			expectedStatus: TransferImportRunning,
+			expectedError:  ErrInvalidTransferImportStatus,
+		},
+		{
+			name:           "Pending job does not complete",
+			transition:     func() (TransferImport, error) { return pending.Complete(now) },
+			expectedStatus: TransferImportPending,
+			expectedError:  ErrInvalidTransferImportStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.transition()
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+			}
+
+			if got.Status() != tt.expectedStatus {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got.Status(), tt.expectedStatus)
+			}
+
+			if got.LastError() != tt.expectedLastError {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got.LastError(), tt.expectedLastError)
+			}
+		})
+	}
+}
+
+func TestTransferImport_WithLine(t *testing.T) {
+	t.Parallel()
+
+	var (
+		first  = NewReadTransferImportLine(2, "3c096a40-ccba-4b58-93ed-57379ab04680", "a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c", 100, nil)
+		second = NewReadTransferImportLine(3, "3c096a40-ccba-4b58-93ed-57379ab04680", "a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c", 200, nil)
+		job    = NewPendingTransferImport(
+			"0b4c4b5e-8a0f-4f0c-9f4d-7c2c5f1d9e11",
+			"payroll.csv",
+			TransferImportCSV,
+			"",
+			NewPrincipal("admin", RoleAdmin),
+			[]TransferImportLine{first, second},
+			time.Now(),
+		)
+		running, _ = second.Run()
+		got        = job.WithLine(running)
+	)
+
+	if got.Count(TransferImportLineRunning) != 1 || got.Count(TransferImportLinePending) != 1 {
+		t.Errorf("[TestCase '%s'] Result: '%+v' | Expected: '%v'", "Replaces the line of the same number", got.Lines(), running)
+	}
+
+	if job.Count(TransferImportLinePending) != 2 {
+		t.Errorf("[TestCase '%s'] Result: '%+v' | Expected: '%v'", "Keeps the lines of the job given", job.Lines(), 2)
+	}
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTransfer_TransitionTo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		from        TransferStatus
+		to          TransferStatus
+		expectedErr error
+	}{
+		{name: "Pending to completed", from: TransferStatusPending, to: TransferStatusCompleted},
+		{name: "Pending to held", from: TransferStatusPending, to: TransferStatusHeld},
+		{name: "Pending to pending approval", from: TransferStatusPending, to: TransferStatusPendingApproval},
+		{name: "Pending to cancelled", from: TransferStatusPending, to: TransferStatusCancelled},
+		{name: "Held to completed", from: TransferStatusHeld, to: TransferStatusCompleted},
+		{name: "Pending approval to rejected", from: TransferStatusPendingApproval, to: TransferStatusRejected},
+		{name: "Pending approval to expired", from: TransferStatusPendingApproval, to: TransferStatusExpired},
+		{name: "Completed to reversed", from: TransferStatusCompleted, to: TransferStatusReversed},
+		{
+			name:        "Completed to cancelled",
+			from:        TransferStatusCompleted,
+			to:          TransferStatusCancelled,
+			expectedErr: ErrInvalidTransferTransition,
+		},
+		{
+			name:        "Reversed is final",
+			from:        TransferStatusReversed,
+			to:          TransferStatusCompleted,
+			expectedErr: ErrInvalidTransferTransition,
+		},
+		{
+			name:        "Failed is final",
+			from:        TransferStatusFailed,
+			to:          TransferStatusCompleted,
+			expectedErr: ErrInvalidTransferTransition,
+		},
+		{
+			name:        "Pending to reversed",
+			from:        TransferStatusPending,
+			to:          TransferStatusReversed,
+			expectedErr: ErrInvalidTransferTransition,
+		},
+		{
+			name:        "Failing requires a failure code",
+			from:        TransferStatusPending,
+			to:          TransferStatusFailed,
+			expectedErr: ErrInvalidTransferTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var transfer = NewTransfer("", "", "", 100, tt.from, time.Time{})
+
+			got, err := transfer.TransitionTo(tt.to)
+			if err != tt.expectedErr {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedErr)
+				return
+			}
+
+			if err == nil && got.Status() != tt.to {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got.Status(), tt.to)
+			}
+		})
+	}
+}
+
+func TestTransfer_Fail(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		from        TransferStatus
+		expectedErr error
+	}{
+		{name: "Pending fails", from: TransferStatusPending},
+		{name: "Held fails", from: TransferStatusHeld},
+		{name: "Completed can not fail", from: TransferStatusCompleted, expectedErr: ErrInvalidTransferTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var transfer = NewTransfer("", "", "", 100, tt.from, time.Time{})
+
+			got, err := transfer.Fail(TransferFailureInsufficientBalance)
+			if err != tt.expectedErr {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedErr)
+				return
+			}
+
+			if err == nil && (got.Status() != TransferStatusFailed || got.FailureCode() != TransferFailureInsufficientBalance) {
+				t.Errorf("[TestCase '%s'] Result: '%v' '%v'", tt.name, got.Status(), got.FailureCode())
+			}
+		})
+	}
+}
+
+func TestParseTransferStatus(t *testing.T) {
+	t.Parallel()
+
+	if got, err := ParseTransferStatus("failed"); err != nil || got != TransferStatusFailed {
+		t.Errorf("Result: '%v' '%v' | Expected: '%v'", got, err, TransferStatusFailed)
+	}
+
+	if _, err := ParseTransferStatus("lost"); err != ErrInvalidTransferStatus {
+		t.Errorf("Result: '%v' | ExpectedError: '%v'", err, ErrInvalidTransferStatus)
+	}
+}
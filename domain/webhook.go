@@ -0,0 +1,392 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+	ErrWebhookDisabled = errors.New("webhook is disabled")
+
+	ErrWebhookDeliveryPending = errors.New("webhook delivery is still pending")
+
+	ErrWebhookURLNotAllowed = errors.New("webhook URL must reach a public address")
+)
+
+// WebhookSignatureHeader carries the signature of a delivery, WebhookTimestampHeader the time it is signed with
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+)
+
+type WebhookID string
+
+func (w WebhookID) String() string {
+	return string(w)
+}
+
+type WebhookDeliveryID string
+
+func (w WebhookDeliveryID) String() string {
+	return string(w)
+}
+
+// WebhookDeliveryStatus is where a delivery is in its attempts
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending deliveries wait to be posted, now or after a failed attempt
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliverySucceeded deliveries were answered with a 2xx status
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryFailed deliveries failed every attempt, or their webhook was disabled, until redelivered
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+func (w WebhookDeliveryStatus) String() string {
+	return string(w)
+}
+
+type (
+	WebhookRepository interface {
+		Create(context.Context, Webhook) (Webhook, error)
+		// Update writes the failures of the webhook and when it was disabled
+		Update(context.Context, Webhook) error
+		FindAll(context.Context) ([]Webhook, error)
+		// FindByID locks the webhook when called inside a transaction, so the changes of its failures and of its
+		// state are serialized
+		FindByID(context.Context, WebhookID) (Webhook, error)
+		// FindByEvent returns the enabled webhooks subscribed to the event name and watching any of the accounts
+		FindByEvent(context.Context, EventName, []AccountID) ([]Webhook, error)
+		WithTransaction(context.Context, func(context.Context) error) error
+	}
+
+	WebhookDeliveryRepository interface {
+		// Create skips the deliveries of an event its webhook already has, so an event published twice is posted once
+		Create(context.Context, ...WebhookDelivery) error
+		Update(context.Context, WebhookDelivery) error
+		FindByID(context.Context, WebhookDeliveryID) (WebhookDelivery, error)
+		// FindByWebhook returns the deliveries of the webhook, the latest first
+		FindByWebhook(context.Context, WebhookID) ([]WebhookDelivery, error)
+		// ClaimDue returns up to limit pending deliveries due at now, in the order they were created, leased to the
+		// caller until the second time: they are not due again before it unless updated, so deliverers running side by
+		// side never post the same delivery while its lease lasts
+		ClaimDue(ctx context.Context, now time.Time, until time.Time, limit int) ([]WebhookDelivery, error)
+	}
+
+	// WebhookSender posts a delivery to the URL of its webhook, signed with its secret. It returns the HTTP status of
+	// the answer, zero when there was none. Any status other than 2xx is an error
+	WebhookSender interface {
+		Send(context.Context, Webhook, WebhookDelivery) (int, error)
+		// CheckURL returns ErrWebhookURLNotAllowed unless deliveries may be posted to the URL: an http or https URL
+		// whose host only resolves to public addresses, or to networks the sender allows
+		CheckURL(context.Context, string) error
+	}
+
+	// Webhook is a subscription of a partner to the events of the accounts it watches. The owner is the subject of the
+	// principal who created it. The secret signs the deliveries, so it is kept as given
+	Webhook struct {
+		id         WebhookID
+		owner      string
+		url        string
+		events     []EventName
+		accountIDs []AccountID
+		secret     string
+		failures   int
+		disabledAt time.Time
+		createdAt  time.Time
+	}
+
+	// WebhookDelivery is an event to be posted to a webhook, with the state of its attempts
+	WebhookDelivery struct {
+		id             WebhookDeliveryID
+		webhookID      WebhookID
+		eventID        string
+		eventName      EventName
+		payload        []byte
+		status         WebhookDeliveryStatus
+		attempts       int
+		nextAttemptAt  time.Time
+		responseStatus int
+		lastError      string
+		deliveredAt    time.Time
+		createdAt      time.Time
+	}
+)
+
+func NewWebhook(
+	ID WebhookID,
+	owner string,
+	url string,
+	events []EventName,
+	accountIDs []AccountID,
+	secret string,
+	failures int,
+	disabledAt time.Time,
+	createdAt time.Time,
+) Webhook {
+	return Webhook{
+		id:         ID,
+		owner:      owner,
+		url:        url,
+		events:     events,
+		accountIDs: accountIDs,
+		secret:     secret,
+		failures:   failures,
+		disabledAt: disabledAt,
+		createdAt:  createdAt,
+	}
+}
+
+// GenerateWebhookSecret returns a random secret for webhooks created without one
+func GenerateWebhookSecret() (string, error) {
+	var buf = make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+// Sign returns the value of WebhookSignatureHeader: the hex HMAC-SHA256, under the secret, of the timestamp in Unix
+// seconds, a dot and the body. Receivers recompute it and reject old timestamps to stop replays
+func (w Webhook) Sign(timestamp time.Time, body []byte) string {
+	var mac = hmac.New(sha256.New, []byte(w.secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Subscribes reports whether the webhook wants the events of the name
+func (w Webhook) Subscribes(name EventName) bool {
+	for _, e := range w.events {
+		if e == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Watches reports whether the webhook is scoped to any of the accounts
+func (w Webhook) Watches(accountIDs []AccountID) bool {
+	for _, watched := range w.accountIDs {
+		for _, ID := range accountIDs {
+			if watched == ID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// OwnedBy reports whether the principal created the webhook
+func (w Webhook) OwnedBy(p Principal) bool {
+	return w.owner != "" && w.owner == p.Subject()
+}
+
+func (w Webhook) Enabled() bool {
+	return w.disabledAt.IsZero()
+}
+
+// Enable lets the webhook receive deliveries again, forgetting its failures
+func (w *Webhook) Enable() {
+	w.disabledAt = time.Time{}
+	w.failures = 0
+}
+
+func (w *Webhook) Disable(now time.Time) {
+	if w.disabledAt.IsZero() {
+		w.disabledAt = now
+	}
+}
+
+// RecordSuccess resets the consecutive failures
+func (w *Webhook) RecordSuccess() {
+	w.failures = 0
+}
+
+// RecordFailure counts a failed attempt and disables the webhook once disableAfter attempts failed in a row. It
+// reports whether this failure disabled it
+func (w *Webhook) RecordFailure(now time.Time, disableAfter int) bool {
+	w.failures++
+
+	if w.Enabled() && w.failures >= disableAfter {
+		w.Disable(now)
+		return true
+	}
+
+	return false
+}
+
+func (w Webhook) ID() WebhookID {
+	return w.id
+}
+
+func (w Webhook) Owner() string {
+	return w.owner
+}
+
+func (w Webhook) URL() string {
+	return w.url
+}
+
+func (w Webhook) Events() []EventName {
+	return w.events
+}
+
+// AccountIDs are the accounts whose events the webhook receives
+func (w Webhook) AccountIDs() []AccountID {
+	return w.accountIDs
+}
+
+func (w Webhook) Secret() string {
+	return w.secret
+}
+
+// Failures is how many attempts failed in a row
+func (w Webhook) Failures() int {
+	return w.failures
+}
+
+func (w Webhook) DisabledAt() time.Time {
+	return w.disabledAt
+}
+
+func (w Webhook) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+func NewWebhookDelivery(
+	ID WebhookDeliveryID,
+	webhookID WebhookID,
+	eventID string,
+	eventName EventName,
+	payload []byte,
+	status WebhookDeliveryStatus,
+	attempts int,
+	nextAttemptAt time.Time,
+	responseStatus int,
+	lastError string,
+	deliveredAt time.Time,
+	createdAt time.Time,
+) WebhookDelivery {
+	return WebhookDelivery{
+		id:             ID,
+		webhookID:      webhookID,
+		eventID:        eventID,
+		eventName:      eventName,
+		payload:        payload,
+		status:         status,
+		attempts:       attempts,
+		nextAttemptAt:  nextAttemptAt,
+		responseStatus: responseStatus,
+		lastError:      lastError,
+		deliveredAt:    deliveredAt,
+		createdAt:      createdAt,
+	}
+}
+
+// Due reports whether a pending delivery may be attempted at now
+func (w WebhookDelivery) Due(now time.Time) bool {
+	return w.status == WebhookDeliveryPending && !w.nextAttemptAt.After(now)
+}
+
+// MarkSucceeded records the attempt answered with the 2xx status
+func (w WebhookDelivery) MarkSucceeded(responseStatus int, at time.Time) WebhookDelivery {
+	w.status = WebhookDeliverySucceeded
+	w.attempts++
+	w.responseStatus = responseStatus
+	w.lastError = ""
+	w.deliveredAt = at
+	return w
+}
+
+// MarkFailed records a failed attempt, either to be retried at nextAttemptAt or, when failed, not until redelivered
+func (w WebhookDelivery) MarkFailed(responseStatus int, err error, nextAttemptAt time.Time, failed bool) WebhookDelivery {
+	w.attempts++
+	w.responseStatus = responseStatus
+	w.lastError = err.Error()
+	w.nextAttemptAt = nextAttemptAt
+
+	if failed {
+		w.status = WebhookDeliveryFailed
+	}
+
+	return w
+}
+
+// Redeliver schedules a finished delivery to be posted again at now, with a new set of attempts
+func (w *WebhookDelivery) Redeliver(now time.Time) error {
+	if w.status == WebhookDeliveryPending {
+		return ErrWebhookDeliveryPending
+	}
+
+	w.status = WebhookDeliveryPending
+	w.attempts = 0
+	w.nextAttemptAt = now
+	return nil
+}
+
+func (w WebhookDelivery) ID() WebhookDeliveryID {
+	return w.id
+}
+
+func (w WebhookDelivery) WebhookID() WebhookID {
+	return w.webhookID
+}
+
+func (w WebhookDelivery) EventID() string {
+	return w.eventID
+}
+
+func (w WebhookDelivery) EventName() EventName {
+	return w.eventName
+}
+
+// Payload is the event envelope posted as the body
+func (w WebhookDelivery) Payload() []byte {
+	return w.payload
+}
+
+func (w WebhookDelivery) Status() WebhookDeliveryStatus {
+	return w.status
+}
+
+func (w WebhookDelivery) Attempts() int {
+	return w.attempts
+}
+
+func (w WebhookDelivery) NextAttemptAt() time.Time {
+	return w.nextAttemptAt
+}
+
+// ResponseStatus is the HTTP status of the last attempt, zero when the receiver did not answer
+func (w WebhookDelivery) ResponseStatus() int {
+	return w.responseStatus
+}
+
+func (w WebhookDelivery) LastError() string {
+	return w.lastError
+}
+
+func (w WebhookDelivery) DeliveredAt() time.Time {
+	return w.deliveredAt
+}
+
+func (w WebhookDelivery) CreatedAt() time.Time {
+	return w.createdAt
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWebhook_Sign(t *testing.T) {
+	t.Parallel()
+
+	var (
+		webhook   = NewWebhook("0db298eb-c8e7-4829-84b7-c1036b4f0791", "", "http://localhost", nil, nil, "secret", 0, time.Time{}, time.Now())
+		timestamp = time.Unix(1600000000, 0)
+		body      = []byte(`{"id":"1"}`)
+	)
+
+	// echo -n '1600000000.{"id":"1"}' | openssl dgst -sha256 -hmac secret
+	var expected = "sha256=3831eb7dbf183fdbdf6145e3aa0b7029f210195f352de3815ebec7b67268edbc"
+	if got := webhook.Sign(timestamp, body); got != expected {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Sign timestamp and body", got, expected)
+	}
+
+	if webhook.Sign(timestamp, body) == webhook.Sign(timestamp.Add(time.Second), body) {
+		t.Errorf("[TestCase '%s'] the timestamp is not signed", "Sign timestamp and body")
+	}
+}
+
+func TestWebhook_RecordFailure(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now     = time.Now()
+		webhook = NewWebhook("0db298eb-c8e7-4829-84b7-c1036b4f0791", "", "http://localhost", nil, nil, "secret", 0, time.Time{}, now)
+	)
+
+	for i := 1; i < 3; i++ {
+		if webhook.RecordFailure(now, 3) || !webhook.Enabled() {
+			t.Fatalf("[TestCase '%s'] disabled after %d failures", "Disable after repeated failures", i)
+		}
+	}
+
+	if !webhook.RecordFailure(now, 3) || webhook.Enabled() {
+		t.Fatalf("[TestCase '%s'] not disabled after 3 failures", "Disable after repeated failures")
+	}
+
+	if webhook.RecordFailure(now, 3) {
+		t.Errorf("[TestCase '%s'] disabled twice", "Disable after repeated failures")
+	}
+
+	webhook.Enable()
+	if !webhook.Enabled() || webhook.Failures() != 0 {
+		t.Errorf("[TestCase '%s'] Result: '%v' '%v'", "Enable forgets failures", webhook.Enabled(), webhook.Failures())
+	}
+}
+
+func TestWebhook_Watches(t *testing.T) {
+	t.Parallel()
+
+	var webhook = NewWebhook(
+		"0db298eb-c8e7-4829-84b7-c1036b4f0791",
+		"client",
+		"http://localhost",
+		nil,
+		[]AccountID{"a", "b"},
+		"secret",
+		0,
+		time.Time{},
+		time.Now(),
+	)
+
+	tests := []struct {
+		name     string
+		accounts []AccountID
+		expected bool
+	}{
+		{name: "Watched origin", accounts: []AccountID{"a", "c"}, expected: true},
+		{name: "Watched destination", accounts: []AccountID{"c", "b"}, expected: true},
+		{name: "Accounts of another tenant", accounts: []AccountID{"c", "d"}, expected: false},
+		{name: "No accounts", accounts: []AccountID{}, expected: false},
+	}
+
+	for _, tt := range tests {
+		if result := webhook.Watches(tt.accounts); result != tt.expected {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+		}
+	}
+}
+
+func TestWebhookDelivery_Redeliver(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now     = time.Now()
+		pending = NewWebhookDelivery(
+			"5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+			"0db298eb-c8e7-4829-84b7-c1036b4f0791",
+			"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10",
+			EventTransferCompleted,
+			[]byte(`{}`),
+			WebhookDeliveryPending,
+			0,
+			now,
+			0,
+			"",
+			time.Time{},
+			now,
+		)
+	)
+
+	tests := []struct {
+		name          string
+		delivery      WebhookDelivery
+		expectedError error
+	}{
+		{
+			name:          "Pending delivery can not be redelivered",
+			delivery:      pending.MarkFailed(500, errors.New("error"), now.Add(time.Minute), false),
+			expectedError: ErrWebhookDeliveryPending,
+		},
+		{
+			name:     "Failed delivery is redelivered",
+			delivery: pending.MarkFailed(500, errors.New("error"), now, true),
+		},
+		{
+			name:     "Succeeded delivery is redelivered",
+			delivery: pending.MarkSucceeded(200, now),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var delivery = tt.delivery
+
+			if err := delivery.Redeliver(now); err != tt.expectedError {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if tt.expectedError == nil && (!delivery.Due(now) || delivery.Attempts() != 0) {
+				t.Errorf("[TestCase '%s'] Result: '%v' '%v'", tt.name, delivery.Status(), delivery.Attempts())
+			}
+		})
+	}
+}
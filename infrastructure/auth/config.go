@@ -0,0 +1,19 @@
+package auth
+
+import "os"
+
+type config struct {
+	secret   string
+	jwksFile string
+	issuer   string
+	audience string
+}
+
+func newConfigJWT() *config {
+	return &config{
+		secret:   os.Getenv("JWT_SECRET"),
+		jwksFile: os.Getenv("JWT_JWKS_FILE"),
+		issuer:   os.Getenv("JWT_ISSUER"),
+		audience: os.Getenv("JWT_AUDIENCE"),
+	}
+}
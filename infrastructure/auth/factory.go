@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/auth"
+)
+
+var (
+	errInvalidTokenVerifierInstance = errors.New("invalid token verifier instance")
+)
+
+const (
+	InstanceJWTHMAC int = iota
+	InstanceJWTJWKS
+)
+
+func NewTokenVerifierFactory(instance int) (auth.TokenVerifier, error) {
+	switch instance {
+	case InstanceJWTHMAC:
+		return NewJWTHMAC(newConfigJWT())
+	case InstanceJWTJWKS:
+		return NewJWTJWKS(newConfigJWT())
+	default:
+		return nil, errInvalidTokenVerifierInstance
+	}
+}
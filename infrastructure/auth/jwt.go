@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/auth"
+	"github.com/gsabadini/go-clean-architecture/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+type jwtVerifier struct {
+	parser  *jwt.Parser
+	keyFunc jwt.Keyfunc
+}
+
+// NewJWTHMAC creates a verifier for tokens signed with HS256 and a shared secret
+func NewJWTHMAC(c *config) (auth.TokenVerifier, error) {
+	if c.secret == "" {
+		return nil, errors.New("jwt secret not defined")
+	}
+
+	var secret = []byte(c.secret)
+
+	return jwtVerifier{
+		parser: newJWTParser(c, jwt.SigningMethodHS256.Alg()),
+		keyFunc: func(_ *jwt.Token) (interface{}, error) {
+			return secret, nil
+		},
+	}, nil
+}
+
+// NewJWTJWKS creates a verifier for tokens signed with RS256 by one of the keys of a local JWKS file
+func NewJWTJWKS(c *config) (auth.TokenVerifier, error) {
+	keys, err := loadJWKS(c.jwksFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return jwtVerifier{
+		parser: newJWTParser(c, jwt.SigningMethodRS256.Alg()),
+		keyFunc: func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			if key, ok := keys[kid]; ok {
+				return key, nil
+			}
+
+			if kid == "" && len(keys) == 1 {
+				for _, key := range keys {
+					return key, nil
+				}
+			}
+
+			return nil, errors.Errorf("signing key %q not found", kid)
+		},
+	}, nil
+}
+
+func newJWTParser(c *config, alg string) *jwt.Parser {
+	var opts = []jwt.ParserOption{
+		jwt.WithValidMethods([]string{alg}),
+		jwt.WithExpirationRequired(),
+	}
+
+	if c.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(c.issuer))
+	}
+
+	if c.audience != "" {
+		opts = append(opts, jwt.WithAudience(c.audience))
+	}
+
+	return jwt.NewParser(opts...)
+}
+
+type claims struct {
+	Scope string `json:"scope"`
+	Role  string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+func (j jwtVerifier) Verify(token string) (domain.Principal, error) {
+	var c claims
+
+	if _, err := j.parser.ParseWithClaims(token, &c, j.keyFunc); err != nil {
+		return domain.Principal{}, auth.ErrInvalidToken
+	}
+
+	if c.Subject == "" {
+		return domain.Principal{}, auth.ErrInvalidToken
+	}
+
+	role, err := domain.ParseRole(c.Role)
+	if err != nil {
+		return domain.Principal{}, auth.ErrInvalidToken
+	}
+
+	var scopes = domain.ParseScopes(c.Scope)
+	if len(scopes) == 0 {
+		scopes = role.DefaultScopes()
+	}
+
+	return domain.NewPrincipal(c.Subject, role, scopes...), nil
+}
+
+type jwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+func loadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	if path == "" {
+		return nil, errors.New("jwks file not defined")
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading jwks file")
+	}
+
+	var set jwks
+	if err = json.Unmarshal(raw, &set); err != nil {
+		return nil, errors.Wrap(err, "error decoding jwks file")
+	}
+
+	var keys = make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error decoding modulus of key %q", k.Kid)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error decoding exponent of key %q", k.Kid)
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("jwks file has no RSA keys")
+	}
+
+	return keys, nil
+}
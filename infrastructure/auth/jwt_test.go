@@ -0,0 +1,288 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/auth"
+	"github.com/gsabadini/go-clean-architecture/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newTestClaims(exp time.Time, issuer string, audience string) claims {
+	return claims{
+		Role: string(domain.RoleCustomer),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "07094564964",
+			Issuer:    issuer,
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(exp),
+		},
+	}
+}
+
+func signHS256(t *testing.T, secret string, c claims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, c claims) string {
+	t.Helper()
+
+	var token = jwt.NewWithClaims(jwt.SigningMethodRS256, c)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed
+}
+
+func writeJWKS(t *testing.T, keys map[string]*rsa.PrivateKey) string {
+	t.Helper()
+
+	var set jwks
+	for kid, key := range keys {
+		set.Keys = append(set.Keys, struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		}{
+			Kty: "RSA",
+			Kid: kid,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+
+	raw, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var path = filepath.Join(t.TempDir(), "jwks.json")
+	if err = os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestJWTHMAC_Verify(t *testing.T) {
+	t.Parallel()
+
+	const (
+		secret   = "secret"
+		issuer   = "bank"
+		audience = "bank-api"
+	)
+
+	verifier, err := NewJWTHMAC(&config{secret: secret, issuer: issuer, audience: audience})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		valid   = time.Now().Add(time.Hour)
+		expired = time.Now().Add(-time.Hour)
+		noAlg   = jwt.NewWithClaims(jwt.SigningMethodNone, newTestClaims(valid, issuer, audience))
+	)
+
+	unsigned, err := noAlg.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		token           string
+		expectedSubject string
+		expectedErr     error
+	}{
+		{
+			name:            "Valid token",
+			token:           signHS256(t, secret, newTestClaims(valid, issuer, audience)),
+			expectedSubject: "07094564964",
+		},
+		{
+			name:        "Expired token",
+			token:       signHS256(t, secret, newTestClaims(expired, issuer, audience)),
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "Token signed with another secret",
+			token:       signHS256(t, "another", newTestClaims(valid, issuer, audience)),
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "Token signed with RS256",
+			token:       signRS256(t, key, "", newTestClaims(valid, issuer, audience)),
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "Unsigned token",
+			token:       unsigned,
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "Token of another issuer",
+			token:       signHS256(t, secret, newTestClaims(valid, "another", audience)),
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "Token of another audience",
+			token:       signHS256(t, secret, newTestClaims(valid, issuer, "another")),
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "Malformed token",
+			token:       "not-a-token",
+			expectedErr: auth.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			principal, err := verifier.Verify(tt.token)
+			if err != tt.expectedErr {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, err, tt.expectedErr)
+			}
+
+			if principal.Subject() != tt.expectedSubject {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, principal.Subject(), tt.expectedSubject)
+			}
+		})
+	}
+}
+
+func TestJWTJWKS_Verify(t *testing.T) {
+	t.Parallel()
+
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier, err := NewJWTJWKS(&config{
+		jwksFile: writeJWKS(t, map[string]*rsa.PrivateKey{"first": first, "second": second}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var valid = time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name            string
+		token           string
+		expectedSubject string
+		expectedErr     error
+	}{
+		{
+			name:            "Token signed by the first key",
+			token:           signRS256(t, first, "first", newTestClaims(valid, "", "")),
+			expectedSubject: "07094564964",
+		},
+		{
+			name:            "Token signed by the second key",
+			token:           signRS256(t, second, "second", newTestClaims(valid, "", "")),
+			expectedSubject: "07094564964",
+		},
+		{
+			name:        "Token whose kid names another key",
+			token:       signRS256(t, first, "second", newTestClaims(valid, "", "")),
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "Token with an unknown kid",
+			token:       signRS256(t, first, "unknown", newTestClaims(valid, "", "")),
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "Token without kid with several keys",
+			token:       signRS256(t, first, "", newTestClaims(valid, "", "")),
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "Token signed with HS256",
+			token:       signHS256(t, "secret", newTestClaims(valid, "", "")),
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "Expired token",
+			token:       signRS256(t, first, "first", newTestClaims(time.Now().Add(-time.Hour), "", "")),
+			expectedErr: auth.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			principal, err := verifier.Verify(tt.token)
+			if err != tt.expectedErr {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, err, tt.expectedErr)
+			}
+
+			if principal.Subject() != tt.expectedSubject {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, principal.Subject(), tt.expectedSubject)
+			}
+		})
+	}
+}
+
+func TestJWTJWKS_SingleKeyWithoutKid(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier, err := NewJWTJWKS(&config{jwksFile: writeJWKS(t, map[string]*rsa.PrivateKey{"only": key})})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	principal, err := verifier.Verify(signRS256(t, key, "", newTestClaims(time.Now().Add(time.Hour), "", "")))
+	if err != nil {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Single key without kid", err, nil)
+	}
+
+	if principal.Role() != domain.RoleCustomer {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Single key without kid", principal.Role(), domain.RoleCustomer)
+	}
+}
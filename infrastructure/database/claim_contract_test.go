@@ -0,0 +1,545 @@
+package database
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/repository"
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// The claim contract runs the claims of the outbox relay, of the webhook deliverer and of the import job, and the
+// content hash a file is imported by, through memory and every SQL backend, as the repository contract
+
+// contractClaimLimit takes in one claim every message a case left due, along with any left behind by earlier runs
+const contractClaimLimit = 10000
+
+// claimContractStores are the repositories whose rows are claimed
+type claimContractStores struct {
+	outbox     domain.OutboxRepository
+	webhooks   domain.WebhookRepository
+	deliveries domain.WebhookDeliveryRepository
+	imports    domain.TransferImportRepository
+}
+
+func newClaimContractStoresSQL(db repository.SQL) claimContractStores {
+	return claimContractStores{
+		outbox:     repository.NewOutboxSQL(db),
+		webhooks:   repository.NewWebhookSQL(db),
+		deliveries: repository.NewWebhookDeliverySQL(db),
+		imports:    repository.NewTransferImportSQL(db),
+	}
+}
+
+func TestClaimContract_Memory(t *testing.T) {
+	t.Parallel()
+
+	var memory = repository.NewMemory()
+	testClaimContract(t, claimContractStores{
+		outbox:     repository.NewOutboxMemory(memory),
+		webhooks:   repository.NewWebhookMemory(memory),
+		deliveries: repository.NewWebhookDeliveryMemory(memory),
+		imports:    repository.NewTransferImportMemory(memory),
+	})
+}
+
+func TestClaimContract_SQLite(t *testing.T) {
+	t.Parallel()
+
+	db, err := NewSQLiteHandler(&config{database: filepath.Join(t.TempDir(), "bank.db"), driver: "sqlite"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrateContractDatabase(t, db)
+	testClaimContract(t, newClaimContractStoresSQL(db))
+}
+
+func TestClaimContract_Postgres(t *testing.T) {
+	if os.Getenv("TEST_POSTGRES") == "" {
+		t.Skip("TEST_POSTGRES is not set")
+	}
+
+	t.Parallel()
+
+	db, err := NewPostgresHandler(newConfigPostgres())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrateContractDatabase(t, db)
+	testClaimContract(t, newClaimContractStoresSQL(db))
+}
+
+func TestClaimContract_MySQL(t *testing.T) {
+	if os.Getenv("TEST_MYSQL") == "" {
+		t.Skip("TEST_MYSQL is not set")
+	}
+
+	t.Parallel()
+
+	db, err := NewMySQLHandler(newConfigMySQL())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrateContractDatabase(t, db)
+	testClaimContract(t, newClaimContractStoresSQL(db))
+}
+
+func testClaimContract(t *testing.T, stores claimContractStores) {
+	var (
+		ctx    = context.Background()
+		outbox = stores.outbox
+	)
+
+	t.Run("Claims the due messages heading their aggregate once", func(t *testing.T) {
+		var (
+			a        = domain.NewUUID()
+			b        = domain.NewUUID()
+			occurred = time.Now().UTC().Add(-time.Minute).Truncate(time.Microsecond)
+			a1       = newContractEvent(a, occurred)
+			a2       = newContractEvent(a, occurred)
+			b1       = newContractEvent(b, occurred)
+			lease    = time.Now().Add(time.Minute)
+		)
+
+		if err := outbox.Create(ctx, a1, b1, a2); err != nil {
+			t.Fatal(err)
+		}
+
+		claimed, err := claimContractOutbox(ctx, outbox, time.Now(), lease, a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if expected := []string{a1.ID(), b1.ID()}; !reflect.DeepEqual(contractEventIDs(claimed), expected) {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "First claim", contractEventIDs(claimed), expected)
+		}
+
+		again, err := claimContractOutbox(ctx, outbox, time.Now(), lease, a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(again) != 0 {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Leased", contractEventIDs(again), []string{})
+		}
+
+		if err = outbox.Update(ctx, claimed[0].MarkSent(time.Now())); err != nil {
+			t.Fatal(err)
+		}
+
+		next, err := claimContractOutbox(ctx, outbox, time.Now(), lease, a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if expected := []string{a2.ID()}; !reflect.DeepEqual(contractEventIDs(next), expected) {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "After sent", contractEventIDs(next), expected)
+		}
+
+		// b1 and a2 are taken again once their lease expires
+		expired, err := claimContractOutbox(ctx, outbox, lease.Add(time.Second), lease.Add(time.Minute), a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if expected := []string{b1.ID(), a2.ID()}; !reflect.DeepEqual(contractEventIDs(expired), expected) {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Lease expired", contractEventIDs(expired), expected)
+		}
+	})
+
+	t.Run("Relays claiming side by side never take the same message", func(t *testing.T) {
+		const messages = 20
+
+		var (
+			aggregates = make([]string, 0, messages)
+			occurred   = time.Now().UTC().Add(-time.Minute).Truncate(time.Microsecond)
+		)
+
+		for i := 0; i < messages; i++ {
+			var aggregateID = domain.NewUUID()
+			aggregates = append(aggregates, aggregateID)
+
+			if err := outbox.Create(ctx, newContractEvent(aggregateID, occurred)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var (
+			wg    sync.WaitGroup
+			mu    sync.Mutex
+			taken = make(map[string]int)
+		)
+
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				claimed, err := claimContractOutbox(ctx, outbox, time.Now(), time.Now().Add(time.Minute), aggregates...)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				for _, message := range claimed {
+					taken[message.Event().ID()]++
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(taken) != messages {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Every message claimed", len(taken), messages)
+		}
+
+		for ID, count := range taken {
+			if count != 1 {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Claimed once "+ID, count, 1)
+			}
+		}
+	})
+
+	t.Run("Deliverers claiming side by side never take the same delivery", func(t *testing.T) {
+		const deliveries = 20
+
+		var webhook = domain.NewWebhook(
+			domain.WebhookID(domain.NewUUID()),
+			"client",
+			"https://example.com/hooks",
+			[]domain.EventName{domain.EventTransferCompleted},
+			nil,
+			"secret",
+			0,
+			time.Time{},
+			time.Now().UTC().Truncate(time.Microsecond),
+		)
+
+		if _, err := stores.webhooks.Create(ctx, webhook); err != nil {
+			t.Fatal(err)
+		}
+
+		var created = time.Now().UTC().Add(-time.Minute).Truncate(time.Microsecond)
+		for i := 0; i < deliveries; i++ {
+			if err := stores.deliveries.Create(ctx, domain.NewWebhookDelivery(
+				domain.WebhookDeliveryID(domain.NewUUID()),
+				webhook.ID(),
+				domain.NewUUID(),
+				domain.EventTransferCompleted,
+				[]byte(`{}`),
+				domain.WebhookDeliveryPending,
+				0,
+				created,
+				0,
+				"",
+				time.Time{},
+				created,
+			)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var (
+			wg    sync.WaitGroup
+			mu    sync.Mutex
+			taken = make(map[domain.WebhookDeliveryID]domain.WebhookDelivery)
+			twice int
+		)
+
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				claimed, err := claimContractDeliveries(ctx, stores.deliveries, time.Now(), webhook.ID())
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				for _, delivery := range claimed {
+					if _, ok := taken[delivery.ID()]; ok {
+						twice++
+					}
+					taken[delivery.ID()] = delivery
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(taken) != deliveries {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Every delivery claimed", len(taken), deliveries)
+		}
+
+		if twice != 0 {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Claimed twice", twice, 0)
+		}
+
+		// A delivery written back as it was claimed is released
+		for _, delivery := range taken {
+			if err := stores.deliveries.Update(ctx, delivery); err != nil {
+				t.Fatal(err)
+			}
+
+			break
+		}
+
+		released, err := claimContractDeliveries(ctx, stores.deliveries, time.Now(), webhook.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(released) != 1 {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Released", len(released), 1)
+		}
+	})
+
+	t.Run("Import jobs claiming side by side never take the same job", func(t *testing.T) {
+		const jobs = 10
+
+		var (
+			created = time.Now().UTC().Add(-time.Minute).Truncate(time.Microsecond)
+			mine    = make(map[domain.TransferImportID]bool)
+		)
+
+		for i := 0; i < jobs; i++ {
+			var job = domain.NewPendingTransferImport(
+				domain.TransferImportID(domain.NewUUID()),
+				"payroll.csv",
+				domain.TransferImportCSV,
+				domain.TransferImportContentHash([]byte(domain.NewUUID())),
+				domain.NewPrincipal("client", domain.RoleCustomer),
+				[]domain.TransferImportLine{
+					domain.NewReadTransferImportLine(2, domain.AccountID(domain.NewUUID()), domain.AccountID(domain.NewUUID()), 100, nil),
+				},
+				created,
+			)
+
+			if err := stores.imports.Create(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+
+			mine[job.ID()] = true
+		}
+
+		var (
+			wg    sync.WaitGroup
+			mu    sync.Mutex
+			taken = make(map[domain.TransferImportID]domain.TransferImport)
+			twice int
+		)
+
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				claimed, err := stores.imports.ClaimDue(ctx, time.Now(), time.Now().Add(time.Minute), contractClaimLimit)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				for _, job := range claimed {
+					if !mine[job.ID()] {
+						continue
+					}
+
+					if _, ok := taken[job.ID()]; ok {
+						twice++
+					}
+					taken[job.ID()] = job
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(taken) != jobs {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Every job claimed", len(taken), jobs)
+		}
+
+		if twice != 0 {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Claimed twice", twice, 0)
+		}
+
+		// The line of a job is claimed once, whoever claims it first
+		for _, job := range taken {
+			running, err := job.Lines()[0].Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			first, err := stores.imports.ClaimLine(ctx, job.ID(), running)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			again, err := stores.imports.ClaimLine(ctx, job.ID(), running)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !first || again {
+				t.Errorf("[TestCase '%s'] Result: '%v, %v' | Expected: '%v, %v'", "Line claimed once", first, again, true, false)
+			}
+
+			break
+		}
+
+		// The jobs are taken again once their lease expires
+		expired, err := stores.imports.ClaimDue(ctx, time.Now().Add(2*time.Minute), time.Now().Add(3*time.Minute), contractClaimLimit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var again int
+		for _, job := range expired {
+			if mine[job.ID()] {
+				again++
+			}
+		}
+
+		if again != jobs {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Lease expired", again, jobs)
+		}
+	})
+
+	t.Run("A file imported side by side is taken once by its requester, and again by another", func(t *testing.T) {
+		var (
+			hash = domain.TransferImportContentHash([]byte(domain.NewUUID()))
+			file = func(requester string) domain.TransferImport {
+				return domain.NewPendingTransferImport(
+					domain.TransferImportID(domain.NewUUID()),
+					"payroll.csv",
+					domain.TransferImportCSV,
+					hash,
+					domain.NewPrincipal(requester, domain.RoleCustomer),
+					nil,
+					time.Now(),
+				)
+			}
+		)
+
+		var (
+			wg         sync.WaitGroup
+			mu         sync.Mutex
+			created    int
+			duplicates int
+		)
+
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				err := stores.imports.Create(ctx, file("client"))
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				switch err {
+				case nil:
+					created++
+				case domain.ErrDuplicateTransferImport:
+					duplicates++
+				default:
+					t.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if created != 1 || duplicates != 3 {
+			t.Errorf("[TestCase '%s'] Result: '%v, %v' | Expected: '%v, %v'", "Taken once", created, duplicates, 1, 3)
+		}
+
+		if err := stores.imports.Create(ctx, file("another-client")); err != nil {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Another requester", err, nil)
+		}
+	})
+}
+
+// claimContractDeliveries claims the due deliveries, keeping those of the webhook of the case
+func claimContractDeliveries(
+	ctx context.Context,
+	repo domain.WebhookDeliveryRepository,
+	now time.Time,
+	webhookID domain.WebhookID,
+) ([]domain.WebhookDelivery, error) {
+	claimed, err := repo.ClaimDue(ctx, now, now.Add(time.Minute), contractClaimLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []domain.WebhookDelivery
+	for _, delivery := range claimed {
+		if delivery.WebhookID() == webhookID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	return deliveries, nil
+}
+
+// claimContractOutbox claims the due messages, keeping those of the aggregates of the case
+func claimContractOutbox(
+	ctx context.Context,
+	outbox domain.OutboxRepository,
+	now, until time.Time,
+	aggregates ...string,
+) ([]domain.OutboxMessage, error) {
+	claimed, err := outbox.ClaimDue(ctx, now, until, contractClaimLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	var mine = make(map[string]bool)
+	for _, aggregateID := range aggregates {
+		mine[aggregateID] = true
+	}
+
+	var messages []domain.OutboxMessage
+	for _, message := range claimed {
+		if mine[message.Event().AggregateID()] {
+			messages = append(messages, message)
+		}
+	}
+
+	return messages, nil
+}
+
+func newContractEvent(aggregateID string, occurredAt time.Time) domain.Event {
+	return domain.NewEvent(
+		domain.NewUUID(),
+		domain.EventTransferCompleted,
+		domain.EventSchemaVersion,
+		aggregateID,
+		[]byte(`{}`),
+		occurredAt,
+	)
+}
+
+func contractEventIDs(messages []domain.OutboxMessage) []string {
+	var IDs = make([]string, 0, len(messages))
+	for _, message := range messages {
+		IDs = append(IDs, message.Event().ID())
+	}
+
+	return IDs
+}
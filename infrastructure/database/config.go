@@ -2,6 +2,7 @@ package database
 
 import (
 	"os"
+	"strings"
 	"time"
 )
 
@@ -12,6 +13,13 @@ type config struct {
 	driver   string
 	user     string
 	password string
+	// replicas are the hosts, as host or host:port, of the read replicas of the primary. They are reached with its
+	// database, user and password, and with its port when they name none
+	replicas []string
+	// readPreference is the mode of the MongoDB read preference, primary when empty, and maxStaleness is the duration,
+	// such as 90s, bounding the lag of the secondaries it reads from
+	readPreference string
+	maxStaleness   string
 
 	ctxTimeout time.Duration
 }
@@ -23,6 +31,17 @@ func newConfigMongoDB() *config {
 		password:   os.Getenv("MONGODB_ROOT_PASSWORD"),
 		user:       os.Getenv("MONGODB_ROOT_USER"),
 		ctxTimeout: 60 * time.Second,
+
+		readPreference: os.Getenv("MONGODB_READ_PREFERENCE"),
+		maxStaleness:   os.Getenv("MONGODB_MAX_STALENESS"),
+	}
+}
+
+// newConfigSQLite reads the path of the database file, created when missing
+func newConfigSQLite() *config {
+	return &config{
+		database: os.Getenv("SQLITE_PATH"),
+		driver:   "sqlite",
 	}
 }
 
@@ -34,5 +53,30 @@ func newConfigPostgres() *config {
 		driver:   os.Getenv("POSTGRES_DRIVER"),
 		user:     os.Getenv("POSTGRES_USER"),
 		password: os.Getenv("POSTGRES_PASSWORD"),
+		replicas: envList("POSTGRES_REPLICA_HOSTS"),
 	}
 }
+
+func newConfigMySQL() *config {
+	return &config{
+		host:     os.Getenv("MYSQL_HOST"),
+		database: os.Getenv("MYSQL_DATABASE"),
+		port:     os.Getenv("MYSQL_PORT"),
+		driver:   "mysql",
+		user:     os.Getenv("MYSQL_USER"),
+		password: os.Getenv("MYSQL_PASSWORD"),
+		replicas: envList("MYSQL_REPLICA_HOSTS"),
+	}
+}
+
+// envList reads a comma separated list, empty when the variable is not set
+func envList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/repository"
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// accountSnapshotEvery is how many entries of an event-sourced account are replayed at most past its snapshot
+const accountSnapshotEvery = 100
+
+var (
+	errInvalidAccountRepositoryInstance = errors.New("invalid account repository instance")
+)
+
+const (
+	InstanceAccountPostgres int = iota
+	InstanceAccountMongoDB
+	InstanceAccountEventSourcedPostgres
+	InstanceAccountMemory
+)
+
+// NewAccountRepositoryFactory picks how accounts are persisted: as their current state, or as the event stream of
+// their changes with the accounts table as its read model. Both use the same schema for reads. The in-memory
+// repository keeps them in the process, sharing its transactions with the in-memory transfers
+func NewAccountRepositoryFactory(
+	instance int,
+	dbSQL repository.SQL,
+	dbNoSQL repository.NoSQL,
+) (domain.AccountRepository, error) {
+	switch instance {
+	case InstanceAccountPostgres:
+		return repository.NewAccountSQL(dbSQL), nil
+	case InstanceAccountMongoDB:
+		return repository.NewAccountNoSQL(dbNoSQL), nil
+	case InstanceAccountEventSourcedPostgres:
+		return repository.NewAccountEventSourcedSQL(dbSQL, accountSnapshotEvery), nil
+	case InstanceAccountMemory:
+		return repository.NewAccountMemory(memory), nil
+	default:
+		return nil, errInvalidAccountRepositoryInstance
+	}
+}
@@ -12,12 +12,18 @@ var (
 
 const (
 	InstancePostgres int = iota
+	InstanceSQLite
+	InstanceMySQL
 )
 
 func NewDatabaseSQLFactory(instance int) (repository.SQL, error) {
 	switch instance {
 	case InstancePostgres:
 		return NewPostgresHandler(newConfigPostgres())
+	case InstanceSQLite:
+		return NewSQLiteHandler(newConfigSQLite())
+	case InstanceMySQL:
+		return NewMySQLHandler(newConfigMySQL())
 	default:
 		return nil, errInvalidSQLDatabaseInstance
 	}
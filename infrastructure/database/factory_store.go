@@ -0,0 +1,100 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/repository"
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+var (
+	errInvalidStoresInstance = errors.New("invalid stores instance")
+)
+
+const (
+	InstanceStoresSQL int = iota
+	InstanceStoresNoSQL
+	InstanceStoresMemory
+)
+
+// Stores are the repositories of everything but the accounts and the transfers
+type Stores struct {
+	APIKeys                 domain.APIKeyRepository
+	Audit                   domain.AuditRepository
+	DailyActivity           domain.DailyActivityRepository
+	DailyBalances           domain.DailyBalanceRepository
+	Ledger                  domain.LedgerRepository
+	NotificationPreferences domain.NotificationPreferenceRepository
+	Notifications           domain.NotificationRepository
+	Outbox                  domain.OutboxRepository
+	Reconciliations         domain.ReconciliationRepository
+	RiskEvaluations         domain.RiskEvaluationRepository
+	TransferApprovals       domain.TransferApprovalRepository
+	TransferImports         domain.TransferImportRepository
+	WebhookDeliveries       domain.WebhookDeliveryRepository
+	Webhooks                domain.WebhookRepository
+}
+
+// NewStoresFactory picks where the other stores are persisted. The ones written next to a transfer or an account join
+// its transaction, so they must be on the backend of the accounts and transfers: the in-memory stores share the
+// transactions of the in-memory repositories
+func NewStoresFactory(instance int, dbSQL repository.SQL, dbNoSQL repository.NoSQL) (Stores, error) {
+	switch instance {
+	case InstanceStoresSQL:
+		var ledger = repository.NewLedgerSQL(dbSQL)
+		return Stores{
+			APIKeys:                 repository.NewAPIKeySQL(dbSQL),
+			Audit:                   repository.NewAuditSQL(dbSQL),
+			DailyActivity:           ledger,
+			DailyBalances:           repository.NewDailyBalanceSQL(dbSQL),
+			Ledger:                  ledger,
+			NotificationPreferences: repository.NewNotificationPreferenceSQL(dbSQL),
+			Notifications:           repository.NewNotificationSQL(dbSQL),
+			Outbox:                  repository.NewOutboxSQL(dbSQL),
+			Reconciliations:         repository.NewReconciliationSQL(dbSQL),
+			RiskEvaluations:         repository.NewRiskEvaluationSQL(dbSQL),
+			TransferApprovals:       repository.NewTransferApprovalSQL(dbSQL),
+			TransferImports:         repository.NewTransferImportSQL(dbSQL),
+			WebhookDeliveries:       repository.NewWebhookDeliverySQL(dbSQL),
+			Webhooks:                repository.NewWebhookSQL(dbSQL),
+		}, nil
+	case InstanceStoresNoSQL:
+		var ledger = repository.NewLedgerNoSQL(dbNoSQL)
+		return Stores{
+			APIKeys:                 repository.NewAPIKeyNoSQL(dbNoSQL),
+			Audit:                   repository.NewAuditNoSQL(dbNoSQL),
+			DailyActivity:           ledger,
+			DailyBalances:           repository.NewDailyBalanceNoSQL(dbNoSQL),
+			Ledger:                  ledger,
+			NotificationPreferences: repository.NewNotificationPreferenceNoSQL(dbNoSQL),
+			Notifications:           repository.NewNotificationNoSQL(dbNoSQL),
+			Outbox:                  repository.NewOutboxNoSQL(dbNoSQL),
+			Reconciliations:         repository.NewReconciliationNoSQL(dbNoSQL),
+			RiskEvaluations:         repository.NewRiskEvaluationNoSQL(dbNoSQL),
+			TransferApprovals:       repository.NewTransferApprovalNoSQL(dbNoSQL),
+			TransferImports:         repository.NewTransferImportNoSQL(dbNoSQL),
+			WebhookDeliveries:       repository.NewWebhookDeliveryNoSQL(dbNoSQL),
+			Webhooks:                repository.NewWebhookNoSQL(dbNoSQL),
+		}, nil
+	case InstanceStoresMemory:
+		var ledger = repository.NewLedgerMemory(memory)
+		return Stores{
+			APIKeys:                 repository.NewAPIKeyMemory(memory),
+			Audit:                   repository.NewAuditMemory(memory),
+			DailyActivity:           ledger,
+			DailyBalances:           repository.NewDailyBalanceMemory(memory),
+			Ledger:                  ledger,
+			NotificationPreferences: repository.NewNotificationPreferenceMemory(memory),
+			Notifications:           repository.NewNotificationMemory(memory),
+			Outbox:                  repository.NewOutboxMemory(memory),
+			Reconciliations:         repository.NewReconciliationMemory(memory),
+			RiskEvaluations:         repository.NewRiskEvaluationMemory(memory),
+			TransferApprovals:       repository.NewTransferApprovalMemory(memory),
+			TransferImports:         repository.NewTransferImportMemory(memory),
+			WebhookDeliveries:       repository.NewWebhookDeliveryMemory(memory),
+			Webhooks:                repository.NewWebhookMemory(memory),
+		}, nil
+	default:
+		return Stores{}, errInvalidStoresInstance
+	}
+}
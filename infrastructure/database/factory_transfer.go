@@ -0,0 +1,40 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/repository"
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// memory is shared by the in-memory repositories, so a transfer and the balances it moves commit together
+var memory = repository.NewMemory()
+
+var (
+	errInvalidTransferRepositoryInstance = errors.New("invalid transfer repository instance")
+)
+
+const (
+	InstanceTransferPostgres int = iota
+	InstanceTransferMongoDB
+	InstanceTransferMemory
+)
+
+// NewTransferRepositoryFactory picks where transfers are persisted. The in-memory repository needs the in-memory
+// accounts, as transfers change their balances in the same transaction
+func NewTransferRepositoryFactory(
+	instance int,
+	dbSQL repository.SQL,
+	dbNoSQL repository.NoSQL,
+) (domain.TransferRepository, error) {
+	switch instance {
+	case InstanceTransferPostgres:
+		return repository.NewTransferSQL(dbSQL), nil
+	case InstanceTransferMongoDB:
+		return repository.NewTransferNoSQL(dbNoSQL), nil
+	case InstanceTransferMemory:
+		return repository.NewTransferMemory(memory), nil
+	default:
+		return nil, errInvalidTransferRepositoryInstance
+	}
+}
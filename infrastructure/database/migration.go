@@ -0,0 +1,315 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/repository"
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+const (
+	// migrationLockLease is how long the lock of a migrator holds. A migrator that died holding it only blocks the
+	// others until then
+	migrationLockLease = 30 * time.Minute
+	// migrationLockRetry is how often a migrator waiting for the lock tries again
+	migrationLockRetry = time.Second
+)
+
+// migrations holds the scripts of every engine, in a directory each. A migration is a pair of files named
+// <version>_<name>.up.<ext> and <version>_<name>.down.<ext>, applied in the order of their versions
+//
+//go:embed migrations
+var migrations embed.FS
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.(sql|json)$`)
+
+var errInvalidMigrationDatabase = errors.New("migrations are not supported for the database")
+
+type (
+	// Migration is a versioned change of the schema, with the script applying it and the one reverting it
+	Migration struct {
+		Version int
+		Name    string
+		up      []byte
+		down    []byte
+	}
+
+	// MigrationStatus tells whether a migration was applied, and when. A migration applied by a newer build, unknown
+	// to this one, is listed too
+	MigrationStatus struct {
+		Version   int
+		Name      string
+		AppliedAt time.Time
+	}
+
+	// Migrator applies and reverts the migrations of a database. It holds the lock of the database while it works, so
+	// instances starting together apply every migration once
+	Migrator struct {
+		store      migrationStore
+		migrations []Migration
+		owner      string
+		lease      time.Duration
+		retry      time.Duration
+	}
+
+	// migrationStore keeps the migrations applied to a database and runs their scripts
+	migrationStore interface {
+		// init creates where the applied migrations and the lock are kept, when missing
+		init(ctx context.Context) error
+		// lock takes the lock for the owner until the time, unless another owner holds it and it has not expired
+		lock(ctx context.Context, owner string, now time.Time, until time.Time) (bool, error)
+		unlock(ctx context.Context, owner string) error
+		applied(ctx context.Context) ([]MigrationStatus, error)
+		// up runs the script of the migration and records it, down runs its revert script and forgets it
+		up(ctx context.Context, migration Migration, at time.Time) error
+		down(ctx context.Context, migration Migration) error
+	}
+)
+
+func (s MigrationStatus) Applied() bool {
+	return !s.AppliedAt.IsZero()
+}
+
+// NewSQLMigrator migrates the database with the scripts of its engine
+func NewSQLMigrator(db repository.SQL) (Migrator, error) {
+	var dir string
+	switch db.Dialect().(type) {
+	case repository.PostgresDialect:
+		dir = "migrations/postgres"
+	case repository.SQLiteDialect:
+		dir = "migrations/sqlite"
+	case repository.MySQLDialect:
+		dir = "migrations/mysql"
+	default:
+		return Migrator{}, errInvalidMigrationDatabase
+	}
+
+	return newMigrator(newSQLMigrationStore(db), dir)
+}
+
+// NewNoSQLMigrator migrates the MongoDB database
+func NewNoSQLMigrator(db repository.NoSQL) (Migrator, error) {
+	mgo, ok := db.(*mongoHandler)
+	if !ok {
+		return Migrator{}, errInvalidMigrationDatabase
+	}
+
+	return newMigrator(newMongoMigrationStore(mgo.db), "migrations/mongodb")
+}
+
+func newMigrator(store migrationStore, dir string) (Migrator, error) {
+	loaded, err := loadMigrations(migrations, dir)
+	if err != nil {
+		return Migrator{}, err
+	}
+
+	host, _ := os.Hostname()
+
+	return Migrator{
+		store:      store,
+		migrations: loaded,
+		owner:      fmt.Sprintf("%s:%d:%s", host, os.Getpid(), domain.NewUUID()),
+		lease:      migrationLockLease,
+		retry:      migrationLockRetry,
+	}, nil
+}
+
+// Up applies the migrations not applied yet, in order, and returns them. It stops at the first one failing
+func (m Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+
+	err := m.withLock(ctx, func() error {
+		applied, err := m.appliedVersions(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if applied[migration.Version] {
+				continue
+			}
+
+			if err = m.store.up(ctx, migration, time.Now().UTC()); err != nil {
+				return errors.Wrapf(err, "error applying migration %d %s", migration.Version, migration.Name)
+			}
+
+			done = append(done, migration)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// Down reverts the last steps migrations applied, newest first, and returns them
+func (m Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+
+	err := m.withLock(ctx, func() error {
+		applied, err := m.appliedVersions(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			var migration = m.migrations[i]
+			if !applied[migration.Version] {
+				continue
+			}
+
+			if err = m.store.down(ctx, migration); err != nil {
+				return errors.Wrapf(err, "error reverting migration %d %s", migration.Version, migration.Name)
+			}
+
+			done = append(done, migration)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// Status lists every migration, in order, with when it was applied
+func (m Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := m.store.init(ctx); err != nil {
+		return nil, errors.Wrap(err, "error creating migrations table")
+	}
+
+	applied, err := m.store.applied(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing applied migrations")
+	}
+
+	var byVersion = make(map[int]MigrationStatus, len(m.migrations))
+	for _, migration := range m.migrations {
+		byVersion[migration.Version] = MigrationStatus{Version: migration.Version, Name: migration.Name}
+	}
+
+	for _, status := range applied {
+		byVersion[status.Version] = status
+	}
+
+	var statuses = make([]MigrationStatus, 0, len(byVersion))
+	for _, status := range byVersion {
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+func (m Migrator) appliedVersions(ctx context.Context) (map[int]bool, error) {
+	applied, err := m.store.applied(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing applied migrations")
+	}
+
+	var versions = make(map[int]bool, len(applied))
+	for _, status := range applied {
+		versions[status.Version] = true
+	}
+
+	return versions, nil
+}
+
+// withLock runs the function holding the lock of the database, waiting for it until the context is done
+func (m Migrator) withLock(ctx context.Context, fn func() error) error {
+	if err := m.store.init(ctx); err != nil {
+		return errors.Wrap(err, "error creating migrations table")
+	}
+
+	for {
+		var now = time.Now().UTC()
+
+		ok, err := m.store.lock(ctx, m.owner, now, now.Add(m.lease))
+		if err != nil {
+			return errors.Wrap(err, "error taking migrations lock")
+		}
+
+		if ok {
+			break
+		}
+
+		select {
+		case <-time.After(m.retry):
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "error waiting for migrations lock")
+		}
+	}
+
+	defer func() {
+		_ = m.store.unlock(context.WithoutCancel(ctx), m.owner)
+	}()
+
+	return fn()
+}
+
+// loadMigrations reads the migrations of the directory, ordered by version. Every version needs both scripts
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading migrations")
+	}
+
+	var byVersion = make(map[int]*Migration)
+	for _, entry := range entries {
+		var match = migrationFile.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			return nil, errors.Errorf("invalid migration file %s", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != match[2] {
+			return nil, errors.Errorf("migration %d is named both %s and %s", version, migration.Name, match[2])
+		}
+
+		script, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading migrations")
+		}
+
+		if match[3] == "up" {
+			migration.up = script
+		} else {
+			migration.down = script
+		}
+	}
+
+	var loaded = make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.up == nil || migration.down == nil {
+			return nil, errors.Errorf("migration %d %s needs both an up and a down script", migration.Version, migration.Name)
+		}
+
+		loaded = append(loaded, *migration)
+	}
+
+	sort.Slice(loaded, func(i, j int) bool {
+		return loaded[i].Version < loaded[j].Version
+	})
+
+	return loaded, nil
+}
@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+const (
+	mongoErrNamespaceNotFound = 26
+	mongoErrNamespaceExists   = 48
+)
+
+// mongoMigrationStore keeps the applied migrations in the schema_migrations collection, and the lock in the single
+// document of schema_migrations_lock. A script is a JSON document whose commands field lists the database commands
+// to run, in extended JSON. Creating a collection that exists or dropping one that does not is not an error, so the
+// first migration also runs on databases created before migrations
+type mongoMigrationStore struct {
+	db *mongo.Database
+}
+
+func newMongoMigrationStore(db *mongo.Database) mongoMigrationStore {
+	return mongoMigrationStore{db: db}
+}
+
+type (
+	mongoMigrationScript struct {
+		Commands []bson.D `bson:"commands"`
+	}
+
+	mongoMigrationBSON struct {
+		Version   int       `bson:"_id"`
+		Name      string    `bson:"name"`
+		AppliedAt time.Time `bson:"applied_at"`
+	}
+
+	mongoMigrationLockBSON struct {
+		ID        string    `bson:"_id"`
+		Owner     string    `bson:"owner"`
+		ExpiresAt time.Time `bson:"expires_at"`
+	}
+)
+
+func (s mongoMigrationStore) init(_ context.Context) error {
+	return nil
+}
+
+// lock clears the lock when it expired, then tries to insert it. The insert fails while another owner holds it
+func (s mongoMigrationStore) lock(ctx context.Context, owner string, now time.Time, until time.Time) (bool, error) {
+	var locks = s.db.Collection("schema_migrations_lock")
+
+	if _, err := locks.DeleteOne(ctx, bson.M{
+		"_id": "lock",
+		"$or": bson.A{bson.M{"expires_at": bson.M{"$lt": now}}, bson.M{"owner": owner}},
+	}); err != nil {
+		return false, err
+	}
+
+	_, err := locks.InsertOne(ctx, mongoMigrationLockBSON{ID: "lock", Owner: owner, ExpiresAt: until})
+	switch {
+	case mongo.IsDuplicateKeyError(err):
+		return false, nil
+	case err != nil:
+		return false, err
+	default:
+		return true, nil
+	}
+}
+
+func (s mongoMigrationStore) unlock(ctx context.Context, owner string) error {
+	_, err := s.db.Collection("schema_migrations_lock").DeleteOne(ctx, bson.M{"_id": "lock", "owner": owner})
+	return err
+}
+
+// applied reads the migrations from the primary, never from a secondary lagging behind the last one applied
+func (s mongoMigrationStore) applied(ctx context.Context) ([]MigrationStatus, error) {
+	var migrations = s.db.Collection("schema_migrations", options.Collection().SetReadPreference(readpref.Primary()))
+
+	cur, err := migrations.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []mongoMigrationBSON
+	if err = cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	var applied = make([]MigrationStatus, 0, len(docs))
+	for _, doc := range docs {
+		applied = append(applied, MigrationStatus{Version: doc.Version, Name: doc.Name, AppliedAt: doc.AppliedAt})
+	}
+
+	return applied, nil
+}
+
+func (s mongoMigrationStore) up(ctx context.Context, migration Migration, at time.Time) error {
+	if err := s.run(ctx, migration.up); err != nil {
+		return err
+	}
+
+	_, err := s.db.Collection("schema_migrations").InsertOne(ctx, mongoMigrationBSON{
+		Version:   migration.Version,
+		Name:      migration.Name,
+		AppliedAt: at,
+	})
+
+	return err
+}
+
+func (s mongoMigrationStore) down(ctx context.Context, migration Migration) error {
+	if err := s.run(ctx, migration.down); err != nil {
+		return err
+	}
+
+	_, err := s.db.Collection("schema_migrations").DeleteOne(ctx, bson.M{"_id": migration.Version})
+	return err
+}
+
+func (s mongoMigrationStore) run(ctx context.Context, script []byte) error {
+	var parsed mongoMigrationScript
+	if err := bson.UnmarshalExtJSON(script, false, &parsed); err != nil {
+		return errors.Wrap(err, "error parsing migration")
+	}
+
+	for _, command := range parsed.Commands {
+		var err = s.db.RunCommand(ctx, command).Err()
+
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) &&
+			(cmdErr.Code == mongoErrNamespaceExists || cmdErr.Code == mongoErrNamespaceNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
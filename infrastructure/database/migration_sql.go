@@ -0,0 +1,182 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/repository"
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// sqlMigrationStore keeps the applied migrations in schema_migrations, and the lock in the single row of
+// schema_migrations_lock. The scripts run in a transaction with the record of their version, which on MySQL only
+// covers the record: its schema changes commit on their own
+type sqlMigrationStore struct {
+	db repository.SQL
+	// split runs the scripts a statement at a time, for the engines running one statement per call
+	split bool
+}
+
+func newSQLMigrationStore(db repository.SQL) sqlMigrationStore {
+	_, split := db.Dialect().(repository.MySQLDialect)
+
+	return sqlMigrationStore{db: db, split: split}
+}
+
+func (s sqlMigrationStore) init(ctx context.Context) error {
+	var tables = []string{
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS schema_migrations_lock (
+			id INTEGER PRIMARY KEY NOT NULL,
+			owner VARCHAR(255) NOT NULL,
+			expires_at TIMESTAMP NOT NULL
+		)`,
+	}
+
+	for _, table := range tables {
+		// Postgres may fail one of two instances creating the table at once, which then finds it on the second try
+		if err := s.db.ExecuteContext(ctx, table); err != nil {
+			if err = s.db.ExecuteContext(ctx, table); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// lock clears the lock when it expired, then tries to insert it. The insert fails while another owner holds it
+func (s sqlMigrationStore) lock(ctx context.Context, owner string, now time.Time, until time.Time) (bool, error) {
+	if err := s.db.ExecuteContext(
+		ctx,
+		"DELETE FROM schema_migrations_lock WHERE id = 1 AND (expires_at < $1 OR owner = $2)",
+		now,
+		owner,
+	); err != nil {
+		return false, err
+	}
+
+	var err = s.db.ExecuteContext(
+		ctx,
+		"INSERT INTO schema_migrations_lock (id, owner, expires_at) VALUES (1, $1, $2)",
+		owner,
+		until,
+	)
+	if err == nil {
+		return true, nil
+	}
+
+	var (
+		holder    string
+		lookupErr = s.db.QueryRowContext(domain.ContextWithReadYourWrites(ctx), "SELECT owner FROM schema_migrations_lock WHERE id = 1").Scan(&holder)
+	)
+
+	switch {
+	case lookupErr == sql.ErrNoRows:
+		return false, err
+	case lookupErr != nil:
+		return false, lookupErr
+	default:
+		return false, nil
+	}
+}
+
+func (s sqlMigrationStore) unlock(ctx context.Context, owner string) error {
+	return s.db.ExecuteContext(ctx, "DELETE FROM schema_migrations_lock WHERE id = 1 AND owner = $1", owner)
+}
+
+// applied reads the migrations from the primary database, never from a replica lagging behind the last one applied
+func (s sqlMigrationStore) applied(ctx context.Context) ([]MigrationStatus, error) {
+	rows, err := s.db.QueryContext(domain.ContextWithReadYourWrites(ctx), "SELECT version, name, applied_at FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var applied = make([]MigrationStatus, 0)
+	for rows.Next() {
+		var status MigrationStatus
+		if err = rows.Scan(&status.Version, &status.Name, &status.AppliedAt); err != nil {
+			return nil, err
+		}
+
+		applied = append(applied, status)
+	}
+
+	return applied, rows.Err()
+}
+
+func (s sqlMigrationStore) up(ctx context.Context, migration Migration, at time.Time) error {
+	return s.run(ctx, migration.up, func(tx repository.Tx) error {
+		return tx.ExecuteContext(
+			ctx,
+			"INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)",
+			migration.Version,
+			migration.Name,
+			at,
+		)
+	})
+}
+
+func (s sqlMigrationStore) down(ctx context.Context, migration Migration) error {
+	return s.run(ctx, migration.down, func(tx repository.Tx) error {
+		return tx.ExecuteContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+	})
+}
+
+// run executes the script and then records it, in one transaction
+func (s sqlMigrationStore) run(ctx context.Context, script []byte, record func(repository.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range s.statements(string(script)) {
+		if err = tx.ExecuteContext(ctx, statement); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	if err = record(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// statements splits the script on the semicolons ending its lines, when the engine needs it. Such scripts keep to
+// statements without a semicolon at the end of an inner line. Parts made of comments only are left out, as those
+// engines reject an empty query
+func (s sqlMigrationStore) statements(script string) []string {
+	if !s.split {
+		return []string{script}
+	}
+
+	var statements []string
+	for _, statement := range strings.Split(script, ";\n") {
+		if statement = strings.TrimSpace(statement); statement != "" && !commentOnly(statement) {
+			statements = append(statements, strings.TrimSuffix(statement, ";"))
+		}
+	}
+
+	return statements
+}
+
+func commentOnly(statement string) bool {
+	for _, line := range strings.Split(statement, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "--") {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,288 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		files            fstest.MapFS
+		expectedVersions []int
+		expectedError    bool
+	}{
+		{
+			name: "Orders the migrations by version",
+			files: fstest.MapFS{
+				"m/0010_webhooks.up.sql":   {Data: []byte("CREATE TABLE webhooks (id INT)")},
+				"m/0010_webhooks.down.sql": {Data: []byte("DROP TABLE webhooks")},
+				"m/0002_accounts.up.sql":   {Data: []byte("CREATE TABLE accounts (id INT)")},
+				"m/0002_accounts.down.sql": {Data: []byte("DROP TABLE accounts")},
+			},
+			expectedVersions: []int{2, 10},
+		},
+		{
+			name: "Rejects a migration without its down script",
+			files: fstest.MapFS{
+				"m/0001_accounts.up.sql": {Data: []byte("CREATE TABLE accounts (id INT)")},
+			},
+			expectedError: true,
+		},
+		{
+			name: "Rejects a version with two names",
+			files: fstest.MapFS{
+				"m/0001_accounts.up.sql":    {Data: []byte("CREATE TABLE accounts (id INT)")},
+				"m/0001_transfers.down.sql": {Data: []byte("DROP TABLE transfers")},
+			},
+			expectedError: true,
+		},
+		{
+			name: "Rejects a file not named as a migration",
+			files: fstest.MapFS{
+				"m/accounts.sql": {Data: []byte("CREATE TABLE accounts (id INT)")},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		loaded, err := loadMigrations(tt.files, "m")
+		if (err != nil) != tt.expectedError {
+			t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+			continue
+		}
+
+		var versions []int
+		for _, migration := range loaded {
+			versions = append(versions, migration.Version)
+		}
+
+		if !reflect.DeepEqual(versions, tt.expectedVersions) {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, versions, tt.expectedVersions)
+		}
+	}
+}
+
+func TestMigrations_Embedded(t *testing.T) {
+	t.Parallel()
+
+	var expected []int
+	for _, dir := range []string{"migrations/postgres", "migrations/sqlite", "migrations/mysql", "migrations/mongodb"} {
+		loaded, err := loadMigrations(migrations, dir)
+		if err != nil {
+			t.Fatalf("[TestCase '%s'] Result: '%v'", dir, err)
+		}
+
+		var versions []int
+		for _, migration := range loaded {
+			versions = append(versions, migration.Version)
+		}
+
+		if expected == nil {
+			expected = versions
+		}
+
+		if !reflect.DeepEqual(versions, expected) {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", dir, versions, expected)
+		}
+	}
+}
+
+func TestSQLMigrationStore_Statements(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		split    bool
+		script   string
+		expected []string
+	}{
+		{
+			name:     "Runs the script at once",
+			script:   "DROP TABLE a;\nDROP TABLE b;\n",
+			expected: []string{"DROP TABLE a;\nDROP TABLE b;\n"},
+		},
+		{
+			name:     "Splits the statements",
+			split:    true,
+			script:   "-- Drops both\nDROP TABLE a;\nDROP TABLE\n    b;\n",
+			expected: []string{"-- Drops both\nDROP TABLE a", "DROP TABLE\n    b"},
+		},
+		{
+			name:     "Leaves out the comments",
+			split:    true,
+			script:   "-- Nothing to undo\n-- on this engine\n",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		var result = sqlMigrationStore{split: tt.split}.statements(tt.script)
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("[TestCase '%s'] Result: '%q' | Expected: '%q'", tt.name, result, tt.expected)
+		}
+	}
+}
+
+func TestMigrator_UpDown(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = context.Background()
+		db       = newMigrationTestDatabase(t)
+		migrator = newMigrationTestMigrator(t, db)
+	)
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(applied) != len(migrator.migrations) {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Up", len(applied), len(migrator.migrations))
+	}
+
+	if err = db.ExecuteContext(ctx, "SELECT COUNT(*) FROM accounts"); err != nil {
+		t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Tables created", err, nil)
+	}
+
+	if applied, err = migrator.Up(ctx); err != nil || len(applied) != 0 {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Up again", applied, nil)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, status := range statuses {
+		if !status.Applied() {
+			t.Errorf("[TestCase '%s'] Result: '%+v' | Expected: applied", "Status after up", status)
+		}
+	}
+
+	reverted, err := migrator.Down(ctx, len(migrator.migrations))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reverted) != len(migrator.migrations) || reverted[0].Version != migrator.migrations[len(migrator.migrations)-1].Version {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: newest first", "Down", reverted)
+	}
+
+	if err = db.ExecuteContext(ctx, "SELECT COUNT(*) FROM accounts"); err == nil {
+		t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Tables dropped", err, "no such table")
+	}
+
+	if statuses, err = migrator.Status(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, status := range statuses {
+		if status.Applied() {
+			t.Errorf("[TestCase '%s'] Result: '%+v' | Expected: pending", "Status after down", status)
+		}
+	}
+}
+
+func TestMigrator_ConcurrentUp(t *testing.T) {
+	t.Parallel()
+
+	const instances = 4
+
+	var (
+		ctx     = context.Background()
+		db      = newMigrationTestDatabase(t)
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		applied int
+	)
+
+	for i := 0; i < instances; i++ {
+		var migrator = newMigrationTestMigrator(t, db)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			done, err := migrator.Up(ctx)
+			if err != nil {
+				t.Error(err)
+			}
+
+			mu.Lock()
+			applied += len(done)
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	if expected := len(newMigrationTestMigrator(t, db).migrations); applied != expected {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Applied once", applied, expected)
+	}
+}
+
+func TestMigrator_Lock(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = context.Background()
+		store = newSQLMigrationStore(newMigrationTestDatabase(t))
+		now   = time.Now().UTC()
+	)
+
+	if err := store.init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		owner    string
+		now      time.Time
+		expected bool
+	}{
+		{name: "Takes the free lock", owner: "a", now: now, expected: true},
+		{name: "Waits for a lock held by another owner", owner: "b", now: now, expected: false},
+		{name: "Takes again its own lock", owner: "a", now: now, expected: true},
+		{name: "Takes over an expired lock", owner: "b", now: now.Add(2 * time.Minute), expected: true},
+	}
+
+	for _, tt := range tests {
+		ok, err := store.lock(ctx, tt.owner, tt.now, tt.now.Add(time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if ok != tt.expected {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, ok, tt.expected)
+		}
+	}
+}
+
+func newMigrationTestDatabase(t *testing.T) *sqliteHandler {
+	db, err := NewSQLiteHandler(&config{database: filepath.Join(t.TempDir(), "bank.db"), driver: "sqlite"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func newMigrationTestMigrator(t *testing.T, db *sqliteHandler) Migrator {
+	migrator, err := NewSQLMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrator.retry = 10 * time.Millisecond
+	return migrator
+}
@@ -0,0 +1,255 @@
+CREATE TABLE transfers (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    account_origin_id VARCHAR(255) NOT NULL,
+    account_destination_id VARCHAR(255) NOT NULL,
+    amount BIGINT NOT NULL,
+    status VARCHAR(255) NOT NULL DEFAULT 'completed',
+    failure_code VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME(6) NOT NULL
+);
+
+CREATE TABLE accounts (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    cpf VARCHAR(255) UNIQUE NOT NULL,
+    balance BIGINT NOT NULL,
+    opening_balance BIGINT NOT NULL DEFAULT 0,
+    created_at DATETIME(6) NOT NULL
+);
+
+CREATE TABLE account_events (
+    account_id VARCHAR(36) NOT NULL,
+    version BIGINT NOT NULL,
+    type VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    cpf VARCHAR(255) NOT NULL DEFAULT '',
+    amount BIGINT NOT NULL,
+    occurred_at DATETIME(6) NOT NULL,
+    PRIMARY KEY (account_id, version)
+);
+
+CREATE TABLE account_snapshots (
+    account_id VARCHAR(36) PRIMARY KEY NOT NULL,
+    version BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    cpf VARCHAR(255) NOT NULL,
+    balance BIGINT NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    taken_at DATETIME(6) NOT NULL
+);
+
+CREATE TABLE api_keys (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(255) NOT NULL,
+    hash VARCHAR(255) UNIQUE NOT NULL,
+    scopes VARCHAR(2048) NOT NULL,
+    expires_at DATETIME(6),
+    last_used_at DATETIME(6),
+    revoked_at DATETIME(6),
+    created_at DATETIME(6) NOT NULL
+);
+
+CREATE TABLE risk_evaluations (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    transfer_id VARCHAR(36) NOT NULL,
+    account_origin_id VARCHAR(255) NOT NULL,
+    account_destination_id VARCHAR(255) NOT NULL,
+    amount BIGINT NOT NULL,
+    decision VARCHAR(255) NOT NULL,
+    results TEXT NOT NULL,
+    created_at DATETIME(6) NOT NULL
+);
+
+CREATE INDEX risk_evaluations_account_origin_id_idx ON risk_evaluations (account_origin_id, created_at);
+
+CREATE INDEX transfers_status_idx ON transfers (status);
+
+CREATE TABLE transfer_approvals (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    transfer_id VARCHAR(36) NOT NULL,
+    action VARCHAR(255) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    role VARCHAR(255) NOT NULL,
+    reason VARCHAR(2048) NOT NULL,
+    created_at DATETIME(6) NOT NULL
+);
+
+CREATE INDEX transfer_approvals_transfer_id_idx ON transfer_approvals (transfer_id, created_at);
+
+CREATE TABLE outbox (
+    sequence BIGINT AUTO_INCREMENT PRIMARY KEY,
+    id VARCHAR(36) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    version INT NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    data TEXT NOT NULL,
+    occurred_at DATETIME(6) NOT NULL,
+    status VARCHAR(255) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME(6) NOT NULL,
+    last_error VARCHAR(2048) NOT NULL DEFAULT '',
+    sent_at DATETIME(6)
+);
+
+CREATE INDEX outbox_status_sequence_idx ON outbox (status, sequence);
+
+CREATE TABLE webhooks (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    events VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    failures INT NOT NULL DEFAULT 0,
+    disabled_at DATETIME(6),
+    created_at DATETIME(6) NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    webhook_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    event_name VARCHAR(255) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(255) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME(6) NOT NULL,
+    response_status INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(2048) NOT NULL DEFAULT '',
+    delivered_at DATETIME(6),
+    created_at DATETIME(6) NOT NULL,
+    UNIQUE (webhook_id, event_id),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks (id)
+);
+
+CREATE INDEX webhook_deliveries_status_next_attempt_at_idx ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, created_at);
+
+CREATE TABLE notification_preferences (
+    account_id VARCHAR(36) PRIMARY KEY NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    locale VARCHAR(255) NOT NULL,
+    transfer_received BOOLEAN NOT NULL DEFAULT FALSE,
+    low_balance_threshold BIGINT NOT NULL DEFAULT 0,
+    updated_at DATETIME(6) NOT NULL,
+    FOREIGN KEY (account_id) REFERENCES accounts (id)
+);
+
+CREATE TABLE notifications (
+    `key` VARCHAR(255) PRIMARY KEY NOT NULL,
+    account_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    kind VARCHAR(255) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    subject VARCHAR(2048) NOT NULL,
+    body TEXT NOT NULL,
+    status VARCHAR(255) NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    sent_at DATETIME(6)
+);
+
+CREATE TABLE audit_records (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    role VARCHAR(255) NOT NULL,
+    action VARCHAR(255) NOT NULL,
+    targets VARCHAR(2048) NOT NULL DEFAULT '',
+    input_hash CHAR(64) NOT NULL,
+    payload TEXT NOT NULL,
+    outcome VARCHAR(255) NOT NULL,
+    error VARCHAR(2048) NOT NULL DEFAULT '',
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    occurred_at DATETIME(6) NOT NULL
+);
+
+CREATE INDEX audit_records_occurred_at_idx ON audit_records (occurred_at);
+CREATE INDEX audit_records_actor_idx ON audit_records (actor, occurred_at);
+
+CREATE TABLE audit_record_targets (
+    record_id VARCHAR(36) NOT NULL,
+    target VARCHAR(255) NOT NULL,
+    PRIMARY KEY (target, record_id),
+    FOREIGN KEY (record_id) REFERENCES audit_records (id)
+);
+
+CREATE TRIGGER audit_records_no_update
+    BEFORE UPDATE ON audit_records
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit trail is append-only';
+
+CREATE TRIGGER audit_records_no_delete
+    BEFORE DELETE ON audit_records
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit trail is append-only';
+
+CREATE TRIGGER audit_record_targets_no_update
+    BEFORE UPDATE ON audit_record_targets
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit trail is append-only';
+
+CREATE TRIGGER audit_record_targets_no_delete
+    BEFORE DELETE ON audit_record_targets
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit trail is append-only';
+
+CREATE TABLE reconciliation_runs (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    status VARCHAR(255) NOT NULL,
+    triggered_by VARCHAR(255) NOT NULL,
+    accounts_checked INTEGER NOT NULL DEFAULT 0,
+    last_error VARCHAR(2048) NOT NULL DEFAULT '',
+    requested_at DATETIME(6) NOT NULL,
+    started_at DATETIME(6) NULL,
+    finished_at DATETIME(6) NULL
+);
+
+CREATE INDEX reconciliation_runs_status_idx ON reconciliation_runs (status, requested_at);
+CREATE INDEX reconciliation_runs_requested_at_idx ON reconciliation_runs (requested_at);
+
+CREATE TABLE reconciliation_mismatches (
+    run_id VARCHAR(36) NOT NULL,
+    account_id VARCHAR(36) NOT NULL,
+    stored_balance BIGINT NOT NULL,
+    expected_balance BIGINT NOT NULL,
+    PRIMARY KEY (run_id, account_id),
+    FOREIGN KEY (run_id) REFERENCES reconciliation_runs (id)
+);
+
+CREATE INDEX transfers_origin_created_at_idx ON transfers (account_origin_id, created_at);
+CREATE INDEX transfers_destination_created_at_idx ON transfers (account_destination_id, created_at);
+
+CREATE TABLE account_daily_balances (
+    account_id VARCHAR(36) NOT NULL,
+    day DATE NOT NULL,
+    closing_balance BIGINT NOT NULL,
+    credits BIGINT NOT NULL,
+    debits BIGINT NOT NULL,
+    transaction_count INTEGER NOT NULL,
+    PRIMARY KEY (account_id, day),
+    FOREIGN KEY (account_id) REFERENCES accounts (id)
+);
+
+CREATE TABLE transfer_imports (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    format VARCHAR(255) NOT NULL,
+    content_hash VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(255) NOT NULL,
+    requested_by VARCHAR(255) NOT NULL,
+    requested_role VARCHAR(255) NOT NULL,
+    requested_scopes VARCHAR(2048) NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    started_at DATETIME(6) NULL,
+    finished_at DATETIME(6) NULL
+);
+
+CREATE INDEX transfer_imports_status_idx ON transfer_imports (status, created_at);
+
+CREATE TABLE transfer_import_lines (
+    import_id VARCHAR(36) NOT NULL,
+    line INTEGER NOT NULL,
+    account_origin_id VARCHAR(255) NOT NULL,
+    account_destination_id VARCHAR(255) NOT NULL,
+    amount BIGINT NOT NULL,
+    status VARCHAR(255) NOT NULL,
+    errors TEXT NOT NULL,
+    transfer_id VARCHAR(255) NOT NULL DEFAULT '',
+    transfer_status VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (import_id, line),
+    FOREIGN KEY (import_id) REFERENCES transfer_imports (id)
+);
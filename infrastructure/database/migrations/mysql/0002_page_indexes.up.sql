@@ -0,0 +1,2 @@
+CREATE INDEX accounts_created_at_id_idx ON accounts (created_at, id);
+CREATE INDEX transfers_created_at_id_idx ON transfers (created_at, id);
@@ -0,0 +1,3 @@
+DROP INDEX transfers_destination_amount_idx ON transfers;
+DROP INDEX transfers_origin_amount_idx ON transfers;
+DROP INDEX transfers_amount_id_idx ON transfers;
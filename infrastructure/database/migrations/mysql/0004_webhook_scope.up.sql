@@ -0,0 +1,2 @@
+ALTER TABLE webhooks ADD COLUMN owner VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN account_ids VARCHAR(4096) NOT NULL DEFAULT '';
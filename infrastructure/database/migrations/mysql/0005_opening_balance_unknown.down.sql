@@ -0,0 +1,4 @@
+DROP TABLE reconciliation_unverifiable;
+
+UPDATE accounts SET opening_balance = 0 WHERE opening_balance IS NULL;
+ALTER TABLE accounts MODIFY opening_balance BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Keys issued before are each their own client; the keys they were rotated from can not be told
+ALTER TABLE api_keys ADD COLUMN client_id VARCHAR(36) NOT NULL DEFAULT '';
+UPDATE api_keys SET client_id = id;
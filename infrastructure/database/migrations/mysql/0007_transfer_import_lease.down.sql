@@ -0,0 +1,2 @@
+ALTER TABLE transfer_imports DROP COLUMN last_error;
+ALTER TABLE transfer_imports DROP COLUMN leased_until;
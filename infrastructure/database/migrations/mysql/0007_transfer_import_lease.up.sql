@@ -0,0 +1,3 @@
+-- A job is leased to the import job running it; the error that stopped its last run is kept until it completes
+ALTER TABLE transfer_imports ADD COLUMN leased_until DATETIME(6) NULL;
+ALTER TABLE transfer_imports ADD COLUMN last_error VARCHAR(2048) NOT NULL DEFAULT '';
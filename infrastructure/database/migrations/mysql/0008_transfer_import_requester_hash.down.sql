@@ -0,0 +1,3 @@
+-- Fails while two requesters imported the same file
+CREATE UNIQUE INDEX content_hash ON transfer_imports (content_hash);
+DROP INDEX transfer_imports_requester_hash_idx ON transfer_imports;
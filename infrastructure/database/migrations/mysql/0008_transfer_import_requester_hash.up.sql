@@ -0,0 +1,3 @@
+-- A file is a duplicate only of one the same requester imported, so the content hash is unique to the requester
+CREATE UNIQUE INDEX transfer_imports_requester_hash_idx ON transfer_imports (requested_by, content_hash);
+DROP INDEX content_hash ON transfer_imports;
@@ -0,0 +1,255 @@
+CREATE TABLE transfers (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    account_origin_id VARCHAR NOT NULL,
+    account_destination_id VARCHAR NOT NULL,
+    amount BIGINT NOT NULL,
+    status VARCHAR NOT NULL DEFAULT 'completed',
+    failure_code VARCHAR NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE accounts (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    name VARCHAR NOT NULL,
+    cpf VARCHAR UNIQUE NOT NULL,
+    balance BIGINT NOT NULL,
+    opening_balance BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE account_events (
+    account_id VARCHAR(36) NOT NULL,
+    version BIGINT NOT NULL,
+    type VARCHAR NOT NULL,
+    name VARCHAR NOT NULL DEFAULT '',
+    cpf VARCHAR NOT NULL DEFAULT '',
+    amount BIGINT NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (account_id, version)
+);
+
+CREATE TABLE account_snapshots (
+    account_id VARCHAR(36) PRIMARY KEY NOT NULL,
+    version BIGINT NOT NULL,
+    name VARCHAR NOT NULL,
+    cpf VARCHAR NOT NULL,
+    balance BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    taken_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE api_keys (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    name VARCHAR NOT NULL,
+    prefix VARCHAR NOT NULL,
+    hash VARCHAR UNIQUE NOT NULL,
+    scopes VARCHAR NOT NULL,
+    expires_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE risk_evaluations (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    transfer_id VARCHAR(36) NOT NULL,
+    account_origin_id VARCHAR NOT NULL,
+    account_destination_id VARCHAR NOT NULL,
+    amount BIGINT NOT NULL,
+    decision VARCHAR NOT NULL,
+    results JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX risk_evaluations_account_origin_id_idx ON risk_evaluations (account_origin_id, created_at);
+
+CREATE INDEX transfers_status_idx ON transfers (status);
+
+CREATE TABLE transfer_approvals (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    transfer_id VARCHAR(36) NOT NULL,
+    action VARCHAR NOT NULL,
+    actor VARCHAR NOT NULL,
+    role VARCHAR NOT NULL,
+    reason VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX transfer_approvals_transfer_id_idx ON transfer_approvals (transfer_id, created_at);
+
+CREATE TABLE outbox (
+    sequence BIGSERIAL PRIMARY KEY,
+    id VARCHAR(36) UNIQUE NOT NULL,
+    name VARCHAR NOT NULL,
+    version INT NOT NULL,
+    aggregate_id VARCHAR NOT NULL,
+    data JSONB NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    status VARCHAR NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error VARCHAR NOT NULL DEFAULT '',
+    sent_at TIMESTAMP
+);
+
+CREATE INDEX outbox_status_sequence_idx ON outbox (status, sequence);
+
+CREATE TABLE webhooks (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    url VARCHAR NOT NULL,
+    events VARCHAR NOT NULL,
+    secret VARCHAR NOT NULL,
+    failures INT NOT NULL DEFAULT 0,
+    disabled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    webhook_id VARCHAR(36) NOT NULL REFERENCES webhooks (id),
+    event_id VARCHAR(36) NOT NULL,
+    event_name VARCHAR NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    response_status INT NOT NULL DEFAULT 0,
+    last_error VARCHAR NOT NULL DEFAULT '',
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_status_next_attempt_at_idx ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, created_at);
+
+CREATE TABLE notification_preferences (
+    account_id VARCHAR(36) PRIMARY KEY NOT NULL REFERENCES accounts (id),
+    email VARCHAR NOT NULL,
+    locale VARCHAR NOT NULL,
+    transfer_received BOOLEAN NOT NULL DEFAULT FALSE,
+    low_balance_threshold BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE notifications (
+    key VARCHAR PRIMARY KEY NOT NULL,
+    account_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    kind VARCHAR NOT NULL,
+    recipient VARCHAR NOT NULL,
+    subject VARCHAR NOT NULL,
+    body TEXT NOT NULL,
+    status VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    sent_at TIMESTAMP
+);
+
+CREATE TABLE audit_records (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    actor VARCHAR NOT NULL,
+    role VARCHAR NOT NULL,
+    action VARCHAR NOT NULL,
+    targets VARCHAR NOT NULL DEFAULT '',
+    input_hash CHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    outcome VARCHAR NOT NULL,
+    error VARCHAR NOT NULL DEFAULT '',
+    request_id VARCHAR NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX audit_records_occurred_at_idx ON audit_records (occurred_at);
+CREATE INDEX audit_records_actor_idx ON audit_records (actor, occurred_at);
+
+CREATE TABLE audit_record_targets (
+    record_id VARCHAR(36) NOT NULL REFERENCES audit_records (id),
+    target VARCHAR NOT NULL,
+    PRIMARY KEY (target, record_id)
+);
+
+CREATE FUNCTION audit_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit trail is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_records_append_only
+    BEFORE UPDATE OR DELETE ON audit_records
+    FOR EACH ROW EXECUTE PROCEDURE audit_append_only();
+
+CREATE TRIGGER audit_records_no_truncate
+    BEFORE TRUNCATE ON audit_records
+    FOR EACH STATEMENT EXECUTE PROCEDURE audit_append_only();
+
+CREATE TRIGGER audit_record_targets_append_only
+    BEFORE UPDATE OR DELETE ON audit_record_targets
+    FOR EACH ROW EXECUTE PROCEDURE audit_append_only();
+
+CREATE TRIGGER audit_record_targets_no_truncate
+    BEFORE TRUNCATE ON audit_record_targets
+    FOR EACH STATEMENT EXECUTE PROCEDURE audit_append_only();
+
+CREATE TABLE reconciliation_runs (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    status VARCHAR NOT NULL,
+    triggered_by VARCHAR NOT NULL,
+    accounts_checked INTEGER NOT NULL DEFAULT 0,
+    last_error VARCHAR NOT NULL DEFAULT '',
+    requested_at TIMESTAMP NOT NULL,
+    started_at TIMESTAMP NULL,
+    finished_at TIMESTAMP NULL
+);
+
+CREATE INDEX reconciliation_runs_status_idx ON reconciliation_runs (status, requested_at);
+CREATE INDEX reconciliation_runs_requested_at_idx ON reconciliation_runs (requested_at);
+
+CREATE TABLE reconciliation_mismatches (
+    run_id VARCHAR(36) NOT NULL REFERENCES reconciliation_runs (id),
+    account_id VARCHAR(36) NOT NULL,
+    stored_balance BIGINT NOT NULL,
+    expected_balance BIGINT NOT NULL,
+    PRIMARY KEY (run_id, account_id)
+);
+
+CREATE INDEX transfers_origin_created_at_idx ON transfers (account_origin_id, created_at);
+CREATE INDEX transfers_destination_created_at_idx ON transfers (account_destination_id, created_at);
+
+CREATE TABLE account_daily_balances (
+    account_id VARCHAR(36) NOT NULL REFERENCES accounts (id),
+    day DATE NOT NULL,
+    closing_balance BIGINT NOT NULL,
+    credits BIGINT NOT NULL,
+    debits BIGINT NOT NULL,
+    transaction_count INTEGER NOT NULL,
+    PRIMARY KEY (account_id, day)
+);
+
+CREATE TABLE transfer_imports (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    file_name VARCHAR NOT NULL DEFAULT '',
+    format VARCHAR NOT NULL,
+    content_hash VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR NOT NULL,
+    requested_by VARCHAR NOT NULL,
+    requested_role VARCHAR NOT NULL,
+    requested_scopes VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    started_at TIMESTAMP NULL,
+    finished_at TIMESTAMP NULL
+);
+
+CREATE INDEX transfer_imports_status_idx ON transfer_imports (status, created_at);
+
+CREATE TABLE transfer_import_lines (
+    import_id VARCHAR(36) NOT NULL REFERENCES transfer_imports (id),
+    line INTEGER NOT NULL,
+    account_origin_id VARCHAR NOT NULL,
+    account_destination_id VARCHAR NOT NULL,
+    amount BIGINT NOT NULL,
+    status VARCHAR NOT NULL,
+    errors TEXT NOT NULL DEFAULT '',
+    transfer_id VARCHAR NOT NULL DEFAULT '',
+    transfer_status VARCHAR NOT NULL DEFAULT '',
+    PRIMARY KEY (import_id, line)
+);
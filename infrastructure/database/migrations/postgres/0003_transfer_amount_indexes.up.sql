@@ -0,0 +1,3 @@
+CREATE INDEX transfers_amount_id_idx ON transfers (amount, id);
+CREATE INDEX transfers_origin_amount_idx ON transfers (account_origin_id, amount, id);
+CREATE INDEX transfers_destination_amount_idx ON transfers (account_destination_id, amount, id);
@@ -0,0 +1,2 @@
+ALTER TABLE webhooks DROP COLUMN account_ids;
+ALTER TABLE webhooks DROP COLUMN owner;
@@ -0,0 +1,2 @@
+ALTER TABLE webhooks ADD COLUMN owner VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN account_ids VARCHAR NOT NULL DEFAULT '';
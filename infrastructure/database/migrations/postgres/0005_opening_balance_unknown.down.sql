@@ -0,0 +1,5 @@
+DROP TABLE reconciliation_unverifiable;
+
+UPDATE accounts SET opening_balance = 0 WHERE opening_balance IS NULL;
+ALTER TABLE accounts ALTER COLUMN opening_balance SET NOT NULL;
+ALTER TABLE accounts ALTER COLUMN opening_balance SET DEFAULT 0;
@@ -0,0 +1,9 @@
+-- An opening balance is only known when recorded as the account is opened, so accounts without one are left NULL
+ALTER TABLE accounts ALTER COLUMN opening_balance DROP DEFAULT;
+ALTER TABLE accounts ALTER COLUMN opening_balance DROP NOT NULL;
+
+CREATE TABLE reconciliation_unverifiable (
+    run_id VARCHAR(36) NOT NULL REFERENCES reconciliation_runs (id),
+    account_id VARCHAR(36) NOT NULL,
+    PRIMARY KEY (run_id, account_id)
+);
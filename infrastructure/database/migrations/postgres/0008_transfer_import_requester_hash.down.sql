@@ -0,0 +1,3 @@
+-- Fails while two requesters imported the same file
+DROP INDEX transfer_imports_requester_hash_idx;
+ALTER TABLE transfer_imports ADD CONSTRAINT transfer_imports_content_hash_key UNIQUE (content_hash);
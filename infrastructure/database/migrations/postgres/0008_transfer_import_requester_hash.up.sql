@@ -0,0 +1,3 @@
+-- A file is a duplicate only of one the same requester imported, so the content hash is unique to the requester
+ALTER TABLE transfer_imports DROP CONSTRAINT transfer_imports_content_hash_key;
+CREATE UNIQUE INDEX transfer_imports_requester_hash_idx ON transfer_imports (requested_by, content_hash);
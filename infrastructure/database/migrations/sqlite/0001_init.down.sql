@@ -0,0 +1,19 @@
+DROP TABLE transfer_import_lines;
+DROP TABLE transfer_imports;
+DROP TABLE account_daily_balances;
+DROP TABLE reconciliation_mismatches;
+DROP TABLE reconciliation_runs;
+DROP TABLE audit_record_targets;
+DROP TABLE audit_records;
+DROP TABLE notifications;
+DROP TABLE notification_preferences;
+DROP TABLE webhook_deliveries;
+DROP TABLE webhooks;
+DROP TABLE outbox;
+DROP TABLE transfer_approvals;
+DROP TABLE risk_evaluations;
+DROP TABLE api_keys;
+DROP TABLE account_snapshots;
+DROP TABLE account_events;
+DROP TABLE accounts;
+DROP TABLE transfers;
@@ -0,0 +1,2 @@
+DROP INDEX transfers_created_at_id_idx;
+DROP INDEX accounts_created_at_id_idx;
@@ -0,0 +1,21 @@
+DROP TABLE reconciliation_unverifiable;
+
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TEMP TABLE accounts_copy AS SELECT * FROM accounts;
+DROP TABLE accounts;
+
+CREATE TABLE accounts (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    name VARCHAR NOT NULL,
+    cpf VARCHAR UNIQUE NOT NULL,
+    balance BIGINT NOT NULL,
+    opening_balance BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL
+);
+
+INSERT INTO accounts (id, name, cpf, balance, opening_balance, created_at)
+    SELECT id, name, cpf, balance, COALESCE(opening_balance, 0), created_at FROM accounts_copy;
+DROP TABLE accounts_copy;
+
+CREATE INDEX accounts_created_at_id_idx ON accounts (created_at, id);
@@ -0,0 +1,27 @@
+-- An opening balance is only known when recorded as the account is opened, so accounts without one are left NULL.
+-- SQLite can not change a column, so the table is rebuilt, the references to it checked as the migration commits
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TEMP TABLE accounts_copy AS SELECT * FROM accounts;
+DROP TABLE accounts;
+
+CREATE TABLE accounts (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    name VARCHAR NOT NULL,
+    cpf VARCHAR UNIQUE NOT NULL,
+    balance BIGINT NOT NULL,
+    opening_balance BIGINT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+INSERT INTO accounts (id, name, cpf, balance, opening_balance, created_at)
+    SELECT id, name, cpf, balance, opening_balance, created_at FROM accounts_copy;
+DROP TABLE accounts_copy;
+
+CREATE INDEX accounts_created_at_id_idx ON accounts (created_at, id);
+
+CREATE TABLE reconciliation_unverifiable (
+    run_id VARCHAR(36) NOT NULL REFERENCES reconciliation_runs (id),
+    account_id VARCHAR(36) NOT NULL,
+    PRIMARY KEY (run_id, account_id)
+);
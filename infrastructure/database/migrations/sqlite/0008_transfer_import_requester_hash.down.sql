@@ -0,0 +1,33 @@
+-- Fails while two requesters imported the same file
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TEMP TABLE transfer_imports_copy AS SELECT * FROM transfer_imports;
+DROP TABLE transfer_imports;
+
+CREATE TABLE transfer_imports (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    file_name VARCHAR NOT NULL DEFAULT '',
+    format VARCHAR NOT NULL,
+    content_hash VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR NOT NULL,
+    requested_by VARCHAR NOT NULL,
+    requested_role VARCHAR NOT NULL,
+    requested_scopes VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    started_at TIMESTAMP NULL,
+    finished_at TIMESTAMP NULL,
+    leased_until TIMESTAMP NULL,
+    last_error VARCHAR NOT NULL DEFAULT ''
+);
+
+INSERT INTO transfer_imports (
+    id, file_name, format, content_hash, status, requested_by, requested_role, requested_scopes, created_at,
+    started_at, finished_at, leased_until, last_error
+)
+    SELECT
+        id, file_name, format, content_hash, status, requested_by, requested_role, requested_scopes, created_at,
+        started_at, finished_at, leased_until, last_error
+    FROM transfer_imports_copy;
+DROP TABLE transfer_imports_copy;
+
+CREATE INDEX transfer_imports_status_idx ON transfer_imports (status, created_at);
@@ -0,0 +1,36 @@
+-- A file is a duplicate only of one the same requester imported, so the content hash is unique to the requester.
+-- SQLite can not drop the constraint of a column, so the table is rebuilt, the references to it checked as the
+-- migration commits
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TEMP TABLE transfer_imports_copy AS SELECT * FROM transfer_imports;
+DROP TABLE transfer_imports;
+
+CREATE TABLE transfer_imports (
+    id VARCHAR(36) PRIMARY KEY NOT NULL,
+    file_name VARCHAR NOT NULL DEFAULT '',
+    format VARCHAR NOT NULL,
+    content_hash VARCHAR(64) NOT NULL,
+    status VARCHAR NOT NULL,
+    requested_by VARCHAR NOT NULL,
+    requested_role VARCHAR NOT NULL,
+    requested_scopes VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    started_at TIMESTAMP NULL,
+    finished_at TIMESTAMP NULL,
+    leased_until TIMESTAMP NULL,
+    last_error VARCHAR NOT NULL DEFAULT ''
+);
+
+INSERT INTO transfer_imports (
+    id, file_name, format, content_hash, status, requested_by, requested_role, requested_scopes, created_at,
+    started_at, finished_at, leased_until, last_error
+)
+    SELECT
+        id, file_name, format, content_hash, status, requested_by, requested_role, requested_scopes, created_at,
+        started_at, finished_at, leased_until, last_error
+    FROM transfer_imports_copy;
+DROP TABLE transfer_imports_copy;
+
+CREATE INDEX transfer_imports_status_idx ON transfer_imports (status, created_at);
+CREATE UNIQUE INDEX transfer_imports_requester_hash_idx ON transfer_imports (requested_by, content_hash);
@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 	"github.com/gsabadini/go-clean-architecture/adapter/repository"
+	"github.com/gsabadini/go-clean-architecture/domain"
 	"log"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
+// mongoHandler reads with the read preference of its config. The reads of a session, or of a context asking to read
+// its own writes, go to the primary whatever the preference
 type mongoHandler struct {
 	db     *mongo.Database
 	client *mongo.Client
@@ -26,7 +31,12 @@ func NewMongoHandler(c *config) (*mongoHandler, error) {
 		c.password,
 	)
 
-	clientOpts := options.Client().ApplyURI(uri)
+	readPreference, err := mongoReadPreference(c)
+	if err != nil {
+		return &mongoHandler{}, err
+	}
+
+	clientOpts := options.Client().ApplyURI(uri).SetReadPreference(readPreference)
 	client, err := mongo.Connect(ctx, clientOpts)
 	if err != nil {
 		log.Fatal(err)
@@ -43,6 +53,39 @@ func NewMongoHandler(c *config) (*mongoHandler, error) {
 	}, nil
 }
 
+// mongoReadPreference builds the read preference of the config, primary when it names none
+func mongoReadPreference(c *config) (*readpref.ReadPref, error) {
+	if c.readPreference == "" {
+		return readpref.Primary(), nil
+	}
+
+	mode, err := readpref.ModeFromString(c.readPreference)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []readpref.Option
+	if c.maxStaleness != "" {
+		maxStaleness, err := time.ParseDuration(c.maxStaleness)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, readpref.WithMaxStaleness(maxStaleness))
+	}
+
+	return readpref.New(mode, opts...)
+}
+
+// reader is the collection serving the reads of the context
+func (mgo mongoHandler) reader(ctx context.Context, collection string) *mongo.Collection {
+	if mongo.SessionFromContext(ctx) != nil || domain.ReadYourWritesFromContext(ctx) {
+		return mgo.db.Collection(collection, options.Collection().SetReadPreference(readpref.Primary()))
+	}
+
+	return mgo.db.Collection(collection)
+}
+
 func (mgo mongoHandler) Store(ctx context.Context, collection string, data interface{}) error {
 	if _, err := mgo.db.Collection(collection).InsertOne(ctx, data); err != nil {
 		return err
@@ -59,8 +102,22 @@ func (mgo mongoHandler) Update(ctx context.Context, collection string, query int
 	return nil
 }
 
+func (mgo mongoHandler) UpdateMatched(
+	ctx context.Context,
+	collection string,
+	query interface{},
+	update interface{},
+) (bool, error) {
+	result, err := mgo.db.Collection(collection).UpdateOne(ctx, query, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount == 1, nil
+}
+
 func (mgo mongoHandler) FindAll(ctx context.Context, collection string, query interface{}, result interface{}) error {
-	cur, err := mgo.db.Collection(collection).Find(ctx, query)
+	cur, err := mgo.reader(ctx, collection).Find(ctx, query)
 	if err != nil {
 		return err
 	}
@@ -77,6 +134,55 @@ func (mgo mongoHandler) FindAll(ctx context.Context, collection string, query in
 	return nil
 }
 
+func (mgo mongoHandler) FindPage(
+	ctx context.Context,
+	collection string,
+	query interface{},
+	sort interface{},
+	limit int64,
+	result interface{},
+) error {
+	cur, err := mgo.reader(ctx, collection).Find(ctx, query, options.Find().SetSort(sort).SetLimit(limit))
+	if err != nil {
+		return err
+	}
+
+	defer cur.Close(ctx)
+	return cur.All(ctx, result)
+}
+
+func (mgo mongoHandler) Stream(
+	ctx context.Context,
+	collection string,
+	query interface{},
+	sort interface{},
+	fn func(func(interface{}) error) error,
+) error {
+	cur, err := mgo.reader(ctx, collection).Find(ctx, query, options.Find().SetSort(sort))
+	if err != nil {
+		return err
+	}
+
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		if err = fn(cur.Decode); err != nil {
+			return err
+		}
+	}
+
+	return cur.Err()
+}
+
+func (mgo mongoHandler) Aggregate(ctx context.Context, collection string, pipeline interface{}, result interface{}) error {
+	cur, err := mgo.reader(ctx, collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+
+	defer cur.Close(ctx)
+	return cur.All(ctx, result)
+}
+
 func (mgo mongoHandler) FindOne(
 	ctx context.Context,
 	collection string,
@@ -84,7 +190,7 @@ func (mgo mongoHandler) FindOne(
 	projection interface{},
 	result interface{},
 ) error {
-	var err = mgo.db.Collection(collection).
+	var err = mgo.reader(ctx, collection).
 		FindOne(
 			ctx,
 			query,
@@ -98,7 +204,8 @@ func (mgo mongoHandler) FindOne(
 }
 
 func (mgo *mongoHandler) StartSession() (repository.Session, error) {
-	session, err := mgo.client.StartSession()
+	// Transactions read from the primary, whatever the read preference of the client
+	session, err := mgo.client.StartSession(options.Session().SetDefaultReadPreference(readpref.Primary()))
 	if err != nil {
 		log.Fatal(err)
 	}
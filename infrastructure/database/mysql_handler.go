@@ -0,0 +1,148 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/repository"
+)
+
+// mysqlHandler runs the queries of the SQL repositories on MySQL, rebound by its dialect. Timestamps are kept in UTC,
+// and the session quotes identifiers with double quotes, as the other engines do. The reads made outside of a
+// transaction are spread over the replicas, when it has some
+type mysqlHandler struct {
+	db      *sql.DB
+	reads   *replicaSet
+	dialect repository.Dialect
+}
+
+func NewMySQLHandler(c *config) (*mysqlHandler, error) {
+	var ds = mysql.NewConfig()
+	ds.Net = "tcp"
+	ds.Addr = net.JoinHostPort(c.host, c.port)
+	ds.User = c.user
+	ds.Passwd = c.password
+	ds.DBName = c.database
+	ds.ParseTime = true
+	ds.Loc = time.UTC
+	ds.Params = map[string]string{
+		"sql_mode":  "'TRADITIONAL,ANSI_QUOTES'",
+		"time_zone": "'+00:00'",
+	}
+
+	db, err := sql.Open(c.driver, ds.FormatDSN())
+	if err != nil {
+		return &mysqlHandler{}, err
+	}
+
+	if err = db.Ping(); err != nil {
+		return &mysqlHandler{}, err
+	}
+
+	var replicas []*sql.DB
+	for _, address := range c.replicas {
+		var replicaDS = ds.Clone()
+		replicaDS.Addr = net.JoinHostPort(replicaHostPort(address, c.port))
+
+		replica, err := sql.Open(c.driver, replicaDS.FormatDSN())
+		if err != nil {
+			return &mysqlHandler{}, err
+		}
+
+		replicas = append(replicas, replica)
+	}
+
+	var reads = newReplicaSet(db, replicas...)
+	reads.watch(replicaCheckInterval)
+
+	return &mysqlHandler{db: db, reads: reads, dialect: repository.MySQLDialect{}}, nil
+}
+
+func (m mysqlHandler) Dialect() repository.Dialect {
+	return m.dialect
+}
+
+func (m mysqlHandler) BeginTx(ctx context.Context) (repository.Tx, error) {
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return mysqlTx{}, err
+	}
+
+	return mysqlTx{tx: tx, dialect: m.dialect}, nil
+}
+
+func (m mysqlHandler) ExecuteContext(ctx context.Context, query string, args ...interface{}) error {
+	query, args = m.dialect.Rebind(query, args)
+
+	_, err := m.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (m mysqlHandler) ExecuteAffectedContext(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	query, args = m.dialect.Rebind(query, args)
+
+	result, err := m.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (m mysqlHandler) QueryContext(ctx context.Context, query string, args ...interface{}) (repository.Rows, error) {
+	query, args = m.dialect.Rebind(query, args)
+
+	rows, err := m.reads.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPostgresRows(rows), nil
+}
+
+func (m mysqlHandler) QueryRowContext(ctx context.Context, query string, args ...interface{}) repository.Row {
+	query, args = m.dialect.Rebind(query, args)
+
+	return newPostgresRow(m.reads.QueryRowContext(ctx, query, args...))
+}
+
+type mysqlTx struct {
+	tx      *sql.Tx
+	dialect repository.Dialect
+}
+
+func (m mysqlTx) ExecuteContext(ctx context.Context, query string, args ...interface{}) error {
+	query, args = m.dialect.Rebind(query, args)
+
+	_, err := m.tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (m mysqlTx) QueryContext(ctx context.Context, query string, args ...interface{}) (repository.Rows, error) {
+	query, args = m.dialect.Rebind(query, args)
+
+	rows, err := m.tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPostgresRows(rows), nil
+}
+
+func (m mysqlTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) repository.Row {
+	query, args = m.dialect.Rebind(query, args)
+
+	return newPostgresRow(m.tx.QueryRowContext(ctx, query, args...))
+}
+
+func (m mysqlTx) Commit() error {
+	return m.tx.Commit()
+}
+
+func (m mysqlTx) Rollback() error {
+	return m.tx.Rollback()
+}
@@ -11,19 +11,15 @@ import (
 	_ "github.com/lib/pq"
 )
 
+// postgresHandler writes to the primary database and spreads the reads made outside of a transaction over its
+// replicas, when it has some
 type postgresHandler struct {
-	db *sql.DB
+	db    *sql.DB
+	reads *replicaSet
 }
 
 func NewPostgresHandler(c *config) (*postgresHandler, error) {
-	var ds = fmt.Sprintf(
-		"host=%s port=%s user=%s dbname=%s sslmode=disable password=%s",
-		c.host,
-		c.port,
-		c.user,
-		c.database,
-		c.password,
-	)
+	var ds = postgresDSN(c, c.host, c.port)
 
 	fmt.Println(ds)
 	db, err := sql.Open(c.driver, ds)
@@ -36,7 +32,37 @@ func NewPostgresHandler(c *config) (*postgresHandler, error) {
 		log.Fatalln(err)
 	}
 
-	return &postgresHandler{db: db}, nil
+	var replicas []*sql.DB
+	for _, address := range c.replicas {
+		host, port := replicaHostPort(address, c.port)
+
+		replica, err := sql.Open(c.driver, postgresDSN(c, host, port))
+		if err != nil {
+			return &postgresHandler{}, err
+		}
+
+		replicas = append(replicas, replica)
+	}
+
+	var reads = newReplicaSet(db, replicas...)
+	reads.watch(replicaCheckInterval)
+
+	return &postgresHandler{db: db, reads: reads}, nil
+}
+
+func postgresDSN(c *config, host, port string) string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s dbname=%s sslmode=disable password=%s",
+		host,
+		port,
+		c.user,
+		c.database,
+		c.password,
+	)
+}
+
+func (p postgresHandler) Dialect() repository.Dialect {
+	return repository.PostgresDialect{}
 }
 
 func (p postgresHandler) BeginTx(ctx context.Context) (repository.Tx, error) {
@@ -57,8 +83,17 @@ func (p postgresHandler) ExecuteContext(ctx context.Context, query string, args
 	return nil
 }
 
+func (p postgresHandler) ExecuteAffectedContext(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	result, err := p.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 func (p postgresHandler) QueryContext(ctx context.Context, query string, args ...interface{}) (repository.Rows, error) {
-	rows, err := p.db.QueryContext(ctx, query, args...)
+	rows, err := p.reads.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +104,7 @@ func (p postgresHandler) QueryContext(ctx context.Context, query string, args ..
 }
 
 func (p postgresHandler) QueryRowContext(ctx context.Context, query string, args ...interface{}) repository.Row {
-	row := p.db.QueryRowContext(ctx, query, args...)
+	row := p.reads.QueryRowContext(ctx, query, args...)
 
 	return newPostgresRow(row)
 }
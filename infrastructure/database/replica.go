@@ -0,0 +1,149 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"regexp"
+	"sync/atomic"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/repository"
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// lockingRead matches the clauses locking the rows read, which a replica can not take
+var lockingRead = regexp.MustCompile(`(?i)\bFOR\s+(NO\s+KEY\s+UPDATE|UPDATE|KEY\s+SHARE|SHARE)\b|\bLOCK\s+IN\s+SHARE\s+MODE\b`)
+
+const (
+	// replicaCheckInterval is how often the replicas are pinged, to take them out of the reads and back in
+	replicaCheckInterval = 5 * time.Second
+
+	replicaCheckTimeout = 2 * time.Second
+)
+
+type (
+	// replicaSet spreads the reads made outside of a transaction over the replicas of the primary database,
+	// round-robin. The reads of a transaction, of a context asking to read its own writes, or locking their rows go
+	// to the primary, as do all of them while no replica is healthy
+	replicaSet struct {
+		primary  *sql.DB
+		replicas []*replica
+		next     atomic.Uint64
+	}
+
+	replica struct {
+		db      *sql.DB
+		healthy atomic.Bool
+	}
+)
+
+// newReplicaSet checks the replicas once before taking them in the reads. Without replicas every read goes to the
+// primary
+func newReplicaSet(primary *sql.DB, replicas ...*sql.DB) *replicaSet {
+	var r = &replicaSet{primary: primary}
+	for _, db := range replicas {
+		r.replicas = append(r.replicas, &replica{db: db})
+	}
+
+	r.check(context.Background())
+	return r
+}
+
+// replicaHostPort splits the address of a replica, which reaches the port of the primary when it names none
+func replicaHostPort(address, port string) (string, string) {
+	if host, replicaPort, err := net.SplitHostPort(address); err == nil {
+		return host, replicaPort
+	}
+
+	return address, port
+}
+
+// watch checks the replicas every interval, for as long as the process runs
+func (r *replicaSet) watch(interval time.Duration) {
+	if len(r.replicas) == 0 {
+		return
+	}
+
+	go func() {
+		var ticker = time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			r.check(context.Background())
+		}
+	}()
+}
+
+// check pings every replica, taking the ones that answer in the reads and the others out of them
+func (r *replicaSet) check(ctx context.Context) {
+	for _, replica := range r.replicas {
+		ctxPing, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+		replica.healthy.Store(replica.db.PingContext(ctxPing) == nil)
+		cancel()
+	}
+}
+
+// reader chooses the database serving a read of the context, and the replica behind it when it is not the primary
+func (r *replicaSet) reader(ctx context.Context, query string) (*sql.DB, *replica) {
+	if _, ok := ctx.Value("TransactionContextKey").(repository.Tx); ok || domain.ReadYourWritesFromContext(ctx) {
+		return r.primary, nil
+	}
+
+	if lockingRead.MatchString(query) {
+		return r.primary, nil
+	}
+
+	for range r.replicas {
+		var replica = r.replicas[(r.next.Add(1)-1)%uint64(len(r.replicas))]
+		if replica.healthy.Load() {
+			return replica.db, replica
+		}
+	}
+
+	return r.primary, nil
+}
+
+// QueryContext reads from the database chosen by reader. A query failed by a replica runs again on the primary, and
+// the replica is taken out of the reads until its next check when it no longer answers a ping
+func (r *replicaSet) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	db, replica := r.reader(ctx, query)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil && r.fallback(ctx, replica) {
+		return r.primary.QueryContext(ctx, query, args...)
+	}
+
+	return rows, err
+}
+
+// QueryRowContext reads a row as QueryContext does
+func (r *replicaSet) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	db, replica := r.reader(ctx, query)
+
+	row := db.QueryRowContext(ctx, query, args...)
+	if row.Err() != nil && r.fallback(ctx, replica) {
+		return r.primary.QueryRowContext(ctx, query, args...)
+	}
+
+	return row
+}
+
+// fallback tells whether a read failed by the replica goes to the primary, taking the replica out of the reads when
+// it is down. A replica answering its ping may still fail a query, lagging behind a migration or cancelling it on a
+// conflict with the changes it replays, so the query goes to the primary either way. Queries failed by the primary,
+// or by their own context, are not run again
+func (r *replicaSet) fallback(ctx context.Context, replica *replica) bool {
+	if replica == nil || ctx.Err() != nil {
+		return false
+	}
+
+	ctxPing, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+	defer cancel()
+
+	if err := replica.db.PingContext(ctxPing); err != nil {
+		replica.healthy.Store(false)
+	}
+
+	return true
+}
@@ -0,0 +1,274 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+func TestReplicaSet_Reads(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = context.Background()
+		primary  = newReplicaTestDatabase(t, "primary")
+		replicas = newReplicaSet(primary, newReplicaTestDatabase(t, "a"), newReplicaTestDatabase(t, "b"))
+	)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected []string
+	}{
+		{name: "Outside of a transaction, round-robin", ctx: ctx, expected: []string{"a", "b", "a", "b"}},
+		{
+			name:     "Inside a transaction",
+			ctx:      context.WithValue(ctx, "TransactionContextKey", postgresTx{}),
+			expected: []string{"primary", "primary"},
+		},
+		{
+			name:     "Reading its own writes",
+			ctx:      domain.ContextWithReadYourWrites(ctx),
+			expected: []string{"primary", "primary"},
+		},
+	}
+
+	for _, tt := range tests {
+		var result []string
+		for range tt.expected {
+			result = append(result, queryReplicaTestNode(t, tt.ctx, replicas))
+		}
+
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+		}
+	}
+}
+
+func TestReplicaSet_LockingReads(t *testing.T) {
+	t.Parallel()
+
+	var (
+		primary  = newReplicaTestDatabase(t, "primary")
+		replicas = newReplicaSet(primary, newReplicaTestDatabase(t, "a"))
+	)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected bool
+	}{
+		{name: "Plain read", query: "SELECT name FROM node", expected: false},
+		{name: "Locked for update", query: "SELECT name FROM node FOR UPDATE", expected: true},
+		{name: "Locked for no key update", query: "SELECT name FROM node\n\tFOR NO KEY UPDATE", expected: true},
+		{name: "Locked for share", query: "SELECT name FROM node for share", expected: true},
+		{name: "Locked in share mode", query: "SELECT name FROM node LOCK IN SHARE MODE", expected: true},
+		{name: "Column named like a lock", query: "SELECT update_at FROM node", expected: false},
+	}
+
+	for _, tt := range tests {
+		db, _ := replicas.reader(context.Background(), tt.query)
+		if result := db == primary; result != tt.expected {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+		}
+	}
+}
+
+func TestReplicaSet_QueryError(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = context.Background()
+		primary  = newReplicaTestDatabase(t, "primary")
+		a        = newReplicaTestDatabase(t, "a")
+		replicas = newReplicaSet(primary, a)
+	)
+
+	// The replica answers its ping but fails the query, as one lagging behind the migration creating the table
+	if _, err := a.Exec("DROP TABLE node"); err != nil {
+		t.Fatal(err)
+	}
+
+	var result = []string{queryReplicaTestNode(t, ctx, replicas), queryReplicaTestNodeRows(t, ctx, replicas)}
+	if expected := []string{"primary", "primary"}; !reflect.DeepEqual(result, expected) {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Falls back to the primary", result, expected)
+	}
+
+	if !replicas.replicas[0].healthy.Load() {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Keeps the replica answering its ping", false, true)
+	}
+}
+
+func TestReplicaSet_Health(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = context.Background()
+		primary  = newReplicaTestDatabase(t, "primary")
+		a        = newReplicaTestDatabase(t, "a")
+		b        = newReplicaTestDatabase(t, "b")
+		replicas = newReplicaSet(primary, a, b)
+	)
+
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The closed replica still takes reads until it fails one, which then falls back to the primary
+	var result = []string{queryReplicaTestNode(t, ctx, replicas), queryReplicaTestNode(t, ctx, replicas)}
+	if expected := []string{"a", "primary"}; !reflect.DeepEqual(result, expected) {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Falls back to the primary", result, expected)
+	}
+
+	result = []string{queryReplicaTestNode(t, ctx, replicas), queryReplicaTestNode(t, ctx, replicas)}
+	if expected := []string{"a", "a"}; !reflect.DeepEqual(result, expected) {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Skips the unhealthy replica", result, expected)
+	}
+
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var rows = []string{queryReplicaTestNodeRows(t, ctx, replicas), queryReplicaTestNodeRows(t, ctx, replicas)}
+	if expected := []string{"primary", "primary"}; !reflect.DeepEqual(rows, expected) {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Reads the primary without replicas", rows, expected)
+	}
+
+	// The replicas failing their check never take reads
+	if result := queryReplicaTestNode(t, ctx, newReplicaSet(primary, a, b)); result != "primary" {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Checks the replicas", result, "primary")
+	}
+}
+
+func TestReplicaHostPort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		address      string
+		expectedHost string
+		expectedPort string
+	}{
+		{name: "Host and port", address: "replica-1:5433", expectedHost: "replica-1", expectedPort: "5433"},
+		{name: "Host alone", address: "replica-1", expectedHost: "replica-1", expectedPort: "5432"},
+		{name: "IPv6 host and port", address: "[::1]:5433", expectedHost: "::1", expectedPort: "5433"},
+	}
+
+	for _, tt := range tests {
+		host, port := replicaHostPort(tt.address, "5432")
+		if host != tt.expectedHost || port != tt.expectedPort {
+			t.Errorf(
+				"[TestCase '%s'] Result: '%v:%v' | Expected: '%v:%v'",
+				tt.name,
+				host,
+				port,
+				tt.expectedHost,
+				tt.expectedPort,
+			)
+		}
+	}
+}
+
+func TestMongoReadPreference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		config               *config
+		expectedMode         readpref.Mode
+		expectedMaxStaleness time.Duration
+		expectedError        bool
+	}{
+		{name: "Primary by default", config: &config{}, expectedMode: readpref.PrimaryMode},
+		{
+			name:         "Secondary preferred",
+			config:       &config{readPreference: "secondaryPreferred"},
+			expectedMode: readpref.SecondaryPreferredMode,
+		},
+		{
+			name:                 "Nearest with a bounded lag",
+			config:               &config{readPreference: "nearest", maxStaleness: "90s"},
+			expectedMode:         readpref.NearestMode,
+			expectedMaxStaleness: 90 * time.Second,
+		},
+		{name: "Unknown mode", config: &config{readPreference: "fastest"}, expectedError: true},
+		{name: "Invalid lag", config: &config{readPreference: "secondary", maxStaleness: "soon"}, expectedError: true},
+		{name: "Primary with a lag", config: &config{readPreference: "primary", maxStaleness: "90s"}, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		result, err := mongoReadPreference(tt.config)
+		if (err != nil) != tt.expectedError {
+			t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		maxStaleness, _ := result.MaxStaleness()
+		if result.Mode() != tt.expectedMode || maxStaleness != tt.expectedMaxStaleness {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expectedMode)
+		}
+	}
+}
+
+// newReplicaTestDatabase creates an SQLite database whose node table names it
+func newReplicaTestDatabase(t *testing.T, name string) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), name+".db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	if _, err = db.Exec("CREATE TABLE node (name TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = db.Exec("INSERT INTO node (name) VALUES (?)", name); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func queryReplicaTestNode(t *testing.T, ctx context.Context, replicas *replicaSet) string {
+	t.Helper()
+
+	var name string
+	if err := replicas.QueryRowContext(ctx, "SELECT name FROM node").Scan(&name); err != nil {
+		t.Fatal(err)
+	}
+
+	return name
+}
+
+func queryReplicaTestNodeRows(t *testing.T, ctx context.Context, replicas *replicaSet) string {
+	t.Helper()
+
+	rows, err := replicas.QueryContext(ctx, "SELECT name FROM node")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer rows.Close()
+
+	var name string
+	for rows.Next() {
+		if err = rows.Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return name
+}
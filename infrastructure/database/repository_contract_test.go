@@ -0,0 +1,576 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/repository"
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// The contract tests run the account and transfer repositories of every backend through the same cases. Memory and
+// SQLite always run; Postgres and MySQL run when TEST_POSTGRES or TEST_MYSQL is set, against the database described by
+// their usual environment variables. The SQL databases are migrated first
+
+func TestRepositoryContract_Memory(t *testing.T) {
+	t.Parallel()
+
+	var memory = repository.NewMemory()
+	testRepositoryContract(t, repository.NewAccountMemory(memory), repository.NewTransferMemory(memory))
+}
+
+func TestRepositoryContract_SQLite(t *testing.T) {
+	t.Parallel()
+
+	db, err := NewSQLiteHandler(&config{database: filepath.Join(t.TempDir(), "bank.db"), driver: "sqlite"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrateContractDatabase(t, db)
+	testRepositoryContract(t, repository.NewAccountSQL(db), repository.NewTransferSQL(db))
+}
+
+func TestRepositoryContract_Postgres(t *testing.T) {
+	if os.Getenv("TEST_POSTGRES") == "" {
+		t.Skip("TEST_POSTGRES is not set")
+	}
+
+	t.Parallel()
+
+	db, err := NewPostgresHandler(newConfigPostgres())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrateContractDatabase(t, db)
+	testRepositoryContract(t, repository.NewAccountSQL(db), repository.NewTransferSQL(db))
+}
+
+func TestRepositoryContract_MySQL(t *testing.T) {
+	if os.Getenv("TEST_MYSQL") == "" {
+		t.Skip("TEST_MYSQL is not set")
+	}
+
+	t.Parallel()
+
+	db, err := NewMySQLHandler(newConfigMySQL())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrateContractDatabase(t, db)
+	testRepositoryContract(t, repository.NewAccountSQL(db), repository.NewTransferSQL(db))
+}
+
+// contractPage holds every row written by a case
+var contractPage = domain.PageRequest{Limit: domain.MaxPageSize}
+
+// testRepositoryContract leaves the rows it writes behind. Their IDs and CPFs are new on every run, so it can run
+// again on the same database
+func testRepositoryContract(t *testing.T, accounts domain.AccountRepository, transfers domain.TransferRepository) {
+	var ctx = context.Background()
+
+	t.Run("Creates and finds an account", func(t *testing.T) {
+		var account = newContractAccount(1000)
+		if _, err := accounts.Create(ctx, account); err != nil {
+			t.Fatal(err)
+		}
+
+		byID, err := accounts.FindByID(ctx, account.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertContractAccount(t, "FindByID", byID, account)
+
+		byCPF, err := accounts.FindByCPF(ctx, account.CPF())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertContractAccount(t, "FindByCPF", byCPF, account)
+
+		balance, err := accounts.FindBalance(ctx, account.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if balance.Balance() != account.Balance() {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "FindBalance", balance.Balance(), account.Balance())
+		}
+	})
+
+	t.Run("Pages the accounts by cursor", func(t *testing.T) {
+		var (
+			createdAt = time.Now().UTC().Truncate(time.Microsecond)
+			created   []domain.AccountID
+		)
+
+		for i := 0; i < 3; i++ {
+			var account = domain.NewAccount(domain.AccountID(domain.NewUUID()), "Test", domain.NewUUID(), 0, createdAt)
+			if _, err := accounts.Create(ctx, account); err != nil {
+				t.Fatal(err)
+			}
+
+			created = append(created, account.ID())
+		}
+
+		// Accounts created at the same time are paged in the order of their IDs
+		sort.Slice(created, func(i, j int) bool { return created[i] < created[j] })
+
+		first, err := accounts.FindAll(ctx, domain.PageRequest{
+			Limit: 2,
+			After: domain.NewCursor(createdAt.Add(-time.Microsecond), domain.NewUUID()),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(first) != 2 || first[0].ID() != created[0] || first[1].ID() != created[1] {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "First page", first, created[:2])
+			return
+		}
+
+		second, err := accounts.FindAll(ctx, domain.PageRequest{
+			Limit: 1,
+			After: domain.NewCursor(first[1].CreatedAt(), first[1].ID().String()),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(second) != 1 || second[0].ID() != created[2] {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Next page", second, created[2])
+		}
+	})
+
+	t.Run("Rejects a second account with the same CPF", func(t *testing.T) {
+		var account = newContractAccount(0)
+		if _, err := accounts.Create(ctx, account); err != nil {
+			t.Fatal(err)
+		}
+
+		var duplicate = domain.NewAccount(domain.AccountID(domain.NewUUID()), "Other", account.CPF(), 0, account.CreatedAt())
+		if _, err := accounts.Create(ctx, duplicate); err == nil {
+			t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Duplicate CPF", err, "duplicate key")
+		}
+	})
+
+	t.Run("Does not find unknown accounts and transfers", func(t *testing.T) {
+		if _, err := accounts.FindByID(ctx, domain.AccountID(domain.NewUUID())); err != domain.ErrAccountNotFound {
+			t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Account", err, domain.ErrAccountNotFound)
+		}
+
+		if _, err := transfers.FindByID(ctx, domain.TransferID(domain.NewUUID())); err != domain.ErrTransferNotFound {
+			t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Transfer", err, domain.ErrTransferNotFound)
+		}
+	})
+
+	t.Run("Updates the balance", func(t *testing.T) {
+		var account = newContractAccount(1000)
+		if _, err := accounts.Create(ctx, account); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := accounts.UpdateBalance(ctx, account.ID(), 250); err != nil {
+			t.Fatal(err)
+		}
+
+		balance, err := accounts.FindBalance(ctx, account.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if balance.Balance() != 250 {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "UpdateBalance", balance.Balance(), 250)
+		}
+	})
+
+	t.Run("Creates, finds and lists transfers", func(t *testing.T) {
+		var (
+			origin      = newContractAccount(1000)
+			destination = newContractAccount(0)
+			first       = newContractTransfer(origin.ID(), destination.ID(), 100, 0)
+			second      = newContractTransfer(destination.ID(), origin.ID(), 50, time.Second)
+		)
+
+		for _, transfer := range []domain.Transfer{second, first} {
+			if _, err := transfers.Create(ctx, transfer); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		found, err := transfers.FindByID(ctx, first.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if found.ID() != first.ID() ||
+			found.AccountOriginID() != first.AccountOriginID() ||
+			found.AccountDestinationID() != first.AccountDestinationID() ||
+			found.Amount() != first.Amount() ||
+			found.Status() != first.Status() ||
+			!found.CreatedAt().Equal(first.CreatedAt()) {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "FindByID", found, first)
+		}
+
+		all, err := transfers.FindAll(ctx, domain.TransferFilter{AccountID: origin.ID()}, contractPage)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(all) != 2 || all[0].ID() != first.ID() || all[1].ID() != second.ID() {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "FindAll oldest first", all, []domain.Transfer{first, second})
+		}
+
+		page, err := transfers.FindAll(
+			ctx,
+			domain.TransferFilter{AccountID: origin.ID()},
+			domain.PageRequest{Limit: 1, After: domain.NewCursor(first.CreatedAt(), first.ID().String())},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(page) != 1 || page[0].ID() != second.ID() {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "FindAll after cursor", page, second.ID())
+		}
+
+		if err = transfers.UpdateStatus(ctx, second.ID(), domain.TransferStatusFailed); err != nil {
+			t.Fatal(err)
+		}
+
+		failed, err := transfers.FindAll(ctx, domain.TransferFilter{
+			AccountID: origin.ID(),
+			Status:    domain.TransferStatusFailed,
+		}, contractPage)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(failed) != 1 || failed[0].ID() != second.ID() {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "FindAll by status", failed, second.ID())
+		}
+
+		ranged, err := transfers.FindAll(ctx, domain.TransferFilter{
+			AccountID: origin.ID(),
+			From:      first.CreatedAt(),
+			To:        second.CreatedAt(),
+		}, contractPage)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(ranged) != 1 || ranged[0].ID() != first.ID() {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "FindAll by creation time", ranged, first.ID())
+		}
+	})
+
+	t.Run("Filters and sorts the transfers", func(t *testing.T) {
+		var (
+			origin      = domain.AccountID(domain.NewUUID())
+			destination = domain.AccountID(domain.NewUUID())
+			other       = domain.AccountID(domain.NewUUID())
+			created     = []domain.Transfer{
+				newContractTransfer(origin, destination, 300, 0),
+				newContractTransfer(origin, other, 100, time.Second),
+				newContractTransfer(destination, origin, 300, 2*time.Second),
+				newContractTransfer(other, origin, 50, 3*time.Second),
+			}
+		)
+
+		for _, transfer := range created {
+			if _, err := transfers.Create(ctx, transfer); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		tests := []struct {
+			name     string
+			filter   domain.TransferFilter
+			expected []domain.Transfer
+		}{
+			{
+				name:     "By origin",
+				filter:   domain.TransferFilter{AccountOriginID: origin},
+				expected: created[:2],
+			},
+			{
+				name:     "By destination",
+				filter:   domain.TransferFilter{AccountDestinationID: origin},
+				expected: created[2:],
+			},
+			{
+				name:     "By origin and destination",
+				filter:   domain.TransferFilter{AccountOriginID: origin, AccountDestinationID: destination},
+				expected: created[:1],
+			},
+			{
+				name:     "By amount",
+				filter:   domain.TransferFilter{AccountID: origin, MinAmount: 100, MaxAmount: 300},
+				expected: created[:3],
+			},
+		}
+
+		for _, tt := range tests {
+			found, err := transfers.FindAll(ctx, tt.filter, contractPage)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertContractTransfers(t, tt.name, found, tt.expected)
+		}
+
+		// Transfers of the same amount are sorted by their IDs, in the same direction
+		var largest = append([]domain.Transfer{}, created...)
+		sort.Slice(largest, func(i, j int) bool {
+			if largest[i].Amount() != largest[j].Amount() {
+				return largest[i].Amount() > largest[j].Amount()
+			}
+
+			return largest[i].ID() > largest[j].ID()
+		})
+
+		var byAmount = domain.Sort{Key: domain.SortByAmount, Descending: true}
+
+		first, err := transfers.FindAll(ctx, domain.TransferFilter{AccountID: origin}, domain.PageRequest{Limit: 3, Sort: byAmount})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertContractTransfers(t, "Largest amounts first", first, largest[:3])
+		if len(first) != 3 {
+			return
+		}
+
+		next, err := transfers.FindAll(ctx, domain.TransferFilter{AccountID: origin}, domain.PageRequest{
+			Limit: 3,
+			Sort:  byAmount,
+			After: domain.NewTransferCursor(byAmount, first[2]),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertContractTransfers(t, "Largest amounts after cursor", next, largest[3:])
+	})
+
+	t.Run("Summarizes the transfers", func(t *testing.T) {
+		var (
+			origin      = domain.AccountID(domain.NewUUID())
+			destination = domain.AccountID(domain.NewUUID())
+			created     = []domain.Transfer{
+				newContractTransfer(origin, destination, 300, 0),
+				newContractTransfer(origin, destination, 100, time.Second),
+				newContractTransfer(destination, origin, 50, 2*time.Second),
+			}
+		)
+
+		for _, transfer := range created {
+			if _, err := transfers.Create(ctx, transfer); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		tests := []struct {
+			name     string
+			filter   domain.TransferFilter
+			expected domain.TransferSummary
+		}{
+			{
+				name:     "By origin",
+				filter:   domain.TransferFilter{AccountOriginID: origin},
+				expected: domain.TransferSummary{Count: 2, Total: 400},
+			},
+			{
+				name:     "Within a window",
+				filter:   domain.TransferFilter{AccountID: origin, From: created[1].CreatedAt()},
+				expected: domain.TransferSummary{Count: 2, Total: 150},
+			},
+			{
+				name:     "Without transfers",
+				filter:   domain.TransferFilter{AccountOriginID: origin, To: created[0].CreatedAt()},
+				expected: domain.TransferSummary{},
+			},
+		}
+
+		for _, tt := range tests {
+			result, err := transfers.Summarize(ctx, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+			}
+		}
+	})
+
+	t.Run("Rolls back a failed transaction", func(t *testing.T) {
+		var account = newContractAccount(1000)
+		if _, err := accounts.Create(ctx, account); err != nil {
+			t.Fatal(err)
+		}
+
+		var transfer = newContractTransfer(account.ID(), domain.AccountID(domain.NewUUID()), 100, 0)
+		err := transfers.WithTransaction(ctx, func(ctxTx context.Context) error {
+			if err := accounts.UpdateBalance(ctxTx, account.ID(), 900); err != nil {
+				return err
+			}
+
+			if _, err := transfers.Create(ctxTx, transfer); err != nil {
+				return err
+			}
+
+			return domain.ErrInsufficientBalance
+		})
+		if !errors.Is(err, domain.ErrInsufficientBalance) {
+			t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "WithTransaction", err, domain.ErrInsufficientBalance)
+		}
+
+		balance, err := accounts.FindBalance(ctx, account.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if balance.Balance() != 1000 {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Balance", balance.Balance(), 1000)
+		}
+
+		if _, err = transfers.FindByID(ctx, transfer.ID()); err != domain.ErrTransferNotFound {
+			t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Transfer", err, domain.ErrTransferNotFound)
+		}
+	})
+
+	t.Run("Serializes concurrent withdrawals from one account", func(t *testing.T) {
+		const withdrawals = 20
+
+		var account = newContractAccount(withdrawals * 10)
+		if _, err := accounts.Create(ctx, account); err != nil {
+			t.Fatal(err)
+		}
+
+		var (
+			wg   sync.WaitGroup
+			errs = make(chan error, withdrawals)
+		)
+
+		for i := 0; i < withdrawals; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				errs <- transfers.WithTransaction(ctx, func(ctxTx context.Context) error {
+					origin, err := accounts.FindByID(ctxTx, account.ID())
+					if err != nil {
+						return err
+					}
+
+					if err = accounts.UpdateBalance(ctxTx, origin.ID(), origin.Balance()-10); err != nil {
+						return err
+					}
+
+					_, err = transfers.Create(ctxTx, newContractTransfer(origin.ID(), domain.AccountID(domain.NewUUID()), 10, 0))
+					return err
+				})
+			}()
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		balance, err := accounts.FindBalance(ctx, account.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if balance.Balance() != 0 {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Balance", balance.Balance(), 0)
+		}
+
+		all, err := transfers.FindAll(ctx, domain.TransferFilter{AccountID: account.ID()}, contractPage)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(all) != withdrawals {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Transfers", len(all), withdrawals)
+		}
+	})
+}
+
+func migrateContractDatabase(t *testing.T, db repository.SQL) {
+	t.Helper()
+
+	migrator, err := NewSQLMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = migrator.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newContractAccount creates an account with a new CPF. Timestamps are in UTC, to the microsecond kept by every engine
+func newContractAccount(balance domain.Money) domain.Account {
+	return domain.NewAccount(
+		domain.AccountID(domain.NewUUID()),
+		"Test",
+		domain.NewUUID(),
+		balance,
+		time.Now().UTC().Truncate(time.Microsecond),
+	)
+}
+
+func newContractTransfer(origin, destination domain.AccountID, amount domain.Money, after time.Duration) domain.Transfer {
+	return domain.NewTransfer(
+		domain.TransferID(domain.NewUUID()),
+		origin,
+		destination,
+		amount,
+		domain.TransferStatusCompleted,
+		time.Now().UTC().Truncate(time.Microsecond).Add(after),
+	)
+}
+
+func assertContractAccount(t *testing.T, name string, result, expected domain.Account) {
+	t.Helper()
+
+	if result.ID() != expected.ID() ||
+		result.Name() != expected.Name() ||
+		result.CPF() != expected.CPF() ||
+		result.Balance() != expected.Balance() ||
+		!result.CreatedAt().Equal(expected.CreatedAt()) {
+		t.Errorf("[TestCase '%s'] Result: '%+v' | Expected: '%+v'", name, result, expected)
+	}
+}
+
+func assertContractTransfers(t *testing.T, name string, result, expected []domain.Transfer) {
+	t.Helper()
+
+	var resultIDs, expectedIDs []domain.TransferID
+	for _, transfer := range result {
+		resultIDs = append(resultIDs, transfer.ID())
+	}
+
+	for _, transfer := range expected {
+		expectedIDs = append(expectedIDs, transfer.ID())
+	}
+
+	if !reflect.DeepEqual(resultIDs, expectedIDs) {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", name, resultIDs, expectedIDs)
+	}
+}
@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/repository"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteHandler runs the queries of the SQL repositories on an SQLite file, through a driver written in Go. The
+// queries are rebound by its dialect, and its transactions are immediate, taking the write lock as they begin, so
+// the reads they lock are serialized as in Postgres
+type sqliteHandler struct {
+	db      *sql.DB
+	dialect repository.Dialect
+}
+
+func NewSQLiteHandler(c *config) (*sqliteHandler, error) {
+	var params = url.Values{}
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Set("_time_format", "sqlite")
+	params.Set("_txlock", "immediate")
+
+	db, err := sql.Open(c.driver, "file:"+c.database+"?"+params.Encode())
+	if err != nil {
+		return &sqliteHandler{}, err
+	}
+
+	if err = db.Ping(); err != nil {
+		return &sqliteHandler{}, err
+	}
+
+	return &sqliteHandler{db: db, dialect: repository.SQLiteDialect{}}, nil
+}
+
+func (s sqliteHandler) Dialect() repository.Dialect {
+	return s.dialect
+}
+
+func (s sqliteHandler) BeginTx(ctx context.Context) (repository.Tx, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return sqliteTx{}, err
+	}
+
+	return sqliteTx{tx: tx, dialect: s.dialect}, nil
+}
+
+func (s sqliteHandler) ExecuteContext(ctx context.Context, query string, args ...interface{}) error {
+	query, args = s.dialect.Rebind(query, args)
+
+	_, err := s.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (s sqliteHandler) ExecuteAffectedContext(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	query, args = s.dialect.Rebind(query, args)
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (s sqliteHandler) QueryContext(ctx context.Context, query string, args ...interface{}) (repository.Rows, error) {
+	query, args = s.dialect.Rebind(query, args)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPostgresRows(rows), nil
+}
+
+func (s sqliteHandler) QueryRowContext(ctx context.Context, query string, args ...interface{}) repository.Row {
+	query, args = s.dialect.Rebind(query, args)
+
+	return newPostgresRow(s.db.QueryRowContext(ctx, query, args...))
+}
+
+type sqliteTx struct {
+	tx      *sql.Tx
+	dialect repository.Dialect
+}
+
+func (s sqliteTx) ExecuteContext(ctx context.Context, query string, args ...interface{}) error {
+	query, args = s.dialect.Rebind(query, args)
+
+	_, err := s.tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (s sqliteTx) QueryContext(ctx context.Context, query string, args ...interface{}) (repository.Rows, error) {
+	query, args = s.dialect.Rebind(query, args)
+
+	rows, err := s.tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPostgresRows(rows), nil
+}
+
+func (s sqliteTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) repository.Row {
+	query, args = s.dialect.Rebind(query, args)
+
+	return newPostgresRow(s.tx.QueryRowContext(ctx, query, args...))
+}
+
+func (s sqliteTx) Commit() error {
+	return s.tx.Commit()
+}
+
+func (s sqliteTx) Rollback() error {
+	return s.tx.Rollback()
+}
@@ -0,0 +1,24 @@
+package event
+
+import (
+	"errors"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+)
+
+var (
+	errInvalidEventBusInstance = errors.New("invalid event bus instance")
+)
+
+const (
+	InstanceMemoryBus int = iota
+)
+
+func NewEventBusFactory(instance int, log logger.Logger) (Bus, error) {
+	switch instance {
+	case InstanceMemoryBus:
+		return NewMemoryBus(log, asyncQueueSize), nil
+	default:
+		return nil, errInvalidEventBusInstance
+	}
+}
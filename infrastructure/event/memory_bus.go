@@ -0,0 +1,171 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// AllEvents subscribes a handler to every event name
+const AllEvents domain.EventName = "*"
+
+// asyncQueueSize is how many events an asynchronous subscriber may fall behind before new ones are refused
+const asyncQueueSize = 1024
+
+var (
+	errSubscriberBehind = errors.New("asynchronous subscriber is behind")
+	errBusClosed        = errors.New("event bus is closed")
+)
+
+type (
+	// Handler reacts to an event. The error of a synchronous handler is returned by Publish, the one of an
+	// asynchronous handler is only logged
+	Handler func(context.Context, domain.Event) error
+
+	// Bus is an EventPublisher that subscribers can register to
+	Bus interface {
+		domain.EventPublisher
+		// Subscribe runs the handler inside Publish, in subscription order
+		Subscribe(domain.EventName, Handler)
+		// SubscribeAsync runs the handler in its own goroutine, in publication order
+		SubscribeAsync(domain.EventName, Handler)
+		// Close waits for the asynchronous subscribers to handle the events already published
+		Close()
+	}
+
+	// MemoryBus delivers events within the process. Events are lost if it stops before delivering them
+	MemoryBus struct {
+		log       logger.Logger
+		queueSize int
+
+		mu     sync.RWMutex
+		sync   map[domain.EventName][]Handler
+		async  map[domain.EventName][]chan delivery
+		closed bool
+		wg     sync.WaitGroup
+	}
+
+	delivery struct {
+		ctx   context.Context
+		event domain.Event
+	}
+)
+
+func NewMemoryBus(log logger.Logger, queueSize int) *MemoryBus {
+	return &MemoryBus{
+		log:       log,
+		queueSize: queueSize,
+		sync:      make(map[domain.EventName][]Handler),
+		async:     make(map[domain.EventName][]chan delivery),
+	}
+}
+
+func (b *MemoryBus) Subscribe(name domain.EventName, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sync[name] = append(b.sync[name], h)
+}
+
+func (b *MemoryBus) SubscribeAsync(name domain.EventName, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var queue = make(chan delivery, b.queueSize)
+	b.async[name] = append(b.async[name], queue)
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+
+		for d := range queue {
+			b.handle(d.ctx, h, d.event)
+		}
+	}()
+}
+
+// Publish runs the synchronous subscribers and queues the event to the asynchronous ones. Every subscriber is
+// tried, the errors of those that failed are returned together
+func (b *MemoryBus) Publish(ctx context.Context, events ...domain.Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return errBusClosed
+	}
+
+	// The caller may return right after publishing, its deadline must not cut the asynchronous subscribers short
+	var asyncCtx = context.WithoutCancel(ctx)
+
+	var errs []error
+	for _, e := range events {
+		for _, h := range b.sync[e.Name()] {
+			errs = append(errs, h(ctx, e))
+		}
+		for _, h := range b.sync[AllEvents] {
+			errs = append(errs, h(ctx, e))
+		}
+
+		for _, queue := range b.async[e.Name()] {
+			errs = append(errs, enqueue(queue, delivery{ctx: asyncCtx, event: e}))
+		}
+		for _, queue := range b.async[AllEvents] {
+			errs = append(errs, enqueue(queue, delivery{ctx: asyncCtx, event: e}))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func enqueue(queue chan delivery, d delivery) error {
+	select {
+	case queue <- d:
+		return nil
+	default:
+		return errSubscriberBehind
+	}
+}
+
+func (b *MemoryBus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+
+	b.closed = true
+	for _, queues := range b.async {
+		for _, queue := range queues {
+			close(queue)
+		}
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
+
+func (b *MemoryBus) handle(ctx context.Context, h Handler, e domain.Event) {
+	if err := h(ctx, e); err != nil {
+		b.log.WithFields(logger.Fields{
+			"event_id":   e.ID(),
+			"event_name": e.Name(),
+		}).WithError(err).Errorf("error when handling event")
+	}
+}
+
+// LogHandler logs every event it receives
+func LogHandler(log logger.Logger) Handler {
+	return func(_ context.Context, e domain.Event) error {
+		log.WithFields(logger.Fields{
+			"event_id":      e.ID(),
+			"event_name":    e.Name(),
+			"event_version": e.Version(),
+			"aggregate_id":  e.AggregateID(),
+		}).Infof("event published")
+
+		return nil
+	}
+}
@@ -0,0 +1,255 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+)
+
+func newTestEvent(name domain.EventName, aggregateID string) domain.Event {
+	return domain.NewEvent(domain.NewUUID(), name, domain.EventSchemaVersion, aggregateID, []byte(`{}`), time.Now())
+}
+
+// recorder keeps the aggregate IDs of the events a handler received, in the order it received them
+type recorder struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (r *recorder) handler(_ context.Context, e domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ids = append(r.ids, e.AggregateID())
+	return nil
+}
+
+func (r *recorder) received() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ids []string
+	return append(ids, r.ids...)
+}
+
+func TestMemoryBus_Publish(t *testing.T) {
+	t.Parallel()
+
+	var (
+		events = []domain.Event{
+			newTestEvent(domain.EventAccountCreated, "1"),
+			newTestEvent(domain.EventTransferCompleted, "2"),
+			newTestEvent(domain.EventTransferFailed, "3"),
+		}
+		errFirst  = errors.New("first handler failed")
+		errSecond = errors.New("second handler failed")
+	)
+
+	tests := []struct {
+		name          string
+		subscribe     func(*MemoryBus, *recorder)
+		expected      []string
+		expectedError []error
+	}{
+		{
+			name: "Sync subscriber receives its events",
+			subscribe: func(b *MemoryBus, r *recorder) {
+				b.Subscribe(domain.EventTransferCompleted, r.handler)
+			},
+			expected: []string{"2"},
+		},
+		{
+			name: "Async subscriber receives its events",
+			subscribe: func(b *MemoryBus, r *recorder) {
+				b.SubscribeAsync(domain.EventTransferCompleted, r.handler)
+			},
+			expected: []string{"2"},
+		},
+		{
+			name: "Sync wildcard subscriber receives every event",
+			subscribe: func(b *MemoryBus, r *recorder) {
+				b.Subscribe(AllEvents, r.handler)
+			},
+			expected: []string{"1", "2", "3"},
+		},
+		{
+			name: "Async wildcard subscriber receives every event",
+			subscribe: func(b *MemoryBus, r *recorder) {
+				b.SubscribeAsync(AllEvents, r.handler)
+			},
+			expected: []string{"1", "2", "3"},
+		},
+		{
+			name: "Subscriber of an event not published receives nothing",
+			subscribe: func(b *MemoryBus, r *recorder) {
+				b.Subscribe("account.closed", r.handler)
+			},
+		},
+		{
+			name: "Errors of every failing handler are returned together",
+			subscribe: func(b *MemoryBus, r *recorder) {
+				b.Subscribe(domain.EventAccountCreated, func(context.Context, domain.Event) error { return errFirst })
+				b.Subscribe(AllEvents, func(context.Context, domain.Event) error { return errSecond })
+				b.Subscribe(AllEvents, r.handler)
+			},
+			expected:      []string{"1", "2", "3"},
+			expectedError: []error{errFirst, errSecond},
+		},
+		{
+			name: "Errors of async handlers are not returned",
+			subscribe: func(b *MemoryBus, r *recorder) {
+				b.SubscribeAsync(AllEvents, func(context.Context, domain.Event) error { return errFirst })
+				b.SubscribeAsync(AllEvents, r.handler)
+			},
+			expected: []string{"1", "2", "3"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				bus = NewMemoryBus(log.LoggerMock{}, asyncQueueSize)
+				r   = &recorder{}
+			)
+
+			tt.subscribe(bus, r)
+
+			err := bus.Publish(context.Background(), events...)
+			for _, expected := range tt.expectedError {
+				if !errors.Is(err, expected) {
+					t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, expected)
+				}
+			}
+
+			if len(tt.expectedError) == 0 && err != nil {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, nil)
+			}
+
+			bus.Close()
+
+			if got := r.received(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMemoryBus_AsyncOrder(t *testing.T) {
+	t.Parallel()
+
+	var (
+		bus      = NewMemoryBus(log.LoggerMock{}, asyncQueueSize)
+		slow     = &recorder{}
+		fast     = &recorder{}
+		expected []string
+	)
+
+	bus.SubscribeAsync(domain.EventTransferCompleted, func(ctx context.Context, e domain.Event) error {
+		time.Sleep(time.Millisecond)
+		return slow.handler(ctx, e)
+	})
+	bus.SubscribeAsync(AllEvents, fast.handler)
+
+	for i := 0; i < 50; i++ {
+		var ID = domain.NewUUID()
+		expected = append(expected, ID)
+
+		if err := bus.Publish(context.Background(), newTestEvent(domain.EventTransferCompleted, ID)); err != nil {
+			t.Fatalf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Publish in order", err, nil)
+		}
+	}
+
+	bus.Close()
+
+	for name, r := range map[string]*recorder{"slow": slow, "fast": fast} {
+		if got := r.received(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", name, got, expected)
+		}
+	}
+}
+
+func TestMemoryBus_Close(t *testing.T) {
+	t.Parallel()
+
+	var (
+		bus = NewMemoryBus(log.LoggerMock{}, asyncQueueSize)
+		r   = &recorder{}
+	)
+
+	bus.SubscribeAsync(AllEvents, func(ctx context.Context, e domain.Event) error {
+		time.Sleep(5 * time.Millisecond)
+		return r.handler(ctx, e)
+	})
+
+	// The publisher returning must not cancel the delivery of what it published
+	ctx, cancel := context.WithCancel(context.Background())
+	for i := 0; i < 10; i++ {
+		if err := bus.Publish(ctx, newTestEvent(domain.EventAccountCreated, "1")); err != nil {
+			t.Fatalf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Publish before close", err, nil)
+		}
+	}
+	cancel()
+
+	bus.Close()
+
+	if got := len(r.received()); got != 10 {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Close drains the queues", got, 10)
+	}
+
+	if err := bus.Publish(context.Background(), newTestEvent(domain.EventAccountCreated, "1")); err != errBusClosed {
+		t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Publish after close", err, errBusClosed)
+	}
+
+	// Closing twice is a no-op
+	bus.Close()
+}
+
+func TestMemoryBus_SubscriberBehind(t *testing.T) {
+	t.Parallel()
+
+	var (
+		bus     = NewMemoryBus(log.LoggerMock{}, 1)
+		started = make(chan struct{})
+		release = make(chan struct{})
+		r       = &recorder{}
+		once    sync.Once
+	)
+
+	bus.SubscribeAsync(AllEvents, func(ctx context.Context, e domain.Event) error {
+		once.Do(func() { close(started) })
+		<-release
+		return r.handler(ctx, e)
+	})
+	bus.Subscribe(AllEvents, func(context.Context, domain.Event) error { return nil })
+
+	// The first event is being handled and the second fills the queue
+	if err := bus.Publish(context.Background(), newTestEvent(domain.EventAccountCreated, "1")); err != nil {
+		t.Fatalf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Handled event", err, nil)
+	}
+	<-started
+
+	if err := bus.Publish(context.Background(), newTestEvent(domain.EventAccountCreated, "2")); err != nil {
+		t.Fatalf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Queued event", err, nil)
+	}
+
+	err := bus.Publish(context.Background(), newTestEvent(domain.EventAccountCreated, "3"))
+	if !errors.Is(err, errSubscriberBehind) {
+		t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Queue full", err, errSubscriberBehind)
+	}
+
+	close(release)
+	bus.Close()
+
+	if got := r.received(); !reflect.DeepEqual(got, []string{"1", "2"}) {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Queue full", got, []string{"1", "2"})
+	}
+}
@@ -2,21 +2,40 @@ package infrastructure
 
 import (
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gsabadini/go-clean-architecture/adapter/auth"
 	"github.com/gsabadini/go-clean-architecture/adapter/logger"
 	"github.com/gsabadini/go-clean-architecture/adapter/repository"
 	"github.com/gsabadini/go-clean-architecture/adapter/validator"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	infraauth "github.com/gsabadini/go-clean-architecture/infrastructure/auth"
 	"github.com/gsabadini/go-clean-architecture/infrastructure/database"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/event"
 	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/notification"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/risk"
 	"github.com/gsabadini/go-clean-architecture/infrastructure/router"
 	"github.com/gsabadini/go-clean-architecture/infrastructure/validation"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/webhook"
+	"github.com/gsabadini/go-clean-architecture/usecase"
 )
 
 type config struct {
 	appName       string
 	logger        logger.Logger
 	validator     validator.Validator
+	verifier      auth.TokenVerifier
+	riskEngine    domain.RiskEngine
+	approval      usecase.TransferApprovalConfig
+	tariff        usecase.TariffConfig
+	eventBus      event.Bus
+	webhookSender domain.WebhookSender
+	notifier      domain.Notifier
+	accountRepo   domain.AccountRepository
+	transferRepo  domain.TransferRepository
+	stores        database.Stores
 	dbSQL         repository.SQL
 	dbNoSQL       repository.NoSQL
 	ctxTimeout    time.Duration
@@ -85,13 +104,197 @@ func (c *config) Validator(instance int) *config {
 	return c
 }
 
+func (c *config) TokenVerifier(instance int) *config {
+	v, err := infraauth.NewTokenVerifierFactory(instance)
+	if err != nil {
+		c.logger.Fatalln(err)
+	}
+
+	c.logger.Infof("Successfully configured token verifier")
+
+	c.verifier = v
+	return c
+}
+
+func (c *config) RiskEngine(instance int) *config {
+	r, err := risk.NewRiskEngineFactory(instance)
+	if err != nil {
+		c.logger.Fatalln(err)
+	}
+
+	c.logger.Infof("Successfully configured risk engine")
+
+	c.riskEngine = r
+	return c
+}
+
+// EventBus sets where domain events go once committed. Every event is logged
+func (c *config) EventBus(instance int) *config {
+	b, err := event.NewEventBusFactory(instance, c.logger)
+	if err != nil {
+		c.logger.Fatalln(err)
+	}
+
+	b.SubscribeAsync(event.AllEvents, event.LogHandler(c.logger))
+
+	c.logger.Infof("Successfully configured event bus")
+
+	c.eventBus = b
+	return c
+}
+
+// WebhookSender sets how webhook deliveries are posted to partners
+func (c *config) WebhookSender(instance int) *config {
+	s, err := webhook.NewWebhookSenderFactory(instance)
+	if err != nil {
+		c.logger.Fatalln(err)
+	}
+
+	c.logger.Infof("Successfully configured webhook sender")
+
+	c.webhookSender = s
+	return c
+}
+
+// Notifier sets how customers are notified of the transfers they receive and of low balances
+func (c *config) Notifier(instance int) *config {
+	n, err := notification.NewNotifierFactory(instance, c.logger)
+	if err != nil {
+		c.logger.Fatalln(err)
+	}
+
+	c.logger.Infof("Successfully configured notifier")
+
+	c.notifier = n
+	return c
+}
+
+// TransferApproval sets the amount, in cents, above which transfers wait for a second user and how long they wait.
+// Empty values disable the approval and the expiration
+func (c *config) TransferApproval(threshold, timeout string) *config {
+	if threshold != "" {
+		t, err := strconv.ParseInt(threshold, 10, 64)
+		if err != nil {
+			c.logger.Fatalln(err)
+		}
+
+		c.approval.Threshold = domain.Money(t)
+	}
+
+	if timeout != "" {
+		t, err := time.ParseDuration(timeout)
+		if err != nil {
+			c.logger.Fatalln(err)
+		}
+
+		c.approval.Timeout = t
+	}
+
+	return c
+}
+
+// Tariff sets how amounts are rounded, the fee charged on transfers, in basis points, with the accounts receiving it
+// as a comma separated list of id:share, and the annual interest rate of the balances, in basis points. Empty values
+// round half even, charge no fee and accrue no interest
+func (c *config) Tariff(rounding, feeRate, feeAccounts, interestRate string) *config {
+	mode, err := domain.ParseRoundingMode(rounding)
+	if err != nil {
+		c.logger.Fatalln(err)
+	}
+
+	c.tariff.Rounding = mode
+
+	if feeRate != "" {
+		if c.tariff.FeeRate, err = strconv.ParseInt(feeRate, 10, 64); err != nil || c.tariff.FeeRate < 0 {
+			c.logger.Fatalln("invalid transfer fee rate " + feeRate)
+		}
+	}
+
+	for _, item := range strings.Split(feeAccounts, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		var account = usecase.FeeAccount{Share: 1}
+		if i := strings.IndexByte(item, ':'); i >= 0 {
+			if account.Share, err = strconv.ParseInt(item[i+1:], 10, 64); err != nil || account.Share <= 0 {
+				c.logger.Fatalln("invalid share of fee account " + item)
+			}
+			item = item[:i]
+		}
+
+		account.ID = domain.AccountID(item)
+		c.tariff.FeeAccounts = append(c.tariff.FeeAccounts, account)
+	}
+
+	if c.tariff.FeeRate > 0 && len(c.tariff.FeeAccounts) == 0 {
+		c.logger.Fatalln("transfer fee rate set without fee accounts")
+	}
+
+	if interestRate != "" {
+		if c.tariff.InterestRate, err = strconv.ParseInt(interestRate, 10, 64); err != nil || c.tariff.InterestRate < 0 {
+			c.logger.Fatalln("invalid interest rate " + interestRate)
+		}
+	}
+
+	return c
+}
+
+// AccountRepository sets how accounts are persisted, on the databases configured before it
+func (c *config) AccountRepository(instance int) *config {
+	r, err := database.NewAccountRepositoryFactory(instance, c.dbSQL, c.dbNoSQL)
+	if err != nil {
+		c.logger.Fatalln(err)
+	}
+
+	c.logger.Infof("Successfully configured account repository")
+
+	c.accountRepo = r
+	return c
+}
+
+// TransferRepository sets how transfers are persisted, on the databases configured before it
+func (c *config) TransferRepository(instance int) *config {
+	r, err := database.NewTransferRepositoryFactory(instance, c.dbSQL, c.dbNoSQL)
+	if err != nil {
+		c.logger.Fatalln(err)
+	}
+
+	c.logger.Infof("Successfully configured transfer repository")
+
+	c.transferRepo = r
+	return c
+}
+
+// Stores sets where the other stores are persisted, on the databases configured before it. They must be on the
+// backend of the accounts and transfers, as some are written in their transactions
+func (c *config) Stores(instance int) *config {
+	s, err := database.NewStoresFactory(instance, c.dbSQL, c.dbNoSQL)
+	if err != nil {
+		c.logger.Fatalln(err)
+	}
+
+	c.logger.Infof("Successfully configured stores")
+
+	c.stores = s
+	return c
+}
+
 func (c *config) WebServer(instance int) *config {
 	s, err := router.NewWebServerFactory(
 		instance,
 		c.logger,
-		c.dbSQL,
-		c.dbNoSQL,
+		c.accountRepo,
+		c.transferRepo,
+		c.stores,
 		c.validator,
+		c.verifier,
+		c.riskEngine,
+		c.approval,
+		c.tariff,
+		c.eventBus,
+		c.webhookSender,
+		c.notifier,
 		c.webServerPort,
 		c.ctxTimeout,
 	)
@@ -118,4 +321,5 @@ func (c *config) WebServerPort(port string) *config {
 
 func (c *config) Start() {
 	c.webServer.Listen()
+	c.eventBus.Close()
 }
@@ -0,0 +1,119 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/presenter"
+	"github.com/gsabadini/go-clean-architecture/adapter/transferfile"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+)
+
+// transferImportPollInterval is how often the CLI looks at the job it is waiting for
+const transferImportPollInterval = time.Second
+
+var errImportWithoutDatabase = errors.New("the import command needs the database of the server")
+
+// ImportTransfers queues a csv or pain.001 file for the import job of the server, as an admin, and prints the job.
+// With -wait it follows the job until every line ran, with -report it also writes the lines that failed to a csv file
+func (c *config) ImportTransfers(args []string) {
+	var (
+		flags  = flag.NewFlagSet("import", flag.ExitOnError)
+		file   = flags.String("file", "", "path of the csv or pain.001 file to import")
+		format = flags.String("format", "", "csv or pain001, from the extension of the file when empty")
+		wait   = flags.Bool("wait", false, "wait for the transfers of the file to be created")
+		report = flags.String("report", "", "path to write the error report to, once the transfers are created")
+	)
+
+	_ = flags.Parse(args)
+
+	if *file == "" {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	// The job is run by the server, found through the database they share
+	if c.dbSQL == nil && c.dbNoSQL == nil {
+		c.logger.Fatalln(errImportWithoutDatabase)
+	}
+
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(*file)) {
+		case ".csv":
+			*format = domain.TransferImportCSV.String()
+		case ".xml":
+			*format = domain.TransferImportPain001.String()
+		}
+	}
+
+	content, err := os.ReadFile(*file)
+	if err != nil {
+		c.logger.Fatalln(err)
+	}
+
+	lines, err := transferfile.Read(domain.TransferImportFormat(*format), content, c.validator)
+	if err != nil {
+		c.logger.Fatalln(err)
+	}
+
+	var (
+		repo = c.stores.TransferImports
+		ctx  = domain.ContextWithPrincipal(
+			context.Background(),
+			domain.NewPrincipal("cli", domain.RoleAdmin, domain.ScopeTransfersRead, domain.ScopeTransfersWrite),
+		)
+		importUC = usecase.NewAuditedImportTransfersInteractor(
+			usecase.NewImportTransfersInteractor(repo, presenter.NewTransferImportPresenter(), c.ctxTimeout),
+			c.stores.Audit,
+			logging.NewAuditAlerter(c.logger),
+			c.ctxTimeout,
+		)
+		findUC = usecase.NewFindTransferImportInteractor(repo, presenter.NewTransferImportPresenter(), c.ctxTimeout)
+	)
+
+	output, err := importUC.Execute(ctx, usecase.ImportTransfersInput{
+		FileName: filepath.Base(*file),
+		Format:   *format,
+		Content:  content,
+		Lines:    lines,
+	})
+	if err != nil {
+		c.logger.Fatalln(err)
+	}
+
+	c.logger.Infof("Transfer import " + output.ID + " queued")
+
+	for (*wait || *report != "") && output.Status != domain.TransferImportCompleted.String() {
+		time.Sleep(transferImportPollInterval)
+
+		if output, err = findUC.Execute(ctx, domain.TransferImportID(output.ID)); err != nil {
+			c.logger.Fatalln(err)
+		}
+	}
+
+	var enc = json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(output); err != nil {
+		c.logger.Fatalln(err)
+	}
+
+	if *report != "" {
+		f, err := os.Create(*report)
+		if err != nil {
+			c.logger.Fatalln(err)
+		}
+		defer f.Close()
+
+		if err := presenter.WriteTransferImportErrorReport(f, output); err != nil {
+			c.logger.Fatalln(err)
+		}
+	}
+}
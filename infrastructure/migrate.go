@@ -0,0 +1,147 @@
+package infrastructure
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/infrastructure/database"
+)
+
+// migrateTimeout bounds a run of the migrations, waiting for the lock included
+const migrateTimeout = 30 * time.Minute
+
+// MigrateOnStart applies the pending migrations of the databases configured before it, when enabled. Instances
+// starting together wait for each other, so every migration is applied once
+func (c *config) MigrateOnStart(enabled string) *config {
+	if enabled == "" {
+		return c
+	}
+
+	on, err := strconv.ParseBool(enabled)
+	if err != nil {
+		c.logger.Fatalln(err)
+	}
+
+	if !on {
+		return c
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	for _, migrator := range c.migrators("all") {
+		c.migrateUp(ctx, migrator)
+	}
+
+	c.logger.Infof("Successfully migrated databases")
+	return c
+}
+
+// Migrate runs the migrate command: up applies the pending migrations, down reverts the last -steps of them and
+// status lists them. -db chooses the database, sql, nosql or both
+func (c *config) Migrate(args []string) {
+	var (
+		flags = flag.NewFlagSet("migrate", flag.ExitOnError)
+		db    = flags.String("db", "all", "database to migrate: sql, nosql or all")
+		steps = flags.Int("steps", 1, "number of migrations reverted by down")
+	)
+
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: migrate up|down|status [-db sql|nosql|all] [-steps n]")
+		flags.PrintDefaults()
+	}
+
+	if len(args) == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	var command = args[0]
+	_ = flags.Parse(args[1:])
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	for _, migrator := range c.migrators(*db) {
+		switch command {
+		case "up":
+			c.migrateUp(ctx, migrator)
+		case "down":
+			reverted, err := migrator.Down(ctx, *steps)
+			for _, migration := range reverted {
+				c.logger.Infof("Reverted migration %d %s", migration.Version, migration.Name)
+			}
+
+			if err != nil {
+				c.logger.Fatalln(err)
+			}
+		case "status":
+			statuses, err := migrator.Status(ctx)
+			if err != nil {
+				c.logger.Fatalln(err)
+			}
+
+			printMigrationStatus(statuses)
+		default:
+			flags.Usage()
+			os.Exit(2)
+		}
+	}
+}
+
+func (c *config) migrateUp(ctx context.Context, migrator database.Migrator) {
+	applied, err := migrator.Up(ctx)
+	for _, migration := range applied {
+		c.logger.Infof("Applied migration %d %s", migration.Version, migration.Name)
+	}
+
+	if err != nil {
+		c.logger.Fatalln(err)
+	}
+}
+
+// migrators builds the migrators of the chosen databases among those configured
+func (c *config) migrators(db string) []database.Migrator {
+	var migrators []database.Migrator
+
+	if c.dbSQL != nil && (db == "all" || db == "sql") {
+		m, err := database.NewSQLMigrator(c.dbSQL)
+		if err != nil {
+			c.logger.Fatalln(err)
+		}
+
+		migrators = append(migrators, m)
+	}
+
+	if c.dbNoSQL != nil && (db == "all" || db == "nosql") {
+		m, err := database.NewNoSQLMigrator(c.dbNoSQL)
+		if err != nil {
+			c.logger.Fatalln(err)
+		}
+
+		migrators = append(migrators, m)
+	}
+
+	return migrators
+}
+
+func printMigrationStatus(statuses []database.MigrationStatus) {
+	var w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+
+	for _, status := range statuses {
+		var appliedAt = "pending"
+		if status.Applied() {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+
+	_ = w.Flush()
+}
@@ -0,0 +1,34 @@
+package notification
+
+import "os"
+
+// defaultFile is where the file notifier writes when NOTIFIER_FILE is not set
+const defaultFile = "notifications.log"
+
+type config struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+	file     string
+}
+
+func newConfigSMTP() *config {
+	return &config{
+		host:     os.Getenv("SMTP_HOST"),
+		port:     os.Getenv("SMTP_PORT"),
+		username: os.Getenv("SMTP_USERNAME"),
+		password: os.Getenv("SMTP_PASSWORD"),
+		from:     os.Getenv("SMTP_FROM"),
+	}
+}
+
+func newConfigFile() *config {
+	var c = &config{file: os.Getenv("NOTIFIER_FILE")}
+	if c.file == "" {
+		c.file = defaultFile
+	}
+
+	return c
+}
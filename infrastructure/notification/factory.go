@@ -0,0 +1,31 @@
+package notification
+
+import (
+	"errors"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+var (
+	errInvalidNotifierInstance = errors.New("invalid notifier instance")
+)
+
+const (
+	InstanceSMTP int = iota
+	InstanceFile
+	InstanceLog
+)
+
+func NewNotifierFactory(instance int, log logger.Logger) (domain.Notifier, error) {
+	switch instance {
+	case InstanceSMTP:
+		return NewSMTP(newConfigSMTP())
+	case InstanceFile:
+		return NewFile(newConfigFile())
+	case InstanceLog:
+		return NewLog(log), nil
+	default:
+		return nil, errInvalidNotifierInstance
+	}
+}
@@ -0,0 +1,53 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// File appends the notifications to a file instead of sending them, for development
+type File struct {
+	mu   *sync.Mutex
+	path string
+}
+
+// NewFile checks the file can be written, creating it if needed
+func NewFile(c *config) (File, error) {
+	f, err := os.OpenFile(c.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return File{}, err
+	}
+
+	return File{mu: &sync.Mutex{}, path: c.file}, f.Close()
+}
+
+func (f File) Notify(_ context.Context, notification domain.Notification) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(
+		file,
+		"Date: %s\nTo: %s\nSubject: %s\nKey: %s\n\n%s\n---\n",
+		time.Now().Format(time.RFC1123Z),
+		notification.Recipient(),
+		notification.Subject(),
+		notification.Key(),
+		notification.Body(),
+	)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
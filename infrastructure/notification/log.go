@@ -0,0 +1,29 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// Log writes the notifications to the logger instead of sending them, for development
+type Log struct {
+	log logger.Logger
+}
+
+func NewLog(log logger.Logger) Log {
+	return Log{log: log}
+}
+
+func (l Log) Notify(_ context.Context, notification domain.Notification) error {
+	l.log.WithFields(logger.Fields{
+		"key":       notification.Key(),
+		"kind":      notification.Kind().String(),
+		"recipient": notification.Recipient(),
+		"subject":   notification.Subject(),
+		"body":      notification.Body(),
+	}).Infof("Notification")
+
+	return nil
+}
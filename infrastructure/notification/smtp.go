@@ -0,0 +1,160 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// defaultSMTPPort is used when SMTP_PORT is not set
+const defaultSMTPPort = "25"
+
+var (
+	errSMTPHostRequired = errors.New("SMTP_HOST is required")
+	errSMTPFromInvalid  = errors.New("SMTP_FROM must be an email address")
+)
+
+// SMTP emails the notifications. It upgrades to TLS when the server offers STARTTLS and authenticates when a
+// username is set, so it works with a relay as well as with a local fake server
+type SMTP struct {
+	addr     string
+	host     string
+	from     *mail.Address
+	username string
+	password string
+}
+
+func NewSMTP(c *config) (SMTP, error) {
+	if c.host == "" {
+		return SMTP{}, errSMTPHostRequired
+	}
+
+	from, err := mail.ParseAddress(c.from)
+	if err != nil {
+		return SMTP{}, errSMTPFromInvalid
+	}
+
+	var port = c.port
+	if port == "" {
+		port = defaultSMTPPort
+	}
+
+	return SMTP{
+		addr:     net.JoinHostPort(c.host, port),
+		host:     c.host,
+		from:     from,
+		username: c.username,
+		password: c.password,
+	}, nil
+}
+
+// Notify sends the notification as a plain text email, giving up when ctx is done
+func (s SMTP) Notify(ctx context.Context, notification domain.Notification) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+
+	if s.username != "" {
+		if err = client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(s.from.Address); err != nil {
+		return err
+	}
+
+	if err = client.Rcpt(notification.Recipient()); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	message, err := s.message(notification)
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write(message); err != nil {
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// message writes the email. The Message-ID is derived from the notification key, so a copy resent after the server
+// took it but the answer was lost carries the same one, which mail clients use to drop duplicates
+func (s SMTP) message(notification domain.Notification) ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		id  = sha256.Sum256([]byte(notification.Key()))
+		to  = mail.Address{Address: notification.Recipient()}
+	)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", s.from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Subject()))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n", hex.EncodeToString(id[:16]), s.domain())
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(&buf, "Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	var qp = quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(notification.Body())); err != nil {
+		return nil, err
+	}
+
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// domain is the part of the sender address after the @, used to scope the Message-ID
+func (s SMTP) domain() string {
+	var address = s.from.Address
+	return address[strings.LastIndexByte(address, '@')+1:]
+}
@@ -0,0 +1,180 @@
+package notification
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// fakeSMTPServer accepts one message on a local port, answering the commands a client sends without TLS or auth
+type fakeSMTPServer struct {
+	listener net.Listener
+	rcpt     chan string
+	data     chan string
+}
+
+func newFakeSMTPServer(t *testing.T, rejectRcpt bool) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var s = &fakeSMTPServer{listener: listener, rcpt: make(chan string, 1), data: make(chan string, 1)}
+	go s.serve(rejectRcpt)
+
+	return s
+}
+
+func (s *fakeSMTPServer) serve(rejectRcpt bool) {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var (
+		r     = bufio.NewReader(conn)
+		reply = func(line string) { io.WriteString(conn, line+"\r\n") }
+	)
+
+	reply("220 localhost fake SMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		var cmd = strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM"):
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO"):
+			s.rcpt <- strings.TrimSpace(line)
+			if rejectRcpt {
+				reply("550 mailbox unavailable")
+				continue
+			}
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 end with <CRLF>.<CRLF>")
+
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+
+			s.data <- data.String()
+			reply("250 OK queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTP_Notify(t *testing.T) {
+	t.Parallel()
+
+	var notification = domain.NewNotification(
+		"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10:3c096a40-ccba-4b58-93ed-57379ab04681:transfer_received",
+		"3c096a40-ccba-4b58-93ed-57379ab04681",
+		"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10",
+		domain.NotificationTransferReceived,
+		"customer@example.com",
+		"Você recebeu R$ 1.234,56",
+		"Olá, Test.\n\nVocê recebeu uma transferência de R$ 1.234,56.\n",
+		domain.NotificationPending,
+		time.Now(),
+		time.Time{},
+	)
+
+	tests := []struct {
+		name          string
+		rejectRcpt    bool
+		expectedError bool
+	}{
+		{
+			name: "Server accepts the message",
+		},
+		{
+			name:          "Server rejects the recipient",
+			rejectRcpt:    true,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var server = newFakeSMTPServer(t, tt.rejectRcpt)
+			defer server.listener.Close()
+
+			host, port, _ := net.SplitHostPort(server.listener.Addr().String())
+			sender, err := NewSMTP(&config{host: host, port: port, from: "Go Bank <no-reply@bank.example.com>"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			err = sender.Notify(ctx, notification)
+			if (err != nil) != tt.expectedError {
+				t.Fatalf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+			}
+
+			if rcpt := <-server.rcpt; rcpt != "RCPT TO:<customer@example.com>" {
+				t.Errorf("[TestCase '%s'] Recipient: '%v'", tt.name, rcpt)
+			}
+
+			if tt.expectedError {
+				return
+			}
+
+			msg, err := mail.ReadMessage(strings.NewReader(<-server.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+			if subject != notification.Subject() {
+				t.Errorf("[TestCase '%s'] Subject: '%v' | Expected: '%v'", tt.name, subject, notification.Subject())
+			}
+
+			if msg.Header.Get("From") != `"Go Bank" <no-reply@bank.example.com>` {
+				t.Errorf("[TestCase '%s'] From: '%v'", tt.name, msg.Header.Get("From"))
+			}
+
+			if !strings.HasSuffix(msg.Header.Get("Message-ID"), "@bank.example.com>") {
+				t.Errorf("[TestCase '%s'] Message-ID: '%v'", tt.name, msg.Header.Get("Message-ID"))
+			}
+
+			body, _ := io.ReadAll(quotedprintable.NewReader(msg.Body))
+			if strings.ReplaceAll(string(body), "\r\n", "\n") != notification.Body() {
+				t.Errorf("[TestCase '%s'] Body: '%q' | Expected: '%q'", tt.name, body, notification.Body())
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package risk
+
+import "os"
+
+type config struct {
+	rulesFile string
+}
+
+func newConfigRules() *config {
+	return &config{
+		rulesFile: os.Getenv("RISK_RULES_FILE"),
+	}
+}
@@ -0,0 +1,24 @@
+package risk
+
+import (
+	"errors"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+var (
+	errInvalidRiskEngineInstance = errors.New("invalid risk engine instance")
+)
+
+const (
+	InstanceRulesFile int = iota
+)
+
+func NewRiskEngineFactory(instance int) (domain.RiskEngine, error) {
+	switch instance {
+	case InstanceRulesFile:
+		return NewRulesFile(newConfigRules())
+	default:
+		return nil, errInvalidRiskEngineInstance
+	}
+}
@@ -0,0 +1,255 @@
+package risk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ruleNewDestinationVelocity = "new_destination_velocity"
+	ruleAmountAboveHistory     = "amount_above_history"
+	ruleNewAccountLargeAmount  = "new_account_large_amount"
+)
+
+type (
+	rulesFile struct {
+		Rules []ruleConfig `json:"rules"`
+	}
+
+	// ruleConfig is a rule of the rules file. Amounts are in cents and durations use the time.ParseDuration format
+	ruleConfig struct {
+		Name          string              `json:"name"`
+		Type          string              `json:"type"`
+		Action        domain.RiskDecision `json:"action"`
+		Window        string              `json:"window"`
+		MaxCount      int                 `json:"max_count"`
+		Multiplier    float64             `json:"multiplier"`
+		MinHistory    int                 `json:"min_history"`
+		MaxAccountAge string              `json:"max_account_age"`
+		MinAmount     int64               `json:"min_amount"`
+	}
+
+	rule interface {
+		evaluate(context.Context, domain.RiskSubject) (bool, string, error)
+	}
+
+	namedRule struct {
+		name   string
+		action domain.RiskDecision
+		rule   rule
+	}
+
+	rulesEngine struct {
+		rules []namedRule
+	}
+)
+
+// NewRulesFile creates a risk engine with the rules of the JSON file in RISK_RULES_FILE
+func NewRulesFile(c *config) (domain.RiskEngine, error) {
+	if c.rulesFile == "" {
+		return nil, errors.New("risk rules file not defined")
+	}
+
+	raw, err := os.ReadFile(c.rulesFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading risk rules file")
+	}
+
+	var file rulesFile
+	if err = json.Unmarshal(raw, &file); err != nil {
+		return nil, errors.Wrap(err, "error decoding risk rules file")
+	}
+
+	var engine rulesEngine
+	for _, cfg := range file.Rules {
+		r, err := newRule(cfg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error loading risk rule %q", cfg.Name)
+		}
+
+		engine.rules = append(engine.rules, namedRule{
+			name:   cfg.Name,
+			action: cfg.Action,
+			rule:   r,
+		})
+	}
+
+	return engine, nil
+}
+
+func newRule(cfg ruleConfig) (rule, error) {
+	if cfg.Action != domain.RiskReview && cfg.Action != domain.RiskDeny {
+		return nil, errors.Errorf("action must be %q or %q", domain.RiskReview, domain.RiskDeny)
+	}
+
+	switch cfg.Type {
+	case ruleNewDestinationVelocity:
+		window, err := time.ParseDuration(cfg.Window)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid window")
+		}
+
+		return newDestinationVelocity{window: window, maxCount: cfg.MaxCount}, nil
+	case ruleAmountAboveHistory:
+		if cfg.Multiplier <= 0 {
+			return nil, errors.New("multiplier must be positive")
+		}
+
+		return amountAboveHistory{multiplier: cfg.Multiplier, minHistory: cfg.MinHistory}, nil
+	case ruleNewAccountLargeAmount:
+		maxAge, err := time.ParseDuration(cfg.MaxAccountAge)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid max_account_age")
+		}
+
+		return newAccountLargeAmount{maxAge: maxAge, minAmount: domain.Money(cfg.MinAmount)}, nil
+	default:
+		return nil, errors.Errorf("unknown rule type %q", cfg.Type)
+	}
+}
+
+// Evaluate returns the result of every rule, allowing the transfer when the rule does not match
+func (e rulesEngine) Evaluate(ctx context.Context, subject domain.RiskSubject) ([]domain.RuleResult, error) {
+	var results = make([]domain.RuleResult, 0, len(e.rules))
+
+	for _, r := range e.rules {
+		matched, reason, err := r.rule.evaluate(ctx, subject)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error evaluating risk rule %q", r.name)
+		}
+
+		if matched {
+			results = append(results, domain.NewRuleResult(r.name, r.action, reason))
+			continue
+		}
+
+		results = append(results, domain.NewRuleResult(r.name, domain.RiskAllow, ""))
+	}
+
+	return results, nil
+}
+
+// newDestinationVelocity matches when the origin pays more than maxCount destinations it never paid before
+// within the window
+type newDestinationVelocity struct {
+	window   time.Duration
+	maxCount int
+}
+
+// evaluate reads the transfers of the window, then looks up whether each of their destinations was paid before it,
+// stopping as soon as the count is over
+func (r newDestinationVelocity) evaluate(ctx context.Context, s domain.RiskSubject) (bool, string, error) {
+	var (
+		since        = s.Transfer.CreatedAt().Add(-r.window)
+		seen         = make(map[domain.AccountID]bool)
+		destinations []domain.AccountID
+	)
+
+	if err := s.History.Stream(ctx, domain.TransferFilter{
+		AccountOriginID: s.Origin.ID(),
+		Status:          domain.TransferStatusCompleted,
+		From:            since,
+	}, func(t domain.Transfer) error {
+		if !seen[t.AccountDestinationID()] {
+			seen[t.AccountDestinationID()] = true
+			destinations = append(destinations, t.AccountDestinationID())
+		}
+
+		return nil
+	}); err != nil {
+		return false, "", err
+	}
+
+	if !seen[s.Transfer.AccountDestinationID()] {
+		destinations = append(destinations, s.Transfer.AccountDestinationID())
+	}
+
+	var count int
+	for _, destination := range destinations {
+		summary, err := s.History.Summarize(ctx, domain.TransferFilter{
+			AccountOriginID:      s.Origin.ID(),
+			AccountDestinationID: destination,
+			Status:               domain.TransferStatusCompleted,
+			To:                   since,
+		})
+		if err != nil {
+			return false, "", err
+		}
+
+		if summary.Count > 0 {
+			continue
+		}
+
+		if count++; count > r.maxCount {
+			return true, fmt.Sprintf("more than %d new destinations within %s", r.maxCount, r.window), nil
+		}
+	}
+
+	return false, "", nil
+}
+
+// amountAboveHistory matches when the amount is more than multiplier times the average outgoing transfer
+type amountAboveHistory struct {
+	multiplier float64
+	minHistory int
+}
+
+func (r amountAboveHistory) evaluate(ctx context.Context, s domain.RiskSubject) (bool, string, error) {
+	summary, err := s.History.Summarize(ctx, domain.TransferFilter{
+		AccountOriginID: s.Origin.ID(),
+		Status:          domain.TransferStatusCompleted,
+	})
+	if err != nil {
+		return false, "", err
+	}
+
+	if summary.Count == 0 || summary.Count < int64(r.minHistory) {
+		return false, "", nil
+	}
+
+	var average = float64(summary.Total.Int64()) / float64(summary.Count)
+	if float64(s.Transfer.Amount().Int64()) > average*r.multiplier {
+		return true, fmt.Sprintf("amount %d above %.1f times the average of %.0f", s.Transfer.Amount(), r.multiplier, average), nil
+	}
+
+	return false, "", nil
+}
+
+// newAccountLargeAmount matches when a destination younger than maxAge receives minAmount or more since it was opened
+type newAccountLargeAmount struct {
+	maxAge    time.Duration
+	minAmount domain.Money
+}
+
+func (r newAccountLargeAmount) evaluate(ctx context.Context, s domain.RiskSubject) (bool, string, error) {
+	if s.Transfer.CreatedAt().Sub(s.Destination.CreatedAt()) > r.maxAge {
+		return false, "", nil
+	}
+
+	summary, err := s.History.Summarize(ctx, domain.TransferFilter{
+		AccountDestinationID: s.Destination.ID(),
+		Status:               domain.TransferStatusCompleted,
+		From:                 s.Destination.CreatedAt(),
+	})
+	if err != nil {
+		return false, "", err
+	}
+
+	received, err := s.Transfer.Amount().Add(summary.Total)
+	if err != nil {
+		return true, fmt.Sprintf("account opened within %s received more than %d", r.maxAge, s.Transfer.Amount()), nil
+	}
+
+	if received >= r.minAmount {
+		return true, fmt.Sprintf("account opened within %s received %d", r.maxAge, received), nil
+	}
+
+	return false, "", nil
+}
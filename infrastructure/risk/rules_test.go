@@ -0,0 +1,284 @@
+package risk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/repository"
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+var (
+	riskTestNow         = time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	riskTestOrigin      = domain.NewAccount("origin", "Origin", "07094564964", 100000, riskTestNow.AddDate(-1, 0, 0))
+	riskTestDestination = domain.NewAccount("destination", "Destination", "11122233344", 0, riskTestNow.AddDate(-1, 0, 0))
+)
+
+func TestNewDestinationVelocity_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rule  = newDestinationVelocity{window: time.Hour, maxCount: 2}
+		since = riskTestNow.Add(-time.Hour)
+	)
+
+	tests := []struct {
+		name     string
+		history  []domain.Transfer
+		transfer domain.Transfer
+		expected bool
+	}{
+		{
+			name:     "First destination",
+			transfer: newRiskTestTransfer("origin", "destination", 100, riskTestNow),
+			expected: false,
+		},
+		{
+			name: "New destinations up to the limit",
+			history: []domain.Transfer{
+				newRiskTestTransfer("origin", "a", 100, since.Add(time.Minute)),
+			},
+			transfer: newRiskTestTransfer("origin", "destination", 100, riskTestNow),
+			expected: false,
+		},
+		{
+			name: "New destinations over the limit",
+			history: []domain.Transfer{
+				newRiskTestTransfer("origin", "a", 100, since.Add(time.Minute)),
+				newRiskTestTransfer("origin", "b", 100, since.Add(2*time.Minute)),
+			},
+			transfer: newRiskTestTransfer("origin", "destination", 100, riskTestNow),
+			expected: true,
+		},
+		{
+			name: "Destination paid at the start of the window",
+			history: []domain.Transfer{
+				newRiskTestTransfer("origin", "a", 100, since),
+				newRiskTestTransfer("origin", "b", 100, since.Add(time.Minute)),
+			},
+			transfer: newRiskTestTransfer("origin", "destination", 100, riskTestNow),
+			expected: true,
+		},
+		{
+			name: "Destination paid before the window",
+			history: []domain.Transfer{
+				newRiskTestTransfer("origin", "a", 100, since.Add(-time.Second)),
+				newRiskTestTransfer("origin", "b", 100, since.Add(time.Minute)),
+			},
+			transfer: newRiskTestTransfer("origin", "destination", 100, riskTestNow),
+			expected: false,
+		},
+		{
+			name: "Destinations paid again within the window",
+			history: []domain.Transfer{
+				newRiskTestTransfer("origin", "a", 100, since.Add(-time.Minute)),
+				newRiskTestTransfer("origin", "b", 100, since.Add(-time.Minute)),
+				newRiskTestTransfer("origin", "a", 100, since.Add(time.Minute)),
+				newRiskTestTransfer("origin", "b", 100, since.Add(time.Minute)),
+			},
+			transfer: newRiskTestTransfer("origin", "destination", 100, riskTestNow),
+			expected: false,
+		},
+		{
+			name: "Known destination",
+			history: []domain.Transfer{
+				newRiskTestTransfer("origin", "destination", 100, since.Add(-time.Minute)),
+				newRiskTestTransfer("origin", "a", 100, since.Add(time.Minute)),
+				newRiskTestTransfer("origin", "b", 100, since.Add(2*time.Minute)),
+			},
+			transfer: newRiskTestTransfer("origin", "destination", 100, riskTestNow),
+			expected: false,
+		},
+		{
+			name: "Transfers not completed",
+			history: []domain.Transfer{
+				domain.NewTransfer("1", "origin", "a", 100, domain.TransferStatusFailed, since.Add(time.Minute)),
+				domain.NewTransfer("2", "origin", "b", 100, domain.TransferStatusRejected, since.Add(time.Minute)),
+			},
+			transfer: newRiskTestTransfer("origin", "destination", 100, riskTestNow),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		result := evaluateRiskTestRule(t, rule, tt.history, tt.transfer, riskTestDestination)
+		if result != tt.expected {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+		}
+	}
+}
+
+func TestAmountAboveHistory_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rule    = amountAboveHistory{multiplier: 3, minHistory: 2}
+		history = []domain.Transfer{
+			newRiskTestTransfer("origin", "a", 50, riskTestNow.Add(-2*time.Hour)),
+			newRiskTestTransfer("origin", "b", 150, riskTestNow.Add(-time.Hour)),
+		}
+	)
+
+	tests := []struct {
+		name     string
+		history  []domain.Transfer
+		transfer domain.Transfer
+		expected bool
+	}{
+		{
+			name:     "Without history",
+			transfer: newRiskTestTransfer("origin", "destination", 100000, riskTestNow),
+			expected: false,
+		},
+		{
+			name:     "History shorter than the minimum",
+			history:  history[:1],
+			transfer: newRiskTestTransfer("origin", "destination", 100000, riskTestNow),
+			expected: false,
+		},
+		{
+			name:     "Amount at the threshold",
+			history:  history,
+			transfer: newRiskTestTransfer("origin", "destination", 300, riskTestNow),
+			expected: false,
+		},
+		{
+			name:     "Amount above the threshold",
+			history:  history,
+			transfer: newRiskTestTransfer("origin", "destination", 301, riskTestNow),
+			expected: true,
+		},
+		{
+			name: "Incoming and failed transfers",
+			history: append([]domain.Transfer{
+				newRiskTestTransfer("a", "origin", 100000, riskTestNow.Add(-time.Hour)),
+				domain.NewTransfer("1", "origin", "a", 100000, domain.TransferStatusFailed, riskTestNow.Add(-time.Hour)),
+			}, history...),
+			transfer: newRiskTestTransfer("origin", "destination", 301, riskTestNow),
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		result := evaluateRiskTestRule(t, rule, tt.history, tt.transfer, riskTestDestination)
+		if result != tt.expected {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+		}
+	}
+}
+
+func TestNewAccountLargeAmount_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	var rule = newAccountLargeAmount{maxAge: 24 * time.Hour, minAmount: 1000}
+
+	tests := []struct {
+		name        string
+		history     []domain.Transfer
+		transfer    domain.Transfer
+		destination domain.Account
+		expected    bool
+	}{
+		{
+			name:        "Old account",
+			transfer:    newRiskTestTransfer("origin", "destination", 5000, riskTestNow),
+			destination: newRiskTestDestination(riskTestNow.Add(-24*time.Hour - time.Second)),
+			expected:    false,
+		},
+		{
+			name:        "Account at the maximum age",
+			transfer:    newRiskTestTransfer("origin", "destination", 1000, riskTestNow),
+			destination: newRiskTestDestination(riskTestNow.Add(-24 * time.Hour)),
+			expected:    true,
+		},
+		{
+			name:        "Amount below the minimum",
+			transfer:    newRiskTestTransfer("origin", "destination", 999, riskTestNow),
+			destination: newRiskTestDestination(riskTestNow.Add(-time.Hour)),
+			expected:    false,
+		},
+		{
+			name:        "Amount at the minimum",
+			transfer:    newRiskTestTransfer("origin", "destination", 1000, riskTestNow),
+			destination: newRiskTestDestination(riskTestNow.Add(-time.Hour)),
+			expected:    true,
+		},
+		{
+			name: "Received since opened",
+			history: []domain.Transfer{
+				newRiskTestTransfer("a", "destination", 600, riskTestNow.Add(-30*time.Minute)),
+				domain.NewTransfer("1", "b", "destination", 5000, domain.TransferStatusFailed, riskTestNow.Add(-time.Minute)),
+				newRiskTestTransfer("destination", "a", 5000, riskTestNow.Add(-time.Minute)),
+			},
+			transfer:    newRiskTestTransfer("origin", "destination", 400, riskTestNow),
+			destination: newRiskTestDestination(riskTestNow.Add(-time.Hour)),
+			expected:    true,
+		},
+		{
+			name: "Received below the minimum",
+			history: []domain.Transfer{
+				newRiskTestTransfer("a", "destination", 599, riskTestNow.Add(-30*time.Minute)),
+			},
+			transfer:    newRiskTestTransfer("origin", "destination", 400, riskTestNow),
+			destination: newRiskTestDestination(riskTestNow.Add(-time.Hour)),
+			expected:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		result := evaluateRiskTestRule(t, rule, tt.history, tt.transfer, tt.destination)
+		if result != tt.expected {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+		}
+	}
+}
+
+// evaluateRiskTestRule evaluates the rule against a history holding the transfers
+func evaluateRiskTestRule(
+	t *testing.T,
+	rule rule,
+	history []domain.Transfer,
+	transfer domain.Transfer,
+	destination domain.Account,
+) bool {
+	t.Helper()
+
+	var (
+		ctx       = context.Background()
+		transfers = repository.NewTransferMemory(repository.NewMemory())
+	)
+
+	for _, h := range history {
+		if _, err := transfers.Create(ctx, h); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	matched, _, err := rule.evaluate(ctx, domain.RiskSubject{
+		Transfer:    transfer,
+		Origin:      riskTestOrigin,
+		Destination: destination,
+		History:     transfers,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return matched
+}
+
+func newRiskTestTransfer(origin, destination domain.AccountID, amount domain.Money, createdAt time.Time) domain.Transfer {
+	return domain.NewTransfer(
+		domain.TransferID(domain.NewUUID()),
+		origin,
+		destination,
+		amount,
+		domain.TransferStatusCompleted,
+		createdAt,
+	)
+}
+
+func newRiskTestDestination(createdAt time.Time) domain.Account {
+	return domain.NewAccount("destination", "Destination", "11122233344", 0, createdAt)
+}
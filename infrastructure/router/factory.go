@@ -1,12 +1,20 @@
 package router
 
 import (
+	"context"
 	"errors"
-	"github.com/gsabadini/go-clean-architecture/adapter/repository"
+	"expvar"
 	"time"
 
+	"github.com/gsabadini/go-clean-architecture/adapter/api/action"
+	"github.com/gsabadini/go-clean-architecture/adapter/auth"
 	"github.com/gsabadini/go-clean-architecture/adapter/logger"
+	"github.com/gsabadini/go-clean-architecture/adapter/presenter"
 	"github.com/gsabadini/go-clean-architecture/adapter/validator"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/database"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/event"
+	"github.com/gsabadini/go-clean-architecture/usecase"
 )
 
 type Server interface {
@@ -15,6 +23,110 @@ type Server interface {
 
 type Port int64
 
+// apiKeyRotationOverlap is how long a rotated API key keeps working next to its replacement
+const apiKeyRotationOverlap = 24 * time.Hour
+
+// transferApprovalSweepInterval is how often transfers waiting for a decision past their timeout are expired
+const transferApprovalSweepInterval = time.Minute
+
+// outboxRelayInterval is how often the outbox is published
+const outboxRelayInterval = time.Second
+
+// outboxRelay leases the messages it publishes for a minute, and retries a message failing to publish up to 10 times,
+// from 1 second to 10 minutes apart
+var outboxRelay = usecase.OutboxRelayConfig{
+	BatchSize:   100,
+	Lease:       time.Minute,
+	MaxAttempts: 10,
+	BaseBackoff: time.Second,
+	MaxBackoff:  10 * time.Minute,
+}
+
+// webhookDeliveryInterval is how often the due webhook deliveries are posted
+const webhookDeliveryInterval = time.Second
+
+// webhookDelivery leases the deliveries it posts for a minute, retries a delivery up to 8 times, from 10 seconds to an
+// hour apart, and disables a webhook after 20 attempts failed in a row
+var webhookDelivery = usecase.WebhookDeliveryConfig{
+	BatchSize:    50,
+	Lease:        time.Minute,
+	MaxAttempts:  8,
+	BaseBackoff:  10 * time.Second,
+	MaxBackoff:   time.Hour,
+	DisableAfter: 20,
+}
+
+// reconciliationInterval is how often the requested reconciliation runs are looked for
+const reconciliationInterval = time.Minute
+
+// reconciliationTimeout bounds a pass of the reconciliation job, which reads every account and transfer
+const reconciliationTimeout = 10 * time.Minute
+
+// reconciliation schedules a run of the balances once a day
+var reconciliation = usecase.ReconciliationConfig{
+	Every: 24 * time.Hour,
+}
+
+// dailyBalanceInterval is how often the days ended are closed for every account
+const dailyBalanceInterval = time.Hour
+
+// dailyBalanceTimeout bounds a pass of the daily closing, which backfills every day missing
+const dailyBalanceTimeout = 10 * time.Minute
+
+// dailyBalance closes the last 7 days again when a new one closes, so transfers approved late are counted
+var dailyBalance = usecase.DailyBalanceConfig{
+	Reclose: 7,
+}
+
+// transferImportInterval is how often the import jobs waiting are looked for
+const transferImportInterval = 10 * time.Second
+
+// transferImportTimeout bounds a pass of the import job, which creates a transfer per line of the files waiting
+const transferImportTimeout = 30 * time.Minute
+
+// jobContext is the context of a pass of the periodic jobs. They read what they wrote on their previous passes, so
+// their reads never go to a replica lagging behind
+func jobContext() context.Context {
+	return domain.ContextWithReadYourWrites(context.Background())
+}
+
+// exportTimeout bounds an export, which streams every transfer asked for
+const exportTimeout = 5 * time.Minute
+
+// exportFormats are the files transfers and statements are exported as, JSON being the default
+var exportFormats = []action.ExportFormat{
+	{
+		Name:        "json",
+		MediaTypes:  []string{"application/json"},
+		Extension:   "json",
+		NewExporter: presenter.NewJSONExporter,
+	},
+	{
+		Name:        "csv",
+		MediaTypes:  []string{"text/csv"},
+		Extension:   "csv",
+		NewExporter: presenter.NewCSVExporter,
+	},
+	{
+		Name:        "ofx",
+		MediaTypes:  []string{"application/x-ofx"},
+		Extension:   "ofx",
+		Account:     true,
+		NewExporter: presenter.NewOFXExporter,
+	},
+	{
+		Name:        "camt053",
+		MediaTypes:  []string{"application/xml", "text/xml"},
+		Extension:   "xml",
+		Account:     true,
+		NewExporter: presenter.NewCamt053Exporter,
+	},
+}
+
+// outboxMetrics is served at /debug/vars: the messages in the outbox by status, pending being the backlog, and the
+// totals of the relay runs
+var outboxMetrics = expvar.NewMap("outbox")
+
 var (
 	errInvalidWebServerInstance = errors.New("invalid router server instance")
 )
@@ -27,18 +139,68 @@ const (
 func NewWebServerFactory(
 	instance int,
 	log logger.Logger,
-	dbSQL repository.SQL,
-	dbNoSQL repository.NoSQL,
+	accounts domain.AccountRepository,
+	transfers domain.TransferRepository,
+	stores database.Stores,
 	validator validator.Validator,
+	verifier auth.TokenVerifier,
+	riskEngine domain.RiskEngine,
+	approval usecase.TransferApprovalConfig,
+	tariff usecase.TariffConfig,
+	bus event.Bus,
+	sender domain.WebhookSender,
+	notifier domain.Notifier,
 	port Port,
 	ctxTimeout time.Duration,
 ) (Server, error) {
 	switch instance {
 	case InstanceGorillaMux:
-		return newGorillaMux(log, dbSQL, validator, port, ctxTimeout), nil
+		return newGorillaMux(
+			log,
+			accounts,
+			transfers,
+			stores,
+			validator,
+			verifier,
+			riskEngine,
+			approval,
+			tariff,
+			bus,
+			sender,
+			notifier,
+			port,
+			ctxTimeout,
+		), nil
 	case InstanceGin:
-		return newGinServer(log, dbNoSQL, validator, port, ctxTimeout), nil
+		return newGinServer(
+			log,
+			accounts,
+			transfers,
+			stores,
+			validator,
+			verifier,
+			riskEngine,
+			approval,
+			tariff,
+			bus,
+			sender,
+			notifier,
+			port,
+			ctxTimeout,
+		), nil
 	default:
 		return nil, errInvalidWebServerInstance
 	}
 }
+
+func recordOutboxRelay(output usecase.RelayOutboxOutput) {
+	outboxMetrics.Add("sent_total", int64(output.Sent))
+	outboxMetrics.Add("retried_total", int64(output.Retried))
+	outboxMetrics.Add("dead_total", int64(output.Dead))
+
+	for status, count := range output.Backlog {
+		var v = new(expvar.Int)
+		v.Set(int64(count))
+		outboxMetrics.Set("messages_"+status.String(), v)
+	}
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"net/http"
 	"os"
@@ -11,34 +12,67 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/gsabadini/go-clean-architecture/adapter/api/action"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/middleware"
+	"github.com/gsabadini/go-clean-architecture/adapter/auth"
 	"github.com/gsabadini/go-clean-architecture/adapter/logger"
 	"github.com/gsabadini/go-clean-architecture/adapter/presenter"
-	"github.com/gsabadini/go-clean-architecture/adapter/repository"
 	"github.com/gsabadini/go-clean-architecture/adapter/validator"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/database"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/event"
 	"github.com/gsabadini/go-clean-architecture/usecase"
+	"github.com/urfave/negroni"
 )
 
 type ginEngine struct {
 	router     *gin.Engine
 	log        logger.Logger
-	db         repository.NoSQL
+	accounts   domain.AccountRepository
+	transfers  domain.TransferRepository
+	stores     database.Stores
 	validator  validator.Validator
+	verifier   auth.TokenVerifier
+	riskEngine domain.RiskEngine
+	approval   usecase.TransferApprovalConfig
+	tariff     usecase.TariffConfig
+	bus        event.Bus
+	sender     domain.WebhookSender
+	notifier   domain.Notifier
 	port       Port
 	ctxTimeout time.Duration
 }
 
 func newGinServer(
 	log logger.Logger,
-	db repository.NoSQL,
+	accounts domain.AccountRepository,
+	transfers domain.TransferRepository,
+	stores database.Stores,
 	validator validator.Validator,
+	verifier auth.TokenVerifier,
+	riskEngine domain.RiskEngine,
+	approval usecase.TransferApprovalConfig,
+	tariff usecase.TariffConfig,
+	bus event.Bus,
+	sender domain.WebhookSender,
+	notifier domain.Notifier,
 	port Port,
 	t time.Duration,
 ) *ginEngine {
 	return &ginEngine{
 		router:     gin.New(),
 		log:        log,
-		db:         db,
+		accounts:   accounts,
+		transfers:  transfers,
+		stores:     stores,
 		validator:  validator,
+		verifier:   verifier,
+		riskEngine: riskEngine,
+		approval:   approval,
+		tariff:     tariff,
+		bus:        bus,
+		sender:     sender,
+		notifier:   notifier,
 		port:       port,
 		ctxTimeout: t,
 	}
@@ -67,6 +101,16 @@ func (g ginEngine) Listen() {
 		}
 	}()
 
+	g.bus.Subscribe(event.AllEvents, g.enqueueWebhookDeliveries())
+	g.bus.Subscribe(domain.EventTransferCompleted, g.notifyCustomers())
+
+	go g.expireTransferApprovals()
+	go g.relayOutbox()
+	go g.deliverWebhooks()
+	go g.reconcileBalances()
+	go g.closeDailyBalances()
+	go g.runTransferImports()
+
 	<-stop
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -81,25 +125,112 @@ func (g ginEngine) Listen() {
 	g.log.Infof("Service down")
 }
 
-/* TODO ADD MIDDLEWARE */
 func (g ginEngine) setAppHandlers(router *gin.Engine) {
-	router.POST("/v1/transfers", g.buildCreateTransferAction())
-	router.GET("/v1/transfers", g.buildFindAllTransferAction())
+	var authn = g.authentication()
+
+	router.Use(adaptMiddleware(middleware.NewRequestID().Execute))
+
+	router.POST("/v1/transfers", authn, g.authorization(usecase.OpCreateTransfer), g.buildCreateTransferAction())
+	router.GET("/v1/transfers", authn, g.authorization(usecase.OpFindAllTransfer), g.buildFindAllTransferAction())
+	router.POST("/v1/transfers/imports", authn, g.authorization(usecase.OpImportTransfers), g.buildImportTransfersAction())
+	router.GET("/v1/transfers/imports/:import_id", authn, g.authorization(usecase.OpFindTransferImport), g.buildFindTransferImportAction())
+	router.GET("/v1/transfers/imports/:import_id/errors", authn, g.authorization(usecase.OpFindTransferImport), g.buildFindTransferImportErrorsAction())
+	router.POST("/v1/transfers/:transfer_id/approve", authn, g.authorization(usecase.OpApproveTransfer), g.buildApproveTransferAction())
+	router.POST("/v1/transfers/:transfer_id/reject", authn, g.authorization(usecase.OpRejectTransfer), g.buildRejectTransferAction())
+	router.GET("/v1/transfers/:transfer_id/approvals", authn, g.authorization(usecase.OpFindTransferApprovals), g.buildFindTransferApprovalsAction())
+
+	router.GET("/v1/accounts/:account_id/balance", authn, g.authorization(usecase.OpFindAccountBalance), g.buildFindBalanceAccountAction())
+	router.GET("/v1/accounts/:account_id/balances/daily", authn, g.authorization(usecase.OpFindDailyBalances), g.buildFindDailyBalancesAction())
+	router.GET("/v1/accounts/:account_id/statement", authn, g.authorization(usecase.OpExportStatement), g.buildExportStatementAction())
+	router.POST("/v1/accounts", authn, g.authorization(usecase.OpCreateAccount), g.buildCreateAccountAction())
+	router.GET("/v1/accounts", authn, g.authorization(usecase.OpFindAllAccount), g.buildFindAllAccountAction())
+	router.GET("/v1/accounts/:account_id/notification-preferences", authn, g.authorization(usecase.OpFindNotificationPreference), g.buildFindNotificationPreferenceAction())
+	router.PUT("/v1/accounts/:account_id/notification-preferences", authn, g.authorization(usecase.OpUpdateNotificationPreference), g.buildUpdateNotificationPreferenceAction())
+
+	router.POST("/v1/admin/api-keys", authn, g.authorization(usecase.OpCreateAPIKey), g.buildCreateAPIKeyAction())
+	router.GET("/v1/admin/api-keys", authn, g.authorization(usecase.OpFindAllAPIKey), g.buildFindAllAPIKeyAction())
+	router.POST("/v1/admin/api-keys/:api_key_id/revoke", authn, g.authorization(usecase.OpRevokeAPIKey), g.buildRevokeAPIKeyAction())
+	router.POST("/v1/admin/api-keys/:api_key_id/rotate", authn, g.authorization(usecase.OpRotateAPIKey), g.buildRotateAPIKeyAction())
+
+	router.POST("/v1/webhooks", authn, g.authorization(usecase.OpCreateWebhook), g.buildCreateWebhookAction())
+	router.GET("/v1/webhooks", authn, g.authorization(usecase.OpFindAllWebhook), g.buildFindAllWebhookAction())
+	router.PATCH("/v1/webhooks/:webhook_id", authn, g.authorization(usecase.OpUpdateWebhook), g.buildUpdateWebhookAction())
+	router.GET("/v1/webhooks/:webhook_id/deliveries", authn, g.authorization(usecase.OpFindWebhookDeliveries), g.buildFindWebhookDeliveriesAction())
+	router.POST("/v1/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", authn, g.authorization(usecase.OpRedeliverWebhook), g.buildRedeliverWebhookAction())
 
-	router.GET("/v1/accounts/:account_id/balance", g.buildFindBalanceAccountAction())
-	router.POST("/v1/accounts", g.buildCreateAccountAction())
-	router.GET("/v1/accounts", g.buildFindAllAccountAction())
+	router.GET("/v1/audit", authn, g.authorization(usecase.OpFindAudit), g.buildFindAuditAction())
+
+	router.POST("/v1/admin/reconciliation", authn, g.authorization(usecase.OpRequestReconciliation), g.buildRequestReconciliationAction())
+	router.GET("/v1/admin/reconciliation/:run_id", authn, g.authorization(usecase.OpFindReconciliation), g.buildFindReconciliationAction())
 
 	router.GET("/v1/health", g.healthcheck())
+
+	router.GET("/debug/vars", gin.WrapH(expvar.Handler()))
+
+	// v2 answers money as decimal strings with their currency
+	router.POST("/v2/transfers", authn, g.authorization(usecase.OpCreateTransfer), g.buildCreateTransferActionV2())
+	router.GET("/v2/transfers", authn, g.authorization(usecase.OpFindAllTransfer), g.buildFindAllTransferActionV2())
+	router.POST("/v2/transfers/:transfer_id/approve", authn, g.authorization(usecase.OpApproveTransfer), g.buildApproveTransferActionV2())
+	router.POST("/v2/transfers/:transfer_id/reject", authn, g.authorization(usecase.OpRejectTransfer), g.buildRejectTransferActionV2())
+
+	router.GET("/v2/accounts/:account_id/balance", authn, g.authorization(usecase.OpFindAccountBalance), g.buildFindBalanceAccountActionV2())
+	router.POST("/v2/accounts", authn, g.authorization(usecase.OpCreateAccount), g.buildCreateAccountActionV2())
+	router.GET("/v2/accounts", authn, g.authorization(usecase.OpFindAllAccount), g.buildFindAllAccountActionV2())
+}
+
+func (g ginEngine) authentication() gin.HandlerFunc {
+	return adaptMiddleware(middleware.NewAuthentication(
+		g.log,
+		g.verifier,
+		usecase.NewAuthenticateAPIKeyInteractor(g.stores.APIKeys, g.ctxTimeout),
+	).Execute)
+}
+
+// authorization requires the principal to be allowed to run the operation. Routes whose operation has no policy
+// refuse to start
+func (g ginEngine) authorization(op usecase.Operation) gin.HandlerFunc {
+	if _, ok := usecase.PolicyFor(op); !ok {
+		g.log.Fatalln("no authorization policy for operation " + string(op))
+	}
+
+	return adaptMiddleware(middleware.NewAuthorization(g.log, op).Execute)
+}
+
+// adaptMiddleware runs a negroni style middleware inside the gin chain, aborting it when next is not called
+func adaptMiddleware(fn negroni.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var called bool
+
+		fn(c.Writer, c.Request, func(_ http.ResponseWriter, r *http.Request) {
+			called = true
+			c.Request = r
+			c.Next()
+		})
+
+		if !called {
+			c.Abort()
+		}
+	}
 }
 
 func (g ginEngine) buildCreateTransferAction() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var (
-			uc = usecase.NewCreateTransferInteractor(
-				repository.NewTransferNoSQL(g.db),
-				repository.NewAccountNoSQL(g.db),
-				presenter.NewCreateTransferPresenter(),
+			uc = usecase.NewAuditedCreateTransferInteractor(
+				usecase.NewCreateTransferInteractor(
+					g.transfers,
+					g.accounts,
+					g.stores.TransferApprovals,
+					g.riskEngine,
+					g.stores.RiskEvaluations,
+					g.approval,
+					g.tariff,
+					g.stores.Outbox,
+					presenter.NewCreateTransferPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
 				g.ctxTimeout,
 			)
 
@@ -112,25 +243,153 @@ func (g ginEngine) buildCreateTransferAction() gin.HandlerFunc {
 
 func (g ginEngine) buildFindAllTransferAction() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if action.WantsExport(exportFormats, c.Request) {
+			g.buildExportTransfersAction()(c)
+			return
+		}
+
 		var (
 			uc = usecase.NewFindAllTransferInteractor(
-				repository.NewTransferNoSQL(g.db),
+				g.transfers,
+				g.accounts,
 				presenter.NewFindAllTransferPresenter(),
 				g.ctxTimeout,
 			)
-			act = action.NewFindAllTransferAction(uc, g.log)
+			act = action.NewFindAllTransferAction(uc, g.log, g.validator)
+		)
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildExportTransfersAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewExportTransfersInteractor(
+				g.transfers,
+				g.accounts,
+				exportTimeout,
+			)
+			act = action.NewExportTransfersAction(uc, exportFormats, g.log)
+		)
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildApproveTransferAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedApproveTransferInteractor(
+				usecase.NewApproveTransferInteractor(
+					g.transfers,
+					g.accounts,
+					g.stores.TransferApprovals,
+					g.approval,
+					g.tariff,
+					g.stores.Outbox,
+					presenter.NewCreateTransferPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewApproveTransferAction(uc, g.log)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("transfer_id", c.Param("transfer_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildRejectTransferAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedRejectTransferInteractor(
+				usecase.NewRejectTransferInteractor(
+					g.transfers,
+					g.stores.TransferApprovals,
+					g.approval,
+					presenter.NewCreateTransferPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewRejectTransferAction(uc, g.log, g.validator)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("transfer_id", c.Param("transfer_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildFindTransferApprovalsAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewFindTransferApprovalsInteractor(
+				g.transfers,
+				g.accounts,
+				g.stores.TransferApprovals,
+				presenter.NewFindTransferApprovalsPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindTransferApprovalsAction(uc, g.log)
 		)
 
+		q := c.Request.URL.Query()
+		q.Add("transfer_id", c.Param("transfer_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
 		act.Execute(c.Writer, c.Request)
 	}
 }
 
+// expireTransferApprovals periodically expires the transfers that waited too long for a decision
+func (g ginEngine) expireTransferApprovals() {
+	var uc = usecase.NewExpireTransferApprovalsInteractor(
+		g.transfers,
+		g.stores.TransferApprovals,
+		g.approval,
+		g.ctxTimeout,
+	)
+
+	ticker := time.NewTicker(transferApprovalSweepInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		expired, err := uc.Execute(jobContext())
+		if err != nil {
+			g.log.WithError(err).Errorf("Error expiring transfer approvals")
+			continue
+		}
+
+		if expired > 0 {
+			g.log.WithFields(logger.Fields{"expired": expired}).Infof("Expired transfer approvals")
+		}
+	}
+}
+
 func (g ginEngine) buildCreateAccountAction() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var (
-			uc = usecase.NewCreateAccountInteractor(
-				repository.NewAccountNoSQL(g.db),
-				presenter.NewCreateAccountPresenter(),
+			uc = usecase.NewAuditedCreateAccountInteractor(
+				usecase.NewCreateAccountInteractor(
+					g.accounts,
+					g.stores.Outbox,
+					presenter.NewCreateAccountPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
 				g.ctxTimeout,
 			)
 			act = action.NewCreateAccountAction(uc, g.log, g.validator)
@@ -144,7 +403,7 @@ func (g ginEngine) buildFindAllAccountAction() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var (
 			uc = usecase.NewFindAllAccountInteractor(
-				repository.NewAccountNoSQL(g.db),
+				g.accounts,
 				presenter.NewFindAllAccountPresenter(),
 				g.ctxTimeout,
 			)
@@ -159,7 +418,7 @@ func (g ginEngine) buildFindBalanceAccountAction() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var (
 			uc = usecase.NewFindBalanceAccountInteractor(
-				repository.NewAccountNoSQL(g.db),
+				g.accounts,
 				presenter.NewFindAccountBalancePresenter(),
 				g.ctxTimeout,
 			)
@@ -174,8 +433,747 @@ func (g ginEngine) buildFindBalanceAccountAction() gin.HandlerFunc {
 	}
 }
 
+func (g ginEngine) buildCreateAPIKeyAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedCreateAPIKeyInteractor(
+				usecase.NewCreateAPIKeyInteractor(
+					g.stores.APIKeys,
+					presenter.NewCreateAPIKeyPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewCreateAPIKeyAction(uc, g.log, g.validator)
+		)
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildFindAllAPIKeyAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewFindAllAPIKeyInteractor(
+				g.stores.APIKeys,
+				presenter.NewFindAllAPIKeyPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindAllAPIKeyAction(uc, g.log)
+		)
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildRevokeAPIKeyAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedRevokeAPIKeyInteractor(
+				usecase.NewRevokeAPIKeyInteractor(
+					g.stores.APIKeys,
+					presenter.NewRevokeAPIKeyPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewRevokeAPIKeyAction(uc, g.log)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("api_key_id", c.Param("api_key_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildRotateAPIKeyAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedRotateAPIKeyInteractor(
+				usecase.NewRotateAPIKeyInteractor(
+					g.stores.APIKeys,
+					presenter.NewCreateAPIKeyPresenter(),
+					apiKeyRotationOverlap,
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewRotateAPIKeyAction(uc, g.log)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("api_key_id", c.Param("api_key_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
 func (g ginEngine) healthcheck() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		action.HealthCheck(c.Writer, c.Request)
 	}
 }
+
+func (g ginEngine) buildCreateTransferActionV2() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedCreateTransferInteractor(
+				usecase.NewCreateTransferInteractor(
+					g.transfers,
+					g.accounts,
+					g.stores.TransferApprovals,
+					g.riskEngine,
+					g.stores.RiskEvaluations,
+					g.approval,
+					g.tariff,
+					g.stores.Outbox,
+					presenter.NewCreateTransferPresenterV2(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewCreateTransferActionV2(uc, g.log, g.validator)
+		)
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildFindAllTransferActionV2() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewFindAllTransferInteractor(
+				g.transfers,
+				g.accounts,
+				presenter.NewFindAllTransferPresenterV2(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindAllTransferAction(uc, g.log, g.validator)
+		)
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildApproveTransferActionV2() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedApproveTransferInteractor(
+				usecase.NewApproveTransferInteractor(
+					g.transfers,
+					g.accounts,
+					g.stores.TransferApprovals,
+					g.approval,
+					g.tariff,
+					g.stores.Outbox,
+					presenter.NewCreateTransferPresenterV2(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewApproveTransferAction(uc, g.log)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("transfer_id", c.Param("transfer_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildRejectTransferActionV2() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedRejectTransferInteractor(
+				usecase.NewRejectTransferInteractor(
+					g.transfers,
+					g.stores.TransferApprovals,
+					g.approval,
+					presenter.NewCreateTransferPresenterV2(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewRejectTransferAction(uc, g.log, g.validator)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("transfer_id", c.Param("transfer_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildCreateAccountActionV2() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedCreateAccountInteractor(
+				usecase.NewCreateAccountInteractor(
+					g.accounts,
+					g.stores.Outbox,
+					presenter.NewCreateAccountPresenterV2(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewCreateAccountActionV2(uc, g.log, g.validator)
+		)
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildFindAllAccountActionV2() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewFindAllAccountInteractor(
+				g.accounts,
+				presenter.NewFindAllAccountPresenterV2(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindAllAccountAction(uc, g.log)
+		)
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildFindBalanceAccountActionV2() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewFindBalanceAccountInteractor(
+				g.accounts,
+				presenter.NewFindAccountBalancePresenterV2(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindAccountBalanceAction(uc, g.log)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("account_id", c.Param("account_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+// relayOutbox periodically publishes the outbox and records its metrics
+func (g ginEngine) relayOutbox() {
+	var uc = usecase.NewRelayOutboxInteractor(
+		g.stores.Outbox,
+		g.bus,
+		outboxRelay,
+		g.ctxTimeout,
+	)
+
+	ticker := time.NewTicker(outboxRelayInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		output, err := uc.Execute(jobContext())
+		recordOutboxRelay(output)
+		if err != nil {
+			g.log.WithError(err).Errorf("Error relaying outbox")
+			continue
+		}
+
+		if output.Retried > 0 || output.Dead > 0 {
+			g.log.WithFields(logger.Fields{
+				"sent":    output.Sent,
+				"retried": output.Retried,
+				"dead":    output.Dead,
+			}).Warnf("Outbox messages failed to publish")
+		}
+	}
+}
+
+func (g ginEngine) buildCreateWebhookAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedCreateWebhookInteractor(
+				usecase.NewCreateWebhookInteractor(
+					g.stores.Webhooks,
+					g.accounts,
+					g.sender,
+					presenter.NewCreateWebhookPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewCreateWebhookAction(uc, g.log, g.validator)
+		)
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildFindAllWebhookAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewFindAllWebhookInteractor(
+				g.stores.Webhooks,
+				presenter.NewFindAllWebhookPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindAllWebhookAction(uc, g.log)
+		)
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildUpdateWebhookAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedUpdateWebhookInteractor(
+				usecase.NewUpdateWebhookInteractor(
+					g.stores.Webhooks,
+					presenter.NewUpdateWebhookPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewUpdateWebhookAction(uc, g.log, g.validator)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("webhook_id", c.Param("webhook_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildFindWebhookDeliveriesAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewFindWebhookDeliveriesInteractor(
+				g.stores.Webhooks,
+				g.stores.WebhookDeliveries,
+				presenter.NewFindWebhookDeliveriesPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindWebhookDeliveriesAction(uc, g.log)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("webhook_id", c.Param("webhook_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildRedeliverWebhookAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedRedeliverWebhookInteractor(
+				usecase.NewRedeliverWebhookInteractor(
+					g.stores.Webhooks,
+					g.stores.WebhookDeliveries,
+					presenter.NewRedeliverWebhookPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewRedeliverWebhookAction(uc, g.log)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("webhook_id", c.Param("webhook_id"))
+		q.Add("delivery_id", c.Param("delivery_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildFindNotificationPreferenceAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewFindNotificationPreferenceInteractor(
+				g.accounts,
+				g.stores.NotificationPreferences,
+				presenter.NewFindNotificationPreferencePresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindNotificationPreferenceAction(uc, g.log)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("account_id", c.Param("account_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildUpdateNotificationPreferenceAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedUpdateNotificationPreferenceInteractor(
+				usecase.NewUpdateNotificationPreferenceInteractor(
+					g.accounts,
+					g.stores.NotificationPreferences,
+					presenter.NewUpdateNotificationPreferencePresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewUpdateNotificationPreferenceAction(uc, g.log, g.validator)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("account_id", c.Param("account_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+// notifyCustomers notifies the customers of the completed transfers. It runs inside Publish, so a failure has the
+// outbox publish the event again
+func (g ginEngine) notifyCustomers() event.Handler {
+	var uc = usecase.NewNotifyCustomersInteractor(
+		g.accounts,
+		g.stores.NotificationPreferences,
+		g.stores.Notifications,
+		g.notifier,
+		presenter.NewNotificationPresenter(),
+		g.ctxTimeout,
+	)
+
+	return uc.Execute
+}
+
+// enqueueWebhookDeliveries records the deliveries of every published event. It runs inside Publish, so a failure has
+// the outbox publish the event again
+func (g ginEngine) enqueueWebhookDeliveries() event.Handler {
+	var uc = usecase.NewEnqueueWebhookDeliveriesInteractor(
+		g.stores.Webhooks,
+		g.stores.WebhookDeliveries,
+		g.ctxTimeout,
+	)
+
+	return uc.Execute
+}
+
+// deliverWebhooks periodically posts the webhook deliveries that are due
+func (g ginEngine) deliverWebhooks() {
+	var uc = usecase.NewDeliverWebhooksInteractor(
+		g.stores.Webhooks,
+		g.stores.WebhookDeliveries,
+		g.sender,
+		webhookDelivery,
+		g.ctxTimeout,
+	)
+
+	ticker := time.NewTicker(webhookDeliveryInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		output, err := uc.Execute(jobContext())
+		if err != nil {
+			g.log.WithError(err).Errorf("Error delivering webhooks")
+			continue
+		}
+
+		if output.Retried > 0 || output.Failed > 0 || output.Disabled > 0 {
+			g.log.WithFields(logger.Fields{
+				"succeeded": output.Succeeded,
+				"retried":   output.Retried,
+				"failed":    output.Failed,
+				"disabled":  output.Disabled,
+			}).Warnf("Webhook deliveries failed")
+		}
+	}
+}
+
+func (g ginEngine) buildFindAuditAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewFindAuditInteractor(
+				g.stores.Audit,
+				presenter.NewFindAuditPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindAuditAction(uc, g.log)
+		)
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+// reconcileBalances periodically schedules a reconciliation of the balances and runs the requested ones
+func (g ginEngine) reconcileBalances() {
+	var uc = usecase.NewReconcileBalancesInteractor(
+		g.stores.Reconciliations,
+		g.stores.Ledger,
+		reconciliation,
+		reconciliationTimeout,
+	)
+
+	ticker := time.NewTicker(reconciliationInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		output, err := uc.Execute(jobContext())
+		if err != nil {
+			g.log.WithError(err).Errorf("Error reconciling balances")
+			continue
+		}
+
+		if output.Failed > 0 || output.Mismatches > 0 || output.Unverifiable > 0 {
+			g.log.WithFields(logger.Fields{
+				"runs":         output.Runs,
+				"failed":       output.Failed,
+				"mismatches":   output.Mismatches,
+				"unverifiable": output.Unverifiable,
+			}).Warnf("Balance reconciliation found problems")
+		}
+	}
+}
+
+func (g ginEngine) buildRequestReconciliationAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedRequestReconciliationInteractor(
+				usecase.NewRequestReconciliationInteractor(
+					g.stores.Reconciliations,
+					presenter.NewReconciliationPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewRequestReconciliationAction(uc, g.log)
+		)
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildFindReconciliationAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewFindReconciliationInteractor(
+				g.stores.Reconciliations,
+				presenter.NewReconciliationPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindReconciliationAction(uc, g.log)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("run_id", c.Param("run_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+// closeDailyBalances periodically writes the snapshots of the days ended
+func (g ginEngine) closeDailyBalances() {
+	var uc = usecase.NewCloseDailyBalancesInteractor(
+		g.stores.Ledger,
+		g.stores.DailyActivity,
+		g.stores.DailyBalances,
+		dailyBalance,
+		dailyBalanceTimeout,
+	)
+
+	ticker := time.NewTicker(dailyBalanceInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		output, err := uc.Execute(jobContext())
+		if err != nil {
+			g.log.WithError(err).Errorf("Error closing daily balances")
+			continue
+		}
+
+		if output.Days > 0 {
+			g.log.WithFields(logger.Fields{
+				"accounts": output.Accounts,
+				"days":     output.Days,
+			}).Infof("Daily balances closed")
+		}
+	}
+}
+
+func (g ginEngine) buildFindDailyBalancesAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewFindDailyBalancesInteractor(
+				g.accounts,
+				g.stores.DailyBalances,
+				g.tariff,
+				presenter.NewFindDailyBalancesPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindDailyBalancesAction(uc, g.log)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("account_id", c.Param("account_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildExportStatementAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewExportStatementInteractor(
+				g.transfers,
+				g.accounts,
+				g.stores.Ledger,
+				g.stores.DailyActivity,
+				g.stores.DailyBalances,
+				exportTimeout,
+			)
+			act = action.NewExportStatementAction(uc, exportFormats, g.log)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("account_id", c.Param("account_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+// runTransferImports periodically creates the transfers of the files imported, on behalf of who uploaded them
+func (g ginEngine) runTransferImports() {
+	var uc = usecase.NewRunTransferImportsInteractor(
+		g.stores.TransferImports,
+		usecase.NewAuditedCreateTransferInteractor(
+			usecase.NewCreateTransferInteractor(
+				g.transfers,
+				g.accounts,
+				g.stores.TransferApprovals,
+				g.riskEngine,
+				g.stores.RiskEvaluations,
+				g.approval,
+				g.tariff,
+				g.stores.Outbox,
+				presenter.NewCreateTransferPresenter(),
+				g.ctxTimeout,
+			),
+			g.stores.Audit,
+			logging.NewAuditAlerter(g.log),
+			g.ctxTimeout,
+		),
+		transferImportTimeout,
+	)
+
+	ticker := time.NewTicker(transferImportInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		output, err := uc.Execute(jobContext())
+		if err != nil {
+			g.log.WithError(err).Errorf("Error running transfer imports")
+			continue
+		}
+
+		if output.Failed > 0 {
+			g.log.WithFields(logger.Fields{
+				"completed": output.Completed,
+				"failed":    output.Failed,
+			}).Warnf("Transfer imports failed, to be run again")
+			continue
+		}
+
+		if output.Completed > 0 {
+			g.log.WithFields(logger.Fields{"completed": output.Completed}).Infof("Transfer imports completed")
+		}
+	}
+}
+
+func (g ginEngine) buildImportTransfersAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewAuditedImportTransfersInteractor(
+				usecase.NewImportTransfersInteractor(
+					g.stores.TransferImports,
+					presenter.NewTransferImportPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewImportTransfersAction(uc, g.log, g.validator)
+		)
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildFindTransferImportAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewFindTransferImportInteractor(
+				g.stores.TransferImports,
+				presenter.NewTransferImportPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindTransferImportAction(uc, g.log)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("import_id", c.Param("import_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
+
+func (g ginEngine) buildFindTransferImportErrorsAction() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			uc = usecase.NewFindTransferImportInteractor(
+				g.stores.TransferImports,
+				presenter.NewTransferImportPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindTransferImportErrorsAction(uc, presenter.WriteTransferImportErrorReport, g.log)
+		)
+
+		q := c.Request.URL.Query()
+		q.Add("import_id", c.Param("import_id"))
+		c.Request.URL.RawQuery = q.Encode()
+
+		act.Execute(c.Writer, c.Request)
+	}
+}
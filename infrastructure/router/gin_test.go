@@ -0,0 +1,121 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gsabadini/go-clean-architecture/adapter/api/middleware"
+	"github.com/gsabadini/go-clean-architecture/adapter/auth"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type stubTokenVerifier map[string]domain.Principal
+
+func (s stubTokenVerifier) Verify(token string) (domain.Principal, error) {
+	principal, ok := s[token]
+	if !ok {
+		return domain.Principal{}, auth.ErrInvalidToken
+	}
+
+	return principal, nil
+}
+
+type stubAuthenticateAPIKey struct{}
+
+func (stubAuthenticateAPIKey) Execute(_ context.Context, _ string) (domain.Principal, error) {
+	return domain.Principal{}, errors.New("api key not found")
+}
+
+func TestAdaptMiddleware(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	var (
+		verifier = stubTokenVerifier{
+			"admin":    domain.NewPrincipal("admin", domain.RoleAdmin, domain.ScopeAuditRead),
+			"customer": domain.NewPrincipal("07094564964", domain.RoleCustomer, domain.ScopeAuditRead),
+		}
+		authn = adaptMiddleware(middleware.NewAuthentication(log.LoggerMock{}, verifier, stubAuthenticateAPIKey{}).Execute)
+		authz = adaptMiddleware(middleware.NewAuthorization(log.LoggerMock{}, usecase.OpFindAudit).Execute)
+	)
+
+	tests := []struct {
+		name               string
+		header             string
+		expectedStatusCode int
+		expectedHandler    bool
+	}{
+		{
+			name:               "Allowed principal reaches the handler",
+			header:             "Bearer admin",
+			expectedStatusCode: http.StatusOK,
+			expectedHandler:    true,
+		},
+		{
+			name:               "Missing token aborts before the handler",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Invalid token aborts before the handler",
+			header:             "Bearer invalid",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Denied principal aborts before the handler",
+			header:             "Bearer customer",
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				engine  = gin.New()
+				reached bool
+			)
+
+			engine.GET("/v1/audit", authn, authz, func(c *gin.Context) {
+				principal, _ := domain.PrincipalFromContext(c.Request.Context())
+				reached = principal.Subject() != ""
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "/v1/audit", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			var rr = httptest.NewRecorder()
+			engine.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					rr.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			if reached != tt.expectedHandler {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					reached,
+					tt.expectedHandler,
+				)
+			}
+		})
+	}
+}
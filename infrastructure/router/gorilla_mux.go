@@ -2,6 +2,7 @@ package router
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"net/http"
 	"os"
@@ -10,11 +11,15 @@ import (
 	"time"
 
 	"github.com/gsabadini/go-clean-architecture/adapter/api/action"
+	"github.com/gsabadini/go-clean-architecture/adapter/api/logging"
 	"github.com/gsabadini/go-clean-architecture/adapter/api/middleware"
+	"github.com/gsabadini/go-clean-architecture/adapter/auth"
 	"github.com/gsabadini/go-clean-architecture/adapter/logger"
 	"github.com/gsabadini/go-clean-architecture/adapter/presenter"
-	"github.com/gsabadini/go-clean-architecture/adapter/repository"
 	"github.com/gsabadini/go-clean-architecture/adapter/validator"
+	"github.com/gsabadini/go-clean-architecture/domain"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/database"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/event"
 	"github.com/gsabadini/go-clean-architecture/usecase"
 
 	"github.com/gorilla/mux"
@@ -25,16 +30,34 @@ type gorillaMux struct {
 	router     *mux.Router
 	middleware *negroni.Negroni
 	log        logger.Logger
-	db         repository.SQL
+	accounts   domain.AccountRepository
+	transfers  domain.TransferRepository
+	stores     database.Stores
 	validator  validator.Validator
+	verifier   auth.TokenVerifier
+	riskEngine domain.RiskEngine
+	approval   usecase.TransferApprovalConfig
+	tariff     usecase.TariffConfig
+	bus        event.Bus
+	sender     domain.WebhookSender
+	notifier   domain.Notifier
 	port       Port
 	ctxTimeout time.Duration
 }
 
 func newGorillaMux(
 	log logger.Logger,
-	db repository.SQL,
+	accounts domain.AccountRepository,
+	transfers domain.TransferRepository,
+	stores database.Stores,
 	validator validator.Validator,
+	verifier auth.TokenVerifier,
+	riskEngine domain.RiskEngine,
+	approval usecase.TransferApprovalConfig,
+	tariff usecase.TariffConfig,
+	bus event.Bus,
+	sender domain.WebhookSender,
+	notifier domain.Notifier,
 	port Port,
 	t time.Duration,
 ) *gorillaMux {
@@ -42,8 +65,17 @@ func newGorillaMux(
 		router:     mux.NewRouter(),
 		middleware: negroni.New(),
 		log:        log,
-		db:         db,
+		accounts:   accounts,
+		transfers:  transfers,
+		stores:     stores,
 		validator:  validator,
+		verifier:   verifier,
+		riskEngine: riskEngine,
+		approval:   approval,
+		tariff:     tariff,
+		bus:        bus,
+		sender:     sender,
+		notifier:   notifier,
 		port:       port,
 		ctxTimeout: t,
 	}
@@ -70,6 +102,16 @@ func (g gorillaMux) Listen() {
 		}
 	}()
 
+	g.bus.Subscribe(event.AllEvents, g.enqueueWebhookDeliveries())
+	g.bus.Subscribe(domain.EventTransferCompleted, g.notifyCustomers())
+
+	go g.expireTransferApprovals()
+	go g.relayOutbox()
+	go g.deliverWebhooks()
+	go g.reconcileBalances()
+	go g.closeDailyBalances()
+	go g.runTransferImports()
+
 	<-stop
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -87,23 +129,97 @@ func (g gorillaMux) Listen() {
 func (g gorillaMux) setAppHandlers(router *mux.Router) {
 	api := router.PathPrefix("/v1").Subrouter()
 
-	api.Handle("/transfers", g.buildCreateTransferAction()).Methods(http.MethodPost)
-	api.Handle("/transfers", g.buildFindAllTransferAction()).Methods(http.MethodGet)
+	api.Handle("/transfers", g.secure(usecase.OpCreateTransfer, g.buildCreateTransferAction())).Methods(http.MethodPost)
+	api.Handle("/transfers", g.secure(usecase.OpFindAllTransfer, g.buildFindAllTransferAction())).Methods(http.MethodGet)
+	api.Handle("/transfers/imports", g.secure(usecase.OpImportTransfers, g.buildImportTransfersAction())).Methods(http.MethodPost)
+	api.Handle("/transfers/imports/{import_id}", g.secure(usecase.OpFindTransferImport, g.buildFindTransferImportAction())).Methods(http.MethodGet)
+	api.Handle("/transfers/imports/{import_id}/errors", g.secure(usecase.OpFindTransferImport, g.buildFindTransferImportErrorsAction())).Methods(http.MethodGet)
+	api.Handle("/transfers/{transfer_id}/approve", g.secure(usecase.OpApproveTransfer, g.buildApproveTransferAction())).Methods(http.MethodPost)
+	api.Handle("/transfers/{transfer_id}/reject", g.secure(usecase.OpRejectTransfer, g.buildRejectTransferAction())).Methods(http.MethodPost)
+	api.Handle("/transfers/{transfer_id}/approvals", g.secure(usecase.OpFindTransferApprovals, g.buildFindTransferApprovalsAction())).Methods(http.MethodGet)
+
+	api.Handle("/accounts/{account_id}/balance", g.secure(usecase.OpFindAccountBalance, g.buildFindBalanceAccountAction())).Methods(http.MethodGet)
+	api.Handle("/accounts/{account_id}/balances/daily", g.secure(usecase.OpFindDailyBalances, g.buildFindDailyBalancesAction())).Methods(http.MethodGet)
+	api.Handle("/accounts/{account_id}/statement", g.secure(usecase.OpExportStatement, g.buildExportStatementAction())).Methods(http.MethodGet)
+	api.Handle("/accounts/{account_id}/notification-preferences", g.secure(usecase.OpFindNotificationPreference, g.buildFindNotificationPreferenceAction())).Methods(http.MethodGet)
+	api.Handle("/accounts/{account_id}/notification-preferences", g.secure(usecase.OpUpdateNotificationPreference, g.buildUpdateNotificationPreferenceAction())).Methods(http.MethodPut)
+	api.Handle("/accounts", g.secure(usecase.OpCreateAccount, g.buildCreateAccountAction())).Methods(http.MethodPost)
+	api.Handle("/accounts", g.secure(usecase.OpFindAllAccount, g.buildFindAllAccountAction())).Methods(http.MethodGet)
+
+	api.Handle("/admin/api-keys", g.secure(usecase.OpCreateAPIKey, g.buildCreateAPIKeyAction())).Methods(http.MethodPost)
+	api.Handle("/admin/api-keys", g.secure(usecase.OpFindAllAPIKey, g.buildFindAllAPIKeyAction())).Methods(http.MethodGet)
+	api.Handle("/admin/api-keys/{api_key_id}/revoke", g.secure(usecase.OpRevokeAPIKey, g.buildRevokeAPIKeyAction())).Methods(http.MethodPost)
+	api.Handle("/admin/api-keys/{api_key_id}/rotate", g.secure(usecase.OpRotateAPIKey, g.buildRotateAPIKeyAction())).Methods(http.MethodPost)
 
-	api.Handle("/accounts/{account_id}/balance", g.buildFindBalanceAccountAction()).Methods(http.MethodGet)
-	api.Handle("/accounts", g.buildCreateAccountAction()).Methods(http.MethodPost)
-	api.Handle("/accounts", g.buildFindAllAccountAction()).Methods(http.MethodGet)
+	api.Handle("/webhooks", g.secure(usecase.OpCreateWebhook, g.buildCreateWebhookAction())).Methods(http.MethodPost)
+	api.Handle("/webhooks", g.secure(usecase.OpFindAllWebhook, g.buildFindAllWebhookAction())).Methods(http.MethodGet)
+	api.Handle("/webhooks/{webhook_id}", g.secure(usecase.OpUpdateWebhook, g.buildUpdateWebhookAction())).Methods(http.MethodPatch)
+	api.Handle("/webhooks/{webhook_id}/deliveries", g.secure(usecase.OpFindWebhookDeliveries, g.buildFindWebhookDeliveriesAction())).Methods(http.MethodGet)
+	api.Handle("/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver", g.secure(usecase.OpRedeliverWebhook, g.buildRedeliverWebhookAction())).Methods(http.MethodPost)
+
+	api.Handle("/audit", g.secure(usecase.OpFindAudit, g.buildFindAuditAction())).Methods(http.MethodGet)
+
+	api.Handle("/admin/reconciliation", g.secure(usecase.OpRequestReconciliation, g.buildRequestReconciliationAction())).Methods(http.MethodPost)
+	api.Handle("/admin/reconciliation/{run_id}", g.secure(usecase.OpFindReconciliation, g.buildFindReconciliationAction())).Methods(http.MethodGet)
 
 	api.HandleFunc("/health", action.HealthCheck).Methods(http.MethodGet)
+
+	router.Handle("/debug/vars", expvar.Handler()).Methods(http.MethodGet)
+
+	// v2 answers money as decimal strings with their currency
+	apiV2 := router.PathPrefix("/v2").Subrouter()
+
+	apiV2.Handle("/transfers", g.secure(usecase.OpCreateTransfer, g.buildCreateTransferActionV2())).Methods(http.MethodPost)
+	apiV2.Handle("/transfers", g.secure(usecase.OpFindAllTransfer, g.buildFindAllTransferActionV2())).Methods(http.MethodGet)
+	apiV2.Handle("/transfers/{transfer_id}/approve", g.secure(usecase.OpApproveTransfer, g.buildApproveTransferActionV2())).Methods(http.MethodPost)
+	apiV2.Handle("/transfers/{transfer_id}/reject", g.secure(usecase.OpRejectTransfer, g.buildRejectTransferActionV2())).Methods(http.MethodPost)
+
+	apiV2.Handle("/accounts/{account_id}/balance", g.secure(usecase.OpFindAccountBalance, g.buildFindBalanceAccountActionV2())).Methods(http.MethodGet)
+	apiV2.Handle("/accounts", g.secure(usecase.OpCreateAccount, g.buildCreateAccountActionV2())).Methods(http.MethodPost)
+	apiV2.Handle("/accounts", g.secure(usecase.OpFindAllAccount, g.buildFindAllAccountActionV2())).Methods(http.MethodGet)
+}
+
+// secure wraps the handler with the common middlewares and requires an authenticated principal allowed to run
+// the operation. Routes whose operation has no policy refuse to start
+func (g gorillaMux) secure(op usecase.Operation, handler http.HandlerFunc) *negroni.Negroni {
+	if _, ok := usecase.PolicyFor(op); !ok {
+		g.log.Fatalln("no authorization policy for operation " + string(op))
+	}
+
+	var authn = middleware.NewAuthentication(
+		g.log,
+		g.verifier,
+		usecase.NewAuthenticateAPIKeyInteractor(g.stores.APIKeys, g.ctxTimeout),
+	)
+
+	return negroni.New(
+		negroni.HandlerFunc(middleware.NewRequestID().Execute),
+		negroni.HandlerFunc(middleware.NewLogger(g.log).Execute),
+		negroni.NewRecovery(),
+		negroni.HandlerFunc(authn.Execute),
+		negroni.HandlerFunc(middleware.NewAuthorization(g.log, op).Execute),
+		negroni.Wrap(handler),
+	)
 }
 
-func (g gorillaMux) buildCreateTransferAction() *negroni.Negroni {
-	var handler http.HandlerFunc = func(res http.ResponseWriter, req *http.Request) {
+func (g gorillaMux) buildCreateTransferAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
 		var (
-			uc = usecase.NewCreateTransferInteractor(
-				repository.NewTransferSQL(g.db),
-				repository.NewAccountSQL(g.db),
-				presenter.NewCreateTransferPresenter(),
+			uc = usecase.NewAuditedCreateTransferInteractor(
+				usecase.NewCreateTransferInteractor(
+					g.transfers,
+					g.accounts,
+					g.stores.TransferApprovals,
+					g.riskEngine,
+					g.stores.RiskEvaluations,
+					g.approval,
+					g.tariff,
+					g.stores.Outbox,
+					presenter.NewCreateTransferPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
 				g.ctxTimeout,
 			)
 			act = action.NewCreateTransferAction(uc, g.log, g.validator)
@@ -111,61 +227,183 @@ func (g gorillaMux) buildCreateTransferAction() *negroni.Negroni {
 
 		act.Execute(res, req)
 	}
-
-	return negroni.New(
-		negroni.HandlerFunc(middleware.NewLogger(g.log).Execute),
-		negroni.NewRecovery(),
-		negroni.Wrap(handler),
-	)
 }
 
-func (g gorillaMux) buildFindAllTransferAction() *negroni.Negroni {
-	var handler http.HandlerFunc = func(res http.ResponseWriter, req *http.Request) {
+func (g gorillaMux) buildFindAllTransferAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		if action.WantsExport(exportFormats, req) {
+			g.buildExportTransfersAction()(res, req)
+			return
+		}
+
 		var (
 			uc = usecase.NewFindAllTransferInteractor(
-				repository.NewTransferSQL(g.db),
+				g.transfers,
+				g.accounts,
 				presenter.NewFindAllTransferPresenter(),
 				g.ctxTimeout,
 			)
-			act = action.NewFindAllTransferAction(uc, g.log)
+			act = action.NewFindAllTransferAction(uc, g.log, g.validator)
 		)
 
 		act.Execute(res, req)
 	}
+}
 
-	return negroni.New(
-		negroni.HandlerFunc(middleware.NewLogger(g.log).Execute),
-		negroni.NewRecovery(),
-		negroni.Wrap(handler),
-	)
+func (g gorillaMux) buildExportTransfersAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewExportTransfersInteractor(
+				g.transfers,
+				g.accounts,
+				exportTimeout,
+			)
+			act = action.NewExportTransfersAction(uc, exportFormats, g.log)
+		)
+
+		act.Execute(res, req)
+	}
 }
 
-func (g gorillaMux) buildCreateAccountAction() *negroni.Negroni {
-	var handler http.HandlerFunc = func(res http.ResponseWriter, req *http.Request) {
+func (g gorillaMux) buildApproveTransferAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
 		var (
-			uc = usecase.NewCreateAccountInteractor(
-				repository.NewAccountSQL(g.db),
-				presenter.NewCreateAccountPresenter(),
+			uc = usecase.NewAuditedApproveTransferInteractor(
+				usecase.NewApproveTransferInteractor(
+					g.transfers,
+					g.accounts,
+					g.stores.TransferApprovals,
+					g.approval,
+					g.tariff,
+					g.stores.Outbox,
+					presenter.NewCreateTransferPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
 				g.ctxTimeout,
 			)
-			act = action.NewCreateAccountAction(uc, g.log, g.validator)
+			act = action.NewApproveTransferAction(uc, g.log)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
 		)
 
+		q.Add("transfer_id", vars["transfer_id"])
+		req.URL.RawQuery = q.Encode()
+
 		act.Execute(res, req)
 	}
+}
 
-	return negroni.New(
-		negroni.HandlerFunc(middleware.NewLogger(g.log).Execute),
-		negroni.NewRecovery(),
-		negroni.Wrap(handler),
+func (g gorillaMux) buildRejectTransferAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedRejectTransferInteractor(
+				usecase.NewRejectTransferInteractor(
+					g.transfers,
+					g.stores.TransferApprovals,
+					g.approval,
+					presenter.NewCreateTransferPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewRejectTransferAction(uc, g.log, g.validator)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("transfer_id", vars["transfer_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildFindTransferApprovalsAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewFindTransferApprovalsInteractor(
+				g.transfers,
+				g.accounts,
+				g.stores.TransferApprovals,
+				presenter.NewFindTransferApprovalsPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindTransferApprovalsAction(uc, g.log)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("transfer_id", vars["transfer_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+// expireTransferApprovals periodically expires the transfers that waited too long for a decision
+func (g gorillaMux) expireTransferApprovals() {
+	var uc = usecase.NewExpireTransferApprovalsInteractor(
+		g.transfers,
+		g.stores.TransferApprovals,
+		g.approval,
+		g.ctxTimeout,
 	)
+
+	ticker := time.NewTicker(transferApprovalSweepInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		expired, err := uc.Execute(jobContext())
+		if err != nil {
+			g.log.WithError(err).Errorf("Error expiring transfer approvals")
+			continue
+		}
+
+		if expired > 0 {
+			g.log.WithFields(logger.Fields{"expired": expired}).Infof("Expired transfer approvals")
+		}
+	}
+}
+
+func (g gorillaMux) buildCreateAccountAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedCreateAccountInteractor(
+				usecase.NewCreateAccountInteractor(
+					g.accounts,
+					g.stores.Outbox,
+					presenter.NewCreateAccountPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewCreateAccountAction(uc, g.log, g.validator)
+		)
+
+		act.Execute(res, req)
+	}
 }
 
-func (g gorillaMux) buildFindAllAccountAction() *negroni.Negroni {
-	var handler http.HandlerFunc = func(res http.ResponseWriter, req *http.Request) {
+func (g gorillaMux) buildFindAllAccountAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
 		var (
 			uc = usecase.NewFindAllAccountInteractor(
-				repository.NewAccountSQL(g.db),
+				g.accounts,
 				presenter.NewFindAllAccountPresenter(),
 				g.ctxTimeout,
 			)
@@ -174,19 +412,13 @@ func (g gorillaMux) buildFindAllAccountAction() *negroni.Negroni {
 
 		act.Execute(res, req)
 	}
-
-	return negroni.New(
-		negroni.HandlerFunc(middleware.NewLogger(g.log).Execute),
-		negroni.NewRecovery(),
-		negroni.Wrap(handler),
-	)
 }
 
-func (g gorillaMux) buildFindBalanceAccountAction() *negroni.Negroni {
-	var handler http.HandlerFunc = func(res http.ResponseWriter, req *http.Request) {
+func (g gorillaMux) buildFindBalanceAccountAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
 		var (
 			uc = usecase.NewFindBalanceAccountInteractor(
-				repository.NewAccountSQL(g.db),
+				g.accounts,
 				presenter.NewFindAccountBalancePresenter(),
 				g.ctxTimeout,
 			)
@@ -203,10 +435,803 @@ func (g gorillaMux) buildFindBalanceAccountAction() *negroni.Negroni {
 
 		act.Execute(res, req)
 	}
+}
 
-	return negroni.New(
-		negroni.HandlerFunc(middleware.NewLogger(g.log).Execute),
-		negroni.NewRecovery(),
-		negroni.Wrap(handler),
+func (g gorillaMux) buildCreateAPIKeyAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedCreateAPIKeyInteractor(
+				usecase.NewCreateAPIKeyInteractor(
+					g.stores.APIKeys,
+					presenter.NewCreateAPIKeyPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewCreateAPIKeyAction(uc, g.log, g.validator)
+		)
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildFindAllAPIKeyAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewFindAllAPIKeyInteractor(
+				g.stores.APIKeys,
+				presenter.NewFindAllAPIKeyPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindAllAPIKeyAction(uc, g.log)
+		)
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildRevokeAPIKeyAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedRevokeAPIKeyInteractor(
+				usecase.NewRevokeAPIKeyInteractor(
+					g.stores.APIKeys,
+					presenter.NewRevokeAPIKeyPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewRevokeAPIKeyAction(uc, g.log)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("api_key_id", vars["api_key_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildRotateAPIKeyAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedRotateAPIKeyInteractor(
+				usecase.NewRotateAPIKeyInteractor(
+					g.stores.APIKeys,
+					presenter.NewCreateAPIKeyPresenter(),
+					apiKeyRotationOverlap,
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewRotateAPIKeyAction(uc, g.log)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("api_key_id", vars["api_key_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildCreateTransferActionV2() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedCreateTransferInteractor(
+				usecase.NewCreateTransferInteractor(
+					g.transfers,
+					g.accounts,
+					g.stores.TransferApprovals,
+					g.riskEngine,
+					g.stores.RiskEvaluations,
+					g.approval,
+					g.tariff,
+					g.stores.Outbox,
+					presenter.NewCreateTransferPresenterV2(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewCreateTransferActionV2(uc, g.log, g.validator)
+		)
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildFindAllTransferActionV2() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewFindAllTransferInteractor(
+				g.transfers,
+				g.accounts,
+				presenter.NewFindAllTransferPresenterV2(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindAllTransferAction(uc, g.log, g.validator)
+		)
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildApproveTransferActionV2() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedApproveTransferInteractor(
+				usecase.NewApproveTransferInteractor(
+					g.transfers,
+					g.accounts,
+					g.stores.TransferApprovals,
+					g.approval,
+					g.tariff,
+					g.stores.Outbox,
+					presenter.NewCreateTransferPresenterV2(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewApproveTransferAction(uc, g.log)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("transfer_id", vars["transfer_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildRejectTransferActionV2() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedRejectTransferInteractor(
+				usecase.NewRejectTransferInteractor(
+					g.transfers,
+					g.stores.TransferApprovals,
+					g.approval,
+					presenter.NewCreateTransferPresenterV2(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewRejectTransferAction(uc, g.log, g.validator)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("transfer_id", vars["transfer_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildCreateAccountActionV2() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedCreateAccountInteractor(
+				usecase.NewCreateAccountInteractor(
+					g.accounts,
+					g.stores.Outbox,
+					presenter.NewCreateAccountPresenterV2(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewCreateAccountActionV2(uc, g.log, g.validator)
+		)
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildFindAllAccountActionV2() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewFindAllAccountInteractor(
+				g.accounts,
+				presenter.NewFindAllAccountPresenterV2(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindAllAccountAction(uc, g.log)
+		)
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildFindBalanceAccountActionV2() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewFindBalanceAccountInteractor(
+				g.accounts,
+				presenter.NewFindAccountBalancePresenterV2(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindAccountBalanceAction(uc, g.log)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("account_id", vars["account_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+// relayOutbox periodically publishes the outbox and records its metrics
+func (g gorillaMux) relayOutbox() {
+	var uc = usecase.NewRelayOutboxInteractor(
+		g.stores.Outbox,
+		g.bus,
+		outboxRelay,
+		g.ctxTimeout,
 	)
+
+	ticker := time.NewTicker(outboxRelayInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		output, err := uc.Execute(jobContext())
+		recordOutboxRelay(output)
+		if err != nil {
+			g.log.WithError(err).Errorf("Error relaying outbox")
+			continue
+		}
+
+		if output.Retried > 0 || output.Dead > 0 {
+			g.log.WithFields(logger.Fields{
+				"sent":    output.Sent,
+				"retried": output.Retried,
+				"dead":    output.Dead,
+			}).Warnf("Outbox messages failed to publish")
+		}
+	}
+}
+
+func (g gorillaMux) buildCreateWebhookAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedCreateWebhookInteractor(
+				usecase.NewCreateWebhookInteractor(
+					g.stores.Webhooks,
+					g.accounts,
+					g.sender,
+					presenter.NewCreateWebhookPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewCreateWebhookAction(uc, g.log, g.validator)
+		)
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildFindAllWebhookAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewFindAllWebhookInteractor(
+				g.stores.Webhooks,
+				presenter.NewFindAllWebhookPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindAllWebhookAction(uc, g.log)
+		)
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildUpdateWebhookAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedUpdateWebhookInteractor(
+				usecase.NewUpdateWebhookInteractor(
+					g.stores.Webhooks,
+					presenter.NewUpdateWebhookPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewUpdateWebhookAction(uc, g.log, g.validator)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("webhook_id", vars["webhook_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildFindWebhookDeliveriesAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewFindWebhookDeliveriesInteractor(
+				g.stores.Webhooks,
+				g.stores.WebhookDeliveries,
+				presenter.NewFindWebhookDeliveriesPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindWebhookDeliveriesAction(uc, g.log)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("webhook_id", vars["webhook_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildRedeliverWebhookAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedRedeliverWebhookInteractor(
+				usecase.NewRedeliverWebhookInteractor(
+					g.stores.Webhooks,
+					g.stores.WebhookDeliveries,
+					presenter.NewRedeliverWebhookPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewRedeliverWebhookAction(uc, g.log)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("webhook_id", vars["webhook_id"])
+		q.Add("delivery_id", vars["delivery_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildFindNotificationPreferenceAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewFindNotificationPreferenceInteractor(
+				g.accounts,
+				g.stores.NotificationPreferences,
+				presenter.NewFindNotificationPreferencePresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindNotificationPreferenceAction(uc, g.log)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("account_id", vars["account_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildUpdateNotificationPreferenceAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedUpdateNotificationPreferenceInteractor(
+				usecase.NewUpdateNotificationPreferenceInteractor(
+					g.accounts,
+					g.stores.NotificationPreferences,
+					presenter.NewUpdateNotificationPreferencePresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewUpdateNotificationPreferenceAction(uc, g.log, g.validator)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("account_id", vars["account_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+// notifyCustomers notifies the customers of the completed transfers. It runs inside Publish, so a failure has the
+// outbox publish the event again
+func (g gorillaMux) notifyCustomers() event.Handler {
+	var uc = usecase.NewNotifyCustomersInteractor(
+		g.accounts,
+		g.stores.NotificationPreferences,
+		g.stores.Notifications,
+		g.notifier,
+		presenter.NewNotificationPresenter(),
+		g.ctxTimeout,
+	)
+
+	return uc.Execute
+}
+
+// enqueueWebhookDeliveries records the deliveries of every published event. It runs inside Publish, so a failure has
+// the outbox publish the event again
+func (g gorillaMux) enqueueWebhookDeliveries() event.Handler {
+	var uc = usecase.NewEnqueueWebhookDeliveriesInteractor(
+		g.stores.Webhooks,
+		g.stores.WebhookDeliveries,
+		g.ctxTimeout,
+	)
+
+	return uc.Execute
+}
+
+// deliverWebhooks periodically posts the webhook deliveries that are due
+func (g gorillaMux) deliverWebhooks() {
+	var uc = usecase.NewDeliverWebhooksInteractor(
+		g.stores.Webhooks,
+		g.stores.WebhookDeliveries,
+		g.sender,
+		webhookDelivery,
+		g.ctxTimeout,
+	)
+
+	ticker := time.NewTicker(webhookDeliveryInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		output, err := uc.Execute(jobContext())
+		if err != nil {
+			g.log.WithError(err).Errorf("Error delivering webhooks")
+			continue
+		}
+
+		if output.Retried > 0 || output.Failed > 0 || output.Disabled > 0 {
+			g.log.WithFields(logger.Fields{
+				"succeeded": output.Succeeded,
+				"retried":   output.Retried,
+				"failed":    output.Failed,
+				"disabled":  output.Disabled,
+			}).Warnf("Webhook deliveries failed")
+		}
+	}
+}
+
+func (g gorillaMux) buildFindAuditAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewFindAuditInteractor(
+				g.stores.Audit,
+				presenter.NewFindAuditPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindAuditAction(uc, g.log)
+		)
+
+		act.Execute(res, req)
+	}
+}
+
+// reconcileBalances periodically schedules a reconciliation of the balances and runs the requested ones
+func (g gorillaMux) reconcileBalances() {
+	var uc = usecase.NewReconcileBalancesInteractor(
+		g.stores.Reconciliations,
+		g.stores.Ledger,
+		reconciliation,
+		reconciliationTimeout,
+	)
+
+	ticker := time.NewTicker(reconciliationInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		output, err := uc.Execute(jobContext())
+		if err != nil {
+			g.log.WithError(err).Errorf("Error reconciling balances")
+			continue
+		}
+
+		if output.Failed > 0 || output.Mismatches > 0 || output.Unverifiable > 0 {
+			g.log.WithFields(logger.Fields{
+				"runs":         output.Runs,
+				"failed":       output.Failed,
+				"mismatches":   output.Mismatches,
+				"unverifiable": output.Unverifiable,
+			}).Warnf("Balance reconciliation found problems")
+		}
+	}
+}
+
+func (g gorillaMux) buildRequestReconciliationAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedRequestReconciliationInteractor(
+				usecase.NewRequestReconciliationInteractor(
+					g.stores.Reconciliations,
+					presenter.NewReconciliationPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewRequestReconciliationAction(uc, g.log)
+		)
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildFindReconciliationAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewFindReconciliationInteractor(
+				g.stores.Reconciliations,
+				presenter.NewReconciliationPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindReconciliationAction(uc, g.log)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("run_id", vars["run_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+// closeDailyBalances periodically writes the snapshots of the days ended
+func (g gorillaMux) closeDailyBalances() {
+	var uc = usecase.NewCloseDailyBalancesInteractor(
+		g.stores.Ledger,
+		g.stores.DailyActivity,
+		g.stores.DailyBalances,
+		dailyBalance,
+		dailyBalanceTimeout,
+	)
+
+	ticker := time.NewTicker(dailyBalanceInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		output, err := uc.Execute(jobContext())
+		if err != nil {
+			g.log.WithError(err).Errorf("Error closing daily balances")
+			continue
+		}
+
+		if output.Days > 0 {
+			g.log.WithFields(logger.Fields{
+				"accounts": output.Accounts,
+				"days":     output.Days,
+			}).Infof("Daily balances closed")
+		}
+	}
+}
+
+func (g gorillaMux) buildFindDailyBalancesAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewFindDailyBalancesInteractor(
+				g.accounts,
+				g.stores.DailyBalances,
+				g.tariff,
+				presenter.NewFindDailyBalancesPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindDailyBalancesAction(uc, g.log)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("account_id", vars["account_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildExportStatementAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewExportStatementInteractor(
+				g.transfers,
+				g.accounts,
+				g.stores.Ledger,
+				g.stores.DailyActivity,
+				g.stores.DailyBalances,
+				exportTimeout,
+			)
+			act = action.NewExportStatementAction(uc, exportFormats, g.log)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("account_id", vars["account_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+// runTransferImports periodically creates the transfers of the files imported, on behalf of who uploaded them
+func (g gorillaMux) runTransferImports() {
+	var uc = usecase.NewRunTransferImportsInteractor(
+		g.stores.TransferImports,
+		usecase.NewAuditedCreateTransferInteractor(
+			usecase.NewCreateTransferInteractor(
+				g.transfers,
+				g.accounts,
+				g.stores.TransferApprovals,
+				g.riskEngine,
+				g.stores.RiskEvaluations,
+				g.approval,
+				g.tariff,
+				g.stores.Outbox,
+				presenter.NewCreateTransferPresenter(),
+				g.ctxTimeout,
+			),
+			g.stores.Audit,
+			logging.NewAuditAlerter(g.log),
+			g.ctxTimeout,
+		),
+		transferImportTimeout,
+	)
+
+	ticker := time.NewTicker(transferImportInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		output, err := uc.Execute(jobContext())
+		if err != nil {
+			g.log.WithError(err).Errorf("Error running transfer imports")
+			continue
+		}
+
+		if output.Failed > 0 {
+			g.log.WithFields(logger.Fields{
+				"completed": output.Completed,
+				"failed":    output.Failed,
+			}).Warnf("Transfer imports failed, to be run again")
+			continue
+		}
+
+		if output.Completed > 0 {
+			g.log.WithFields(logger.Fields{"completed": output.Completed}).Infof("Transfer imports completed")
+		}
+	}
+}
+
+func (g gorillaMux) buildImportTransfersAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewAuditedImportTransfersInteractor(
+				usecase.NewImportTransfersInteractor(
+					g.stores.TransferImports,
+					presenter.NewTransferImportPresenter(),
+					g.ctxTimeout,
+				),
+				g.stores.Audit,
+				logging.NewAuditAlerter(g.log),
+				g.ctxTimeout,
+			)
+			act = action.NewImportTransfersAction(uc, g.log, g.validator)
+		)
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildFindTransferImportAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewFindTransferImportInteractor(
+				g.stores.TransferImports,
+				presenter.NewTransferImportPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindTransferImportAction(uc, g.log)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("import_id", vars["import_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
+}
+
+func (g gorillaMux) buildFindTransferImportErrorsAction() http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var (
+			uc = usecase.NewFindTransferImportInteractor(
+				g.stores.TransferImports,
+				presenter.NewTransferImportPresenter(),
+				g.ctxTimeout,
+			)
+			act = action.NewFindTransferImportErrorsAction(uc, presenter.WriteTransferImportErrorReport, g.log)
+		)
+
+		var (
+			vars = mux.Vars(req)
+			q    = req.URL.Query()
+		)
+
+		q.Add("import_id", vars["import_id"])
+		req.URL.RawQuery = q.Encode()
+
+		act.Execute(res, req)
+	}
 }
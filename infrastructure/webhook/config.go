@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultTimeout bounds a delivery when WEBHOOK_TIMEOUT is not set
+const defaultTimeout = 5 * time.Second
+
+type config struct {
+	timeout time.Duration
+	allowed []*net.IPNet
+}
+
+func newConfigHTTP() (*config, error) {
+	var c = &config{timeout: defaultTimeout}
+
+	if raw := os.Getenv("WEBHOOK_TIMEOUT"); raw != "" {
+		t, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		c.timeout = t
+	}
+
+	allowed, err := parseNetworks(os.Getenv("WEBHOOK_ALLOWED_NETWORKS"))
+	if err != nil {
+		return nil, err
+	}
+	c.allowed = allowed
+
+	return c, nil
+}
+
+// parseNetworks reads a comma separated list of networks in CIDR notation or of single addresses
+func parseNetworks(raw string) ([]*net.IPNet, error) {
+	var networks = make([]*net.IPNet, 0)
+
+	for _, s := range strings.Split(raw, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		if !strings.Contains(s, "/") {
+			if ip := net.ParseIP(s); ip != nil && ip.To4() != nil {
+				s += "/32"
+			} else {
+				s += "/128"
+			}
+		}
+
+		_, network, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, err
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
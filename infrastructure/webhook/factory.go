@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"errors"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+var (
+	errInvalidWebhookSenderInstance = errors.New("invalid webhook sender instance")
+)
+
+const (
+	InstanceHTTP int = iota
+)
+
+func NewWebhookSenderFactory(instance int) (domain.WebhookSender, error) {
+	switch instance {
+	case InstanceHTTP:
+		c, err := newConfigHTTP()
+		if err != nil {
+			return nil, err
+		}
+
+		return NewHTTPSender(c.timeout, c.allowed), nil
+	default:
+		return nil, errInvalidWebhookSenderInstance
+	}
+}
@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// maxResponseBody is how much of an answer is read, so the connection can be reused
+const maxResponseBody = 64 << 10
+
+// sharedAddressSpace is the carrier-grade NAT range, private to a provider although net does not classify it so
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// HTTPSender posts deliveries as JSON. Redirects are not followed: the URL of a webhook is where it is delivered.
+// Deliveries only reach public addresses, or the networks allowed: the address is checked as the connection is
+// dialed, after the host is resolved, so a name resolving elsewhere since the webhook was created is caught too.
+// Proxies are not used, as they would dial in its place
+type HTTPSender struct {
+	client   *http.Client
+	allowed  []*net.IPNet
+	resolver *net.Resolver
+}
+
+func NewHTTPSender(timeout time.Duration, allowed []*net.IPNet) HTTPSender {
+	var sender = HTTPSender{allowed: allowed, resolver: net.DefaultResolver}
+
+	var dialer = &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !sender.permits(ip) {
+				return domain.ErrWebhookURLNotAllowed
+			}
+
+			return nil
+		},
+	}
+
+	var transport = http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	sender.client = &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return sender
+}
+
+// CheckURL resolves the host of the URL, refusing it when any of its addresses may not be reached or when it does not
+// resolve
+func (h HTTPSender) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return domain.ErrWebhookURLNotAllowed
+	}
+
+	addrs, err := h.resolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return domain.ErrWebhookURLNotAllowed
+	}
+
+	for _, addr := range addrs {
+		if !h.permits(addr.IP) {
+			return domain.ErrWebhookURLNotAllowed
+		}
+	}
+
+	return nil
+}
+
+// permits tells whether the address is public, or in a network allowed. Loopback, private, link-local, multicast and
+// unspecified addresses are refused, IPv4 ones mapped in IPv6 included
+func (h HTTPSender) permits(ip net.IP) bool {
+	for _, network := range h.allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return !(ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() ||
+		sharedAddressSpace.Contains(ip))
+}
+
+func (h HTTPSender) Send(ctx context.Context, webhook domain.Webhook, delivery domain.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL(), bytes.NewReader(delivery.Payload()))
+	if err != nil {
+		return 0, err
+	}
+
+	var now = time.Now()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-clean-architecture-webhooks")
+	req.Header.Set("X-Webhook-Id", webhook.ID().String())
+	req.Header.Set("X-Webhook-Delivery", delivery.ID().String())
+	req.Header.Set("X-Webhook-Event", delivery.EventName().String())
+	req.Header.Set(domain.WebhookTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(domain.WebhookSignatureHeader, webhook.Sign(now, delivery.Payload()))
+
+	res, err := h.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxResponseBody))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("webhook answered with status %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
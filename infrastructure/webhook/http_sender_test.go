@@ -0,0 +1,289 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// loopback allows the receivers httptest starts
+var loopback = []*net.IPNet{{IP: net.IPv4(127, 0, 0, 1), Mask: net.CIDRMask(32, 32)}}
+
+func TestHTTPSender_Send(t *testing.T) {
+	t.Parallel()
+
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+
+	var delivery = domain.NewWebhookDelivery(
+		"5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+		"0db298eb-c8e7-4829-84b7-c1036b4f0791",
+		"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10",
+		domain.EventTransferCompleted,
+		[]byte(`{"id":"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10","name":"transfer.completed"}`),
+		domain.WebhookDeliveryPending,
+		0,
+		time.Now(),
+		0,
+		"",
+		time.Time{},
+		time.Now(),
+	)
+
+	tests := []struct {
+		name          string
+		handler       func(http.ResponseWriter, *http.Request)
+		timeout       time.Duration
+		expectedCode  int
+		expectedError bool
+	}{
+		{
+			name: "Receiver accepts the delivery",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			timeout:      time.Second,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "Receiver answers with an error status",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			timeout:       time.Second,
+			expectedCode:  http.StatusServiceUnavailable,
+			expectedError: true,
+		},
+		{
+			name: "Redirects are not followed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "/elsewhere", http.StatusFound)
+			},
+			timeout:       time.Second,
+			expectedCode:  http.StatusFound,
+			expectedError: true,
+		},
+		{
+			name: "Receiver is slower than the timeout",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(time.Second):
+				}
+			},
+			timeout:       50 * time.Millisecond,
+			expectedCode:  0,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got = make(chan received, 1)
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				select {
+				case got <- received{header: r.Header.Clone(), body: body}:
+				default:
+				}
+
+				tt.handler(w, r)
+			}))
+			defer receiver.Close()
+
+			var webhook = domain.NewWebhook(
+				"0db298eb-c8e7-4829-84b7-c1036b4f0791",
+				"",
+				receiver.URL,
+				[]domain.EventName{domain.EventTransferCompleted},
+				nil,
+				"partner-secret-0123456789",
+				0,
+				time.Time{},
+				time.Now(),
+			)
+
+			code, err := NewHTTPSender(tt.timeout, loopback).Send(context.Background(), webhook, delivery)
+
+			if (err != nil) != tt.expectedError {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+			}
+
+			if code != tt.expectedCode {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, code, tt.expectedCode)
+			}
+
+			var r = <-got
+
+			if string(r.body) != string(delivery.Payload()) {
+				t.Errorf("[TestCase '%s'] Body: '%s' | Expected: '%s'", tt.name, r.body, delivery.Payload())
+			}
+
+			if r.header.Get("X-Webhook-Event") != domain.EventTransferCompleted.String() {
+				t.Errorf("[TestCase '%s'] Event header: '%v'", tt.name, r.header.Get("X-Webhook-Event"))
+			}
+
+			// The receiver verifies the signature the way partners are told to
+			var mac = hmac.New(sha256.New, []byte("partner-secret-0123456789"))
+			mac.Write([]byte(r.header.Get(domain.WebhookTimestampHeader) + "."))
+			mac.Write(r.body)
+
+			var expected = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+			if !hmac.Equal([]byte(r.header.Get(domain.WebhookSignatureHeader)), []byte(expected)) {
+				t.Errorf(
+					"[TestCase '%s'] Signature: '%v' | Expected: '%v'",
+					tt.name,
+					r.header.Get(domain.WebhookSignatureHeader),
+					expected,
+				)
+			}
+		})
+	}
+}
+
+func TestHTTPSender_SendNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	var called = make(chan struct{}, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called <- struct{}{}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	var webhook = domain.NewWebhook(
+		"0db298eb-c8e7-4829-84b7-c1036b4f0791",
+		"",
+		receiver.URL,
+		[]domain.EventName{domain.EventTransferCompleted},
+		nil,
+		"partner-secret-0123456789",
+		0,
+		time.Time{},
+		time.Now(),
+	)
+
+	var delivery = domain.NewWebhookDelivery(
+		"5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10",
+		"0db298eb-c8e7-4829-84b7-c1036b4f0791",
+		"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10",
+		domain.EventTransferCompleted,
+		[]byte(`{}`),
+		domain.WebhookDeliveryPending,
+		0,
+		time.Now(),
+		0,
+		"",
+		time.Time{},
+		time.Now(),
+	)
+
+	// The receiver listens on loopback, which is only reached when allowed
+	code, err := NewHTTPSender(time.Second, nil).Send(context.Background(), webhook, delivery)
+	if !errors.Is(err, domain.ErrWebhookURLNotAllowed) {
+		t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Loopback", err, domain.ErrWebhookURLNotAllowed)
+	}
+
+	if code != 0 {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Loopback", code, 0)
+	}
+
+	select {
+	case <-called:
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Loopback", "delivered", "not delivered")
+	default:
+	}
+}
+
+func TestHTTPSender_CheckURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		url           string
+		allowed       []*net.IPNet
+		expectedError error
+	}{
+		{
+			name: "Public address",
+			url:  "https://93.184.216.34/hooks",
+		},
+		{
+			name:          "Loopback address",
+			url:           "http://127.0.0.1:8080/hooks",
+			expectedError: domain.ErrWebhookURLNotAllowed,
+		},
+		{
+			name:          "Loopback name",
+			url:           "http://localhost/hooks",
+			expectedError: domain.ErrWebhookURLNotAllowed,
+		},
+		{
+			name:          "Private address",
+			url:           "http://10.0.0.12/hooks",
+			expectedError: domain.ErrWebhookURLNotAllowed,
+		},
+		{
+			name:          "Link-local address, where cloud metadata is served",
+			url:           "http://169.254.169.254/latest/meta-data",
+			expectedError: domain.ErrWebhookURLNotAllowed,
+		},
+		{
+			name:          "Shared address space",
+			url:           "http://100.64.1.1/hooks",
+			expectedError: domain.ErrWebhookURLNotAllowed,
+		},
+		{
+			name:          "Unspecified address",
+			url:           "http://0.0.0.0/hooks",
+			expectedError: domain.ErrWebhookURLNotAllowed,
+		},
+		{
+			name:          "IPv6 loopback address",
+			url:           "http://[::1]/hooks",
+			expectedError: domain.ErrWebhookURLNotAllowed,
+		},
+		{
+			name:          "IPv4 loopback mapped in IPv6",
+			url:           "http://[::ffff:127.0.0.1]/hooks",
+			expectedError: domain.ErrWebhookURLNotAllowed,
+		},
+		{
+			name:          "Scheme other than http",
+			url:           "ftp://93.184.216.34/hooks",
+			expectedError: domain.ErrWebhookURLNotAllowed,
+		},
+		{
+			name:    "Loopback address allowed",
+			url:     "http://127.0.0.1:8080/hooks",
+			allowed: loopback,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := NewHTTPSender(time.Second, tt.allowed).CheckURL(context.Background(), tt.url)
+			if !errors.Is(err, tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+			}
+		})
+	}
+}
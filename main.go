@@ -5,20 +5,66 @@ import (
 	"time"
 
 	"github.com/gsabadini/go-clean-architecture/infrastructure"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/auth"
 	"github.com/gsabadini/go-clean-architecture/infrastructure/database"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/event"
 	"github.com/gsabadini/go-clean-architecture/infrastructure/log"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/notification"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/risk"
 	"github.com/gsabadini/go-clean-architecture/infrastructure/router"
 	"github.com/gsabadini/go-clean-architecture/infrastructure/validation"
+	"github.com/gsabadini/go-clean-architecture/infrastructure/webhook"
 )
 
 func main() {
 	var app = infrastructure.NewConfig().
 		Name(os.Getenv("APP_NAME")).
-		ContextTimeout(10 * time.Second).
+		ContextTimeout(10*time.Second).
 		Logger(log.InstanceLogrusLogger).
 		Validator(validation.InstanceGoPlayground).
-		DbSQL(database.InstancePostgres).
-		DbNoSQL(database.InstanceMongoDB)
+		TokenVerifier(auth.InstanceJWTHMAC).
+		RiskEngine(risk.InstanceRulesFile).
+		TransferApproval(os.Getenv("TRANSFER_APPROVAL_THRESHOLD"), os.Getenv("TRANSFER_APPROVAL_TIMEOUT")).
+		Tariff(
+			os.Getenv("MONEY_ROUNDING_MODE"),
+			os.Getenv("TRANSFER_FEE_RATE"),
+			os.Getenv("TRANSFER_FEE_ACCOUNTS"),
+			os.Getenv("INTEREST_RATE"),
+		).
+		EventBus(event.InstanceMemoryBus).
+		WebhookSender(webhook.InstanceHTTP).
+		Notifier(notification.InstanceSMTP)
+
+	// STORAGE_BACKEND=memory keeps everything in the process, connecting to no database
+	var inMemory = os.Getenv("STORAGE_BACKEND") == "memory"
+
+	if !inMemory {
+		app.DbSQL(database.InstancePostgres).
+			DbNoSQL(database.InstanceMongoDB)
+	}
+
+	// go-clean-architecture migrate up|down|status [-db sql|nosql|all] [-steps 1]
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		app.Migrate(os.Args[2:])
+		return
+	}
+
+	if inMemory {
+		app.AccountRepository(database.InstanceAccountMemory).
+			TransferRepository(database.InstanceTransferMemory).
+			Stores(database.InstanceStoresMemory)
+	} else {
+		app.MigrateOnStart(os.Getenv("MIGRATE_ON_START")).
+			AccountRepository(database.InstanceAccountPostgres).
+			TransferRepository(database.InstanceTransferPostgres).
+			Stores(database.InstanceStoresSQL)
+	}
+
+	// go-clean-architecture import -file payroll.csv [-format csv|pain001] [-wait] [-report errors.csv]
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		app.ImportTransfers(os.Args[2:])
+		return
+	}
 
 	app.WebServerPort(os.Getenv("APP_PORT")).
 		WebServer(router.InstanceGorillaMux).
@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// ApproveTransferUseCase input port of /v1
+	ApproveTransferUseCase = ApproveTransferUseCaseOf[CreateTransferOutput]
+
+	// ApproveTransferUseCaseV2 input port of /v2
+	ApproveTransferUseCaseV2 = ApproveTransferUseCaseOf[TransferOutputV2]
+
+	// ApproveTransferUseCaseOf input port, answering with the output of its presenter
+	ApproveTransferUseCaseOf[O any] interface {
+		Execute(context.Context, domain.TransferID) (O, error)
+	}
+
+	approveTransferInteractor[O any] struct {
+		transferRepo domain.TransferRepository
+		accountRepo  domain.AccountRepository
+		approvalRepo domain.TransferApprovalRepository
+		approval     TransferApprovalConfig
+		tariff       TariffConfig
+		outboxRepo   domain.OutboxRepository
+		presenter    CreateTransferPresenterOf[O]
+		ctxTimeout   time.Duration
+	}
+)
+
+// NewApproveTransferInteractor creates new approveTransferInteractor with its dependencies
+func NewApproveTransferInteractor[O any](
+	transferRepo domain.TransferRepository,
+	accountRepo domain.AccountRepository,
+	approvalRepo domain.TransferApprovalRepository,
+	approval TransferApprovalConfig,
+	tariff TariffConfig,
+	outboxRepo domain.OutboxRepository,
+	presenter CreateTransferPresenterOf[O],
+	t time.Duration,
+) ApproveTransferUseCaseOf[O] {
+	return approveTransferInteractor[O]{
+		transferRepo: transferRepo,
+		accountRepo:  accountRepo,
+		approvalRepo: approvalRepo,
+		approval:     approval,
+		tariff:       tariff,
+		outboxRepo:   outboxRepo,
+		presenter:    presenter,
+		ctxTimeout:   t,
+	}
+}
+
+// Execute moves the money of a transfer waiting for a decision, checking the balances at this moment
+func (t approveTransferInteractor[O]) Execute(ctx context.Context, ID domain.TransferID) (O, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.ctxTimeout)
+	defer cancel()
+
+	principal, _, err := Authorize(ctx, OpApproveTransfer)
+	if err != nil {
+		return t.presenter.Output(domain.Transfer{}), err
+	}
+
+	var (
+		transfer domain.Transfer
+		expired  bool
+	)
+
+	err = t.transferRepo.WithTransaction(ctx, func(ctxTx context.Context) error {
+		transfer, expired, err = pendingTransfer(ctxTx, t.transferRepo, t.approvalRepo, t.approval, principal, ID)
+		if err != nil || expired {
+			return err
+		}
+
+		transfer, err = t.approve(ctxTx, principal, transfer)
+		return err
+	})
+	if err != nil {
+		return t.presenter.Output(domain.Transfer{}), err
+	}
+
+	if expired {
+		return t.presenter.Output(domain.Transfer{}), domain.ErrTransferApprovalExpired
+	}
+
+	return t.presenter.Output(transfer), nil
+}
+
+func (t approveTransferInteractor[O]) approve(
+	ctx context.Context,
+	principal domain.Principal,
+	transfer domain.Transfer,
+) (domain.Transfer, error) {
+	origin, err := t.accountRepo.FindByID(ctx, transfer.AccountOriginID())
+	if err != nil {
+		switch err {
+		case domain.ErrAccountNotFound:
+			return domain.Transfer{}, domain.ErrAccountOriginNotFound
+		default:
+			return domain.Transfer{}, err
+		}
+	}
+
+	if err = origin.Withdraw(transfer.Amount()); err != nil {
+		return domain.Transfer{}, err
+	}
+
+	destination, err := t.accountRepo.FindByID(ctx, transfer.AccountDestinationID())
+	if err != nil {
+		switch err {
+		case domain.ErrAccountNotFound:
+			return domain.Transfer{}, domain.ErrAccountDestinationNotFound
+		default:
+			return domain.Transfer{}, err
+		}
+	}
+
+	if err = destination.Deposit(transfer.Amount()); err != nil {
+		return domain.Transfer{}, err
+	}
+
+	if err = updateBalances(ctx, t.accountRepo, origin, destination); err != nil {
+		return domain.Transfer{}, err
+	}
+
+	transfer, err = transfer.TransitionTo(domain.TransferStatusCompleted)
+	if err != nil {
+		return domain.Transfer{}, err
+	}
+
+	if err = t.transferRepo.UpdateStatus(ctx, transfer.ID(), transfer.Status()); err != nil {
+		return domain.Transfer{}, err
+	}
+
+	if err = recordApproval(ctx, t.approvalRepo, transfer, domain.ApprovalApproved, principal, ""); err != nil {
+		return domain.Transfer{}, err
+	}
+
+	if err = t.outboxRepo.Create(ctx, domain.NewTransferCompletedEvent(transfer, origin.Balance())); err != nil {
+		return domain.Transfer{}, err
+	}
+
+	if err = chargeFee(ctx, t.tariff, t.accountRepo, t.transferRepo, t.outboxRepo, transfer); err != nil {
+		return domain.Transfer{}, err
+	}
+
+	return transfer, nil
+}
+
+// pendingTransfer loads the transfer the principal is about to decide. A transfer past its timeout is expired instead
+// and reported as such, so the caller commits the expiration before answering
+func pendingTransfer(
+	ctx context.Context,
+	transferRepo domain.TransferRepository,
+	approvalRepo domain.TransferApprovalRepository,
+	approval TransferApprovalConfig,
+	principal domain.Principal,
+	ID domain.TransferID,
+) (domain.Transfer, bool, error) {
+	transfer, err := transferRepo.FindByID(ctx, ID)
+	if err != nil {
+		return domain.Transfer{}, false, err
+	}
+
+	if !transfer.AwaitingDecision() {
+		return domain.Transfer{}, false, domain.ErrTransferNotPending
+	}
+
+	if approval.Expired(transfer.CreatedAt(), time.Now()) {
+		return domain.Transfer{}, true, expireTransfer(ctx, transferRepo, approvalRepo, transfer)
+	}
+
+	history, err := approvalRepo.FindAllByTransfer(ctx, transfer.ID())
+	if err != nil {
+		return domain.Transfer{}, false, err
+	}
+
+	for _, step := range history {
+		if step.Action() == domain.ApprovalRequested && step.Actor() == principal.Subject() {
+			return domain.Transfer{}, false, domain.ErrApproverIsRequester
+		}
+	}
+
+	return transfer, false, nil
+}
+
+func expireTransfer(
+	ctx context.Context,
+	transferRepo domain.TransferRepository,
+	approvalRepo domain.TransferApprovalRepository,
+	transfer domain.Transfer,
+) error {
+	transfer, err := transfer.TransitionTo(domain.TransferStatusExpired)
+	if err != nil {
+		return err
+	}
+
+	if err = transferRepo.UpdateStatus(ctx, transfer.ID(), transfer.Status()); err != nil {
+		return err
+	}
+
+	return recordApproval(ctx, approvalRepo, transfer, domain.ApprovalExpired, domain.NewPrincipal(domain.ApprovalActorSystem, ""), "")
+}
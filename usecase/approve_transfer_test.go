@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type mockTransferRepoDecision struct {
+	mockTransferRepoStore
+
+	transfer domain.Transfer
+	findErr  error
+	updated  *domain.TransferStatus
+}
+
+func (m mockTransferRepoDecision) FindByID(_ context.Context, _ domain.TransferID) (domain.Transfer, error) {
+	return m.transfer, m.findErr
+}
+
+func (m mockTransferRepoDecision) UpdateStatus(_ context.Context, _ domain.TransferID, status domain.TransferStatus) error {
+	if m.updated != nil {
+		*m.updated = status
+	}
+
+	return nil
+}
+
+func pendingTransferFixture(status domain.TransferStatus, createdAt time.Time) domain.Transfer {
+	return domain.NewTransfer(
+		"3c096a40-ccba-4b58-93ed-57379ab04680",
+		"3c096a40-ccba-4b58-93ed-57379ab04681",
+		"3c096a40-ccba-4b58-93ed-57379ab04682",
+		2999,
+		status,
+		createdAt,
+	)
+}
+
+func requestedBy(subject string) []domain.TransferApproval {
+	return []domain.TransferApproval{
+		domain.NewTransferApproval(
+			"3c096a40-ccba-4b58-93ed-57379ab04690",
+			"3c096a40-ccba-4b58-93ed-57379ab04680",
+			domain.ApprovalRequested,
+			subject,
+			domain.RoleCustomer,
+			"",
+			time.Now(),
+		),
+	}
+}
+
+func TestApproveTransferInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		transfer        domain.Transfer
+		findErr         error
+		history         []domain.TransferApproval
+		accountRepo     domain.AccountRepository
+		approval        TransferApprovalConfig
+		expected        CreateTransferOutput
+		expectedStatus  domain.TransferStatus
+		expectedActions []domain.ApprovalAction
+		expectedEvents  []domain.EventName
+		expectedError   string
+	}{
+		{
+			name:     "Approve transfer successful",
+			ctx:      roleContext(domain.RoleAdmin),
+			transfer: pendingTransferFixture(domain.TransferStatusPendingApproval, time.Now()),
+			history:  requestedBy("08098565895"),
+			accountRepo: mockAccountRepo{
+				updateBalanceOriginFake: func() error {
+					return nil
+				},
+				updateBalanceDestinationFake: func() error {
+					return nil
+				},
+				findByIDOriginFake: func() (domain.Account, error) {
+					return domain.NewAccount("3c096a40-ccba-4b58-93ed-57379ab04681", "Test", "08098565895", 5000, time.Time{}), nil
+				},
+				findByIDDestinationFake: func() (domain.Account, error) {
+					return domain.NewAccount("3c096a40-ccba-4b58-93ed-57379ab04682", "Test2", "13098565491", 3000, time.Time{}), nil
+				},
+				invokedFind:   &invoked{},
+				invokedUpdate: &invoked{},
+			},
+			approval:        TransferApprovalConfig{Threshold: 1000, Timeout: time.Hour},
+			expected:        CreateTransferOutput{Status: "completed"},
+			expectedStatus:  domain.TransferStatusCompleted,
+			expectedActions: []domain.ApprovalAction{domain.ApprovalApproved},
+			expectedEvents:  []domain.EventName{domain.EventTransferCompleted},
+		},
+		{
+			name:     "Approve transfer insufficient balance at decision time",
+			ctx:      roleContext(domain.RoleAdmin),
+			transfer: pendingTransferFixture(domain.TransferStatusPendingApproval, time.Now()),
+			history:  requestedBy("08098565895"),
+			accountRepo: mockAccountRepo{
+				findByIDOriginFake: func() (domain.Account, error) {
+					return domain.NewAccount("3c096a40-ccba-4b58-93ed-57379ab04681", "Test", "08098565895", 100, time.Time{}), nil
+				},
+			},
+			approval:      TransferApprovalConfig{Threshold: 1000, Timeout: time.Hour},
+			expected:      CreateTransferOutput{},
+			expectedError: "origin account does not have sufficient balance",
+		},
+		{
+			name:          "Approve transfer by its requester",
+			ctx:           roleContext(domain.RoleAdmin),
+			transfer:      pendingTransferFixture(domain.TransferStatusPendingApproval, time.Now()),
+			history:       requestedBy("02815517078"),
+			approval:      TransferApprovalConfig{Threshold: 1000, Timeout: time.Hour},
+			expected:      CreateTransferOutput{},
+			expectedError: "transfer must be decided by a different user than its requester",
+		},
+		{
+			name:            "Approve transfer after the timeout",
+			ctx:             roleContext(domain.RoleAdmin),
+			transfer:        pendingTransferFixture(domain.TransferStatusPendingApproval, time.Now().Add(-2*time.Hour)),
+			history:         requestedBy("08098565895"),
+			approval:        TransferApprovalConfig{Threshold: 1000, Timeout: time.Hour},
+			expected:        CreateTransferOutput{},
+			expectedStatus:  domain.TransferStatusExpired,
+			expectedActions: []domain.ApprovalAction{domain.ApprovalExpired},
+			expectedError:   "transfer approval expired",
+		},
+		{
+			name:          "Approve transfer not awaiting decision",
+			ctx:           roleContext(domain.RoleAdmin),
+			transfer:      pendingTransferFixture(domain.TransferStatusCompleted, time.Now()),
+			approval:      TransferApprovalConfig{Threshold: 1000, Timeout: time.Hour},
+			expected:      CreateTransferOutput{},
+			expectedError: "transfer is not awaiting a decision",
+		},
+		{
+			name:          "Approve transfer not found",
+			ctx:           roleContext(domain.RoleAdmin),
+			findErr:       domain.ErrTransferNotFound,
+			expected:      CreateTransferOutput{},
+			expectedError: "transfer not found",
+		},
+		{
+			name:          "Approve transfer by support forbidden",
+			ctx:           roleContext(domain.RoleSupport),
+			expected:      CreateTransferOutput{},
+			expectedError: domain.ErrForbidden.Error(),
+		},
+		{
+			name:          "Approve transfer by customer forbidden",
+			ctx:           customerContext("13098565491"),
+			expected:      CreateTransferOutput{},
+			expectedError: domain.ErrForbidden.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				status    domain.TransferStatus
+				actions   []domain.TransferApproval
+				published []domain.Event
+			)
+
+			var uc = NewApproveTransferInteractor(
+				mockTransferRepoDecision{transfer: tt.transfer, findErr: tt.findErr, updated: &status},
+				tt.accountRepo,
+				mockTransferApprovalRepo{history: tt.history, stored: &actions},
+				tt.approval,
+				TariffConfig{},
+				mockOutboxRepo{created: &published},
+				mockCreateTransferStatusPresenter{},
+				time.Second,
+			)
+
+			got, err := uc.Execute(tt.ctx, "3c096a40-ccba-4b58-93ed-57379ab04680")
+			if (err != nil) && (err.Error() != tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+
+			if tt.expectedStatus != "" && status != tt.expectedStatus {
+				t.Errorf("[TestCase '%s'] Status: '%v' | Expected: '%v'", tt.name, status, tt.expectedStatus)
+			}
+
+			var gotActions []domain.ApprovalAction
+			for _, a := range actions {
+				gotActions = append(gotActions, a.Action())
+			}
+
+			if !reflect.DeepEqual(gotActions, tt.expectedActions) {
+				t.Errorf("[TestCase '%s'] Actions: '%v' | Expected: '%v'", tt.name, gotActions, tt.expectedActions)
+			}
+
+			if got := eventNames(published); !reflect.DeepEqual(got, tt.expectedEvents) {
+				t.Errorf("[TestCase '%s'] Events: '%v' | Expected: '%v'", tt.name, got, tt.expectedEvents)
+			}
+		})
+	}
+}
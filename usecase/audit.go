@@ -0,0 +1,355 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// auditedInteractor records every call to the mutating use case it wraps in the audit trail. A record that can not
+	// be stored is handed to the alerter, so no change goes unaudited unnoticed, and the call answers as it would have
+	auditedInteractor[In, Out any] struct {
+		op         Operation
+		next       func(context.Context, In) (Out, error)
+		targets    func(In, Out) []string
+		repo       domain.AuditRepository
+		alerter    domain.AuditAlerter
+		ctxTimeout time.Duration
+	}
+
+	// rejectTransferCall is the input of RejectTransfer as one value, so it is audited as the others
+	rejectTransferCall struct {
+		TransferID domain.TransferID `json:"transfer_id"`
+		RejectTransferInput
+	}
+
+	auditedRejectTransferInteractor[O any] struct {
+		auditedInteractor[rejectTransferCall, O]
+	}
+
+	// transferOutput is the output of the use cases answering with a transfer, in either version of the API
+	transferOutput interface {
+		CreateTransferOutput | TransferOutputV2
+	}
+
+	// accountOutput is the output of the use cases answering with an account, in either version of the API
+	accountOutput interface {
+		CreateAccountOutput | AccountOutputV2
+	}
+
+	// redeliverWebhookCall is the input of RedeliverWebhook as one value, so it is audited as the others
+	redeliverWebhookCall struct {
+		WebhookID  domain.WebhookID         `json:"webhook_id"`
+		DeliveryID domain.WebhookDeliveryID `json:"delivery_id"`
+	}
+
+	auditedRedeliverWebhookInteractor struct {
+		auditedInteractor[redeliverWebhookCall, WebhookDeliveryOutput]
+	}
+
+	auditedRequestReconciliationInteractor struct {
+		auditedInteractor[struct{}, ReconciliationOutput]
+	}
+)
+
+func newAuditedInteractor[In, Out any](
+	op Operation,
+	next func(context.Context, In) (Out, error),
+	targets func(In, Out) []string,
+	repo domain.AuditRepository,
+	alerter domain.AuditAlerter,
+	t time.Duration,
+) auditedInteractor[In, Out] {
+	return auditedInteractor[In, Out]{
+		op:         op,
+		next:       next,
+		targets:    targets,
+		repo:       repo,
+		alerter:    alerter,
+		ctxTimeout: t,
+	}
+}
+
+// Execute runs the use case and records the call. The record is written once the call returned, when its change is
+// committed, so failing to store it does not fail the call
+func (a auditedInteractor[In, Out]) Execute(ctx context.Context, input In) (Out, error) {
+	output, err := a.next(ctx, input)
+
+	a.record(ctx, input, a.targets(input, output), err)
+
+	return output, err
+}
+
+// record appends the call to the audit trail, alerting the record it could not store. It has a timeout of its own,
+// as the call may have spent the one of the request
+func (a auditedInteractor[In, Out]) record(ctx context.Context, input In, targets []string, err error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), a.ctxTimeout)
+	defer cancel()
+
+	raw, jsonErr := json.Marshal(input)
+
+	var (
+		principal, _ = domain.PrincipalFromContext(ctx)
+		errMessage   string
+		IDs          = make([]string, 0, len(targets))
+	)
+
+	if err != nil {
+		errMessage = err.Error()
+	}
+
+	for _, ID := range targets {
+		if ID != "" {
+			IDs = append(IDs, ID)
+		}
+	}
+
+	var record = domain.NewAuditRecord(
+		domain.NewUUID(),
+		principal.Subject(),
+		principal.Role(),
+		string(a.op),
+		IDs,
+		domain.HashAuditInput(raw),
+		domain.MaskAuditPayload(raw),
+		domain.NewAuditOutcome(err),
+		errMessage,
+		domain.RequestIDFromContext(ctx),
+		time.Now(),
+	)
+
+	if jsonErr != nil {
+		a.alerter.Alert(ctx, record, jsonErr)
+		return
+	}
+
+	if appendErr := a.repo.Append(ctx, record); appendErr != nil {
+		a.alerter.Alert(ctx, record, appendErr)
+	}
+}
+
+// outputID is the ID of the transfer or of the account presented by the output
+func outputID(output interface{}) string {
+	switch o := output.(type) {
+	case CreateTransferOutput:
+		return o.ID
+	case TransferOutputV2:
+		return o.ID
+	case CreateAccountOutput:
+		return o.ID
+	case AccountOutputV2:
+		return o.ID
+	default:
+		return ""
+	}
+}
+
+// NewAuditedCreateAccountInteractor records the calls to CreateAccount, targeting the account created
+func NewAuditedCreateAccountInteractor[O accountOutput](
+	uc CreateAccountUseCaseOf[O],
+	repo domain.AuditRepository,
+	alerter domain.AuditAlerter,
+	t time.Duration,
+) CreateAccountUseCaseOf[O] {
+	return newAuditedInteractor(OpCreateAccount, uc.Execute, func(_ CreateAccountInput, o O) []string {
+		return []string{outputID(o)}
+	}, repo, alerter, t)
+}
+
+// NewAuditedCreateTransferInteractor records the calls to CreateTransfer, targeting the transfer and both accounts
+func NewAuditedCreateTransferInteractor[O transferOutput](
+	uc CreateTransferUseCaseOf[O],
+	repo domain.AuditRepository,
+	alerter domain.AuditAlerter,
+	t time.Duration,
+) CreateTransferUseCaseOf[O] {
+	return newAuditedInteractor(OpCreateTransfer, uc.Execute, func(i CreateTransferInput, o O) []string {
+		return []string{outputID(o), i.AccountOriginID, i.AccountDestinationID}
+	}, repo, alerter, t)
+}
+
+func NewAuditedApproveTransferInteractor[O transferOutput](
+	uc ApproveTransferUseCaseOf[O],
+	repo domain.AuditRepository,
+	alerter domain.AuditAlerter,
+	t time.Duration,
+) ApproveTransferUseCaseOf[O] {
+	return newAuditedInteractor(OpApproveTransfer, uc.Execute, func(ID domain.TransferID, _ O) []string {
+		return []string{ID.String()}
+	}, repo, alerter, t)
+}
+
+func NewAuditedRejectTransferInteractor[O transferOutput](
+	uc RejectTransferUseCaseOf[O],
+	repo domain.AuditRepository,
+	alerter domain.AuditAlerter,
+	t time.Duration,
+) RejectTransferUseCaseOf[O] {
+	return auditedRejectTransferInteractor[O]{newAuditedInteractor(
+		OpRejectTransfer,
+		func(ctx context.Context, c rejectTransferCall) (O, error) {
+			return uc.Execute(ctx, c.TransferID, c.RejectTransferInput)
+		},
+		func(c rejectTransferCall, _ O) []string {
+			return []string{c.TransferID.String()}
+		},
+		repo,
+		alerter,
+		t,
+	)}
+}
+
+func (a auditedRejectTransferInteractor[O]) Execute(
+	ctx context.Context,
+	ID domain.TransferID,
+	input RejectTransferInput,
+) (O, error) {
+	return a.auditedInteractor.Execute(ctx, rejectTransferCall{TransferID: ID, RejectTransferInput: input})
+}
+
+// NewAuditedCreateAPIKeyInteractor records the calls to CreateAPIKey, targeting the key created. The key itself is
+// part of the output only, so it is never recorded
+func NewAuditedCreateAPIKeyInteractor(
+	uc CreateAPIKeyUseCase,
+	repo domain.AuditRepository,
+	alerter domain.AuditAlerter,
+	t time.Duration,
+) CreateAPIKeyUseCase {
+	return newAuditedInteractor(OpCreateAPIKey, uc.Execute, func(_ CreateAPIKeyInput, o CreateAPIKeyOutput) []string {
+		return []string{o.ID}
+	}, repo, alerter, t)
+}
+
+func NewAuditedRevokeAPIKeyInteractor(
+	uc RevokeAPIKeyUseCase,
+	repo domain.AuditRepository,
+	alerter domain.AuditAlerter,
+	t time.Duration,
+) RevokeAPIKeyUseCase {
+	return newAuditedInteractor(OpRevokeAPIKey, uc.Execute, func(ID domain.APIKeyID, _ FindAllAPIKeyOutput) []string {
+		return []string{ID.String()}
+	}, repo, alerter, t)
+}
+
+// NewAuditedRotateAPIKeyInteractor records the calls to RotateAPIKey, targeting the key rotated and its replacement
+func NewAuditedRotateAPIKeyInteractor(
+	uc RotateAPIKeyUseCase,
+	repo domain.AuditRepository,
+	alerter domain.AuditAlerter,
+	t time.Duration,
+) RotateAPIKeyUseCase {
+	return newAuditedInteractor(OpRotateAPIKey, uc.Execute, func(ID domain.APIKeyID, o CreateAPIKeyOutput) []string {
+		return []string{ID.String(), o.ID}
+	}, repo, alerter, t)
+}
+
+// NewAuditedCreateWebhookInteractor records the calls to CreateWebhook, targeting the webhook created. Its secret is
+// redacted from the payload
+func NewAuditedCreateWebhookInteractor(
+	uc CreateWebhookUseCase,
+	repo domain.AuditRepository,
+	alerter domain.AuditAlerter,
+	t time.Duration,
+) CreateWebhookUseCase {
+	return newAuditedInteractor(OpCreateWebhook, uc.Execute, func(_ CreateWebhookInput, o CreateWebhookOutput) []string {
+		return []string{o.ID}
+	}, repo, alerter, t)
+}
+
+func NewAuditedUpdateWebhookInteractor(
+	uc UpdateWebhookUseCase,
+	repo domain.AuditRepository,
+	alerter domain.AuditAlerter,
+	t time.Duration,
+) UpdateWebhookUseCase {
+	return newAuditedInteractor(OpUpdateWebhook, uc.Execute, func(i UpdateWebhookInput, _ WebhookOutput) []string {
+		return []string{i.ID}
+	}, repo, alerter, t)
+}
+
+func NewAuditedRedeliverWebhookInteractor(
+	uc RedeliverWebhookUseCase,
+	repo domain.AuditRepository,
+	alerter domain.AuditAlerter,
+	t time.Duration,
+) RedeliverWebhookUseCase {
+	return auditedRedeliverWebhookInteractor{newAuditedInteractor(
+		OpRedeliverWebhook,
+		func(ctx context.Context, c redeliverWebhookCall) (WebhookDeliveryOutput, error) {
+			return uc.Execute(ctx, c.WebhookID, c.DeliveryID)
+		},
+		func(c redeliverWebhookCall, _ WebhookDeliveryOutput) []string {
+			return []string{c.WebhookID.String(), c.DeliveryID.String()}
+		},
+		repo,
+		alerter,
+		t,
+	)}
+}
+
+func (a auditedRedeliverWebhookInteractor) Execute(
+	ctx context.Context,
+	webhookID domain.WebhookID,
+	deliveryID domain.WebhookDeliveryID,
+) (WebhookDeliveryOutput, error) {
+	return a.auditedInteractor.Execute(ctx, redeliverWebhookCall{WebhookID: webhookID, DeliveryID: deliveryID})
+}
+
+func NewAuditedUpdateNotificationPreferenceInteractor(
+	uc UpdateNotificationPreferenceUseCase,
+	repo domain.AuditRepository,
+	alerter domain.AuditAlerter,
+	t time.Duration,
+) UpdateNotificationPreferenceUseCase {
+	return newAuditedInteractor(
+		OpUpdateNotificationPreference,
+		uc.Execute,
+		func(i UpdateNotificationPreferenceInput, _ NotificationPreferenceOutput) []string {
+			return []string{i.AccountID}
+		},
+		repo,
+		alerter,
+		t,
+	)
+}
+
+// NewAuditedRequestReconciliationInteractor records the calls to RequestReconciliation, targeting the run requested
+func NewAuditedRequestReconciliationInteractor(
+	uc RequestReconciliationUseCase,
+	repo domain.AuditRepository,
+	alerter domain.AuditAlerter,
+	t time.Duration,
+) RequestReconciliationUseCase {
+	return auditedRequestReconciliationInteractor{newAuditedInteractor(
+		OpRequestReconciliation,
+		func(ctx context.Context, _ struct{}) (ReconciliationOutput, error) {
+			return uc.Execute(ctx)
+		},
+		func(_ struct{}, o ReconciliationOutput) []string {
+			return []string{o.ID}
+		},
+		repo,
+		alerter,
+		t,
+	)}
+}
+
+func (a auditedRequestReconciliationInteractor) Execute(ctx context.Context) (ReconciliationOutput, error) {
+	return a.auditedInteractor.Execute(ctx, struct{}{})
+}
+
+// NewAuditedImportTransfersInteractor records the uploads of files of transfers, targeting the job. The transfers of
+// its lines are audited one by one as they are created
+func NewAuditedImportTransfersInteractor(
+	uc ImportTransfersUseCase,
+	repo domain.AuditRepository,
+	alerter domain.AuditAlerter,
+	t time.Duration,
+) ImportTransfersUseCase {
+	return newAuditedInteractor(OpImportTransfers, uc.Execute, func(_ ImportTransfersInput, o TransferImportOutput) []string {
+		return []string{o.ID}
+	}, repo, alerter, t)
+}
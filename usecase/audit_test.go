@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type mockAuditRepoAppend struct {
+	domain.AuditRepository
+
+	records *[]domain.AuditRecord
+	err     error
+}
+
+func (m mockAuditRepoAppend) Append(_ context.Context, record domain.AuditRecord) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	*m.records = append(*m.records, record)
+	return nil
+}
+
+type mockAuditAlerter struct {
+	alerted *[]error
+}
+
+func (m mockAuditAlerter) Alert(_ context.Context, _ domain.AuditRecord, err error) {
+	*m.alerted = append(*m.alerted, err)
+}
+
+type stubCreateAccount struct {
+	output CreateAccountOutput
+	err    error
+}
+
+func (s stubCreateAccount) Execute(_ context.Context, _ CreateAccountInput) (CreateAccountOutput, error) {
+	return s.output, s.err
+}
+
+type stubRejectTransfer struct {
+	called *domain.TransferID
+}
+
+func (s stubRejectTransfer) Execute(
+	_ context.Context,
+	ID domain.TransferID,
+	_ RejectTransferInput,
+) (CreateTransferOutput, error) {
+	*s.called = ID
+	return CreateTransferOutput{ID: ID.String()}, nil
+}
+
+func TestAuditedCreateAccountInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	var input = CreateAccountInput{Name: "Test", CPF: "02815517078", Balance: 100}
+
+	tests := []struct {
+		name            string
+		uc              stubCreateAccount
+		auditErr        error
+		expectedTargets []string
+		expectedOutcome domain.AuditOutcome
+		expectedError   string
+		expectedRecords int
+		expectedAlerts  int
+	}{
+		{
+			name:            "Record the account created",
+			uc:              stubCreateAccount{output: CreateAccountOutput{ID: "3c096a40-ccba-4b58-93ed-57379ab04680"}},
+			expectedTargets: []string{"3c096a40-ccba-4b58-93ed-57379ab04680"},
+			expectedOutcome: domain.AuditSucceeded,
+			expectedRecords: 1,
+		},
+		{
+			name:            "Record a denied call",
+			uc:              stubCreateAccount{err: domain.ErrForbidden},
+			expectedTargets: []string{},
+			expectedOutcome: domain.AuditDenied,
+			expectedError:   domain.ErrForbidden.Error(),
+			expectedRecords: 1,
+		},
+		{
+			name:           "A record that can not be stored is alerted without failing the call",
+			uc:             stubCreateAccount{output: CreateAccountOutput{ID: "3c096a40-ccba-4b58-93ed-57379ab04680"}},
+			auditErr:       errors.New("db error"),
+			expectedAlerts: 1,
+		},
+		{
+			name:           "A failed call whose record can not be stored answers its own error",
+			uc:             stubCreateAccount{err: domain.ErrForbidden},
+			auditErr:       errors.New("db error"),
+			expectedError:  domain.ErrForbidden.Error(),
+			expectedAlerts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				records = make([]domain.AuditRecord, 0)
+				alerted = make([]error, 0)
+				uc      = NewAuditedCreateAccountInteractor(
+					tt.uc,
+					mockAuditRepoAppend{records: &records, err: tt.auditErr},
+					mockAuditAlerter{alerted: &alerted},
+					time.Second,
+				)
+				ctx = domain.ContextWithRequestID(customerContext("02815517078"), "req-1")
+			)
+
+			_, err := uc.Execute(ctx, input)
+			if (err != nil || tt.expectedError != "") && (err == nil || err.Error() != tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+			}
+
+			if len(alerted) != tt.expectedAlerts {
+				t.Errorf("[TestCase '%s'] Alerts: '%v' | Expected: '%v'", tt.name, alerted, tt.expectedAlerts)
+			}
+
+			if len(records) != tt.expectedRecords {
+				t.Fatalf("[TestCase '%s'] Records: '%v'", tt.name, records)
+			}
+
+			if tt.expectedRecords == 0 {
+				return
+			}
+
+			var record = records[0]
+			if record.Actor() != "02815517078" || record.Role() != domain.RoleCustomer {
+				t.Errorf("[TestCase '%s'] Actor: '%v' '%v'", tt.name, record.Actor(), record.Role())
+			}
+
+			if record.Action() != string(OpCreateAccount) || record.RequestID() != "req-1" {
+				t.Errorf("[TestCase '%s'] Action: '%v' RequestID: '%v'", tt.name, record.Action(), record.RequestID())
+			}
+
+			if !reflect.DeepEqual(record.Targets(), tt.expectedTargets) {
+				t.Errorf("[TestCase '%s'] Targets: '%v' | Expected: '%v'", tt.name, record.Targets(), tt.expectedTargets)
+			}
+
+			if record.Outcome() != tt.expectedOutcome {
+				t.Errorf("[TestCase '%s'] Outcome: '%v' | Expected: '%v'", tt.name, record.Outcome(), tt.expectedOutcome)
+			}
+
+			const expectedPayload = `{"balance":100,"cpf":"***.155.170-**","name":"Test"}`
+			if record.Payload() != expectedPayload {
+				t.Errorf("[TestCase '%s'] Payload: '%v' | Expected: '%v'", tt.name, record.Payload(), expectedPayload)
+			}
+
+			if record.InputHash() != domain.HashAuditInput([]byte(`{"name":"Test","cpf":"02815517078","balance":100}`)) {
+				t.Errorf("[TestCase '%s'] InputHash: '%v'", tt.name, record.InputHash())
+			}
+		})
+	}
+}
+
+func TestAuditedRejectTransferInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	var (
+		records = make([]domain.AuditRecord, 0)
+		called  domain.TransferID
+		uc      = NewAuditedRejectTransferInteractor(
+			stubRejectTransfer{called: &called},
+			mockAuditRepoAppend{records: &records},
+			mockAuditAlerter{alerted: &[]error{}},
+			time.Second,
+		)
+	)
+
+	_, err := uc.Execute(roleContext(domain.RoleAdmin), "3c096a40-ccba-4b58-93ed-57379ab04682", RejectTransferInput{
+		Reason: "customer 02815517078 called",
+	})
+	if err != nil {
+		t.Fatalf("Result: '%v' | ExpectedError: '%v'", err, nil)
+	}
+
+	if called != "3c096a40-ccba-4b58-93ed-57379ab04682" {
+		t.Errorf("Called with: '%v'", called)
+	}
+
+	if len(records) != 1 {
+		t.Fatalf("Records: '%v'", records)
+	}
+
+	const expectedPayload = `{"reason":"customer ***.155.170-** called","transfer_id":"3c096a40-ccba-4b58-93ed-57379ab04682"}`
+	if records[0].Payload() != expectedPayload {
+		t.Errorf("Payload: '%v' | Expected: '%v'", records[0].Payload(), expectedPayload)
+	}
+
+	if !reflect.DeepEqual(records[0].Targets(), []string{"3c096a40-ccba-4b58-93ed-57379ab04682"}) {
+		t.Errorf("Targets: '%v'", records[0].Targets())
+	}
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// AuthenticateAPIKeyUseCase input port
+	AuthenticateAPIKeyUseCase interface {
+		Execute(context.Context, string) (domain.Principal, error)
+	}
+
+	authenticateAPIKeyInteractor struct {
+		repo       domain.APIKeyRepository
+		ctxTimeout time.Duration
+	}
+)
+
+// NewAuthenticateAPIKeyInteractor creates new authenticateAPIKeyInteractor with its dependencies
+func NewAuthenticateAPIKeyInteractor(repo domain.APIKeyRepository, t time.Duration) AuthenticateAPIKeyUseCase {
+	return authenticateAPIKeyInteractor{
+		repo:       repo,
+		ctxTimeout: t,
+	}
+}
+
+// Execute resolves a raw key into the principal of the machine client and records its use. The principal is the
+// client the key was issued to, not the key, so it keeps what it owns when the key is rotated
+func (a authenticateAPIKeyInteractor) Execute(ctx context.Context, key string) (domain.Principal, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	var now = time.Now()
+
+	apiKey, err := a.repo.FindByHash(ctx, domain.HashAPIKey(key))
+	if err != nil {
+		return domain.Principal{}, err
+	}
+
+	if !apiKey.Active(now) {
+		return domain.Principal{}, domain.ErrAPIKeyInactive
+	}
+
+	if err = a.repo.UpdateLastUsed(ctx, apiKey.ID(), now); err != nil {
+		return domain.Principal{}, err
+	}
+
+	return domain.NewClientPrincipal(apiKey.ClientID(), apiKey.Scopes()...), nil
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type mockAPIKeyRepoAuthenticate struct {
+	domain.APIKeyRepository
+
+	result domain.APIKey
+	err    error
+}
+
+func (m mockAPIKeyRepoAuthenticate) FindByHash(_ context.Context, _ string) (domain.APIKey, error) {
+	return m.result, m.err
+}
+
+func (m mockAPIKeyRepoAuthenticate) UpdateLastUsed(_ context.Context, _ domain.APIKeyID, _ time.Time) error {
+	return nil
+}
+
+func TestAuthenticateAPIKeyInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	var scopes = []domain.Scope{domain.ScopeAccountsRead}
+
+	tests := []struct {
+		name          string
+		repository    domain.APIKeyRepository
+		expected      domain.Principal
+		expectedError string
+	}{
+		{
+			name: "Authenticate active api key",
+			repository: mockAPIKeyRepoAuthenticate{
+				result: domain.NewAPIKey(
+					"3c096a40-ccba-4b58-93ed-57379ab04680",
+					"8f3b2e1a-4c5d-4e6f-9a0b-1c2d3e4f5a6b",
+					"erp",
+					"abcd1234",
+					"hash",
+					scopes,
+					time.Now().Add(time.Hour),
+					time.Time{},
+					time.Time{},
+					time.Time{},
+				),
+			},
+			expected: domain.NewClientPrincipal("8f3b2e1a-4c5d-4e6f-9a0b-1c2d3e4f5a6b", scopes...),
+		},
+		{
+			name: "Authenticate expired api key",
+			repository: mockAPIKeyRepoAuthenticate{
+				result: domain.NewAPIKey(
+					"3c096a40-ccba-4b58-93ed-57379ab04680",
+					"8f3b2e1a-4c5d-4e6f-9a0b-1c2d3e4f5a6b",
+					"erp",
+					"abcd1234",
+					"hash",
+					scopes,
+					time.Now().Add(-time.Hour),
+					time.Time{},
+					time.Time{},
+					time.Time{},
+				),
+			},
+			expectedError: "api key is revoked or expired",
+		},
+		{
+			name: "Authenticate unknown api key",
+			repository: mockAPIKeyRepoAuthenticate{
+				err: domain.ErrAPIKeyNotFound,
+			},
+			expectedError: "api key not found",
+		},
+		{
+			name: "Authenticate api key generic error",
+			repository: mockAPIKeyRepoAuthenticate{
+				err: errors.New("error"),
+			},
+			expectedError: "error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uc = NewAuthenticateAPIKeyInteractor(tt.repository, time.Second)
+
+			result, err := uc.Execute(context.Background(), "abcd1234.secret")
+			if (err != nil) && (err.Error() != tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// CloseDailyBalancesUseCase input port
+	CloseDailyBalancesUseCase interface {
+		Execute(context.Context) (CloseDailyBalancesOutput, error)
+	}
+
+	// CloseDailyBalancesOutput output data
+	CloseDailyBalancesOutput struct {
+		Accounts int
+		Days     int
+	}
+
+	// DailyBalanceConfig sets how many closed days are closed again when a new one closes. Transfers are counted on
+	// the day they were made, so the ones approved or reversed later change days already closed
+	DailyBalanceConfig struct {
+		Reclose int
+	}
+
+	closeDailyBalancesInteractor struct {
+		ledgerRepo       domain.LedgerRepository
+		activityRepo     domain.DailyActivityRepository
+		dailyBalanceRepo domain.DailyBalanceRepository
+		config           DailyBalanceConfig
+		ctxTimeout       time.Duration
+	}
+)
+
+// NewCloseDailyBalancesInteractor creates new closeDailyBalancesInteractor with its dependencies
+func NewCloseDailyBalancesInteractor(
+	ledgerRepo domain.LedgerRepository,
+	activityRepo domain.DailyActivityRepository,
+	dailyBalanceRepo domain.DailyBalanceRepository,
+	config DailyBalanceConfig,
+	t time.Duration,
+) CloseDailyBalancesUseCase {
+	return closeDailyBalancesInteractor{
+		ledgerRepo:       ledgerRepo,
+		activityRepo:     activityRepo,
+		dailyBalanceRepo: dailyBalanceRepo,
+		config:           config,
+		ctxTimeout:       t,
+	}
+}
+
+// Execute closes, for every account, the days up to yesterday that have no snapshot yet. Running it again once they
+// are closed changes nothing
+func (c closeDailyBalancesInteractor) Execute(ctx context.Context) (CloseDailyBalancesOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.ctxTimeout)
+	defer cancel()
+
+	var (
+		output     CloseDailyBalancesOutput
+		lastClosed = domain.StartOfDay(time.Now()).AddDate(0, 0, -1)
+	)
+
+	ledgers, err := c.ledgerRepo.FindAll(ctx)
+	if err != nil {
+		return output, err
+	}
+
+	for _, ledger := range ledgers {
+		days, err := c.close(ctx, ledger, lastClosed)
+		if err != nil {
+			return output, err
+		}
+
+		if days > 0 {
+			output.Accounts++
+			output.Days += days
+		}
+	}
+
+	return output, nil
+}
+
+// close writes the snapshots of the account from the day after its last one, or from the day it was opened, up to
+// lastClosed. The days of an account whose opening balance is unknown are only closed from a snapshot
+func (c closeDailyBalancesInteractor) close(
+	ctx context.Context,
+	ledger domain.AccountLedger,
+	lastClosed time.Time,
+) (int, error) {
+	var (
+		ID             = ledger.AccountID()
+		from           = domain.StartOfDay(ledger.OpenedAt())
+		balance, known = ledger.OpeningBalance()
+	)
+
+	latest, err := c.dailyBalanceRepo.FindLatest(ctx, ID)
+	switch {
+	case err == domain.ErrDailyBalanceNotFound:
+	case err != nil:
+		return 0, err
+	case !latest.Day().Before(lastClosed):
+		return 0, nil
+	default:
+		var reclose = latest.Day().AddDate(0, 0, 1-c.config.Reclose)
+		if reclose.After(from) {
+			var previousDay = reclose.AddDate(0, 0, -1)
+
+			previous, err := c.dailyBalanceRepo.FindByAccountID(ctx, ID, previousDay, previousDay)
+			if err != nil {
+				return 0, err
+			}
+
+			// Without the snapshot of the day before, the days are closed again from the opening balance
+			if len(previous) == 1 {
+				from = reclose
+				balance = previous[0].ClosingBalance()
+				known = true
+			}
+		}
+	}
+
+	if from.After(lastClosed) || !known {
+		return 0, nil
+	}
+
+	activity, err := c.activityRepo.FindActivity(ctx, ID, from, lastClosed)
+	if err != nil {
+		return 0, err
+	}
+
+	days, err := domain.CloseDays(ID, from, lastClosed, balance, activity)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = c.dailyBalanceRepo.Save(ctx, days...); err != nil {
+		return 0, err
+	}
+
+	return len(days), nil
+}
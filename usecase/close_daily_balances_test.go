@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// mockDailyBalanceRepo keeps the snapshots of a single account by day
+type mockDailyBalanceRepo struct {
+	balances map[time.Time]domain.DailyBalance
+	saved    *int
+}
+
+func (m mockDailyBalanceRepo) Save(_ context.Context, balances ...domain.DailyBalance) error {
+	for _, b := range balances {
+		m.balances[b.Day()] = b
+	}
+
+	*m.saved += len(balances)
+	return nil
+}
+
+func (m mockDailyBalanceRepo) FindByAccountID(
+	_ context.Context,
+	_ domain.AccountID,
+	from time.Time,
+	to time.Time,
+) ([]domain.DailyBalance, error) {
+	var balances = make([]domain.DailyBalance, 0)
+	for day, b := range m.balances {
+		if !day.Before(from) && !day.After(to) {
+			balances = append(balances, b)
+		}
+	}
+
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Day().Before(balances[j].Day())
+	})
+
+	return balances, nil
+}
+
+func (m mockDailyBalanceRepo) FindLatest(ctx context.Context, ID domain.AccountID) (domain.DailyBalance, error) {
+	balances, _ := m.FindByAccountID(ctx, ID, time.Time{}, time.Now())
+	if len(balances) == 0 {
+		return domain.DailyBalance{}, domain.ErrDailyBalanceNotFound
+	}
+
+	return balances[len(balances)-1], nil
+}
+
+// mockDailyLedgerRepo answers a single account and the activity given, within the days asked
+type mockDailyLedgerRepo struct {
+	domain.LedgerRepository
+
+	ledger   domain.AccountLedger
+	activity []domain.DailyActivity
+}
+
+func (m mockDailyLedgerRepo) FindAll(_ context.Context) ([]domain.AccountLedger, error) {
+	return []domain.AccountLedger{m.ledger}, nil
+}
+
+func (m mockDailyLedgerRepo) FindByAccountID(_ context.Context, _ domain.AccountID) (domain.AccountLedger, error) {
+	return m.ledger, nil
+}
+
+func (m mockDailyLedgerRepo) FindActivity(
+	_ context.Context,
+	_ domain.AccountID,
+	from time.Time,
+	to time.Time,
+) ([]domain.DailyActivity, error) {
+	var activity = make([]domain.DailyActivity, 0)
+	for _, a := range m.activity {
+		if !a.Day().Before(from) && !a.Day().After(to) {
+			activity = append(activity, a)
+		}
+	}
+
+	return activity, nil
+}
+
+func TestCloseDailyBalancesInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	const accountID = domain.AccountID("3c096a40-ccba-4b58-93ed-57379ab04680")
+
+	var (
+		today     = domain.StartOfDay(time.Now())
+		yesterday = today.AddDate(0, 0, -1)
+		openedAt  = today.AddDate(0, 0, -3).Add(10 * time.Hour)
+		ledger    = domain.NewAccountLedger(accountID, openedAt, 1000, 0, 0, 0)
+	)
+
+	tests := []struct {
+		name            string
+		existing        []domain.DailyBalance
+		activity        []domain.DailyActivity
+		config          DailyBalanceConfig
+		openingUnknown  bool
+		expected        CloseDailyBalancesOutput
+		expectedSaved   int
+		expectedClosing domain.Money
+	}{
+		{
+			name: "Close backfills every day since the account was opened",
+			activity: []domain.DailyActivity{
+				domain.NewDailyActivity(openedAt, 500, 0, 1),
+				domain.NewDailyActivity(yesterday, 0, 200, 2),
+			},
+			expected:        CloseDailyBalancesOutput{Accounts: 1, Days: 3},
+			expectedSaved:   3,
+			expectedClosing: 1300,
+		},
+		{
+			name: "Close is idempotent once yesterday is closed",
+			existing: []domain.DailyBalance{
+				domain.NewDailyBalance(accountID, yesterday, 1300, 0, 200, 2),
+			},
+			activity: []domain.DailyActivity{
+				domain.NewDailyActivity(yesterday, 0, 999, 1),
+			},
+			expected:        CloseDailyBalancesOutput{},
+			expectedClosing: 1300,
+		},
+		{
+			name: "Close continues from the last day closed",
+			existing: []domain.DailyBalance{
+				domain.NewDailyBalance(accountID, today.AddDate(0, 0, -3), 1500, 500, 0, 1),
+			},
+			activity: []domain.DailyActivity{
+				domain.NewDailyActivity(yesterday, 0, 200, 2),
+			},
+			expected:        CloseDailyBalancesOutput{Accounts: 1, Days: 2},
+			expectedSaved:   2,
+			expectedClosing: 1300,
+		},
+		{
+			name: "Close closes again the days of the reclose window",
+			existing: []domain.DailyBalance{
+				domain.NewDailyBalance(accountID, today.AddDate(0, 0, -3), 1000, 0, 0, 0),
+				domain.NewDailyBalance(accountID, today.AddDate(0, 0, -2), 1000, 0, 0, 0),
+			},
+			activity: []domain.DailyActivity{
+				domain.NewDailyActivity(today.AddDate(0, 0, -2), 700, 0, 1),
+			},
+			config:          DailyBalanceConfig{Reclose: 1},
+			expected:        CloseDailyBalancesOutput{Accounts: 1, Days: 2},
+			expectedSaved:   2,
+			expectedClosing: 1700,
+		},
+		{
+			name: "Close skips the account when its opening balance is unknown",
+			activity: []domain.DailyActivity{
+				domain.NewDailyActivity(openedAt, 500, 0, 1),
+			},
+			openingUnknown:  true,
+			expected:        CloseDailyBalancesOutput{},
+			expectedClosing: 0,
+		},
+		{
+			name: "Close continues from the last day closed when the opening balance is unknown",
+			existing: []domain.DailyBalance{
+				domain.NewDailyBalance(accountID, today.AddDate(0, 0, -3), 1500, 500, 0, 1),
+			},
+			activity: []domain.DailyActivity{
+				domain.NewDailyActivity(yesterday, 0, 200, 2),
+			},
+			openingUnknown:  true,
+			expected:        CloseDailyBalancesOutput{Accounts: 1, Days: 2},
+			expectedSaved:   2,
+			expectedClosing: 1300,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				saved int
+				repo  = mockDailyBalanceRepo{balances: make(map[time.Time]domain.DailyBalance), saved: &saved}
+			)
+
+			for _, b := range tt.existing {
+				repo.balances[b.Day()] = b
+			}
+
+			var ledger = ledger
+			if tt.openingUnknown {
+				ledger = domain.NewAccountLedgerWithoutOpening(accountID, openedAt, 0, 0, 0)
+			}
+
+			var (
+				ledgerRepo = mockDailyLedgerRepo{ledger: ledger, activity: tt.activity}
+				uc         = NewCloseDailyBalancesInteractor(ledgerRepo, ledgerRepo, repo, tt.config, time.Second)
+			)
+
+			got, err := uc.Execute(context.Background())
+			if err != nil {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, nil)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%+v' | Expected: '%+v'", tt.name, got, tt.expected)
+			}
+
+			if saved != tt.expectedSaved {
+				t.Errorf("[TestCase '%s'] Saved: '%v' | Expected: '%v'", tt.name, saved, tt.expectedSaved)
+			}
+
+			if closing := repo.balances[yesterday].ClosingBalance(); closing != tt.expectedClosing {
+				t.Errorf("[TestCase '%s'] Closing: '%v' | Expected: '%v'", tt.name, closing, tt.expectedClosing)
+			}
+		})
+	}
+}
@@ -8,9 +8,15 @@ import (
 )
 
 type (
-	// CreateAccountUseCase input port
-	CreateAccountUseCase interface {
-		Execute(context.Context, CreateAccountInput) (CreateAccountOutput, error)
+	// CreateAccountUseCase input port of /v1
+	CreateAccountUseCase = CreateAccountUseCaseOf[CreateAccountOutput]
+
+	// CreateAccountUseCaseV2 input port of /v2
+	CreateAccountUseCaseV2 = CreateAccountUseCaseOf[AccountOutputV2]
+
+	// CreateAccountUseCaseOf input port, answering with the output of its presenter
+	CreateAccountUseCaseOf[O any] interface {
+		Execute(context.Context, CreateAccountInput) (O, error)
 	}
 
 	// CreateAccountInput input data
@@ -20,9 +26,15 @@ type (
 		Balance int64  `json:"balance" validate:"gt=0,required"`
 	}
 
-	// CreateAccountPresenter output port
-	CreateAccountPresenter interface {
-		Output(domain.Account) CreateAccountOutput
+	// CreateAccountPresenter output port of /v1
+	CreateAccountPresenter = CreateAccountPresenterOf[CreateAccountOutput]
+
+	// CreateAccountPresenterV2 output port of /v2
+	CreateAccountPresenterV2 = CreateAccountPresenterOf[AccountOutputV2]
+
+	// CreateAccountPresenterOf output port
+	CreateAccountPresenterOf[O any] interface {
+		Output(domain.Account) O
 	}
 
 	// CreateAccountOutput output data
@@ -34,31 +46,53 @@ type (
 		CreatedAt string  `json:"created_at"`
 	}
 
-	createAccountInteractor struct {
+	// AccountOutputV2 output data of /v2, with the balance as a decimal string
+	AccountOutputV2 struct {
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		CPF       string `json:"cpf"`
+		Balance   string `json:"balance"`
+		Currency  string `json:"currency"`
+		CreatedAt string `json:"created_at"`
+	}
+
+	createAccountInteractor[O any] struct {
 		repo       domain.AccountRepository
-		presenter  CreateAccountPresenter
+		outboxRepo domain.OutboxRepository
+		presenter  CreateAccountPresenterOf[O]
 		ctxTimeout time.Duration
 	}
 )
 
 // NewCreateAccountInteractor creates new createAccountInteractor with its dependencies
-func NewCreateAccountInteractor(
+func NewCreateAccountInteractor[O any](
 	repo domain.AccountRepository,
-	presenter CreateAccountPresenter,
+	outboxRepo domain.OutboxRepository,
+	presenter CreateAccountPresenterOf[O],
 	t time.Duration,
-) CreateAccountUseCase {
-	return createAccountInteractor{
+) CreateAccountUseCaseOf[O] {
+	return createAccountInteractor[O]{
 		repo:       repo,
+		outboxRepo: outboxRepo,
 		presenter:  presenter,
 		ctxTimeout: t,
 	}
 }
 
 // Execute orchestrates the use case
-func (a createAccountInteractor) Execute(ctx context.Context, input CreateAccountInput) (CreateAccountOutput, error) {
+func (a createAccountInteractor[O]) Execute(ctx context.Context, input CreateAccountInput) (O, error) {
 	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
 	defer cancel()
 
+	principal, access, err := Authorize(ctx, OpCreateAccount)
+	if err != nil {
+		return a.presenter.Output(domain.Account{}), err
+	}
+
+	if access == AccessOwn && principal.Subject() != input.CPF {
+		return a.presenter.Output(domain.Account{}), domain.ErrForbidden
+	}
+
 	var account = domain.NewAccount(
 		domain.AccountID(domain.NewUUID()),
 		input.Name,
@@ -67,7 +101,14 @@ func (a createAccountInteractor) Execute(ctx context.Context, input CreateAccoun
 		time.Now(),
 	)
 
-	account, err := a.repo.Create(ctx, account)
+	err = a.outboxRepo.WithTransaction(ctx, func(ctxTx context.Context) error {
+		account, err = a.repo.Create(ctxTx, account)
+		if err != nil {
+			return err
+		}
+
+		return a.outboxRepo.Create(ctxTx, domain.NewAccountCreatedEvent(account))
+	})
 	if err != nil {
 		return a.presenter.Output(domain.Account{}), err
 	}
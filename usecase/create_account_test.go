@@ -29,6 +29,33 @@ func (m mockCreateAccountPresenter) Output(_ domain.Account) CreateAccountOutput
 	return m.result
 }
 
+type mockOutboxRepo struct {
+	domain.OutboxRepository
+
+	created *[]domain.Event
+}
+
+func (m mockOutboxRepo) Create(_ context.Context, events ...domain.Event) error {
+	if m.created != nil {
+		*m.created = append(*m.created, events...)
+	}
+
+	return nil
+}
+
+func (m mockOutboxRepo) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func eventNames(events []domain.Event) []domain.EventName {
+	var names []domain.EventName
+	for _, e := range events {
+		names = append(names, e.Name())
+	}
+
+	return names
+}
+
 func TestCreateAccountInteractor_Execute(t *testing.T) {
 	t.Parallel()
 
@@ -37,15 +64,18 @@ func TestCreateAccountInteractor_Execute(t *testing.T) {
 	}
 
 	tests := []struct {
-		name          string
-		args          args
-		repository    domain.AccountRepository
-		presenter     CreateAccountPresenter
-		expected      CreateAccountOutput
-		expectedError interface{}
+		name           string
+		ctx            context.Context
+		args           args
+		repository     domain.AccountRepository
+		presenter      CreateAccountPresenter
+		expected       CreateAccountOutput
+		expectedEvents []domain.EventName
+		expectedError  interface{}
 	}{
 		{
 			name: "Create account successful",
+			ctx:  customerContext("02815517078"),
 			args: args{
 				input: CreateAccountInput{
 					Name:    "Test",
@@ -79,9 +109,12 @@ func TestCreateAccountInteractor_Execute(t *testing.T) {
 				Balance:   199.44,
 				CreatedAt: time.Time{}.String(),
 			},
+
+			expectedEvents: []domain.EventName{domain.EventAccountCreated},
 		},
 		{
 			name: "Create account successful",
+			ctx:  customerContext("02815517078"),
 			args: args{
 				input: CreateAccountInput{
 					Name:    "Test",
@@ -115,14 +148,17 @@ func TestCreateAccountInteractor_Execute(t *testing.T) {
 				Balance:   23.5,
 				CreatedAt: time.Time{}.String(),
 			},
+
+			expectedEvents: []domain.EventName{domain.EventAccountCreated},
 		},
 		{
 			name: "Create account generic error",
+			ctx:  customerContext("02815517078"),
 			args: args{
 				input: CreateAccountInput{
-					Name:    "",
-					CPF:     "",
-					Balance: 0,
+					Name:    "Test",
+					CPF:     "02815517078",
+					Balance: 100,
 				},
 			},
 			repository: mockAccountRepoStore{
@@ -135,13 +171,55 @@ func TestCreateAccountInteractor_Execute(t *testing.T) {
 			expectedError: "error",
 			expected:      CreateAccountOutput{},
 		},
+		{
+			name: "Create account for another holder forbidden",
+			ctx:  customerContext("13098565491"),
+			args: args{
+				input: CreateAccountInput{
+					Name:    "Test",
+					CPF:     "02815517078",
+					Balance: 100,
+				},
+			},
+			repository: mockAccountRepoStore{
+				result: domain.Account{},
+				err:    nil,
+			},
+			presenter: mockCreateAccountPresenter{
+				result: CreateAccountOutput{},
+			},
+			expectedError: "caller is not allowed to access this resource",
+			expected:      CreateAccountOutput{},
+		},
+		{
+			name: "Create account without principal forbidden",
+			ctx:  context.Background(),
+			args: args{
+				input: CreateAccountInput{
+					Name:    "Test",
+					CPF:     "02815517078",
+					Balance: 100,
+				},
+			},
+			repository: mockAccountRepoStore{
+				result: domain.Account{},
+				err:    nil,
+			},
+			presenter: mockCreateAccountPresenter{
+				result: CreateAccountOutput{},
+			},
+			expectedError: "caller is not allowed to access this resource",
+			expected:      CreateAccountOutput{},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var uc = NewCreateAccountInteractor(tt.repository, tt.presenter, time.Second)
+			var published []domain.Event
 
-			result, err := uc.Execute(context.TODO(), tt.args.input)
+			var uc = NewCreateAccountInteractor(tt.repository, mockOutboxRepo{created: &published}, tt.presenter, time.Second)
+
+			result, err := uc.Execute(tt.ctx, tt.args.input)
 			if (err != nil) && (err.Error() != tt.expectedError) {
 				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
 			}
@@ -149,6 +227,10 @@ func TestCreateAccountInteractor_Execute(t *testing.T) {
 			if !reflect.DeepEqual(result, tt.expected) {
 				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
 			}
+
+			if got := eventNames(published); !reflect.DeepEqual(got, tt.expectedEvents) {
+				t.Errorf("[TestCase '%s'] Events: '%v' | Expected: '%v'", tt.name, got, tt.expectedEvents)
+			}
 		})
 	}
 }
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// CreateAPIKeyUseCase input port
+	CreateAPIKeyUseCase interface {
+		Execute(context.Context, CreateAPIKeyInput) (CreateAPIKeyOutput, error)
+	}
+
+	// CreateAPIKeyInput input data
+	CreateAPIKeyInput struct {
+		Name      string     `json:"name" validate:"required,max=100"`
+		Scopes    []string   `json:"scopes" validate:"required,min=1,dive,oneof=accounts:read accounts:write transfers:read transfers:write api_keys:admin webhooks:admin audit:read"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+
+	// CreateAPIKeyPresenter output port
+	CreateAPIKeyPresenter interface {
+		Output(domain.APIKey, string) CreateAPIKeyOutput
+	}
+
+	// CreateAPIKeyOutput output data, the key is only ever shown here
+	CreateAPIKeyOutput struct {
+		ID        string   `json:"id"`
+		Name      string   `json:"name"`
+		Key       string   `json:"key"`
+		Prefix    string   `json:"prefix"`
+		Scopes    []string `json:"scopes"`
+		ExpiresAt string   `json:"expires_at,omitempty"`
+		CreatedAt string   `json:"created_at"`
+	}
+
+	createAPIKeyInteractor struct {
+		repo       domain.APIKeyRepository
+		presenter  CreateAPIKeyPresenter
+		ctxTimeout time.Duration
+	}
+)
+
+// NewCreateAPIKeyInteractor creates new createAPIKeyInteractor with its dependencies
+func NewCreateAPIKeyInteractor(
+	repo domain.APIKeyRepository,
+	presenter CreateAPIKeyPresenter,
+	t time.Duration,
+) CreateAPIKeyUseCase {
+	return createAPIKeyInteractor{
+		repo:       repo,
+		presenter:  presenter,
+		ctxTimeout: t,
+	}
+}
+
+// Execute orchestrates the use case
+func (a createAPIKeyInteractor) Execute(ctx context.Context, input CreateAPIKeyInput) (CreateAPIKeyOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	principal, _, err := Authorize(ctx, OpCreateAPIKey)
+	if err != nil {
+		return a.presenter.Output(domain.APIKey{}, ""), err
+	}
+
+	var scopes = make([]domain.Scope, 0, len(input.Scopes))
+	for _, s := range input.Scopes {
+		scopes = append(scopes, domain.Scope(s))
+	}
+
+	if err = authorizeScopes(principal, scopes); err != nil {
+		return a.presenter.Output(domain.APIKey{}, ""), err
+	}
+
+	var expiresAt time.Time
+	if input.ExpiresAt != nil {
+		expiresAt = *input.ExpiresAt
+	}
+
+	apiKey, key, err := issueAPIKey(ctx, a.repo, "", input.Name, scopes, expiresAt)
+	if err != nil {
+		return a.presenter.Output(domain.APIKey{}, ""), err
+	}
+
+	return a.presenter.Output(apiKey, key), nil
+}
+
+// authorizeScopes keeps a client from issuing a key with more scopes than its own, which would escalate its access
+func authorizeScopes(principal domain.Principal, scopes []domain.Scope) error {
+	if principal.IsClient() && !principal.HoldsScopes(scopes) {
+		return domain.ErrScopeNotHeld
+	}
+
+	return nil
+}
+
+// issueAPIKey creates a key for the client, or for a new client named after the key when the client ID is empty
+func issueAPIKey(
+	ctx context.Context,
+	repo domain.APIKeyRepository,
+	clientID string,
+	name string,
+	scopes []domain.Scope,
+	expiresAt time.Time,
+) (domain.APIKey, string, error) {
+	key, prefix, err := domain.GenerateAPIKey()
+	if err != nil {
+		return domain.APIKey{}, "", err
+	}
+
+	var ID = domain.APIKeyID(domain.NewUUID())
+	if clientID == "" {
+		clientID = ID.String()
+	}
+
+	var apiKey = domain.NewAPIKey(
+		ID,
+		clientID,
+		name,
+		prefix,
+		domain.HashAPIKey(key),
+		scopes,
+		expiresAt,
+		time.Time{},
+		time.Time{},
+		time.Now(),
+	)
+
+	apiKey, err = repo.Create(ctx, apiKey)
+	if err != nil {
+		return domain.APIKey{}, "", err
+	}
+
+	return apiKey, key, nil
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type mockAPIKeyRepoStore struct {
+	domain.APIKeyRepository
+
+	err     error
+	created *domain.APIKey
+}
+
+func (m mockAPIKeyRepoStore) Create(_ context.Context, apiKey domain.APIKey) (domain.APIKey, error) {
+	if m.created != nil {
+		*m.created = apiKey
+	}
+
+	return apiKey, m.err
+}
+
+type mockCreateAPIKeyPresenter struct {
+	result CreateAPIKeyOutput
+}
+
+func (m mockCreateAPIKeyPresenter) Output(_ domain.APIKey, _ string) CreateAPIKeyOutput {
+	return m.result
+}
+
+func TestCreateAPIKeyInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	var (
+		expiresAt = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		clientCtx = domain.ContextWithPrincipal(
+			context.Background(),
+			domain.NewClientPrincipal(
+				"3c096a40-ccba-4b58-93ed-57379ab04680",
+				domain.ScopeAPIKeysAdmin,
+				domain.ScopeAccountsRead,
+			),
+		)
+	)
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		input          CreateAPIKeyInput
+		repository     mockAPIKeyRepoStore
+		presenter      CreateAPIKeyPresenter
+		expected       CreateAPIKeyOutput
+		expectedScopes []domain.Scope
+		expectedError  string
+	}{
+		{
+			name: "Create api key successful",
+			ctx:  roleContext(domain.RoleAdmin),
+			input: CreateAPIKeyInput{
+				Name:      "erp",
+				Scopes:    []string{"accounts:read", "transfers:write"},
+				ExpiresAt: &expiresAt,
+			},
+			repository: mockAPIKeyRepoStore{created: &domain.APIKey{}},
+			presenter: mockCreateAPIKeyPresenter{
+				result: CreateAPIKeyOutput{Name: "erp"},
+			},
+			expected:       CreateAPIKeyOutput{Name: "erp"},
+			expectedScopes: []domain.Scope{domain.ScopeAccountsRead, domain.ScopeTransfersWrite},
+		},
+		{
+			name: "Create api key generic error",
+			ctx:  roleContext(domain.RoleAdmin),
+			input: CreateAPIKeyInput{
+				Name:   "erp",
+				Scopes: []string{"accounts:read"},
+			},
+			repository: mockAPIKeyRepoStore{err: errors.New("error")},
+			presenter: mockCreateAPIKeyPresenter{
+				result: CreateAPIKeyOutput{},
+			},
+			expected:      CreateAPIKeyOutput{},
+			expectedError: "error",
+		},
+		{
+			name: "Create api key forbidden for customers",
+			ctx:  roleContext(domain.RoleCustomer),
+			input: CreateAPIKeyInput{
+				Name:   "erp",
+				Scopes: []string{"accounts:read"},
+			},
+			repository: mockAPIKeyRepoStore{},
+			presenter: mockCreateAPIKeyPresenter{
+				result: CreateAPIKeyOutput{},
+			},
+			expected:      CreateAPIKeyOutput{},
+			expectedError: "caller is not allowed to access this resource",
+		},
+		{
+			name: "Create api key with scopes held by the client",
+			ctx:  clientCtx,
+			input: CreateAPIKeyInput{
+				Name:      "erp",
+				Scopes:    []string{"accounts:read"},
+				ExpiresAt: &expiresAt,
+			},
+			repository: mockAPIKeyRepoStore{created: &domain.APIKey{}},
+			presenter: mockCreateAPIKeyPresenter{
+				result: CreateAPIKeyOutput{Name: "erp"},
+			},
+			expected:       CreateAPIKeyOutput{Name: "erp"},
+			expectedScopes: []domain.Scope{domain.ScopeAccountsRead},
+		},
+		{
+			name: "Create api key with a scope the client does not hold",
+			ctx:  clientCtx,
+			input: CreateAPIKeyInput{
+				Name:   "erp",
+				Scopes: []string{"accounts:read", "transfers:write"},
+			},
+			repository: mockAPIKeyRepoStore{},
+			presenter: mockCreateAPIKeyPresenter{
+				result: CreateAPIKeyOutput{},
+			},
+			expected:      CreateAPIKeyOutput{},
+			expectedError: "caller cannot grant a scope it does not hold",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uc = NewCreateAPIKeyInteractor(tt.repository, tt.presenter, time.Second)
+
+			result, err := uc.Execute(tt.ctx, tt.input)
+			if (err != nil || tt.expectedError != "") && (err == nil || err.Error() != tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+			}
+
+			if tt.repository.created == nil {
+				return
+			}
+
+			var created = *tt.repository.created
+			if !reflect.DeepEqual(created.Scopes(), tt.expectedScopes) {
+				t.Errorf("[TestCase '%s'] Scopes: '%v' | Expected: '%v'", tt.name, created.Scopes(), tt.expectedScopes)
+			}
+
+			if !created.ExpiresAt().Equal(expiresAt) {
+				t.Errorf("[TestCase '%s'] ExpiresAt: '%v' | Expected: '%v'", tt.name, created.ExpiresAt(), expiresAt)
+			}
+
+			if created.Hash() == "" || created.Prefix() == "" {
+				t.Errorf("[TestCase '%s'] expected hash and prefix to be generated", tt.name)
+			}
+		})
+	}
+}
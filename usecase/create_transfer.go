@@ -8,9 +8,15 @@ import (
 )
 
 type (
-	// CreateTransferUseCase input port
-	CreateTransferUseCase interface {
-		Execute(context.Context, CreateTransferInput) (CreateTransferOutput, error)
+	// CreateTransferUseCase input port of /v1
+	CreateTransferUseCase = CreateTransferUseCaseOf[CreateTransferOutput]
+
+	// CreateTransferUseCaseV2 input port of /v2
+	CreateTransferUseCaseV2 = CreateTransferUseCaseOf[TransferOutputV2]
+
+	// CreateTransferUseCaseOf input port, answering with the output of its presenter
+	CreateTransferUseCaseOf[O any] interface {
+		Execute(context.Context, CreateTransferInput) (O, error)
 	}
 
 	// CreateTransferInput input data
@@ -20,9 +26,15 @@ type (
 		Amount               int64  `json:"amount" validate:"gt=0,required"`
 	}
 
-	// CreateTransferPresenter output port
-	CreateTransferPresenter interface {
-		Output(domain.Transfer) CreateTransferOutput
+	// CreateTransferPresenter output port of /v1
+	CreateTransferPresenter = CreateTransferPresenterOf[CreateTransferOutput]
+
+	// CreateTransferPresenterV2 output port of /v2
+	CreateTransferPresenterV2 = CreateTransferPresenterOf[TransferOutputV2]
+
+	// CreateTransferPresenterOf output port, shared by the use cases answering with a transfer
+	CreateTransferPresenterOf[O any] interface {
+		Output(domain.Transfer) O
 	}
 
 	// CreateTransferOutput output data
@@ -31,103 +43,304 @@ type (
 		AccountOriginID      string  `json:"account_origin_id"`
 		AccountDestinationID string  `json:"account_destination_id"`
 		Amount               float64 `json:"amount"`
+		Status               string  `json:"status"`
 		CreatedAt            string  `json:"created_at"`
 	}
 
-	createTransferInteractor struct {
-		transferRepo domain.TransferRepository
-		accountRepo  domain.AccountRepository
-		presenter    CreateTransferPresenter
-		ctxTimeout   time.Duration
+	// TransferOutputV2 output data of /v2, with the amount as a decimal string
+	TransferOutputV2 struct {
+		ID                   string `json:"id"`
+		AccountOriginID      string `json:"account_origin_id"`
+		AccountDestinationID string `json:"account_destination_id"`
+		Amount               string `json:"amount"`
+		Currency             string `json:"currency"`
+		Status               string `json:"status"`
+		FailureCode          string `json:"failure_code,omitempty"`
+		CreatedAt            string `json:"created_at"`
+	}
+
+	// TransferApprovalConfig sets which transfers need a second user and how long they wait for one.
+	// A zero threshold disables the approval and a zero timeout never expires pending transfers
+	TransferApprovalConfig struct {
+		Threshold domain.Money
+		Timeout   time.Duration
+	}
+
+	createTransferInteractor[O any] struct {
+		transferRepo   domain.TransferRepository
+		accountRepo    domain.AccountRepository
+		approvalRepo   domain.TransferApprovalRepository
+		riskEngine     domain.RiskEngine
+		evaluationRepo domain.RiskEvaluationRepository
+		approval       TransferApprovalConfig
+		tariff         TariffConfig
+		outboxRepo     domain.OutboxRepository
+		presenter      CreateTransferPresenterOf[O]
+		ctxTimeout     time.Duration
 	}
 )
 
 // NewCreateTransferInteractor creates new createTransferInteractor with its dependencies
-func NewCreateTransferInteractor(
+func NewCreateTransferInteractor[O any](
 	transferRepo domain.TransferRepository,
 	accountRepo domain.AccountRepository,
-	presenter CreateTransferPresenter,
+	approvalRepo domain.TransferApprovalRepository,
+	riskEngine domain.RiskEngine,
+	evaluationRepo domain.RiskEvaluationRepository,
+	approval TransferApprovalConfig,
+	tariff TariffConfig,
+	outboxRepo domain.OutboxRepository,
+	presenter CreateTransferPresenterOf[O],
 	t time.Duration,
-) CreateTransferUseCase {
-	return createTransferInteractor{
-		transferRepo: transferRepo,
-		accountRepo:  accountRepo,
-		presenter:    presenter,
-		ctxTimeout:   t,
+) CreateTransferUseCaseOf[O] {
+	return createTransferInteractor[O]{
+		transferRepo:   transferRepo,
+		accountRepo:    accountRepo,
+		approvalRepo:   approvalRepo,
+		riskEngine:     riskEngine,
+		evaluationRepo: evaluationRepo,
+		approval:       approval,
+		tariff:         tariff,
+		outboxRepo:     outboxRepo,
+		presenter:      presenter,
+		ctxTimeout:     t,
 	}
 }
 
+// RequiresApproval reports whether the amount is over the approval threshold
+func (c TransferApprovalConfig) RequiresApproval(amount domain.Money) bool {
+	return c.Threshold > 0 && amount > c.Threshold
+}
+
+// Expired reports whether a transfer created at createdAt waited longer than the timeout at now
+func (c TransferApprovalConfig) Expired(createdAt, now time.Time) bool {
+	return c.Timeout > 0 && now.Sub(createdAt) > c.Timeout
+}
+
 // Execute orchestrates the use case
-func (t createTransferInteractor) Execute(ctx context.Context, input CreateTransferInput) (CreateTransferOutput, error) {
+func (t createTransferInteractor[O]) Execute(ctx context.Context, input CreateTransferInput) (O, error) {
 	ctx, cancel := context.WithTimeout(ctx, t.ctxTimeout)
 	defer cancel()
 
+	principal, access, err := Authorize(ctx, OpCreateTransfer)
+	if err != nil {
+		return t.presenter.Output(domain.Transfer{}), err
+	}
+
 	var (
 		transfer domain.Transfer
-		err      error
+		decision domain.RiskDecision
 	)
 
 	err = t.transferRepo.WithTransaction(ctx, func(ctxTx context.Context) error {
-		if err = t.process(ctxTx, input); err != nil {
-			return err
-		}
-
-		transfer = domain.NewTransfer(
-			domain.TransferID(domain.NewUUID()),
-			domain.AccountID(input.AccountOriginID),
-			domain.AccountID(input.AccountDestinationID),
-			domain.Money(input.Amount),
-			time.Now(),
-		)
-
-		transfer, err = t.transferRepo.Create(ctxTx, transfer)
-		if err != nil {
-			return err
-		}
-
-		return nil
+		transfer, decision, err = t.process(ctxTx, principal, access, input)
+		return err
 	})
 	if err != nil {
+		if code, ok := domain.TransferFailureCodeOf(err); ok {
+			if errFail := t.recordFailure(ctx, input, code); errFail != nil {
+				return t.presenter.Output(domain.Transfer{}), errFail
+			}
+		}
+
 		return t.presenter.Output(domain.Transfer{}), err
 	}
 
+	// Denied transfers are only reported once the transaction committed, so their evaluation is kept
+	if decision == domain.RiskDeny {
+		return t.presenter.Output(domain.Transfer{}), domain.ErrTransferDenied
+	}
+
 	return t.presenter.Output(transfer), nil
 }
 
-func (t createTransferInteractor) process(ctx context.Context, input CreateTransferInput) error {
+func (t createTransferInteractor[O]) process(
+	ctx context.Context,
+	principal domain.Principal,
+	access Access,
+	input CreateTransferInput,
+) (domain.Transfer, domain.RiskDecision, error) {
 	origin, err := t.accountRepo.FindByID(ctx, domain.AccountID(input.AccountOriginID))
 	if err != nil {
 		switch err {
 		case domain.ErrAccountNotFound:
-			return domain.ErrAccountOriginNotFound
+			return domain.Transfer{}, "", domain.ErrAccountOriginNotFound
 		default:
-			return err
+			return domain.Transfer{}, "", err
 		}
 	}
 
+	if !access.Allows(principal, origin) {
+		return domain.Transfer{}, "", domain.ErrForbidden
+	}
+
 	if err := origin.Withdraw(domain.Money(input.Amount)); err != nil {
-		return err
+		return domain.Transfer{}, "", err
 	}
 
 	destination, err := t.accountRepo.FindByID(ctx, domain.AccountID(input.AccountDestinationID))
 	if err != nil {
 		switch err {
 		case domain.ErrAccountNotFound:
-			return domain.ErrAccountDestinationNotFound
+			return domain.Transfer{}, "", domain.ErrAccountDestinationNotFound
 		default:
-			return err
+			return domain.Transfer{}, "", err
 		}
 	}
 
-	destination.Deposit(domain.Money(input.Amount))
+	var transfer = domain.NewTransfer(
+		domain.TransferID(domain.NewUUID()),
+		origin.ID(),
+		destination.ID(),
+		domain.Money(input.Amount),
+		domain.TransferStatusPending,
+		time.Now(),
+	)
 
-	if err = t.accountRepo.UpdateBalance(ctx, origin.ID(), origin.Balance()); err != nil {
-		return err
+	evaluation, err := t.evaluate(ctx, transfer, origin, destination)
+	if err != nil {
+		return domain.Transfer{}, "", err
+	}
+
+	switch {
+	case evaluation.Decision() == domain.RiskDeny:
+		// The failed transfer is kept with the evaluation, both committed before the denial is reported
+		transfer, err = transfer.Fail(domain.TransferFailureRiskDenied)
+		if err != nil {
+			return domain.Transfer{}, "", err
+		}
+
+		if _, err = t.transferRepo.Create(ctx, transfer); err != nil {
+			return domain.Transfer{}, "", err
+		}
+
+		if err = t.outboxRepo.Create(ctx, domain.NewTransferFailedEvent(transfer)); err != nil {
+			return domain.Transfer{}, "", err
+		}
+
+		return domain.Transfer{}, domain.RiskDeny, nil
+	case evaluation.Decision() == domain.RiskReview:
+		transfer, err = transfer.TransitionTo(domain.TransferStatusHeld)
+	case t.approval.RequiresApproval(transfer.Amount()):
+		transfer, err = transfer.TransitionTo(domain.TransferStatusPendingApproval)
+	default:
+		if err = destination.Deposit(domain.Money(input.Amount)); err != nil {
+			return domain.Transfer{}, "", err
+		}
+
+		if err = updateBalances(ctx, t.accountRepo, origin, destination); err != nil {
+			return domain.Transfer{}, "", err
+		}
+
+		transfer, err = transfer.TransitionTo(domain.TransferStatusCompleted)
+	}
+	if err != nil {
+		return domain.Transfer{}, "", err
+	}
+
+	transfer, err = t.transferRepo.Create(ctx, transfer)
+	if err != nil {
+		return domain.Transfer{}, "", err
+	}
+
+	if transfer.Status() == domain.TransferStatusCompleted {
+		if err = t.outboxRepo.Create(ctx, domain.NewTransferCompletedEvent(transfer, origin.Balance())); err != nil {
+			return domain.Transfer{}, "", err
+		}
+
+		if err = chargeFee(ctx, t.tariff, t.accountRepo, t.transferRepo, t.outboxRepo, transfer); err != nil {
+			return domain.Transfer{}, "", err
+		}
+	}
+
+	if transfer.AwaitingDecision() {
+		if err = recordApproval(ctx, t.approvalRepo, transfer, domain.ApprovalRequested, principal, ""); err != nil {
+			return domain.Transfer{}, "", err
+		}
 	}
 
-	if err = t.accountRepo.UpdateBalance(ctx, destination.ID(), destination.Balance()); err != nil {
+	return transfer, evaluation.Decision(), nil
+}
+
+// recordFailure keeps a transfer attempt that could not be executed. It runs in its own transaction, as the one of the
+// attempt was rolled back
+func (t createTransferInteractor[O]) recordFailure(
+	ctx context.Context,
+	input CreateTransferInput,
+	code domain.TransferFailureCode,
+) error {
+	var transfer = domain.NewFailedTransfer(
+		domain.TransferID(domain.NewUUID()),
+		domain.AccountID(input.AccountOriginID),
+		domain.AccountID(input.AccountDestinationID),
+		domain.Money(input.Amount),
+		code,
+		time.Now(),
+	)
+
+	return t.transferRepo.WithTransaction(ctx, func(ctxTx context.Context) error {
+		if _, err := t.transferRepo.Create(ctxTx, transfer); err != nil {
+			return err
+		}
+
+		return t.outboxRepo.Create(ctxTx, domain.NewTransferFailedEvent(transfer))
+	})
+}
+
+// updateBalances stores the balances of both accounts of a transfer, which must have been read inside the transaction
+func updateBalances(ctx context.Context, repo domain.AccountRepository, origin, destination domain.Account) error {
+	if err := repo.UpdateBalance(ctx, origin.ID(), origin.Balance()); err != nil {
 		return err
 	}
 
-	return nil
+	return repo.UpdateBalance(ctx, destination.ID(), destination.Balance())
+}
+
+// recordApproval appends a step taken by the principal to the approval history of the transfer
+func recordApproval(
+	ctx context.Context,
+	repo domain.TransferApprovalRepository,
+	transfer domain.Transfer,
+	action domain.ApprovalAction,
+	principal domain.Principal,
+	reason string,
+) error {
+	_, err := repo.Create(ctx, domain.NewTransferApproval(
+		domain.TransferApprovalID(domain.NewUUID()),
+		transfer.ID(),
+		action,
+		principal.Subject(),
+		principal.Role(),
+		reason,
+		time.Now(),
+	))
+
+	return err
+}
+
+// evaluate runs the risk rules against the transfer and stores the evaluation, whatever its decision
+func (t createTransferInteractor[O]) evaluate(
+	ctx context.Context,
+	transfer domain.Transfer,
+	origin domain.Account,
+	destination domain.Account,
+) (domain.RiskEvaluation, error) {
+	results, err := t.riskEngine.Evaluate(ctx, domain.RiskSubject{
+		Transfer:    transfer,
+		Origin:      origin,
+		Destination: destination,
+		History:     t.transferRepo,
+	})
+	if err != nil {
+		return domain.RiskEvaluation{}, err
+	}
+
+	var evaluation = domain.NewRiskEvaluation(
+		domain.RiskEvaluationID(domain.NewUUID()),
+		transfer,
+		results,
+		time.Now(),
+	)
+
+	return t.evaluationRepo.Create(ctx, evaluation)
 }
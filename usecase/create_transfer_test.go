@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -21,6 +22,10 @@ func (m mockTransferRepoStore) Create(_ context.Context, _ domain.Transfer) (dom
 	return m.result, m.err
 }
 
+func (m mockTransferRepoStore) Stream(_ context.Context, _ domain.TransferFilter, _ func(domain.Transfer) error) error {
+	return nil
+}
+
 func (m mockTransferRepoStore) WithTransaction(_ context.Context, fn func(context.Context) error) error {
 	if err := fn(context.Background()); err != nil {
 		return err
@@ -29,6 +34,27 @@ func (m mockTransferRepoStore) WithTransaction(_ context.Context, fn func(contex
 	return nil
 }
 
+// mockTransferRepoStoreEcho stores the transfer as given, so its status reaches the presenter
+type mockTransferRepoStoreEcho struct {
+	mockTransferRepoStore
+}
+
+func (m mockTransferRepoStoreEcho) Create(_ context.Context, transfer domain.Transfer) (domain.Transfer, error) {
+	return transfer, nil
+}
+
+// mockTransferRepoRecorder keeps every transfer created, including the failed attempts stored after a rollback
+type mockTransferRepoRecorder struct {
+	mockTransferRepoStore
+
+	created *[]domain.Transfer
+}
+
+func (m mockTransferRepoRecorder) Create(_ context.Context, transfer domain.Transfer) (domain.Transfer, error) {
+	*m.created = append(*m.created, transfer)
+	return transfer, nil
+}
+
 type invoked struct {
 	call bool
 }
@@ -67,6 +93,53 @@ func (m mockAccountRepo) FindByID(_ context.Context, _ domain.AccountID) (domain
 	return m.findByIDOriginFake()
 }
 
+type mockRiskEngine struct {
+	results []domain.RuleResult
+	err     error
+}
+
+func (m mockRiskEngine) Evaluate(_ context.Context, _ domain.RiskSubject) ([]domain.RuleResult, error) {
+	return m.results, m.err
+}
+
+type mockRiskEvaluationRepo struct {
+	stored *domain.RiskEvaluation
+}
+
+func (m mockRiskEvaluationRepo) Create(_ context.Context, evaluation domain.RiskEvaluation) (domain.RiskEvaluation, error) {
+	if m.stored != nil {
+		*m.stored = evaluation
+	}
+
+	return evaluation, nil
+}
+
+type mockTransferApprovalRepo struct {
+	domain.TransferApprovalRepository
+
+	history []domain.TransferApproval
+	stored  *[]domain.TransferApproval
+	err     error
+}
+
+func (m mockTransferApprovalRepo) Create(_ context.Context, approval domain.TransferApproval) (domain.TransferApproval, error) {
+	if m.stored != nil {
+		*m.stored = append(*m.stored, approval)
+	}
+
+	return approval, m.err
+}
+
+func (m mockTransferApprovalRepo) FindAllByTransfer(_ context.Context, _ domain.TransferID) ([]domain.TransferApproval, error) {
+	return m.history, m.err
+}
+
+type mockCreateTransferStatusPresenter struct{}
+
+func (m mockCreateTransferStatusPresenter) Output(transfer domain.Transfer) CreateTransferOutput {
+	return CreateTransferOutput{Status: transfer.Status().String()}
+}
+
 type mockCreateTransferPresenter struct {
 	result CreateTransferOutput
 }
@@ -84,15 +157,19 @@ func TestTransferCreateInteractor_Execute(t *testing.T) {
 
 	tests := []struct {
 		name          string
+		ctx           context.Context
 		args          args
 		transferRepo  domain.TransferRepository
 		accountRepo   domain.AccountRepository
+		riskEngine    domain.RiskEngine
+		approval      TransferApprovalConfig
 		presenter     CreateTransferPresenter
 		expected      CreateTransferOutput
 		expectedError string
 	}{
 		{
 			name: "Create transfer successful",
+			ctx:  customerContext("08098565895"),
 			args: args{input: CreateTransferInput{
 				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04681",
 				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682",
@@ -104,6 +181,7 @@ func TestTransferCreateInteractor_Execute(t *testing.T) {
 					"3c096a40-ccba-4b58-93ed-57379ab04681",
 					"3c096a40-ccba-4b58-93ed-57379ab04682",
 					2999,
+					domain.TransferStatusCompleted,
 					time.Time{},
 				),
 				err: nil,
@@ -153,6 +231,7 @@ func TestTransferCreateInteractor_Execute(t *testing.T) {
 		},
 		{
 			name: "Create transfer generic error transfer gateway",
+			ctx:  customerContext("08098565895"),
 			args: args{input: CreateTransferInput{
 				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
 				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04681",
@@ -197,6 +276,7 @@ func TestTransferCreateInteractor_Execute(t *testing.T) {
 		},
 		{
 			name: "Create transfer error find origin account",
+			ctx:  customerContext("08098565895"),
 			args: args{input: CreateTransferInput{
 				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
 				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04681",
@@ -225,6 +305,7 @@ func TestTransferCreateInteractor_Execute(t *testing.T) {
 		},
 		{
 			name: "Create transfer error not found find origin account",
+			ctx:  customerContext("08098565895"),
 			args: args{input: CreateTransferInput{
 				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
 				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04681",
@@ -253,6 +334,7 @@ func TestTransferCreateInteractor_Execute(t *testing.T) {
 		},
 		{
 			name: "Create transfer error find destination account",
+			ctx:  customerContext("08098565895"),
 			args: args{input: CreateTransferInput{
 				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
 				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04681",
@@ -291,6 +373,7 @@ func TestTransferCreateInteractor_Execute(t *testing.T) {
 		},
 		{
 			name: "Create transfer error not found find destination account",
+			ctx:  customerContext("08098565895"),
 			args: args{input: CreateTransferInput{
 				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
 				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04681",
@@ -329,6 +412,7 @@ func TestTransferCreateInteractor_Execute(t *testing.T) {
 		},
 		{
 			name: "Create transfer error update origin account",
+			ctx:  customerContext("08098565895"),
 			args: args{input: CreateTransferInput{
 				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
 				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04681",
@@ -372,6 +456,7 @@ func TestTransferCreateInteractor_Execute(t *testing.T) {
 		},
 		{
 			name: "Create transfer error update destination account",
+			ctx:  customerContext("08098565895"),
 			args: args{input: CreateTransferInput{
 				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
 				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04681",
@@ -416,6 +501,7 @@ func TestTransferCreateInteractor_Execute(t *testing.T) {
 		},
 		{
 			name: "Create transfer amount not have sufficient",
+			ctx:  customerContext("08098565895"),
 			args: args{input: CreateTransferInput{
 				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
 				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04681",
@@ -458,13 +544,269 @@ func TestTransferCreateInteractor_Execute(t *testing.T) {
 			expectedError: "origin account does not have sufficient balance",
 			expected:      CreateTransferOutput{},
 		},
+		{
+			name: "Create transfer overflows destination balance",
+			ctx:  customerContext("08098565895"),
+			args: args{input: CreateTransferInput{
+				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
+				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04681",
+				Amount:               200,
+			}},
+			transferRepo: mockTransferRepoStore{
+				result: domain.Transfer{},
+				err:    nil,
+			},
+			accountRepo: mockAccountRepo{
+				findByIDOriginFake: func() (domain.Account, error) {
+					return domain.NewAccount(
+						"3c096a40-ccba-4b58-93ed-57379ab04681",
+						"Test",
+						"08098565895",
+						5000,
+						time.Time{},
+					), nil
+				},
+				findByIDDestinationFake: func() (domain.Account, error) {
+					return domain.NewAccount(
+						"3c096a40-ccba-4b58-93ed-57379ab04682",
+						"Test2",
+						"13098565491",
+						math.MaxInt64,
+						time.Time{},
+					), nil
+				},
+				invokedFind: &invoked{},
+			},
+			presenter: mockCreateTransferPresenter{
+				result: CreateTransferOutput{},
+			},
+			expectedError: "money amount is too large",
+			expected:      CreateTransferOutput{},
+		},
+		{
+			name: "Create transfer from account of another holder forbidden",
+			ctx:  customerContext("13098565491"),
+			args: args{input: CreateTransferInput{
+				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04681",
+				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682",
+				Amount:               100,
+			}},
+			transferRepo: mockTransferRepoStore{
+				result: domain.Transfer{},
+				err:    nil,
+			},
+			accountRepo: mockAccountRepo{
+				updateBalanceOriginFake: func() error {
+					return nil
+				},
+				updateBalanceDestinationFake: func() error {
+					return nil
+				},
+				findByIDOriginFake: func() (domain.Account, error) {
+					return domain.NewAccount(
+						"3c096a40-ccba-4b58-93ed-57379ab04681",
+						"Test",
+						"08098565895",
+						5000,
+						time.Time{},
+					), nil
+				},
+			},
+			presenter: mockCreateTransferPresenter{
+				result: CreateTransferOutput{},
+			},
+			expectedError: "caller is not allowed to access this resource",
+			expected:      CreateTransferOutput{},
+		},
+		{
+			name: "Create transfer by support forbidden",
+			ctx:  roleContext(domain.RoleSupport),
+			args: args{input: CreateTransferInput{
+				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04681",
+				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682",
+				Amount:               100,
+			}},
+			transferRepo: mockTransferRepoStore{
+				result: domain.Transfer{},
+				err:    nil,
+			},
+			accountRepo: mockAccountRepo{},
+			presenter: mockCreateTransferPresenter{
+				result: CreateTransferOutput{},
+			},
+			expectedError: "caller is not allowed to access this resource",
+			expected:      CreateTransferOutput{},
+		},
+		{
+			name: "Create transfer without principal forbidden",
+			ctx:  context.Background(),
+			args: args{input: CreateTransferInput{
+				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04681",
+				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682",
+				Amount:               100,
+			}},
+			transferRepo: mockTransferRepoStore{
+				result: domain.Transfer{},
+				err:    nil,
+			},
+			accountRepo: mockAccountRepo{},
+			presenter: mockCreateTransferPresenter{
+				result: CreateTransferOutput{},
+			},
+			expectedError: "caller is not allowed to access this resource",
+			expected:      CreateTransferOutput{},
+		},
+		{
+			name: "Create transfer held for review without moving money",
+			ctx:  customerContext("08098565895"),
+			args: args{input: CreateTransferInput{
+				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04681",
+				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682",
+				Amount:               2999,
+			}},
+			transferRepo: mockTransferRepoStoreEcho{},
+			accountRepo: mockAccountRepo{
+				updateBalanceOriginFake: func() error {
+					return errors.New("balance should not be updated")
+				},
+				updateBalanceDestinationFake: func() error {
+					return errors.New("balance should not be updated")
+				},
+				findByIDOriginFake: func() (domain.Account, error) {
+					return domain.NewAccount(
+						"3c096a40-ccba-4b58-93ed-57379ab04681",
+						"Test",
+						"08098565895",
+						5000,
+						time.Time{},
+					), nil
+				},
+			},
+			riskEngine: mockRiskEngine{
+				results: []domain.RuleResult{
+					domain.NewRuleResult("amount_above_history", domain.RiskReview, "amount above history"),
+				},
+			},
+			presenter: mockCreateTransferStatusPresenter{},
+			expected:  CreateTransferOutput{Status: "held"},
+		},
+		{
+			name: "Create transfer over the approval threshold pending without moving money",
+			ctx:  customerContext("08098565895"),
+			args: args{input: CreateTransferInput{
+				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04681",
+				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682",
+				Amount:               2999,
+			}},
+			transferRepo: mockTransferRepoStoreEcho{},
+			accountRepo: mockAccountRepo{
+				updateBalanceOriginFake: func() error {
+					return errors.New("balance should not be updated")
+				},
+				updateBalanceDestinationFake: func() error {
+					return errors.New("balance should not be updated")
+				},
+				findByIDOriginFake: func() (domain.Account, error) {
+					return domain.NewAccount(
+						"3c096a40-ccba-4b58-93ed-57379ab04681",
+						"Test",
+						"08098565895",
+						5000,
+						time.Time{},
+					), nil
+				},
+			},
+			approval:  TransferApprovalConfig{Threshold: 1000},
+			presenter: mockCreateTransferStatusPresenter{},
+			expected:  CreateTransferOutput{Status: "pending_approval"},
+		},
+		{
+			name: "Create transfer denied by risk rules",
+			ctx:  customerContext("08098565895"),
+			args: args{input: CreateTransferInput{
+				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04681",
+				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682",
+				Amount:               2999,
+			}},
+			transferRepo: mockTransferRepoStoreEcho{},
+			accountRepo: mockAccountRepo{
+				updateBalanceOriginFake: func() error {
+					return errors.New("balance should not be updated")
+				},
+				findByIDOriginFake: func() (domain.Account, error) {
+					return domain.NewAccount(
+						"3c096a40-ccba-4b58-93ed-57379ab04681",
+						"Test",
+						"08098565895",
+						5000,
+						time.Time{},
+					), nil
+				},
+			},
+			riskEngine: mockRiskEngine{
+				results: []domain.RuleResult{
+					domain.NewRuleResult("amount_above_history", domain.RiskReview, "amount above history"),
+					domain.NewRuleResult("new_account_large_amount", domain.RiskDeny, "new account"),
+				},
+			},
+			presenter: mockCreateTransferPresenter{
+				result: CreateTransferOutput{},
+			},
+			expectedError: "transfer denied by risk rules",
+			expected:      CreateTransferOutput{},
+		},
+		{
+			name: "Create transfer risk engine error",
+			ctx:  customerContext("08098565895"),
+			args: args{input: CreateTransferInput{
+				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04681",
+				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682",
+				Amount:               2999,
+			}},
+			transferRepo: mockTransferRepoStore{},
+			accountRepo: mockAccountRepo{
+				findByIDOriginFake: func() (domain.Account, error) {
+					return domain.NewAccount(
+						"3c096a40-ccba-4b58-93ed-57379ab04681",
+						"Test",
+						"08098565895",
+						5000,
+						time.Time{},
+					), nil
+				},
+			},
+			riskEngine: mockRiskEngine{
+				err: errors.New("error"),
+			},
+			presenter: mockCreateTransferPresenter{
+				result: CreateTransferOutput{},
+			},
+			expectedError: "error",
+			expected:      CreateTransferOutput{},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var uc = NewCreateTransferInteractor(tt.transferRepo, tt.accountRepo, tt.presenter, time.Second)
+			var riskEngine domain.RiskEngine = mockRiskEngine{}
+			if tt.riskEngine != nil {
+				riskEngine = tt.riskEngine
+			}
 
-			got, err := uc.Execute(context.Background(), tt.args.input)
+			var uc = NewCreateTransferInteractor(
+				tt.transferRepo,
+				tt.accountRepo,
+				mockTransferApprovalRepo{},
+				riskEngine,
+				mockRiskEvaluationRepo{},
+				tt.approval,
+				TariffConfig{},
+				mockOutboxRepo{},
+				tt.presenter,
+				time.Second,
+			)
+
+			got, err := uc.Execute(tt.ctx, tt.args.input)
 			if (err != nil) && (err.Error() != tt.expectedError) {
 				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
 				return
@@ -476,3 +818,121 @@ func TestTransferCreateInteractor_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestTransferCreateInteractor_ExecuteRecordsFailures(t *testing.T) {
+	t.Parallel()
+
+	var account = func(ID domain.AccountID, CPF string, balance domain.Money) func() (domain.Account, error) {
+		return func() (domain.Account, error) {
+			return domain.NewAccount(ID, "Test", CPF, balance, time.Time{}), nil
+		}
+	}
+
+	tests := []struct {
+		name          string
+		accountRepo   domain.AccountRepository
+		riskEngine    domain.RiskEngine
+		expectedCode  domain.TransferFailureCode
+		expectedError string
+	}{
+		{
+			name: "Failed transfer recorded for insufficient balance",
+			accountRepo: mockAccountRepo{
+				findByIDOriginFake: account("3c096a40-ccba-4b58-93ed-57379ab04681", "08098565895", 100),
+			},
+			riskEngine:    mockRiskEngine{},
+			expectedCode:  domain.TransferFailureInsufficientBalance,
+			expectedError: "origin account does not have sufficient balance",
+		},
+		{
+			name: "Failed transfer recorded for origin account not found",
+			accountRepo: mockAccountRepo{
+				findByIDOriginFake: func() (domain.Account, error) {
+					return domain.Account{}, domain.ErrAccountNotFound
+				},
+			},
+			riskEngine:    mockRiskEngine{},
+			expectedCode:  domain.TransferFailureAccountOriginNotFound,
+			expectedError: "account origin not found",
+		},
+		{
+			name: "Failed transfer recorded for destination account not found",
+			accountRepo: mockAccountRepo{
+				findByIDOriginFake: account("3c096a40-ccba-4b58-93ed-57379ab04681", "08098565895", 5000),
+				findByIDDestinationFake: func() (domain.Account, error) {
+					return domain.Account{}, domain.ErrAccountNotFound
+				},
+				invokedFind: &invoked{},
+			},
+			riskEngine:    mockRiskEngine{},
+			expectedCode:  domain.TransferFailureAccountDestinationNotFound,
+			expectedError: "account destination not found",
+		},
+		{
+			name: "Failed transfer recorded for risk denial",
+			accountRepo: mockAccountRepo{
+				findByIDOriginFake:      account("3c096a40-ccba-4b58-93ed-57379ab04681", "08098565895", 5000),
+				findByIDDestinationFake: account("3c096a40-ccba-4b58-93ed-57379ab04682", "13098565491", 0),
+				invokedFind:             &invoked{},
+			},
+			riskEngine: mockRiskEngine{
+				results: []domain.RuleResult{
+					domain.NewRuleResult("new_account_large_amount", domain.RiskDeny, "new account"),
+				},
+			},
+			expectedCode:  domain.TransferFailureRiskDenied,
+			expectedError: "transfer denied by risk rules",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				created   []domain.Transfer
+				published []domain.Event
+			)
+
+			var uc = NewCreateTransferInteractor(
+				mockTransferRepoRecorder{created: &created},
+				tt.accountRepo,
+				mockTransferApprovalRepo{},
+				tt.riskEngine,
+				mockRiskEvaluationRepo{},
+				TransferApprovalConfig{},
+				TariffConfig{},
+				mockOutboxRepo{created: &published},
+				mockCreateTransferStatusPresenter{},
+				time.Second,
+			)
+
+			_, err := uc.Execute(customerContext("08098565895"), CreateTransferInput{
+				AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04681",
+				AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682",
+				Amount:               2999,
+			})
+			if err == nil || err.Error() != tt.expectedError {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if len(created) != 1 {
+				t.Fatalf("[TestCase '%s'] Created: '%v' | Expected one failed transfer", tt.name, created)
+			}
+
+			if created[0].Status() != domain.TransferStatusFailed || created[0].FailureCode() != tt.expectedCode {
+				t.Errorf(
+					"[TestCase '%s'] Status: '%v' Code: '%v' | Expected: '%v' Code: '%v'",
+					tt.name,
+					created[0].Status(),
+					created[0].FailureCode(),
+					domain.TransferStatusFailed,
+					tt.expectedCode,
+				)
+			}
+
+			if len(published) != 1 || published[0].Name() != domain.EventTransferFailed || published[0].AggregateID() != created[0].ID().String() {
+				t.Errorf("[TestCase '%s'] Events: '%v' | Expected: '%v' of the failed transfer", tt.name, eventNames(published), domain.EventTransferFailed)
+			}
+		})
+	}
+}
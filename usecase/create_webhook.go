@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// CreateWebhookUseCase input port
+	CreateWebhookUseCase interface {
+		Execute(context.Context, CreateWebhookInput) (CreateWebhookOutput, error)
+	}
+
+	// CreateWebhookInput input data. The webhook only receives the events of the accounts given, whose transfers the
+	// caller must be allowed to read, and its URL must reach a public address. A secret is generated when none is given
+	CreateWebhookInput struct {
+		URL        string   `json:"url" validate:"required,url,max=2048"`
+		Events     []string `json:"events" validate:"required,min=1,dive,oneof=account.created transfer.completed transfer.failed"`
+		AccountIDs []string `json:"account_ids" validate:"required,min=1,max=100,dive,uuid4"`
+		Secret     string   `json:"secret" validate:"omitempty,min=16,max=256"`
+	}
+
+	// CreateWebhookPresenter output port
+	CreateWebhookPresenter interface {
+		Output(domain.Webhook) CreateWebhookOutput
+	}
+
+	// CreateWebhookOutput output data, the secret is only ever shown here
+	CreateWebhookOutput struct {
+		ID         string   `json:"id"`
+		URL        string   `json:"url"`
+		Events     []string `json:"events"`
+		AccountIDs []string `json:"account_ids"`
+		Secret     string   `json:"secret"`
+		CreatedAt  string   `json:"created_at"`
+	}
+
+	createWebhookInteractor struct {
+		repo        domain.WebhookRepository
+		accountRepo domain.AccountRepository
+		sender      domain.WebhookSender
+		presenter   CreateWebhookPresenter
+		ctxTimeout  time.Duration
+	}
+)
+
+// NewCreateWebhookInteractor creates new createWebhookInteractor with its dependencies
+func NewCreateWebhookInteractor(
+	repo domain.WebhookRepository,
+	accountRepo domain.AccountRepository,
+	sender domain.WebhookSender,
+	presenter CreateWebhookPresenter,
+	t time.Duration,
+) CreateWebhookUseCase {
+	return createWebhookInteractor{
+		repo:        repo,
+		accountRepo: accountRepo,
+		sender:      sender,
+		presenter:   presenter,
+		ctxTimeout:  t,
+	}
+}
+
+// Execute orchestrates the use case
+func (a createWebhookInteractor) Execute(ctx context.Context, input CreateWebhookInput) (CreateWebhookOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	principal, _, err := Authorize(ctx, OpCreateWebhook)
+	if err != nil {
+		return a.presenter.Output(domain.Webhook{}), err
+	}
+
+	accountIDs, err := a.authorizeAccounts(ctx, input.AccountIDs)
+	if err != nil {
+		return a.presenter.Output(domain.Webhook{}), err
+	}
+
+	if err := a.sender.CheckURL(ctx, input.URL); err != nil {
+		return a.presenter.Output(domain.Webhook{}), err
+	}
+
+	var secret = input.Secret
+	if secret == "" {
+		if secret, err = domain.GenerateWebhookSecret(); err != nil {
+			return a.presenter.Output(domain.Webhook{}), err
+		}
+	}
+
+	var events = make([]domain.EventName, 0, len(input.Events))
+	for _, e := range input.Events {
+		events = append(events, domain.EventName(e))
+	}
+
+	webhook, err := a.repo.Create(ctx, domain.NewWebhook(
+		domain.WebhookID(domain.NewUUID()),
+		principal.Subject(),
+		input.URL,
+		events,
+		accountIDs,
+		secret,
+		0,
+		time.Time{},
+		time.Now(),
+	))
+	if err != nil {
+		return a.presenter.Output(domain.Webhook{}), err
+	}
+
+	return a.presenter.Output(webhook), nil
+}
+
+// authorizeAccounts checks that the accounts exist and that the caller may read their transfers, which the deliveries
+// of the webhook disclose
+func (a createWebhookInteractor) authorizeAccounts(ctx context.Context, IDs []string) ([]domain.AccountID, error) {
+	principal, access, err := Authorize(ctx, OpFindAllTransfer)
+	if err != nil {
+		return []domain.AccountID{}, err
+	}
+
+	var accountIDs = make([]domain.AccountID, 0, len(IDs))
+	for _, ID := range IDs {
+		account, err := a.accountRepo.FindByID(domain.ContextWithReadYourWrites(ctx), domain.AccountID(ID))
+		if err != nil {
+			return []domain.AccountID{}, err
+		}
+
+		if !access.Allows(principal, account) {
+			return []domain.AccountID{}, domain.ErrForbidden
+		}
+
+		accountIDs = append(accountIDs, account.ID())
+	}
+
+	return accountIDs, nil
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type mockWebhookRepoStore struct {
+	domain.WebhookRepository
+
+	created *domain.Webhook
+}
+
+func (m mockWebhookRepoStore) Create(_ context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	*m.created = webhook
+	return webhook, nil
+}
+
+type mockAccountRepoWebhook struct {
+	domain.AccountRepository
+
+	accounts map[domain.AccountID]domain.Account
+}
+
+func (m mockAccountRepoWebhook) FindByID(_ context.Context, ID domain.AccountID) (domain.Account, error) {
+	account, ok := m.accounts[ID]
+	if !ok {
+		return domain.Account{}, domain.ErrAccountNotFound
+	}
+
+	return account, nil
+}
+
+// mockWebhookSenderURL refuses the URLs of the hosts given
+type mockWebhookSenderURL struct {
+	domain.WebhookSender
+
+	refused []string
+}
+
+func (m mockWebhookSenderURL) CheckURL(_ context.Context, URL string) error {
+	for _, host := range m.refused {
+		if strings.Contains(URL, "://"+host) {
+			return domain.ErrWebhookURLNotAllowed
+		}
+	}
+
+	return nil
+}
+
+type mockCreateWebhookPresenter struct{}
+
+func (m mockCreateWebhookPresenter) Output(webhook domain.Webhook) CreateWebhookOutput {
+	var accountIDs = make([]string, 0, len(webhook.AccountIDs()))
+	for _, ID := range webhook.AccountIDs() {
+		accountIDs = append(accountIDs, ID.String())
+	}
+
+	return CreateWebhookOutput{URL: webhook.URL(), AccountIDs: accountIDs}
+}
+
+func clientContext(subject string, scopes ...domain.Scope) context.Context {
+	return domain.ContextWithPrincipal(context.Background(), domain.NewClientPrincipal(subject, scopes...))
+}
+
+func TestCreateWebhookInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = mockAccountRepoWebhook{accounts: map[domain.AccountID]domain.Account{
+			"3c096a40-ccba-4b58-93ed-57379ab04681": domain.NewAccount(
+				"3c096a40-ccba-4b58-93ed-57379ab04681",
+				"Test",
+				"02815517078",
+				0,
+				time.Time{},
+			),
+		}}
+		input = CreateWebhookInput{
+			URL:        "https://partner.example.com/hooks",
+			Events:     []string{"transfer.completed"},
+			AccountIDs: []string{"3c096a40-ccba-4b58-93ed-57379ab04681"},
+		}
+	)
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		input         CreateWebhookInput
+		expected      CreateWebhookOutput
+		expectedOwner string
+		expectedError string
+	}{
+		{
+			name:  "Create webhook watching the accounts of the client",
+			ctx:   clientContext("partner", domain.ScopeWebhooksAdmin, domain.ScopeTransfersRead),
+			input: input,
+			expected: CreateWebhookOutput{
+				URL:        "https://partner.example.com/hooks",
+				AccountIDs: []string{"3c096a40-ccba-4b58-93ed-57379ab04681"},
+			},
+			expectedOwner: "partner",
+		},
+		{
+			name:          "Create webhook for a client not reading transfers",
+			ctx:           clientContext("partner", domain.ScopeWebhooksAdmin),
+			input:         input,
+			expected:      CreateWebhookOutput{AccountIDs: []string{}},
+			expectedError: "caller is not allowed to access this resource",
+		},
+		{
+			name: "Create webhook watching an account not found",
+			ctx:  clientContext("partner", domain.ScopeWebhooksAdmin, domain.ScopeTransfersRead),
+			input: CreateWebhookInput{
+				URL:        "https://partner.example.com/hooks",
+				Events:     []string{"transfer.completed"},
+				AccountIDs: []string{"3c096a40-ccba-4b58-93ed-57379ab04682"},
+			},
+			expected:      CreateWebhookOutput{AccountIDs: []string{}},
+			expectedError: "account not found",
+		},
+		{
+			name: "Create webhook reaching a private address",
+			ctx:  clientContext("partner", domain.ScopeWebhooksAdmin, domain.ScopeTransfersRead),
+			input: CreateWebhookInput{
+				URL:        "http://169.254.169.254/latest/meta-data",
+				Events:     []string{"transfer.completed"},
+				AccountIDs: []string{"3c096a40-ccba-4b58-93ed-57379ab04681"},
+			},
+			expected:      CreateWebhookOutput{AccountIDs: []string{}},
+			expectedError: "webhook URL must reach a public address",
+		},
+		{
+			name:          "Create webhook forbidden to customers",
+			ctx:           customerContext("02815517078"),
+			input:         input,
+			expected:      CreateWebhookOutput{AccountIDs: []string{}},
+			expectedError: "caller is not allowed to access this resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				created domain.Webhook
+				uc      = NewCreateWebhookInteractor(
+					mockWebhookRepoStore{created: &created},
+					accounts,
+					mockWebhookSenderURL{refused: []string{"169.254.169.254"}},
+					mockCreateWebhookPresenter{},
+					time.Second,
+				)
+			)
+
+			result, err := uc.Execute(tt.ctx, tt.input)
+			if (err != nil) && (err.Error() != tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+			}
+
+			if created.Owner() != tt.expectedOwner {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, created.Owner(), tt.expectedOwner)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// DeliverWebhooksUseCase input port
+	DeliverWebhooksUseCase interface {
+		Execute(context.Context) (DeliverWebhooksOutput, error)
+	}
+
+	// DeliverWebhooksOutput is what a delivery run did
+	DeliverWebhooksOutput struct {
+		Succeeded int
+		Retried   int
+		Failed    int
+		// Disabled is how many webhooks the run disabled
+		Disabled int
+	}
+
+	// WebhookDeliveryConfig sets how many deliveries a run claims, how long it holds them and how failed ones are
+	// retried. A delivery failing MaxAttempts times is failed, a webhook failing DisableAfter attempts in a row is
+	// disabled
+	WebhookDeliveryConfig struct {
+		BatchSize    int
+		Lease        time.Duration
+		MaxAttempts  int
+		BaseBackoff  time.Duration
+		MaxBackoff   time.Duration
+		DisableAfter int
+	}
+
+	deliverWebhooksInteractor struct {
+		webhookRepo  domain.WebhookRepository
+		deliveryRepo domain.WebhookDeliveryRepository
+		sender       domain.WebhookSender
+		config       WebhookDeliveryConfig
+		ctxTimeout   time.Duration
+	}
+)
+
+// NewDeliverWebhooksInteractor creates new deliverWebhooksInteractor with its dependencies
+func NewDeliverWebhooksInteractor(
+	webhookRepo domain.WebhookRepository,
+	deliveryRepo domain.WebhookDeliveryRepository,
+	sender domain.WebhookSender,
+	config WebhookDeliveryConfig,
+	t time.Duration,
+) DeliverWebhooksUseCase {
+	return deliverWebhooksInteractor{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       sender,
+		config:       config,
+		ctxTimeout:   t,
+	}
+}
+
+// Backoff is how long to wait before the next attempt after the given number of failed ones, doubling each time
+func (c WebhookDeliveryConfig) Backoff(attempts int) time.Duration {
+	return backoff(c.BaseBackoff, c.MaxBackoff, attempts)
+}
+
+// Execute posts the deliveries that are due. They are leased to the run, so deliverers running side by side never
+// post the same delivery. Once a webhook failed in a run its other deliveries wait for the next one, so a receiver
+// that is down is not flooded; they are released, as are the deliveries the lease leaves no time to post. The context
+// timeout bounds each delivery rather than the whole run
+func (d deliverWebhooksInteractor) Execute(ctx context.Context) (DeliverWebhooksOutput, error) {
+	var output DeliverWebhooksOutput
+
+	var until = time.Now().Add(d.config.Lease)
+
+	deliveries, err := d.claimDue(ctx, until)
+	if err != nil {
+		return output, err
+	}
+
+	var (
+		webhooks = make(map[domain.WebhookID]domain.Webhook)
+		skipped  = make(map[domain.WebhookID]bool)
+	)
+
+	for _, delivery := range deliveries {
+		if skipped[delivery.WebhookID()] || time.Now().Add(d.ctxTimeout).After(until) {
+			if err := d.release(ctx, delivery); err != nil {
+				return output, err
+			}
+			continue
+		}
+
+		webhook, ok := webhooks[delivery.WebhookID()]
+		if !ok {
+			webhook, err = d.findWebhook(ctx, delivery.WebhookID())
+			if err != nil && err != domain.ErrWebhookNotFound {
+				return output, err
+			}
+		}
+
+		delivery, disabled, err := d.deliver(ctx, &webhook, delivery)
+		if err != nil {
+			return output, err
+		}
+		webhooks[delivery.WebhookID()] = webhook
+
+		switch delivery.Status() {
+		case domain.WebhookDeliverySucceeded:
+			output.Succeeded++
+		case domain.WebhookDeliveryPending:
+			output.Retried++
+			skipped[delivery.WebhookID()] = true
+		default:
+			output.Failed++
+			skipped[delivery.WebhookID()] = webhook.Enabled()
+		}
+
+		if disabled {
+			output.Disabled++
+		}
+	}
+
+	return output, nil
+}
+
+func (d deliverWebhooksInteractor) claimDue(ctx context.Context, until time.Time) ([]domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.ctxTimeout)
+	defer cancel()
+
+	return d.deliveryRepo.ClaimDue(ctx, time.Now(), until, d.config.BatchSize)
+}
+
+// release ends the lease of a delivery left for a later run, writing it back as it was claimed
+func (d deliverWebhooksInteractor) release(ctx context.Context, delivery domain.WebhookDelivery) error {
+	ctx, cancel := context.WithTimeout(ctx, d.ctxTimeout)
+	defer cancel()
+
+	return d.deliveryRepo.Update(ctx, delivery)
+}
+
+func (d deliverWebhooksInteractor) findWebhook(ctx context.Context, ID domain.WebhookID) (domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.ctxTimeout)
+	defer cancel()
+
+	return d.webhookRepo.FindByID(ctx, ID)
+}
+
+// deliver posts the delivery unless its webhook is gone or disabled, in which case it fails right away. It returns
+// the delivery as recorded and whether this attempt disabled the webhook. The failures of the webhook are counted on
+// the webhook as stored, locked, so a change made meanwhile, such as enabling or disabling it, is kept
+func (d deliverWebhooksInteractor) deliver(
+	ctx context.Context,
+	webhook *domain.Webhook,
+	delivery domain.WebhookDelivery,
+) (domain.WebhookDelivery, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.ctxTimeout)
+	defer cancel()
+
+	var now = time.Now()
+
+	if webhook.ID() == "" {
+		delivery = delivery.MarkFailed(0, domain.ErrWebhookNotFound, now, true)
+		return delivery, false, d.deliveryRepo.Update(ctx, delivery)
+	}
+
+	if !webhook.Enabled() {
+		delivery = delivery.MarkFailed(0, domain.ErrWebhookDisabled, now, true)
+		return delivery, false, d.deliveryRepo.Update(ctx, delivery)
+	}
+
+	status, err := d.sender.Send(ctx, *webhook, delivery)
+	if err == nil {
+		delivery = delivery.MarkSucceeded(status, time.Now())
+		if err := d.deliveryRepo.Update(ctx, delivery); err != nil {
+			return delivery, false, err
+		}
+
+		if webhook.Failures() == 0 {
+			return delivery, false, nil
+		}
+
+		return delivery, false, d.changeWebhook(ctx, webhook, func(stored *domain.Webhook) {
+			stored.RecordSuccess()
+		})
+	}
+
+	var disabled bool
+	if err := d.changeWebhook(ctx, webhook, func(stored *domain.Webhook) {
+		disabled = stored.RecordFailure(now, d.config.DisableAfter)
+	}); err != nil {
+		return delivery, false, err
+	}
+
+	var (
+		attempts = delivery.Attempts() + 1
+		failed   = attempts >= d.config.MaxAttempts || disabled
+	)
+
+	delivery = delivery.MarkFailed(status, err, now.Add(d.config.Backoff(attempts)), failed)
+	if err := d.deliveryRepo.Update(ctx, delivery); err != nil {
+		return delivery, false, err
+	}
+
+	return delivery, disabled, nil
+}
+
+// changeWebhook applies the change to the webhook read again and locked in a transaction, writing it back, and
+// leaves the webhook as written
+func (d deliverWebhooksInteractor) changeWebhook(
+	ctx context.Context,
+	webhook *domain.Webhook,
+	change func(*domain.Webhook),
+) error {
+	return d.webhookRepo.WithTransaction(ctx, func(ctxTx context.Context) error {
+		stored, err := d.webhookRepo.FindByID(ctxTx, webhook.ID())
+		if err != nil {
+			return err
+		}
+
+		change(&stored)
+
+		if err = d.webhookRepo.Update(ctxTx, stored); err != nil {
+			return err
+		}
+
+		*webhook = stored
+		return nil
+	})
+}
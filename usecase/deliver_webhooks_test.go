@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// mockWebhookRepoDeliver answers the webhooks, or inside a transaction the ones changed since they were first read
+type mockWebhookRepoDeliver struct {
+	domain.WebhookRepository
+
+	webhooks map[domain.WebhookID]domain.Webhook
+	changed  map[domain.WebhookID]domain.Webhook
+	updated  *[]domain.Webhook
+}
+
+type mockWebhookRepoTxKey struct{}
+
+func (m mockWebhookRepoDeliver) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(context.WithValue(ctx, mockWebhookRepoTxKey{}, true))
+}
+
+func (m mockWebhookRepoDeliver) FindByID(ctx context.Context, ID domain.WebhookID) (domain.Webhook, error) {
+	if webhook, ok := m.changed[ID]; ok && ctx.Value(mockWebhookRepoTxKey{}) != nil {
+		return webhook, nil
+	}
+
+	webhook, ok := m.webhooks[ID]
+	if !ok {
+		return domain.Webhook{}, domain.ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+func (m mockWebhookRepoDeliver) Update(_ context.Context, webhook domain.Webhook) error {
+	*m.updated = append(*m.updated, webhook)
+	return nil
+}
+
+type mockWebhookDeliveryRepoDeliver struct {
+	domain.WebhookDeliveryRepository
+
+	due      []domain.WebhookDelivery
+	claimErr error
+	updated  *[]domain.WebhookDelivery
+}
+
+func (m mockWebhookDeliveryRepoDeliver) ClaimDue(_ context.Context, _, _ time.Time, _ int) ([]domain.WebhookDelivery, error) {
+	return m.due, m.claimErr
+}
+
+func (m mockWebhookDeliveryRepoDeliver) Update(_ context.Context, delivery domain.WebhookDelivery) error {
+	*m.updated = append(*m.updated, delivery)
+	return nil
+}
+
+// mockWebhookSender answers every delivery with the status and records the ID of every delivery it is handed
+type mockWebhookSender struct {
+	status int
+	sent   *[]domain.WebhookDeliveryID
+}
+
+func (m mockWebhookSender) Send(_ context.Context, _ domain.Webhook, delivery domain.WebhookDelivery) (int, error) {
+	*m.sent = append(*m.sent, delivery.ID())
+	if m.status < 200 || m.status > 299 {
+		return m.status, errors.New("webhook answered with an error")
+	}
+
+	return m.status, nil
+}
+
+func (m mockWebhookSender) CheckURL(context.Context, string) error {
+	return nil
+}
+
+func webhookFixture(ID domain.WebhookID, failures int, disabledAt time.Time) domain.Webhook {
+	return domain.NewWebhook(
+		ID,
+		"client",
+		"http://localhost/hooks",
+		[]domain.EventName{domain.EventTransferCompleted},
+		[]domain.AccountID{"3c096a40-ccba-4b58-93ed-57379ab04681"},
+		"secret",
+		failures,
+		disabledAt,
+		time.Time{},
+	)
+}
+
+func webhookDeliveryFixture(ID domain.WebhookDeliveryID, webhookID domain.WebhookID, attempts int) domain.WebhookDelivery {
+	return domain.NewWebhookDelivery(
+		ID,
+		webhookID,
+		"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10",
+		domain.EventTransferCompleted,
+		[]byte(`{}`),
+		domain.WebhookDeliveryPending,
+		attempts,
+		time.Time{},
+		0,
+		"",
+		time.Time{},
+		time.Time{},
+	)
+}
+
+func TestDeliverWebhooksInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	var config = WebhookDeliveryConfig{
+		BatchSize:    50,
+		Lease:        time.Minute,
+		MaxAttempts:  3,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   time.Minute,
+		DisableAfter: 5,
+	}
+
+	tests := []struct {
+		name             string
+		webhooks         []domain.Webhook
+		changed          []domain.Webhook
+		due              []domain.WebhookDelivery
+		claimErr         error
+		status           int
+		expected         DeliverWebhooksOutput
+		expectedSent     []domain.WebhookDeliveryID
+		expectedStatuses map[domain.WebhookDeliveryID]domain.WebhookDeliveryStatus
+		expectedDisabled bool
+		expectedError    string
+	}{
+		{
+			name:     "Deliveries accepted by the receiver succeed",
+			webhooks: []domain.Webhook{webhookFixture("w1", 2, time.Time{})},
+			due: []domain.WebhookDelivery{
+				webhookDeliveryFixture("d1", "w1", 0),
+				webhookDeliveryFixture("d2", "w1", 1),
+			},
+			status:       http.StatusOK,
+			expected:     DeliverWebhooksOutput{Succeeded: 2},
+			expectedSent: []domain.WebhookDeliveryID{"d1", "d2"},
+			expectedStatuses: map[domain.WebhookDeliveryID]domain.WebhookDeliveryStatus{
+				"d1": domain.WebhookDeliverySucceeded,
+				"d2": domain.WebhookDeliverySucceeded,
+			},
+		},
+		{
+			name:     "A failing webhook holds back its other deliveries until the next run",
+			webhooks: []domain.Webhook{webhookFixture("w1", 0, time.Time{}), webhookFixture("w2", 0, time.Time{})},
+			due: []domain.WebhookDelivery{
+				webhookDeliveryFixture("d1", "w1", 0),
+				webhookDeliveryFixture("d2", "w1", 0),
+				webhookDeliveryFixture("d3", "w2", 0),
+			},
+			status:       http.StatusServiceUnavailable,
+			expected:     DeliverWebhooksOutput{Retried: 2},
+			expectedSent: []domain.WebhookDeliveryID{"d1", "d3"},
+			expectedStatuses: map[domain.WebhookDeliveryID]domain.WebhookDeliveryStatus{
+				"d1": domain.WebhookDeliveryPending,
+				"d2": domain.WebhookDeliveryPending,
+				"d3": domain.WebhookDeliveryPending,
+			},
+		},
+		{
+			name:         "Delivery failing its last attempt is failed",
+			webhooks:     []domain.Webhook{webhookFixture("w1", 0, time.Time{})},
+			due:          []domain.WebhookDelivery{webhookDeliveryFixture("d1", "w1", 2)},
+			status:       http.StatusInternalServerError,
+			expected:     DeliverWebhooksOutput{Failed: 1},
+			expectedSent: []domain.WebhookDeliveryID{"d1"},
+			expectedStatuses: map[domain.WebhookDeliveryID]domain.WebhookDeliveryStatus{
+				"d1": domain.WebhookDeliveryFailed,
+			},
+		},
+		{
+			name:     "Webhook failing repeatedly is disabled and its deliveries fail",
+			webhooks: []domain.Webhook{webhookFixture("w1", 4, time.Time{})},
+			due: []domain.WebhookDelivery{
+				webhookDeliveryFixture("d1", "w1", 0),
+				webhookDeliveryFixture("d2", "w1", 0),
+			},
+			status:       http.StatusInternalServerError,
+			expected:     DeliverWebhooksOutput{Failed: 2, Disabled: 1},
+			expectedSent: []domain.WebhookDeliveryID{"d1"},
+			expectedStatuses: map[domain.WebhookDeliveryID]domain.WebhookDeliveryStatus{
+				"d1": domain.WebhookDeliveryFailed,
+				"d2": domain.WebhookDeliveryFailed,
+			},
+			expectedDisabled: true,
+		},
+		{
+			name:     "Deliveries of a disabled webhook fail without being sent",
+			webhooks: []domain.Webhook{webhookFixture("w1", 0, time.Now())},
+			due:      []domain.WebhookDelivery{webhookDeliveryFixture("d1", "w1", 0)},
+			status:   http.StatusOK,
+			expected: DeliverWebhooksOutput{Failed: 1},
+			expectedStatuses: map[domain.WebhookDeliveryID]domain.WebhookDeliveryStatus{
+				"d1": domain.WebhookDeliveryFailed,
+			},
+		},
+		{
+			name:         "Failure counted on a webhook disabled meanwhile keeps it disabled",
+			webhooks:     []domain.Webhook{webhookFixture("w1", 0, time.Time{})},
+			changed:      []domain.Webhook{webhookFixture("w1", 0, time.Now())},
+			due:          []domain.WebhookDelivery{webhookDeliveryFixture("d1", "w1", 0)},
+			status:       http.StatusServiceUnavailable,
+			expected:     DeliverWebhooksOutput{Retried: 1},
+			expectedSent: []domain.WebhookDeliveryID{"d1"},
+			expectedStatuses: map[domain.WebhookDeliveryID]domain.WebhookDeliveryStatus{
+				"d1": domain.WebhookDeliveryPending,
+			},
+			expectedDisabled: true,
+		},
+		{
+			name:          "Error claiming deliveries",
+			claimErr:      errors.New("db error"),
+			expectedError: "db error",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				webhooks          = make(map[domain.WebhookID]domain.Webhook)
+				updatedWebhooks   = make([]domain.Webhook, 0)
+				updatedDeliveries = make([]domain.WebhookDelivery, 0)
+				sent              = make([]domain.WebhookDeliveryID, 0)
+			)
+
+			for _, w := range tt.webhooks {
+				webhooks[w.ID()] = w
+			}
+
+			var changed = make(map[domain.WebhookID]domain.Webhook)
+			for _, w := range tt.changed {
+				changed[w.ID()] = w
+			}
+
+			var uc = NewDeliverWebhooksInteractor(
+				mockWebhookRepoDeliver{webhooks: webhooks, changed: changed, updated: &updatedWebhooks},
+				mockWebhookDeliveryRepoDeliver{due: tt.due, claimErr: tt.claimErr, updated: &updatedDeliveries},
+				mockWebhookSender{status: tt.status, sent: &sent},
+				config,
+				time.Second,
+			)
+
+			result, err := uc.Execute(context.Background())
+			if (err != nil) && (err.Error() != tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if result != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%+v' | Expected: '%+v'", tt.name, result, tt.expected)
+			}
+
+			if len(tt.expectedSent) > 0 && !reflect.DeepEqual(sent, tt.expectedSent) {
+				t.Errorf("[TestCase '%s'] Sent: '%v' | Expected: '%v'", tt.name, sent, tt.expectedSent)
+			}
+
+			var statuses = make(map[domain.WebhookDeliveryID]domain.WebhookDeliveryStatus)
+			for _, d := range updatedDeliveries {
+				statuses[d.ID()] = d.Status()
+			}
+
+			if len(tt.expectedStatuses) > 0 && !reflect.DeepEqual(statuses, tt.expectedStatuses) {
+				t.Errorf("[TestCase '%s'] Statuses: '%v' | Expected: '%v'", tt.name, statuses, tt.expectedStatuses)
+			}
+
+			var disabled = len(updatedWebhooks) > 0 && !updatedWebhooks[len(updatedWebhooks)-1].Enabled()
+			if disabled != tt.expectedDisabled {
+				t.Errorf("[TestCase '%s'] Disabled: '%v' | Expected: '%v'", tt.name, disabled, tt.expectedDisabled)
+			}
+		})
+	}
+}
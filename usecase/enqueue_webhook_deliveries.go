@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// EnqueueWebhookDeliveriesUseCase input port
+	EnqueueWebhookDeliveriesUseCase interface {
+		Execute(context.Context, domain.Event) error
+	}
+
+	enqueueWebhookDeliveriesInteractor struct {
+		webhookRepo  domain.WebhookRepository
+		deliveryRepo domain.WebhookDeliveryRepository
+		ctxTimeout   time.Duration
+	}
+)
+
+// NewEnqueueWebhookDeliveriesInteractor creates new enqueueWebhookDeliveriesInteractor with its dependencies
+func NewEnqueueWebhookDeliveriesInteractor(
+	webhookRepo domain.WebhookRepository,
+	deliveryRepo domain.WebhookDeliveryRepository,
+	t time.Duration,
+) EnqueueWebhookDeliveriesUseCase {
+	return enqueueWebhookDeliveriesInteractor{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		ctxTimeout:   t,
+	}
+}
+
+// Execute records a pending delivery of the event for every enabled webhook subscribed to it and watching the accounts
+// it happened to, so a partner never receives the events of another tenant. It runs as a subscriber of the published
+// events, so an error has the event published again
+func (e enqueueWebhookDeliveriesInteractor) Execute(ctx context.Context, event domain.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, e.ctxTimeout)
+	defer cancel()
+
+	webhooks, err := e.webhookRepo.FindByEvent(ctx, event.Name(), event.AccountIDs())
+	if err != nil {
+		return err
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := event.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	var (
+		now        = time.Now()
+		deliveries = make([]domain.WebhookDelivery, 0, len(webhooks))
+	)
+
+	for _, webhook := range webhooks {
+		deliveries = append(deliveries, domain.NewWebhookDelivery(
+			domain.WebhookDeliveryID(domain.NewUUID()),
+			webhook.ID(),
+			event.ID(),
+			event.Name(),
+			payload,
+			domain.WebhookDeliveryPending,
+			0,
+			now,
+			0,
+			"",
+			time.Time{},
+			now,
+		))
+	}
+
+	return e.deliveryRepo.Create(ctx, deliveries...)
+}
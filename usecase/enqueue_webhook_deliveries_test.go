@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type mockWebhookRepoEnqueue struct {
+	domain.WebhookRepository
+
+	result  []domain.Webhook
+	findErr error
+}
+
+func (m mockWebhookRepoEnqueue) FindByEvent(
+	_ context.Context,
+	_ domain.EventName,
+	accountIDs []domain.AccountID,
+) ([]domain.Webhook, error) {
+	var webhooks = make([]domain.Webhook, 0)
+	for _, webhook := range m.result {
+		if webhook.Watches(accountIDs) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return webhooks, m.findErr
+}
+
+type mockWebhookDeliveryRepoEnqueue struct {
+	domain.WebhookDeliveryRepository
+
+	created *[]domain.WebhookDelivery
+}
+
+func (m mockWebhookDeliveryRepoEnqueue) Create(_ context.Context, deliveries ...domain.WebhookDelivery) error {
+	*m.created = append(*m.created, deliveries...)
+	return nil
+}
+
+func TestEnqueueWebhookDeliveriesInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	var event = domain.NewEvent(
+		"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10",
+		domain.EventTransferCompleted,
+		domain.EventSchemaVersion,
+		"3c096a40-ccba-4b58-93ed-57379ab04681",
+		[]byte(`{"transfer_id":"3c096a40-ccba-4b58-93ed-57379ab04680","account_origin_id":"3c096a40-ccba-4b58-93ed-57379ab04681","account_destination_id":"3c096a40-ccba-4b58-93ed-57379ab04682"}`),
+		time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	)
+
+	tests := []struct {
+		name             string
+		repository       mockWebhookRepoEnqueue
+		expectedWebhooks []domain.WebhookID
+		expectedError    string
+	}{
+		{
+			name: "Enqueue a delivery per subscribed webhook",
+			repository: mockWebhookRepoEnqueue{
+				result: []domain.Webhook{webhookFixture("w1", 0, time.Time{}), webhookFixture("w2", 0, time.Time{})},
+			},
+			expectedWebhooks: []domain.WebhookID{"w1", "w2"},
+		},
+		{
+			name: "Enqueue nothing for the webhooks of other accounts",
+			repository: mockWebhookRepoEnqueue{
+				result: []domain.Webhook{
+					webhookFixture("w1", 0, time.Time{}),
+					domain.NewWebhook(
+						"w2",
+						"other-client",
+						"http://localhost/hooks",
+						[]domain.EventName{domain.EventTransferCompleted},
+						[]domain.AccountID{"3c096a40-ccba-4b58-93ed-57379ab04683"},
+						"secret",
+						0,
+						time.Time{},
+						time.Time{},
+					),
+				},
+			},
+			expectedWebhooks: []domain.WebhookID{"w1"},
+		},
+		{
+			name:             "Enqueue nothing without subscribers",
+			repository:       mockWebhookRepoEnqueue{},
+			expectedWebhooks: []domain.WebhookID{},
+		},
+		{
+			name:             "Enqueue error listing webhooks",
+			repository:       mockWebhookRepoEnqueue{findErr: errors.New("db error")},
+			expectedWebhooks: []domain.WebhookID{},
+			expectedError:    "db error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				created = make([]domain.WebhookDelivery, 0)
+				uc      = NewEnqueueWebhookDeliveriesInteractor(
+					tt.repository,
+					mockWebhookDeliveryRepoEnqueue{created: &created},
+					time.Second,
+				)
+			)
+
+			if err := uc.Execute(context.Background(), event); (err != nil) && (err.Error() != tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			var webhooks = make([]domain.WebhookID, 0)
+			for _, d := range created {
+				webhooks = append(webhooks, d.WebhookID())
+
+				payload, _ := event.MarshalJSON()
+				if d.EventID() != event.ID() || string(d.Payload()) != string(payload) || !d.Due(time.Now()) {
+					t.Errorf("[TestCase '%s'] Delivery: '%v' '%s'", tt.name, d.EventID(), d.Payload())
+				}
+			}
+
+			if !reflect.DeepEqual(webhooks, tt.expectedWebhooks) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, webhooks, tt.expectedWebhooks)
+			}
+		})
+	}
+}
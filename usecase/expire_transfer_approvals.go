@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// ExpireTransferApprovalsUseCase input port
+	ExpireTransferApprovalsUseCase interface {
+		Execute(context.Context) (int, error)
+	}
+
+	expireTransferApprovalsInteractor struct {
+		transferRepo domain.TransferRepository
+		approvalRepo domain.TransferApprovalRepository
+		approval     TransferApprovalConfig
+		ctxTimeout   time.Duration
+	}
+)
+
+// NewExpireTransferApprovalsInteractor creates new expireTransferApprovalsInteractor with its dependencies
+func NewExpireTransferApprovalsInteractor(
+	transferRepo domain.TransferRepository,
+	approvalRepo domain.TransferApprovalRepository,
+	approval TransferApprovalConfig,
+	t time.Duration,
+) ExpireTransferApprovalsUseCase {
+	return expireTransferApprovalsInteractor{
+		transferRepo: transferRepo,
+		approvalRepo: approvalRepo,
+		approval:     approval,
+		ctxTimeout:   t,
+	}
+}
+
+// Execute expires the transfers that waited for a decision longer than the timeout and returns how many were expired
+func (t expireTransferApprovalsInteractor) Execute(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.ctxTimeout)
+	defer cancel()
+
+	if t.approval.Timeout <= 0 {
+		return 0, nil
+	}
+
+	var expired int
+	for _, status := range []domain.TransferStatus{domain.TransferStatusPendingApproval, domain.TransferStatusHeld} {
+		transfers, err := findEveryTransfer(ctx, t.transferRepo, domain.TransferFilter{Status: status})
+		if err != nil {
+			return expired, err
+		}
+
+		for _, transfer := range transfers {
+			if !t.approval.Expired(transfer.CreatedAt(), time.Now()) {
+				continue
+			}
+
+			err = t.transferRepo.WithTransaction(ctx, func(ctxTx context.Context) error {
+				// Re-read under lock, a decision may have been taken since the listing
+				current, err := t.transferRepo.FindByID(ctxTx, transfer.ID())
+				if err != nil || !current.AwaitingDecision() {
+					return err
+				}
+
+				return expireTransfer(ctxTx, t.transferRepo, t.approvalRepo, current)
+			})
+			if err != nil {
+				return expired, err
+			}
+
+			expired++
+		}
+	}
+
+	return expired, nil
+}
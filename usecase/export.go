@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// ExportHeader describes the transfers of an export. AccountID is empty when the transfers are not narrowed to
+	// an account, and then the period is zero. From and To bound the creation time of the transfers, To excluded.
+	// Only statements carry an opening balance: their transfers are the completed ones of the period, moving the
+	// balance from OpeningBalance to ClosingBalance
+	ExportHeader struct {
+		AccountID      domain.AccountID
+		From           time.Time
+		To             time.Time
+		Statement      bool
+		OpeningBalance domain.Money
+		ClosingBalance domain.Money
+		GeneratedAt    time.Time
+	}
+
+	// TransferExporter output port that writes the transfers as they are read, so an export never holds all of them
+	TransferExporter interface {
+		Begin(ExportHeader) error
+		Write(domain.Transfer) error
+		End() error
+	}
+)
+
+// exportTransfers writes the header, then each transfer of the filter, oldest first
+func exportTransfers(
+	ctx context.Context,
+	repo domain.TransferRepository,
+	filter domain.TransferFilter,
+	header ExportHeader,
+	exporter TransferExporter,
+) error {
+	if err := exporter.Begin(header); err != nil {
+		return err
+	}
+
+	if err := repo.Stream(ctx, filter, exporter.Write); err != nil {
+		return err
+	}
+
+	return exporter.End()
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// ExportStatementUseCase input port
+	ExportStatementUseCase interface {
+		Execute(context.Context, ExportStatementInput, TransferExporter) error
+	}
+
+	// ExportStatementInput input data, the days between From and To, both included
+	ExportStatementInput struct {
+		AccountID domain.AccountID
+		From      time.Time
+		To        time.Time
+	}
+
+	exportStatementInteractor struct {
+		transferRepo     domain.TransferRepository
+		accountRepo      domain.AccountRepository
+		ledgerRepo       domain.LedgerRepository
+		activityRepo     domain.DailyActivityRepository
+		dailyBalanceRepo domain.DailyBalanceRepository
+		ctxTimeout       time.Duration
+	}
+)
+
+// NewExportStatementInteractor creates new exportStatementInteractor with its dependencies
+func NewExportStatementInteractor(
+	transferRepo domain.TransferRepository,
+	accountRepo domain.AccountRepository,
+	ledgerRepo domain.LedgerRepository,
+	activityRepo domain.DailyActivityRepository,
+	dailyBalanceRepo domain.DailyBalanceRepository,
+	t time.Duration,
+) ExportStatementUseCase {
+	return exportStatementInteractor{
+		transferRepo:     transferRepo,
+		accountRepo:      accountRepo,
+		ledgerRepo:       ledgerRepo,
+		activityRepo:     activityRepo,
+		dailyBalanceRepo: dailyBalanceRepo,
+		ctxTimeout:       t,
+	}
+}
+
+// Execute writes the completed transfers of the period to the exporter, between the balance the account had before
+// the first day and the one it had after the last
+func (e exportStatementInteractor) Execute(
+	ctx context.Context,
+	input ExportStatementInput,
+	exporter TransferExporter,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, e.ctxTimeout)
+	defer cancel()
+
+	if err := authorizeAccount(ctx, e.accountRepo, OpExportStatement, input.AccountID); err != nil {
+		return err
+	}
+
+	var (
+		from = domain.StartOfDay(input.From)
+		to   = domain.StartOfDay(input.To)
+	)
+
+	opening, err := e.openingBalance(ctx, input.AccountID, from)
+	if err != nil {
+		return err
+	}
+
+	activity, err := e.activityRepo.FindActivity(ctx, input.AccountID, from, to)
+	if err != nil {
+		return err
+	}
+
+	closing, err := addActivity(opening, activity)
+	if err != nil {
+		return err
+	}
+
+	var end = to.AddDate(0, 0, 1)
+
+	return exportTransfers(
+		ctx,
+		e.transferRepo,
+		domain.TransferFilter{
+			AccountID: input.AccountID,
+			Status:    domain.TransferStatusCompleted,
+			From:      from,
+			To:        end,
+		},
+		ExportHeader{
+			AccountID:      input.AccountID,
+			From:           from,
+			To:             end,
+			Statement:      true,
+			OpeningBalance: opening,
+			ClosingBalance: closing,
+			GeneratedAt:    time.Now(),
+		},
+		exporter,
+	)
+}
+
+// openingBalance is the balance the account closed the day before from with: its snapshot when that day was closed,
+// otherwise the opening balance of the account moved by every day up to it. Without either it is unknown
+func (e exportStatementInteractor) openingBalance(
+	ctx context.Context,
+	ID domain.AccountID,
+	from time.Time,
+) (domain.Money, error) {
+	var previousDay = from.AddDate(0, 0, -1)
+
+	previous, err := e.dailyBalanceRepo.FindByAccountID(ctx, ID, previousDay, previousDay)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(previous) == 1 {
+		return previous[0].ClosingBalance(), nil
+	}
+
+	ledger, err := e.ledgerRepo.FindByAccountID(ctx, ID)
+	if err != nil {
+		return 0, err
+	}
+
+	opening, known := ledger.OpeningBalance()
+	if !known {
+		return 0, domain.ErrOpeningBalanceUnknown
+	}
+
+	var openedAt = domain.StartOfDay(ledger.OpenedAt())
+	if !openedAt.Before(from) {
+		return opening, nil
+	}
+
+	activity, err := e.activityRepo.FindActivity(ctx, ID, openedAt, previousDay)
+	if err != nil {
+		return 0, err
+	}
+
+	return addActivity(opening, activity)
+}
+
+func addActivity(balance domain.Money, activity []domain.DailyActivity) (domain.Money, error) {
+	var err error
+	for _, day := range activity {
+		if balance, err = balance.Add(day.Credits()); err != nil {
+			return 0, err
+		}
+
+		if balance, err = balance.Sub(day.Debits()); err != nil {
+			return 0, err
+		}
+	}
+
+	return balance, nil
+}
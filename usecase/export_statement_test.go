@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+func TestExportStatementInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	const accountID = domain.AccountID("3c096a40-ccba-4b58-93ed-57379ab04680")
+
+	var (
+		openedAt = time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+		from     = time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)
+		to       = time.Date(2026, time.March, 7, 0, 0, 0, 0, time.UTC)
+		ledger   = domain.NewAccountLedger(accountID, openedAt, 1000, 0, 0, 0)
+		activity = []domain.DailyActivity{
+			domain.NewDailyActivity(openedAt, 500, 0, 1),
+			domain.NewDailyActivity(from, 300, 0, 1),
+			domain.NewDailyActivity(to, 0, 100, 1),
+		}
+	)
+
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		input           ExportStatementInput
+		existing        []domain.DailyBalance
+		openingUnknown  bool
+		expectedOpening domain.Money
+		expectedClosing domain.Money
+		expectedErr     error
+	}{
+		{
+			name:  "Export opens with the snapshot of the day before",
+			ctx:   roleContext(domain.RoleAdmin),
+			input: ExportStatementInput{AccountID: accountID, From: from, To: to},
+			existing: []domain.DailyBalance{
+				domain.NewDailyBalance(accountID, from.AddDate(0, 0, -1), 1600, 0, 0, 0),
+			},
+			expectedOpening: 1600,
+			expectedClosing: 1800,
+		},
+		{
+			name:            "Export opens with the ledger moved up to the day before without a snapshot",
+			ctx:             roleContext(domain.RoleAdmin),
+			input:           ExportStatementInput{AccountID: accountID, From: from, To: to},
+			expectedOpening: 1500,
+			expectedClosing: 1700,
+		},
+		{
+			name:            "Export opens with the opening balance when the period starts before the account",
+			ctx:             roleContext(domain.RoleAdmin),
+			input:           ExportStatementInput{AccountID: accountID, From: openedAt.AddDate(0, 0, -2), To: to},
+			expectedOpening: 1000,
+			expectedClosing: 1700,
+		},
+		{
+			name:  "Export opens with the snapshot of the day before when the opening balance is unknown",
+			ctx:   roleContext(domain.RoleAdmin),
+			input: ExportStatementInput{AccountID: accountID, From: from, To: to},
+			existing: []domain.DailyBalance{
+				domain.NewDailyBalance(accountID, from.AddDate(0, 0, -1), 1600, 0, 0, 0),
+			},
+			openingUnknown:  true,
+			expectedOpening: 1600,
+			expectedClosing: 1800,
+		},
+		{
+			name:           "Export error when the opening balance is unknown without a snapshot",
+			ctx:            roleContext(domain.RoleAdmin),
+			input:          ExportStatementInput{AccountID: accountID, From: from, To: to},
+			openingUnknown: true,
+			expectedErr:    domain.ErrOpeningBalanceUnknown,
+		},
+		{
+			name:        "Export error without a principal",
+			ctx:         context.Background(),
+			input:       ExportStatementInput{AccountID: accountID, From: from, To: to},
+			expectedErr: domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				saved        int
+				dailyBalance = mockDailyBalanceRepo{balances: make(map[time.Time]domain.DailyBalance), saved: &saved}
+			)
+
+			for _, b := range tt.existing {
+				dailyBalance.balances[b.Day()] = b
+			}
+
+			var ledger = ledger
+			if tt.openingUnknown {
+				ledger = domain.NewAccountLedgerWithoutOpening(accountID, openedAt, 0, 0, 0)
+			}
+
+			var (
+				filter     domain.TransferFilter
+				exporter   = &mockTransferExporter{}
+				ledgerRepo = mockDailyLedgerRepo{ledger: ledger, activity: activity}
+				uc         = NewExportStatementInteractor(
+					mockTransferRepoStream{filter: &filter},
+					mockAccountRepoExport{},
+					ledgerRepo,
+					ledgerRepo,
+					dailyBalance,
+					time.Second,
+				)
+			)
+
+			err := uc.Execute(tt.ctx, tt.input, exporter)
+			if err != tt.expectedErr {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedErr)
+				return
+			}
+
+			if tt.expectedErr != nil {
+				return
+			}
+
+			if exporter.header.OpeningBalance != tt.expectedOpening {
+				t.Errorf("[TestCase '%s'] Opening: '%v' | Expected: '%v'", tt.name, exporter.header.OpeningBalance, tt.expectedOpening)
+			}
+
+			if exporter.header.ClosingBalance != tt.expectedClosing {
+				t.Errorf("[TestCase '%s'] Closing: '%v' | Expected: '%v'", tt.name, exporter.header.ClosingBalance, tt.expectedClosing)
+			}
+
+			var expectedFilter = domain.TransferFilter{
+				AccountID: accountID,
+				Status:    domain.TransferStatusCompleted,
+				From:      domain.StartOfDay(tt.input.From),
+				To:        to.AddDate(0, 0, 1),
+			}
+			if filter != expectedFilter {
+				t.Errorf("[TestCase '%s'] Filter: '%+v' | Expected: '%+v'", tt.name, filter, expectedFilter)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// ExportTransfersUseCase input port
+	ExportTransfersUseCase interface {
+		Execute(context.Context, ExportTransfersInput, TransferExporter) error
+	}
+
+	// ExportTransfersInput input data. AccountID narrows the listing to the transfers sent or received by the account
+	ExportTransfersInput struct {
+		AccountID domain.AccountID
+		Status    string
+	}
+
+	exportTransfersInteractor struct {
+		transferRepo domain.TransferRepository
+		accountRepo  domain.AccountRepository
+		ctxTimeout   time.Duration
+	}
+)
+
+// NewExportTransfersInteractor creates new exportTransfersInteractor with its dependencies
+func NewExportTransfersInteractor(
+	transferRepo domain.TransferRepository,
+	accountRepo domain.AccountRepository,
+	t time.Duration,
+) ExportTransfersUseCase {
+	return exportTransfersInteractor{
+		transferRepo: transferRepo,
+		accountRepo:  accountRepo,
+		ctxTimeout:   t,
+	}
+}
+
+// Execute writes the same transfers as the listing to the exporter. Customers only export their own account
+func (e exportTransfersInteractor) Execute(
+	ctx context.Context,
+	input ExportTransfersInput,
+	exporter TransferExporter,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, e.ctxTimeout)
+	defer cancel()
+
+	principal, access, err := Authorize(ctx, OpFindAllTransfer)
+	if err != nil {
+		return err
+	}
+
+	var (
+		filter = domain.TransferFilter{AccountID: input.AccountID, Status: domain.TransferStatus(input.Status)}
+		header = ExportHeader{AccountID: input.AccountID, GeneratedAt: time.Now()}
+	)
+
+	if access == AccessAny && input.AccountID == "" {
+		return exportTransfers(ctx, e.transferRepo, filter, header, exporter)
+	}
+
+	account, err := e.exportedAccount(ctx, principal, access, input.AccountID)
+	switch {
+	case err == domain.ErrAccountNotFound && input.AccountID == "":
+		// A customer without an account has no transfers, as in the listing
+		if err = exporter.Begin(header); err != nil {
+			return err
+		}
+
+		return exporter.End()
+	case err != nil:
+		return err
+	}
+
+	filter.AccountID = account.ID()
+	header.AccountID = account.ID()
+	header.From = account.CreatedAt()
+	header.To = header.GeneratedAt
+	header.ClosingBalance = account.Balance()
+
+	return exportTransfers(ctx, e.transferRepo, filter, header, exporter)
+}
+
+// exportedAccount is the account the export is narrowed to: the one asked for or, for customers, their own
+func (e exportTransfersInteractor) exportedAccount(
+	ctx context.Context,
+	principal domain.Principal,
+	access Access,
+	ID domain.AccountID,
+) (domain.Account, error) {
+	if access == AccessAny {
+		return e.accountRepo.FindByID(ctx, ID)
+	}
+
+	account, err := e.accountRepo.FindByCPF(domain.ContextWithReadYourWrites(ctx), principal.Subject())
+	switch {
+	case err == domain.ErrAccountNotFound && ID != "":
+		return domain.Account{}, domain.ErrForbidden
+	case err != nil:
+		return domain.Account{}, err
+	case ID != "" && ID != account.ID():
+		return domain.Account{}, domain.ErrForbidden
+	}
+
+	return account, nil
+}
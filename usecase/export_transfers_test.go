@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// mockTransferRepoStream streams the transfers given, keeping the filter asked for
+type mockTransferRepoStream struct {
+	domain.TransferRepository
+
+	transfers []domain.Transfer
+	filter    *domain.TransferFilter
+}
+
+func (m mockTransferRepoStream) Stream(
+	_ context.Context,
+	filter domain.TransferFilter,
+	fn func(domain.Transfer) error,
+) error {
+	*m.filter = filter
+	for _, transfer := range m.transfers {
+		if err := fn(transfer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mockAccountRepoExport answers the same account whatever is asked
+type mockAccountRepoExport struct {
+	domain.AccountRepository
+
+	account domain.Account
+	err     error
+}
+
+func (m mockAccountRepoExport) FindByID(_ context.Context, _ domain.AccountID) (domain.Account, error) {
+	return m.account, m.err
+}
+
+func (m mockAccountRepoExport) FindByCPF(_ context.Context, _ string) (domain.Account, error) {
+	return m.account, m.err
+}
+
+// mockTransferExporter records what is written to it
+type mockTransferExporter struct {
+	header    ExportHeader
+	transfers []domain.Transfer
+	ended     bool
+}
+
+func (m *mockTransferExporter) Begin(header ExportHeader) error {
+	m.header = header
+	return nil
+}
+
+func (m *mockTransferExporter) Write(transfer domain.Transfer) error {
+	m.transfers = append(m.transfers, transfer)
+	return nil
+}
+
+func (m *mockTransferExporter) End() error {
+	m.ended = true
+	return nil
+}
+
+func TestExportTransfersInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	var (
+		createdAt = time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+		account   = domain.NewAccount(
+			"3c096a40-ccba-4b58-93ed-57379ab04680",
+			"Test",
+			"02815517078",
+			2500,
+			createdAt,
+		)
+		transfer = domain.NewTransfer(
+			"7bd5d7e2-5a5b-4ef0-8e8a-3f3a3b0a9d8b",
+			"3c096a40-ccba-4b58-93ed-57379ab04680",
+			"b5a2e2d2-4b1f-4e4b-9d4b-9a7b6c2d1e0f",
+			300,
+			domain.TransferStatusCompleted,
+			createdAt,
+		)
+	)
+
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		input           ExportTransfersInput
+		accountErr      error
+		expectedFilter  domain.TransferFilter
+		expectedAccount domain.AccountID
+		expectedClosing domain.Money
+		expectedErr     error
+	}{
+		{
+			name:           "Export every transfer without an account",
+			ctx:            roleContext(domain.RoleAdmin),
+			input:          ExportTransfersInput{Status: "completed"},
+			expectedFilter: domain.TransferFilter{Status: domain.TransferStatusCompleted},
+		},
+		{
+			name:            "Export the transfers of the account asked for with its balance",
+			ctx:             roleContext(domain.RoleSupport),
+			input:           ExportTransfersInput{AccountID: account.ID()},
+			expectedFilter:  domain.TransferFilter{AccountID: account.ID()},
+			expectedAccount: account.ID(),
+			expectedClosing: 2500,
+		},
+		{
+			name:            "Export narrows a customer to their own account",
+			ctx:             customerContext("02815517078"),
+			expectedFilter:  domain.TransferFilter{AccountID: account.ID()},
+			expectedAccount: account.ID(),
+			expectedClosing: 2500,
+		},
+		{
+			name:        "Export error when a customer asks for another account",
+			ctx:         customerContext("02815517078"),
+			input:       ExportTransfersInput{AccountID: "b5a2e2d2-4b1f-4e4b-9d4b-9a7b6c2d1e0f"},
+			expectedErr: domain.ErrForbidden,
+		},
+		{
+			name:        "Export error when the account asked for does not exist",
+			ctx:         roleContext(domain.RoleAdmin),
+			input:       ExportTransfersInput{AccountID: account.ID()},
+			accountErr:  domain.ErrAccountNotFound,
+			expectedErr: domain.ErrAccountNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				filter   domain.TransferFilter
+				exporter = &mockTransferExporter{}
+				uc       = NewExportTransfersInteractor(
+					mockTransferRepoStream{transfers: []domain.Transfer{transfer}, filter: &filter},
+					mockAccountRepoExport{account: account, err: tt.accountErr},
+					time.Second,
+				)
+			)
+
+			err := uc.Execute(tt.ctx, tt.input, exporter)
+			if err != tt.expectedErr {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedErr)
+				return
+			}
+
+			if tt.expectedErr != nil {
+				return
+			}
+
+			if filter != tt.expectedFilter {
+				t.Errorf("[TestCase '%s'] Filter: '%+v' | Expected: '%+v'", tt.name, filter, tt.expectedFilter)
+			}
+
+			if exporter.header.AccountID != tt.expectedAccount {
+				t.Errorf("[TestCase '%s'] Account: '%v' | Expected: '%v'", tt.name, exporter.header.AccountID, tt.expectedAccount)
+			}
+
+			if exporter.header.ClosingBalance != tt.expectedClosing {
+				t.Errorf("[TestCase '%s'] Closing: '%v' | Expected: '%v'", tt.name, exporter.header.ClosingBalance, tt.expectedClosing)
+			}
+
+			if len(exporter.transfers) != 1 || !exporter.ended {
+				t.Errorf("[TestCase '%s'] Transfers: '%v' | Expected: '%v'", tt.name, len(exporter.transfers), 1)
+			}
+		})
+	}
+}
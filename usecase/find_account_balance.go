@@ -8,9 +8,15 @@ import (
 )
 
 type (
-	// FindAccountBalanceUseCase input port
-	FindAccountBalanceUseCase interface {
-		Execute(context.Context, domain.AccountID) (FindAccountBalanceOutput, error)
+	// FindAccountBalanceUseCase input port of /v1
+	FindAccountBalanceUseCase = FindAccountBalanceUseCaseOf[FindAccountBalanceOutput]
+
+	// FindAccountBalanceUseCaseV2 input port of /v2
+	FindAccountBalanceUseCaseV2 = FindAccountBalanceUseCaseOf[FindAccountBalanceOutputV2]
+
+	// FindAccountBalanceUseCaseOf input port, answering with the output of its presenter
+	FindAccountBalanceUseCaseOf[O any] interface {
+		Execute(context.Context, domain.AccountID) (O, error)
 	}
 
 	// FindAccountBalanceInput input data
@@ -18,9 +24,15 @@ type (
 		ID int64 `json:"balance" validate:"gt=0,required"`
 	}
 
-	// FindAccountBalancePresenter output port
-	FindAccountBalancePresenter interface {
-		Output(domain.Money) FindAccountBalanceOutput
+	// FindAccountBalancePresenter output port of /v1
+	FindAccountBalancePresenter = FindAccountBalancePresenterOf[FindAccountBalanceOutput]
+
+	// FindAccountBalancePresenterV2 output port of /v2
+	FindAccountBalancePresenterV2 = FindAccountBalancePresenterOf[FindAccountBalanceOutputV2]
+
+	// FindAccountBalancePresenterOf output port
+	FindAccountBalancePresenterOf[O any] interface {
+		Output(domain.Money) O
 	}
 
 	// FindAccountBalanceOutput output data
@@ -28,20 +40,26 @@ type (
 		Balance float64 `json:"balance"`
 	}
 
-	findBalanceAccountInteractor struct {
+	// FindAccountBalanceOutputV2 output data of /v2, with the balance as a decimal string
+	FindAccountBalanceOutputV2 struct {
+		Balance  string `json:"balance"`
+		Currency string `json:"currency"`
+	}
+
+	findBalanceAccountInteractor[O any] struct {
 		repo       domain.AccountRepository
-		presenter  FindAccountBalancePresenter
+		presenter  FindAccountBalancePresenterOf[O]
 		ctxTimeout time.Duration
 	}
 )
 
 // NewFindBalanceAccountInteractor creates new findBalanceAccountInteractor with its dependencies
-func NewFindBalanceAccountInteractor(
+func NewFindBalanceAccountInteractor[O any](
 	repo domain.AccountRepository,
-	presenter FindAccountBalancePresenter,
+	presenter FindAccountBalancePresenterOf[O],
 	t time.Duration,
-) FindAccountBalanceUseCase {
-	return findBalanceAccountInteractor{
+) FindAccountBalanceUseCaseOf[O] {
+	return findBalanceAccountInteractor[O]{
 		repo:       repo,
 		presenter:  presenter,
 		ctxTimeout: t,
@@ -49,10 +67,14 @@ func NewFindBalanceAccountInteractor(
 }
 
 // Execute orchestrates the use case
-func (a findBalanceAccountInteractor) Execute(ctx context.Context, ID domain.AccountID) (FindAccountBalanceOutput, error) {
+func (a findBalanceAccountInteractor[O]) Execute(ctx context.Context, ID domain.AccountID) (O, error) {
 	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
 	defer cancel()
 
+	if err := a.authorize(ctx, ID); err != nil {
+		return a.presenter.Output(domain.Money(0)), err
+	}
+
 	account, err := a.repo.FindBalance(ctx, ID)
 	if err != nil {
 		return a.presenter.Output(domain.Money(0)), err
@@ -60,3 +82,30 @@ func (a findBalanceAccountInteractor) Execute(ctx context.Context, ID domain.Acc
 
 	return a.presenter.Output(account.Balance()), nil
 }
+
+func (a findBalanceAccountInteractor[O]) authorize(ctx context.Context, ID domain.AccountID) error {
+	principal, access, err := Authorize(ctx, OpFindAccountBalance)
+	if err != nil {
+		return err
+	}
+
+	if access == AccessAny {
+		return nil
+	}
+
+	account, err := a.repo.FindByCPF(domain.ContextWithReadYourWrites(ctx), principal.Subject())
+	if err != nil {
+		switch err {
+		case domain.ErrAccountNotFound:
+			return domain.ErrForbidden
+		default:
+			return err
+		}
+	}
+
+	if account.ID() != ID {
+		return domain.ErrForbidden
+	}
+
+	return nil
+}
@@ -13,10 +13,17 @@ import (
 type mockAccountRepoFindBalance struct {
 	domain.AccountRepository
 
+	owner    domain.Account
+	ownerErr error
+
 	result domain.Account
 	err    error
 }
 
+func (m mockAccountRepoFindBalance) FindByCPF(_ context.Context, _ string) (domain.Account, error) {
+	return m.owner, m.ownerErr
+}
+
 func (m mockAccountRepoFindBalance) FindBalance(_ context.Context, _ domain.AccountID) (domain.Account, error) {
 	return m.result, m.err
 }
@@ -38,6 +45,7 @@ func TestFindBalanceAccountInteractor_Execute(t *testing.T) {
 
 	tests := []struct {
 		name          string
+		ctx           context.Context
 		args          args
 		repository    domain.AccountRepository
 		presenter     FindAccountBalancePresenter
@@ -46,10 +54,18 @@ func TestFindBalanceAccountInteractor_Execute(t *testing.T) {
 	}{
 		{
 			name: "Success when returning the account balance",
+			ctx:  customerContext("02815517078"),
 			args: args{
 				ID: "3c096a40-ccba-4b58-93ed-57379ab04680",
 			},
 			repository: mockAccountRepoFindBalance{
+				owner: domain.NewAccount(
+					"3c096a40-ccba-4b58-93ed-57379ab04680",
+					"Test",
+					"02815517078",
+					100,
+					time.Time{},
+				),
 				result: domain.NewAccountBalance(100),
 				err:    nil,
 			},
@@ -60,10 +76,18 @@ func TestFindBalanceAccountInteractor_Execute(t *testing.T) {
 		},
 		{
 			name: "Success when returning the account balance",
+			ctx:  customerContext("02815517078"),
 			args: args{
 				ID: "3c096a40-ccba-4b58-93ed-57379ab04680",
 			},
 			repository: mockAccountRepoFindBalance{
+				owner: domain.NewAccount(
+					"3c096a40-ccba-4b58-93ed-57379ab04680",
+					"Test",
+					"02815517078",
+					100,
+					time.Time{},
+				),
 				result: domain.NewAccountBalance(20050),
 				err:    nil,
 			},
@@ -74,10 +98,18 @@ func TestFindBalanceAccountInteractor_Execute(t *testing.T) {
 		},
 		{
 			name: "Error returning account balance",
+			ctx:  customerContext("02815517078"),
 			args: args{
 				ID: "3c096a40-ccba-4b58-93ed-57379ab04680",
 			},
 			repository: mockAccountRepoFindBalance{
+				owner: domain.NewAccount(
+					"3c096a40-ccba-4b58-93ed-57379ab04680",
+					"Test",
+					"02815517078",
+					100,
+					time.Time{},
+				),
 				result: domain.Account{},
 				err:    errors.New("error"),
 			},
@@ -87,12 +119,49 @@ func TestFindBalanceAccountInteractor_Execute(t *testing.T) {
 			expectedError: "error",
 			expected:      FindAccountBalanceOutput{},
 		},
+		{
+			name: "Error returning balance of account of another holder",
+			ctx:  customerContext("02815517078"),
+			args: args{
+				ID: "3c096a40-ccba-4b58-93ed-57379ab04681",
+			},
+			repository: mockAccountRepoFindBalance{
+				owner: domain.NewAccount(
+					"3c096a40-ccba-4b58-93ed-57379ab04680",
+					"Test",
+					"02815517078",
+					100,
+					time.Time{},
+				),
+				result: domain.NewAccountBalance(100),
+			},
+			presenter: mockFindAccountBalancePresenter{
+				result: FindAccountBalanceOutput{},
+			},
+			expectedError: "caller is not allowed to access this resource",
+			expected:      FindAccountBalanceOutput{},
+		},
+		{
+			name: "Error returning account balance when the holder has no account",
+			ctx:  customerContext("02815517078"),
+			args: args{
+				ID: "3c096a40-ccba-4b58-93ed-57379ab04680",
+			},
+			repository: mockAccountRepoFindBalance{
+				ownerErr: domain.ErrAccountNotFound,
+			},
+			presenter: mockFindAccountBalancePresenter{
+				result: FindAccountBalanceOutput{},
+			},
+			expectedError: "caller is not allowed to access this resource",
+			expected:      FindAccountBalanceOutput{},
+		},
 	}
 
 	for _, tt := range tests {
 		var uc = NewFindBalanceAccountInteractor(tt.repository, tt.presenter, time.Second)
 
-		result, err := uc.Execute(context.Background(), tt.args.ID)
+		result, err := uc.Execute(tt.ctx, tt.args.ID)
 		if (err != nil) && (err.Error() != tt.expectedError) {
 			t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
 			return
@@ -8,14 +8,35 @@ import (
 )
 
 type (
-	// FindAllAccountUseCase input port
-	FindAllAccountUseCase interface {
-		Execute(context.Context) ([]FindAllAccountOutput, error)
+	// FindAllAccountUseCase input port of /v1
+	FindAllAccountUseCase = FindAllAccountUseCaseOf[FindAllAccountOutput]
+
+	// FindAllAccountUseCaseV2 input port of /v2
+	FindAllAccountUseCaseV2 = FindAllAccountUseCaseOf[AccountOutputV2]
+
+	// FindAllAccountUseCaseOf input port, answering with the page of the outputs of its presenter
+	FindAllAccountUseCaseOf[O any] interface {
+		Execute(context.Context, domain.PageRequest) (FindAllAccountPageOutputOf[O], error)
+	}
+
+	// FindAllAccountPresenter output port of /v1
+	FindAllAccountPresenter = FindAllAccountPresenterOf[FindAllAccountOutput]
+
+	// FindAllAccountPresenterV2 output port of /v2
+	FindAllAccountPresenterV2 = FindAllAccountPresenterOf[AccountOutputV2]
+
+	// FindAllAccountPresenterOf output port
+	FindAllAccountPresenterOf[O any] interface {
+		Output([]domain.Account) []O
 	}
 
-	// FindAllAccountPresenter output port
-	FindAllAccountPresenter interface {
-		Output([]domain.Account) []FindAllAccountOutput
+	// FindAllAccountPageOutput output data of /v1
+	FindAllAccountPageOutput = FindAllAccountPageOutputOf[FindAllAccountOutput]
+
+	// FindAllAccountPageOutputOf output data: a page of the accounts and the cursor of the next one, empty on the last
+	FindAllAccountPageOutputOf[O any] struct {
+		Accounts   []O    `json:"data"`
+		NextCursor string `json:"next_cursor,omitempty"`
 	}
 
 	// FindAllAccountOutput outputData
@@ -27,20 +48,20 @@ type (
 		CreatedAt string  `json:"created_at"`
 	}
 
-	findAllAccountInteractor struct {
+	findAllAccountInteractor[O any] struct {
 		repo       domain.AccountRepository
-		presenter  FindAllAccountPresenter
+		presenter  FindAllAccountPresenterOf[O]
 		ctxTimeout time.Duration
 	}
 )
 
 // NewFindAllAccountInteractor creates new findAllAccountInteractor with its dependencies
-func NewFindAllAccountInteractor(
+func NewFindAllAccountInteractor[O any](
 	repo domain.AccountRepository,
-	presenter FindAllAccountPresenter,
+	presenter FindAllAccountPresenterOf[O],
 	t time.Duration,
-) FindAllAccountUseCase {
-	return findAllAccountInteractor{
+) FindAllAccountUseCaseOf[O] {
+	return findAllAccountInteractor[O]{
 		repo:       repo,
 		presenter:  presenter,
 		ctxTimeout: t,
@@ -48,14 +69,49 @@ func NewFindAllAccountInteractor(
 }
 
 // Execute orchestrates the use case
-func (a findAllAccountInteractor) Execute(ctx context.Context) ([]FindAllAccountOutput, error) {
+func (a findAllAccountInteractor[O]) Execute(ctx context.Context, page domain.PageRequest) (FindAllAccountPageOutputOf[O], error) {
 	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
 	defer cancel()
 
-	accounts, err := a.repo.FindAll(ctx)
+	principal, access, err := Authorize(ctx, OpFindAllAccount)
 	if err != nil {
-		return a.presenter.Output([]domain.Account{}), err
+		return a.output([]domain.Account{}, ""), err
 	}
 
-	return a.presenter.Output(accounts), nil
+	if access == AccessAny {
+		var ahead = pageAhead(page)
+
+		accounts, err := a.repo.FindAll(ctx, ahead)
+		if err != nil {
+			return a.output([]domain.Account{}, ""), err
+		}
+
+		if len(accounts) < ahead.Limit {
+			return a.output(accounts, ""), nil
+		}
+
+		var last = accounts[ahead.Limit-2]
+		return a.output(accounts[:ahead.Limit-1], domain.NewCursor(last.CreatedAt(), last.ID().String()).String()), nil
+	}
+
+	// A holder has a single account, which fits in the first page
+	if !page.After.IsZero() {
+		return a.output([]domain.Account{}, ""), nil
+	}
+
+	account, err := a.repo.FindByCPF(domain.ContextWithReadYourWrites(ctx), principal.Subject())
+	if err != nil {
+		switch err {
+		case domain.ErrAccountNotFound:
+			return a.output([]domain.Account{}, ""), nil
+		default:
+			return a.output([]domain.Account{}, ""), err
+		}
+	}
+
+	return a.output([]domain.Account{account}, ""), nil
+}
+
+func (a findAllAccountInteractor[O]) output(accounts []domain.Account, nextCursor string) FindAllAccountPageOutputOf[O] {
+	return FindAllAccountPageOutputOf[O]{Accounts: a.presenter.Output(accounts), NextCursor: nextCursor}
 }
@@ -10,17 +10,36 @@ import (
 	"github.com/gsabadini/go-clean-architecture/domain"
 )
 
-type mockAccountRepoFindAll struct {
+type mockAccountRepoFindByCPF struct {
 	domain.AccountRepository
 
-	result []domain.Account
+	result domain.Account
 	err    error
 }
 
-func (m mockAccountRepoFindAll) FindAll(_ context.Context) ([]domain.Account, error) {
+func (m mockAccountRepoFindByCPF) FindByCPF(_ context.Context, _ string) (domain.Account, error) {
 	return m.result, m.err
 }
 
+type mockAccountRepoFindAll struct {
+	domain.AccountRepository
+
+	result       []domain.Account
+	expectedPage domain.PageRequest
+}
+
+func (m mockAccountRepoFindAll) FindAll(_ context.Context, page domain.PageRequest) ([]domain.Account, error) {
+	if page != m.expectedPage {
+		return nil, errors.New("unexpected page")
+	}
+
+	if len(m.result) > page.Limit {
+		return m.result[:page.Limit], nil
+	}
+
+	return m.result, nil
+}
+
 type mockFindAllAccountPresenter struct {
 	result []FindAllAccountOutput
 }
@@ -33,31 +52,26 @@ func TestFindAllAccountInteractor_Execute(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name          string
-		repository    domain.AccountRepository
-		presenter     FindAllAccountPresenter
-		expected      []FindAllAccountOutput
-		expectedError interface{}
+		name               string
+		ctx                context.Context
+		page               domain.PageRequest
+		repository         domain.AccountRepository
+		presenter          FindAllAccountPresenter
+		expected           []FindAllAccountOutput
+		expectedNextCursor string
+		expectedError      interface{}
 	}{
 		{
 			name: "Success when returning the account list",
-			repository: mockAccountRepoFindAll{
-				result: []domain.Account{
-					domain.NewAccount(
-						"3c096a40-ccba-4b58-93ed-57379ab04680",
-						"Test",
-						"02815517078",
-						125,
-						time.Time{},
-					),
-					domain.NewAccount(
-						"3c096a40-ccba-4b58-93ed-57379ab04681",
-						"Test",
-						"02815517071",
-						99999,
-						time.Time{},
-					),
-				},
+			ctx:  customerContext("02815517078"),
+			repository: mockAccountRepoFindByCPF{
+				result: domain.NewAccount(
+					"3c096a40-ccba-4b58-93ed-57379ab04680",
+					"Test",
+					"02815517078",
+					125,
+					time.Time{},
+				),
 				err: nil,
 			},
 			presenter: mockFindAllAccountPresenter{
@@ -69,13 +83,6 @@ func TestFindAllAccountInteractor_Execute(t *testing.T) {
 						Balance:   1.25,
 						CreatedAt: time.Time{}.String(),
 					},
-					{
-						ID:        "3c096a40-ccba-4b58-93ed-57379ab04681",
-						Name:      "Test",
-						CPF:       "02815517071",
-						Balance:   999.99,
-						CreatedAt: time.Time{}.String(),
-					},
 				},
 			},
 			expected: []FindAllAccountOutput{
@@ -86,20 +93,14 @@ func TestFindAllAccountInteractor_Execute(t *testing.T) {
 					Balance:   1.25,
 					CreatedAt: time.Time{}.String(),
 				},
-				{
-					ID:        "3c096a40-ccba-4b58-93ed-57379ab04681",
-					Name:      "Test",
-					CPF:       "02815517071",
-					Balance:   999.99,
-					CreatedAt: time.Time{}.String(),
-				},
 			},
 		},
 		{
 			name: "Success when returning the empty account list",
-			repository: mockAccountRepoFindAll{
-				result: []domain.Account{},
-				err:    nil,
+			ctx:  customerContext("02815517078"),
+			repository: mockAccountRepoFindByCPF{
+				result: domain.Account{},
+				err:    domain.ErrAccountNotFound,
 			},
 			presenter: mockFindAllAccountPresenter{
 				result: []FindAllAccountOutput{},
@@ -108,8 +109,9 @@ func TestFindAllAccountInteractor_Execute(t *testing.T) {
 		},
 		{
 			name: "Error when returning the list of accounts",
-			repository: mockAccountRepoFindAll{
-				result: []domain.Account{},
+			ctx:  customerContext("02815517078"),
+			repository: mockAccountRepoFindByCPF{
+				result: domain.Account{},
 				err:    errors.New("error"),
 			},
 			presenter: mockFindAllAccountPresenter{
@@ -118,19 +120,79 @@ func TestFindAllAccountInteractor_Execute(t *testing.T) {
 			expectedError: "error",
 			expected:      []FindAllAccountOutput{},
 		},
+		{
+			name:       "Error when returning the list of accounts without principal",
+			ctx:        context.Background(),
+			repository: mockAccountRepoFindByCPF{},
+			presenter: mockFindAllAccountPresenter{
+				result: []FindAllAccountOutput{},
+			},
+			expectedError: "caller is not allowed to access this resource",
+			expected:      []FindAllAccountOutput{},
+		},
+		{
+			name: "Success when support lists a page followed by another",
+			ctx:  roleContext(domain.RoleSupport),
+			page: domain.PageRequest{Limit: 2},
+			repository: mockAccountRepoFindAll{
+				result: []domain.Account{
+					domain.NewAccount("3c096a40-ccba-4b58-93ed-57379ab04680", "Test", "02815517078", 0, time.Time{}),
+					domain.NewAccount("3c096a40-ccba-4b58-93ed-57379ab04681", "Test", "02815517078", 0, time.Time{}),
+					domain.NewAccount("3c096a40-ccba-4b58-93ed-57379ab04682", "Test", "02815517078", 0, time.Time{}),
+				},
+				expectedPage: domain.PageRequest{Limit: 3},
+			},
+			presenter: mockFindAllAccountPresenter{
+				result: []FindAllAccountOutput{{ID: "3c096a40-ccba-4b58-93ed-57379ab04680"}},
+			},
+			expected:           []FindAllAccountOutput{{ID: "3c096a40-ccba-4b58-93ed-57379ab04680"}},
+			expectedNextCursor: domain.NewCursor(time.Time{}, "3c096a40-ccba-4b58-93ed-57379ab04681").String(),
+		},
+		{
+			name: "Success when support lists the last page",
+			ctx:  roleContext(domain.RoleSupport),
+			page: domain.PageRequest{
+				Limit: 2,
+				After: domain.NewCursor(time.Time{}, "3c096a40-ccba-4b58-93ed-57379ab04681"),
+			},
+			repository: mockAccountRepoFindAll{
+				result: []domain.Account{
+					domain.NewAccount("3c096a40-ccba-4b58-93ed-57379ab04682", "Test", "02815517078", 0, time.Time{}),
+				},
+				expectedPage: domain.PageRequest{
+					Limit: 3,
+					After: domain.NewCursor(time.Time{}, "3c096a40-ccba-4b58-93ed-57379ab04681"),
+				},
+			},
+			presenter: mockFindAllAccountPresenter{
+				result: []FindAllAccountOutput{{ID: "3c096a40-ccba-4b58-93ed-57379ab04682"}},
+			},
+			expected: []FindAllAccountOutput{{ID: "3c096a40-ccba-4b58-93ed-57379ab04682"}},
+		},
+		{
+			name:       "Success when support asks for a page without a limit",
+			ctx:        roleContext(domain.RoleSupport),
+			repository: mockAccountRepoFindAll{expectedPage: domain.PageRequest{Limit: domain.DefaultPageSize + 1}},
+			presenter:  mockFindAllAccountPresenter{result: []FindAllAccountOutput{}},
+			expected:   []FindAllAccountOutput{},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var uc = NewFindAllAccountInteractor(tt.repository, tt.presenter, time.Second)
 
-			result, err := uc.Execute(context.Background())
+			result, err := uc.Execute(tt.ctx, tt.page)
 			if (err != nil) && (err.Error() != tt.expectedError) {
 				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
 			}
 
-			if !reflect.DeepEqual(result, tt.expected) {
-				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+			if !reflect.DeepEqual(result.Accounts, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result.Accounts, tt.expected)
+			}
+
+			if result.NextCursor != tt.expectedNextCursor {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result.NextCursor, tt.expectedNextCursor)
 			}
 		})
 	}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// FindAllAPIKeyUseCase input port
+	FindAllAPIKeyUseCase interface {
+		Execute(context.Context) ([]FindAllAPIKeyOutput, error)
+	}
+
+	// FindAllAPIKeyPresenter output port
+	FindAllAPIKeyPresenter interface {
+		Output([]domain.APIKey) []FindAllAPIKeyOutput
+	}
+
+	// FindAllAPIKeyOutput output data
+	FindAllAPIKeyOutput struct {
+		ID         string   `json:"id"`
+		Name       string   `json:"name"`
+		Prefix     string   `json:"prefix"`
+		Scopes     []string `json:"scopes"`
+		ExpiresAt  string   `json:"expires_at,omitempty"`
+		LastUsedAt string   `json:"last_used_at,omitempty"`
+		RevokedAt  string   `json:"revoked_at,omitempty"`
+		CreatedAt  string   `json:"created_at"`
+	}
+
+	findAllAPIKeyInteractor struct {
+		repo       domain.APIKeyRepository
+		presenter  FindAllAPIKeyPresenter
+		ctxTimeout time.Duration
+	}
+)
+
+// NewFindAllAPIKeyInteractor creates new findAllAPIKeyInteractor with its dependencies
+func NewFindAllAPIKeyInteractor(
+	repo domain.APIKeyRepository,
+	presenter FindAllAPIKeyPresenter,
+	t time.Duration,
+) FindAllAPIKeyUseCase {
+	return findAllAPIKeyInteractor{
+		repo:       repo,
+		presenter:  presenter,
+		ctxTimeout: t,
+	}
+}
+
+// Execute orchestrates the use case
+func (a findAllAPIKeyInteractor) Execute(ctx context.Context) ([]FindAllAPIKeyOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	if _, _, err := Authorize(ctx, OpFindAllAPIKey); err != nil {
+		return a.presenter.Output([]domain.APIKey{}), err
+	}
+
+	apiKeys, err := a.repo.FindAll(ctx)
+	if err != nil {
+		return a.presenter.Output([]domain.APIKey{}), err
+	}
+
+	return a.presenter.Output(apiKeys), nil
+}
@@ -8,14 +8,49 @@ import (
 )
 
 type (
-	// FindAllTransferUseCase input port
-	FindAllTransferUseCase interface {
-		Execute(context.Context) ([]FindAllTransferOutput, error)
+	// FindAllTransferUseCase input port of /v1
+	FindAllTransferUseCase = FindAllTransferUseCaseOf[FindAllTransferOutput]
+
+	// FindAllTransferUseCaseV2 input port of /v2
+	FindAllTransferUseCaseV2 = FindAllTransferUseCaseOf[TransferOutputV2]
+
+	// FindAllTransferUseCaseOf input port, answering with the page of the outputs of its presenter
+	FindAllTransferUseCaseOf[O any] interface {
+		Execute(context.Context, FindAllTransferInput) (FindAllTransferPageOutputOf[O], error)
+	}
+
+	// FindAllTransferInput input data. The empty fields leave the listing unfiltered by them, and the amounts are
+	// in cents
+	FindAllTransferInput struct {
+		AccountID            string `validate:"omitempty,uuid4"`
+		AccountOriginID      string `validate:"omitempty,uuid4"`
+		AccountDestinationID string `validate:"omitempty,uuid4,nefield=AccountOriginID"`
+		Status               string
+		From                 time.Time
+		To                   time.Time `validate:"omitempty,gtfield=From"`
+		MinAmount            int64     `validate:"gte=0"`
+		MaxAmount            int64     `validate:"omitempty,gtefield=MinAmount"`
+		Page                 domain.PageRequest
+	}
+
+	// FindAllTransferPresenter output port of /v1
+	FindAllTransferPresenter = FindAllTransferPresenterOf[FindAllTransferOutput]
+
+	// FindAllTransferPresenterV2 output port of /v2
+	FindAllTransferPresenterV2 = FindAllTransferPresenterOf[TransferOutputV2]
+
+	// FindAllTransferPresenterOf output port
+	FindAllTransferPresenterOf[O any] interface {
+		Output([]domain.Transfer) []O
 	}
 
-	// FindAllTransferPresenter output port
-	FindAllTransferPresenter interface {
-		Output([]domain.Transfer) []FindAllTransferOutput
+	// FindAllTransferPageOutput output data of /v1
+	FindAllTransferPageOutput = FindAllTransferPageOutputOf[FindAllTransferOutput]
+
+	// FindAllTransferPageOutputOf output data: a page of the transfers and the cursor of the next one, empty on the last
+	FindAllTransferPageOutputOf[O any] struct {
+		Transfers  []O    `json:"data"`
+		NextCursor string `json:"next_cursor,omitempty"`
 	}
 
 	// FindAllTransferOutput output data
@@ -24,38 +59,111 @@ type (
 		AccountOriginID      string  `json:"account_origin_id"`
 		AccountDestinationID string  `json:"account_destination_id"`
 		Amount               float64 `json:"amount"`
+		Status               string  `json:"status"`
+		FailureCode          string  `json:"failure_code,omitempty"`
 		CreatedAt            string  `json:"created_at"`
 	}
 
-	findAllTransferInteractor struct {
-		repo       domain.TransferRepository
-		presenter  FindAllTransferPresenter
-		ctxTimeout time.Duration
+	findAllTransferInteractor[O any] struct {
+		transferRepo domain.TransferRepository
+		accountRepo  domain.AccountRepository
+		presenter    FindAllTransferPresenterOf[O]
+		ctxTimeout   time.Duration
 	}
 )
 
 // NewFindAllTransferInteractor creates new findAllTransferInteractor with its dependencies
-func NewFindAllTransferInteractor(
-	repo domain.TransferRepository,
-	presenter FindAllTransferPresenter,
+func NewFindAllTransferInteractor[O any](
+	transferRepo domain.TransferRepository,
+	accountRepo domain.AccountRepository,
+	presenter FindAllTransferPresenterOf[O],
 	t time.Duration,
-) FindAllTransferUseCase {
-	return findAllTransferInteractor{
-		repo:       repo,
-		presenter:  presenter,
-		ctxTimeout: t,
+) FindAllTransferUseCaseOf[O] {
+	return findAllTransferInteractor[O]{
+		transferRepo: transferRepo,
+		accountRepo:  accountRepo,
+		presenter:    presenter,
+		ctxTimeout:   t,
 	}
 }
 
 // Execute orchestrates the use case
-func (t findAllTransferInteractor) Execute(ctx context.Context) ([]FindAllTransferOutput, error) {
+func (t findAllTransferInteractor[O]) Execute(
+	ctx context.Context,
+	input FindAllTransferInput,
+) (FindAllTransferPageOutputOf[O], error) {
 	ctx, cancel := context.WithTimeout(ctx, t.ctxTimeout)
 	defer cancel()
 
-	transfers, err := t.repo.FindAll(ctx)
+	principal, access, err := Authorize(ctx, OpFindAllTransfer)
+	if err != nil {
+		return t.output([]domain.Transfer{}, ""), err
+	}
+
+	var filter = domain.TransferFilter{
+		AccountID:            domain.AccountID(input.AccountID),
+		AccountOriginID:      domain.AccountID(input.AccountOriginID),
+		AccountDestinationID: domain.AccountID(input.AccountDestinationID),
+		Status:               domain.TransferStatus(input.Status),
+		From:                 input.From,
+		To:                   input.To,
+		MinAmount:            domain.Money(input.MinAmount),
+		MaxAmount:            domain.Money(input.MaxAmount),
+	}
+
+	// A holder only lists the transfers of their own account, asking for another one finds none
+	if access != AccessAny {
+		account, err := t.accountRepo.FindByCPF(domain.ContextWithReadYourWrites(ctx), principal.Subject())
+		if err != nil {
+			switch err {
+			case domain.ErrAccountNotFound:
+				return t.output([]domain.Transfer{}, ""), nil
+			default:
+				return t.output([]domain.Transfer{}, ""), err
+			}
+		}
+
+		if filter.AccountID != "" && filter.AccountID != account.ID() {
+			return t.output([]domain.Transfer{}, ""), nil
+		}
+
+		filter.AccountID = account.ID()
+	}
+
+	var ahead = pageAhead(input.Page)
+
+	transfers, err := t.transferRepo.FindAll(ctx, filter, ahead)
+	if err != nil {
+		return t.output([]domain.Transfer{}, ""), err
+	}
+
+	if len(transfers) < ahead.Limit {
+		return t.output(transfers, ""), nil
+	}
+
+	var last = transfers[ahead.Limit-2]
+	return t.output(transfers[:ahead.Limit-1], domain.NewTransferCursor(input.Page.Sort, last).String()), nil
+}
+
+func (t findAllTransferInteractor[O]) output(transfers []domain.Transfer, nextCursor string) FindAllTransferPageOutputOf[O] {
+	return FindAllTransferPageOutputOf[O]{Transfers: t.presenter.Output(transfers), NextCursor: nextCursor}
+}
+
+// findEveryTransfer lists every transfer matching the filter, for the use cases needing the whole listing
+func findEveryTransfer(
+	ctx context.Context,
+	repo domain.TransferRepository,
+	filter domain.TransferFilter,
+) ([]domain.Transfer, error) {
+	var transfers = make([]domain.Transfer, 0)
+
+	err := repo.Stream(ctx, filter, func(transfer domain.Transfer) error {
+		transfers = append(transfers, transfer)
+		return nil
+	})
 	if err != nil {
-		return t.presenter.Output([]domain.Transfer{}), err
+		return []domain.Transfer{}, err
 	}
 
-	return t.presenter.Output(transfers), nil
+	return transfers, nil
 }
@@ -13,11 +13,25 @@ import (
 type mockTransferRepoFindAll struct {
 	domain.TransferRepository
 
-	result []domain.Transfer
-	err    error
+	result         []domain.Transfer
+	err            error
+	expectedFilter *domain.TransferFilter
+	expectedPage   *domain.PageRequest
 }
 
-func (m mockTransferRepoFindAll) FindAll(_ context.Context) ([]domain.Transfer, error) {
+func (m mockTransferRepoFindAll) FindAll(
+	_ context.Context,
+	filter domain.TransferFilter,
+	page domain.PageRequest,
+) ([]domain.Transfer, error) {
+	if m.expectedFilter != nil && *m.expectedFilter != filter {
+		return nil, errors.New("unexpected filter")
+	}
+
+	if m.expectedPage != nil && *m.expectedPage != page {
+		return nil, errors.New("unexpected page")
+	}
+
 	return m.result, m.err
 }
 
@@ -33,14 +47,28 @@ func TestTransferFindAllInteractor_Execute(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name          string
-		expected      []FindAllTransferOutput
-		transferRepo  domain.TransferRepository
-		presenter     FindAllTransferPresenter
-		expectedError string
+		name               string
+		ctx                context.Context
+		input              FindAllTransferInput
+		expected           []FindAllTransferOutput
+		expectedNextCursor string
+		transferRepo       domain.TransferRepository
+		accountRepo        domain.AccountRepository
+		presenter          FindAllTransferPresenter
+		expectedError      string
 	}{
 		{
 			name: "Success when returning the transfer list",
+			ctx:  customerContext("08098565895"),
+			accountRepo: mockAccountRepoFindByCPF{
+				result: domain.NewAccount(
+					"3c096a40-ccba-4b58-93ed-57379ab04681",
+					"Test",
+					"08098565895",
+					5000,
+					time.Time{},
+				),
+			},
 			transferRepo: mockTransferRepoFindAll{
 				result: []domain.Transfer{
 					domain.NewTransfer(
@@ -48,6 +76,7 @@ func TestTransferFindAllInteractor_Execute(t *testing.T) {
 						"3c096a40-ccba-4b58-93ed-57379ab04681",
 						"3c096a40-ccba-4b58-93ed-57379ab04682",
 						100,
+						domain.TransferStatusCompleted,
 						time.Time{},
 					),
 					domain.NewTransfer(
@@ -55,6 +84,7 @@ func TestTransferFindAllInteractor_Execute(t *testing.T) {
 						"3c096a40-ccba-4b58-93ed-57379ab04681",
 						"3c096a40-ccba-4b58-93ed-57379ab04682",
 						500,
+						domain.TransferStatusCompleted,
 						time.Time{},
 					),
 				},
@@ -97,6 +127,16 @@ func TestTransferFindAllInteractor_Execute(t *testing.T) {
 		},
 		{
 			name: "Success when returning the empty transfer list",
+			ctx:  customerContext("08098565895"),
+			accountRepo: mockAccountRepoFindByCPF{
+				result: domain.NewAccount(
+					"3c096a40-ccba-4b58-93ed-57379ab04681",
+					"Test",
+					"08098565895",
+					5000,
+					time.Time{},
+				),
+			},
 			transferRepo: mockTransferRepoFindAll{
 				result: []domain.Transfer{},
 				err:    nil,
@@ -108,6 +148,16 @@ func TestTransferFindAllInteractor_Execute(t *testing.T) {
 		},
 		{
 			name: "Error when returning the transfer list",
+			ctx:  customerContext("08098565895"),
+			accountRepo: mockAccountRepoFindByCPF{
+				result: domain.NewAccount(
+					"3c096a40-ccba-4b58-93ed-57379ab04681",
+					"Test",
+					"08098565895",
+					5000,
+					time.Time{},
+				),
+			},
 			transferRepo: mockTransferRepoFindAll{
 				result: []domain.Transfer{},
 				err:    errors.New("error"),
@@ -118,20 +168,242 @@ func TestTransferFindAllInteractor_Execute(t *testing.T) {
 			expected:      []FindAllTransferOutput{},
 			expectedError: "error",
 		},
+		{
+			name: "Success when returning the empty transfer list for holder without account",
+			ctx:  customerContext("08098565895"),
+			accountRepo: mockAccountRepoFindByCPF{
+				err: domain.ErrAccountNotFound,
+			},
+			transferRepo: mockTransferRepoFindAll{
+				result: []domain.Transfer{},
+				err:    nil,
+			},
+			presenter: mockFindAllTransferPresenter{
+				result: []FindAllTransferOutput{},
+			},
+			expected: []FindAllTransferOutput{},
+		},
+		{
+			name:         "Error when returning the transfer list without principal",
+			ctx:          context.Background(),
+			accountRepo:  mockAccountRepoFindByCPF{},
+			transferRepo: mockTransferRepoFindAll{},
+			presenter: mockFindAllTransferPresenter{
+				result: []FindAllTransferOutput{},
+			},
+			expected:      []FindAllTransferOutput{},
+			expectedError: "caller is not allowed to access this resource",
+		},
+		{
+			name:        "Success when support lists transfers of every account",
+			ctx:         roleContext(domain.RoleSupport),
+			accountRepo: mockAccountRepoFindByCPF{err: domain.ErrAccountNotFound},
+			transferRepo: mockTransferRepoFindAll{
+				result: []domain.Transfer{},
+			},
+			presenter: mockFindAllTransferPresenter{
+				result: []FindAllTransferOutput{{ID: "3c096a40-ccba-4b58-93ed-57379ab04680"}},
+			},
+			expected: []FindAllTransferOutput{{ID: "3c096a40-ccba-4b58-93ed-57379ab04680"}},
+		},
+		{
+			name:  "Success when filtering the transfer list of the holder by status",
+			ctx:   customerContext("08098565895"),
+			input: FindAllTransferInput{Status: "failed"},
+			accountRepo: mockAccountRepoFindByCPF{
+				result: domain.NewAccount(
+					"3c096a40-ccba-4b58-93ed-57379ab04681",
+					"Test",
+					"08098565895",
+					5000,
+					time.Time{},
+				),
+			},
+			transferRepo: mockTransferRepoFindAll{
+				result: []domain.Transfer{},
+				expectedFilter: &domain.TransferFilter{
+					AccountID: "3c096a40-ccba-4b58-93ed-57379ab04681",
+					Status:    domain.TransferStatusFailed,
+				},
+			},
+			presenter: mockFindAllTransferPresenter{
+				result: []FindAllTransferOutput{{ID: "3c096a40-ccba-4b58-93ed-57379ab04680", Status: "failed"}},
+			},
+			expected: []FindAllTransferOutput{{ID: "3c096a40-ccba-4b58-93ed-57379ab04680", Status: "failed"}},
+		},
+		{
+			name:        "Success when support filters transfers of every account by status",
+			ctx:         roleContext(domain.RoleSupport),
+			input:       FindAllTransferInput{Status: "held"},
+			accountRepo: mockAccountRepoFindByCPF{err: domain.ErrAccountNotFound},
+			transferRepo: mockTransferRepoFindAll{
+				result:         []domain.Transfer{},
+				expectedFilter: &domain.TransferFilter{Status: domain.TransferStatusHeld},
+			},
+			presenter: mockFindAllTransferPresenter{
+				result: []FindAllTransferOutput{},
+			},
+			expected: []FindAllTransferOutput{},
+		},
+		{
+			name:        "Success when support lists a page of transfers followed by another",
+			ctx:         roleContext(domain.RoleSupport),
+			input:       FindAllTransferInput{Page: domain.PageRequest{Limit: 1}},
+			accountRepo: mockAccountRepoFindByCPF{err: domain.ErrAccountNotFound},
+			transferRepo: mockTransferRepoFindAll{
+				result: []domain.Transfer{
+					domain.NewTransfer(
+						"3c096a40-ccba-4b58-93ed-57379ab04680",
+						"3c096a40-ccba-4b58-93ed-57379ab04681",
+						"3c096a40-ccba-4b58-93ed-57379ab04682",
+						100,
+						domain.TransferStatusCompleted,
+						time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+					),
+					domain.NewTransfer(
+						"3c096a40-ccba-4b58-93ed-57379ab04683",
+						"3c096a40-ccba-4b58-93ed-57379ab04681",
+						"3c096a40-ccba-4b58-93ed-57379ab04682",
+						500,
+						domain.TransferStatusCompleted,
+						time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+					),
+				},
+				expectedPage: &domain.PageRequest{Limit: 2},
+			},
+			presenter: mockFindAllTransferPresenter{
+				result: []FindAllTransferOutput{{ID: "3c096a40-ccba-4b58-93ed-57379ab04680"}},
+			},
+			expected: []FindAllTransferOutput{{ID: "3c096a40-ccba-4b58-93ed-57379ab04680"}},
+			expectedNextCursor: domain.NewCursor(
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				"3c096a40-ccba-4b58-93ed-57379ab04680",
+			).String(),
+		},
+		{
+			name: "Success when the holder asks for the page after a cursor",
+			ctx:  customerContext("08098565895"),
+			input: FindAllTransferInput{Page: domain.PageRequest{
+				Limit: 10,
+				After: domain.NewCursor(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "3c096a40-ccba-4b58-93ed-57379ab04680"),
+			}},
+			accountRepo: mockAccountRepoFindByCPF{
+				result: domain.NewAccount("3c096a40-ccba-4b58-93ed-57379ab04681", "Test", "08098565895", 5000, time.Time{}),
+			},
+			transferRepo: mockTransferRepoFindAll{
+				result: []domain.Transfer{},
+				expectedPage: &domain.PageRequest{
+					Limit: 11,
+					After: domain.NewCursor(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "3c096a40-ccba-4b58-93ed-57379ab04680"),
+				},
+			},
+			presenter: mockFindAllTransferPresenter{
+				result: []FindAllTransferOutput{},
+			},
+			expected: []FindAllTransferOutput{},
+		},
+		{
+			name: "Success when support filters transfers and pages them by amount",
+			ctx:  roleContext(domain.RoleSupport),
+			input: FindAllTransferInput{
+				AccountOriginID: "3c096a40-ccba-4b58-93ed-57379ab04681",
+				From:            time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:              time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+				MinAmount:       100,
+				MaxAmount:       1000,
+				Page:            domain.PageRequest{Limit: 1, Sort: domain.Sort{Key: domain.SortByAmount, Descending: true}},
+			},
+			accountRepo: mockAccountRepoFindByCPF{err: domain.ErrAccountNotFound},
+			transferRepo: mockTransferRepoFindAll{
+				result: []domain.Transfer{
+					domain.NewTransfer(
+						"3c096a40-ccba-4b58-93ed-57379ab04683",
+						"3c096a40-ccba-4b58-93ed-57379ab04681",
+						"3c096a40-ccba-4b58-93ed-57379ab04682",
+						500,
+						domain.TransferStatusCompleted,
+						time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+					),
+					domain.NewTransfer(
+						"3c096a40-ccba-4b58-93ed-57379ab04680",
+						"3c096a40-ccba-4b58-93ed-57379ab04681",
+						"3c096a40-ccba-4b58-93ed-57379ab04682",
+						100,
+						domain.TransferStatusCompleted,
+						time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+					),
+				},
+				expectedFilter: &domain.TransferFilter{
+					AccountOriginID: "3c096a40-ccba-4b58-93ed-57379ab04681",
+					From:            time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:              time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+					MinAmount:       100,
+					MaxAmount:       1000,
+				},
+				expectedPage: &domain.PageRequest{Limit: 2, Sort: domain.Sort{Key: domain.SortByAmount, Descending: true}},
+			},
+			presenter: mockFindAllTransferPresenter{
+				result: []FindAllTransferOutput{{ID: "3c096a40-ccba-4b58-93ed-57379ab04683"}},
+			},
+			expected: []FindAllTransferOutput{{ID: "3c096a40-ccba-4b58-93ed-57379ab04683"}},
+			expectedNextCursor: domain.Cursor{
+				Sort:   domain.Sort{Key: domain.SortByAmount, Descending: true},
+				Amount: 500,
+				ID:     "3c096a40-ccba-4b58-93ed-57379ab04683",
+			}.String(),
+		},
+		{
+			name:  "Success when the holder filters the transfers of their account by destination",
+			ctx:   customerContext("08098565895"),
+			input: FindAllTransferInput{AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682"},
+			accountRepo: mockAccountRepoFindByCPF{
+				result: domain.NewAccount("3c096a40-ccba-4b58-93ed-57379ab04681", "Test", "08098565895", 5000, time.Time{}),
+			},
+			transferRepo: mockTransferRepoFindAll{
+				result: []domain.Transfer{},
+				expectedFilter: &domain.TransferFilter{
+					AccountID:            "3c096a40-ccba-4b58-93ed-57379ab04681",
+					AccountDestinationID: "3c096a40-ccba-4b58-93ed-57379ab04682",
+				},
+			},
+			presenter: mockFindAllTransferPresenter{
+				result: []FindAllTransferOutput{},
+			},
+			expected: []FindAllTransferOutput{},
+		},
+		{
+			name:  "Success when returning the empty transfer list for holder asking for another account",
+			ctx:   customerContext("08098565895"),
+			input: FindAllTransferInput{AccountID: "3c096a40-ccba-4b58-93ed-57379ab04682"},
+			accountRepo: mockAccountRepoFindByCPF{
+				result: domain.NewAccount("3c096a40-ccba-4b58-93ed-57379ab04681", "Test", "08098565895", 5000, time.Time{}),
+			},
+			transferRepo: mockTransferRepoFindAll{
+				err: errors.New("listed the transfers of another account"),
+			},
+			presenter: mockFindAllTransferPresenter{
+				result: []FindAllTransferOutput{},
+			},
+			expected: []FindAllTransferOutput{},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var uc = NewFindAllTransferInteractor(tt.transferRepo, tt.presenter, time.Second)
+			var uc = NewFindAllTransferInteractor(tt.transferRepo, tt.accountRepo, tt.presenter, time.Second)
 
-			result, err := uc.Execute(context.Background())
+			result, err := uc.Execute(tt.ctx, tt.input)
 			if (err != nil) && (err.Error() != tt.expectedError) {
 				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
 				return
 			}
 
-			if !reflect.DeepEqual(result, tt.expected) {
-				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+			if !reflect.DeepEqual(result.Transfers, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result.Transfers, tt.expected)
+			}
+
+			if result.NextCursor != tt.expectedNextCursor {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result.NextCursor, tt.expectedNextCursor)
 			}
 		})
 	}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// FindAllWebhookUseCase input port
+	FindAllWebhookUseCase interface {
+		Execute(context.Context) ([]WebhookOutput, error)
+	}
+
+	// FindAllWebhookPresenter output port
+	FindAllWebhookPresenter interface {
+		Output([]domain.Webhook) []WebhookOutput
+	}
+
+	// WebhookOutput output data, without the secret
+	WebhookOutput struct {
+		ID         string   `json:"id"`
+		URL        string   `json:"url"`
+		Events     []string `json:"events"`
+		AccountIDs []string `json:"account_ids"`
+		Enabled    bool     `json:"enabled"`
+		Failures   int      `json:"failures"`
+		DisabledAt string   `json:"disabled_at,omitempty"`
+		CreatedAt  string   `json:"created_at"`
+	}
+
+	findAllWebhookInteractor struct {
+		repo       domain.WebhookRepository
+		presenter  FindAllWebhookPresenter
+		ctxTimeout time.Duration
+	}
+)
+
+// NewFindAllWebhookInteractor creates new findAllWebhookInteractor with its dependencies
+func NewFindAllWebhookInteractor(
+	repo domain.WebhookRepository,
+	presenter FindAllWebhookPresenter,
+	t time.Duration,
+) FindAllWebhookUseCase {
+	return findAllWebhookInteractor{
+		repo:       repo,
+		presenter:  presenter,
+		ctxTimeout: t,
+	}
+}
+
+// Execute orchestrates the use case. Clients only see the webhooks they created
+func (a findAllWebhookInteractor) Execute(ctx context.Context) ([]WebhookOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	principal, access, err := Authorize(ctx, OpFindAllWebhook)
+	if err != nil {
+		return a.presenter.Output([]domain.Webhook{}), err
+	}
+
+	webhooks, err := a.repo.FindAll(ctx)
+	if err != nil {
+		return a.presenter.Output([]domain.Webhook{}), err
+	}
+
+	if access == AccessAny {
+		return a.presenter.Output(webhooks), nil
+	}
+
+	var owned = make([]domain.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if webhook.OwnedBy(principal) {
+			owned = append(owned, webhook)
+		}
+	}
+
+	return a.presenter.Output(owned), nil
+}
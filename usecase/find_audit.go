@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// findAuditLimit bounds the records answered by a query of the audit trail
+const findAuditLimit = 1000
+
+type (
+	// FindAuditUseCase input port
+	FindAuditUseCase interface {
+		Execute(context.Context, FindAuditInput) ([]FindAuditOutput, error)
+	}
+
+	// FindAuditInput input data. Zero fields match every record
+	FindAuditInput struct {
+		Actor  string
+		Target string
+		From   time.Time
+		To     time.Time
+	}
+
+	// FindAuditPresenter output port
+	FindAuditPresenter interface {
+		Output([]domain.AuditRecord) []FindAuditOutput
+	}
+
+	// FindAuditOutput output data
+	FindAuditOutput struct {
+		ID         string          `json:"id"`
+		Actor      string          `json:"actor"`
+		Role       string          `json:"role"`
+		Action     string          `json:"action"`
+		Targets    []string        `json:"targets"`
+		InputHash  string          `json:"input_hash"`
+		Payload    json.RawMessage `json:"payload"`
+		Outcome    string          `json:"outcome"`
+		Error      string          `json:"error,omitempty"`
+		RequestID  string          `json:"request_id,omitempty"`
+		OccurredAt string          `json:"occurred_at"`
+	}
+
+	findAuditInteractor struct {
+		repo       domain.AuditRepository
+		presenter  FindAuditPresenter
+		ctxTimeout time.Duration
+	}
+)
+
+// NewFindAuditInteractor creates new findAuditInteractor with its dependencies
+func NewFindAuditInteractor(
+	repo domain.AuditRepository,
+	presenter FindAuditPresenter,
+	t time.Duration,
+) FindAuditUseCase {
+	return findAuditInteractor{
+		repo:       repo,
+		presenter:  presenter,
+		ctxTimeout: t,
+	}
+}
+
+// Execute orchestrates the use case
+func (a findAuditInteractor) Execute(ctx context.Context, input FindAuditInput) ([]FindAuditOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	if _, _, err := Authorize(ctx, OpFindAudit); err != nil {
+		return a.presenter.Output([]domain.AuditRecord{}), err
+	}
+
+	records, err := a.repo.Find(ctx, domain.AuditFilter{
+		Actor:  input.Actor,
+		Target: input.Target,
+		From:   input.From,
+		To:     input.To,
+		Limit:  findAuditLimit,
+	})
+	if err != nil {
+		return a.presenter.Output([]domain.AuditRecord{}), err
+	}
+
+	return a.presenter.Output(records), nil
+}
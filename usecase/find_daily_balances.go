@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// FindDailyBalancesUseCase input port
+	FindDailyBalancesUseCase interface {
+		Execute(context.Context, FindDailyBalancesInput) ([]DailyBalanceOutput, error)
+	}
+
+	// FindDailyBalancesInput input data, the days between From and To, both included
+	FindDailyBalancesInput struct {
+		AccountID domain.AccountID
+		From      time.Time
+		To        time.Time
+	}
+
+	// FindDailyBalancesPresenter output port
+	FindDailyBalancesPresenter interface {
+		Output([]domain.DailyBalance) []DailyBalanceOutput
+	}
+
+	// DailyBalanceOutput output data
+	DailyBalanceOutput struct {
+		Date             string  `json:"date"`
+		ClosingBalance   float64 `json:"closing_balance"`
+		Credits          float64 `json:"credits"`
+		Debits           float64 `json:"debits"`
+		TransactionCount int     `json:"transaction_count"`
+		Interest         float64 `json:"interest"`
+	}
+
+	findDailyBalancesInteractor struct {
+		accountRepo      domain.AccountRepository
+		dailyBalanceRepo domain.DailyBalanceRepository
+		tariff           TariffConfig
+		presenter        FindDailyBalancesPresenter
+		ctxTimeout       time.Duration
+	}
+)
+
+// NewFindDailyBalancesInteractor creates new findDailyBalancesInteractor with its dependencies
+func NewFindDailyBalancesInteractor(
+	accountRepo domain.AccountRepository,
+	dailyBalanceRepo domain.DailyBalanceRepository,
+	tariff TariffConfig,
+	presenter FindDailyBalancesPresenter,
+	t time.Duration,
+) FindDailyBalancesUseCase {
+	return findDailyBalancesInteractor{
+		accountRepo:      accountRepo,
+		dailyBalanceRepo: dailyBalanceRepo,
+		tariff:           tariff,
+		presenter:        presenter,
+		ctxTimeout:       t,
+	}
+}
+
+// Execute returns the snapshots of the days already closed in the period, with the interest their balances accrued
+func (f findDailyBalancesInteractor) Execute(
+	ctx context.Context,
+	input FindDailyBalancesInput,
+) ([]DailyBalanceOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.ctxTimeout)
+	defer cancel()
+
+	if err := authorizeAccount(ctx, f.accountRepo, OpFindDailyBalances, input.AccountID); err != nil {
+		return f.presenter.Output([]domain.DailyBalance{}), err
+	}
+
+	balances, err := f.dailyBalanceRepo.FindByAccountID(
+		ctx,
+		input.AccountID,
+		domain.StartOfDay(input.From),
+		domain.StartOfDay(input.To),
+	)
+	if err != nil {
+		return f.presenter.Output([]domain.DailyBalance{}), err
+	}
+
+	balances, err = f.tariff.AccrueInterest(balances)
+	if err != nil {
+		return f.presenter.Output([]domain.DailyBalance{}), err
+	}
+
+	return f.presenter.Output(balances), nil
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// FindNotificationPreferenceUseCase input port
+	FindNotificationPreferenceUseCase interface {
+		Execute(context.Context, domain.AccountID) (NotificationPreferenceOutput, error)
+	}
+
+	// FindNotificationPreferencePresenter output port
+	FindNotificationPreferencePresenter interface {
+		Output(domain.NotificationPreference) NotificationPreferenceOutput
+	}
+
+	// NotificationPreferenceOutput output data
+	NotificationPreferenceOutput struct {
+		AccountID           string `json:"account_id"`
+		Email               string `json:"email"`
+		Locale              string `json:"locale"`
+		TransferReceived    bool   `json:"transfer_received"`
+		LowBalanceThreshold string `json:"low_balance_threshold"`
+		UpdatedAt           string `json:"updated_at"`
+	}
+
+	findNotificationPreferenceInteractor struct {
+		accountRepo    domain.AccountRepository
+		preferenceRepo domain.NotificationPreferenceRepository
+		presenter      FindNotificationPreferencePresenter
+		ctxTimeout     time.Duration
+	}
+)
+
+// NewFindNotificationPreferenceInteractor creates new findNotificationPreferenceInteractor with its dependencies
+func NewFindNotificationPreferenceInteractor(
+	accountRepo domain.AccountRepository,
+	preferenceRepo domain.NotificationPreferenceRepository,
+	presenter FindNotificationPreferencePresenter,
+	t time.Duration,
+) FindNotificationPreferenceUseCase {
+	return findNotificationPreferenceInteractor{
+		accountRepo:    accountRepo,
+		preferenceRepo: preferenceRepo,
+		presenter:      presenter,
+		ctxTimeout:     t,
+	}
+}
+
+// Execute orchestrates the use case
+func (a findNotificationPreferenceInteractor) Execute(
+	ctx context.Context,
+	ID domain.AccountID,
+) (NotificationPreferenceOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	if err := authorizeAccount(ctx, a.accountRepo, OpFindNotificationPreference, ID); err != nil {
+		return a.presenter.Output(domain.NotificationPreference{}), err
+	}
+
+	preference, err := a.preferenceRepo.FindByAccountID(ctx, ID)
+	if err != nil {
+		return a.presenter.Output(domain.NotificationPreference{}), err
+	}
+
+	return a.presenter.Output(preference), nil
+}
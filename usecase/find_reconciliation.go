@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// FindReconciliationUseCase input port
+	FindReconciliationUseCase interface {
+		Execute(context.Context, domain.ReconciliationRunID) (ReconciliationOutput, error)
+	}
+
+	// ReconciliationPresenter output port
+	ReconciliationPresenter interface {
+		Output(domain.ReconciliationRun) ReconciliationOutput
+	}
+
+	// ReconciliationOutput output data
+	ReconciliationOutput struct {
+		ID              string                         `json:"id"`
+		Status          string                         `json:"status"`
+		Trigger         string                         `json:"trigger"`
+		AccountsChecked int                            `json:"accounts_checked"`
+		Mismatches      []ReconciliationMismatchOutput `json:"mismatches"`
+		Unverifiable    []string                       `json:"unverifiable"`
+		Error           string                         `json:"error,omitempty"`
+		RequestedAt     string                         `json:"requested_at"`
+		StartedAt       string                         `json:"started_at,omitempty"`
+		FinishedAt      string                         `json:"finished_at,omitempty"`
+	}
+
+	// ReconciliationMismatchOutput output data
+	ReconciliationMismatchOutput struct {
+		AccountID       string  `json:"account_id"`
+		StoredBalance   float64 `json:"stored_balance"`
+		ExpectedBalance float64 `json:"expected_balance"`
+		Difference      float64 `json:"difference"`
+	}
+
+	findReconciliationInteractor struct {
+		repo       domain.ReconciliationRepository
+		presenter  ReconciliationPresenter
+		ctxTimeout time.Duration
+	}
+)
+
+// NewFindReconciliationInteractor creates new findReconciliationInteractor with its dependencies
+func NewFindReconciliationInteractor(
+	repo domain.ReconciliationRepository,
+	presenter ReconciliationPresenter,
+	t time.Duration,
+) FindReconciliationUseCase {
+	return findReconciliationInteractor{
+		repo:       repo,
+		presenter:  presenter,
+		ctxTimeout: t,
+	}
+}
+
+// Execute orchestrates the use case
+func (f findReconciliationInteractor) Execute(
+	ctx context.Context,
+	ID domain.ReconciliationRunID,
+) (ReconciliationOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.ctxTimeout)
+	defer cancel()
+
+	if _, _, err := Authorize(ctx, OpFindReconciliation); err != nil {
+		return f.presenter.Output(domain.ReconciliationRun{}), err
+	}
+
+	run, err := f.repo.FindByID(ctx, ID)
+	if err != nil {
+		return f.presenter.Output(domain.ReconciliationRun{}), err
+	}
+
+	return f.presenter.Output(run), nil
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// FindTransferApprovalsUseCase input port
+	FindTransferApprovalsUseCase interface {
+		Execute(context.Context, domain.TransferID) ([]FindTransferApprovalsOutput, error)
+	}
+
+	// FindTransferApprovalsPresenter output port
+	FindTransferApprovalsPresenter interface {
+		Output([]domain.TransferApproval) []FindTransferApprovalsOutput
+	}
+
+	// FindTransferApprovalsOutput output data
+	FindTransferApprovalsOutput struct {
+		ID         string `json:"id"`
+		TransferID string `json:"transfer_id"`
+		Action     string `json:"action"`
+		Actor      string `json:"actor"`
+		Role       string `json:"role,omitempty"`
+		Reason     string `json:"reason,omitempty"`
+		CreatedAt  string `json:"created_at"`
+	}
+
+	findTransferApprovalsInteractor struct {
+		transferRepo domain.TransferRepository
+		accountRepo  domain.AccountRepository
+		approvalRepo domain.TransferApprovalRepository
+		presenter    FindTransferApprovalsPresenter
+		ctxTimeout   time.Duration
+	}
+)
+
+// NewFindTransferApprovalsInteractor creates new findTransferApprovalsInteractor with its dependencies
+func NewFindTransferApprovalsInteractor(
+	transferRepo domain.TransferRepository,
+	accountRepo domain.AccountRepository,
+	approvalRepo domain.TransferApprovalRepository,
+	presenter FindTransferApprovalsPresenter,
+	t time.Duration,
+) FindTransferApprovalsUseCase {
+	return findTransferApprovalsInteractor{
+		transferRepo: transferRepo,
+		accountRepo:  accountRepo,
+		approvalRepo: approvalRepo,
+		presenter:    presenter,
+		ctxTimeout:   t,
+	}
+}
+
+// Execute returns the approval history of the transfer, oldest step first
+func (t findTransferApprovalsInteractor) Execute(
+	ctx context.Context,
+	ID domain.TransferID,
+) ([]FindTransferApprovalsOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.ctxTimeout)
+	defer cancel()
+
+	principal, access, err := Authorize(ctx, OpFindTransferApprovals)
+	if err != nil {
+		return t.presenter.Output([]domain.TransferApproval{}), err
+	}
+
+	transfer, err := t.transferRepo.FindByID(domain.ContextWithReadYourWrites(ctx), ID)
+	if err != nil {
+		return t.presenter.Output([]domain.TransferApproval{}), err
+	}
+
+	if access == AccessOwn {
+		account, err := t.accountRepo.FindByCPF(domain.ContextWithReadYourWrites(ctx), principal.Subject())
+		if err != nil && err != domain.ErrAccountNotFound {
+			return t.presenter.Output([]domain.TransferApproval{}), err
+		}
+
+		if err != nil || (account.ID() != transfer.AccountOriginID() && account.ID() != transfer.AccountDestinationID()) {
+			return t.presenter.Output([]domain.TransferApproval{}), domain.ErrForbidden
+		}
+	}
+
+	approvals, err := t.approvalRepo.FindAllByTransfer(ctx, ID)
+	if err != nil {
+		return t.presenter.Output([]domain.TransferApproval{}), err
+	}
+
+	return t.presenter.Output(approvals), nil
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// FindTransferImportUseCase input port
+	FindTransferImportUseCase interface {
+		Execute(context.Context, domain.TransferImportID) (TransferImportOutput, error)
+	}
+
+	// TransferImportPresenter output port
+	TransferImportPresenter interface {
+		Output(domain.TransferImport) TransferImportOutput
+	}
+
+	// TransferImportOutput output data. Pending counts the lines still to be run
+	TransferImportOutput struct {
+		ID         string                     `json:"id"`
+		FileName   string                     `json:"file_name,omitempty"`
+		Format     string                     `json:"format"`
+		Status     string                     `json:"status"`
+		Lines      int                        `json:"lines"`
+		Pending    int                        `json:"pending"`
+		Created    int                        `json:"created"`
+		Failed     int                        `json:"failed"`
+		Invalid    int                        `json:"invalid"`
+		Results    []TransferImportLineOutput `json:"results"`
+		CreatedAt  string                     `json:"created_at"`
+		StartedAt  string                     `json:"started_at,omitempty"`
+		FinishedAt string                     `json:"finished_at,omitempty"`
+	}
+
+	// TransferImportLineOutput output data
+	TransferImportLineOutput struct {
+		Line                 int      `json:"line"`
+		AccountOriginID      string   `json:"account_origin_id"`
+		AccountDestinationID string   `json:"account_destination_id"`
+		Amount               float64  `json:"amount"`
+		Status               string   `json:"status"`
+		TransferID           string   `json:"transfer_id,omitempty"`
+		TransferStatus       string   `json:"transfer_status,omitempty"`
+		Errors               []string `json:"errors,omitempty"`
+	}
+
+	findTransferImportInteractor struct {
+		repo       domain.TransferImportRepository
+		presenter  TransferImportPresenter
+		ctxTimeout time.Duration
+	}
+)
+
+// NewFindTransferImportInteractor creates new findTransferImportInteractor with its dependencies
+func NewFindTransferImportInteractor(
+	repo domain.TransferImportRepository,
+	presenter TransferImportPresenter,
+	t time.Duration,
+) FindTransferImportUseCase {
+	return findTransferImportInteractor{
+		repo:       repo,
+		presenter:  presenter,
+		ctxTimeout: t,
+	}
+}
+
+// Execute returns the job with the result of every line. Customers only see the files they uploaded
+func (f findTransferImportInteractor) Execute(
+	ctx context.Context,
+	ID domain.TransferImportID,
+) (TransferImportOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.ctxTimeout)
+	defer cancel()
+
+	principal, access, err := Authorize(ctx, OpFindTransferImport)
+	if err != nil {
+		return f.presenter.Output(domain.TransferImport{}), err
+	}
+
+	job, err := f.repo.FindByID(domain.ContextWithReadYourWrites(ctx), ID)
+	if err != nil {
+		return f.presenter.Output(domain.TransferImport{}), err
+	}
+
+	if access != AccessAny && job.RequestedBy().Subject() != principal.Subject() {
+		return f.presenter.Output(domain.TransferImport{}), domain.ErrForbidden
+	}
+
+	return f.presenter.Output(job), nil
+}
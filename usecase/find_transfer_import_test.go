@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type mockTransferImportRepoFind struct {
+	domain.TransferImportRepository
+
+	job domain.TransferImport
+}
+
+func (m mockTransferImportRepoFind) FindByID(_ context.Context, ID domain.TransferImportID) (domain.TransferImport, error) {
+	if ID != m.job.ID() {
+		return domain.TransferImport{}, domain.ErrTransferImportNotFound
+	}
+
+	return m.job, nil
+}
+
+func TestFindTransferImportInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	const clientID = "0db298eb-c8e7-4829-84b7-c1036b4f0791"
+
+	var job = domain.NewPendingTransferImport(
+		"0b4c4b5e-8a0f-4f0c-9f4d-7c2c5f1d9e11",
+		"payroll.csv",
+		domain.TransferImportCSV,
+		"",
+		domain.NewClientPrincipal(clientID, domain.ScopeTransfersWrite, domain.ScopeTransfersRead),
+		nil,
+		time.Now(),
+	)
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		ID            domain.TransferImportID
+		expectedID    string
+		expectedError error
+	}{
+		{
+			name:       "Client finds the job uploaded with a key since rotated, as both belong to it",
+			ctx:        clientContext(clientID, domain.ScopeTransfersRead),
+			ID:         job.ID(),
+			expectedID: job.ID().String(),
+		},
+		{
+			name:          "Another client does not find the job",
+			ctx:           clientContext("5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10", domain.ScopeTransfersRead),
+			ID:            job.ID(),
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name:       "Support finds any job",
+			ctx:        roleContext(domain.RoleSupport),
+			ID:         job.ID(),
+			expectedID: job.ID().String(),
+		},
+		{
+			name:          "Job not found",
+			ctx:           roleContext(domain.RoleAdmin),
+			ID:            "7d1e2f3a-4b5c-4d6e-8f9a-0b1c2d3e4f5a",
+			expectedError: domain.ErrTransferImportNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uc = NewFindTransferImportInteractor(
+				mockTransferImportRepoFind{job: job},
+				mockTransferImportPresenter{},
+				time.Second,
+			)
+
+			got, err := uc.Execute(tt.ctx, tt.ID)
+			if err != tt.expectedError {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+			}
+
+			if got.ID != tt.expectedID {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got.ID, tt.expectedID)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// FindWebhookDeliveriesUseCase input port
+	FindWebhookDeliveriesUseCase interface {
+		Execute(context.Context, domain.WebhookID) ([]WebhookDeliveryOutput, error)
+	}
+
+	// FindWebhookDeliveriesPresenter output port
+	FindWebhookDeliveriesPresenter interface {
+		Output([]domain.WebhookDelivery) []WebhookDeliveryOutput
+	}
+
+	// WebhookDeliveryOutput output data
+	WebhookDeliveryOutput struct {
+		ID             string          `json:"id"`
+		WebhookID      string          `json:"webhook_id"`
+		EventID        string          `json:"event_id"`
+		EventName      string          `json:"event_name"`
+		Status         string          `json:"status"`
+		Attempts       int             `json:"attempts"`
+		ResponseStatus int             `json:"response_status,omitempty"`
+		LastError      string          `json:"last_error,omitempty"`
+		NextAttemptAt  string          `json:"next_attempt_at,omitempty"`
+		DeliveredAt    string          `json:"delivered_at,omitempty"`
+		CreatedAt      string          `json:"created_at"`
+		Payload        json.RawMessage `json:"payload"`
+	}
+
+	findWebhookDeliveriesInteractor struct {
+		webhookRepo  domain.WebhookRepository
+		deliveryRepo domain.WebhookDeliveryRepository
+		presenter    FindWebhookDeliveriesPresenter
+		ctxTimeout   time.Duration
+	}
+)
+
+// NewFindWebhookDeliveriesInteractor creates new findWebhookDeliveriesInteractor with its dependencies
+func NewFindWebhookDeliveriesInteractor(
+	webhookRepo domain.WebhookRepository,
+	deliveryRepo domain.WebhookDeliveryRepository,
+	presenter FindWebhookDeliveriesPresenter,
+	t time.Duration,
+) FindWebhookDeliveriesUseCase {
+	return findWebhookDeliveriesInteractor{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		presenter:    presenter,
+		ctxTimeout:   t,
+	}
+}
+
+// Execute orchestrates the use case
+func (a findWebhookDeliveriesInteractor) Execute(ctx context.Context, ID domain.WebhookID) ([]WebhookDeliveryOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	if _, err := authorizeWebhook(ctx, a.webhookRepo, OpFindWebhookDeliveries, ID); err != nil {
+		return a.presenter.Output([]domain.WebhookDelivery{}), err
+	}
+
+	deliveries, err := a.deliveryRepo.FindByWebhook(ctx, ID)
+	if err != nil {
+		return a.presenter.Output([]domain.WebhookDelivery{}), err
+	}
+
+	return a.presenter.Output(deliveries), nil
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// ImportTransfersUseCase input port
+	ImportTransfersUseCase interface {
+		Execute(context.Context, ImportTransfersInput) (TransferImportOutput, error)
+	}
+
+	// ImportTransfersInput input data. The content identifies the file, the lines are what was read from it
+	ImportTransfersInput struct {
+		FileName string               `json:"file_name"`
+		Format   string               `json:"format"`
+		Content  []byte               `json:"-"`
+		Lines    []ImportTransferLine `json:"-"`
+	}
+
+	// ImportTransferLine is a transfer read from the file, with the problems found reading and validating it
+	ImportTransferLine struct {
+		Number   int
+		Transfer CreateTransferInput
+		Errors   []string
+	}
+
+	importTransfersInteractor struct {
+		repo       domain.TransferImportRepository
+		presenter  TransferImportPresenter
+		ctxTimeout time.Duration
+	}
+)
+
+// NewImportTransfersInteractor creates new importTransfersInteractor with its dependencies
+func NewImportTransfersInteractor(
+	repo domain.TransferImportRepository,
+	presenter TransferImportPresenter,
+	t time.Duration,
+) ImportTransfersUseCase {
+	return importTransfersInteractor{
+		repo:       repo,
+		presenter:  presenter,
+		ctxTimeout: t,
+	}
+}
+
+// Execute queues the lines of the file for the import job, which creates their transfers on behalf of the caller. A
+// file the caller already imported is refused, without telling the job it was imported as
+func (i importTransfersInteractor) Execute(ctx context.Context, input ImportTransfersInput) (TransferImportOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.ctxTimeout)
+	defer cancel()
+
+	principal, _, err := Authorize(ctx, OpImportTransfers)
+	if err != nil {
+		return i.presenter.Output(domain.TransferImport{}), err
+	}
+
+	var lines = make([]domain.TransferImportLine, 0, len(input.Lines))
+	for _, line := range input.Lines {
+		lines = append(lines, domain.NewReadTransferImportLine(
+			line.Number,
+			domain.AccountID(line.Transfer.AccountOriginID),
+			domain.AccountID(line.Transfer.AccountDestinationID),
+			domain.Money(line.Transfer.Amount),
+			line.Errors,
+		))
+	}
+
+	var job = domain.NewPendingTransferImport(
+		domain.TransferImportID(domain.NewUUID()),
+		input.FileName,
+		domain.TransferImportFormat(input.Format),
+		domain.TransferImportContentHash(input.Content),
+		principal,
+		lines,
+		time.Now(),
+	)
+
+	if err = i.repo.Create(ctx, job); err != nil {
+		return i.presenter.Output(domain.TransferImport{}), err
+	}
+
+	return i.presenter.Output(job), nil
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type mockTransferImportRepo struct {
+	domain.TransferImportRepository
+
+	err     error
+	created *[]domain.TransferImport
+}
+
+func (m mockTransferImportRepo) Create(_ context.Context, job domain.TransferImport) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	*m.created = append(*m.created, job)
+	return nil
+}
+
+type mockTransferImportPresenter struct{}
+
+func (m mockTransferImportPresenter) Output(job domain.TransferImport) TransferImportOutput {
+	return TransferImportOutput{
+		ID:      job.ID().String(),
+		Status:  job.Status().String(),
+		Pending: job.Count(domain.TransferImportLinePending),
+		Invalid: job.Count(domain.TransferImportLineInvalid),
+	}
+}
+
+func TestImportTransfersInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	var (
+		content = []byte("account_origin_id,account_destination_id,amount\n")
+		lines   = []ImportTransferLine{
+			{
+				Number: 2,
+				Transfer: CreateTransferInput{
+					AccountOriginID:      "3c096a40-ccba-4b58-93ed-57379ab04680",
+					AccountDestinationID: "a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c",
+					Amount:               100,
+				},
+			},
+			{Number: 3, Errors: []string{"amount is required"}},
+		}
+	)
+
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		repo            mockTransferImportRepo
+		expected        TransferImportOutput
+		expectedCreated int
+		expectedError   error
+	}{
+		{
+			name:            "Queues the lines of the file",
+			ctx:             roleContext(domain.RoleAdmin),
+			repo:            mockTransferImportRepo{},
+			expected:        TransferImportOutput{Status: "pending", Pending: 1, Invalid: 1},
+			expectedCreated: 1,
+		},
+		{
+			name:          "Refuses a file the caller already imported, without telling its job",
+			ctx:           roleContext(domain.RoleAdmin),
+			repo:          mockTransferImportRepo{err: domain.ErrDuplicateTransferImport},
+			expected:      TransferImportOutput{},
+			expectedError: domain.ErrDuplicateTransferImport,
+		},
+		{
+			name:          "Support may not import transfers",
+			ctx:           roleContext(domain.RoleSupport),
+			repo:          mockTransferImportRepo{},
+			expected:      TransferImportOutput{},
+			expectedError: domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created []domain.TransferImport
+			tt.repo.created = &created
+
+			var uc = NewImportTransfersInteractor(tt.repo, mockTransferImportPresenter{}, time.Second)
+
+			got, err := uc.Execute(tt.ctx, ImportTransfersInput{
+				FileName: "payroll.csv",
+				Format:   "csv",
+				Content:  content,
+				Lines:    lines,
+			})
+			if err != tt.expectedError {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if len(created) != tt.expectedCreated {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, len(created), tt.expectedCreated)
+				return
+			}
+
+			if len(created) > 0 {
+				tt.expected.ID = created[0].ID().String()
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%+v' | Expected: '%+v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// NotifyCustomersUseCase input port
+	NotifyCustomersUseCase interface {
+		Execute(context.Context, domain.Event) error
+	}
+
+	// NotificationPresenter output port, rendering the message of the kind in the locale
+	NotificationPresenter interface {
+		Output(domain.NotificationKind, string, NotificationData) (NotificationOutput, error)
+	}
+
+	// NotificationData is what the templates of the messages may show
+	NotificationData struct {
+		Name       string
+		TransferID string
+		Amount     string
+		Balance    string
+		Threshold  string
+		Currency   string
+		OccurredAt time.Time
+	}
+
+	// NotificationOutput is a rendered message
+	NotificationOutput struct {
+		Subject string
+		Body    string
+	}
+
+	notifyCustomersInteractor struct {
+		accountRepo      domain.AccountRepository
+		preferenceRepo   domain.NotificationPreferenceRepository
+		notificationRepo domain.NotificationRepository
+		notifier         domain.Notifier
+		presenter        NotificationPresenter
+		ctxTimeout       time.Duration
+	}
+)
+
+// NewNotifyCustomersInteractor creates new notifyCustomersInteractor with its dependencies
+func NewNotifyCustomersInteractor(
+	accountRepo domain.AccountRepository,
+	preferenceRepo domain.NotificationPreferenceRepository,
+	notificationRepo domain.NotificationRepository,
+	notifier domain.Notifier,
+	presenter NotificationPresenter,
+	t time.Duration,
+) NotifyCustomersUseCase {
+	return notifyCustomersInteractor{
+		accountRepo:      accountRepo,
+		preferenceRepo:   preferenceRepo,
+		notificationRepo: notificationRepo,
+		notifier:         notifier,
+		presenter:        presenter,
+		ctxTimeout:       t,
+	}
+}
+
+// Execute notifies the holder of the destination account of a completed transfer that money arrived, and the holder
+// of the origin account when the transfer took the balance below their threshold. It runs as a subscriber of the
+// published events, so an error has the event published again; notifications already sent are not sent twice
+func (n notifyCustomersInteractor) Execute(ctx context.Context, event domain.Event) error {
+	if event.Name() != domain.EventTransferCompleted {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.ctxTimeout)
+	defer cancel()
+
+	var data domain.TransferData
+	if err := json.Unmarshal(event.Data(), &data); err != nil {
+		return err
+	}
+
+	amount, err := domain.ParseMoney(data.Amount)
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(
+		n.transferReceived(ctx, event, data),
+		n.lowBalance(ctx, event, data, amount),
+	)
+}
+
+func (n notifyCustomersInteractor) transferReceived(
+	ctx context.Context,
+	event domain.Event,
+	data domain.TransferData,
+) error {
+	preference, err := n.findPreference(ctx, domain.AccountID(data.AccountDestinationID))
+	if err != nil || !preference.TransferReceived() {
+		return err
+	}
+
+	account, err := n.accountRepo.FindByID(ctx, preference.AccountID())
+	if err != nil {
+		return err
+	}
+
+	return n.notify(ctx, event, preference, domain.NotificationTransferReceived, NotificationData{
+		Name:       account.Name(),
+		TransferID: data.TransferID,
+		Amount:     data.Amount,
+		Balance:    account.Balance().String(),
+		Currency:   data.Currency,
+		OccurredAt: event.OccurredAt(),
+	})
+}
+
+// lowBalance compares the balance the transfer left the origin with, carried by the event, to the one before it, so
+// transfers made since do not change the outcome when the event is handled again. Events recorded without it are not
+// notified
+func (n notifyCustomersInteractor) lowBalance(
+	ctx context.Context,
+	event domain.Event,
+	data domain.TransferData,
+	amount domain.Money,
+) error {
+	if data.AccountOriginBalance == "" {
+		return nil
+	}
+
+	preference, err := n.findPreference(ctx, domain.AccountID(data.AccountOriginID))
+	if err != nil || preference.LowBalanceThreshold() == 0 {
+		return err
+	}
+
+	after, err := domain.ParseMoney(data.AccountOriginBalance)
+	if err != nil {
+		return err
+	}
+
+	before, err := after.Add(amount)
+	if err != nil {
+		return err
+	}
+
+	if !preference.LowBalanceCrossed(before, after) {
+		return nil
+	}
+
+	account, err := n.accountRepo.FindByID(ctx, preference.AccountID())
+	if err != nil {
+		return err
+	}
+
+	return n.notify(ctx, event, preference, domain.NotificationLowBalance, NotificationData{
+		Name:       account.Name(),
+		TransferID: data.TransferID,
+		Amount:     data.Amount,
+		Balance:    after.String(),
+		Threshold:  preference.LowBalanceThreshold().String(),
+		Currency:   data.Currency,
+		OccurredAt: event.OccurredAt(),
+	})
+}
+
+// findPreference returns a zero preference for accounts that never set one, which turns every notification off
+func (n notifyCustomersInteractor) findPreference(
+	ctx context.Context,
+	ID domain.AccountID,
+) (domain.NotificationPreference, error) {
+	preference, err := n.preferenceRepo.FindByAccountID(ctx, ID)
+	if err == domain.ErrNotificationPreferenceNotFound {
+		return domain.NotificationPreference{}, nil
+	}
+
+	return preference, err
+}
+
+// notify sends the notification of the kind about the event unless it was already sent. One recorded by an attempt
+// that failed is sent again as it was rendered then
+func (n notifyCustomersInteractor) notify(
+	ctx context.Context,
+	event domain.Event,
+	preference domain.NotificationPreference,
+	kind domain.NotificationKind,
+	data NotificationData,
+) error {
+	var key = domain.NotificationKey(event.ID(), preference.AccountID(), kind)
+
+	notification, err := n.notificationRepo.FindByKey(ctx, key)
+	switch err {
+	case nil:
+		if notification.Status() == domain.NotificationSent {
+			return nil
+		}
+	case domain.ErrNotificationNotFound:
+		message, err := n.presenter.Output(kind, preference.Locale(), data)
+		if err != nil {
+			return err
+		}
+
+		notification = domain.NewNotification(
+			key,
+			preference.AccountID(),
+			event.ID(),
+			kind,
+			preference.Email(),
+			message.Subject,
+			message.Body,
+			domain.NotificationPending,
+			time.Now(),
+			time.Time{},
+		)
+
+		if err = n.notificationRepo.Create(ctx, notification); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	if err = n.notifier.Notify(ctx, notification); err != nil {
+		return err
+	}
+
+	return n.notificationRepo.Update(ctx, notification.MarkSent(time.Now()))
+}
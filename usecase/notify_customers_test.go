@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type mockAccountRepoNotify struct {
+	domain.AccountRepository
+
+	accounts map[domain.AccountID]domain.Account
+}
+
+func (m mockAccountRepoNotify) FindByID(_ context.Context, ID domain.AccountID) (domain.Account, error) {
+	account, ok := m.accounts[ID]
+	if !ok {
+		return domain.Account{}, domain.ErrAccountNotFound
+	}
+
+	return account, nil
+}
+
+type mockPreferenceRepoNotify struct {
+	domain.NotificationPreferenceRepository
+
+	preferences map[domain.AccountID]domain.NotificationPreference
+}
+
+func (m mockPreferenceRepoNotify) FindByAccountID(
+	_ context.Context,
+	ID domain.AccountID,
+) (domain.NotificationPreference, error) {
+	preference, ok := m.preferences[ID]
+	if !ok {
+		return domain.NotificationPreference{}, domain.ErrNotificationPreferenceNotFound
+	}
+
+	return preference, nil
+}
+
+type mockNotificationRepoNotify struct {
+	notifications map[string]domain.Notification
+}
+
+func (m mockNotificationRepoNotify) Create(_ context.Context, notification domain.Notification) error {
+	m.notifications[notification.Key()] = notification
+	return nil
+}
+
+func (m mockNotificationRepoNotify) Update(_ context.Context, notification domain.Notification) error {
+	m.notifications[notification.Key()] = notification
+	return nil
+}
+
+func (m mockNotificationRepoNotify) FindByKey(_ context.Context, key string) (domain.Notification, error) {
+	notification, ok := m.notifications[key]
+	if !ok {
+		return domain.Notification{}, domain.ErrNotificationNotFound
+	}
+
+	return notification, nil
+}
+
+type mockNotifier struct {
+	sent *[]domain.Notification
+	err  error
+}
+
+func (m mockNotifier) Notify(_ context.Context, notification domain.Notification) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	*m.sent = append(*m.sent, notification)
+	return nil
+}
+
+type mockNotificationPresenter struct{}
+
+func (m mockNotificationPresenter) Output(
+	kind domain.NotificationKind,
+	locale string,
+	data NotificationData,
+) (NotificationOutput, error) {
+	return NotificationOutput{
+		Subject: kind.String() + " " + locale,
+		Body:    data.Amount + " " + data.Balance + " " + data.Threshold,
+	}, nil
+}
+
+func TestNotifyCustomersInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	const (
+		origin      domain.AccountID = "3c096a40-ccba-4b58-93ed-57379ab04680"
+		destination domain.AccountID = "3c096a40-ccba-4b58-93ed-57379ab04681"
+	)
+
+	var newEvent = func(name domain.EventName, originBalance string) domain.Event {
+		data, _ := json.Marshal(domain.TransferData{
+			TransferID:           "3c096a40-ccba-4b58-93ed-57379ab04682",
+			AccountOriginID:      origin.String(),
+			AccountDestinationID: destination.String(),
+			Amount:               "100.00",
+			Currency:             domain.CurrencyBRL.String(),
+			AccountOriginBalance: originBalance,
+		})
+
+		return domain.NewEvent(
+			"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10",
+			name,
+			domain.EventSchemaVersion,
+			"3c096a40-ccba-4b58-93ed-57379ab04682",
+			data,
+			time.Now(),
+		)
+	}
+
+	var (
+		completed = newEvent(domain.EventTransferCompleted, "50.00")
+
+		accounts = func(originBalance domain.Money) map[domain.AccountID]domain.Account {
+			return map[domain.AccountID]domain.Account{
+				origin:      domain.NewAccount(origin, "Origin", "02815517078", originBalance, time.Now()),
+				destination: domain.NewAccount(destination, "Destination", "07094564964", 15000, time.Now()),
+			}
+		}
+
+		received = map[domain.AccountID]domain.NotificationPreference{
+			destination: domain.NewNotificationPreference(destination, "d@example.com", "en-US", true, 0, time.Now()),
+		}
+
+		lowBalance = map[domain.AccountID]domain.NotificationPreference{
+			origin: domain.NewNotificationPreference(origin, "o@example.com", "", false, 10000, time.Now()),
+		}
+
+		receivedKey = domain.NotificationKey(completed.ID(), destination, domain.NotificationTransferReceived)
+	)
+
+	tests := []struct {
+		name            string
+		event           domain.Event
+		accounts        map[domain.AccountID]domain.Account
+		preferences     map[domain.AccountID]domain.NotificationPreference
+		notifications   map[string]domain.Notification
+		notifierErr     error
+		expectedSent    []string
+		expectedPending []string
+		expectedError   string
+	}{
+		{
+			name:         "Notify the destination of a received transfer",
+			event:        completed,
+			accounts:     accounts(5000),
+			preferences:  received,
+			expectedSent: []string{"d@example.com transfer_received en-US 100.00 150.00 "},
+		},
+		{
+			name:         "Notify the origin when the transfer takes the balance below the threshold",
+			event:        completed,
+			accounts:     accounts(5000),
+			preferences:  lowBalance,
+			expectedSent: []string{"o@example.com low_balance pt-BR 100.00 50.00 100.00"},
+		},
+		{
+			name:         "Notify the crossing once later transfers moved the balance again",
+			event:        completed,
+			accounts:     accounts(-20000),
+			preferences:  lowBalance,
+			expectedSent: []string{"o@example.com low_balance pt-BR 100.00 50.00 100.00"},
+		},
+		{
+			name:         "Do not notify a balance that was already below the threshold",
+			event:        newEvent(domain.EventTransferCompleted, "-50.00"),
+			accounts:     accounts(5000),
+			preferences:  lowBalance,
+			expectedSent: []string{},
+		},
+		{
+			name:         "Do not notify events recorded without the balance of the origin",
+			event:        newEvent(domain.EventTransferCompleted, ""),
+			accounts:     accounts(5000),
+			preferences:  lowBalance,
+			expectedSent: []string{},
+		},
+		{
+			name:         "Do not notify accounts without a preference",
+			event:        completed,
+			accounts:     accounts(5000),
+			preferences:  map[domain.AccountID]domain.NotificationPreference{},
+			expectedSent: []string{},
+		},
+		{
+			name:        "Skip a notification already sent",
+			event:       completed,
+			accounts:    accounts(5000),
+			preferences: received,
+			notifications: map[string]domain.Notification{
+				receivedKey: notificationFixture(receivedKey, "already sent", domain.NotificationSent),
+			},
+			expectedSent: []string{},
+		},
+		{
+			name:        "Send again a notification left pending, as it was rendered",
+			event:       completed,
+			accounts:    accounts(5000),
+			preferences: received,
+			notifications: map[string]domain.Notification{
+				receivedKey: notificationFixture(receivedKey, "rendered before", domain.NotificationPending),
+			},
+			expectedSent: []string{"d@example.com rendered before body"},
+		},
+		{
+			name:            "Notifier error leaves the notification pending",
+			event:           completed,
+			accounts:        accounts(5000),
+			preferences:     received,
+			notifierErr:     errors.New("smtp error"),
+			expectedSent:    []string{},
+			expectedPending: []string{receivedKey},
+			expectedError:   "smtp error",
+		},
+		{
+			name:         "Ignore other events",
+			event:        newEvent(domain.EventTransferFailed, ""),
+			accounts:     accounts(5000),
+			preferences:  received,
+			expectedSent: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var notifications = make(map[string]domain.Notification)
+			for key, n := range tt.notifications {
+				notifications[key] = n
+			}
+
+			var (
+				sent = make([]domain.Notification, 0)
+				uc   = NewNotifyCustomersInteractor(
+					mockAccountRepoNotify{accounts: tt.accounts},
+					mockPreferenceRepoNotify{preferences: tt.preferences},
+					mockNotificationRepoNotify{notifications: notifications},
+					mockNotifier{sent: &sent, err: tt.notifierErr},
+					mockNotificationPresenter{},
+					time.Second,
+				)
+			)
+
+			err := uc.Execute(context.Background(), tt.event)
+			if (err != nil || tt.expectedError != "") && (err == nil || err.Error() != tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			var got = make([]string, 0)
+			for _, n := range sent {
+				got = append(got, n.Recipient()+" "+n.Subject()+" "+n.Body())
+
+				if notifications[n.Key()].Status() != domain.NotificationSent {
+					t.Errorf("[TestCase '%s'] Notification '%s' not recorded as sent", tt.name, n.Key())
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.expectedSent) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expectedSent)
+			}
+
+			for _, key := range tt.expectedPending {
+				if notifications[key].Status() != domain.NotificationPending {
+					t.Errorf("[TestCase '%s'] Notification '%s': '%v'", tt.name, key, notifications[key].Status())
+				}
+			}
+		})
+	}
+}
+
+func notificationFixture(key, subject string, status domain.NotificationStatus) domain.Notification {
+	return domain.NewNotification(
+		key,
+		"3c096a40-ccba-4b58-93ed-57379ab04681",
+		"a7b8f2b0-5e6b-4f1f-9d3e-2b9b3b7f5f10",
+		domain.NotificationTransferReceived,
+		"d@example.com",
+		subject,
+		"body",
+		status,
+		time.Now(),
+		time.Time{},
+	)
+}
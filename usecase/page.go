@@ -0,0 +1,14 @@
+package usecase
+
+import "github.com/gsabadini/go-clean-architecture/domain"
+
+// pageAhead asks for one item more than the page holds, which tells whether another page follows it. A limit out of
+// bounds asks for the default size
+func pageAhead(page domain.PageRequest) domain.PageRequest {
+	if page.Limit <= 0 || page.Limit > domain.MaxPageSize {
+		page.Limit = domain.DefaultPageSize
+	}
+
+	page.Limit++
+	return page
+}
@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// Operation names a use case in the authorization policy
+type Operation string
+
+const (
+	OpCreateAccount         Operation = "create_account"
+	OpFindAllAccount        Operation = "find_all_account"
+	OpFindAccountBalance    Operation = "find_account_balance"
+	OpCreateTransfer        Operation = "create_transfer"
+	OpFindAllTransfer       Operation = "find_all_transfer"
+	OpApproveTransfer       Operation = "approve_transfer"
+	OpRejectTransfer        Operation = "reject_transfer"
+	OpFindTransferApprovals Operation = "find_transfer_approvals"
+	OpCreateAPIKey          Operation = "create_api_key"
+	OpFindAllAPIKey         Operation = "find_all_api_key"
+	OpRevokeAPIKey          Operation = "revoke_api_key"
+	OpRotateAPIKey          Operation = "rotate_api_key"
+	OpCreateWebhook         Operation = "create_webhook"
+	OpFindAllWebhook        Operation = "find_all_webhook"
+	OpUpdateWebhook         Operation = "update_webhook"
+	OpFindWebhookDeliveries Operation = "find_webhook_deliveries"
+	OpRedeliverWebhook      Operation = "redeliver_webhook"
+
+	OpFindNotificationPreference   Operation = "find_notification_preference"
+	OpUpdateNotificationPreference Operation = "update_notification_preference"
+
+	OpFindAudit Operation = "find_audit"
+
+	OpRequestReconciliation Operation = "request_reconciliation"
+	OpFindReconciliation    Operation = "find_reconciliation"
+
+	OpFindDailyBalances Operation = "find_daily_balances"
+	OpExportStatement   Operation = "export_statement"
+
+	OpImportTransfers    Operation = "import_transfers"
+	OpFindTransferImport Operation = "find_transfer_import"
+)
+
+// Access is how far a role may reach within an operation
+type Access int
+
+const (
+	// AccessNone denies the operation
+	AccessNone Access = iota
+	// AccessOwn allows the operation on resources owned by the principal only
+	AccessOwn
+	// AccessAny allows the operation on any resource
+	AccessAny
+)
+
+// Policy is the scope required by an operation and the access granted to each role. Roles not listed are denied
+type Policy struct {
+	Scope domain.Scope
+	Roles map[domain.Role]Access
+}
+
+// policies is the single source of truth for authorization. Operations not listed are denied to everyone
+var policies = map[Operation]Policy{
+	OpCreateAccount: {
+		Scope: domain.ScopeAccountsWrite,
+		Roles: map[domain.Role]Access{
+			domain.RoleCustomer: AccessOwn,
+			domain.RoleAdmin:    AccessAny,
+			domain.RoleClient:   AccessAny,
+		},
+	},
+	OpFindAllAccount: {
+		Scope: domain.ScopeAccountsRead,
+		Roles: map[domain.Role]Access{
+			domain.RoleCustomer: AccessOwn,
+			domain.RoleSupport:  AccessAny,
+			domain.RoleAdmin:    AccessAny,
+			domain.RoleClient:   AccessAny,
+		},
+	},
+	OpFindAccountBalance: {
+		Scope: domain.ScopeAccountsRead,
+		Roles: map[domain.Role]Access{
+			domain.RoleCustomer: AccessOwn,
+			domain.RoleSupport:  AccessAny,
+			domain.RoleAdmin:    AccessAny,
+			domain.RoleClient:   AccessAny,
+		},
+	},
+	OpCreateTransfer: {
+		Scope: domain.ScopeTransfersWrite,
+		Roles: map[domain.Role]Access{
+			domain.RoleCustomer: AccessOwn,
+			domain.RoleAdmin:    AccessAny,
+			domain.RoleClient:   AccessAny,
+		},
+	},
+	OpFindAllTransfer: {
+		Scope: domain.ScopeTransfersRead,
+		Roles: map[domain.Role]Access{
+			domain.RoleCustomer: AccessOwn,
+			domain.RoleSupport:  AccessAny,
+			domain.RoleAdmin:    AccessAny,
+			domain.RoleClient:   AccessAny,
+		},
+	},
+	OpApproveTransfer: checkerPolicy(),
+	OpRejectTransfer:  checkerPolicy(),
+	OpFindTransferApprovals: {
+		Scope: domain.ScopeTransfersRead,
+		Roles: map[domain.Role]Access{
+			domain.RoleCustomer: AccessOwn,
+			domain.RoleSupport:  AccessAny,
+			domain.RoleAdmin:    AccessAny,
+			domain.RoleClient:   AccessAny,
+		},
+	},
+	OpCreateAPIKey:  adminPolicy(),
+	OpFindAllAPIKey: adminPolicy(),
+	OpRevokeAPIKey:  adminPolicy(),
+	OpRotateAPIKey:  adminPolicy(),
+
+	OpCreateWebhook:         webhookPolicy(),
+	OpFindAllWebhook:        webhookPolicy(),
+	OpUpdateWebhook:         webhookPolicy(),
+	OpFindWebhookDeliveries: webhookPolicy(),
+	OpRedeliverWebhook:      webhookPolicy(),
+
+	OpFindNotificationPreference: {
+		Scope: domain.ScopeAccountsRead,
+		Roles: map[domain.Role]Access{
+			domain.RoleCustomer: AccessOwn,
+			domain.RoleSupport:  AccessAny,
+			domain.RoleAdmin:    AccessAny,
+			domain.RoleClient:   AccessAny,
+		},
+	},
+	OpUpdateNotificationPreference: {
+		Scope: domain.ScopeAccountsWrite,
+		Roles: map[domain.Role]Access{
+			domain.RoleCustomer: AccessOwn,
+			domain.RoleAdmin:    AccessAny,
+			domain.RoleClient:   AccessAny,
+		},
+	},
+
+	OpFindAudit: {
+		Scope: domain.ScopeAuditRead,
+		Roles: map[domain.Role]Access{
+			domain.RoleSupport: AccessAny,
+			domain.RoleAdmin:   AccessAny,
+			domain.RoleClient:  AccessAny,
+		},
+	},
+
+	OpRequestReconciliation: {
+		Scope: domain.ScopeAccountsWrite,
+		Roles: map[domain.Role]Access{
+			domain.RoleAdmin: AccessAny,
+		},
+	},
+	OpFindReconciliation: {
+		Scope: domain.ScopeAccountsRead,
+		Roles: map[domain.Role]Access{
+			domain.RoleSupport: AccessAny,
+			domain.RoleAdmin:   AccessAny,
+		},
+	},
+
+	OpFindDailyBalances: {
+		Scope: domain.ScopeAccountsRead,
+		Roles: map[domain.Role]Access{
+			domain.RoleCustomer: AccessOwn,
+			domain.RoleSupport:  AccessAny,
+			domain.RoleAdmin:    AccessAny,
+			domain.RoleClient:   AccessAny,
+		},
+	},
+	OpExportStatement: {
+		Scope: domain.ScopeAccountsRead,
+		Roles: map[domain.Role]Access{
+			domain.RoleCustomer: AccessOwn,
+			domain.RoleSupport:  AccessAny,
+			domain.RoleAdmin:    AccessAny,
+			domain.RoleClient:   AccessAny,
+		},
+	},
+	// Each line of an import is authorized again as a transfer created by the principal who uploaded the file
+	OpImportTransfers: {
+		Scope: domain.ScopeTransfersWrite,
+		Roles: map[domain.Role]Access{
+			domain.RoleCustomer: AccessOwn,
+			domain.RoleAdmin:    AccessAny,
+			domain.RoleClient:   AccessAny,
+		},
+	},
+	OpFindTransferImport: {
+		Scope: domain.ScopeTransfersRead,
+		Roles: map[domain.Role]Access{
+			domain.RoleCustomer: AccessOwn,
+			domain.RoleSupport:  AccessAny,
+			domain.RoleAdmin:    AccessAny,
+			domain.RoleClient:   AccessOwn,
+		},
+	},
+}
+
+// checkerPolicy is for the second user of the maker-checker approval, who never is a customer or a machine client
+func checkerPolicy() Policy {
+	return Policy{
+		Scope: domain.ScopeTransfersApprove,
+		Roles: map[domain.Role]Access{
+			domain.RoleAdmin: AccessAny,
+		},
+	}
+}
+
+func adminPolicy() Policy {
+	return Policy{
+		Scope: domain.ScopeAPIKeysAdmin,
+		Roles: map[domain.Role]Access{
+			domain.RoleAdmin:  AccessAny,
+			domain.RoleClient: AccessAny,
+		},
+	}
+}
+
+// webhookPolicy is for the partners managing the webhooks they created through an API key, and for the admins helping
+// them
+func webhookPolicy() Policy {
+	return Policy{
+		Scope: domain.ScopeWebhooksAdmin,
+		Roles: map[domain.Role]Access{
+			domain.RoleAdmin:  AccessAny,
+			domain.RoleClient: AccessOwn,
+		},
+	}
+}
+
+// PolicyFor returns the policy of the operation, if any
+func PolicyFor(op Operation) (Policy, bool) {
+	p, ok := policies[op]
+	return p, ok
+}
+
+// Authorize checks that the principal carried by ctx may run the operation and returns it with its access level
+func Authorize(ctx context.Context, op Operation) (domain.Principal, Access, error) {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return domain.Principal{}, AccessNone, domain.ErrForbidden
+	}
+
+	policy, ok := policies[op]
+	if !ok || !principal.HasScope(policy.Scope) {
+		return principal, AccessNone, domain.ErrForbidden
+	}
+
+	var access = policy.Roles[principal.Role()]
+	if access == AccessNone {
+		return principal, AccessNone, domain.ErrForbidden
+	}
+
+	return principal, access, nil
+}
+
+// Allows reports whether the access level reaches the account of the principal
+func (a Access) Allows(principal domain.Principal, account domain.Account) bool {
+	return a == AccessAny || (a == AccessOwn && principal.Owns(account))
+}
+
+// authorizeAccount checks that the principal carried by ctx may run the operation on the account
+func authorizeAccount(ctx context.Context, repo domain.AccountRepository, op Operation, ID domain.AccountID) error {
+	principal, access, err := Authorize(ctx, op)
+	if err != nil {
+		return err
+	}
+
+	if access == AccessAny {
+		return nil
+	}
+
+	// Lookups deciding what the principal owns read from the primary: a replica lagging behind would not know yet of
+	// an account created right before, and deny it
+	account, err := repo.FindByID(domain.ContextWithReadYourWrites(ctx), ID)
+	if err != nil {
+		switch err {
+		case domain.ErrAccountNotFound:
+			return domain.ErrForbidden
+		default:
+			return err
+		}
+	}
+
+	if !access.Allows(principal, account) {
+		return domain.ErrForbidden
+	}
+
+	return nil
+}
+
+// authorizeWebhook checks that the principal carried by ctx may run the operation on the webhook and returns it
+func authorizeWebhook(
+	ctx context.Context,
+	repo domain.WebhookRepository,
+	op Operation,
+	ID domain.WebhookID,
+) (domain.Webhook, error) {
+	principal, access, err := Authorize(ctx, op)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	webhook, err := repo.FindByID(domain.ContextWithReadYourWrites(ctx), ID)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	if access != AccessAny && !webhook.OwnedBy(principal) {
+		return domain.Webhook{}, domain.ErrForbidden
+	}
+
+	return webhook, nil
+}
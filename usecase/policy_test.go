@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+func customerContext(cpf string) context.Context {
+	return domain.ContextWithPrincipal(
+		context.Background(),
+		domain.NewPrincipal(cpf, domain.RoleCustomer, domain.CustomerScopes...),
+	)
+}
+
+func roleContext(role domain.Role) context.Context {
+	return domain.ContextWithPrincipal(
+		context.Background(),
+		domain.NewPrincipal("02815517078", role, role.DefaultScopes()...),
+	)
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		op            Operation
+		expected      Access
+		expectedError string
+	}{
+		{
+			name:     "Customer reads own accounts",
+			ctx:      roleContext(domain.RoleCustomer),
+			op:       OpFindAllAccount,
+			expected: AccessOwn,
+		},
+		{
+			name:     "Support reads any account",
+			ctx:      roleContext(domain.RoleSupport),
+			op:       OpFindAccountBalance,
+			expected: AccessAny,
+		},
+		{
+			name:          "Support can not create transfers",
+			ctx:           roleContext(domain.RoleSupport),
+			op:            OpCreateTransfer,
+			expected:      AccessNone,
+			expectedError: "caller is not allowed to access this resource",
+		},
+		{
+			name:          "Customer can not manage api keys",
+			ctx:           roleContext(domain.RoleCustomer),
+			op:            OpCreateAPIKey,
+			expected:      AccessNone,
+			expectedError: "caller is not allowed to access this resource",
+		},
+		{
+			name:     "Admin manages api keys",
+			ctx:      roleContext(domain.RoleAdmin),
+			op:       OpRevokeAPIKey,
+			expected: AccessAny,
+		},
+		{
+			name: "Role without the scope of the operation",
+			ctx: domain.ContextWithPrincipal(
+				context.Background(),
+				domain.NewPrincipal("02815517078", domain.RoleAdmin, domain.ScopeAccountsRead),
+			),
+			op:            OpCreateTransfer,
+			expected:      AccessNone,
+			expectedError: "caller is not allowed to access this resource",
+		},
+		{
+			name:          "Operation without policy",
+			ctx:           roleContext(domain.RoleAdmin),
+			op:            Operation("unknown"),
+			expected:      AccessNone,
+			expectedError: "caller is not allowed to access this resource",
+		},
+		{
+			name:          "Without principal",
+			ctx:           context.Background(),
+			op:            OpFindAllAccount,
+			expected:      AccessNone,
+			expectedError: "caller is not allowed to access this resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, access, err := Authorize(tt.ctx, tt.op)
+			if (err != nil) && (err.Error() != tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if (err == nil) && tt.expectedError != "" {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if access != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, access, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	t.Parallel()
+
+	for op, policy := range policies {
+		if policy.Scope == "" {
+			t.Errorf("[Operation '%s'] policy without scope", op)
+		}
+
+		if policy.Roles[domain.RoleSupport] > AccessNone && policy.Scope == domain.ScopeAccountsWrite {
+			t.Errorf("[Operation '%s'] support must be read-only", op)
+		}
+
+		if policy.Roles[domain.RoleSupport] > AccessNone && policy.Scope == domain.ScopeTransfersWrite {
+			t.Errorf("[Operation '%s'] support must be read-only", op)
+		}
+	}
+}
+
+// mockAccountRepoPrimary finds the account only when asked to read its own writes, as a replica lagging behind the
+// creation of the account would not know of it yet
+type mockAccountRepoPrimary struct {
+	domain.AccountRepository
+
+	account domain.Account
+}
+
+func (m mockAccountRepoPrimary) FindByID(ctx context.Context, _ domain.AccountID) (domain.Account, error) {
+	if !domain.ReadYourWritesFromContext(ctx) {
+		return domain.Account{}, domain.ErrAccountNotFound
+	}
+
+	return m.account, nil
+}
+
+func TestAuthorizeAccount(t *testing.T) {
+	t.Parallel()
+
+	var repo = mockAccountRepoPrimary{
+		account: domain.NewAccount("3c096a40-ccba-4b58-93ed-57379ab04680", "Test", "02815517078", 0, time.Time{}),
+	}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		expectedError error
+	}{
+		{name: "Account just created by the customer", ctx: customerContext("02815517078")},
+		{name: "Account of another customer", ctx: customerContext("07091054954"), expectedError: domain.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		err := authorizeAccount(tt.ctx, repo, OpFindDailyBalances, "3c096a40-ccba-4b58-93ed-57379ab04680")
+		if err != tt.expectedError {
+			t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// ReconcileBalancesUseCase input port
+	ReconcileBalancesUseCase interface {
+		Execute(context.Context) (ReconcileBalancesOutput, error)
+	}
+
+	// ReconcileBalancesOutput output data
+	ReconcileBalancesOutput struct {
+		Runs         int
+		Failed       int
+		Mismatches   int
+		Unverifiable int
+	}
+
+	// ReconciliationConfig sets how often a run is scheduled, none when Every is zero
+	ReconciliationConfig struct {
+		Every time.Duration
+	}
+
+	reconcileBalancesInteractor struct {
+		reconciliationRepo domain.ReconciliationRepository
+		ledgerRepo         domain.LedgerRepository
+		config             ReconciliationConfig
+		ctxTimeout         time.Duration
+	}
+)
+
+// NewReconcileBalancesInteractor creates new reconcileBalancesInteractor with its dependencies
+func NewReconcileBalancesInteractor(
+	reconciliationRepo domain.ReconciliationRepository,
+	ledgerRepo domain.LedgerRepository,
+	config ReconciliationConfig,
+	t time.Duration,
+) ReconcileBalancesUseCase {
+	return reconcileBalancesInteractor{
+		reconciliationRepo: reconciliationRepo,
+		ledgerRepo:         ledgerRepo,
+		config:             config,
+		ctxTimeout:         t,
+	}
+}
+
+// Execute schedules a run when the last one was requested longer than Every ago, then runs the pending ones. A run
+// that can not read the balances is recorded as failed and the others still run
+func (r reconcileBalancesInteractor) Execute(ctx context.Context) (ReconcileBalancesOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.ctxTimeout)
+	defer cancel()
+
+	var output ReconcileBalancesOutput
+
+	if err := r.schedule(ctx); err != nil {
+		return output, err
+	}
+
+	runs, err := r.reconciliationRepo.FindPending(ctx)
+	if err != nil {
+		return output, err
+	}
+
+	for _, run := range runs {
+		run, err = r.run(ctx, run)
+		if err != nil {
+			return output, err
+		}
+
+		output.Runs++
+		output.Mismatches += len(run.Mismatches())
+		output.Unverifiable += len(run.Unverifiable())
+		if run.Status() == domain.ReconciliationFailed {
+			output.Failed++
+		}
+	}
+
+	return output, nil
+}
+
+func (r reconcileBalancesInteractor) schedule(ctx context.Context) error {
+	if r.config.Every <= 0 {
+		return nil
+	}
+
+	latest, err := r.reconciliationRepo.FindLatest(ctx)
+	switch {
+	case err == domain.ErrReconciliationNotFound:
+	case err != nil:
+		return err
+	case time.Since(latest.RequestedAt()) < r.config.Every:
+		return nil
+	}
+
+	return r.reconciliationRepo.Create(ctx, domain.NewPendingReconciliationRun(
+		domain.ReconciliationRunID(domain.NewUUID()),
+		domain.ReconciliationScheduled,
+		time.Now(),
+	))
+}
+
+func (r reconcileBalancesInteractor) run(ctx context.Context, run domain.ReconciliationRun) (domain.ReconciliationRun, error) {
+	run, err := run.Start(time.Now())
+	if err != nil {
+		return run, err
+	}
+
+	if err = r.reconciliationRepo.Update(ctx, run); err != nil {
+		return run, err
+	}
+
+	checked, mismatches, unverifiable, err := r.compare(ctx)
+	if err != nil {
+		run, err = run.Fail(err, time.Now())
+	} else {
+		run, err = run.Complete(checked, mismatches, unverifiable, time.Now())
+	}
+	if err != nil {
+		return run, err
+	}
+
+	return run, r.reconciliationRepo.Update(ctx, run)
+}
+
+// compare recomputes the balance of every account. The accounts that do not match are read again, so a transfer
+// completed between the reads of a backend without consistent snapshots is not reported. The accounts whose opening
+// balance is unknown are listed apart, as their balance can not be recomputed
+func (r reconcileBalancesInteractor) compare(
+	ctx context.Context,
+) (int, []domain.ReconciliationMismatch, []domain.AccountID, error) {
+	ledgers, err := r.ledgerRepo.FindAll(ctx)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	var (
+		mismatches   = make([]domain.ReconciliationMismatch, 0)
+		unverifiable = make([]domain.AccountID, 0)
+	)
+
+	for _, ledger := range ledgers {
+		if _, known := ledger.OpeningBalance(); !known {
+			unverifiable = append(unverifiable, ledger.AccountID())
+			continue
+		}
+
+		_, ok, err := ledger.Mismatch()
+		if err != nil {
+			return 0, nil, nil, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		recheck, err := r.ledgerRepo.FindByAccountID(ctx, ledger.AccountID())
+		if err != nil {
+			return 0, nil, nil, err
+		}
+
+		mismatch, ok, err := recheck.Mismatch()
+		if err != nil {
+			return 0, nil, nil, err
+		}
+
+		if ok {
+			mismatches = append(mismatches, mismatch)
+		}
+	}
+
+	return len(ledgers), mismatches, unverifiable, nil
+}
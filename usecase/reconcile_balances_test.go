@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type mockReconciliationRepo struct {
+	domain.ReconciliationRepository
+
+	latest    domain.ReconciliationRun
+	latestErr error
+	pending   []domain.ReconciliationRun
+	created   *[]domain.ReconciliationRun
+	updated   *[]domain.ReconciliationRun
+}
+
+func (m mockReconciliationRepo) Create(_ context.Context, run domain.ReconciliationRun) error {
+	*m.created = append(*m.created, run)
+	return nil
+}
+
+func (m mockReconciliationRepo) Update(_ context.Context, run domain.ReconciliationRun) error {
+	*m.updated = append(*m.updated, run)
+	return nil
+}
+
+// FindPending answers the runs given, followed by those created by the call
+func (m mockReconciliationRepo) FindPending(_ context.Context) ([]domain.ReconciliationRun, error) {
+	return append(append([]domain.ReconciliationRun{}, m.pending...), *m.created...), nil
+}
+
+func (m mockReconciliationRepo) FindLatest(_ context.Context) (domain.ReconciliationRun, error) {
+	return m.latest, m.latestErr
+}
+
+// mockLedgerRepo answers the ledgers given, and the ones in recheck when an account is read again
+type mockLedgerRepo struct {
+	ledgers []domain.AccountLedger
+	findErr error
+	recheck map[domain.AccountID]domain.AccountLedger
+}
+
+func (m mockLedgerRepo) FindAll(_ context.Context) ([]domain.AccountLedger, error) {
+	return m.ledgers, m.findErr
+}
+
+func (m mockLedgerRepo) FindByAccountID(_ context.Context, ID domain.AccountID) (domain.AccountLedger, error) {
+	if ledger, ok := m.recheck[ID]; ok {
+		return ledger, nil
+	}
+
+	for _, ledger := range m.ledgers {
+		if ledger.AccountID() == ID {
+			return ledger, nil
+		}
+	}
+
+	return domain.AccountLedger{}, domain.ErrAccountNotFound
+}
+
+func TestReconcileBalancesInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	const (
+		accountA = domain.AccountID("3c096a40-ccba-4b58-93ed-57379ab04680")
+		accountB = domain.AccountID("3c096a40-ccba-4b58-93ed-57379ab04681")
+	)
+
+	var (
+		config  = ReconciliationConfig{Every: 24 * time.Hour}
+		manual  = domain.NewPendingReconciliationRun("5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10", domain.ReconciliationManual, time.Now())
+		recent  = domain.NewReconciliationRun("5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d11", domain.ReconciliationCompleted, domain.ReconciliationScheduled, 0, nil, nil, "", time.Now().Add(-time.Hour), time.Time{}, time.Time{})
+		old     = domain.NewReconciliationRun("5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d12", domain.ReconciliationCompleted, domain.ReconciliationScheduled, 0, nil, nil, "", time.Now().Add(-25*time.Hour), time.Time{}, time.Time{})
+		matched = domain.NewAccountLedger(accountA, time.Time{}, 1000, 1200, 300, 100)
+		drifted = domain.NewAccountLedger(accountB, time.Time{}, 1000, 900, 0, 0)
+		unknown = domain.NewAccountLedgerWithoutOpening(accountB, time.Time{}, 900, 0, 0)
+	)
+
+	tests := []struct {
+		name                 string
+		repo                 mockReconciliationRepo
+		ledgerRepo           mockLedgerRepo
+		expected             ReconcileBalancesOutput
+		expectedCreated      int
+		expectedStatus       domain.ReconciliationStatus
+		expectedMismatches   []domain.ReconciliationMismatch
+		expectedUnverifiable []domain.AccountID
+	}{
+		{
+			name:            "Reconcile schedules the first run",
+			repo:            mockReconciliationRepo{latestErr: domain.ErrReconciliationNotFound},
+			ledgerRepo:      mockLedgerRepo{ledgers: []domain.AccountLedger{matched}},
+			expected:        ReconcileBalancesOutput{Runs: 1},
+			expectedCreated: 1,
+			expectedStatus:  domain.ReconciliationCompleted,
+		},
+		{
+			name:            "Reconcile schedules a run when the last one is older than a day",
+			repo:            mockReconciliationRepo{latest: old},
+			ledgerRepo:      mockLedgerRepo{ledgers: []domain.AccountLedger{matched}},
+			expected:        ReconcileBalancesOutput{Runs: 1},
+			expectedCreated: 1,
+			expectedStatus:  domain.ReconciliationCompleted,
+		},
+		{
+			name:       "Reconcile reports the accounts that do not match",
+			repo:       mockReconciliationRepo{latest: recent, pending: []domain.ReconciliationRun{manual}},
+			ledgerRepo: mockLedgerRepo{ledgers: []domain.AccountLedger{matched, drifted}},
+			expected:   ReconcileBalancesOutput{Runs: 1, Mismatches: 1},
+			expectedMismatches: []domain.ReconciliationMismatch{
+				domain.NewReconciliationMismatch(accountB, 900, 1000),
+			},
+			expectedStatus: domain.ReconciliationCompleted,
+		},
+		{
+			name:                 "Reconcile reports the accounts whose opening balance is unknown as unverifiable",
+			repo:                 mockReconciliationRepo{latest: recent, pending: []domain.ReconciliationRun{manual}},
+			ledgerRepo:           mockLedgerRepo{ledgers: []domain.AccountLedger{matched, unknown}},
+			expected:             ReconcileBalancesOutput{Runs: 1, Unverifiable: 1},
+			expectedUnverifiable: []domain.AccountID{accountB},
+			expectedStatus:       domain.ReconciliationCompleted,
+		},
+		{
+			name: "Reconcile skips the accounts that match when read again",
+			repo: mockReconciliationRepo{latest: recent, pending: []domain.ReconciliationRun{manual}},
+			ledgerRepo: mockLedgerRepo{
+				ledgers: []domain.AccountLedger{matched, drifted},
+				recheck: map[domain.AccountID]domain.AccountLedger{
+					accountB: domain.NewAccountLedger(accountB, time.Time{}, 1000, 900, 0, 100),
+				},
+			},
+			expected:       ReconcileBalancesOutput{Runs: 1},
+			expectedStatus: domain.ReconciliationCompleted,
+		},
+		{
+			name:           "Reconcile fails the run when the balances can not be read",
+			repo:           mockReconciliationRepo{latest: recent, pending: []domain.ReconciliationRun{manual}},
+			ledgerRepo:     mockLedgerRepo{findErr: errors.New("error")},
+			expected:       ReconcileBalancesOutput{Runs: 1, Failed: 1},
+			expectedStatus: domain.ReconciliationFailed,
+		},
+		{
+			name:     "Reconcile has nothing to run",
+			repo:     mockReconciliationRepo{latest: recent},
+			expected: ReconcileBalancesOutput{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created, updated []domain.ReconciliationRun
+
+			tt.repo.created = &created
+			tt.repo.updated = &updated
+
+			var uc = NewReconcileBalancesInteractor(tt.repo, tt.ledgerRepo, config, time.Second)
+
+			got, err := uc.Execute(context.Background())
+			if err != nil {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, nil)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%+v' | Expected: '%+v'", tt.name, got, tt.expected)
+			}
+
+			if len(created) != tt.expectedCreated {
+				t.Errorf("[TestCase '%s'] Created: '%v' | Expected: '%v'", tt.name, len(created), tt.expectedCreated)
+			}
+
+			if tt.expectedStatus == "" {
+				if len(updated) != 0 {
+					t.Errorf("[TestCase '%s'] Updated: '%v' | Expected: '%v'", tt.name, len(updated), 0)
+				}
+				return
+			}
+
+			var last = updated[len(updated)-1]
+			if last.Status() != tt.expectedStatus {
+				t.Errorf("[TestCase '%s'] Status: '%v' | Expected: '%v'", tt.name, last.Status(), tt.expectedStatus)
+			}
+
+			if len(last.Mismatches()) != 0 || len(tt.expectedMismatches) != 0 {
+				if !reflect.DeepEqual(last.Mismatches(), tt.expectedMismatches) {
+					t.Errorf("[TestCase '%s'] Mismatches: '%+v' | Expected: '%+v'", tt.name, last.Mismatches(), tt.expectedMismatches)
+				}
+			}
+
+			if len(last.Unverifiable()) != 0 || len(tt.expectedUnverifiable) != 0 {
+				if !reflect.DeepEqual(last.Unverifiable(), tt.expectedUnverifiable) {
+					t.Errorf("[TestCase '%s'] Unverifiable: '%v' | Expected: '%v'", tt.name, last.Unverifiable(), tt.expectedUnverifiable)
+				}
+			}
+		})
+	}
+}
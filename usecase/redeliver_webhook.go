@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// RedeliverWebhookUseCase input port
+	RedeliverWebhookUseCase interface {
+		Execute(context.Context, domain.WebhookID, domain.WebhookDeliveryID) (WebhookDeliveryOutput, error)
+	}
+
+	// RedeliverWebhookPresenter output port
+	RedeliverWebhookPresenter interface {
+		Output(domain.WebhookDelivery) WebhookDeliveryOutput
+	}
+
+	redeliverWebhookInteractor struct {
+		webhookRepo  domain.WebhookRepository
+		deliveryRepo domain.WebhookDeliveryRepository
+		presenter    RedeliverWebhookPresenter
+		ctxTimeout   time.Duration
+	}
+)
+
+// NewRedeliverWebhookInteractor creates new redeliverWebhookInteractor with its dependencies
+func NewRedeliverWebhookInteractor(
+	webhookRepo domain.WebhookRepository,
+	deliveryRepo domain.WebhookDeliveryRepository,
+	presenter RedeliverWebhookPresenter,
+	t time.Duration,
+) RedeliverWebhookUseCase {
+	return redeliverWebhookInteractor{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		presenter:    presenter,
+		ctxTimeout:   t,
+	}
+}
+
+// Execute schedules a finished delivery of an enabled webhook to be posted again by the next delivery run
+func (a redeliverWebhookInteractor) Execute(
+	ctx context.Context,
+	webhookID domain.WebhookID,
+	ID domain.WebhookDeliveryID,
+) (WebhookDeliveryOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	webhook, err := authorizeWebhook(ctx, a.webhookRepo, OpRedeliverWebhook, webhookID)
+	if err != nil {
+		return a.presenter.Output(domain.WebhookDelivery{}), err
+	}
+
+	delivery, err := a.deliveryRepo.FindByID(ctx, ID)
+	if err != nil {
+		return a.presenter.Output(domain.WebhookDelivery{}), err
+	}
+
+	if delivery.WebhookID() != webhookID {
+		return a.presenter.Output(domain.WebhookDelivery{}), domain.ErrWebhookDeliveryNotFound
+	}
+
+	if !webhook.Enabled() {
+		return a.presenter.Output(domain.WebhookDelivery{}), domain.ErrWebhookDisabled
+	}
+
+	if err = delivery.Redeliver(time.Now()); err != nil {
+		return a.presenter.Output(domain.WebhookDelivery{}), err
+	}
+
+	if err = a.deliveryRepo.Update(ctx, delivery); err != nil {
+		return a.presenter.Output(domain.WebhookDelivery{}), err
+	}
+
+	return a.presenter.Output(delivery), nil
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type mockWebhookRepoRedeliver struct {
+	domain.WebhookRepository
+
+	result  domain.Webhook
+	findErr error
+}
+
+func (m mockWebhookRepoRedeliver) FindByID(_ context.Context, _ domain.WebhookID) (domain.Webhook, error) {
+	return m.result, m.findErr
+}
+
+type mockWebhookDeliveryRepoRedeliver struct {
+	domain.WebhookDeliveryRepository
+
+	result  domain.WebhookDelivery
+	findErr error
+	updated *domain.WebhookDelivery
+}
+
+func (m mockWebhookDeliveryRepoRedeliver) FindByID(_ context.Context, _ domain.WebhookDeliveryID) (domain.WebhookDelivery, error) {
+	return m.result, m.findErr
+}
+
+func (m mockWebhookDeliveryRepoRedeliver) Update(_ context.Context, delivery domain.WebhookDelivery) error {
+	*m.updated = delivery
+	return nil
+}
+
+type mockRedeliverWebhookPresenter struct{}
+
+func (m mockRedeliverWebhookPresenter) Output(delivery domain.WebhookDelivery) WebhookDeliveryOutput {
+	return WebhookDeliveryOutput{ID: delivery.ID().String(), Status: delivery.Status().String()}
+}
+
+func TestRedeliverWebhookInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	var (
+		failed    = webhookDeliveryFixture("d1", "w1", 3).MarkFailed(500, domain.ErrWebhookDisabled, time.Now(), true)
+		succeeded = webhookDeliveryFixture("d1", "w1", 0).MarkSucceeded(200, time.Now())
+	)
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		webhook       mockWebhookRepoRedeliver
+		delivery      domain.WebhookDelivery
+		deliveryErr   error
+		expected      WebhookDeliveryOutput
+		expectedError string
+	}{
+		{
+			name:     "Redeliver failed delivery",
+			ctx:      roleContext(domain.RoleAdmin),
+			webhook:  mockWebhookRepoRedeliver{result: webhookFixture("w1", 0, time.Time{})},
+			delivery: failed,
+			expected: WebhookDeliveryOutput{ID: "d1", Status: "pending"},
+		},
+		{
+			name:     "Redeliver succeeded delivery",
+			ctx:      roleContext(domain.RoleAdmin),
+			webhook:  mockWebhookRepoRedeliver{result: webhookFixture("w1", 0, time.Time{})},
+			delivery: succeeded,
+			expected: WebhookDeliveryOutput{ID: "d1", Status: "pending"},
+		},
+		{
+			name:          "Redeliver pending delivery",
+			ctx:           roleContext(domain.RoleAdmin),
+			webhook:       mockWebhookRepoRedeliver{result: webhookFixture("w1", 0, time.Time{})},
+			delivery:      webhookDeliveryFixture("d1", "w1", 1),
+			expected:      WebhookDeliveryOutput{},
+			expectedError: "webhook delivery is still pending",
+		},
+		{
+			name:          "Redeliver delivery of a disabled webhook",
+			ctx:           roleContext(domain.RoleAdmin),
+			webhook:       mockWebhookRepoRedeliver{result: webhookFixture("w1", 20, time.Now())},
+			delivery:      failed,
+			expected:      WebhookDeliveryOutput{},
+			expectedError: "webhook is disabled",
+		},
+		{
+			name:          "Redeliver delivery of another webhook",
+			ctx:           roleContext(domain.RoleAdmin),
+			webhook:       mockWebhookRepoRedeliver{result: webhookFixture("w1", 0, time.Time{})},
+			delivery:      webhookDeliveryFixture("d1", "w2", 3).MarkFailed(500, domain.ErrWebhookDisabled, time.Now(), true),
+			expected:      WebhookDeliveryOutput{},
+			expectedError: "webhook delivery not found",
+		},
+		{
+			name:          "Redeliver delivery not found",
+			ctx:           roleContext(domain.RoleAdmin),
+			webhook:       mockWebhookRepoRedeliver{result: webhookFixture("w1", 0, time.Time{})},
+			deliveryErr:   domain.ErrWebhookDeliveryNotFound,
+			expected:      WebhookDeliveryOutput{},
+			expectedError: "webhook delivery not found",
+		},
+		{
+			name:     "Redeliver delivery of a webhook of the client",
+			ctx:      clientContext("client", domain.ScopeWebhooksAdmin),
+			webhook:  mockWebhookRepoRedeliver{result: webhookFixture("w1", 0, time.Time{})},
+			delivery: failed,
+			expected: WebhookDeliveryOutput{ID: "d1", Status: "pending"},
+		},
+		{
+			name:          "Redeliver delivery of a webhook of another client",
+			ctx:           clientContext("other-client", domain.ScopeWebhooksAdmin),
+			webhook:       mockWebhookRepoRedeliver{result: webhookFixture("w1", 0, time.Time{})},
+			delivery:      failed,
+			expected:      WebhookDeliveryOutput{},
+			expectedError: "caller is not allowed to access this resource",
+		},
+		{
+			name:          "Redeliver forbidden to customers",
+			ctx:           customerContext("07091054965"),
+			webhook:       mockWebhookRepoRedeliver{result: webhookFixture("w1", 0, time.Time{})},
+			delivery:      failed,
+			expected:      WebhookDeliveryOutput{},
+			expectedError: "caller is not allowed to access this resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				updated domain.WebhookDelivery
+				uc      = NewRedeliverWebhookInteractor(
+					tt.webhook,
+					mockWebhookDeliveryRepoRedeliver{result: tt.delivery, findErr: tt.deliveryErr, updated: &updated},
+					mockRedeliverWebhookPresenter{},
+					time.Second,
+				)
+			)
+
+			result, err := uc.Execute(tt.ctx, "w1", "d1")
+			if (err != nil) && (err.Error() != tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+			}
+
+			if tt.expectedError == "" && (updated.Attempts() != 0 || !updated.Due(time.Now())) {
+				t.Errorf("[TestCase '%s'] Updated: '%v' '%v'", tt.name, updated.Status(), updated.Attempts())
+			}
+		})
+	}
+}
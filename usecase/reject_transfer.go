@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// RejectTransferUseCase input port of /v1
+	RejectTransferUseCase = RejectTransferUseCaseOf[CreateTransferOutput]
+
+	// RejectTransferUseCaseV2 input port of /v2
+	RejectTransferUseCaseV2 = RejectTransferUseCaseOf[TransferOutputV2]
+
+	// RejectTransferUseCaseOf input port, answering with the output of its presenter
+	RejectTransferUseCaseOf[O any] interface {
+		Execute(context.Context, domain.TransferID, RejectTransferInput) (O, error)
+	}
+
+	// RejectTransferInput input data
+	RejectTransferInput struct {
+		Reason string `json:"reason" validate:"max=500"`
+	}
+
+	rejectTransferInteractor[O any] struct {
+		transferRepo domain.TransferRepository
+		approvalRepo domain.TransferApprovalRepository
+		approval     TransferApprovalConfig
+		presenter    CreateTransferPresenterOf[O]
+		ctxTimeout   time.Duration
+	}
+)
+
+// NewRejectTransferInteractor creates new rejectTransferInteractor with its dependencies
+func NewRejectTransferInteractor[O any](
+	transferRepo domain.TransferRepository,
+	approvalRepo domain.TransferApprovalRepository,
+	approval TransferApprovalConfig,
+	presenter CreateTransferPresenterOf[O],
+	t time.Duration,
+) RejectTransferUseCaseOf[O] {
+	return rejectTransferInteractor[O]{
+		transferRepo: transferRepo,
+		approvalRepo: approvalRepo,
+		approval:     approval,
+		presenter:    presenter,
+		ctxTimeout:   t,
+	}
+}
+
+// Execute turns down a transfer waiting for a decision without moving the money
+func (t rejectTransferInteractor[O]) Execute(
+	ctx context.Context,
+	ID domain.TransferID,
+	input RejectTransferInput,
+) (O, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.ctxTimeout)
+	defer cancel()
+
+	principal, _, err := Authorize(ctx, OpRejectTransfer)
+	if err != nil {
+		return t.presenter.Output(domain.Transfer{}), err
+	}
+
+	var (
+		transfer domain.Transfer
+		expired  bool
+	)
+
+	err = t.transferRepo.WithTransaction(ctx, func(ctxTx context.Context) error {
+		transfer, expired, err = pendingTransfer(ctxTx, t.transferRepo, t.approvalRepo, t.approval, principal, ID)
+		if err != nil || expired {
+			return err
+		}
+
+		transfer, err = transfer.TransitionTo(domain.TransferStatusRejected)
+		if err != nil {
+			return err
+		}
+
+		if err = t.transferRepo.UpdateStatus(ctxTx, transfer.ID(), transfer.Status()); err != nil {
+			return err
+		}
+
+		return recordApproval(ctxTx, t.approvalRepo, transfer, domain.ApprovalRejected, principal, input.Reason)
+	})
+	if err != nil {
+		return t.presenter.Output(domain.Transfer{}), err
+	}
+
+	if expired {
+		return t.presenter.Output(domain.Transfer{}), domain.ErrTransferApprovalExpired
+	}
+
+	return t.presenter.Output(transfer), nil
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+func TestRejectTransferInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		transfer       domain.Transfer
+		history        []domain.TransferApproval
+		repoErr        error
+		expected       CreateTransferOutput
+		expectedStatus domain.TransferStatus
+		expectedReason string
+		expectedError  string
+	}{
+		{
+			name:           "Reject transfer successful",
+			ctx:            roleContext(domain.RoleAdmin),
+			transfer:       pendingTransferFixture(domain.TransferStatusHeld, time.Now()),
+			history:        requestedBy("08098565895"),
+			expected:       CreateTransferOutput{Status: "rejected"},
+			expectedStatus: domain.TransferStatusRejected,
+			expectedReason: "suspected fraud",
+		},
+		{
+			name:          "Reject transfer by its requester",
+			ctx:           roleContext(domain.RoleAdmin),
+			transfer:      pendingTransferFixture(domain.TransferStatusHeld, time.Now()),
+			history:       requestedBy("02815517078"),
+			expected:      CreateTransferOutput{},
+			expectedError: "transfer must be decided by a different user than its requester",
+		},
+		{
+			name:          "Reject transfer approval repository error",
+			ctx:           roleContext(domain.RoleAdmin),
+			transfer:      pendingTransferFixture(domain.TransferStatusHeld, time.Now()),
+			repoErr:       errors.New("error"),
+			expected:      CreateTransferOutput{},
+			expectedError: "error",
+		},
+		{
+			name:          "Reject transfer by client forbidden",
+			ctx:           domain.ContextWithPrincipal(context.Background(), domain.NewClientPrincipal("key", domain.ScopeTransfersWrite)),
+			expected:      CreateTransferOutput{},
+			expectedError: domain.ErrForbidden.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				status  domain.TransferStatus
+				actions []domain.TransferApproval
+			)
+
+			var uc = NewRejectTransferInteractor(
+				mockTransferRepoDecision{transfer: tt.transfer, updated: &status},
+				mockTransferApprovalRepo{history: tt.history, stored: &actions, err: tt.repoErr},
+				TransferApprovalConfig{Threshold: 1000, Timeout: time.Hour},
+				mockCreateTransferStatusPresenter{},
+				time.Second,
+			)
+
+			got, err := uc.Execute(tt.ctx, "3c096a40-ccba-4b58-93ed-57379ab04680", RejectTransferInput{Reason: "suspected fraud"})
+			if (err != nil) && (err.Error() != tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+
+			if tt.expectedStatus == "" {
+				return
+			}
+
+			if status != tt.expectedStatus {
+				t.Errorf("[TestCase '%s'] Status: '%v' | Expected: '%v'", tt.name, status, tt.expectedStatus)
+			}
+
+			if len(actions) != 1 || actions[0].Reason() != tt.expectedReason {
+				t.Errorf("[TestCase '%s'] Actions: '%v' | Expected reason: '%v'", tt.name, actions, tt.expectedReason)
+			}
+		})
+	}
+}
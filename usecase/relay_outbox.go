@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// RelayOutboxUseCase input port
+	RelayOutboxUseCase interface {
+		Execute(context.Context) (RelayOutboxOutput, error)
+	}
+
+	// RelayOutboxOutput is what a relay run did, and how many messages the outbox holds by status afterwards
+	RelayOutboxOutput struct {
+		Sent    int
+		Retried int
+		Dead    int
+		Backlog map[domain.OutboxStatus]int
+	}
+
+	// OutboxRelayConfig sets how many messages a run claims at a time, how long it holds them and how failed messages
+	// are retried. A message failing MaxAttempts times is dead-lettered
+	OutboxRelayConfig struct {
+		BatchSize   int
+		Lease       time.Duration
+		MaxAttempts int
+		BaseBackoff time.Duration
+		MaxBackoff  time.Duration
+	}
+
+	relayOutboxInteractor struct {
+		outboxRepo domain.OutboxRepository
+		publisher  domain.EventPublisher
+		config     OutboxRelayConfig
+		ctxTimeout time.Duration
+	}
+)
+
+// NewRelayOutboxInteractor creates new relayOutboxInteractor with its dependencies
+func NewRelayOutboxInteractor(
+	outboxRepo domain.OutboxRepository,
+	publisher domain.EventPublisher,
+	config OutboxRelayConfig,
+	t time.Duration,
+) RelayOutboxUseCase {
+	return relayOutboxInteractor{
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+		config:     config,
+		ctxTimeout: t,
+	}
+}
+
+// Backoff is how long to wait before the next attempt after the given number of failed ones, doubling each time
+func (c OutboxRelayConfig) Backoff(attempts int) time.Duration {
+	return backoff(c.BaseBackoff, c.MaxBackoff, attempts)
+}
+
+func backoff(base, max time.Duration, attempts int) time.Duration {
+	var d = base
+	for i := 1; i < attempts && d < max; i++ {
+		d *= 2
+	}
+
+	if d > max {
+		d = max
+	}
+
+	return d
+}
+
+// Execute publishes the pending messages that are due, a batch at a time, until none is left. Each batch is leased
+// to the run, so relays running side by side never publish the same message; the lease of a run stopping midway
+// expires and its messages are taken again. A message is marked as sent only after it was published, so it may be
+// published again if marking fails. The messages of an aggregate are published in order: one that must wait for a
+// retry holds back the following ones
+func (t relayOutboxInteractor) Execute(ctx context.Context) (RelayOutboxOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.ctxTimeout)
+	defer cancel()
+
+	var output RelayOutboxOutput
+
+	for {
+		var now = time.Now()
+
+		messages, err := t.outboxRepo.ClaimDue(ctx, now, now.Add(t.config.Lease), t.config.BatchSize)
+		if err != nil {
+			return output, err
+		}
+
+		if len(messages) == 0 {
+			break
+		}
+
+		for _, message := range messages {
+			if err := t.publisher.Publish(ctx, message.Event()); err != nil {
+				var (
+					attempts = message.Attempts() + 1
+					dead     = attempts >= t.config.MaxAttempts
+				)
+
+				message = message.MarkFailed(err, now.Add(t.config.Backoff(attempts)), dead)
+
+				if dead {
+					output.Dead++
+				} else {
+					output.Retried++
+				}
+			} else {
+				message = message.MarkSent(time.Now())
+				output.Sent++
+			}
+
+			if err := t.outboxRepo.Update(ctx, message); err != nil {
+				return output, err
+			}
+		}
+	}
+
+	var err error
+	output.Backlog, err = t.outboxRepo.CountByStatus(ctx)
+	if err != nil {
+		return output, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// mockOutboxRepoRelay keeps the messages as the repositories do, claiming the due ones heading their aggregate
+type mockOutboxRepoRelay struct {
+	domain.OutboxRepository
+
+	messages map[string]domain.OutboxMessage
+	order    []string
+	claimErr error
+	updated  *[]domain.OutboxMessage
+}
+
+func newMockOutboxRepoRelay(pending []domain.OutboxMessage, claimErr error, updated *[]domain.OutboxMessage) mockOutboxRepoRelay {
+	var m = mockOutboxRepoRelay{messages: make(map[string]domain.OutboxMessage), claimErr: claimErr, updated: updated}
+	for _, message := range pending {
+		m.messages[message.Event().ID()] = message
+		m.order = append(m.order, message.Event().ID())
+	}
+
+	return m
+}
+
+func (m mockOutboxRepoRelay) ClaimDue(_ context.Context, now, until time.Time, limit int) ([]domain.OutboxMessage, error) {
+	if m.claimErr != nil {
+		return nil, m.claimErr
+	}
+
+	var (
+		claimed []domain.OutboxMessage
+		heads   = make(map[string]bool)
+	)
+
+	for _, ID := range m.order {
+		var message = m.messages[ID]
+		if len(claimed) == limit || message.Status() != domain.OutboxPending || heads[message.Event().AggregateID()] {
+			continue
+		}
+		heads[message.Event().AggregateID()] = true
+
+		if message.Due(now) {
+			claimed = append(claimed, message)
+			m.messages[ID] = domain.NewOutboxMessage(
+				message.Event(),
+				message.Sequence(),
+				message.Status(),
+				message.Attempts(),
+				until,
+				message.LastError(),
+				message.SentAt(),
+			)
+		}
+	}
+
+	return claimed, nil
+}
+
+func (m mockOutboxRepoRelay) Update(_ context.Context, message domain.OutboxMessage) error {
+	*m.updated = append(*m.updated, message)
+	m.messages[message.Event().ID()] = message
+	return nil
+}
+
+func (m mockOutboxRepoRelay) CountByStatus(_ context.Context) (map[domain.OutboxStatus]int, error) {
+	var counts = make(map[domain.OutboxStatus]int)
+	for _, message := range m.messages {
+		counts[message.Status()]++
+	}
+
+	return counts, nil
+}
+
+// mockEventPublisherFailing fails the events whose ID is listed and records the ID of every event it is handed
+type mockEventPublisherFailing struct {
+	failing   map[string]bool
+	published *[]string
+}
+
+func (m mockEventPublisherFailing) Publish(_ context.Context, events ...domain.Event) error {
+	for _, e := range events {
+		*m.published = append(*m.published, e.ID())
+		if m.failing[e.ID()] {
+			return errors.New("subscriber unavailable")
+		}
+	}
+
+	return nil
+}
+
+func outboxMessageFixture(ID, aggregateID string, attempts int, nextAttemptAt time.Time) domain.OutboxMessage {
+	return domain.NewOutboxMessage(
+		domain.NewEvent(ID, domain.EventTransferCompleted, domain.EventSchemaVersion, aggregateID, []byte(`{}`), time.Time{}),
+		0,
+		domain.OutboxPending,
+		attempts,
+		nextAttemptAt,
+		"",
+		time.Time{},
+	)
+}
+
+func TestRelayOutboxInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	var (
+		config = OutboxRelayConfig{
+			BatchSize:   100,
+			Lease:       time.Minute,
+			MaxAttempts: 3,
+			BaseBackoff: time.Second,
+			MaxBackoff:  time.Minute,
+		}
+		past   = time.Now().Add(-time.Minute)
+		future = time.Now().Add(time.Hour)
+	)
+
+	tests := []struct {
+		name              string
+		pending           []domain.OutboxMessage
+		claimErr          error
+		failing           map[string]bool
+		expected          RelayOutboxOutput
+		expectedPublished []string
+		expectedStatuses  map[string]domain.OutboxStatus
+		expectedError     string
+	}{
+		{
+			name: "Relay publishes every due message",
+			pending: []domain.OutboxMessage{
+				outboxMessageFixture("a1", "a", 0, past),
+				outboxMessageFixture("b1", "b", 0, past),
+				outboxMessageFixture("a2", "a", 0, past),
+			},
+			expected: RelayOutboxOutput{
+				Sent:    3,
+				Backlog: map[domain.OutboxStatus]int{domain.OutboxSent: 3},
+			},
+			expectedPublished: []string{"a1", "b1", "a2"},
+			expectedStatuses:  map[string]domain.OutboxStatus{"a1": domain.OutboxSent, "b1": domain.OutboxSent, "a2": domain.OutboxSent},
+		},
+		{
+			name: "Relay holds back the aggregate of a failed message",
+			pending: []domain.OutboxMessage{
+				outboxMessageFixture("a1", "a", 0, past),
+				outboxMessageFixture("b1", "b", 0, past),
+				outboxMessageFixture("a2", "a", 0, past),
+			},
+			failing: map[string]bool{"a1": true},
+			expected: RelayOutboxOutput{
+				Sent:    1,
+				Retried: 1,
+				Backlog: map[domain.OutboxStatus]int{domain.OutboxPending: 2, domain.OutboxSent: 1},
+			},
+			expectedPublished: []string{"a1", "b1"},
+			expectedStatuses:  map[string]domain.OutboxStatus{"a1": domain.OutboxPending, "b1": domain.OutboxSent},
+		},
+		{
+			name: "Relay waits for the backoff of a message and its aggregate",
+			pending: []domain.OutboxMessage{
+				outboxMessageFixture("a1", "a", 1, future),
+				outboxMessageFixture("a2", "a", 0, past),
+				outboxMessageFixture("b1", "b", 0, past),
+			},
+			expected: RelayOutboxOutput{
+				Sent:    1,
+				Backlog: map[domain.OutboxStatus]int{domain.OutboxPending: 2, domain.OutboxSent: 1},
+			},
+			expectedPublished: []string{"b1"},
+			expectedStatuses:  map[string]domain.OutboxStatus{"b1": domain.OutboxSent},
+		},
+		{
+			name: "Relay dead-letters a message after its last attempt",
+			pending: []domain.OutboxMessage{
+				outboxMessageFixture("a1", "a", 2, past),
+				outboxMessageFixture("a2", "a", 0, past),
+			},
+			failing: map[string]bool{"a1": true},
+			expected: RelayOutboxOutput{
+				Sent:    1,
+				Dead:    1,
+				Backlog: map[domain.OutboxStatus]int{domain.OutboxDead: 1, domain.OutboxSent: 1},
+			},
+			expectedPublished: []string{"a1", "a2"},
+			expectedStatuses:  map[string]domain.OutboxStatus{"a1": domain.OutboxDead, "a2": domain.OutboxSent},
+		},
+		{
+			name:              "Relay error claiming the outbox",
+			claimErr:          errors.New("error"),
+			expected:          RelayOutboxOutput{},
+			expectedStatuses:  map[string]domain.OutboxStatus{},
+			expectedPublished: nil,
+			expectedError:     "error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				updated   []domain.OutboxMessage
+				published []string
+			)
+
+			var uc = NewRelayOutboxInteractor(
+				newMockOutboxRepoRelay(tt.pending, tt.claimErr, &updated),
+				mockEventPublisherFailing{failing: tt.failing, published: &published},
+				config,
+				time.Second,
+			)
+
+			got, err := uc.Execute(context.Background())
+			if (err != nil) && (err.Error() != tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%+v' | Expected: '%+v'", tt.name, got, tt.expected)
+			}
+
+			if !reflect.DeepEqual(published, tt.expectedPublished) {
+				t.Errorf("[TestCase '%s'] Published: '%v' | Expected: '%v'", tt.name, published, tt.expectedPublished)
+			}
+
+			var statuses = make(map[string]domain.OutboxStatus)
+			for _, m := range updated {
+				statuses[m.Event().ID()] = m.Status()
+			}
+
+			if !reflect.DeepEqual(statuses, tt.expectedStatuses) {
+				t.Errorf("[TestCase '%s'] Statuses: '%v' | Expected: '%v'", tt.name, statuses, tt.expectedStatuses)
+			}
+		})
+	}
+}
+
+func TestOutboxRelayConfig_Backoff(t *testing.T) {
+	t.Parallel()
+
+	var config = OutboxRelayConfig{BaseBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 1, expected: time.Second},
+		{attempts: 2, expected: 2 * time.Second},
+		{attempts: 4, expected: 8 * time.Second},
+		{attempts: 5, expected: 10 * time.Second},
+		{attempts: 60, expected: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := config.Backoff(tt.attempts); got != tt.expected {
+			t.Errorf("[TestCase '%d attempts'] Result: '%v' | Expected: '%v'", tt.attempts, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// RequestReconciliationUseCase input port
+	RequestReconciliationUseCase interface {
+		Execute(context.Context) (ReconciliationOutput, error)
+	}
+
+	requestReconciliationInteractor struct {
+		repo       domain.ReconciliationRepository
+		presenter  ReconciliationPresenter
+		ctxTimeout time.Duration
+	}
+)
+
+// NewRequestReconciliationInteractor creates new requestReconciliationInteractor with its dependencies
+func NewRequestReconciliationInteractor(
+	repo domain.ReconciliationRepository,
+	presenter ReconciliationPresenter,
+	t time.Duration,
+) RequestReconciliationUseCase {
+	return requestReconciliationInteractor{
+		repo:       repo,
+		presenter:  presenter,
+		ctxTimeout: t,
+	}
+}
+
+// Execute requests a run, which the reconciliation job takes on its next pass
+func (r requestReconciliationInteractor) Execute(ctx context.Context) (ReconciliationOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.ctxTimeout)
+	defer cancel()
+
+	if _, _, err := Authorize(ctx, OpRequestReconciliation); err != nil {
+		return r.presenter.Output(domain.ReconciliationRun{}), err
+	}
+
+	var run = domain.NewPendingReconciliationRun(
+		domain.ReconciliationRunID(domain.NewUUID()),
+		domain.ReconciliationManual,
+		time.Now(),
+	)
+
+	if err := r.repo.Create(ctx, run); err != nil {
+		return r.presenter.Output(domain.ReconciliationRun{}), err
+	}
+
+	return r.presenter.Output(run), nil
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// RevokeAPIKeyUseCase input port
+	RevokeAPIKeyUseCase interface {
+		Execute(context.Context, domain.APIKeyID) (FindAllAPIKeyOutput, error)
+	}
+
+	// RevokeAPIKeyPresenter output port
+	RevokeAPIKeyPresenter interface {
+		Output(domain.APIKey) FindAllAPIKeyOutput
+	}
+
+	revokeAPIKeyInteractor struct {
+		repo       domain.APIKeyRepository
+		presenter  RevokeAPIKeyPresenter
+		ctxTimeout time.Duration
+	}
+)
+
+// NewRevokeAPIKeyInteractor creates new revokeAPIKeyInteractor with its dependencies
+func NewRevokeAPIKeyInteractor(
+	repo domain.APIKeyRepository,
+	presenter RevokeAPIKeyPresenter,
+	t time.Duration,
+) RevokeAPIKeyUseCase {
+	return revokeAPIKeyInteractor{
+		repo:       repo,
+		presenter:  presenter,
+		ctxTimeout: t,
+	}
+}
+
+// Execute orchestrates the use case
+func (a revokeAPIKeyInteractor) Execute(ctx context.Context, ID domain.APIKeyID) (FindAllAPIKeyOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	if _, _, err := Authorize(ctx, OpRevokeAPIKey); err != nil {
+		return a.presenter.Output(domain.APIKey{}), err
+	}
+
+	apiKey, err := a.repo.FindByID(ctx, ID)
+	if err != nil {
+		return a.presenter.Output(domain.APIKey{}), err
+	}
+
+	apiKey.Revoke(time.Now())
+
+	if err = a.repo.Update(ctx, apiKey); err != nil {
+		return a.presenter.Output(domain.APIKey{}), err
+	}
+
+	return a.presenter.Output(apiKey), nil
+}
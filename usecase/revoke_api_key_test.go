@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type mockAPIKeyRepoRevoke struct {
+	domain.APIKeyRepository
+
+	result    domain.APIKey
+	findErr   error
+	updateErr error
+}
+
+func (m mockAPIKeyRepoRevoke) FindByID(_ context.Context, _ domain.APIKeyID) (domain.APIKey, error) {
+	return m.result, m.findErr
+}
+
+func (m mockAPIKeyRepoRevoke) Update(_ context.Context, apiKey domain.APIKey) error {
+	if apiKey.RevokedAt().IsZero() {
+		return errors.New("api key was not revoked")
+	}
+
+	return m.updateErr
+}
+
+type mockRevokeAPIKeyPresenter struct {
+	result FindAllAPIKeyOutput
+}
+
+func (m mockRevokeAPIKeyPresenter) Output(_ domain.APIKey) FindAllAPIKeyOutput {
+	return m.result
+}
+
+func TestRevokeAPIKeyInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		repository    domain.APIKeyRepository
+		presenter     RevokeAPIKeyPresenter
+		expected      FindAllAPIKeyOutput
+		expectedError string
+	}{
+		{
+			name: "Revoke api key successful",
+			repository: mockAPIKeyRepoRevoke{
+				result: domain.NewAPIKey(
+					"3c096a40-ccba-4b58-93ed-57379ab04680",
+					"client",
+					"erp",
+					"abcd1234",
+					"hash",
+					[]domain.Scope{domain.ScopeAccountsRead},
+					time.Time{},
+					time.Time{},
+					time.Time{},
+					time.Time{},
+				),
+			},
+			presenter: mockRevokeAPIKeyPresenter{
+				result: FindAllAPIKeyOutput{ID: "3c096a40-ccba-4b58-93ed-57379ab04680"},
+			},
+			expected: FindAllAPIKeyOutput{ID: "3c096a40-ccba-4b58-93ed-57379ab04680"},
+		},
+		{
+			name: "Revoke api key not found",
+			repository: mockAPIKeyRepoRevoke{
+				findErr: domain.ErrAPIKeyNotFound,
+			},
+			presenter: mockRevokeAPIKeyPresenter{
+				result: FindAllAPIKeyOutput{},
+			},
+			expected:      FindAllAPIKeyOutput{},
+			expectedError: "api key not found",
+		},
+		{
+			name: "Revoke api key generic error",
+			repository: mockAPIKeyRepoRevoke{
+				updateErr: errors.New("error"),
+			},
+			presenter: mockRevokeAPIKeyPresenter{
+				result: FindAllAPIKeyOutput{},
+			},
+			expected:      FindAllAPIKeyOutput{},
+			expectedError: "error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uc = NewRevokeAPIKeyInteractor(tt.repository, tt.presenter, time.Second)
+
+			result, err := uc.Execute(roleContext(domain.RoleAdmin), "3c096a40-ccba-4b58-93ed-57379ab04680")
+			if (err != nil) && (err.Error() != tt.expectedError) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// RotateAPIKeyUseCase input port
+	RotateAPIKeyUseCase interface {
+		Execute(context.Context, domain.APIKeyID) (CreateAPIKeyOutput, error)
+	}
+
+	rotateAPIKeyInteractor struct {
+		repo       domain.APIKeyRepository
+		presenter  CreateAPIKeyPresenter
+		overlap    time.Duration
+		ctxTimeout time.Duration
+	}
+)
+
+// NewRotateAPIKeyInteractor creates new rotateAPIKeyInteractor with its dependencies. The replaced key stays
+// valid for the overlap so clients can switch over without downtime, and the new key is issued to the same client
+func NewRotateAPIKeyInteractor(
+	repo domain.APIKeyRepository,
+	presenter CreateAPIKeyPresenter,
+	overlap time.Duration,
+	t time.Duration,
+) RotateAPIKeyUseCase {
+	return rotateAPIKeyInteractor{
+		repo:       repo,
+		presenter:  presenter,
+		overlap:    overlap,
+		ctxTimeout: t,
+	}
+}
+
+// Execute orchestrates the use case
+func (a rotateAPIKeyInteractor) Execute(ctx context.Context, ID domain.APIKeyID) (CreateAPIKeyOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	principal, _, err := Authorize(ctx, OpRotateAPIKey)
+	if err != nil {
+		return a.presenter.Output(domain.APIKey{}, ""), err
+	}
+
+	var now = time.Now()
+
+	current, err := a.repo.FindByID(ctx, ID)
+	if err != nil {
+		return a.presenter.Output(domain.APIKey{}, ""), err
+	}
+
+	if !current.Active(now) {
+		return a.presenter.Output(domain.APIKey{}, ""), domain.ErrAPIKeyInactive
+	}
+
+	if err = authorizeScopes(principal, current.Scopes()); err != nil {
+		return a.presenter.Output(domain.APIKey{}, ""), err
+	}
+
+	apiKey, key, err := issueAPIKey(ctx, a.repo, current.ClientID(), current.Name(), current.Scopes(), current.ExpiresAt())
+	if err != nil {
+		return a.presenter.Output(domain.APIKey{}, ""), err
+	}
+
+	current.ExpireBy(now.Add(a.overlap))
+
+	if err = a.repo.Update(ctx, current); err != nil {
+		return a.presenter.Output(domain.APIKey{}, ""), err
+	}
+
+	return a.presenter.Output(apiKey, key), nil
+}
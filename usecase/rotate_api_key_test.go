@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type mockAPIKeyRepoRotate struct {
+	domain.APIKeyRepository
+
+	current   domain.APIKey
+	findErr   error
+	createErr error
+	updated   *domain.APIKey
+	created   *domain.APIKey
+}
+
+func (m mockAPIKeyRepoRotate) FindByID(_ context.Context, _ domain.APIKeyID) (domain.APIKey, error) {
+	return m.current, m.findErr
+}
+
+func (m mockAPIKeyRepoRotate) Create(_ context.Context, apiKey domain.APIKey) (domain.APIKey, error) {
+	*m.created = apiKey
+	return apiKey, m.createErr
+}
+
+func (m mockAPIKeyRepoRotate) Update(_ context.Context, apiKey domain.APIKey) error {
+	*m.updated = apiKey
+	return nil
+}
+
+func TestRotateAPIKeyInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now     = time.Now()
+		overlap = time.Hour
+		scopes  = []domain.Scope{domain.ScopeTransfersWrite}
+	)
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		repository    mockAPIKeyRepoRotate
+		expectedError string
+	}{
+		{
+			name: "Rotate api key keeps the old key for the overlap",
+			repository: mockAPIKeyRepoRotate{
+				current: domain.NewAPIKey(
+					"3c096a40-ccba-4b58-93ed-57379ab04680",
+					"client",
+					"erp",
+					"abcd1234",
+					"hash",
+					scopes,
+					time.Time{},
+					time.Time{},
+					time.Time{},
+					now.Add(-24*time.Hour),
+				),
+				updated: &domain.APIKey{},
+				created: &domain.APIKey{},
+			},
+		},
+		{
+			name: "Rotate revoked api key",
+			repository: mockAPIKeyRepoRotate{
+				current: domain.NewAPIKey(
+					"3c096a40-ccba-4b58-93ed-57379ab04680",
+					"client",
+					"erp",
+					"abcd1234",
+					"hash",
+					scopes,
+					time.Time{},
+					time.Time{},
+					now.Add(-time.Minute),
+					now.Add(-24*time.Hour),
+				),
+				updated: &domain.APIKey{},
+				created: &domain.APIKey{},
+			},
+			expectedError: "api key is revoked or expired",
+		},
+		{
+			name: "Rotate api key not found",
+			repository: mockAPIKeyRepoRotate{
+				findErr: domain.ErrAPIKeyNotFound,
+				updated: &domain.APIKey{},
+				created: &domain.APIKey{},
+			},
+			expectedError: "api key not found",
+		},
+		{
+			name: "Rotate api key with a scope the client does not hold",
+			ctx: domain.ContextWithPrincipal(
+				context.Background(),
+				domain.NewClientPrincipal("c5b4a3e1-21a2-4e3f-9d4c-4a5f1bbf3e55", domain.ScopeAPIKeysAdmin),
+			),
+			repository: mockAPIKeyRepoRotate{
+				current: domain.NewAPIKey(
+					"3c096a40-ccba-4b58-93ed-57379ab04680",
+					"client",
+					"erp",
+					"abcd1234",
+					"hash",
+					scopes,
+					time.Time{},
+					time.Time{},
+					time.Time{},
+					now.Add(-24*time.Hour),
+				),
+				updated: &domain.APIKey{},
+				created: &domain.APIKey{},
+			},
+			expectedError: "caller cannot grant a scope it does not hold",
+		},
+		{
+			name: "Rotate api key generic error",
+			repository: mockAPIKeyRepoRotate{
+				current: domain.NewAPIKey(
+					"3c096a40-ccba-4b58-93ed-57379ab04680",
+					"client",
+					"erp",
+					"abcd1234",
+					"hash",
+					scopes,
+					time.Time{},
+					time.Time{},
+					time.Time{},
+					now.Add(-24*time.Hour),
+				),
+				createErr: errors.New("error"),
+				updated:   &domain.APIKey{},
+				created:   &domain.APIKey{},
+			},
+			expectedError: "error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uc = NewRotateAPIKeyInteractor(tt.repository, mockCreateAPIKeyPresenter{}, overlap, time.Second)
+
+			var ctx = tt.ctx
+			if ctx == nil {
+				ctx = roleContext(domain.RoleAdmin)
+			}
+
+			_, err := uc.Execute(ctx, "3c096a40-ccba-4b58-93ed-57379ab04680")
+			if tt.expectedError != "" {
+				if err == nil || err.Error() != tt.expectedError {
+					t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("[TestCase '%s'] unexpected error: '%v'", tt.name, err)
+			}
+
+			var (
+				old         = *tt.repository.updated
+				replacement = *tt.repository.created
+			)
+
+			if !old.Active(time.Now()) || old.Active(time.Now().Add(overlap+time.Minute)) {
+				t.Errorf("[TestCase '%s'] old key should only be active during the overlap, expires at '%v'", tt.name, old.ExpiresAt())
+			}
+
+			if replacement.Name() != "erp" || len(replacement.Scopes()) != 1 || replacement.ID() == old.ID() {
+				t.Errorf("[TestCase '%s'] unexpected replacement key '%+v'", tt.name, replacement)
+			}
+
+			// What the client owns stays its own with the new key
+			if replacement.ClientID() != old.ClientID() {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, replacement.ClientID(), old.ClientID())
+			}
+		})
+	}
+}
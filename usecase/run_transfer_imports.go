@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// RunTransferImportsUseCase input port
+	RunTransferImportsUseCase interface {
+		Execute(context.Context) (RunTransferImportsOutput, error)
+	}
+
+	// RunTransferImportsOutput counts the jobs a pass completed and those whose run failed, to be run again
+	RunTransferImportsOutput struct {
+		Completed int
+		Failed    int
+	}
+
+	runTransferImportsInteractor struct {
+		repo       domain.TransferImportRepository
+		transfers  CreateTransferUseCase
+		ctxTimeout time.Duration
+	}
+)
+
+// NewRunTransferImportsInteractor creates new runTransferImportsInteractor with its dependencies. Transfers are
+// created by the use case given, with its validation, risk rules, approval and audit
+func NewRunTransferImportsInteractor(
+	repo domain.TransferImportRepository,
+	transfers CreateTransferUseCase,
+	t time.Duration,
+) RunTransferImportsUseCase {
+	return runTransferImportsInteractor{
+		repo:       repo,
+		transfers:  transfers,
+		ctxTimeout: t,
+	}
+}
+
+// Execute runs the jobs not completed, one at a time, until none is left. Each job is leased to the pass for as long
+// as it may last, so passes running side by side never run the same job. A job whose run fails keeps the error and
+// its lease, and the pass goes on with the next one; it is run again once the lease expires. Every line is saved as it
+// goes, so a job stopped halfway resumes from the first line not run
+func (r runTransferImportsInteractor) Execute(ctx context.Context) (RunTransferImportsOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.ctxTimeout)
+	defer cancel()
+
+	var output RunTransferImportsOutput
+
+	for {
+		var now = time.Now()
+
+		jobs, err := r.repo.ClaimDue(ctx, now, now.Add(r.ctxTimeout), 1)
+		if err != nil {
+			return output, err
+		}
+
+		if len(jobs) == 0 {
+			return output, nil
+		}
+
+		job, err := r.run(ctx, jobs[0])
+		if err == nil {
+			output.Completed++
+			continue
+		}
+
+		// The pass ran out of time, the job is taken again as the next one starts
+		if ctx.Err() != nil {
+			return output, err
+		}
+
+		output.Failed++
+		if err = r.repo.Update(ctx, job.Interrupt(err)); err != nil {
+			return output, err
+		}
+	}
+}
+
+// run creates the transfers of the lines of the job not run yet, returning the job as far as it got
+func (r runTransferImportsInteractor) run(ctx context.Context, job domain.TransferImport) (domain.TransferImport, error) {
+	var err error
+	if job.Status() == domain.TransferImportPending {
+		started, err := job.Start(time.Now())
+		if err != nil {
+			return job, err
+		}
+
+		if err = r.repo.Update(ctx, started); err != nil {
+			return job, err
+		}
+
+		job = started
+	}
+
+	// The transfers are created as the principal who uploaded the file, so the same rules apply as to an API call
+	var asRequester = domain.ContextWithPrincipal(ctx, job.RequestedBy())
+
+	for _, line := range job.Lines() {
+		if err = ctx.Err(); err != nil {
+			return job, err
+		}
+
+		var run bool
+		switch line.Status() {
+		case domain.TransferImportLineRunning:
+			line, err = line.Fail(domain.ErrTransferImportInterrupted)
+			run = true
+		case domain.TransferImportLinePending:
+			line, run, err = r.runLine(ctx, asRequester, job.ID(), line)
+		}
+		if err != nil {
+			return job, err
+		}
+
+		if !run {
+			continue
+		}
+
+		if err = r.repo.UpdateLine(ctx, job.ID(), line); err != nil {
+			return job, err
+		}
+
+		job = job.WithLine(line)
+	}
+
+	completed, err := job.Complete(time.Now())
+	if err != nil {
+		return job, err
+	}
+
+	if err = r.repo.Update(ctx, completed); err != nil {
+		return job, err
+	}
+
+	return completed, nil
+}
+
+// runLine creates the transfer of the line, telling whether it ran it. The line is claimed as running first, so a
+// transfer created by a job stopped before saving its result is never created twice, and a line another run claimed
+// is left to it
+func (r runTransferImportsInteractor) runLine(
+	ctx context.Context,
+	asRequester context.Context,
+	ID domain.TransferImportID,
+	line domain.TransferImportLine,
+) (domain.TransferImportLine, bool, error) {
+	line, err := line.Run()
+	if err != nil {
+		return line, false, err
+	}
+
+	claimed, err := r.repo.ClaimLine(ctx, ID, line)
+	if err != nil || !claimed {
+		return line, false, err
+	}
+
+	output, err := r.transfers.Execute(asRequester, CreateTransferInput{
+		AccountOriginID:      line.AccountOriginID().String(),
+		AccountDestinationID: line.AccountDestinationID().String(),
+		Amount:               line.Amount().Int64(),
+	})
+	if err != nil {
+		line, err = line.Fail(err)
+		return line, true, err
+	}
+
+	line, err = line.Succeed(domain.TransferID(output.ID), domain.TransferStatus(output.Status))
+	return line, true, err
+}
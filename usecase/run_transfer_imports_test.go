@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// mockTransferImportRepoRun leases each job once, as the repositories do while the lease lasts. The lines numbered in
+// taken were claimed by another run, and the lines of the failing job can not be saved
+type mockTransferImportRepoRun struct {
+	domain.TransferImportRepository
+
+	pending []domain.TransferImport
+	leased  map[domain.TransferImportID]bool
+	taken   map[int]bool
+	failing domain.TransferImportID
+	updated *[]domain.TransferImportStatus
+	errors  *[]string
+	lines   *[]domain.TransferImportLineStatus
+}
+
+func (m mockTransferImportRepoRun) ClaimDue(_ context.Context, _, _ time.Time, limit int) ([]domain.TransferImport, error) {
+	var claimed []domain.TransferImport
+	for _, job := range m.pending {
+		if len(claimed) == limit || m.leased[job.ID()] {
+			continue
+		}
+
+		m.leased[job.ID()] = true
+		claimed = append(claimed, job)
+	}
+
+	return claimed, nil
+}
+
+func (m mockTransferImportRepoRun) Update(_ context.Context, job domain.TransferImport) error {
+	*m.updated = append(*m.updated, job.Status())
+	if job.LastError() != "" {
+		*m.errors = append(*m.errors, job.LastError())
+	}
+
+	return nil
+}
+
+func (m mockTransferImportRepoRun) UpdateLine(_ context.Context, ID domain.TransferImportID, line domain.TransferImportLine) error {
+	if ID == m.failing {
+		return errors.New("connection reset")
+	}
+
+	*m.lines = append(*m.lines, line.Status())
+	return nil
+}
+
+func (m mockTransferImportRepoRun) ClaimLine(_ context.Context, _ domain.TransferImportID, line domain.TransferImportLine) (bool, error) {
+	if m.taken[line.Number()] {
+		return false, nil
+	}
+
+	*m.lines = append(*m.lines, line.Status())
+	return true, nil
+}
+
+// mockCreateTransferUseCase fails the transfers to the destination given and records who asked for each transfer
+type mockCreateTransferUseCase struct {
+	failTo     string
+	requesters *[]string
+}
+
+func (m mockCreateTransferUseCase) Execute(ctx context.Context, input CreateTransferInput) (CreateTransferOutput, error) {
+	principal, _ := domain.PrincipalFromContext(ctx)
+	*m.requesters = append(*m.requesters, principal.Subject())
+
+	if input.AccountDestinationID == m.failTo {
+		return CreateTransferOutput{}, domain.ErrInsufficientBalance
+	}
+
+	return CreateTransferOutput{ID: "5ecd3a6f-1e1a-4b8a-b1d3-3f2c1c9a1d10", Status: domain.TransferStatusCompleted.String()}, nil
+}
+
+func TestRunTransferImportsInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	const (
+		first       = domain.TransferImportID("0b4c4b5e-8a0f-4f0c-9f4d-7c2c5f1d9e11")
+		second      = domain.TransferImportID("7d1e2f3a-4b5c-4d6e-8f9a-0b1c2d3e4f5a")
+		origin      = domain.AccountID("3c096a40-ccba-4b58-93ed-57379ab04680")
+		destination = domain.AccountID("a8a1b6b1-4d1f-4ad2-9d8a-9f1e0e0b6f3c")
+		poor        = domain.AccountID("c1a2b3d4-0e5f-4a6b-8c7d-9e0f1a2b3c4d")
+	)
+
+	var (
+		requestedBy = domain.NewPrincipal("02815517078", domain.RoleCustomer, domain.CustomerScopes...)
+		newJob      = func(ID domain.TransferImportID, status domain.TransferImportStatus, lines ...domain.TransferImportLine) domain.TransferImport {
+			return domain.NewTransferImport(
+				ID,
+				"payroll.csv",
+				domain.TransferImportCSV,
+				"",
+				status,
+				requestedBy,
+				lines,
+				"",
+				time.Now(),
+				time.Time{},
+				time.Time{},
+			)
+		}
+		line = func(number int, destination domain.AccountID, status domain.TransferImportLineStatus) domain.TransferImportLine {
+			return domain.NewTransferImportLine(number, origin, destination, 100, status, nil, "", "")
+		}
+	)
+
+	tests := []struct {
+		name               string
+		pending            []domain.TransferImport
+		taken              map[int]bool
+		failing            domain.TransferImportID
+		expected           RunTransferImportsOutput
+		expectedUpdated    []domain.TransferImportStatus
+		expectedErrors     []string
+		expectedLines      []domain.TransferImportLineStatus
+		expectedRequesters []string
+	}{
+		{
+			name: "Creates the transfers of the pending lines",
+			pending: []domain.TransferImport{newJob(
+				first,
+				domain.TransferImportPending,
+				line(2, destination, domain.TransferImportLinePending),
+				line(3, destination, domain.TransferImportLineInvalid),
+				line(4, poor, domain.TransferImportLinePending),
+			)},
+			expected:        RunTransferImportsOutput{Completed: 1},
+			expectedUpdated: []domain.TransferImportStatus{domain.TransferImportRunning, domain.TransferImportCompleted},
+			expectedLines: []domain.TransferImportLineStatus{
+				domain.TransferImportLineRunning,
+				domain.TransferImportLineCreated,
+				domain.TransferImportLineRunning,
+				domain.TransferImportLineFailed,
+			},
+			expectedRequesters: []string{"02815517078", "02815517078"},
+		},
+		{
+			name: "Resumes a job stopped halfway without creating the running line again",
+			pending: []domain.TransferImport{newJob(
+				first,
+				domain.TransferImportRunning,
+				line(2, destination, domain.TransferImportLineCreated),
+				line(3, destination, domain.TransferImportLineRunning),
+				line(4, destination, domain.TransferImportLinePending),
+			)},
+			expected:        RunTransferImportsOutput{Completed: 1},
+			expectedUpdated: []domain.TransferImportStatus{domain.TransferImportCompleted},
+			expectedLines: []domain.TransferImportLineStatus{
+				domain.TransferImportLineFailed,
+				domain.TransferImportLineRunning,
+				domain.TransferImportLineCreated,
+			},
+			expectedRequesters: []string{"02815517078"},
+		},
+		{
+			name: "Leaves the lines claimed by another run",
+			pending: []domain.TransferImport{newJob(
+				first,
+				domain.TransferImportRunning,
+				line(2, destination, domain.TransferImportLinePending),
+				line(3, destination, domain.TransferImportLinePending),
+			)},
+			taken:           map[int]bool{2: true},
+			expected:        RunTransferImportsOutput{Completed: 1},
+			expectedUpdated: []domain.TransferImportStatus{domain.TransferImportCompleted},
+			expectedLines: []domain.TransferImportLineStatus{
+				domain.TransferImportLineRunning,
+				domain.TransferImportLineCreated,
+			},
+			expectedRequesters: []string{"02815517078"},
+		},
+		{
+			name: "Records the failure of a job and goes on with the next one",
+			pending: []domain.TransferImport{
+				newJob(first, domain.TransferImportPending, line(2, destination, domain.TransferImportLinePending)),
+				newJob(second, domain.TransferImportPending, line(2, destination, domain.TransferImportLinePending)),
+			},
+			failing:  first,
+			expected: RunTransferImportsOutput{Completed: 1, Failed: 1},
+			expectedUpdated: []domain.TransferImportStatus{
+				domain.TransferImportRunning,
+				domain.TransferImportRunning,
+				domain.TransferImportRunning,
+				domain.TransferImportCompleted,
+			},
+			expectedErrors: []string{"connection reset"},
+			expectedLines: []domain.TransferImportLineStatus{
+				domain.TransferImportLineRunning,
+				domain.TransferImportLineRunning,
+				domain.TransferImportLineCreated,
+			},
+			expectedRequesters: []string{"02815517078", "02815517078"},
+		},
+		{
+			name:     "No job waiting",
+			expected: RunTransferImportsOutput{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				updated    []domain.TransferImportStatus
+				errs       []string
+				lines      []domain.TransferImportLineStatus
+				requesters []string
+				repo       = mockTransferImportRepoRun{
+					pending: tt.pending,
+					leased:  make(map[domain.TransferImportID]bool),
+					taken:   tt.taken,
+					failing: tt.failing,
+					updated: &updated,
+					errors:  &errs,
+					lines:   &lines,
+				}
+				uc = NewRunTransferImportsInteractor(
+					repo,
+					mockCreateTransferUseCase{failTo: poor.String(), requesters: &requesters},
+					time.Second,
+				)
+			)
+
+			got, err := uc.Execute(context.Background())
+			if err != nil {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, nil)
+				return
+			}
+
+			if got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%+v' | Expected: '%+v'", tt.name, got, tt.expected)
+			}
+
+			if !reflect.DeepEqual(updated, tt.expectedUpdated) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, updated, tt.expectedUpdated)
+			}
+
+			if !reflect.DeepEqual(errs, tt.expectedErrors) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, errs, tt.expectedErrors)
+			}
+
+			if !reflect.DeepEqual(lines, tt.expectedLines) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, lines, tt.expectedLines)
+			}
+
+			if !reflect.DeepEqual(requesters, tt.expectedRequesters) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, requesters, tt.expectedRequesters)
+			}
+		})
+	}
+}
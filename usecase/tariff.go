@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// TariffConfig prices the transfers and the balances, every amount it works out rounded to the cent by Rounding.
+	// A zero fee rate disables the transfer fee and a zero interest rate accrues nothing
+	TariffConfig struct {
+		Rounding domain.RoundingMode
+		// FeeRate is charged to the origin of completed transfers, in basis points of their amount
+		FeeRate int64
+		// FeeAccounts receive the fees, split in proportion to their shares
+		FeeAccounts []FeeAccount
+		// InterestRate is the annual rate, in basis points, the closing balances accrue
+		InterestRate int64
+	}
+
+	// FeeAccount is an account receiving a share of the transfer fees
+	FeeAccount struct {
+		ID    domain.AccountID
+		Share int64
+	}
+)
+
+// Fee returns the fee of a transfer of the amount
+func (c TariffConfig) Fee(amount domain.Money) (domain.Money, error) {
+	if c.FeeRate == 0 || len(c.FeeAccounts) == 0 {
+		return 0, nil
+	}
+
+	return amount.Fee(c.FeeRate, c.Rounding)
+}
+
+// SplitFee returns the part of the fee each fee account receives, in their order. The parts add up to the fee
+func (c TariffConfig) SplitFee(fee domain.Money) ([]domain.Money, error) {
+	var shares = make([]int64, 0, len(c.FeeAccounts))
+	for _, account := range c.FeeAccounts {
+		shares = append(shares, account.Share)
+	}
+
+	return fee.Allocate(c.Rounding, shares...)
+}
+
+// AccrueInterest works out the interest the closing balance of each snapshot earned over its day
+func (c TariffConfig) AccrueInterest(balances []domain.DailyBalance) ([]domain.DailyBalance, error) {
+	var accrued = make([]domain.DailyBalance, 0, len(balances))
+	for _, balance := range balances {
+		balance, err := balance.AccrueInterest(c.InterestRate, c.Rounding)
+		if err != nil {
+			return nil, err
+		}
+
+		accrued = append(accrued, balance)
+	}
+
+	return accrued, nil
+}
+
+// isFeeAccount reports whether the account receives the fees, which it does not pay
+func (c TariffConfig) isFeeAccount(ID domain.AccountID) bool {
+	for _, account := range c.FeeAccounts {
+		if account.ID == ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// chargeFee moves the fee of a completed transfer from its origin to the fee accounts, each part as a completed
+// transfer of its own. It runs in the transaction of the transfer, once its balances were stored, so an origin that
+// can not pay the fee fails the transfer as well
+func chargeFee(
+	ctx context.Context,
+	tariff TariffConfig,
+	accountRepo domain.AccountRepository,
+	transferRepo domain.TransferRepository,
+	outboxRepo domain.OutboxRepository,
+	transfer domain.Transfer,
+) error {
+	if tariff.isFeeAccount(transfer.AccountOriginID()) {
+		return nil
+	}
+
+	fee, err := tariff.Fee(transfer.Amount())
+	if err != nil || fee == 0 {
+		return err
+	}
+
+	parts, err := tariff.SplitFee(fee)
+	if err != nil {
+		return err
+	}
+
+	origin, err := accountRepo.FindByID(ctx, transfer.AccountOriginID())
+	if err != nil {
+		return err
+	}
+
+	// The balance the origin is left with after each part, for the events of the charges
+	var balance = origin.Balance()
+
+	if err = origin.Withdraw(fee); err != nil {
+		return err
+	}
+
+	if err = accountRepo.UpdateBalance(ctx, origin.ID(), origin.Balance()); err != nil {
+		return err
+	}
+
+	for i, part := range parts {
+		if part == 0 {
+			continue
+		}
+
+		feeAccount, err := accountRepo.FindByID(ctx, tariff.FeeAccounts[i].ID)
+		if err != nil {
+			switch err {
+			case domain.ErrAccountNotFound:
+				return domain.ErrFeeAccountNotFound
+			default:
+				return err
+			}
+		}
+
+		if err = feeAccount.Deposit(part); err != nil {
+			return err
+		}
+
+		if err = accountRepo.UpdateBalance(ctx, feeAccount.ID(), feeAccount.Balance()); err != nil {
+			return err
+		}
+
+		charge, err := domain.NewTransfer(
+			domain.TransferID(domain.NewUUID()),
+			origin.ID(),
+			feeAccount.ID(),
+			part,
+			domain.TransferStatusPending,
+			time.Now(),
+		).TransitionTo(domain.TransferStatusCompleted)
+		if err != nil {
+			return err
+		}
+
+		if _, err = transferRepo.Create(ctx, charge); err != nil {
+			return err
+		}
+
+		if balance, err = balance.Sub(part); err != nil {
+			return err
+		}
+
+		if err = outboxRepo.Create(ctx, domain.NewTransferCompletedEvent(charge, balance)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,412 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+// mockAccountRepoBalances keeps the balances written, so reads after an update see it as a transaction would
+type mockAccountRepoBalances struct {
+	domain.AccountRepository
+
+	accounts map[domain.AccountID]domain.Account
+}
+
+func (m mockAccountRepoBalances) FindByID(_ context.Context, ID domain.AccountID) (domain.Account, error) {
+	account, ok := m.accounts[ID]
+	if !ok {
+		return domain.Account{}, domain.ErrAccountNotFound
+	}
+
+	return account, nil
+}
+
+func (m mockAccountRepoBalances) UpdateBalance(_ context.Context, ID domain.AccountID, balance domain.Money) error {
+	var account = m.accounts[ID]
+	m.accounts[ID] = domain.NewAccount(ID, account.Name(), account.CPF(), balance, account.CreatedAt())
+	return nil
+}
+
+func TestTariffConfig_Fee(t *testing.T) {
+	t.Parallel()
+
+	var feeAccounts = []FeeAccount{{ID: "3c096a40-ccba-4b58-93ed-57379ab04689", Share: 1}}
+
+	tests := []struct {
+		name     string
+		tariff   TariffConfig
+		amount   domain.Money
+		expected domain.Money
+	}{
+		{
+			name:     "Fee rounded half even",
+			tariff:   TariffConfig{Rounding: domain.RoundHalfEven, FeeRate: 50, FeeAccounts: feeAccounts},
+			amount:   100,
+			expected: 0,
+		},
+		{
+			name:     "Fee rounded half up",
+			tariff:   TariffConfig{Rounding: domain.RoundHalfUp, FeeRate: 50, FeeAccounts: feeAccounts},
+			amount:   100,
+			expected: 1,
+		},
+		{
+			name:     "Fee rounded down",
+			tariff:   TariffConfig{Rounding: domain.RoundDown, FeeRate: 150, FeeAccounts: feeAccounts},
+			amount:   199,
+			expected: 2,
+		},
+		{
+			name:     "Fee without rate",
+			tariff:   TariffConfig{Rounding: domain.RoundHalfEven, FeeAccounts: feeAccounts},
+			amount:   10000,
+			expected: 0,
+		},
+		{
+			name:     "Fee without fee accounts",
+			tariff:   TariffConfig{Rounding: domain.RoundHalfEven, FeeRate: 100},
+			amount:   10000,
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.tariff.Fee(tt.amount)
+			if err != nil {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, nil)
+				return
+			}
+
+			if got != tt.expected {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTariffConfig_SplitFee(t *testing.T) {
+	t.Parallel()
+
+	var feeAccounts = []FeeAccount{
+		{ID: "3c096a40-ccba-4b58-93ed-57379ab04688", Share: 1},
+		{ID: "3c096a40-ccba-4b58-93ed-57379ab04689", Share: 2},
+	}
+
+	tests := []struct {
+		name     string
+		rounding domain.RoundingMode
+		fee      domain.Money
+		expected []domain.Money
+	}{
+		{name: "Split rounded down", rounding: domain.RoundDown, fee: 10, expected: []domain.Money{4, 6}},
+		{name: "Split rounded half up", rounding: domain.RoundHalfUp, fee: 10, expected: []domain.Money{3, 7}},
+		{name: "Split rounded half even", rounding: domain.RoundHalfEven, fee: 9, expected: []domain.Money{3, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TariffConfig{Rounding: tt.rounding, FeeAccounts: feeAccounts}.SplitFee(tt.fee)
+			if err != nil {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, nil)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTariffConfig_AccrueInterest(t *testing.T) {
+	t.Parallel()
+
+	var balances = []domain.DailyBalance{
+		domain.NewDailyBalance("3c096a40-ccba-4b58-93ed-57379ab04680", time.Now(), 100000, 0, 0, 0),
+		domain.NewDailyBalance("3c096a40-ccba-4b58-93ed-57379ab04680", time.Now(), 0, 0, 0, 0),
+	}
+
+	tests := []struct {
+		name     string
+		tariff   TariffConfig
+		expected []domain.Money
+	}{
+		{
+			name:     "Interest rounded down",
+			tariff:   TariffConfig{Rounding: domain.RoundDown, InterestRate: 1000},
+			expected: []domain.Money{27, 0},
+		},
+		{
+			name:     "Interest rounded half up",
+			tariff:   TariffConfig{Rounding: domain.RoundHalfUp, InterestRate: 1000},
+			expected: []domain.Money{27, 0},
+		},
+		{
+			name:     "Interest rounded half even",
+			tariff:   TariffConfig{Rounding: domain.RoundHalfEven, InterestRate: 1100},
+			expected: []domain.Money{30, 0},
+		},
+		{
+			name:     "Interest without rate",
+			tariff:   TariffConfig{Rounding: domain.RoundHalfEven},
+			expected: []domain.Money{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.tariff.AccrueInterest(balances)
+			if err != nil {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, nil)
+				return
+			}
+
+			var interest = make([]domain.Money, 0)
+			for _, balance := range got {
+				interest = append(interest, balance.Interest())
+			}
+
+			if !reflect.DeepEqual(interest, tt.expected) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, interest, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChargeFee(t *testing.T) {
+	t.Parallel()
+
+	const (
+		originID      domain.AccountID = "3c096a40-ccba-4b58-93ed-57379ab04681"
+		destinationID domain.AccountID = "3c096a40-ccba-4b58-93ed-57379ab04682"
+		bankID        domain.AccountID = "3c096a40-ccba-4b58-93ed-57379ab04688"
+		partnerID     domain.AccountID = "3c096a40-ccba-4b58-93ed-57379ab04689"
+	)
+
+	var tariff = TariffConfig{
+		Rounding:    domain.RoundHalfUp,
+		FeeRate:     125,
+		FeeAccounts: []FeeAccount{{ID: bankID, Share: 2}, {ID: partnerID, Share: 1}},
+	}
+
+	tests := []struct {
+		name             string
+		tariff           TariffConfig
+		origin           domain.AccountID
+		originBalance    domain.Money
+		accounts         []domain.AccountID
+		expectedBalances map[domain.AccountID]domain.Money
+		expectedCharges  []domain.Money
+		expectedLeft     []string
+		expectedError    error
+	}{
+		{
+			name:          "Charge fee split between the fee accounts",
+			tariff:        tariff,
+			origin:        originID,
+			originBalance: 1000,
+			accounts:      []domain.AccountID{bankID, partnerID},
+			expectedBalances: map[domain.AccountID]domain.Money{
+				originID:  987,
+				bankID:    9,
+				partnerID: 4,
+			},
+			expectedCharges: []domain.Money{9, 4},
+			expectedLeft:    []string{"9.91", "9.87"},
+		},
+		{
+			name:          "Charge fee rounded down",
+			tariff:        TariffConfig{Rounding: domain.RoundDown, FeeRate: 125, FeeAccounts: tariff.FeeAccounts},
+			origin:        originID,
+			originBalance: 1000,
+			accounts:      []domain.AccountID{bankID, partnerID},
+			expectedBalances: map[domain.AccountID]domain.Money{
+				originID:  988,
+				bankID:    8,
+				partnerID: 4,
+			},
+			expectedCharges: []domain.Money{8, 4},
+			expectedLeft:    []string{"9.92", "9.88"},
+		},
+		{
+			name:          "Charge no fee when disabled",
+			tariff:        TariffConfig{Rounding: domain.RoundHalfUp},
+			origin:        originID,
+			originBalance: 1000,
+			accounts:      []domain.AccountID{bankID, partnerID},
+			expectedBalances: map[domain.AccountID]domain.Money{
+				originID:  1000,
+				bankID:    0,
+				partnerID: 0,
+			},
+		},
+		{
+			name:          "Charge no fee to a fee account",
+			tariff:        tariff,
+			origin:        bankID,
+			originBalance: 1000,
+			accounts:      []domain.AccountID{partnerID},
+			expectedBalances: map[domain.AccountID]domain.Money{
+				bankID:    1000,
+				partnerID: 0,
+			},
+		},
+		{
+			name:          "Charge fee origin without balance",
+			tariff:        tariff,
+			origin:        originID,
+			originBalance: 10,
+			accounts:      []domain.AccountID{bankID, partnerID},
+			expectedError: domain.ErrInsufficientBalance,
+		},
+		{
+			name:          "Charge fee account not found",
+			tariff:        tariff,
+			origin:        originID,
+			originBalance: 1000,
+			accounts:      []domain.AccountID{bankID},
+			expectedError: domain.ErrFeeAccountNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				repo = mockAccountRepoBalances{accounts: map[domain.AccountID]domain.Account{
+					tt.origin: domain.NewAccount(tt.origin, "Test", "07094564964", tt.originBalance, time.Now()),
+				}}
+				created   []domain.Transfer
+				published []domain.Event
+			)
+
+			for _, ID := range tt.accounts {
+				repo.accounts[ID] = domain.NewAccount(ID, "Fees", "", 0, time.Now())
+			}
+
+			var transfer = domain.NewTransfer(
+				"3c096a40-ccba-4b58-93ed-57379ab04680",
+				tt.origin,
+				destinationID,
+				1000,
+				domain.TransferStatusCompleted,
+				time.Now(),
+			)
+
+			err := chargeFee(
+				context.Background(),
+				tt.tariff,
+				repo,
+				mockTransferRepoRecorder{created: &created},
+				mockOutboxRepo{created: &published},
+				transfer,
+			)
+			if err != tt.expectedError {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			for ID, expected := range tt.expectedBalances {
+				if got := repo.accounts[ID].Balance(); got != expected {
+					t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, expected)
+				}
+			}
+
+			var charges []domain.Money
+			for _, charge := range created {
+				if charge.AccountOriginID() != tt.origin || charge.Status() != domain.TransferStatusCompleted {
+					t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, charge, "completed transfer from the origin")
+				}
+
+				charges = append(charges, charge.Amount())
+			}
+
+			if !reflect.DeepEqual(charges, tt.expectedCharges) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, charges, tt.expectedCharges)
+			}
+
+			if got := originBalances(published); !reflect.DeepEqual(got, tt.expectedLeft) {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, got, tt.expectedLeft)
+			}
+		})
+	}
+}
+
+func TestCreateTransferInteractor_Fee(t *testing.T) {
+	t.Parallel()
+
+	const (
+		originID      domain.AccountID = "3c096a40-ccba-4b58-93ed-57379ab04681"
+		destinationID domain.AccountID = "3c096a40-ccba-4b58-93ed-57379ab04682"
+		bankID        domain.AccountID = "3c096a40-ccba-4b58-93ed-57379ab04688"
+	)
+
+	var (
+		repo = mockAccountRepoBalances{accounts: map[domain.AccountID]domain.Account{
+			originID:      domain.NewAccount(originID, "Origin", "08098565895", 5000, time.Now()),
+			destinationID: domain.NewAccount(destinationID, "Destination", "13098565491", 0, time.Now()),
+			bankID:        domain.NewAccount(bankID, "Fees", "", 0, time.Now()),
+		}}
+		created   []domain.Transfer
+		published []domain.Event
+	)
+
+	var uc = NewCreateTransferInteractor(
+		mockTransferRepoRecorder{created: &created},
+		repo,
+		mockTransferApprovalRepo{},
+		mockRiskEngine{},
+		mockRiskEvaluationRepo{},
+		TransferApprovalConfig{},
+		TariffConfig{Rounding: domain.RoundHalfUp, FeeRate: 125, FeeAccounts: []FeeAccount{{ID: bankID, Share: 1}}},
+		mockOutboxRepo{created: &published},
+		mockCreateTransferStatusPresenter{},
+		time.Second,
+	)
+
+	_, err := uc.Execute(customerContext("08098565895"), CreateTransferInput{
+		AccountOriginID:      originID.String(),
+		AccountDestinationID: destinationID.String(),
+		Amount:               1000,
+	})
+	if err != nil {
+		t.Fatalf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", "Create transfer with fee", err, nil)
+	}
+
+	var expectedBalances = map[domain.AccountID]domain.Money{originID: 3987, destinationID: 1000, bankID: 13}
+	for ID, expected := range expectedBalances {
+		if got := repo.accounts[ID].Balance(); got != expected {
+			t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Create transfer with fee", got, expected)
+		}
+	}
+
+	if len(created) != 2 || created[1].AccountDestinationID() != bankID || created[1].Amount() != 13 {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Create transfer with fee", created, "transfer and its fee")
+	}
+
+	if got := originBalances(published); !reflect.DeepEqual(got, []string{"40.00", "39.87"}) {
+		t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", "Create transfer with fee", got, []string{"40.00", "39.87"})
+	}
+}
+
+// originBalances are the balances the events tell each transfer left its origin with
+func originBalances(events []domain.Event) []string {
+	var balances []string
+	for _, event := range events {
+		var data domain.TransferData
+		_ = json.Unmarshal(event.Data(), &data)
+
+		balances = append(balances, data.AccountOriginBalance)
+	}
+
+	return balances
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// UpdateNotificationPreferenceUseCase input port
+	UpdateNotificationPreferenceUseCase interface {
+		Execute(context.Context, UpdateNotificationPreferenceInput) (NotificationPreferenceOutput, error)
+	}
+
+	// UpdateNotificationPreferenceInput input data. The threshold is an exact decimal, such as "100.00", and an empty
+	// or zero one turns the low balance notification off
+	UpdateNotificationPreferenceInput struct {
+		AccountID           string `json:"-"`
+		Email               string `json:"email" validate:"required,email,max=254"`
+		Locale              string `json:"locale" validate:"omitempty,oneof=pt-BR en-US"`
+		TransferReceived    bool   `json:"transfer_received"`
+		LowBalanceThreshold string `json:"low_balance_threshold" validate:"omitempty,max=32"`
+	}
+
+	// UpdateNotificationPreferencePresenter output port
+	UpdateNotificationPreferencePresenter interface {
+		Output(domain.NotificationPreference) NotificationPreferenceOutput
+	}
+
+	updateNotificationPreferenceInteractor struct {
+		accountRepo    domain.AccountRepository
+		preferenceRepo domain.NotificationPreferenceRepository
+		presenter      UpdateNotificationPreferencePresenter
+		ctxTimeout     time.Duration
+	}
+)
+
+// NewUpdateNotificationPreferenceInteractor creates new updateNotificationPreferenceInteractor with its dependencies
+func NewUpdateNotificationPreferenceInteractor(
+	accountRepo domain.AccountRepository,
+	preferenceRepo domain.NotificationPreferenceRepository,
+	presenter UpdateNotificationPreferencePresenter,
+	t time.Duration,
+) UpdateNotificationPreferenceUseCase {
+	return updateNotificationPreferenceInteractor{
+		accountRepo:    accountRepo,
+		preferenceRepo: preferenceRepo,
+		presenter:      presenter,
+		ctxTimeout:     t,
+	}
+}
+
+// Execute replaces the notification preference of the account
+func (a updateNotificationPreferenceInteractor) Execute(
+	ctx context.Context,
+	input UpdateNotificationPreferenceInput,
+) (NotificationPreferenceOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	var ID = domain.AccountID(input.AccountID)
+
+	if err := authorizeAccount(ctx, a.accountRepo, OpUpdateNotificationPreference, ID); err != nil {
+		return a.presenter.Output(domain.NotificationPreference{}), err
+	}
+
+	if _, err := a.accountRepo.FindByID(ctx, ID); err != nil {
+		return a.presenter.Output(domain.NotificationPreference{}), err
+	}
+
+	var threshold domain.Money
+	if input.LowBalanceThreshold != "" {
+		var err error
+		if threshold, err = domain.ParseMoney(input.LowBalanceThreshold); err != nil {
+			return a.presenter.Output(domain.NotificationPreference{}), err
+		}
+
+		if threshold < 0 {
+			return a.presenter.Output(domain.NotificationPreference{}), domain.ErrNegativeThreshold
+		}
+	}
+
+	var preference = domain.NewNotificationPreference(
+		ID,
+		input.Email,
+		input.Locale,
+		input.TransferReceived,
+		threshold,
+		time.Now(),
+	)
+
+	if err := a.preferenceRepo.Save(ctx, preference); err != nil {
+		return a.presenter.Output(domain.NotificationPreference{}), err
+	}
+
+	return a.presenter.Output(preference), nil
+}
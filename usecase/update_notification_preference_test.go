@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type mockPreferenceRepoSave struct {
+	domain.NotificationPreferenceRepository
+
+	saved *[]domain.NotificationPreference
+}
+
+func (m mockPreferenceRepoSave) Save(_ context.Context, preference domain.NotificationPreference) error {
+	*m.saved = append(*m.saved, preference)
+	return nil
+}
+
+func TestUpdateNotificationPreferenceInteractor_Execute(t *testing.T) {
+	t.Parallel()
+
+	const accountID = "3c096a40-ccba-4b58-93ed-57379ab04680"
+
+	var accounts = map[domain.AccountID]domain.Account{
+		accountID: domain.NewAccount(accountID, "Test", "02815517078", 10000, time.Now()),
+	}
+
+	tests := []struct {
+		name              string
+		ctx               context.Context
+		input             UpdateNotificationPreferenceInput
+		expectedThreshold domain.Money
+		expectedLocale    string
+		expectedError     error
+	}{
+		{
+			name: "Customer updates the preference of their account",
+			ctx:  customerContext("02815517078"),
+			input: UpdateNotificationPreferenceInput{
+				AccountID:           accountID,
+				Email:               "test@example.com",
+				TransferReceived:    true,
+				LowBalanceThreshold: "100.50",
+			},
+			expectedThreshold: 10050,
+			expectedLocale:    domain.DefaultLocale,
+		},
+		{
+			name: "Admin turns the low balance notification off",
+			ctx:  roleContext(domain.RoleAdmin),
+			input: UpdateNotificationPreferenceInput{
+				AccountID: accountID,
+				Email:     "test@example.com",
+				Locale:    "en-US",
+			},
+			expectedLocale: "en-US",
+		},
+		{
+			name: "Customer cannot update the preference of another account",
+			ctx:  customerContext("07094564964"),
+			input: UpdateNotificationPreferenceInput{
+				AccountID: accountID,
+				Email:     "test@example.com",
+			},
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name: "Support cannot update preferences",
+			ctx:  roleContext(domain.RoleSupport),
+			input: UpdateNotificationPreferenceInput{
+				AccountID: accountID,
+				Email:     "test@example.com",
+			},
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name: "Admin cannot set the preference of a missing account",
+			ctx:  roleContext(domain.RoleAdmin),
+			input: UpdateNotificationPreferenceInput{
+				AccountID: "3c096a40-ccba-4b58-93ed-57379ab04689",
+				Email:     "test@example.com",
+			},
+			expectedError: domain.ErrAccountNotFound,
+		},
+		{
+			name: "Threshold with too many decimals",
+			ctx:  roleContext(domain.RoleAdmin),
+			input: UpdateNotificationPreferenceInput{
+				AccountID:           accountID,
+				Email:               "test@example.com",
+				LowBalanceThreshold: "10.001",
+			},
+			expectedError: domain.ErrMoneyTooManyDecimals,
+		},
+		{
+			name: "Negative threshold",
+			ctx:  roleContext(domain.RoleAdmin),
+			input: UpdateNotificationPreferenceInput{
+				AccountID:           accountID,
+				Email:               "test@example.com",
+				LowBalanceThreshold: "-10.00",
+			},
+			expectedError: domain.ErrNegativeThreshold,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				saved = make([]domain.NotificationPreference, 0)
+				uc    = NewUpdateNotificationPreferenceInteractor(
+					mockAccountRepoNotify{accounts: accounts},
+					mockPreferenceRepoSave{saved: &saved},
+					mockUpdateNotificationPreferencePresenter{},
+					time.Second,
+				)
+			)
+
+			_, err := uc.Execute(tt.ctx, tt.input)
+			if err != tt.expectedError {
+				t.Errorf("[TestCase '%s'] Result: '%v' | ExpectedError: '%v'", tt.name, err, tt.expectedError)
+				return
+			}
+
+			if tt.expectedError != nil {
+				if len(saved) != 0 {
+					t.Errorf("[TestCase '%s'] Saved: '%v'", tt.name, saved)
+				}
+				return
+			}
+
+			if len(saved) != 1 {
+				t.Fatalf("[TestCase '%s'] Saved: '%v'", tt.name, saved)
+			}
+
+			if saved[0].LowBalanceThreshold() != tt.expectedThreshold {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					saved[0].LowBalanceThreshold(),
+					tt.expectedThreshold,
+				)
+			}
+
+			if saved[0].Locale() != tt.expectedLocale {
+				t.Errorf("[TestCase '%s'] Result: '%v' | Expected: '%v'", tt.name, saved[0].Locale(), tt.expectedLocale)
+			}
+		})
+	}
+}
+
+type mockUpdateNotificationPreferencePresenter struct{}
+
+func (m mockUpdateNotificationPreferencePresenter) Output(
+	_ domain.NotificationPreference,
+) NotificationPreferenceOutput {
+	return NotificationPreferenceOutput{}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsabadini/go-clean-architecture/domain"
+)
+
+type (
+	// UpdateWebhookUseCase input port
+	UpdateWebhookUseCase interface {
+		Execute(context.Context, UpdateWebhookInput) (WebhookOutput, error)
+	}
+
+	// UpdateWebhookInput input data. Enabling a webhook disabled after repeated failures forgets them
+	UpdateWebhookInput struct {
+		ID      string `json:"-"`
+		Enabled *bool  `json:"enabled" validate:"required"`
+	}
+
+	// UpdateWebhookPresenter output port
+	UpdateWebhookPresenter interface {
+		Output(domain.Webhook) WebhookOutput
+	}
+
+	updateWebhookInteractor struct {
+		repo       domain.WebhookRepository
+		presenter  UpdateWebhookPresenter
+		ctxTimeout time.Duration
+	}
+)
+
+// NewUpdateWebhookInteractor creates new updateWebhookInteractor with its dependencies
+func NewUpdateWebhookInteractor(
+	repo domain.WebhookRepository,
+	presenter UpdateWebhookPresenter,
+	t time.Duration,
+) UpdateWebhookUseCase {
+	return updateWebhookInteractor{
+		repo:       repo,
+		presenter:  presenter,
+		ctxTimeout: t,
+	}
+}
+
+// Execute orchestrates the use case. The webhook is read locked, so the failures a delivery counts meanwhile are not
+// overwritten
+func (a updateWebhookInteractor) Execute(ctx context.Context, input UpdateWebhookInput) (WebhookOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	var webhook domain.Webhook
+
+	err := a.repo.WithTransaction(ctx, func(ctxTx context.Context) error {
+		var err error
+		webhook, err = authorizeWebhook(ctxTx, a.repo, OpUpdateWebhook, domain.WebhookID(input.ID))
+		if err != nil {
+			return err
+		}
+
+		if *input.Enabled {
+			webhook.Enable()
+		} else {
+			webhook.Disable(time.Now())
+		}
+
+		return a.repo.Update(ctxTx, webhook)
+	})
+	if err != nil {
+		return a.presenter.Output(domain.Webhook{}), err
+	}
+
+	return a.presenter.Output(webhook), nil
+}